@@ -57,8 +57,44 @@ type ChangeTransferPolicySpec struct {
 	// +listType:=map
 	// +listMapKey=key
 	ProposedCommitStatuses []CommitStatusSelector `json:"proposedCommitStatuses"`
+
+	// ReconcileInterval overrides the ControllerConfiguration's ChangeTransferPolicy requeue duration for this
+	// ChangeTransferPolicy. Values below the ControllerConfiguration's minReconcileInterval are raised to the minimum.
+	// +kubebuilder:validation:Optional
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+
+	// ResolveDivergence is what the controller does when the proposed branch diverged from the commits it previously
+	// saw, e.g. because a commit was pushed to it by hand. With reset, the proposed branch is force-updated back to the
+	// last proposed commit. With manual, the ChangeTransferPolicy stops promoting until someone fixes the branch. If it
+	// is not set, the divergence is only reported on the ProposedBranchDiverged condition.
+	// +kubebuilder:validation:Optional
+	ResolveDivergence DivergenceResolution `json:"resolveDivergence,omitempty"`
+
+	// PropagateLabels lists the keys of the labels and annotations of this ChangeTransferPolicy that are copied to its
+	// PullRequests and CommitStatuses, and kept in sync with it. The PromotionStrategy sets it to the keys of its own
+	// and its GitRepository's propagateLabels.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	PropagateLabels []string `json:"propagateLabels,omitempty"`
+
+	// PullRequest configures the pull requests of this ChangeTransferPolicy. The PromotionStrategy sets it to its
+	// pullRequest merged with the environment's.
+	// +kubebuilder:validation:Optional
+	PullRequest *PullRequestTemplateSpec `json:"pullRequest,omitempty"`
 }
 
+// DivergenceResolution is how a ChangeTransferPolicy handles a proposed branch that diverged from the commits it
+// previously saw.
+// +kubebuilder:validation:Enum=reset;manual
+type DivergenceResolution string
+
+const (
+	// DivergenceResolutionReset force-updates the proposed branch back to the last proposed commit.
+	DivergenceResolutionReset DivergenceResolution = "reset"
+	// DivergenceResolutionManual stops promoting until the proposed branch is fixed by hand.
+	DivergenceResolutionManual DivergenceResolution = "manual"
+)
+
 // ChangeRequestPolicyCommitStatusPhase defines the phase of a commit status in a ChangeTransferPolicy.
 type ChangeRequestPolicyCommitStatusPhase struct {
 	// Key staging hydrated branch
@@ -136,7 +172,7 @@ type CommitShaState struct {
 	Author string `json:"author,omitempty"`
 	// Subject is the subject line of the commit message
 	Subject string `json:"subject,omitempty"`
-	// Body is the body of the commit message without the subject line
+	// Body is the body of the commit message without the subject line. Bodies longer than 4096 characters are truncated.
 	Body string `json:"body,omitempty"`
 	// References are the references to other commits, that went into the hydration of the branch
 	References []RevisionReference `json:"references,omitempty"`
@@ -171,6 +207,36 @@ type ChangeTransferPolicyStatus struct {
 	// PullRequest is the state of the pull request that was created for this ChangeTransferPolicy.
 	PullRequest *PullRequestCommonStatus `json:"pullRequest,omitempty"`
 
+	// ActiveDryShortSha is the abbreviated active.dry.sha, for display.
+	// +optional
+	ActiveDryShortSha string `json:"activeDryShortSha,omitempty"`
+	// ProposedDryShortSha is the abbreviated proposed.dry.sha, for display.
+	// +optional
+	ProposedDryShortSha string `json:"proposedDryShortSha,omitempty"`
+
+	// ProposedDryShaSuperseded is true when the proposed dry commit is no longer an ancestor of (or equal to) the newest
+	// dry commit the hydrator has processed for the proposed branch, i.e. it was removed upstream, or when a commit up
+	// to that one reverts it ("This reverts commit <sha>" in its message). Newer dry commits alone never set this field.
+	// While it is true, no pull request is opened for the proposed change and the PromotionStrategy closes any pull
+	// request that is still open for it.
+	// +optional
+	ProposedDryShaSuperseded bool `json:"proposedDryShaSuperseded,omitempty"`
+
+	// EffectivelyPromotedDrySha is set to the proposed dry sha when the proposed branch's hydrated tree is identical to
+	// the active branch's, ignoring hydrator.metadata files. Merging it would not change what is deployed, so no pull
+	// request is opened and the PromotionStrategy treats the dry commit as promoted to this environment.
+	// +optional
+	EffectivelyPromotedDrySha string `json:"effectivelyPromotedDrySha,omitempty"`
+
+	// LastLsRemote is the result of the last ls-remote of the branches. When it matches the previous reconcile and the
+	// shas in this status, the branches are not fetched again.
+	// +optional
+	LastLsRemote *LsRemoteState `json:"lastLsRemote,omitempty"`
+
+	// Polling shows how often the ChangeTransferPolicy is polled and whether webhooks are delivered for its repository.
+	// +optional
+	Polling *PollingStatus `json:"polling,omitempty"`
+
 	// History defines the history of promoted changes done by the ChangeTransferPolicy. You can think of
 	// it as a list of PRs merged by GitOps Promoter. It will not include changes that were manually merged.
 	// The history length is hard-coded to be at most 5 entries. This may change in the future.
@@ -186,6 +252,21 @@ type ChangeTransferPolicyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
+// LsRemoteState is the result of an ls-remote of the refs a ChangeTransferPolicy depends on.
+type LsRemoteState struct {
+	// Time is when the ls-remote ran.
+	Time metav1.Time `json:"time"`
+	// ActiveSha is the SHA the active branch pointed at.
+	// +optional
+	ActiveSha string `json:"activeSha,omitempty"`
+	// ProposedSha is the SHA the proposed branch pointed at.
+	// +optional
+	ProposedSha string `json:"proposedSha,omitempty"`
+	// NotesSha is the SHA the hydrator notes ref pointed at. It is empty if the ref does not exist.
+	// +optional
+	NotesSha string `json:"notesSha,omitempty"`
+}
+
 // History describes a particular change that was promoted by the ChangeTransferPolicy.
 type History struct {
 	// Proposed is the state of the proposed branch at the time the PR was merged.
@@ -194,8 +275,32 @@ type History struct {
 	Active CommitBranchState `json:"active,omitempty"`
 	// PullRequest is the state of the pull request that was created for this ChangeTransferPolicy.
 	PullRequest *PullRequestCommonStatus `json:"pullRequest,omitempty"`
+	// AutoRevert is the name of the RevertCommit that the PromotionStrategy created to revert this promotion
+	// automatically, because the environment's active commit statuses started failing shortly after it. It is only set
+	// in the history of a PromotionStrategy.
+	// +optional
+	AutoRevert string `json:"autoRevert,omitempty"`
+	// Kind is revert for a promotion that rolled the environment back for a RevertCommit, and empty for a forward
+	// promotion. It is only set in the history of a PromotionStrategy.
+	// +optional
+	Kind HistoryKind `json:"kind,omitempty"`
+	// RevertCommitRef is the RevertCommit that a revert was promoted for.
+	// +optional
+	RevertCommitRef *ObjectReference `json:"revertCommitRef,omitempty"`
+	// RevertedSha is the commit that a revert reverted, the sha of its RevertCommit.
+	// +optional
+	RevertedSha string `json:"revertedSha,omitempty"`
 }
 
+// HistoryKind is the kind of change a History entry promoted.
+// +kubebuilder:validation:Enum=revert
+type HistoryKind string
+
+const (
+	// HistoryKindRevert is a promotion that rolled the environment back for a RevertCommit.
+	HistoryKindRevert HistoryKind = "revert"
+)
+
 // CommitBranchStateHistoryProposed is identical to CommitBranchState minus the Dry state. In the context of History, the Dry state is not relevant as
 // the proposed dry side at merge becomes the Active.
 type CommitBranchStateHistoryProposed struct {
@@ -246,11 +351,15 @@ func (ps *ChangeTransferPolicy) SetObservedGeneration(generation int64) {
 // +kubebuilder:ac:generate=true
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+// +kubebuilder:storageversion
 
 // ChangeTransferPolicy is the Schema for the changetransferpolicies API
-// +kubebuilder:printcolumn:name="Active Dry Sha",type=string,JSONPath=`.status.active.dry.sha`
-// +kubebuilder:printcolumn:name="Proposed Dry Sha",type=string,JSONPath=`.status.proposed.dry.sha`
-// +kubebuilder:printcolumn:name="Proposed Note Dry Sha",type=string,JSONPath=`.status.proposed.note.drySha`
+// +kubebuilder:printcolumn:name="Active",type=string,JSONPath=`.spec.activeBranch`,priority=1
+// +kubebuilder:printcolumn:name="Active Dry Sha",type=string,JSONPath=`.status.activeDryShortSha`
+// +kubebuilder:printcolumn:name="Proposed Dry Sha",type=string,JSONPath=`.status.proposedDryShortSha`
+// +kubebuilder:printcolumn:name="Active Dry Sha (Full)",type=string,JSONPath=`.status.active.dry.sha`,priority=1
+// +kubebuilder:printcolumn:name="Proposed Dry Sha (Full)",type=string,JSONPath=`.status.proposed.dry.sha`,priority=1
+// +kubebuilder:printcolumn:name="Proposed Note Dry Sha",type=string,JSONPath=`.status.proposed.note.drySha`,priority=1
 // +kubebuilder:printcolumn:name="PR State",type=string,JSONPath=`.status.pullRequest.state`
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 type ChangeTransferPolicy struct {
@@ -85,6 +85,9 @@ type CommitStatusStatus struct {
 	// +kubebuilder:validation:MaxLength=64
 	// +kubebuilder:validation:Pattern=`^([a-f0-9]{40}|[a-f0-9]{64})$`
 	Sha string `json:"sha,omitempty"`
+	// ShortSha is the abbreviated sha, for display.
+	// +optional
+	ShortSha string `json:"shortSha,omitempty"`
 	// Phase is the state of the commit status. This will be mapped to the appropriate equivalent in the SCM.
 	// +kubebuilder:default:=pending
 	// +kubebuilder:validation:Enum:=pending;success;failure;""
@@ -116,9 +119,10 @@ func (cs *CommitStatus) SetObservedGeneration(generation int64) {
 // CommitStatus is the Schema for the commitstatuses API
 // +kubebuilder:printcolumn:name="Key",type=string,JSONPath=`.metadata.labels['promoter\.argoproj\.io/commit-status']`
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
-// +kubebuilder:printcolumn:name="Sha",type=string,JSONPath=`.status.sha`
+// +kubebuilder:printcolumn:name="Sha",type=string,JSONPath=`.status.shortSha`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // +kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.spec.name`,priority=1
-// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,priority=1
+// +kubebuilder:printcolumn:name="Sha (Full)",type=string,JSONPath=`.status.sha`,priority=1
 type CommitStatus struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
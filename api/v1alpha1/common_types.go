@@ -109,15 +109,18 @@ type GitLabRepo struct {
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:Pattern=^[a-zA-Z0-9_\-\/.]+$
 	Namespace string `json:"namespace"`
-	// Name is the project slug of the repository.
+	// Name is the project slug of the repository. The groups it is in belong in the namespace, a name with subgroups
+	// (e.g. subgroup/project) is still accepted for the GitRepositories that were created with one.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
 	// +kubebuilder:validation:Pattern=^[a-zA-Z0-9_\-\/.]+$
 	Name string `json:"name"`
-	// ProjectID is the ID of the project in GitLab.
+	// ProjectID is the ID of the project in GitLab. If it is not set, the GitRepository controller looks it up by the
+	// namespace and name.
+	// +optional
 	// +kubebuilder:validation:Minimum=0
-	// +kubebuilder:validation:Required
-	ProjectID int `json:"projectId"`
+	ProjectID int `json:"projectId,omitempty"`
 }
 
 // ForgejoRepo is a repository in Forgejo, identified by its owner and name.
@@ -125,10 +128,14 @@ type ForgejoRepo struct {
 	// Owner is the owner of the repository.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=40
+	// +kubebuilder:validation:Pattern=^[a-zA-Z0-9_\-.]+$
 	Owner string `json:"owner"`
 	// Name is the name of the repository.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=100
+	// +kubebuilder:validation:Pattern=^[a-zA-Z0-9_\-.]+$
 	Name string `json:"name"`
 }
 
@@ -137,9 +144,14 @@ type GiteaRepo struct {
 	// Owner is the owner of the repository.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=40
+	// +kubebuilder:validation:Pattern=^[a-zA-Z0-9_\-.]+$
 	Owner string `json:"owner"`
 	// Name is the name of the repository.
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=100
+	// +kubebuilder:validation:Pattern=^[a-zA-Z0-9_\-.]+$
 	Name string `json:"name"`
 }
 
@@ -164,11 +176,13 @@ type AzureDevOpsRepo struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^[^/\\]+$`
 	Project string `json:"project"`
 	// Name is the name of the repository.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^[^/\\]+$`
 	Name string `json:"name"`
 }
 
@@ -177,10 +191,12 @@ type FakeRepo struct {
 	// Owner is the owner of the repository.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=^[a-zA-Z0-9_\-.]+$
 	Owner string `json:"owner"`
 	// Name is the name of the repository.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=^[a-zA-Z0-9_\-.]+$
 	Name string `json:"name"`
 }
 
@@ -211,3 +227,34 @@ type RevisionReference struct {
 	// Commit contains metadata about the commit that is related in some way to another commit.
 	Commit *CommitMetadata `json:"commit,omitempty"`
 }
+
+// GitIdentity is the name and email that git records as the author and committer of the commits the promoter creates.
+type GitIdentity struct {
+	// Name is the author and committer name. If empty, the name from the next less specific configuration is used.
+	// +optional
+	// +kubebuilder:validation:MaxLength=256
+	Name string `json:"name,omitempty"`
+	// Email is the author and committer email. If empty, the email from the next less specific configuration is used.
+	// +optional
+	// +kubebuilder:validation:MaxLength=256
+	Email string `json:"email,omitempty"`
+}
+
+// PollingStatus shows how often a resource is polled and whether webhooks are delivered for its repository.
+type PollingStatus struct {
+	// RequeueInterval is how long the controller waits after a reconcile before it reconciles the resource again:
+	// the ControllerConfiguration's adaptivePolling.maxRequeueDuration while webhook deliveries for the resource's
+	// GitRepository are fresh, and the usual interval otherwise. The resource is reconciled sooner when the deliveries
+	// go stale first, or when a reconcile waits for something shorter, such as an auto-revert.
+	RequeueInterval metav1.Duration `json:"requeueInterval"`
+
+	// LastWebhookDelivery is the status.lastWebhookDelivery of the resource's GitRepository, when a replica of the
+	// controller last received a verified webhook delivery for it. It is unset if none was received.
+	// +optional
+	LastWebhookDelivery *metav1.Time `json:"lastWebhookDelivery,omitempty"`
+
+	// WebhooksFresh is true while the last delivery, received by this replica or persisted in LastWebhookDelivery, is
+	// within the ControllerConfiguration's adaptivePolling.webhookFreshness.
+	// +optional
+	WebhooksFresh bool `json:"webhooksFresh,omitempty"`
+}
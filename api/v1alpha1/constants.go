@@ -10,6 +10,11 @@ const PreviousEnvProposedCommitPrefixNameLabel = "promoter-previous-env-"
 // PromotionStrategyLabel the promotion strategy which the proposed commit is associated with
 const PromotionStrategyLabel = "promoter.argoproj.io/promotion-strategy"
 
+// PromotionStrategyUIDLabel the UID of the promotion strategy which the proposed commit is associated with. Unlike
+// PromotionStrategyLabel, whose value may be truncated, it is unique across promotion strategies and is used to scope
+// lookups of resources owned by a promotion strategy.
+const PromotionStrategyUIDLabel = "promoter.argoproj.io/promotion-strategy-uid"
+
 // EnvironmentLabel the environment branch for the proposed commit
 const EnvironmentLabel = "promoter.argoproj.io/environment"
 
@@ -22,12 +27,26 @@ const TimedCommitStatusLabel = "promoter.argoproj.io/timed-commit-status"
 // WebRequestCommitStatusLabel the web request commit status which the commit status is associated with.
 const WebRequestCommitStatusLabel = "promoter.argoproj.io/web-request-commit-status"
 
+// IngestedCommitStatusLabel is set to the provider on the commit statuses the webhook receiver creates from the commit
+// statuses and check runs the SCM reports, for the keys of the ChangeTransferPolicies of the commit. They are not set on
+// the SCM again, it already has them.
+const IngestedCommitStatusLabel = "promoter.argoproj.io/ingested-from"
+
 // PreviousEnvironmentCommitStatusKey the commit status key name used to indicate the previous environment health
 const PreviousEnvironmentCommitStatusKey = "promoter-previous-environment"
 
 // CommitStatusPreviousEnvironmentStatusesAnnotation is the label used to identify commit statuses that make up the aggregated active commit status
 const CommitStatusPreviousEnvironmentStatusesAnnotation = "promoter.argoproj.io/previous-environment-statuses"
 
+// CommitStatusCopyFromShaAnnotation is the active hydrated sha of the previous environment whose commit statuses were
+// copied into a previous environment commit status. The copy is stale if the previous environment's active hydrated
+// sha has changed since.
+const CommitStatusCopyFromShaAnnotation = "promoter.argoproj.io/copy-from-sha"
+
+// ForceDeleteAfterAnnotation is a duration, e.g. "1h", after which a GitRepository being deleted is deleted even if
+// resources still depend on it. It is meant for cleaning up after a disaster, the dependent resources are left behind.
+const ForceDeleteAfterAnnotation = "promoter.argoproj.io/force-delete-after"
+
 // Finalizer constants for preventing premature resource deletion
 
 // PullRequestFinalizer prevents deletion of PullRequest until the PR is closed in the SCM
@@ -41,7 +60,11 @@ const ChangeTransferPolicyPullRequestFinalizer = "changetransferpolicy.promoter.
 // and clears this finalizer so the policy can finish deleting without waiting for per-PR status propagation.
 const ChangeTransferPolicyPullRequestCleanupFinalizer = "changetransferpolicy.promoter.argoproj.io/finalizer"
 
-// GitRepositoryFinalizer prevents deletion of GitRepository while PullRequests reference it
+// RevertCommitFinalizer prevents deletion of RevertCommit until its revert pull request is closed and its revert
+// branch is deleted
+const RevertCommitFinalizer = "revertcommit.promoter.argoproj.io/finalizer"
+
+// GitRepositoryFinalizer prevents deletion of GitRepository while PullRequests or ChangeTransferPolicies reference it
 const GitRepositoryFinalizer = "gitrepository.promoter.argoproj.io/finalizer"
 
 // ScmProviderFinalizer prevents deletion of ScmProvider while GitRepositories reference it
@@ -69,6 +69,52 @@ type ControllerConfigurationSpec struct {
 	// including WorkQueue settings that control reconciliation behavior.
 	// +required
 	WebRequestCommitStatus WebRequestCommitStatusConfiguration `json:"webRequestCommitStatus"`
+
+	// GitRepository contains the configuration for the GitRepository controller.
+	// +optional
+	GitRepository GitRepositoryConfiguration `json:"gitRepository,omitempty"`
+
+	// ScmProvider contains the configuration for the ScmProvider and ClusterScmProvider controllers.
+	// +optional
+	ScmProvider ScmProviderConfiguration `json:"scmProvider,omitempty"`
+
+	// AdaptivePolling configures how the ChangeTransferPolicy and PromotionStrategy controllers lengthen their requeue
+	// intervals while webhook deliveries for a repository are received.
+	// +optional
+	AdaptivePolling AdaptivePollingConfiguration `json:"adaptivePolling,omitempty"`
+}
+
+// AdaptivePollingConfiguration defines how the requeue intervals of ChangeTransferPolicies and PromotionStrategies
+// adapt to webhook deliveries.
+type AdaptivePollingConfiguration struct {
+	// MaxRequeueDuration is the longest ChangeTransferPolicies and PromotionStrategies wait between reconciles while
+	// verified webhook deliveries for their GitRepository are fresh. Their usual requeue interval is lengthened up to
+	// this value, but not past the time the last delivery becomes stale. Unset disables adaptive polling.
+	// +optional
+	MaxRequeueDuration *metav1.Duration `json:"maxRequeueDuration,omitempty"`
+
+	// WebhookFreshness is how long a verified webhook delivery for a GitRepository is considered fresh. Once no delivery
+	// was received for as long, the usual requeue interval is used again. Defaults to 30m.
+	// +optional
+	WebhookFreshness *metav1.Duration `json:"webhookFreshness,omitempty"`
+}
+
+// GitRepositoryConfiguration defines the configuration for the GitRepository controller.
+type GitRepositoryConfiguration struct {
+	// AccessCheckInterval is how often the controller checks that each GitRepository exists and can be accessed with its
+	// ScmProvider's credentials. The check also runs whenever the GitRepository or its ScmProvider changes. The
+	// controller's --git-repository-requeue-duration flag overrides it when set. Defaults to 5m.
+	// +optional
+	AccessCheckInterval *metav1.Duration `json:"accessCheckInterval,omitempty"`
+}
+
+// ScmProviderConfiguration defines the configuration for the ScmProvider and ClusterScmProvider controllers.
+type ScmProviderConfiguration struct {
+	// CredentialsCheckInterval is how often the controllers check the Secret and credentials of each ScmProvider and
+	// ClusterScmProvider with their SCM. The check also runs whenever the provider or its Secret changes. The
+	// controller's --scm-provider-requeue-duration flag overrides it when set. Defaults to 5m.
+	// +optional
+	CredentialsCheckInterval *metav1.Duration `json:"credentialsCheckInterval,omitempty"`
 }
 
 // PromotionStrategyConfiguration defines the configuration for the PromotionStrategy controller.
@@ -91,6 +137,32 @@ type ChangeTransferPolicyConfiguration struct {
 	// This includes requeue duration, maximum concurrent reconciles, and rate limiter settings.
 	// +required
 	WorkQueue WorkQueue `json:"workQueue"`
+
+	// CloneDepth is the number of commits fetched when the controller clones a repository. Zero clones the full
+	// history. Shallow clones are deepened automatically when an operation needs history beyond the fetched commits.
+	// GitRepository.spec.cloneDepth overrides this value for a single repository. Defaults to the --git-clone-depth
+	// flag of the controller.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	CloneDepth *int32 `json:"cloneDepth,omitempty"`
+
+	// AlwaysOpenPullRequests opens a pull request for every proposed dry commit, even when the proposed branch's hydrated
+	// tree is identical to the active branch's. By default no pull request is opened for such commits, set this to keep
+	// a pull request for every dry commit, e.g. for auditing.
+	// +optional
+	AlwaysOpenPullRequests bool `json:"alwaysOpenPullRequests,omitempty"`
+
+	// CloneIdleTimeout is how long a cached clone may go unused before the controller removes it from disk. A removed
+	// clone is cloned again the next time its environment is reconciled. Clones are also removed when their
+	// ChangeTransferPolicy or GitRepository is deleted. Defaults to 24h, set to 0s to keep clones until they are deleted.
+	// The store of objects shared by the clones of a repository is removed once it had no clones for as long.
+	// +optional
+	CloneIdleTimeout *metav1.Duration `json:"cloneIdleTimeout,omitempty"`
+
+	// MinReconcileInterval is the shortest reconcileInterval a ChangeTransferPolicy may set, shorter intervals are raised
+	// to this value. It keeps a single resource from polling its repository too often. Defaults to 10s.
+	// +optional
+	MinReconcileInterval *metav1.Duration `json:"minReconcileInterval,omitempty"`
 }
 
 // PullRequestConfiguration defines the configuration for the PullRequest controller.
@@ -195,9 +267,10 @@ type WorkQueue struct {
 
 	// RateLimiter defines the rate limiting strategy for the controller's work queue.
 	// Rate limiting controls how quickly failed reconciliations are retried and helps
-	// prevent overwhelming external APIs or systems.
-	// +required
-	RateLimiter RateLimiter `json:"rateLimiter"`
+	// prevent overwhelming external APIs or systems. If it is not set, the controller uses the rate limiter
+	// configured with the controller's --rate-limiter-* flags.
+	// +optional
+	RateLimiter RateLimiter `json:"rateLimiter,omitempty"`
 }
 
 // ExponentialFailure defines an exponential backoff rate limiter configuration.
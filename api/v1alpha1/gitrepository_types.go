@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -24,7 +25,7 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // GitRepositorySpec defines the desired state of GitRepository
-// +kubebuilder:validation:ExactlyOneOf=github;gitlab;forgejo;gitea;bitbucketCloud;azureDevOps;fake
+// +kubebuilder:validation:ExactlyOneOf=github;gitlab;forgejo;gitea;bitbucketCloud;azureDevOps;fake;url
 type GitRepositorySpec struct {
 	GitHub         *GitHubRepo         `json:"github,omitempty"`
 	GitLab         *GitLabRepo         `json:"gitlab,omitempty"`
@@ -33,8 +34,114 @@ type GitRepositorySpec struct {
 	BitbucketCloud *BitbucketCloudRepo `json:"bitbucketCloud,omitempty"`
 	AzureDevOps    *AzureDevOpsRepo    `json:"azureDevOps,omitempty"`
 	Fake           *FakeRepo           `json:"fake,omitempty"`
+	// Url is the URL the repository is cloned, fetched and pushed with, instead of identifying it by the owner and
+	// name of one of the SCM specific fields. The ScmProvider's credentials are used with it. It is rejected by
+	// ScmProviders that need the owner and name for their API, such as GitHub.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(https?|ssh)://`
+	// +kubebuilder:validation:XValidation:rule="isURL(self)",message="must be a valid URL"
+	Url string `json:"url,omitempty"`
 	// +kubebuilder:validation:Required
 	ScmProviderRef ScmProviderObjectReference `json:"scmProviderRef"`
+	// CloneDepth overrides the ControllerConfiguration clone depth for this repository. It is the number of commits
+	// fetched when the repository is cloned, zero clones the full history.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	CloneDepth *int32 `json:"cloneDepth,omitempty"`
+	// CommitSigning signs the commits the promoter creates in this repository, such as the merge commits that resolve
+	// conflicts between the proposed and active branches, so that they pass signature verification on protected
+	// branches.
+	// +optional
+	CommitSigning *CommitSigning `json:"commitSigning,omitempty"`
+	// GitIdentity overrides the ScmProvider's and the controller's git identity for the commits the promoter creates in
+	// this repository.
+	// +optional
+	GitIdentity *GitIdentity `json:"gitIdentity,omitempty"`
+	// SparseCheckout limits the files checked out in the promoter's clones of this repository. When unset, the clones
+	// check out every file.
+	// +optional
+	SparseCheckout *SparseCheckout `json:"sparseCheckout,omitempty"`
+	// ManageWebhooks makes the controller register a webhook on the repository that notifies the promoter's webhook
+	// receiver, and keep it registered. It is only supported for GitHub, GitLab and fake repositories, and the
+	// ScmProvider's credentials must be allowed to manage the repository's webhooks.
+	// +optional
+	ManageWebhooks *ManageWebhooks `json:"manageWebhooks,omitempty"`
+	// PropagateLabels lists the keys of the labels and annotations of this GitRepository that are copied to the
+	// ChangeTransferPolicies, PullRequests and CommitStatuses the promoter creates for its PromotionStrategies, and
+	// kept in sync with it. Keys in the promoter.argoproj.io domain are never copied.
+	// +optional
+	// +listType=set
+	PropagateLabels []string `json:"propagateLabels,omitempty"`
+}
+
+// ManageWebhooks configures the webhook the controller registers on a repository.
+type ManageWebhooks struct {
+	// Url is the URL of the promoter's webhook receiver that the SCM sends the events to.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Url string `json:"url"`
+	// SecretRef references a secret in the GitRepository's namespace that holds the shared secret of the webhook in
+	// "webhookSecret". GitHub, Gitea, Forgejo and Bitbucket sign the payloads with it, GitLab sends it in the
+	// X-Gitlab-Token header and Azure DevOps as the password of the service hook's basic authentication. The webhook
+	// receiver rejects the deliveries for the repository that don't carry it, and the deliveries verified with it only
+	// trigger reconciles for this GitRepository.
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+	// Events are the events the webhook is sent for. Defaults to all of them.
+	// +optional
+	// +listType=set
+	Events []WebhookEvent `json:"events,omitempty"`
+}
+
+// WebhookEvent is an event a managed webhook is sent for.
+// +kubebuilder:validation:Enum=push;pull_request;status
+type WebhookEvent string
+
+const (
+	// WebhookEventPush is sent when commits are pushed to the repository.
+	WebhookEventPush WebhookEvent = "push"
+	// WebhookEventPullRequest is sent when a pull request, or a GitLab merge request, changes.
+	WebhookEventPullRequest WebhookEvent = "pull_request"
+	// WebhookEventStatus is sent when a commit status changes, or a GitLab pipeline.
+	WebhookEventStatus WebhookEvent = "status"
+)
+
+// WebhookSecretKey is the key of the shared webhook secret in the secret referenced by ManageWebhooks.
+const WebhookSecretKey = "webhookSecret"
+
+// SparseCheckout configures a cone mode sparse checkout of a repository.
+type SparseCheckout struct {
+	// Paths are the directories, relative to the root of the repository, that are checked out. Files in the root of
+	// the repository, such as hydrator.metadata, are always checked out.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:Pattern=`^[^/-]`
+	// +listType=set
+	Paths []string `json:"paths"`
+}
+
+// CommitSigningFormat is the format of the key used to sign commits.
+// +kubebuilder:validation:Enum=ssh;openpgp
+type CommitSigningFormat string
+
+const (
+	// CommitSigningFormatSSH signs commits with an SSH key.
+	CommitSigningFormatSSH CommitSigningFormat = "ssh"
+	// CommitSigningFormatOpenPGP signs commits with a GPG key.
+	CommitSigningFormatOpenPGP CommitSigningFormat = "openpgp"
+)
+
+// CommitSigning configures the key that signs the commits the promoter creates.
+type CommitSigning struct {
+	// Format is the format of the signing key, either ssh or openpgp.
+	// +kubebuilder:validation:Required
+	Format CommitSigningFormat `json:"format"`
+	// SecretRef references a secret in the GitRepository's namespace. The secret must contain the private key in
+	// "signingKey", an OpenSSH private key for the ssh format or an ASCII armored secret key for the openpgp format.
+	// An encrypted SSH key also needs its passphrase in "signingKeyPassphrase". OpenPGP keys must not be encrypted.
+	// +kubebuilder:validation:Required
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
 }
 
 // ScmProviderObjectReference is a reference to a SCM provider object.
@@ -70,6 +177,66 @@ type GitRepositoryStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// DefaultBranch is the repository's default branch, as reported by the SCM.
+	// +optional
+	DefaultBranch string `json:"defaultBranch,omitempty"`
+
+	// HttpsCloneUrl is the URL to clone the repository over HTTPS, as reported by the SCM.
+	// +optional
+	HttpsCloneUrl string `json:"httpsCloneUrl,omitempty"`
+
+	// SshCloneUrl is the URL to clone the repository over SSH, as reported by the SCM.
+	// +optional
+	SshCloneUrl string `json:"sshCloneUrl,omitempty"`
+
+	// RepositoryID is the SCM's internal ID of the repository, such as the numeric project ID in GitLab. It is empty for
+	// SCMs whose repositories are only looked up with git.
+	// +optional
+	RepositoryID string `json:"repositoryId,omitempty"`
+
+	// WebhookID is the SCM's ID of the webhook registered for spec.manageWebhooks.
+	// +optional
+	WebhookID string `json:"webhookId,omitempty"`
+
+	// GitLab holds the ID of the GitLab project, looked up for spec.gitlab so that the GitLab provider doesn't look
+	// it up for every API call.
+	// +optional
+	GitLab *GitLabRepositoryStatus `json:"gitlab,omitempty"`
+
+	// AzureDevOps holds the IDs of the Azure DevOps project and repository, looked up for spec.azureDevOps.
+	// +optional
+	AzureDevOps *AzureDevOpsRepositoryStatus `json:"azureDevOps,omitempty"`
+
+	// LastWebhookDelivery is when a replica of the controller last received a verified webhook delivery for the
+	// repository. It is refreshed at most once a minute, so that every replica and shard of the controller polls less
+	// while webhooks are delivered.
+	// +optional
+	LastWebhookDelivery *metav1.Time `json:"lastWebhookDelivery,omitempty"`
+}
+
+// GitLabRepositoryStatus is the ID of a GitLab project and the namespace and name it was looked up for. The ID is
+// only used while spec.gitlab still has the same namespace and name.
+type GitLabRepositoryStatus struct {
+	// Namespace is the namespace of the project the ID was looked up for.
+	Namespace string `json:"namespace"`
+	// Name is the name of the project the ID was looked up for.
+	Name string `json:"name"`
+	// ProjectID is the ID of the project.
+	ProjectID int `json:"projectId"`
+}
+
+// AzureDevOpsRepositoryStatus are the IDs of an Azure DevOps project and repository and the names they were looked up
+// for. The IDs are only used while spec.azureDevOps still has the same project and name.
+type AzureDevOpsRepositoryStatus struct {
+	// Project is the name of the project the IDs were looked up for.
+	Project string `json:"project"`
+	// Name is the name of the repository the IDs were looked up for.
+	Name string `json:"name"`
+	// ProjectID is the ID of the project.
+	ProjectID string `json:"projectId"`
+	// RepositoryID is the ID of the repository.
+	RepositoryID string `json:"repositoryId"`
 }
 
 // +kubebuilder:ac:generate=true
@@ -17,12 +17,18 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"reflect"
+	"slices"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// PromotionStrategyKind is the kind of the PromotionStrategy resource.
+var PromotionStrategyKind = reflect.TypeOf(PromotionStrategy{}).Name()
+
 // PromotionStrategySpec defines the desired state of PromotionStrategy
 type PromotionStrategySpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -53,11 +59,31 @@ type PromotionStrategySpec struct {
 	// +listMapKey=key
 	ProposedCommitStatuses []CommitStatusSelector `json:"proposedCommitStatuses,omitempty"`
 
+	// ProposedBranchTemplate is a Go template that renders the name of each environment's proposed branch, which is the
+	// branch the hydrator writes proposed changes to. It is rendered with .Branch set to the environment's branch, and
+	// defaults to "{{ .Branch }}-next". Environments can override it with their own proposedBranchTemplate.
+	//
+	// The rendered name is stored on the environment's ChangeTransferPolicy and cannot change once it was created.
+	// +kubebuilder:validation:Optional
+	ProposedBranchTemplate string `json:"proposedBranchTemplate,omitempty"`
+
+	// PullRequest is merged into the pull requests the promoter opens for every environment. Environments can
+	// override it with their own pullRequest.
+	// +kubebuilder:validation:Optional
+	PullRequest *PullRequestTemplateSpec `json:"pullRequest,omitempty"`
+
 	// Environments is the sequence of environments that a dry commit will be promoted through.
 	// +kubebuilder:validation:MinItems:=1
 	// +listType:=map
 	// +listMapKey=branch
 	Environments []Environment `json:"environments"`
+
+	// PropagateLabels lists the keys of the labels and annotations of this PromotionStrategy that are copied to the
+	// ChangeTransferPolicies, PullRequests and CommitStatuses the promoter creates for it, and kept in sync with it.
+	// They take precedence over the GitRepository's. Keys in the promoter.argoproj.io domain are never copied.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	PropagateLabels []string `json:"propagateLabels,omitempty"`
 }
 
 // Environment defines a single environment in the promotion sequence.
@@ -71,6 +97,13 @@ type Environment struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default:=true
 	AutoMerge *bool `json:"autoMerge,omitempty"`
+	// ProposedBranchTemplate overrides spec.proposedBranchTemplate for this environment.
+	// +kubebuilder:validation:Optional
+	ProposedBranchTemplate string `json:"proposedBranchTemplate,omitempty"`
+	// PullRequest is merged over spec.pullRequest for the pull requests of this environment: its values win, and its
+	// labels and reviewers are added to spec.pullRequest's.
+	// +kubebuilder:validation:Optional
+	PullRequest *PullRequestTemplateSpec `json:"pullRequest,omitempty"`
 	// ActiveCommitStatuses are commit statuses describing an actively running dry commit. If an active commit status
 	// is failing for an environment, subsequent environments will not deploy the failing commit.
 	//
@@ -90,6 +123,44 @@ type Environment struct {
 	// +listType:=map
 	// +listMapKey=key
 	ProposedCommitStatuses []CommitStatusSelector `json:"proposedCommitStatuses,omitempty"`
+	// ReconcileInterval is passed to the environment's ChangeTransferPolicy, where it overrides the controller's default
+	// requeue duration. Use it to poll busy environments more often without raising git traffic for every environment.
+	// +kubebuilder:validation:Optional
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+	// ResolveDivergence is passed to the environment's ChangeTransferPolicy, where it decides what happens when the
+	// proposed branch diverged from the commits the controller previously saw, either reset or manual.
+	// +kubebuilder:validation:Optional
+	ResolveDivergence DivergenceResolution `json:"resolveDivergence,omitempty"`
+	// AutoRevert reverts a promotion to this environment automatically when the environment's active commit statuses
+	// start failing shortly after it.
+	// +kubebuilder:validation:Optional
+	AutoRevert *AutoRevert `json:"autoRevert,omitempty"`
+}
+
+// AutoRevert configures automatic reverts of promotions to an environment. When an active commit status of the
+// environment goes to failure within Within of a promotion and is still failing after Debounce, the PromotionStrategy
+// creates a RevertCommit scoped to the environment for the promoted dry commit. The earlier manifests are proposed like
+// any other change, so the revert has to pass the environment's proposed commit statuses before it is promoted.
+//
+// A dry commit is reverted automatically at most once per environment, and dry commits that a RevertCommit restored
+// are never reverted automatically.
+type AutoRevert struct {
+	// Enabled turns automatic reverts on for the environment.
+	// +kubebuilder:validation:Required
+	Enabled bool `json:"enabled"`
+	// DryBranch is the branch of the dry commits promoted to the environment. It is set on the RevertCommits created
+	// for the environment.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	DryBranch string `json:"dryBranch"`
+	// Within is how long after a promotion a failing active commit status leads to a revert. Failures that start later
+	// are left to be handled by hand.
+	// +kubebuilder:validation:Required
+	Within metav1.Duration `json:"within"`
+	// Debounce is how long an active commit status has to keep failing before the promotion is reverted, so that
+	// short-lived failures, for example during a rollout, don't lead to a revert.
+	// +kubebuilder:validation:Optional
+	Debounce metav1.Duration `json:"debounce,omitempty"`
 }
 
 // GetAutoMerge returns the value of the AutoMerge field, defaulting to true if the field is nil.
@@ -100,6 +171,96 @@ func (e *Environment) GetAutoMerge() bool {
 	return *e.AutoMerge
 }
 
+// DefaultProposedBranchTemplate is the template used to name proposed branches when none is configured.
+const DefaultProposedBranchTemplate = "{{ .Branch }}-next"
+
+// GetProposedBranchTemplate returns the template used to name the proposed branch of the environment: the
+// environment's own template, else the PromotionStrategy's, else DefaultProposedBranchTemplate.
+func (ps *PromotionStrategy) GetProposedBranchTemplate(environment Environment) string {
+	if environment.ProposedBranchTemplate != "" {
+		return environment.ProposedBranchTemplate
+	}
+	if ps.Spec.ProposedBranchTemplate != "" {
+		return ps.Spec.ProposedBranchTemplate
+	}
+	return DefaultProposedBranchTemplate
+}
+
+// PullRequestTemplateSpec configures the pull requests the promoter opens for a PromotionStrategy. Changes are applied
+// to the open pull requests on their next reconcile.
+type PullRequestTemplateSpec struct {
+	// TitlePrefix is prepended to the title of the pull requests.
+	// +kubebuilder:validation:Optional
+	TitlePrefix string `json:"titlePrefix,omitempty"`
+	// Title is a Go template that renders the title of the pull requests, in place of the ControllerConfiguration's
+	// pull request title template. It is rendered with the same data.
+	// +kubebuilder:validation:Optional
+	Title string `json:"title,omitempty"`
+	// Description is a Go template that renders the description of the pull requests, in place of the
+	// ControllerConfiguration's pull request description template. It is rendered with the same data.
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+	// Labels are added to the pull requests on the SCM.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Labels []string `json:"labels,omitempty"`
+	// Reviewers are requested to review the pull requests when they are opened. Teams are given as organization/team.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Reviewers []string `json:"reviewers,omitempty"`
+	// MergeStrategy is how the pull requests are merged, either merge or squash. Defaults to merge.
+	// +kubebuilder:validation:Optional
+	MergeStrategy PullRequestMergeStrategy `json:"mergeStrategy,omitempty"`
+	// Draft opens the pull requests as drafts. Draft pull requests are not merged automatically.
+	// +kubebuilder:validation:Optional
+	Draft *bool `json:"draft,omitempty"`
+}
+
+// GetPullRequestTemplate returns the pull request template of the environment: spec.pullRequest with the
+// environment's pullRequest merged over it, or nil if neither is set. The environment's titlePrefix, title,
+// description, mergeStrategy and draft win over the PromotionStrategy's, and the labels and reviewers of both are
+// combined.
+func (ps *PromotionStrategy) GetPullRequestTemplate(environment Environment) *PullRequestTemplateSpec {
+	if ps.Spec.PullRequest == nil {
+		return environment.PullRequest.DeepCopy()
+	}
+	merged := ps.Spec.PullRequest.DeepCopy()
+	override := environment.PullRequest
+	if override == nil {
+		return merged
+	}
+
+	if override.TitlePrefix != "" {
+		merged.TitlePrefix = override.TitlePrefix
+	}
+	if override.Title != "" {
+		merged.Title = override.Title
+	}
+	if override.Description != "" {
+		merged.Description = override.Description
+	}
+	if override.MergeStrategy != "" {
+		merged.MergeStrategy = override.MergeStrategy
+	}
+	if override.Draft != nil {
+		draft := *override.Draft
+		merged.Draft = &draft
+	}
+	merged.Labels = appendMissing(merged.Labels, override.Labels)
+	merged.Reviewers = appendMissing(merged.Reviewers, override.Reviewers)
+	return merged
+}
+
+// appendMissing appends the values that aren't in list yet to it.
+func appendMissing(list, values []string) []string {
+	for _, value := range values {
+		if !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
 // CommitStatusSelector is used to select commit statuses by their key.
 type CommitStatusSelector struct {
 	// +required
@@ -123,6 +284,25 @@ type PromotionStrategyStatus struct {
 	// +listMapKey=branch
 	Environments []EnvironmentStatus `json:"environments"`
 
+	// Summary is the active dry commit of each environment in the promotion sequence, abbreviated, such as
+	// "environment/dev=abc1234, environment/prod=9f8e7d6". It is for display.
+	// +optional
+	Summary string `json:"summary,omitempty"`
+
+	// LastEnvironmentState is whether the last environment in the promotion sequence runs the change proposed for it.
+	// It is for display.
+	// +optional
+	LastEnvironmentState EnvironmentPromotionState `json:"lastEnvironmentState,omitempty"`
+
+	// LastEnvironmentDryShortSha is the abbreviated active dry commit of the last environment in the promotion
+	// sequence. It is for display.
+	// +optional
+	LastEnvironmentDryShortSha string `json:"lastEnvironmentDryShortSha,omitempty"`
+
+	// Polling shows how often the PromotionStrategy is polled and whether webhooks are delivered for its repository.
+	// +optional
+	Polling *PollingStatus `json:"polling,omitempty"`
+
 	// Conditions Represents the observations of the current state.
 	// +patchMergeKey=type
 	// +patchStrategy=merge
@@ -131,6 +311,19 @@ type PromotionStrategyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
+// EnvironmentPromotionState is whether an environment runs the change proposed for it.
+// +kubebuilder:validation:Enum=Promoted;Promoting;Blocked
+type EnvironmentPromotionState string
+
+const (
+	// EnvironmentPromoted means the environment's active branch has the change proposed for it, or none is proposed.
+	EnvironmentPromoted EnvironmentPromotionState = "Promoted"
+	// EnvironmentPromoting means a change is proposed for the environment and isn't active yet.
+	EnvironmentPromoting EnvironmentPromotionState = "Promoting"
+	// EnvironmentBlocked means a change is proposed for the environment and a commit status of it failed.
+	EnvironmentBlocked EnvironmentPromotionState = "Blocked"
+)
+
 // GetConditions returns the conditions of the PromotionStrategy.
 func (ps *PromotionStrategy) GetConditions() *[]metav1.Condition {
 	return &ps.Status.Conditions
@@ -154,6 +347,11 @@ type EnvironmentStatus struct {
 	// PullRequest is the state of the pull request that was created for this environment.
 	PullRequest *PullRequestCommonStatus `json:"pullRequest,omitempty"`
 
+	// EffectivelyPromotedDrySha is the proposed dry sha when it does not change the environment's hydrated manifests,
+	// so it counts as promoted without a pull request. It is copied from the environment's ChangeTransferPolicy.
+	// +optional
+	EffectivelyPromotedDrySha string `json:"effectivelyPromotedDrySha,omitempty"`
+
 	// LastHealthyDryShas is a list of dry commits that were observed to be healthy in the environment.
 	// +kubebuilder:validation:Optional
 	LastHealthyDryShas []HealthyDryShas `json:"lastHealthyDryShas"`
@@ -164,6 +362,49 @@ type EnvironmentStatus struct {
 	// History is constructed on a best-effort basis and should be used for informational purposes only.
 	// History is in reverse chronological order (newest is first).
 	History []History `json:"history,omitempty"`
+
+	// AutoRevert is the state of the automatic revert of the dry commit active in the environment. It is only set when
+	// autoRevert is enabled for the environment.
+	// +optional
+	AutoRevert *AutoRevertStatus `json:"autoRevert,omitempty"`
+
+	// EmergencyRevert is the hydrated commit that was reverted directly on the environment's active branch by a
+	// RevertCommit with target hydrated. While it is set, the active branch has diverged from the proposed branch on
+	// purpose and auto-merge is held for the environment. It is cleared once a different dry commit is hydrated to the
+	// proposed branch or the RevertCommit is deleted.
+	// +optional
+	EmergencyRevert *EmergencyRevertStatus `json:"emergencyRevert,omitempty"`
+}
+
+// AutoRevertStatus is the state of the automatic revert of a dry commit in an environment.
+type AutoRevertStatus struct {
+	// DrySha is the dry commit active in the environment.
+	// Supports both SHA-1 (40 chars) and SHA-256 (64 chars) Git hash formats.
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^([a-f0-9]{40}|[a-f0-9]{64})$`
+	DrySha string `json:"drySha"`
+	// FailingSince is when an active commit status of the dry commit was first seen failing. It is cleared when the
+	// active commit statuses stop failing.
+	// +optional
+	FailingSince *metav1.Time `json:"failingSince,omitempty"`
+	// RevertCommit is the name of the RevertCommit created to revert the dry commit in the environment.
+	// +optional
+	RevertCommit string `json:"revertCommit,omitempty"`
+}
+
+// EmergencyRevertStatus is a hydrated commit that was reverted directly on an environment's active branch.
+type EmergencyRevertStatus struct {
+	// RevertCommit is the name of the RevertCommit that reverted the hydrated commit.
+	RevertCommit string `json:"revertCommit"`
+	// HydratedSha is the hydrated commit that was reverted.
+	// Supports both SHA-1 (40 chars) and SHA-256 (64 chars) Git hash formats.
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^([a-f0-9]{40}|[a-f0-9]{64})$`
+	HydratedSha string `json:"hydratedSha"`
+	// DrySha is the dry commit the reverted commit was hydrated from. Auto-merge is held while it is still the
+	// proposed dry commit.
+	// +optional
+	DrySha string `json:"drySha,omitempty"`
 }
 
 // HealthyDryShas is a list of dry commits that were observed to be healthy in the environment.
@@ -180,8 +421,13 @@ type HealthyDryShas struct {
 // +kubebuilder:ac:generate=true
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+// +kubebuilder:storageversion
 
 // PromotionStrategy is the Schema for the promotionstrategies API
+// +kubebuilder:printcolumn:name="Repository",type=string,JSONPath=`.spec.gitRepositoryRef.name`,priority=1
+// +kubebuilder:printcolumn:name="Last Env State",type=string,JSONPath=`.status.lastEnvironmentState`
+// +kubebuilder:printcolumn:name="Last Env Dry Sha",type=string,JSONPath=`.status.lastEnvironmentDryShortSha`
+// +kubebuilder:printcolumn:name="Environments",type=string,JSONPath=`.status.summary`,priority=1
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 type PromotionStrategy struct {
 	metav1.TypeMeta   `json:",inline"`
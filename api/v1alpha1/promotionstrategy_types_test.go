@@ -0,0 +1,135 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+func TestGetPullRequestTemplate(t *testing.T) {
+	t.Parallel()
+
+	strategyTemplate := &v1alpha1.PullRequestTemplateSpec{
+		TitlePrefix:   "[promote] ",
+		Title:         "Promote {{ .ChangeTransferPolicy.Spec.ActiveBranch }}",
+		Description:   "Promotion",
+		Labels:        []string{"promotion", "automated"},
+		Reviewers:     []string{"alice"},
+		MergeStrategy: v1alpha1.PullRequestMergeStrategyMerge,
+		Draft:         ptr.To(true),
+	}
+
+	tests := []struct {
+		name        string
+		strategy    *v1alpha1.PullRequestTemplateSpec
+		environment *v1alpha1.PullRequestTemplateSpec
+		want        *v1alpha1.PullRequestTemplateSpec
+	}{
+		{
+			name: "neither set",
+			want: nil,
+		},
+		{
+			name:     "only the strategy's",
+			strategy: strategyTemplate,
+			want:     strategyTemplate,
+		},
+		{
+			name:        "only the environment's",
+			environment: &v1alpha1.PullRequestTemplateSpec{TitlePrefix: "[prod] ", Labels: []string{"prod"}},
+			want:        &v1alpha1.PullRequestTemplateSpec{TitlePrefix: "[prod] ", Labels: []string{"prod"}},
+		},
+		{
+			name:     "the environment's values win",
+			strategy: strategyTemplate,
+			environment: &v1alpha1.PullRequestTemplateSpec{
+				TitlePrefix:   "[prod] ",
+				Title:         "Deploy to production",
+				Description:   "Production deployment",
+				MergeStrategy: v1alpha1.PullRequestMergeStrategySquash,
+				Draft:         ptr.To(false),
+			},
+			want: &v1alpha1.PullRequestTemplateSpec{
+				TitlePrefix:   "[prod] ",
+				Title:         "Deploy to production",
+				Description:   "Production deployment",
+				Labels:        []string{"promotion", "automated"},
+				Reviewers:     []string{"alice"},
+				MergeStrategy: v1alpha1.PullRequestMergeStrategySquash,
+				Draft:         ptr.To(false),
+			},
+		},
+		{
+			name:     "empty environment values keep the strategy's",
+			strategy: strategyTemplate,
+			environment: &v1alpha1.PullRequestTemplateSpec{
+				Labels: []string{"prod"},
+			},
+			want: &v1alpha1.PullRequestTemplateSpec{
+				TitlePrefix:   "[promote] ",
+				Title:         "Promote {{ .ChangeTransferPolicy.Spec.ActiveBranch }}",
+				Description:   "Promotion",
+				Labels:        []string{"promotion", "automated", "prod"},
+				Reviewers:     []string{"alice"},
+				MergeStrategy: v1alpha1.PullRequestMergeStrategyMerge,
+				Draft:         ptr.To(true),
+			},
+		},
+		{
+			name:     "labels and reviewers are combined without duplicates",
+			strategy: strategyTemplate,
+			environment: &v1alpha1.PullRequestTemplateSpec{
+				Labels:    []string{"automated", "prod"},
+				Reviewers: []string{"bob", "alice", "org/sre"},
+			},
+			want: &v1alpha1.PullRequestTemplateSpec{
+				TitlePrefix:   "[promote] ",
+				Title:         "Promote {{ .ChangeTransferPolicy.Spec.ActiveBranch }}",
+				Description:   "Promotion",
+				Labels:        []string{"promotion", "automated", "prod"},
+				Reviewers:     []string{"alice", "bob", "org/sre"},
+				MergeStrategy: v1alpha1.PullRequestMergeStrategyMerge,
+				Draft:         ptr.To(true),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ps := &v1alpha1.PromotionStrategy{
+				Spec: v1alpha1.PromotionStrategySpec{
+					PullRequest:  tt.strategy.DeepCopy(),
+					Environments: []v1alpha1.Environment{{Branch: "production", PullRequest: tt.environment.DeepCopy()}},
+				},
+			}
+			got := ps.GetPullRequestTemplate(ps.Spec.Environments[0])
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("GetPullRequestTemplate() = %+v, want %+v", got, tt.want)
+			}
+			if tt.strategy != nil && !reflect.DeepEqual(ps.Spec.PullRequest, tt.strategy) {
+				t.Fatalf("GetPullRequestTemplate() modified spec.pullRequest: %+v", ps.Spec.PullRequest)
+			}
+		})
+	}
+}
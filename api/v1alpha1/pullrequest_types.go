@@ -35,11 +35,11 @@ type PullRequestSpec struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
 	Title string `json:"title"`
-	// TargetBranch is the head the git reference we are merging from Head ---> Base
+	// TargetBranch is the branch that the source branch is merged into, the base branch of the pull request.
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	// +kubebuilder:validation:Required
 	TargetBranch string `json:"targetBranch"`
-	// SourceBranch is the base the git reference that we are merging into Head ---> Base
+	// SourceBranch is the branch with the changes, the head branch of the pull request.
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	// +kubebuilder:validation:Required
 	SourceBranch string `json:"sourceBranch"`
@@ -61,8 +61,34 @@ type PullRequestSpec struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Enum=closed;merged;open
 	State PullRequestState `json:"state"`
+	// Labels are added to the pull request on the SCM. Labels that were added to it on the SCM are left alone.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Labels []string `json:"labels,omitempty"`
+	// Reviewers are requested to review the pull request when it is opened. Teams are given as organization/team.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Reviewers []string `json:"reviewers,omitempty"`
+	// MergeStrategy is how the pull request is merged, either merge or squash. Defaults to merge.
+	// +kubebuilder:validation:Optional
+	MergeStrategy PullRequestMergeStrategy `json:"mergeStrategy,omitempty"`
+	// Draft opens the pull request as a draft. Draft pull requests are not merged automatically: they have to be
+	// marked as ready and merged on the SCM.
+	// +kubebuilder:validation:Optional
+	Draft bool `json:"draft,omitempty"`
 }
 
+// PullRequestMergeStrategy is how a pull request is merged.
+// +kubebuilder:validation:Enum=merge;squash
+type PullRequestMergeStrategy string
+
+const (
+	// PullRequestMergeStrategyMerge merges the pull request with a merge commit.
+	PullRequestMergeStrategyMerge PullRequestMergeStrategy = "merge"
+	// PullRequestMergeStrategySquash squashes the commits of the pull request into a single commit.
+	PullRequestMergeStrategySquash PullRequestMergeStrategy = "squash"
+)
+
 // CommitConfiguration defines the commit configuration for how we will merge/squash/etc the pull request.
 type CommitConfiguration struct {
 	// Message is the commit message that will be written for the commit that's made when merging the PR.
@@ -101,6 +127,21 @@ type PullRequestStatus struct {
 	// preserved in the owning ChangeTransferPolicy to maintain a record.
 	ExternallyMergedOrClosed *bool `json:"externallyMergedOrClosed,omitempty"`
 
+	// Labels are the spec.labels the controller last added to the pull request on the SCM. Labels that are removed from
+	// spec.labels are removed from the pull request, the ones added on the SCM are left alone.
+	// +optional
+	// +listType=set
+	Labels []string `json:"labels,omitempty"`
+	// Reviewers are the spec.reviewers the controller last requested reviews from. The review requests of reviewers
+	// that are removed from spec.reviewers are removed from the pull request.
+	// +optional
+	// +listType=set
+	Reviewers []string `json:"reviewers,omitempty"`
+	// Draft is the spec.draft the pull request was last opened or updated with on the SCM. The pull request is only
+	// converted to or from a draft when spec.draft changes, so marking it as ready on the SCM is left alone.
+	// +optional
+	Draft bool `json:"draft,omitempty"`
+
 	// Conditions Represents the observations of the current state.
 	// +patchMergeKey=type
 	// +patchStrategy=merge
@@ -122,6 +163,7 @@ func (ps *PullRequest) SetObservedGeneration(generation int64) {
 // +kubebuilder:ac:generate=true
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+// +kubebuilder:storageversion
 
 // PullRequest is the Schema for the pullrequests API
 // +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
@@ -129,7 +171,7 @@ func (ps *PullRequest) SetObservedGeneration(generation int64) {
 // +kubebuilder:printcolumn:name="Source",type=string,JSONPath=`.spec.sourceBranch`,priority=1
 // +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.targetBranch`,priority=1
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
-// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.status.url`,priority=1
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.status.url`
 // +kubebuilder:validation:XValidation:rule=`self.spec.state == 'open' || has(self.status.id) && self.status.id != ""`,message="Cannot transition to 'closed' or 'merged' state when status.id is empty"
 type PullRequest struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -24,18 +24,209 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // RevertCommitSpec defines the desired state of RevertCommit
+// +kubebuilder:validation:XValidation:rule="!has(self.target) || self.target != 'hydrated' || (has(self.confirmEmergency) && self.confirmEmergency)",message="confirmEmergency must be true to revert a hydrated commit"
+// +kubebuilder:validation:XValidation:rule="!has(self.target) || self.target != 'hydrated' || (has(self.environments) && size(self.environments) == 1)",message="a hydrated commit must be reverted in exactly one environment"
 type RevertCommitSpec struct {
-	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// PromotionStrategyRef is a reference to the PromotionStrategy whose repository contains the commit to revert.
+	// +required
+	PromotionStrategyRef ObjectReference `json:"promotionStrategyRef"`
 
-	// Foo is an example field of RevertCommit. Edit revertcommit_types.go to remove/update
-	Foo string `json:"foo,omitempty"`
+	// DryBranch is the branch that contains the commit to revert. The revert pull request targets this branch, so the
+	// revert is hydrated and promoted through the environments like any other change. When target is hydrated, it is
+	// the dry branch the environment is hydrated from.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	DryBranch string `json:"dryBranch"`
+
+	// Sha is the commit to revert, a dry commit unless target is hydrated. A merge commit is reverted relative to its
+	// first parent.
+	// Supports both SHA-1 (40 chars) and SHA-256 (64 chars) Git hash formats.
+	// +required
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^([a-f0-9]{40}|[a-f0-9]{64})$`
+	Sha string `json:"sha"`
+
+	// Environments are the active branches of the environments to revert the commit in. When set, the dry branch is
+	// left alone: each environment is instead returned to the manifests it ran before the commit was promoted to it.
+	// They are proposed on the environment's proposed branch and promoted through its usual commit statuses, except that
+	// the revert doesn't wait for the environments before it. Anything promoted to the environment after the commit is
+	// rolled back as well. Every environment must be part of the
+	// PromotionStrategy. When target is hydrated, it must be exactly the one environment whose active branch
+	// contains spec.sha.
+	// +optional
+	// +listType=set
+	Environments []string `json:"environments,omitempty"`
+
+	// Target is the kind of commit spec.sha is. With dry, the default, it is a dry commit. With hydrated, it is a
+	// hydrated commit that is reverted directly on the active branch of the environment in spec.environments, without
+	// waiting for the revert to be hydrated. This is a break-glass path for when hydration itself is broken: the revert
+	// is merged through a pull request, so branch protection still applies, but the environment's proposed commit
+	// statuses are not. The revert commit carries an Emergency-revert trailer, and the PromotionStrategy holds
+	// auto-merge for the environment until a different dry commit is hydrated to it.
+	// +optional
+	// +kubebuilder:default=dry
+	Target RevertTarget `json:"target,omitempty"`
+
+	// ConfirmEmergency must be true when target is hydrated, to acknowledge that the revert bypasses hydration.
+	// +optional
+	ConfirmEmergency bool `json:"confirmEmergency,omitempty"`
+
+	// MaxDistanceFromTip is how many commits may be on top of spec.sha before it is checked whether they changed the
+	// paths spec.sha changes. If the tip of the branch has different contents in any of them, the commit isn't reverted
+	// unless force is true. Defaults to 0: only the tip of the branch is reverted without the check.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxDistanceFromTip int `json:"maxDistanceFromTip,omitempty"`
+
+	// Force reverts spec.sha even if newer commits change the same paths. The revert may still conflict.
+	// +optional
+	Force bool `json:"force,omitempty"`
+
+	// CommitMessageTemplate is a Go template that renders the message of the revert commit instead of git's default.
+	// Its first line is also the title of the revert pull request, and the rest its description. It is rendered with
+	// .Sha, .ShortSha, .Subject and .Author of the reverted commit, .Name and .Namespace of the RevertCommit, and
+	// .TemplateFields. Sprig functions are available except env, expandenv and getHostByName.
+	// +optional
+	// +kubebuilder:validation:MaxLength=4096
+	// +kubebuilder:validation:XValidation:rule="self.matches('^([^{]|[{][^{])*([{][{]([^}]|[}][^}])*[}][}]([^{]|[{][^{])*)*[{]?$')",message="commitMessageTemplate has an unterminated {{ action"
+	CommitMessageTemplate string `json:"commitMessageTemplate,omitempty"`
+
+	// TemplateFields are free-form values for commitMessageTemplate, such as an incident ticket. A field the template
+	// uses must be set.
+	// +optional
+	TemplateFields map[string]string `json:"templateFields,omitempty"`
 }
 
+// RevertTarget is the kind of commit a RevertCommit reverts.
+// +kubebuilder:validation:Enum=dry;hydrated
+type RevertTarget string
+
+const (
+	// RevertTargetDry reverts a dry commit, which is then hydrated and promoted like any other change.
+	RevertTargetDry RevertTarget = "dry"
+	// RevertTargetHydrated reverts a hydrated commit directly on an environment's active branch.
+	RevertTargetHydrated RevertTarget = "hydrated"
+)
+
 // RevertCommitStatus defines the observed state of RevertCommit
 type RevertCommitStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// ObservedGeneration is the .metadata.generation that this status was reconciled from.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase is where the RevertCommit is in reverting spec.sha. Conflicted, Superseded, Merged, Closed and Reverted are
+	// terminal: the RevertCommit is not reconciled again until its spec changes.
+	// +optional
+	// +kubebuilder:validation:Enum=Cloning;Reverting;Conflicted;Superseded;PullRequestOpen;Promoting;Merged;Closed;Reverted
+	Phase RevertCommitPhase `json:"phase,omitempty"`
+
+	// ShortSha is the abbreviated spec.sha, for display.
+	// +optional
+	ShortSha string `json:"shortSha,omitempty"`
+
+	// RevertBranch is the branch the revert commit was pushed to.
+	// +optional
+	RevertBranch string `json:"revertBranch,omitempty"`
+
+	// RevertSha is the commit that reverts spec.sha. It is created once per generation of the RevertCommit.
+	// +optional
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^([a-f0-9]{40}|[a-f0-9]{64})$`
+	RevertSha string `json:"revertSha,omitempty"`
+
+	// DrySha is the dry commit that spec.sha was hydrated from, when target is hydrated.
+	// +optional
+	DrySha string `json:"drySha,omitempty"`
+
+	// PullRequestName is the name of the PullRequest that merges the revert branch into the dry branch, or into the
+	// environment's active branch when target is hydrated.
+	// +optional
+	PullRequestName string `json:"pullRequestName,omitempty"`
+
+	// PullRequest is the state of the revert pull request. It is kept after the PullRequest is deleted, which happens
+	// once the pull request is merged or closed.
+	// +optional
+	PullRequest *PullRequestCommonStatus `json:"pullRequest,omitempty"`
+
+	// Url is the URL of the revert pull request.
+	// +optional
+	Url string `json:"url,omitempty"`
+
+	// Environments is the progress of the revert in each environment of spec.environments.
+	// +optional
+	// +listType=map
+	// +listMapKey=branch
+	Environments []RevertCommitEnvironmentStatus `json:"environments,omitempty"`
+
+	// Conditions represent the latest available observations of an object's state
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// RevertCommitPhase is where a RevertCommit is in reverting its commit.
+type RevertCommitPhase string
+
+const (
+	// RevertCommitPhaseCloning indicates that the repository is being cloned.
+	RevertCommitPhaseCloning RevertCommitPhase = "Cloning"
+	// RevertCommitPhaseReverting indicates that the revert commit is being created, or for a RevertCommit scoped to
+	// environments, that the earlier manifests are being proposed.
+	RevertCommitPhaseReverting RevertCommitPhase = "Reverting"
+	// RevertCommitPhaseConflicted indicates that the commit can't be reverted without resolving conflicts by hand.
+	RevertCommitPhaseConflicted RevertCommitPhase = "Conflicted"
+	// RevertCommitPhaseSuperseded indicates that the commit is no longer on the dry branch, so there is nothing to
+	// revert.
+	RevertCommitPhaseSuperseded RevertCommitPhase = "Superseded"
+	// RevertCommitPhasePullRequestOpen indicates that the revert pull request is open.
+	RevertCommitPhasePullRequestOpen RevertCommitPhase = "PullRequestOpen"
+	// RevertCommitPhasePromoting indicates that the earlier manifests were proposed in every environment and wait to be
+	// promoted.
+	RevertCommitPhasePromoting RevertCommitPhase = "Promoting"
+	// RevertCommitPhaseMerged indicates that the revert pull request was merged.
+	RevertCommitPhaseMerged RevertCommitPhase = "Merged"
+	// RevertCommitPhaseClosed indicates that the revert pull request was closed without the controller seeing it
+	// merged.
+	RevertCommitPhaseClosed RevertCommitPhase = "Closed"
+	// RevertCommitPhaseReverted indicates that every environment was promoted back to its earlier manifests.
+	RevertCommitPhaseReverted RevertCommitPhase = "Reverted"
+)
+
+// IsTerminal reports whether the phase is final for the current spec.
+func (p RevertCommitPhase) IsTerminal() bool {
+	switch p {
+	case RevertCommitPhaseConflicted, RevertCommitPhaseSuperseded, RevertCommitPhaseMerged, RevertCommitPhaseClosed, RevertCommitPhaseReverted:
+		return true
+	default:
+		return false
+	}
+}
+
+// RevertCommitEnvironmentStatus is the progress of a revert in one environment.
+type RevertCommitEnvironmentStatus struct {
+	// Branch is the active branch of the environment.
+	// +kubebuilder:validation:MinLength=1
+	Branch string `json:"branch"`
+
+	// RestoredHydratedSha is the commit on the active branch that the environment ran before spec.sha was promoted to
+	// it. It is empty if spec.sha was never promoted to the environment.
+	// +optional
+	RestoredHydratedSha string `json:"restoredHydratedSha,omitempty"`
+
+	// RestoredDrySha is the dry commit that RestoredHydratedSha was hydrated from.
+	// +optional
+	RestoredDrySha string `json:"restoredDrySha,omitempty"`
+
+	// ProposedSha is the commit on the environment's proposed branch that restores the manifests of
+	// RestoredHydratedSha.
+	// +optional
+	ProposedSha string `json:"proposedSha,omitempty"`
+
+	// Reverted is true once the environment no longer runs spec.sha, either because the restored manifests were
+	// promoted or because spec.sha was never promoted to the environment.
+	// +optional
+	Reverted bool `json:"reverted,omitempty"`
 }
 
 // +kubebuilder:ac:generate=true
@@ -43,6 +234,12 @@ type RevertCommitStatus struct {
 //+kubebuilder:subresource:status
 
 // RevertCommit is the Schema for the revertcommits API
+// +kubebuilder:printcolumn:name="Sha",type=string,JSONPath=`.status.shortSha`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="PR",type=string,JSONPath=`.status.pullRequest.id`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.status.url`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type RevertCommit struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -60,6 +257,16 @@ type RevertCommitList struct {
 	Items           []RevertCommit `json:"items"`
 }
 
+// GetConditions returns the conditions of the RevertCommit.
+func (rc *RevertCommit) GetConditions() *[]metav1.Condition {
+	return &rc.Status.Conditions
+}
+
+// SetObservedGeneration records the object generation that produced the current status.
+func (rc *RevertCommit) SetObservedGeneration(generation int64) {
+	rc.Status.ObservedGeneration = generation
+}
+
 func init() {
 	SchemeBuilder.Register(&RevertCommit{}, &RevertCommitList{})
 }
@@ -59,6 +59,11 @@ type ScmProviderSpec struct {
 
 	// Fake required configuration for Fake as the SCM provider
 	Fake *Fake `json:"fake,omitempty"`
+
+	// GitIdentity overrides the controller's git identity for the commits the promoter creates in repositories that use
+	// this provider. A GitRepository's git identity takes precedence.
+	// +optional
+	GitIdentity *GitIdentity `json:"gitIdentity,omitempty"`
 }
 
 // ScmProviderStatus defines the observed state of ScmProvider
@@ -27,6 +27,31 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdaptivePollingConfiguration) DeepCopyInto(out *AdaptivePollingConfiguration) {
+	*out = *in
+	if in.MaxRequeueDuration != nil {
+		in, out := &in.MaxRequeueDuration, &out.MaxRequeueDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.WebhookFreshness != nil {
+		in, out := &in.WebhookFreshness, &out.WebhookFreshness
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdaptivePollingConfiguration.
+func (in *AdaptivePollingConfiguration) DeepCopy() *AdaptivePollingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AdaptivePollingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationsSelected) DeepCopyInto(out *ApplicationsSelected) {
 	*out = *in
@@ -172,6 +197,42 @@ func (in *ArgoCDCommitStatusStatus) DeepCopy() *ArgoCDCommitStatusStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoRevert) DeepCopyInto(out *AutoRevert) {
+	*out = *in
+	out.Within = in.Within
+	out.Debounce = in.Debounce
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoRevert.
+func (in *AutoRevert) DeepCopy() *AutoRevert {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoRevert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoRevertStatus) DeepCopyInto(out *AutoRevertStatus) {
+	*out = *in
+	if in.FailingSince != nil {
+		in, out := &in.FailingSince, &out.FailingSince
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoRevertStatus.
+func (in *AutoRevertStatus) DeepCopy() *AutoRevertStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoRevertStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureDevOps) DeepCopyInto(out *AzureDevOps) {
 	*out = *in
@@ -202,6 +263,21 @@ func (in *AzureDevOpsRepo) DeepCopy() *AzureDevOpsRepo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureDevOpsRepositoryStatus) DeepCopyInto(out *AzureDevOpsRepositoryStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDevOpsRepositoryStatus.
+func (in *AzureDevOpsRepositoryStatus) DeepCopy() *AzureDevOpsRepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureDevOpsRepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
@@ -333,6 +409,21 @@ func (in *ChangeTransferPolicy) DeepCopyObject() runtime.Object {
 func (in *ChangeTransferPolicyConfiguration) DeepCopyInto(out *ChangeTransferPolicyConfiguration) {
 	*out = *in
 	in.WorkQueue.DeepCopyInto(&out.WorkQueue)
+	if in.CloneDepth != nil {
+		in, out := &in.CloneDepth, &out.CloneDepth
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CloneIdleTimeout != nil {
+		in, out := &in.CloneIdleTimeout, &out.CloneIdleTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinReconcileInterval != nil {
+		in, out := &in.MinReconcileInterval, &out.MinReconcileInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangeTransferPolicyConfiguration.
@@ -396,6 +487,21 @@ func (in *ChangeTransferPolicySpec) DeepCopyInto(out *ChangeTransferPolicySpec)
 		*out = make([]CommitStatusSelector, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileInterval != nil {
+		in, out := &in.ReconcileInterval, &out.ReconcileInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PropagateLabels != nil {
+		in, out := &in.PropagateLabels, &out.PropagateLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(PullRequestTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangeTransferPolicySpec.
@@ -418,6 +524,16 @@ func (in *ChangeTransferPolicyStatus) DeepCopyInto(out *ChangeTransferPolicyStat
 		*out = new(PullRequestCommonStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LastLsRemote != nil {
+		in, out := &in.LastLsRemote, &out.LastLsRemote
+		*out = new(LsRemoteState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Polling != nil {
+		in, out := &in.Polling, &out.Polling
+		*out = new(PollingStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.History != nil {
 		in, out := &in.History, &out.History
 		*out = make([]History, len(*in))
@@ -608,6 +724,22 @@ func (in *CommitShaState) DeepCopy() *CommitShaState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommitSigning) DeepCopyInto(out *CommitSigning) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommitSigning.
+func (in *CommitSigning) DeepCopy() *CommitSigning {
+	if in == nil {
+		return nil
+	}
+	out := new(CommitSigning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommitStatus) DeepCopyInto(out *CommitStatus) {
 	*out = *in
@@ -806,6 +938,9 @@ func (in *ControllerConfigurationSpec) DeepCopyInto(out *ControllerConfiguration
 	in.TimedCommitStatus.DeepCopyInto(&out.TimedCommitStatus)
 	in.GitCommitStatus.DeepCopyInto(&out.GitCommitStatus)
 	in.WebRequestCommitStatus.DeepCopyInto(&out.WebRequestCommitStatus)
+	in.GitRepository.DeepCopyInto(&out.GitRepository)
+	in.ScmProvider.DeepCopyInto(&out.ScmProvider)
+	in.AdaptivePolling.DeepCopyInto(&out.AdaptivePolling)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerConfigurationSpec.
@@ -833,6 +968,21 @@ func (in *ControllerConfigurationStatus) DeepCopy() *ControllerConfigurationStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmergencyRevertStatus) DeepCopyInto(out *EmergencyRevertStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmergencyRevertStatus.
+func (in *EmergencyRevertStatus) DeepCopy() *EmergencyRevertStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EmergencyRevertStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Environment) DeepCopyInto(out *Environment) {
 	*out = *in
@@ -841,6 +991,11 @@ func (in *Environment) DeepCopyInto(out *Environment) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(PullRequestTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ActiveCommitStatuses != nil {
 		in, out := &in.ActiveCommitStatuses, &out.ActiveCommitStatuses
 		*out = make([]CommitStatusSelector, len(*in))
@@ -851,6 +1006,16 @@ func (in *Environment) DeepCopyInto(out *Environment) {
 		*out = make([]CommitStatusSelector, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileInterval != nil {
+		in, out := &in.ReconcileInterval, &out.ReconcileInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AutoRevert != nil {
+		in, out := &in.AutoRevert, &out.AutoRevert
+		*out = new(AutoRevert)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Environment.
@@ -887,6 +1052,16 @@ func (in *EnvironmentStatus) DeepCopyInto(out *EnvironmentStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AutoRevert != nil {
+		in, out := &in.AutoRevert, &out.AutoRevert
+		*out = new(AutoRevertStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmergencyRevert != nil {
+		in, out := &in.EmergencyRevert, &out.EmergencyRevert
+		*out = new(EmergencyRevertStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentStatus.
@@ -1163,6 +1338,21 @@ func (in *GitHubRepo) DeepCopy() *GitHubRepo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitIdentity) DeepCopyInto(out *GitIdentity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitIdentity.
+func (in *GitIdentity) DeepCopy() *GitIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(GitIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitLab) DeepCopyInto(out *GitLab) {
 	*out = *in
@@ -1193,6 +1383,21 @@ func (in *GitLabRepo) DeepCopy() *GitLabRepo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLabRepositoryStatus) DeepCopyInto(out *GitLabRepositoryStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabRepositoryStatus.
+func (in *GitLabRepositoryStatus) DeepCopy() *GitLabRepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLabRepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitRepository) DeepCopyInto(out *GitRepository) {
 	*out = *in
@@ -1220,6 +1425,26 @@ func (in *GitRepository) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitRepositoryConfiguration) DeepCopyInto(out *GitRepositoryConfiguration) {
+	*out = *in
+	if in.AccessCheckInterval != nil {
+		in, out := &in.AccessCheckInterval, &out.AccessCheckInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitRepositoryConfiguration.
+func (in *GitRepositoryConfiguration) DeepCopy() *GitRepositoryConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(GitRepositoryConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitRepositoryList) DeepCopyInto(out *GitRepositoryList) {
 	*out = *in
@@ -1291,6 +1516,36 @@ func (in *GitRepositorySpec) DeepCopyInto(out *GitRepositorySpec) {
 		**out = **in
 	}
 	out.ScmProviderRef = in.ScmProviderRef
+	if in.CloneDepth != nil {
+		in, out := &in.CloneDepth, &out.CloneDepth
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CommitSigning != nil {
+		in, out := &in.CommitSigning, &out.CommitSigning
+		*out = new(CommitSigning)
+		**out = **in
+	}
+	if in.GitIdentity != nil {
+		in, out := &in.GitIdentity, &out.GitIdentity
+		*out = new(GitIdentity)
+		**out = **in
+	}
+	if in.SparseCheckout != nil {
+		in, out := &in.SparseCheckout, &out.SparseCheckout
+		*out = new(SparseCheckout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ManageWebhooks != nil {
+		in, out := &in.ManageWebhooks, &out.ManageWebhooks
+		*out = new(ManageWebhooks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PropagateLabels != nil {
+		in, out := &in.PropagateLabels, &out.PropagateLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitRepositorySpec.
@@ -1313,6 +1568,20 @@ func (in *GitRepositoryStatus) DeepCopyInto(out *GitRepositoryStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.GitLab != nil {
+		in, out := &in.GitLab, &out.GitLab
+		*out = new(GitLabRepositoryStatus)
+		**out = **in
+	}
+	if in.AzureDevOps != nil {
+		in, out := &in.AzureDevOps, &out.AzureDevOps
+		*out = new(AzureDevOpsRepositoryStatus)
+		**out = **in
+	}
+	if in.LastWebhookDelivery != nil {
+		in, out := &in.LastWebhookDelivery, &out.LastWebhookDelivery
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitRepositoryStatus.
@@ -1449,6 +1718,11 @@ func (in *History) DeepCopyInto(out *History) {
 		*out = new(PullRequestCommonStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RevertCommitRef != nil {
+		in, out := &in.RevertCommitRef, &out.RevertCommitRef
+		*out = new(ObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new History.
@@ -1484,6 +1758,47 @@ func (in *HydratorMetadata) DeepCopy() *HydratorMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LsRemoteState) DeepCopyInto(out *LsRemoteState) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LsRemoteState.
+func (in *LsRemoteState) DeepCopy() *LsRemoteState {
+	if in == nil {
+		return nil
+	}
+	out := new(LsRemoteState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManageWebhooks) DeepCopyInto(out *ManageWebhooks) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]WebhookEvent, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageWebhooks.
+func (in *ManageWebhooks) DeepCopy() *ManageWebhooks {
+	if in == nil {
+		return nil
+	}
+	out := new(ManageWebhooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModeSpec) DeepCopyInto(out *ModeSpec) {
 	*out = *in
@@ -1580,6 +1895,26 @@ func (in *PollingModeSpec) DeepCopy() *PollingModeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PollingStatus) DeepCopyInto(out *PollingStatus) {
+	*out = *in
+	out.RequeueInterval = in.RequeueInterval
+	if in.LastWebhookDelivery != nil {
+		in, out := &in.LastWebhookDelivery, &out.LastWebhookDelivery
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PollingStatus.
+func (in *PollingStatus) DeepCopy() *PollingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PollingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PromotionStrategy) DeepCopyInto(out *PromotionStrategy) {
 	*out = *in
@@ -1669,6 +2004,11 @@ func (in *PromotionStrategySpec) DeepCopyInto(out *PromotionStrategySpec) {
 		*out = make([]CommitStatusSelector, len(*in))
 		copy(*out, *in)
 	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(PullRequestTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Environments != nil {
 		in, out := &in.Environments, &out.Environments
 		*out = make([]Environment, len(*in))
@@ -1676,6 +2016,11 @@ func (in *PromotionStrategySpec) DeepCopyInto(out *PromotionStrategySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PropagateLabels != nil {
+		in, out := &in.PropagateLabels, &out.PropagateLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionStrategySpec.
@@ -1698,6 +2043,11 @@ func (in *PromotionStrategyStatus) DeepCopyInto(out *PromotionStrategyStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Polling != nil {
+		in, out := &in.Polling, &out.Polling
+		*out = new(PollingStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -1722,7 +2072,7 @@ func (in *PullRequest) DeepCopyInto(out *PullRequest) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -1820,6 +2170,16 @@ func (in *PullRequestSpec) DeepCopyInto(out *PullRequestSpec) {
 	*out = *in
 	out.RepositoryReference = in.RepositoryReference
 	out.Commit = in.Commit
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Reviewers != nil {
+		in, out := &in.Reviewers, &out.Reviewers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestSpec.
@@ -1841,6 +2201,16 @@ func (in *PullRequestStatus) DeepCopyInto(out *PullRequestStatus) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Reviewers != nil {
+		in, out := &in.Reviewers, &out.Reviewers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -1875,6 +2245,36 @@ func (in *PullRequestTemplate) DeepCopy() *PullRequestTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullRequestTemplateSpec) DeepCopyInto(out *PullRequestTemplateSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Reviewers != nil {
+		in, out := &in.Reviewers, &out.Reviewers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Draft != nil {
+		in, out := &in.Draft, &out.Draft
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestTemplateSpec.
+func (in *PullRequestTemplateSpec) DeepCopy() *PullRequestTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PullRequestTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RateLimiter) DeepCopyInto(out *RateLimiter) {
 	*out = *in
@@ -1949,8 +2349,8 @@ func (in *RevertCommit) DeepCopyInto(out *RevertCommit) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevertCommit.
@@ -1971,6 +2371,21 @@ func (in *RevertCommit) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevertCommitEnvironmentStatus) DeepCopyInto(out *RevertCommitEnvironmentStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevertCommitEnvironmentStatus.
+func (in *RevertCommitEnvironmentStatus) DeepCopy() *RevertCommitEnvironmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RevertCommitEnvironmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RevertCommitList) DeepCopyInto(out *RevertCommitList) {
 	*out = *in
@@ -2006,6 +2421,19 @@ func (in *RevertCommitList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RevertCommitSpec) DeepCopyInto(out *RevertCommitSpec) {
 	*out = *in
+	out.PromotionStrategyRef = in.PromotionStrategyRef
+	if in.Environments != nil {
+		in, out := &in.Environments, &out.Environments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TemplateFields != nil {
+		in, out := &in.TemplateFields, &out.TemplateFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevertCommitSpec.
@@ -2021,6 +2449,23 @@ func (in *RevertCommitSpec) DeepCopy() *RevertCommitSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RevertCommitStatus) DeepCopyInto(out *RevertCommitStatus) {
 	*out = *in
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(PullRequestCommonStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Environments != nil {
+		in, out := &in.Environments, &out.Environments
+		*out = make([]RevertCommitEnvironmentStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevertCommitStatus.
@@ -2095,6 +2540,26 @@ func (in *ScmProvider) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScmProviderConfiguration) DeepCopyInto(out *ScmProviderConfiguration) {
+	*out = *in
+	if in.CredentialsCheckInterval != nil {
+		in, out := &in.CredentialsCheckInterval, &out.CredentialsCheckInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScmProviderConfiguration.
+func (in *ScmProviderConfiguration) DeepCopy() *ScmProviderConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ScmProviderConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScmProviderList) DeepCopyInto(out *ScmProviderList) {
 	*out = *in
@@ -2185,6 +2650,11 @@ func (in *ScmProviderSpec) DeepCopyInto(out *ScmProviderSpec) {
 		*out = new(Fake)
 		**out = **in
 	}
+	if in.GitIdentity != nil {
+		in, out := &in.GitIdentity, &out.GitIdentity
+		*out = new(GitIdentity)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScmProviderSpec.
@@ -2219,6 +2689,26 @@ func (in *ScmProviderStatus) DeepCopy() *ScmProviderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SparseCheckout) DeepCopyInto(out *SparseCheckout) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SparseCheckout.
+func (in *SparseCheckout) DeepCopy() *SparseCheckout {
+	if in == nil {
+		return nil
+	}
+	out := new(SparseCheckout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SuccessSpec) DeepCopyInto(out *SuccessSpec) {
 	*out = *in
@@ -0,0 +1,102 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+var _ conversion.Convertible = &ChangeTransferPolicy{}
+
+// ConvertTo converts the ChangeTransferPolicy to the hub version, v1alpha1. Every field has a v1alpha1 counterpart,
+// so no data is lost.
+func (ctp *ChangeTransferPolicy) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1alpha1.ChangeTransferPolicy)
+	if !ok {
+		return fmt.Errorf("cannot convert ChangeTransferPolicy to %T", hub)
+	}
+
+	dst.ObjectMeta = ctp.ObjectMeta
+	dst.Spec = v1alpha1.ChangeTransferPolicySpec{
+		RepositoryReference:    v1alpha1.ObjectReference{Name: ctp.Spec.GitRepositoryRef.Name},
+		ProposedBranch:         ctp.Spec.ProposedBranch,
+		ActiveBranch:           ctp.Spec.ActiveBranch,
+		AutoMerge:              ctp.Spec.AutoMerge,
+		ActiveCommitStatuses:   convertSlice(ctp.Spec.PostMergeCommitStatuses, commitStatusSelectorToHub),
+		ProposedCommitStatuses: convertSlice(ctp.Spec.PreMergeCommitStatuses, commitStatusSelectorToHub),
+		ReconcileInterval:      ctp.Spec.ReconcileInterval,
+		ResolveDivergence:      v1alpha1.DivergenceResolution(ctp.Spec.ResolveDivergence),
+		PropagateLabels:        ctp.Spec.PropagateLabels,
+		PullRequest:            convertPointer(ctp.Spec.PullRequest, pullRequestTemplateToHub),
+	}
+	dst.Status = v1alpha1.ChangeTransferPolicyStatus{
+		ObservedGeneration:        ctp.Status.ObservedGeneration,
+		Proposed:                  commitBranchStateToHub(ctp.Status.Proposed),
+		Active:                    commitBranchStateToHub(ctp.Status.Active),
+		PullRequest:               convertPointer(ctp.Status.PullRequest, pullRequestCommonStatusToHub),
+		ActiveDryShortSha:         ctp.Status.ActiveDryShortSha,
+		ProposedDryShortSha:       ctp.Status.ProposedDryShortSha,
+		ProposedDryShaSuperseded:  ctp.Status.ProposedDryShaSuperseded,
+		EffectivelyPromotedDrySha: ctp.Status.EffectivelyPromotedDrySha,
+		LastLsRemote:              (*v1alpha1.LsRemoteState)(ctp.Status.LastLsRemote),
+		Polling:                   (*v1alpha1.PollingStatus)(ctp.Status.Polling),
+		History:                   convertSlice(ctp.Status.History, historyToHub),
+		Conditions:                ctp.Status.Conditions,
+	}
+	return nil
+}
+
+// ConvertFrom converts the hub version, v1alpha1, to the ChangeTransferPolicy.
+func (ctp *ChangeTransferPolicy) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha1.ChangeTransferPolicy)
+	if !ok {
+		return fmt.Errorf("cannot convert %T to ChangeTransferPolicy", hub)
+	}
+
+	ctp.ObjectMeta = src.ObjectMeta
+	ctp.Spec = ChangeTransferPolicySpec{
+		GitRepositoryRef:        ObjectReference{Name: src.Spec.RepositoryReference.Name},
+		ProposedBranch:          src.Spec.ProposedBranch,
+		ActiveBranch:            src.Spec.ActiveBranch,
+		AutoMerge:               src.Spec.AutoMerge,
+		PreMergeCommitStatuses:  convertSlice(src.Spec.ProposedCommitStatuses, commitStatusSelectorFromHub),
+		PostMergeCommitStatuses: convertSlice(src.Spec.ActiveCommitStatuses, commitStatusSelectorFromHub),
+		ReconcileInterval:       src.Spec.ReconcileInterval,
+		ResolveDivergence:       DivergenceResolution(src.Spec.ResolveDivergence),
+		PropagateLabels:         src.Spec.PropagateLabels,
+		PullRequest:             convertPointer(src.Spec.PullRequest, pullRequestTemplateFromHub),
+	}
+	ctp.Status = ChangeTransferPolicyStatus{
+		ObservedGeneration:        src.Status.ObservedGeneration,
+		Proposed:                  commitBranchStateFromHub(src.Status.Proposed),
+		Active:                    commitBranchStateFromHub(src.Status.Active),
+		PullRequest:               convertPointer(src.Status.PullRequest, pullRequestCommonStatusFromHub),
+		ActiveDryShortSha:         src.Status.ActiveDryShortSha,
+		ProposedDryShortSha:       src.Status.ProposedDryShortSha,
+		ProposedDryShaSuperseded:  src.Status.ProposedDryShaSuperseded,
+		EffectivelyPromotedDrySha: src.Status.EffectivelyPromotedDrySha,
+		LastLsRemote:              (*LsRemoteState)(src.Status.LastLsRemote),
+		Polling:                   (*PollingStatus)(src.Status.Polling),
+		History:                   convertSlice(src.Status.History, historyFromHub),
+		Conditions:                src.Status.Conditions,
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2_test
+
+import (
+	"testing"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"sigs.k8s.io/randfill"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha2"
+)
+
+// FuzzChangeTransferPolicyConversion checks that ChangeTransferPolicies survive a round trip through the other version
+// unchanged, in both directions. The seed corpus runs with go test; run go test -fuzz to explore more seeds.
+func FuzzChangeTransferPolicyConversion(f *testing.F) {
+	for seed := range int64(50) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, seed int64) {
+		filler := randfill.NewWithSeed(seed).NilChance(0.2)
+
+		spoke := &v1alpha2.ChangeTransferPolicy{}
+		filler.Fill(spoke)
+		spoke.TypeMeta = metav1.TypeMeta{}
+		hub := &v1alpha1.ChangeTransferPolicy{}
+		if err := spoke.ConvertTo(hub); err != nil {
+			t.Fatalf("converting v1alpha2 to v1alpha1: %v", err)
+		}
+		spokeRoundTrip := &v1alpha2.ChangeTransferPolicy{}
+		if err := spokeRoundTrip.ConvertFrom(hub); err != nil {
+			t.Fatalf("converting v1alpha1 to v1alpha2: %v", err)
+		}
+		if !apiequality.Semantic.DeepEqual(spoke, spokeRoundTrip) {
+			t.Fatalf("v1alpha2 round trip changed the ChangeTransferPolicy:\n%s", diff.Diff(spoke, spokeRoundTrip))
+		}
+
+		hub = &v1alpha1.ChangeTransferPolicy{}
+		filler.Fill(hub)
+		hub.TypeMeta = metav1.TypeMeta{}
+		spoke = &v1alpha2.ChangeTransferPolicy{}
+		if err := spoke.ConvertFrom(hub); err != nil {
+			t.Fatalf("converting v1alpha1 to v1alpha2: %v", err)
+		}
+		hubRoundTrip := &v1alpha1.ChangeTransferPolicy{}
+		if err := spoke.ConvertTo(hubRoundTrip); err != nil {
+			t.Fatalf("converting v1alpha2 to v1alpha1: %v", err)
+		}
+		if !apiequality.Semantic.DeepEqual(hub, hubRoundTrip) {
+			t.Fatalf("v1alpha1 round trip changed the ChangeTransferPolicy:\n%s", diff.Diff(hub, hubRoundTrip))
+		}
+	})
+}
+
+func TestChangeTransferPolicyConversionRenamesCommitStatuses(t *testing.T) {
+	t.Parallel()
+
+	hub := &v1alpha1.ChangeTransferPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "ctp", Namespace: "default"},
+		Spec: v1alpha1.ChangeTransferPolicySpec{
+			ActiveCommitStatuses:   []v1alpha1.CommitStatusSelector{{Key: "health"}},
+			ProposedCommitStatuses: []v1alpha1.CommitStatusSelector{{Key: "ci"}},
+		},
+		Status: v1alpha1.ChangeTransferPolicyStatus{
+			Proposed: v1alpha1.CommitBranchState{CommitStatuses: []v1alpha1.ChangeRequestPolicyCommitStatusPhase{
+				{Key: "ci", Phase: string(v1alpha1.CommitPhaseSuccess), Url: "https://example.com/ci/1"},
+			}},
+		},
+	}
+	spoke := &v1alpha2.ChangeTransferPolicy{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("converting v1alpha1 to v1alpha2: %v", err)
+	}
+	if len(spoke.Spec.PreMergeCommitStatuses) != 1 || spoke.Spec.PreMergeCommitStatuses[0].Key != "ci" {
+		t.Fatalf("expected the proposed commit statuses to be the pre-merge ones, got %+v", spoke.Spec.PreMergeCommitStatuses)
+	}
+	if len(spoke.Spec.PostMergeCommitStatuses) != 1 || spoke.Spec.PostMergeCommitStatuses[0].Key != "health" {
+		t.Fatalf("expected the active commit statuses to be the post-merge ones, got %+v", spoke.Spec.PostMergeCommitStatuses)
+	}
+	expected := v1alpha2.CommitStatusState{Key: "ci", Phase: v1alpha2.CommitPhaseSuccess, URL: "https://example.com/ci/1"}
+	if len(spoke.Status.Proposed.CommitStatuses) != 1 || spoke.Status.Proposed.CommitStatuses[0] != expected {
+		t.Fatalf("expected the proposed commit status %+v, got %+v", expected, spoke.Status.Proposed.CommitStatuses)
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ChangeTransferPolicySpec defines the desired state of ChangeTransferPolicy.
+type ChangeTransferPolicySpec struct {
+	// GitRepositoryRef is the repository the branches are in and the pull requests are opened on.
+	// +kubebuilder:validation:Required
+	GitRepositoryRef ObjectReference `json:"gitRepositoryRef"`
+
+	// ProposedBranch is the branch the hydrator writes proposed changes to.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ProposedBranch string `json:"proposedBranch"`
+
+	// ActiveBranch is the branch the proposed changes are merged into.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ActiveBranch string `json:"activeBranch"`
+
+	// AutoMerge merges the pull request once the pre-merge commit statuses pass.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=true
+	AutoMerge *bool `json:"autoMerge,omitempty"`
+
+	// PreMergeCommitStatuses are the commit statuses the proposed hydrated commit has to pass before it is merged.
+	// +kubebuilder:validation:Optional
+	// +listType:=map
+	// +listMapKey=key
+	PreMergeCommitStatuses []CommitStatusSelector `json:"preMergeCommitStatuses,omitempty"`
+
+	// PostMergeCommitStatuses are the commit statuses of the active hydrated commit, once it was merged.
+	// +kubebuilder:validation:Optional
+	// +listType:=map
+	// +listMapKey=key
+	PostMergeCommitStatuses []CommitStatusSelector `json:"postMergeCommitStatuses,omitempty"`
+
+	// ReconcileInterval overrides the ControllerConfiguration's ChangeTransferPolicy requeue duration for this
+	// ChangeTransferPolicy. Values below the ControllerConfiguration's minReconcileInterval are raised to the minimum.
+	// +kubebuilder:validation:Optional
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+
+	// ResolveDivergence is what the controller does when the proposed branch diverged from the commits it previously
+	// saw, either reset or manual. If it is not set, the divergence is only reported on the ProposedBranchDiverged
+	// condition.
+	// +kubebuilder:validation:Optional
+	ResolveDivergence DivergenceResolution `json:"resolveDivergence,omitempty"`
+
+	// PropagateLabels lists the keys of the labels and annotations of this ChangeTransferPolicy that are copied to its
+	// PullRequests and CommitStatuses, and kept in sync with it.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	PropagateLabels []string `json:"propagateLabels,omitempty"`
+
+	// PullRequest configures the pull requests of this ChangeTransferPolicy.
+	// +kubebuilder:validation:Optional
+	PullRequest *PullRequestTemplateSpec `json:"pullRequest,omitempty"`
+}
+
+// ChangeTransferPolicyStatus defines the observed state of ChangeTransferPolicy.
+type ChangeTransferPolicyStatus struct {
+	// ObservedGeneration is the .metadata.generation that this status was reconciled from.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Proposed is the state of the proposed branch.
+	Proposed CommitBranchState `json:"proposed,omitempty"`
+	// Active is the state of the active branch.
+	Active CommitBranchState `json:"active,omitempty"`
+	// PullRequest is the state of the pull request of this ChangeTransferPolicy.
+	PullRequest *PullRequestCommonStatus `json:"pullRequest,omitempty"`
+
+	// ActiveDryShortSha is the abbreviated active.dry.sha, for display.
+	// +optional
+	ActiveDryShortSha string `json:"activeDryShortSha,omitempty"`
+	// ProposedDryShortSha is the abbreviated proposed.dry.sha, for display.
+	// +optional
+	ProposedDryShortSha string `json:"proposedDryShortSha,omitempty"`
+
+	// ProposedDryShaSuperseded is true when the proposed dry commit was removed or reverted upstream. No pull request
+	// is opened for it while it is true.
+	// +optional
+	ProposedDryShaSuperseded bool `json:"proposedDryShaSuperseded,omitempty"`
+
+	// EffectivelyPromotedDrySha is the proposed dry sha when merging it would not change the active branch's
+	// hydrated manifests, so it counts as promoted without a pull request.
+	// +optional
+	EffectivelyPromotedDrySha string `json:"effectivelyPromotedDrySha,omitempty"`
+
+	// LastLsRemote is the result of the last ls-remote of the branches.
+	// +optional
+	LastLsRemote *LsRemoteState `json:"lastLsRemote,omitempty"`
+
+	// Polling shows how often the ChangeTransferPolicy is polled and whether webhooks are delivered for its repository.
+	// +optional
+	Polling *PollingStatus `json:"polling,omitempty"`
+
+	// History is the last promotions merged by the promoter, newest first. It is informational only.
+	History []History `json:"history,omitempty"`
+
+	// Conditions Represents the observations of the current state.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// LsRemoteState is the result of an ls-remote of the refs a ChangeTransferPolicy depends on.
+type LsRemoteState struct {
+	// Time is when the ls-remote ran.
+	Time metav1.Time `json:"time"`
+	// ActiveSha is the SHA the active branch pointed at.
+	// +optional
+	ActiveSha string `json:"activeSha,omitempty"`
+	// ProposedSha is the SHA the proposed branch pointed at.
+	// +optional
+	ProposedSha string `json:"proposedSha,omitempty"`
+	// NotesSha is the SHA the hydrator notes ref pointed at. It is empty if the ref does not exist.
+	// +optional
+	NotesSha string `json:"notesSha,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:unservedversion
+
+// ChangeTransferPolicy is the Schema for the changetransferpolicies API. It is served only when the conversion webhook
+// is enabled.
+// +kubebuilder:printcolumn:name="Active",type=string,JSONPath=`.spec.activeBranch`,priority=1
+// +kubebuilder:printcolumn:name="Active Dry Sha",type=string,JSONPath=`.status.activeDryShortSha`
+// +kubebuilder:printcolumn:name="Proposed Dry Sha",type=string,JSONPath=`.status.proposedDryShortSha`
+// +kubebuilder:printcolumn:name="PR State",type=string,JSONPath=`.status.pullRequest.state`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+type ChangeTransferPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ChangeTransferPolicySpec   `json:"spec,omitempty"`
+	Status ChangeTransferPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ChangeTransferPolicyList contains a list of ChangeTransferPolicy
+type ChangeTransferPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ChangeTransferPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ChangeTransferPolicy{}, &ChangeTransferPolicyList{})
+}
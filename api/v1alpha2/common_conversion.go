@@ -0,0 +1,222 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+// The types below are shared by ChangeTransferPolicies and PromotionStrategies. Each has a function converting it to
+// the hub version, v1alpha1, and one converting it back. Nil slices and pointers stay nil, so that round trips don't
+// change the objects.
+
+// convertSlice converts each element of a slice, keeping a nil slice nil.
+func convertSlice[S, D any](in []S, convert func(S) D) []D {
+	if in == nil {
+		return nil
+	}
+	out := make([]D, len(in))
+	for i := range in {
+		out[i] = convert(in[i])
+	}
+	return out
+}
+
+// convertPointer converts the value a pointer points at, keeping a nil pointer nil.
+func convertPointer[S, D any](in *S, convert func(S) D) *D {
+	if in == nil {
+		return nil
+	}
+	out := convert(*in)
+	return &out
+}
+
+func commitStatusSelectorToHub(in CommitStatusSelector) v1alpha1.CommitStatusSelector {
+	return v1alpha1.CommitStatusSelector{Key: in.Key}
+}
+
+func commitStatusSelectorFromHub(in v1alpha1.CommitStatusSelector) CommitStatusSelector {
+	return CommitStatusSelector{Key: in.Key}
+}
+
+func commitStatusStateToHub(in CommitStatusState) v1alpha1.ChangeRequestPolicyCommitStatusPhase {
+	return v1alpha1.ChangeRequestPolicyCommitStatusPhase{
+		Key:         in.Key,
+		Phase:       string(in.Phase),
+		Url:         in.URL,
+		Description: in.Description,
+	}
+}
+
+func commitStatusStateFromHub(in v1alpha1.ChangeRequestPolicyCommitStatusPhase) CommitStatusState {
+	return CommitStatusState{
+		Key:         in.Key,
+		Phase:       CommitStatusPhase(in.Phase),
+		URL:         in.Url,
+		Description: in.Description,
+	}
+}
+
+func commitBranchStateToHub(in CommitBranchState) v1alpha1.CommitBranchState {
+	return v1alpha1.CommitBranchState{
+		Dry:            commitShaStateToHub(in.Dry),
+		Hydrated:       commitShaStateToHub(in.Hydrated),
+		Note:           convertPointer(in.Note, hydratorMetadataToHub),
+		CommitStatuses: convertSlice(in.CommitStatuses, commitStatusStateToHub),
+	}
+}
+
+func commitBranchStateFromHub(in v1alpha1.CommitBranchState) CommitBranchState {
+	return CommitBranchState{
+		Dry:            commitShaStateFromHub(in.Dry),
+		Hydrated:       commitShaStateFromHub(in.Hydrated),
+		Note:           convertPointer(in.Note, hydratorMetadataFromHub),
+		CommitStatuses: convertSlice(in.CommitStatuses, commitStatusStateFromHub),
+	}
+}
+
+func hydratorMetadataToHub(in HydratorMetadata) v1alpha1.HydratorMetadata {
+	return v1alpha1.HydratorMetadata{
+		RepoURL:    in.RepoURL,
+		DrySha:     in.DrySha,
+		Author:     in.Author,
+		Date:       in.Date,
+		Subject:    in.Subject,
+		Body:       in.Body,
+		References: convertSlice(in.References, revisionReferenceToHub),
+	}
+}
+
+func hydratorMetadataFromHub(in v1alpha1.HydratorMetadata) HydratorMetadata {
+	return HydratorMetadata{
+		RepoURL:    in.RepoURL,
+		DrySha:     in.DrySha,
+		Author:     in.Author,
+		Date:       in.Date,
+		Subject:    in.Subject,
+		Body:       in.Body,
+		References: convertSlice(in.References, revisionReferenceFromHub),
+	}
+}
+
+func commitShaStateToHub(in CommitShaState) v1alpha1.CommitShaState {
+	return v1alpha1.CommitShaState{
+		Sha:        in.Sha,
+		CommitTime: in.CommitTime,
+		RepoURL:    in.RepoURL,
+		Author:     in.Author,
+		Subject:    in.Subject,
+		Body:       in.Body,
+		References: convertSlice(in.References, revisionReferenceToHub),
+	}
+}
+
+func commitShaStateFromHub(in v1alpha1.CommitShaState) CommitShaState {
+	return CommitShaState{
+		Sha:        in.Sha,
+		CommitTime: in.CommitTime,
+		RepoURL:    in.RepoURL,
+		Author:     in.Author,
+		Subject:    in.Subject,
+		Body:       in.Body,
+		References: convertSlice(in.References, revisionReferenceFromHub),
+	}
+}
+
+func revisionReferenceToHub(in RevisionReference) v1alpha1.RevisionReference {
+	return v1alpha1.RevisionReference{Commit: (*v1alpha1.CommitMetadata)(in.Commit)}
+}
+
+func revisionReferenceFromHub(in v1alpha1.RevisionReference) RevisionReference {
+	return RevisionReference{Commit: (*CommitMetadata)(in.Commit)}
+}
+
+func pullRequestTemplateToHub(in PullRequestTemplateSpec) v1alpha1.PullRequestTemplateSpec {
+	return v1alpha1.PullRequestTemplateSpec{
+		TitlePrefix:   in.TitlePrefix,
+		Title:         in.Title,
+		Description:   in.Description,
+		Labels:        in.Labels,
+		Reviewers:     in.Reviewers,
+		MergeStrategy: v1alpha1.PullRequestMergeStrategy(in.MergeStrategy),
+		Draft:         in.Draft,
+	}
+}
+
+func pullRequestTemplateFromHub(in v1alpha1.PullRequestTemplateSpec) PullRequestTemplateSpec {
+	return PullRequestTemplateSpec{
+		TitlePrefix:   in.TitlePrefix,
+		Title:         in.Title,
+		Description:   in.Description,
+		Labels:        in.Labels,
+		Reviewers:     in.Reviewers,
+		MergeStrategy: PullRequestMergeStrategy(in.MergeStrategy),
+		Draft:         in.Draft,
+	}
+}
+
+func pullRequestCommonStatusToHub(in PullRequestCommonStatus) v1alpha1.PullRequestCommonStatus {
+	return v1alpha1.PullRequestCommonStatus{
+		ID:                       in.ID,
+		State:                    v1alpha1.PullRequestState(in.State),
+		PRCreationTime:           in.CreatedAt,
+		PRMergeTime:              in.MergedAt,
+		Url:                      in.URL,
+		ExternallyMergedOrClosed: in.ExternallyMergedOrClosed,
+	}
+}
+
+func pullRequestCommonStatusFromHub(in v1alpha1.PullRequestCommonStatus) PullRequestCommonStatus {
+	return PullRequestCommonStatus{
+		ID:                       in.ID,
+		State:                    PullRequestState(in.State),
+		CreatedAt:                in.PRCreationTime,
+		MergedAt:                 in.PRMergeTime,
+		URL:                      in.Url,
+		ExternallyMergedOrClosed: in.ExternallyMergedOrClosed,
+	}
+}
+
+func historyToHub(in History) v1alpha1.History {
+	return v1alpha1.History{
+		Proposed: v1alpha1.CommitBranchStateHistoryProposed{
+			Hydrated:       commitShaStateToHub(in.Proposed.Hydrated),
+			CommitStatuses: convertSlice(in.Proposed.CommitStatuses, commitStatusStateToHub),
+		},
+		Active:          commitBranchStateToHub(in.Active),
+		PullRequest:     convertPointer(in.PullRequest, pullRequestCommonStatusToHub),
+		AutoRevert:      in.AutoRevert,
+		Kind:            v1alpha1.HistoryKind(in.Kind),
+		RevertCommitRef: (*v1alpha1.ObjectReference)(in.RevertCommitRef),
+		RevertedSha:     in.RevertedSha,
+	}
+}
+
+func historyFromHub(in v1alpha1.History) History {
+	return History{
+		Proposed: HistoryProposedState{
+			Hydrated:       commitShaStateFromHub(in.Proposed.Hydrated),
+			CommitStatuses: convertSlice(in.Proposed.CommitStatuses, commitStatusStateFromHub),
+		},
+		Active:          commitBranchStateFromHub(in.Active),
+		PullRequest:     convertPointer(in.PullRequest, pullRequestCommonStatusFromHub),
+		AutoRevert:      in.AutoRevert,
+		Kind:            HistoryKind(in.Kind),
+		RevertCommitRef: (*ObjectReference)(in.RevertCommitRef),
+		RevertedSha:     in.RevertedSha,
+	}
+}
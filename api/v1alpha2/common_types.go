@@ -0,0 +1,278 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CommitStatusSelector is used to select commit statuses by their key.
+type CommitStatusSelector struct {
+	// Key is the key of the CommitStatuses to select.
+	// +required
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=63
+	// +kubebuilder:validation:Pattern:=([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]
+	Key string `json:"key"`
+}
+
+// CommitStatusPhase is the phase of a commit status.
+// +kubebuilder:validation:Enum=pending;success;failure
+type CommitStatusPhase string
+
+const (
+	// CommitPhaseFailure indicates that the commit status has failed.
+	CommitPhaseFailure CommitStatusPhase = "failure"
+	// CommitPhaseSuccess indicates that the commit status has been successfully completed.
+	CommitPhaseSuccess CommitStatusPhase = "success"
+	// CommitPhasePending indicates that the commit status is still being processed or has not yet been set.
+	CommitPhasePending CommitStatusPhase = "pending"
+)
+
+// CommitStatusState is the state of a commit status that a branch is gated on.
+type CommitStatusState struct {
+	// Key is the key of the commit status.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=63
+	// +kubebuilder:validation:Pattern:=([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]
+	Key string `json:"key"`
+
+	// Phase is the phase of the commit status.
+	// +kubebuilder:validation:Required
+	Phase CommitStatusPhase `json:"phase"`
+
+	// URL is the URL of the commit status.
+	// +kubebuilder:validation:XValidation:rule="self == '' || isURL(self)",message="must be a valid URL"
+	// +kubebuilder:validation:Pattern="^(https?://.*)?$"
+	URL string `json:"url,omitempty"`
+
+	// Description is the description of the commit status.
+	Description string `json:"description,omitempty"`
+}
+
+// CommitBranchState is the state of a branch.
+type CommitBranchState struct {
+	// Dry is the dry commit the branch was hydrated from.
+	Dry CommitShaState `json:"dry,omitempty"`
+	// Hydrated is the commit the branch points at.
+	Hydrated CommitShaState `json:"hydrated,omitempty"`
+	// Note is the hydrator metadata from the git note attached to the hydrated commit.
+	Note *HydratorMetadata `json:"note,omitempty"`
+	// CommitStatuses are the states of the commit statuses the hydrated commit is gated on.
+	// +kubebuilder:validation:Optional
+	// +listType:=map
+	// +listMapKey=key
+	CommitStatuses []CommitStatusState `json:"commitStatuses,omitempty"`
+}
+
+// HydratorMetadata contains metadata about the hydrated commit.
+// This is extracted from the git note or metadata file.
+type HydratorMetadata struct {
+	// RepoURL is the URL of the repository where the commit is located.
+	RepoURL string `json:"repoURL,omitempty"`
+	// DrySha is the SHA of the commit that was used as the dry source for hydration.
+	// Supports both SHA-1 (40 chars) and SHA-256 (64 chars) Git hash formats.
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^([a-f0-9]{40}|[a-f0-9]{64})$`
+	DrySha string `json:"drySha,omitempty"`
+	// Author is the author of the dry commit that was used to hydrate the branch.
+	Author string `json:"author,omitempty"`
+	// Date is the date of the dry commit that was used to hydrate the branch.
+	Date metav1.Time `json:"date,omitempty"`
+	// Subject is the subject line of the dry commit that was used to hydrate the branch.
+	Subject string `json:"subject,omitempty"`
+	// Body is the body of the dry commit that was used to hydrate the branch without the subject.
+	Body string `json:"body,omitempty"`
+	// References are the references to other commits, that went into the hydration of the branch.
+	References []RevisionReference `json:"references,omitempty"`
+}
+
+// CommitShaState is the state of a commit in a branch.
+type CommitShaState struct {
+	// Sha is the SHA of the commit in the branch
+	// Supports both SHA-1 (40 chars) and SHA-256 (64 chars) Git hash formats.
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^([a-f0-9]{40}|[a-f0-9]{64})$`
+	Sha string `json:"sha,omitempty"`
+	// CommitTime is the time the commit was made
+	CommitTime metav1.Time `json:"commitTime,omitempty"`
+	// RepoURL is the URL of the repository where the commit is located
+	// +kubebuilder:validation:XValidation:rule="self == '' || isURL(self)",message="must be a valid URL"
+	// +kubebuilder:validation:Pattern="^(https?://.*)?$"
+	RepoURL string `json:"repoURL,omitempty"`
+	// Author is the author of the commit
+	Author string `json:"author,omitempty"`
+	// Subject is the subject line of the commit message
+	Subject string `json:"subject,omitempty"`
+	// Body is the body of the commit message without the subject line. Bodies longer than 4096 characters are truncated.
+	Body string `json:"body,omitempty"`
+	// References are the references to other commits, that went into the hydration of the branch
+	References []RevisionReference `json:"references,omitempty"`
+}
+
+// RevisionReference contains a reference to a some information that is related in some way to another commit. For now,
+// it supports only references to a commit. In the future, it may support other types of references.
+type RevisionReference struct {
+	// Commit contains metadata about the commit that is related in some way to another commit.
+	Commit *CommitMetadata `json:"commit,omitempty"`
+}
+
+// CommitMetadata contains metadata about a commit that is related in some way to another commit.
+type CommitMetadata struct {
+	// Author is the author of the commit.
+	Author string `json:"author,omitempty"`
+	// Date is the date of the commit, formatted as by `git show -s --format=%aI`.
+	Date *metav1.Time `json:"date,omitempty"`
+	// Subject is the subject line of the commit message, i.e. `git show --format=%s`.
+	Subject string `json:"subject,omitempty"`
+	// Body is the body of the commit message, excluding the subject line, i.e. `git show --format=%b`.
+	Body string `json:"body,omitempty"`
+	// Sha is the commit hash.
+	// Supports both SHA-1 (40 chars) and SHA-256 (64 chars) Git hash formats.
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^([a-f0-9]{40}|[a-f0-9]{64})$`
+	Sha string `json:"sha,omitempty"`
+	// RepoURL is the URL of the repository where the commit is located.
+	// +kubebuilder:validation:XValidation:rule="self == '' || isURL(self)",message="must be a valid URL"
+	// +kubebuilder:validation:Pattern="^(https?://.*)?$"
+	RepoURL string `json:"repoURL,omitempty"`
+}
+
+// PullRequestTemplateSpec configures the pull requests the promoter opens for a PromotionStrategy. Changes are applied
+// to the open pull requests on their next reconcile.
+type PullRequestTemplateSpec struct {
+	// TitlePrefix is prepended to the title of the pull requests.
+	// +kubebuilder:validation:Optional
+	TitlePrefix string `json:"titlePrefix,omitempty"`
+	// Title is a Go template that renders the title of the pull requests, in place of the ControllerConfiguration's
+	// pull request title template. It is rendered with the same data.
+	// +kubebuilder:validation:Optional
+	Title string `json:"title,omitempty"`
+	// Description is a Go template that renders the description of the pull requests, in place of the
+	// ControllerConfiguration's pull request description template. It is rendered with the same data.
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+	// Labels are added to the pull requests on the SCM.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Labels []string `json:"labels,omitempty"`
+	// Reviewers are requested to review the pull requests when they are opened. Teams are given as organization/team.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Reviewers []string `json:"reviewers,omitempty"`
+	// MergeStrategy is how the pull requests are merged, either merge or squash. Defaults to merge.
+	// +kubebuilder:validation:Optional
+	MergeStrategy PullRequestMergeStrategy `json:"mergeStrategy,omitempty"`
+	// Draft opens the pull requests as drafts. Draft pull requests are not merged automatically.
+	// +kubebuilder:validation:Optional
+	Draft *bool `json:"draft,omitempty"`
+}
+
+// PullRequestCommonStatus is the state of the pull request of an environment.
+type PullRequestCommonStatus struct {
+	// ID is the unique identifier of the pull request, set by the SCM.
+	ID string `json:"id,omitempty"`
+	// State is the state of the pull request.
+	// +kubebuilder:validation:Enum=closed;merged;open
+	State PullRequestState `json:"state,omitempty"`
+	// CreatedAt is the time the pull request was created on the SCM.
+	CreatedAt metav1.Time `json:"createdAt,omitempty"`
+	// MergedAt is the time the controller set the PullRequest's spec to merge it, which can be slightly before the
+	// SCM merged it.
+	MergedAt metav1.Time `json:"mergedAt,omitempty"`
+	// URL is the URL of the pull request.
+	// +kubebuilder:validation:XValidation:rule="self == '' || isURL(self)",message="must be a valid URL"
+	// +kubebuilder:validation:Pattern="^(https?://.*)?$"
+	URL string `json:"url,omitempty"`
+	// ExternallyMergedOrClosed indicates that the pull request is no longer open on the SCM while the PullRequest still
+	// desired it open. When true, State is empty since merges can't be told apart from closes on the SCM.
+	ExternallyMergedOrClosed *bool `json:"externallyMergedOrClosed,omitempty"`
+}
+
+// History describes a change that was promoted to an environment.
+type History struct {
+	// Proposed is the state of the proposed branch at the time the pull request was merged.
+	Proposed HistoryProposedState `json:"proposed,omitempty"`
+	// Active is the state of the active branch at the time the pull request was merged.
+	Active CommitBranchState `json:"active,omitempty"`
+	// PullRequest is the state of the pull request that promoted the change.
+	PullRequest *PullRequestCommonStatus `json:"pullRequest,omitempty"`
+	// AutoRevert is the name of the RevertCommit that the PromotionStrategy created to revert this promotion
+	// automatically. It is only set in the history of a PromotionStrategy.
+	// +optional
+	AutoRevert string `json:"autoRevert,omitempty"`
+	// Kind is revert for a promotion that rolled the environment back for a RevertCommit, and empty for a forward
+	// promotion. It is only set in the history of a PromotionStrategy.
+	// +optional
+	Kind HistoryKind `json:"kind,omitempty"`
+	// RevertCommitRef is the RevertCommit that a revert was promoted for.
+	// +optional
+	RevertCommitRef *ObjectReference `json:"revertCommitRef,omitempty"`
+	// RevertedSha is the commit that a revert reverted, the sha of its RevertCommit.
+	// +optional
+	RevertedSha string `json:"revertedSha,omitempty"`
+}
+
+// HistoryKind is the kind of change a History entry promoted.
+// +kubebuilder:validation:Enum=revert
+type HistoryKind string
+
+const (
+	// HistoryKindRevert is a promotion that rolled the environment back for a RevertCommit.
+	HistoryKindRevert HistoryKind = "revert"
+)
+
+// HistoryProposedState is the state of the proposed branch when a change was promoted. Its dry commit is the active
+// one of the History entry.
+type HistoryProposedState struct {
+	// Hydrated is the hydrated commit that was merged.
+	Hydrated CommitShaState `json:"hydrated,omitempty"`
+	// CommitStatuses are the states of the commit statuses the hydrated commit was gated on when it was merged.
+	CommitStatuses []CommitStatusState `json:"commitStatuses,omitempty"`
+}
+
+// DivergenceResolution is how a ChangeTransferPolicy handles a proposed branch that diverged from the commits it
+// previously saw.
+// +kubebuilder:validation:Enum=reset;manual
+type DivergenceResolution string
+
+const (
+	// DivergenceResolutionReset force-updates the proposed branch back to the last proposed commit.
+	DivergenceResolutionReset DivergenceResolution = "reset"
+	// DivergenceResolutionManual stops promoting until the proposed branch is fixed by hand.
+	DivergenceResolutionManual DivergenceResolution = "manual"
+)
+
+// PollingStatus shows how often a resource is polled and whether webhooks are delivered for its repository.
+type PollingStatus struct {
+	// RequeueInterval is how long the controller waits after a reconcile before it reconciles the resource again:
+	// the ControllerConfiguration's adaptivePolling.maxRequeueDuration while webhook deliveries for the resource's
+	// GitRepository are fresh, and the usual interval otherwise. The resource is reconciled sooner when the deliveries
+	// go stale first, or when a reconcile waits for something shorter, such as an auto-revert.
+	RequeueInterval metav1.Duration `json:"requeueInterval"`
+
+	// LastWebhookDelivery is the status.lastWebhookDelivery of the resource's GitRepository, when a replica of the
+	// controller last received a verified webhook delivery for it. It is unset if none was received.
+	// +optional
+	LastWebhookDelivery *metav1.Time `json:"lastWebhookDelivery,omitempty"`
+
+	// WebhooksFresh is true while the last delivery, received by this replica or persisted in LastWebhookDelivery, is
+	// within the ControllerConfiguration's adaptivePolling.webhookFreshness.
+	// +optional
+	WebhooksFresh bool `json:"webhooksFresh,omitempty"`
+}
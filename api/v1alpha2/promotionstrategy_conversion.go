@@ -0,0 +1,146 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+var _ conversion.Convertible = &PromotionStrategy{}
+
+// ConvertTo converts the PromotionStrategy to the hub version, v1alpha1. Every field has a v1alpha1 counterpart, so
+// no data is lost.
+func (ps *PromotionStrategy) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1alpha1.PromotionStrategy)
+	if !ok {
+		return fmt.Errorf("cannot convert PromotionStrategy to %T", hub)
+	}
+
+	dst.ObjectMeta = ps.ObjectMeta
+	dst.Spec = v1alpha1.PromotionStrategySpec{
+		RepositoryReference:    v1alpha1.ObjectReference{Name: ps.Spec.GitRepositoryRef.Name},
+		ActiveCommitStatuses:   convertSlice(ps.Spec.PostMergeCommitStatuses, commitStatusSelectorToHub),
+		ProposedCommitStatuses: convertSlice(ps.Spec.PreMergeCommitStatuses, commitStatusSelectorToHub),
+		ProposedBranchTemplate: ps.Spec.ProposedBranchTemplate,
+		PullRequest:            convertPointer(ps.Spec.PullRequest, pullRequestTemplateToHub),
+		Environments:           convertSlice(ps.Spec.Environments, environmentToHub),
+		PropagateLabels:        ps.Spec.PropagateLabels,
+	}
+	dst.Status = v1alpha1.PromotionStrategyStatus{
+		ObservedGeneration:         ps.Status.ObservedGeneration,
+		Environments:               convertSlice(ps.Status.Environments, environmentStatusToHub),
+		Summary:                    ps.Status.Summary,
+		LastEnvironmentState:       v1alpha1.EnvironmentPromotionState(ps.Status.LastEnvironmentState),
+		LastEnvironmentDryShortSha: ps.Status.LastEnvironmentDryShortSha,
+		Polling:                    (*v1alpha1.PollingStatus)(ps.Status.Polling),
+		Conditions:                 ps.Status.Conditions,
+	}
+	return nil
+}
+
+// ConvertFrom converts the hub version, v1alpha1, to the PromotionStrategy.
+func (ps *PromotionStrategy) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha1.PromotionStrategy)
+	if !ok {
+		return fmt.Errorf("cannot convert %T to PromotionStrategy", hub)
+	}
+
+	ps.ObjectMeta = src.ObjectMeta
+	ps.Spec = PromotionStrategySpec{
+		GitRepositoryRef:        ObjectReference{Name: src.Spec.RepositoryReference.Name},
+		PreMergeCommitStatuses:  convertSlice(src.Spec.ProposedCommitStatuses, commitStatusSelectorFromHub),
+		PostMergeCommitStatuses: convertSlice(src.Spec.ActiveCommitStatuses, commitStatusSelectorFromHub),
+		ProposedBranchTemplate:  src.Spec.ProposedBranchTemplate,
+		PullRequest:             convertPointer(src.Spec.PullRequest, pullRequestTemplateFromHub),
+		Environments:            convertSlice(src.Spec.Environments, environmentFromHub),
+		PropagateLabels:         src.Spec.PropagateLabels,
+	}
+	ps.Status = PromotionStrategyStatus{
+		ObservedGeneration:         src.Status.ObservedGeneration,
+		Environments:               convertSlice(src.Status.Environments, environmentStatusFromHub),
+		Summary:                    src.Status.Summary,
+		LastEnvironmentState:       EnvironmentPromotionState(src.Status.LastEnvironmentState),
+		LastEnvironmentDryShortSha: src.Status.LastEnvironmentDryShortSha,
+		Polling:                    (*PollingStatus)(src.Status.Polling),
+		Conditions:                 src.Status.Conditions,
+	}
+	return nil
+}
+
+func environmentToHub(in Environment) v1alpha1.Environment {
+	return v1alpha1.Environment{
+		Branch:                 in.Branch,
+		AutoMerge:              in.AutoMerge,
+		ProposedBranchTemplate: in.ProposedBranchTemplate,
+		PullRequest:            convertPointer(in.PullRequest, pullRequestTemplateToHub),
+		ActiveCommitStatuses:   convertSlice(in.PostMergeCommitStatuses, commitStatusSelectorToHub),
+		ProposedCommitStatuses: convertSlice(in.PreMergeCommitStatuses, commitStatusSelectorToHub),
+		ReconcileInterval:      in.ReconcileInterval,
+		ResolveDivergence:      v1alpha1.DivergenceResolution(in.ResolveDivergence),
+		AutoRevert:             (*v1alpha1.AutoRevert)(in.AutoRevert),
+	}
+}
+
+func environmentFromHub(in v1alpha1.Environment) Environment {
+	return Environment{
+		Branch:                  in.Branch,
+		AutoMerge:               in.AutoMerge,
+		ProposedBranchTemplate:  in.ProposedBranchTemplate,
+		PullRequest:             convertPointer(in.PullRequest, pullRequestTemplateFromHub),
+		PreMergeCommitStatuses:  convertSlice(in.ProposedCommitStatuses, commitStatusSelectorFromHub),
+		PostMergeCommitStatuses: convertSlice(in.ActiveCommitStatuses, commitStatusSelectorFromHub),
+		ReconcileInterval:       in.ReconcileInterval,
+		ResolveDivergence:       DivergenceResolution(in.ResolveDivergence),
+		AutoRevert:              (*AutoRevert)(in.AutoRevert),
+	}
+}
+
+func environmentStatusToHub(in EnvironmentStatus) v1alpha1.EnvironmentStatus {
+	return v1alpha1.EnvironmentStatus{
+		Branch:                    in.Branch,
+		Proposed:                  commitBranchStateToHub(in.Proposed),
+		Active:                    commitBranchStateToHub(in.Active),
+		PullRequest:               convertPointer(in.PullRequest, pullRequestCommonStatusToHub),
+		EffectivelyPromotedDrySha: in.EffectivelyPromotedDrySha,
+		LastHealthyDryShas: convertSlice(in.HealthyDryCommits, func(in HealthyDryCommit) v1alpha1.HealthyDryShas {
+			return v1alpha1.HealthyDryShas{Sha: in.Sha, Time: in.MergedAt}
+		}),
+		History:         convertSlice(in.History, historyToHub),
+		AutoRevert:      (*v1alpha1.AutoRevertStatus)(in.AutoRevert),
+		EmergencyRevert: (*v1alpha1.EmergencyRevertStatus)(in.EmergencyRevert),
+	}
+}
+
+func environmentStatusFromHub(in v1alpha1.EnvironmentStatus) EnvironmentStatus {
+	return EnvironmentStatus{
+		Branch:                    in.Branch,
+		Proposed:                  commitBranchStateFromHub(in.Proposed),
+		Active:                    commitBranchStateFromHub(in.Active),
+		PullRequest:               convertPointer(in.PullRequest, pullRequestCommonStatusFromHub),
+		EffectivelyPromotedDrySha: in.EffectivelyPromotedDrySha,
+		HealthyDryCommits: convertSlice(in.LastHealthyDryShas, func(in v1alpha1.HealthyDryShas) HealthyDryCommit {
+			return HealthyDryCommit{Sha: in.Sha, MergedAt: in.Time}
+		}),
+		History:         convertSlice(in.History, historyFromHub),
+		AutoRevert:      (*AutoRevertStatus)(in.AutoRevert),
+		EmergencyRevert: (*EmergencyRevertStatus)(in.EmergencyRevert),
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2_test
+
+import (
+	"testing"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"sigs.k8s.io/randfill"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha2"
+)
+
+// FuzzPromotionStrategyConversion checks that PromotionStrategies survive a round trip through the other version
+// unchanged, in both directions. The seed corpus runs with go test; run go test -fuzz to explore more seeds.
+func FuzzPromotionStrategyConversion(f *testing.F) {
+	for seed := range int64(50) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, seed int64) {
+		filler := randfill.NewWithSeed(seed).NilChance(0.2)
+
+		spoke := &v1alpha2.PromotionStrategy{}
+		filler.Fill(spoke)
+		spoke.TypeMeta = metav1.TypeMeta{}
+		hub := &v1alpha1.PromotionStrategy{}
+		if err := spoke.ConvertTo(hub); err != nil {
+			t.Fatalf("converting v1alpha2 to v1alpha1: %v", err)
+		}
+		spokeRoundTrip := &v1alpha2.PromotionStrategy{}
+		if err := spokeRoundTrip.ConvertFrom(hub); err != nil {
+			t.Fatalf("converting v1alpha1 to v1alpha2: %v", err)
+		}
+		if !apiequality.Semantic.DeepEqual(spoke, spokeRoundTrip) {
+			t.Fatalf("v1alpha2 round trip changed the PromotionStrategy:\n%s", diff.Diff(spoke, spokeRoundTrip))
+		}
+
+		hub = &v1alpha1.PromotionStrategy{}
+		filler.Fill(hub)
+		hub.TypeMeta = metav1.TypeMeta{}
+		spoke = &v1alpha2.PromotionStrategy{}
+		if err := spoke.ConvertFrom(hub); err != nil {
+			t.Fatalf("converting v1alpha1 to v1alpha2: %v", err)
+		}
+		hubRoundTrip := &v1alpha1.PromotionStrategy{}
+		if err := spoke.ConvertTo(hubRoundTrip); err != nil {
+			t.Fatalf("converting v1alpha2 to v1alpha1: %v", err)
+		}
+		if !apiequality.Semantic.DeepEqual(hub, hubRoundTrip) {
+			t.Fatalf("v1alpha1 round trip changed the PromotionStrategy:\n%s", diff.Diff(hub, hubRoundTrip))
+		}
+	})
+}
+
+func TestPromotionStrategyConversionRenamesEnvironmentFields(t *testing.T) {
+	t.Parallel()
+
+	mergedAt := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	hub := &v1alpha1.PromotionStrategy{
+		ObjectMeta: metav1.ObjectMeta{Name: "ps", Namespace: "default"},
+		Spec: v1alpha1.PromotionStrategySpec{
+			Environments: []v1alpha1.Environment{{
+				Branch:                 "environment/production",
+				ProposedCommitStatuses: []v1alpha1.CommitStatusSelector{{Key: "ci"}},
+			}},
+		},
+		Status: v1alpha1.PromotionStrategyStatus{
+			Environments: []v1alpha1.EnvironmentStatus{{
+				Branch:             "environment/production",
+				LastHealthyDryShas: []v1alpha1.HealthyDryShas{{Sha: "abc", Time: mergedAt}},
+			}},
+		},
+	}
+	spoke := &v1alpha2.PromotionStrategy{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("converting v1alpha1 to v1alpha2: %v", err)
+	}
+	environment := spoke.Spec.Environments[0]
+	if len(environment.PreMergeCommitStatuses) != 1 || environment.PreMergeCommitStatuses[0].Key != "ci" ||
+		environment.PostMergeCommitStatuses != nil {
+		t.Fatalf("expected the proposed commit statuses to be the pre-merge ones, got %+v", environment)
+	}
+	healthy := spoke.Status.Environments[0].HealthyDryCommits
+	if len(healthy) != 1 || healthy[0].Sha != "abc" || !healthy[0].MergedAt.Equal(&mergedAt) {
+		t.Fatalf("expected the healthy dry commit abc merged at %s, got %+v", mergedAt, healthy)
+	}
+}
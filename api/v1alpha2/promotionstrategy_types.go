@@ -0,0 +1,276 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PromotionStrategySpec defines the desired state of PromotionStrategy.
+type PromotionStrategySpec struct {
+	// GitRepositoryRef is the repository to promote commits in.
+	// +kubebuilder:validation:Required
+	GitRepositoryRef ObjectReference `json:"gitRepositoryRef"`
+
+	// PreMergeCommitStatuses are the commit statuses a change has to pass in an environment's proposed branch before
+	// it is merged into the environment. They apply to every environment, in addition to the environment's own.
+	// +kubebuilder:validation:Optional
+	// +listType:=map
+	// +listMapKey=key
+	PreMergeCommitStatuses []CommitStatusSelector `json:"preMergeCommitStatuses,omitempty"`
+
+	// PostMergeCommitStatuses are the commit statuses of a change once it runs in an environment. A change is only
+	// promoted to the next environment once they pass. They apply to every environment, in addition to the
+	// environment's own.
+	// +kubebuilder:validation:Optional
+	// +listType:=map
+	// +listMapKey=key
+	PostMergeCommitStatuses []CommitStatusSelector `json:"postMergeCommitStatuses,omitempty"`
+
+	// ProposedBranchTemplate is a Go template that renders the name of each environment's proposed branch. It is
+	// rendered with .Branch set to the environment's branch, and defaults to "{{ .Branch }}-next".
+	// +kubebuilder:validation:Optional
+	ProposedBranchTemplate string `json:"proposedBranchTemplate,omitempty"`
+
+	// PullRequest is merged into the pull requests the promoter opens for every environment. Environments can
+	// override it with their own pullRequest.
+	// +kubebuilder:validation:Optional
+	PullRequest *PullRequestTemplateSpec `json:"pullRequest,omitempty"`
+
+	// Environments is the sequence of environments that a dry commit will be promoted through.
+	// +kubebuilder:validation:MinItems:=1
+	// +listType:=map
+	// +listMapKey=branch
+	Environments []Environment `json:"environments"`
+
+	// PropagateLabels lists the keys of the labels and annotations of this PromotionStrategy that are copied to the
+	// resources the promoter creates for it, and kept in sync with it.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	PropagateLabels []string `json:"propagateLabels,omitempty"`
+}
+
+// Environment defines a single environment in the promotion sequence.
+type Environment struct {
+	// Branch is the name of the active branch for the environment.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Branch string `json:"branch"`
+	// AutoMerge merges the environment's pull requests once their pre-merge commit statuses pass.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=true
+	AutoMerge *bool `json:"autoMerge,omitempty"`
+	// ProposedBranchTemplate overrides spec.proposedBranchTemplate for this environment.
+	// +kubebuilder:validation:Optional
+	ProposedBranchTemplate string `json:"proposedBranchTemplate,omitempty"`
+	// PullRequest is merged over spec.pullRequest for the pull requests of this environment.
+	// +kubebuilder:validation:Optional
+	PullRequest *PullRequestTemplateSpec `json:"pullRequest,omitempty"`
+	// PreMergeCommitStatuses are added to spec.preMergeCommitStatuses for this environment.
+	// +kubebuilder:validation:Optional
+	// +listType:=map
+	// +listMapKey=key
+	PreMergeCommitStatuses []CommitStatusSelector `json:"preMergeCommitStatuses,omitempty"`
+	// PostMergeCommitStatuses are added to spec.postMergeCommitStatuses for this environment.
+	// +kubebuilder:validation:Optional
+	// +listType:=map
+	// +listMapKey=key
+	PostMergeCommitStatuses []CommitStatusSelector `json:"postMergeCommitStatuses,omitempty"`
+	// ReconcileInterval is passed to the environment's ChangeTransferPolicy.
+	// +kubebuilder:validation:Optional
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+	// ResolveDivergence is passed to the environment's ChangeTransferPolicy, either reset or manual.
+	// +kubebuilder:validation:Optional
+	ResolveDivergence DivergenceResolution `json:"resolveDivergence,omitempty"`
+	// AutoRevert reverts a promotion to this environment automatically when the environment's post-merge commit
+	// statuses start failing shortly after it.
+	// +kubebuilder:validation:Optional
+	AutoRevert *AutoRevert `json:"autoRevert,omitempty"`
+}
+
+// AutoRevert configures automatic reverts of promotions to an environment.
+type AutoRevert struct {
+	// Enabled turns automatic reverts on for the environment.
+	// +kubebuilder:validation:Required
+	Enabled bool `json:"enabled"`
+	// DryBranch is the branch of the dry commits promoted to the environment.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	DryBranch string `json:"dryBranch"`
+	// Within is how long after a promotion a failing post-merge commit status leads to a revert.
+	// +kubebuilder:validation:Required
+	Within metav1.Duration `json:"within"`
+	// Debounce is how long a post-merge commit status has to keep failing before the promotion is reverted.
+	// +kubebuilder:validation:Optional
+	Debounce metav1.Duration `json:"debounce,omitempty"`
+}
+
+// PromotionStrategyStatus defines the observed state of PromotionStrategy.
+type PromotionStrategyStatus struct {
+	// ObservedGeneration is the .metadata.generation that this status was reconciled from.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Environments holds the status of each environment in the promotion sequence.
+	// +listType:=map
+	// +listMapKey=branch
+	Environments []EnvironmentStatus `json:"environments,omitempty"`
+
+	// Summary is the abbreviated active dry commit of each environment in the promotion sequence, for display.
+	// +optional
+	Summary string `json:"summary,omitempty"`
+
+	// LastEnvironmentState is whether the last environment in the promotion sequence runs the change proposed for it.
+	// +optional
+	LastEnvironmentState EnvironmentPromotionState `json:"lastEnvironmentState,omitempty"`
+
+	// LastEnvironmentDryShortSha is the abbreviated active dry commit of the last environment, for display.
+	// +optional
+	LastEnvironmentDryShortSha string `json:"lastEnvironmentDryShortSha,omitempty"`
+
+	// Polling shows how often the PromotionStrategy is polled and whether webhooks are delivered for its repository.
+	// +optional
+	Polling *PollingStatus `json:"polling,omitempty"`
+
+	// Conditions Represents the observations of the current state.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// EnvironmentPromotionState is whether an environment runs the change proposed for it.
+// +kubebuilder:validation:Enum=Promoted;Promoting;Blocked
+type EnvironmentPromotionState string
+
+const (
+	// EnvironmentPromoted means the environment's active branch has the change proposed for it, or none is proposed.
+	EnvironmentPromoted EnvironmentPromotionState = "Promoted"
+	// EnvironmentPromoting means a change is proposed for the environment and isn't active yet.
+	EnvironmentPromoting EnvironmentPromotionState = "Promoting"
+	// EnvironmentBlocked means a change is proposed for the environment and a commit status of it failed.
+	EnvironmentBlocked EnvironmentPromotionState = "Blocked"
+)
+
+// EnvironmentStatus defines the observed state of an environment in a PromotionStrategy.
+type EnvironmentStatus struct {
+	// Branch is the name of the active branch for the environment.
+	// +kubebuilder:validation:MinLength=1
+	Branch string `json:"branch"`
+	// Proposed is the state of the proposed branch for the environment.
+	Proposed CommitBranchState `json:"proposed,omitempty"`
+	// Active is the state of the active branch for the environment.
+	Active CommitBranchState `json:"active,omitempty"`
+
+	// PullRequest is the state of the pull request of the environment.
+	PullRequest *PullRequestCommonStatus `json:"pullRequest,omitempty"`
+
+	// EffectivelyPromotedDrySha is the proposed dry sha when it does not change the environment's hydrated manifests,
+	// so it counts as promoted without a pull request.
+	// +optional
+	EffectivelyPromotedDrySha string `json:"effectivelyPromotedDrySha,omitempty"`
+
+	// HealthyDryCommits are the dry commits whose post-merge commit statuses passed in the environment, newest first.
+	// +optional
+	HealthyDryCommits []HealthyDryCommit `json:"healthyDryCommits,omitempty"`
+
+	// History is the last promotions to the environment merged by the promoter, newest first. It is informational
+	// only.
+	History []History `json:"history,omitempty"`
+
+	// AutoRevert is the state of the automatic revert of the dry commit active in the environment.
+	// +optional
+	AutoRevert *AutoRevertStatus `json:"autoRevert,omitempty"`
+
+	// EmergencyRevert is the hydrated commit that was reverted directly on the environment's active branch. Auto-merge
+	// is held for the environment while it is set.
+	// +optional
+	EmergencyRevert *EmergencyRevertStatus `json:"emergencyRevert,omitempty"`
+}
+
+// HealthyDryCommit is a dry commit whose post-merge commit statuses passed in an environment.
+type HealthyDryCommit struct {
+	// Sha is the dry commit.
+	// Supports both SHA-1 (40 chars) and SHA-256 (64 chars) Git hash formats.
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^([a-f0-9]{40}|[a-f0-9]{64})$`
+	Sha string `json:"sha"`
+	// MergedAt is when the dry commit was merged into the environment's active branch.
+	MergedAt metav1.Time `json:"mergedAt"`
+}
+
+// AutoRevertStatus is the state of the automatic revert of a dry commit in an environment.
+type AutoRevertStatus struct {
+	// DrySha is the dry commit active in the environment.
+	// Supports both SHA-1 (40 chars) and SHA-256 (64 chars) Git hash formats.
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^([a-f0-9]{40}|[a-f0-9]{64})$`
+	DrySha string `json:"drySha"`
+	// FailingSince is when a post-merge commit status of the dry commit was first seen failing.
+	// +optional
+	FailingSince *metav1.Time `json:"failingSince,omitempty"`
+	// RevertCommit is the name of the RevertCommit created to revert the dry commit in the environment.
+	// +optional
+	RevertCommit string `json:"revertCommit,omitempty"`
+}
+
+// EmergencyRevertStatus is a hydrated commit that was reverted directly on an environment's active branch.
+type EmergencyRevertStatus struct {
+	// RevertCommit is the name of the RevertCommit that reverted the hydrated commit.
+	RevertCommit string `json:"revertCommit"`
+	// HydratedSha is the hydrated commit that was reverted.
+	// Supports both SHA-1 (40 chars) and SHA-256 (64 chars) Git hash formats.
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^([a-f0-9]{40}|[a-f0-9]{64})$`
+	HydratedSha string `json:"hydratedSha"`
+	// DrySha is the dry commit the reverted commit was hydrated from.
+	// +optional
+	DrySha string `json:"drySha,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:unservedversion
+
+// PromotionStrategy is the Schema for the promotionstrategies API. It is served only when the conversion webhook is
+// enabled.
+// +kubebuilder:printcolumn:name="Repository",type=string,JSONPath=`.spec.gitRepositoryRef.name`,priority=1
+// +kubebuilder:printcolumn:name="Last Env State",type=string,JSONPath=`.status.lastEnvironmentState`
+// +kubebuilder:printcolumn:name="Last Env Dry Sha",type=string,JSONPath=`.status.lastEnvironmentDryShortSha`
+// +kubebuilder:printcolumn:name="Environments",type=string,JSONPath=`.status.summary`,priority=1
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+type PromotionStrategy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PromotionStrategySpec   `json:"spec,omitempty"`
+	Status PromotionStrategyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PromotionStrategyList contains a list of PromotionStrategy
+type PromotionStrategyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PromotionStrategy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PromotionStrategy{}, &PromotionStrategyList{})
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+var _ conversion.Convertible = &PullRequest{}
+
+// ConvertTo converts the PullRequest to the hub version, v1alpha1. Every field has a v1alpha1 counterpart, so no
+// data is lost.
+func (pr *PullRequest) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1alpha1.PullRequest)
+	if !ok {
+		return fmt.Errorf("cannot convert PullRequest to %T", hub)
+	}
+
+	dst.ObjectMeta = pr.ObjectMeta
+	dst.Spec = v1alpha1.PullRequestSpec{
+		RepositoryReference: v1alpha1.ObjectReference{Name: pr.Spec.GitRepositoryRef.Name},
+		Title:               pr.Spec.Title,
+		TargetBranch:        pr.Spec.BaseBranch,
+		SourceBranch:        pr.Spec.HeadBranch,
+		Description:         pr.Spec.Description,
+		Commit:              v1alpha1.CommitConfiguration{Message: pr.Spec.Commit.Message},
+		MergeSha:            pr.Spec.MergeSha,
+		State:               v1alpha1.PullRequestState(pr.Spec.State),
+		Labels:              pr.Spec.Labels,
+		Reviewers:           pr.Spec.Reviewers,
+		MergeStrategy:       v1alpha1.PullRequestMergeStrategy(pr.Spec.MergeStrategy),
+		Draft:               pr.Spec.Draft,
+	}
+	dst.Status = v1alpha1.PullRequestStatus{
+		ObservedGeneration:       pr.Status.ObservedGeneration,
+		ID:                       pr.Status.ID,
+		State:                    v1alpha1.PullRequestState(pr.Status.State),
+		PRCreationTime:           pr.Status.CreatedAt,
+		Url:                      pr.Status.URL,
+		ExternallyMergedOrClosed: pr.Status.ExternallyMergedOrClosed,
+		Labels:                   pr.Status.Labels,
+		Reviewers:                pr.Status.Reviewers,
+		Draft:                    pr.Status.Draft,
+		Conditions:               pr.Status.Conditions,
+	}
+	return nil
+}
+
+// ConvertFrom converts the hub version, v1alpha1, to the PullRequest.
+func (pr *PullRequest) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha1.PullRequest)
+	if !ok {
+		return fmt.Errorf("cannot convert %T to PullRequest", hub)
+	}
+
+	pr.ObjectMeta = src.ObjectMeta
+	pr.Spec = PullRequestSpec{
+		GitRepositoryRef: ObjectReference{Name: src.Spec.RepositoryReference.Name},
+		Title:            src.Spec.Title,
+		HeadBranch:       src.Spec.SourceBranch,
+		BaseBranch:       src.Spec.TargetBranch,
+		Description:      src.Spec.Description,
+		Commit:           CommitConfiguration{Message: src.Spec.Commit.Message},
+		MergeSha:         src.Spec.MergeSha,
+		State:            PullRequestState(src.Spec.State),
+		Labels:           src.Spec.Labels,
+		Reviewers:        src.Spec.Reviewers,
+		MergeStrategy:    PullRequestMergeStrategy(src.Spec.MergeStrategy),
+		Draft:            src.Spec.Draft,
+	}
+	pr.Status = PullRequestStatus{
+		ObservedGeneration:       src.Status.ObservedGeneration,
+		ID:                       src.Status.ID,
+		State:                    PullRequestState(src.Status.State),
+		CreatedAt:                src.Status.PRCreationTime,
+		URL:                      src.Status.Url,
+		ExternallyMergedOrClosed: src.Status.ExternallyMergedOrClosed,
+		Labels:                   src.Status.Labels,
+		Reviewers:                src.Status.Reviewers,
+		Draft:                    src.Status.Draft,
+		Conditions:               src.Status.Conditions,
+	}
+	return nil
+}
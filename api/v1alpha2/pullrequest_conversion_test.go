@@ -0,0 +1,95 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2_test
+
+import (
+	"testing"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"sigs.k8s.io/randfill"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha2"
+)
+
+// FuzzPullRequestConversion checks that PullRequests survive a round trip through the other version unchanged, in
+// both directions. The seed corpus runs with go test; run go test -fuzz to explore more seeds.
+func FuzzPullRequestConversion(f *testing.F) {
+	for seed := range int64(50) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, seed int64) {
+		filler := randfill.NewWithSeed(seed).NilChance(0.2)
+
+		spoke := &v1alpha2.PullRequest{}
+		filler.Fill(spoke)
+		spoke.TypeMeta = metav1.TypeMeta{}
+		hub := &v1alpha1.PullRequest{}
+		if err := spoke.ConvertTo(hub); err != nil {
+			t.Fatalf("converting v1alpha2 to v1alpha1: %v", err)
+		}
+		spokeRoundTrip := &v1alpha2.PullRequest{}
+		if err := spokeRoundTrip.ConvertFrom(hub); err != nil {
+			t.Fatalf("converting v1alpha1 to v1alpha2: %v", err)
+		}
+		if !apiequality.Semantic.DeepEqual(spoke, spokeRoundTrip) {
+			t.Fatalf("v1alpha2 round trip changed the PullRequest:\n%s", diff.Diff(spoke, spokeRoundTrip))
+		}
+
+		hub = &v1alpha1.PullRequest{}
+		filler.Fill(hub)
+		hub.TypeMeta = metav1.TypeMeta{}
+		spoke = &v1alpha2.PullRequest{}
+		if err := spoke.ConvertFrom(hub); err != nil {
+			t.Fatalf("converting v1alpha1 to v1alpha2: %v", err)
+		}
+		hubRoundTrip := &v1alpha1.PullRequest{}
+		if err := spoke.ConvertTo(hubRoundTrip); err != nil {
+			t.Fatalf("converting v1alpha2 to v1alpha1: %v", err)
+		}
+		if !apiequality.Semantic.DeepEqual(hub, hubRoundTrip) {
+			t.Fatalf("v1alpha1 round trip changed the PullRequest:\n%s", diff.Diff(hub, hubRoundTrip))
+		}
+	})
+}
+
+func TestPullRequestConversionRenamesBranches(t *testing.T) {
+	t.Parallel()
+
+	hub := &v1alpha1.PullRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "default"},
+		Spec: v1alpha1.PullRequestSpec{
+			SourceBranch: "environment/production-next",
+			TargetBranch: "environment/production",
+		},
+		Status: v1alpha1.PullRequestStatus{Url: "https://example.com/pull/1"},
+	}
+	spoke := &v1alpha2.PullRequest{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("converting v1alpha1 to v1alpha2: %v", err)
+	}
+	if spoke.Spec.HeadBranch != "environment/production-next" || spoke.Spec.BaseBranch != "environment/production" {
+		t.Fatalf("expected head branch %q and base branch %q, got %q and %q",
+			"environment/production-next", "environment/production", spoke.Spec.HeadBranch, spoke.Spec.BaseBranch)
+	}
+	if spoke.Name != "pr" || spoke.Status.URL != "https://example.com/pull/1" {
+		t.Fatalf("expected the metadata and status to be kept, got %+v", spoke)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ObjectReference is a reference to an object by name.
+type ObjectReference struct {
+	// Name is the name of the object to refer to.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	Name string `json:"name"`
+}
+
+// PullRequestSpec defines the desired state of PullRequest
+type PullRequestSpec struct {
+	// GitRepositoryRef indicates what repository to open the PR on.
+	// +kubebuilder:validation:Required
+	GitRepositoryRef ObjectReference `json:"gitRepositoryRef"`
+	// Title is the title of the pull request.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Title string `json:"title"`
+	// HeadBranch is the branch with the changes, which is merged into the base branch.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// +kubebuilder:validation:Required
+	HeadBranch string `json:"headBranch"`
+	// BaseBranch is the branch that the head branch is merged into.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// +kubebuilder:validation:Required
+	BaseBranch string `json:"baseBranch"`
+	// Description is the description body of the pull/merge request
+	Description string `json:"description,omitempty"`
+	// Commit contains configuration for how we will merge/squash/etc the pull request.
+	Commit CommitConfiguration `json:"commit,omitempty"`
+	// MergeSha is the commit SHA that the head branch must match before the PR can be merged.
+	// This prevents a race condition where a PR is merged with a different commit than intended.
+	// Supports both SHA-1 (40 chars) and SHA-256 (64 chars) Git hash formats.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=40
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^([a-f0-9]{40}|[a-f0-9]{64})$`
+	MergeSha string `json:"mergeSha"`
+	// State of the pull request (closed, merged, or open). Must always be "open" when creating a new pull request.
+	// This value may not be changed to "closed" or "merged" unless the pull request status.id is set.
+	// +kubebuilder:default:=open
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=closed;merged;open
+	State PullRequestState `json:"state"`
+	// Labels are added to the pull request on the SCM. Labels that were added to it on the SCM are left alone.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Labels []string `json:"labels,omitempty"`
+	// Reviewers are requested to review the pull request when it is opened. Teams are given as organization/team.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Reviewers []string `json:"reviewers,omitempty"`
+	// MergeStrategy is how the pull request is merged, either merge or squash. Defaults to merge.
+	// +kubebuilder:validation:Optional
+	MergeStrategy PullRequestMergeStrategy `json:"mergeStrategy,omitempty"`
+	// Draft opens the pull request as a draft. Draft pull requests are not merged automatically: they have to be
+	// marked as ready and merged on the SCM.
+	// +kubebuilder:validation:Optional
+	Draft bool `json:"draft,omitempty"`
+}
+
+// PullRequestMergeStrategy is how a pull request is merged.
+// +kubebuilder:validation:Enum=merge;squash
+type PullRequestMergeStrategy string
+
+// CommitConfiguration defines the commit configuration for how we will merge/squash/etc the pull request.
+type CommitConfiguration struct {
+	// Message is the commit message that will be written for the commit that's made when merging the PR.
+	Message string `json:"message"`
+}
+
+// PullRequestStatus defines the observed state of PullRequest
+type PullRequestStatus struct {
+	// ObservedGeneration is the .metadata.generation that this status was reconciled from.
+	// Because status is written via Server-Side Apply with ForceOwnership (which has no
+	// optimistic-concurrency check), this field is the canonical way to detect stale
+	// status writes: compare status.observedGeneration with metadata.generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ID the id of the pull request
+	ID string `json:"id,omitempty"`
+	// State of the merge request closed/merged/open
+	// +kubebuilder:validation:Enum="";closed;merged;open
+	State PullRequestState `json:"state,omitempty"`
+	// CreatedAt is the time the pull request was created on the SCM.
+	CreatedAt metav1.Time `json:"createdAt,omitempty"`
+	// URL is the URL of the pull request.
+	// +kubebuilder:validation:XValidation:rule="self == '' || isURL(self)",message="must be a valid URL"
+	// +kubebuilder:validation:Pattern="^(https?://.*)?$"
+	URL string `json:"url,omitempty"`
+	// ExternallyMergedOrClosed indicates that the pull request is no longer open on the SCM while the
+	// resource still desired it open (spec.state is "open"): either it was merged or closed outside the
+	// controller, or it was closed on the SCM because the PullRequest resource was deleted (finalizer)
+	// and a subsequent sync observed it missing. The controller does not distinguish those cases here.
+	// When true, the State field will be empty ("") since we cannot tell merge vs. close from the provider.
+	// The PullRequest resource will be deleted after this flag is set when possible, but the status is
+	// preserved in the owning ChangeTransferPolicy to maintain a record.
+	ExternallyMergedOrClosed *bool `json:"externallyMergedOrClosed,omitempty"`
+
+	// Labels are the spec.labels the controller last added to the pull request on the SCM. Labels that are removed from
+	// spec.labels are removed from the pull request, the ones added on the SCM are left alone.
+	// +optional
+	// +listType=set
+	Labels []string `json:"labels,omitempty"`
+	// Reviewers are the spec.reviewers the controller last requested reviews from. The review requests of reviewers
+	// that are removed from spec.reviewers are removed from the pull request.
+	// +optional
+	// +listType=set
+	Reviewers []string `json:"reviewers,omitempty"`
+	// Draft is the spec.draft the pull request was last opened or updated with on the SCM. The pull request is only
+	// converted to or from a draft when spec.draft changes, so marking it as ready on the SCM is left alone.
+	// +optional
+	Draft bool `json:"draft,omitempty"`
+
+	// Conditions Represents the observations of the current state.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:unservedversion
+
+// PullRequest is the Schema for the pullrequests API. It is served only when the conversion webhook is enabled.
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`
+// +kubebuilder:printcolumn:name="Head",type=string,JSONPath=`.spec.headBranch`,priority=1
+// +kubebuilder:printcolumn:name="Base",type=string,JSONPath=`.spec.baseBranch`,priority=1
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.status.url`
+// +kubebuilder:validation:XValidation:rule=`self.spec.state == 'open' || has(self.status.id) && self.status.id != ""`,message="Cannot transition to 'closed' or 'merged' state when status.id is empty"
+type PullRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PullRequestSpec   `json:"spec,omitempty"`
+	Status PullRequestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PullRequestList contains a list of PullRequest
+type PullRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PullRequest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PullRequest{}, &PullRequestList{})
+}
+
+// PullRequestState represents the state of a pull request.
+type PullRequestState string
+
+const (
+	// PullRequestClosed indicates that the pull request is closed.
+	PullRequestClosed PullRequestState = "closed"
+	// PullRequestOpen indicates that the pull request is open.
+	PullRequestOpen PullRequestState = "open"
+	// PullRequestMerged indicates that the pull request has been merged.
+	PullRequestMerged PullRequestState = "merged"
+)
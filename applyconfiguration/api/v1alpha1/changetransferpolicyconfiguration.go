@@ -17,6 +17,10 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // ChangeTransferPolicyConfigurationApplyConfiguration represents a declarative configuration of the ChangeTransferPolicyConfiguration type for use
 // with apply.
 //
@@ -28,6 +32,23 @@ type ChangeTransferPolicyConfigurationApplyConfiguration struct {
 	// WorkQueue contains the work queue configuration for the ChangeTransferPolicy controller.
 	// This includes requeue duration, maximum concurrent reconciles, and rate limiter settings.
 	WorkQueue *WorkQueueApplyConfiguration `json:"workQueue,omitempty"`
+	// CloneDepth is the number of commits fetched when the controller clones a repository. Zero clones the full
+	// history. Shallow clones are deepened automatically when an operation needs history beyond the fetched commits.
+	// GitRepository.spec.cloneDepth overrides this value for a single repository. Defaults to the --git-clone-depth
+	// flag of the controller.
+	CloneDepth *int32 `json:"cloneDepth,omitempty"`
+	// AlwaysOpenPullRequests opens a pull request for every proposed dry commit, even when the proposed branch's hydrated
+	// tree is identical to the active branch's. By default no pull request is opened for such commits, set this to keep
+	// a pull request for every dry commit, e.g. for auditing.
+	AlwaysOpenPullRequests *bool `json:"alwaysOpenPullRequests,omitempty"`
+	// CloneIdleTimeout is how long a cached clone may go unused before the controller removes it from disk. A removed
+	// clone is cloned again the next time its environment is reconciled. Clones are also removed when their
+	// ChangeTransferPolicy or GitRepository is deleted. Defaults to 24h, set to 0s to keep clones until they are deleted.
+	// The store of objects shared by the clones of a repository is removed once it had no clones for as long.
+	CloneIdleTimeout *v1.Duration `json:"cloneIdleTimeout,omitempty"`
+	// MinReconcileInterval is the shortest reconcileInterval a ChangeTransferPolicy may set, shorter intervals are raised
+	// to this value. It keeps a single resource from polling its repository too often. Defaults to 10s.
+	MinReconcileInterval *v1.Duration `json:"minReconcileInterval,omitempty"`
 }
 
 // ChangeTransferPolicyConfigurationApplyConfiguration constructs a declarative configuration of the ChangeTransferPolicyConfiguration type for use with
@@ -43,3 +64,35 @@ func (b *ChangeTransferPolicyConfigurationApplyConfiguration) WithWorkQueue(valu
 	b.WorkQueue = value
 	return b
 }
+
+// WithCloneDepth sets the CloneDepth field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CloneDepth field is set to the value of the last call.
+func (b *ChangeTransferPolicyConfigurationApplyConfiguration) WithCloneDepth(value int32) *ChangeTransferPolicyConfigurationApplyConfiguration {
+	b.CloneDepth = &value
+	return b
+}
+
+// WithAlwaysOpenPullRequests sets the AlwaysOpenPullRequests field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AlwaysOpenPullRequests field is set to the value of the last call.
+func (b *ChangeTransferPolicyConfigurationApplyConfiguration) WithAlwaysOpenPullRequests(value bool) *ChangeTransferPolicyConfigurationApplyConfiguration {
+	b.AlwaysOpenPullRequests = &value
+	return b
+}
+
+// WithCloneIdleTimeout sets the CloneIdleTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CloneIdleTimeout field is set to the value of the last call.
+func (b *ChangeTransferPolicyConfigurationApplyConfiguration) WithCloneIdleTimeout(value v1.Duration) *ChangeTransferPolicyConfigurationApplyConfiguration {
+	b.CloneIdleTimeout = &value
+	return b
+}
+
+// WithMinReconcileInterval sets the MinReconcileInterval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinReconcileInterval field is set to the value of the last call.
+func (b *ChangeTransferPolicyConfigurationApplyConfiguration) WithMinReconcileInterval(value v1.Duration) *ChangeTransferPolicyConfigurationApplyConfiguration {
+	b.MinReconcileInterval = &value
+	return b
+}
@@ -17,6 +17,11 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	apiv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // ChangeTransferPolicySpecApplyConfiguration represents a declarative configuration of the ChangeTransferPolicySpec type for use
 // with apply.
 //
@@ -35,6 +40,21 @@ type ChangeTransferPolicySpecApplyConfiguration struct {
 	ActiveCommitStatuses []CommitStatusSelectorApplyConfiguration `json:"activeCommitStatuses,omitempty"`
 	// ProposedCommitStatuses lists the statuses to be monitored on the proposed branch
 	ProposedCommitStatuses []CommitStatusSelectorApplyConfiguration `json:"proposedCommitStatuses,omitempty"`
+	// ReconcileInterval overrides the ControllerConfiguration's ChangeTransferPolicy requeue duration for this
+	// ChangeTransferPolicy. Values below the ControllerConfiguration's minReconcileInterval are raised to the minimum.
+	ReconcileInterval *v1.Duration `json:"reconcileInterval,omitempty"`
+	// ResolveDivergence is what the controller does when the proposed branch diverged from the commits it previously
+	// saw, e.g. because a commit was pushed to it by hand. With reset, the proposed branch is force-updated back to the
+	// last proposed commit. With manual, the ChangeTransferPolicy stops promoting until someone fixes the branch. If it
+	// is not set, the divergence is only reported on the ProposedBranchDiverged condition.
+	ResolveDivergence *apiv1alpha1.DivergenceResolution `json:"resolveDivergence,omitempty"`
+	// PropagateLabels lists the keys of the labels and annotations of this ChangeTransferPolicy that are copied to its
+	// PullRequests and CommitStatuses, and kept in sync with it. The PromotionStrategy sets it to the keys of its own
+	// and its GitRepository's propagateLabels.
+	PropagateLabels []string `json:"propagateLabels,omitempty"`
+	// PullRequest configures the pull requests of this ChangeTransferPolicy. The PromotionStrategy sets it to its
+	// pullRequest merged with the environment's.
+	PullRequest *PullRequestTemplateSpecApplyConfiguration `json:"pullRequest,omitempty"`
 }
 
 // ChangeTransferPolicySpecApplyConfiguration constructs a declarative configuration of the ChangeTransferPolicySpec type for use with
@@ -100,3 +120,37 @@ func (b *ChangeTransferPolicySpecApplyConfiguration) WithProposedCommitStatuses(
 	}
 	return b
 }
+
+// WithReconcileInterval sets the ReconcileInterval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReconcileInterval field is set to the value of the last call.
+func (b *ChangeTransferPolicySpecApplyConfiguration) WithReconcileInterval(value v1.Duration) *ChangeTransferPolicySpecApplyConfiguration {
+	b.ReconcileInterval = &value
+	return b
+}
+
+// WithResolveDivergence sets the ResolveDivergence field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResolveDivergence field is set to the value of the last call.
+func (b *ChangeTransferPolicySpecApplyConfiguration) WithResolveDivergence(value apiv1alpha1.DivergenceResolution) *ChangeTransferPolicySpecApplyConfiguration {
+	b.ResolveDivergence = &value
+	return b
+}
+
+// WithPropagateLabels adds the given value to the PropagateLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PropagateLabels field.
+func (b *ChangeTransferPolicySpecApplyConfiguration) WithPropagateLabels(values ...string) *ChangeTransferPolicySpecApplyConfiguration {
+	for i := range values {
+		b.PropagateLabels = append(b.PropagateLabels, values[i])
+	}
+	return b
+}
+
+// WithPullRequest sets the PullRequest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PullRequest field is set to the value of the last call.
+func (b *ChangeTransferPolicySpecApplyConfiguration) WithPullRequest(value *PullRequestTemplateSpecApplyConfiguration) *ChangeTransferPolicySpecApplyConfiguration {
+	b.PullRequest = value
+	return b
+}
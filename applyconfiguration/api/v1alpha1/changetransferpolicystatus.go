@@ -37,6 +37,24 @@ type ChangeTransferPolicyStatusApplyConfiguration struct {
 	Active *CommitBranchStateApplyConfiguration `json:"active,omitempty"`
 	// PullRequest is the state of the pull request that was created for this ChangeTransferPolicy.
 	PullRequest *PullRequestCommonStatusApplyConfiguration `json:"pullRequest,omitempty"`
+	// ActiveDryShortSha is the abbreviated active.dry.sha, for display.
+	ActiveDryShortSha *string `json:"activeDryShortSha,omitempty"`
+	// ProposedDryShortSha is the abbreviated proposed.dry.sha, for display.
+	ProposedDryShortSha *string `json:"proposedDryShortSha,omitempty"`
+	// ProposedDryShaSuperseded is true when the proposed dry commit is no longer an ancestor of (or equal to) the newest
+	// dry commit the hydrator has processed for the proposed branch, i.e. it was reverted or removed upstream. Newer dry
+	// commits alone never set this field. While it is true, no pull request is opened for the proposed change and the
+	// PromotionStrategy closes any pull request that is still open for it.
+	ProposedDryShaSuperseded *bool `json:"proposedDryShaSuperseded,omitempty"`
+	// EffectivelyPromotedDrySha is set to the proposed dry sha when the proposed branch's hydrated tree is identical to
+	// the active branch's, ignoring hydrator.metadata files. Merging it would not change what is deployed, so no pull
+	// request is opened and the PromotionStrategy treats the dry commit as promoted to this environment.
+	EffectivelyPromotedDrySha *string `json:"effectivelyPromotedDrySha,omitempty"`
+	// LastLsRemote is the result of the last ls-remote of the branches. When it matches the previous reconcile and the
+	// shas in this status, the branches are not fetched again.
+	LastLsRemote *LsRemoteStateApplyConfiguration `json:"lastLsRemote,omitempty"`
+	// Polling shows how often the ChangeTransferPolicy is polled and whether webhooks are delivered for its repository.
+	Polling *PollingStatusApplyConfiguration `json:"polling,omitempty"`
 	// History defines the history of promoted changes done by the ChangeTransferPolicy. You can think of
 	// it as a list of PRs merged by GitOps Promoter. It will not include changes that were manually merged.
 	// The history length is hard-coded to be at most 5 entries. This may change in the future.
@@ -85,6 +103,54 @@ func (b *ChangeTransferPolicyStatusApplyConfiguration) WithPullRequest(value *Pu
 	return b
 }
 
+// WithActiveDryShortSha sets the ActiveDryShortSha field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ActiveDryShortSha field is set to the value of the last call.
+func (b *ChangeTransferPolicyStatusApplyConfiguration) WithActiveDryShortSha(value string) *ChangeTransferPolicyStatusApplyConfiguration {
+	b.ActiveDryShortSha = &value
+	return b
+}
+
+// WithProposedDryShortSha sets the ProposedDryShortSha field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProposedDryShortSha field is set to the value of the last call.
+func (b *ChangeTransferPolicyStatusApplyConfiguration) WithProposedDryShortSha(value string) *ChangeTransferPolicyStatusApplyConfiguration {
+	b.ProposedDryShortSha = &value
+	return b
+}
+
+// WithProposedDryShaSuperseded sets the ProposedDryShaSuperseded field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProposedDryShaSuperseded field is set to the value of the last call.
+func (b *ChangeTransferPolicyStatusApplyConfiguration) WithProposedDryShaSuperseded(value bool) *ChangeTransferPolicyStatusApplyConfiguration {
+	b.ProposedDryShaSuperseded = &value
+	return b
+}
+
+// WithEffectivelyPromotedDrySha sets the EffectivelyPromotedDrySha field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EffectivelyPromotedDrySha field is set to the value of the last call.
+func (b *ChangeTransferPolicyStatusApplyConfiguration) WithEffectivelyPromotedDrySha(value string) *ChangeTransferPolicyStatusApplyConfiguration {
+	b.EffectivelyPromotedDrySha = &value
+	return b
+}
+
+// WithLastLsRemote sets the LastLsRemote field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastLsRemote field is set to the value of the last call.
+func (b *ChangeTransferPolicyStatusApplyConfiguration) WithLastLsRemote(value *LsRemoteStateApplyConfiguration) *ChangeTransferPolicyStatusApplyConfiguration {
+	b.LastLsRemote = value
+	return b
+}
+
+// WithPolling sets the Polling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Polling field is set to the value of the last call.
+func (b *ChangeTransferPolicyStatusApplyConfiguration) WithPolling(value *PollingStatusApplyConfiguration) *ChangeTransferPolicyStatusApplyConfiguration {
+	b.Polling = value
+	return b
+}
+
 // WithHistory adds the given value to the History field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the History field.
@@ -37,7 +37,7 @@ type CommitShaStateApplyConfiguration struct {
 	Author *string `json:"author,omitempty"`
 	// Subject is the subject line of the commit message
 	Subject *string `json:"subject,omitempty"`
-	// Body is the body of the commit message without the subject line
+	// Body is the body of the commit message without the subject line. Bodies longer than 4096 characters are truncated.
 	Body *string `json:"body,omitempty"`
 	// References are the references to other commits, that went into the hydration of the branch
 	References []RevisionReferenceApplyConfiguration `json:"references,omitempty"`
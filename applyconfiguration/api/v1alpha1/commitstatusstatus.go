@@ -37,6 +37,8 @@ type CommitStatusStatusApplyConfiguration struct {
 	// Sha is the commit SHA that the status is set on.
 	// Supports both SHA-1 (40 chars) and SHA-256 (64 chars) Git hash formats.
 	Sha *string `json:"sha,omitempty"`
+	// ShortSha is the abbreviated sha, for display.
+	ShortSha *string `json:"shortSha,omitempty"`
 	// Phase is the state of the commit status. This will be mapped to the appropriate equivalent in the SCM.
 	Phase *apiv1alpha1.CommitStatusPhase `json:"phase,omitempty"`
 	// Conditions Represents the observations of the current state.
@@ -73,6 +75,14 @@ func (b *CommitStatusStatusApplyConfiguration) WithSha(value string) *CommitStat
 	return b
 }
 
+// WithShortSha sets the ShortSha field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ShortSha field is set to the value of the last call.
+func (b *CommitStatusStatusApplyConfiguration) WithShortSha(value string) *CommitStatusStatusApplyConfiguration {
+	b.ShortSha = &value
+	return b
+}
+
 // WithPhase sets the Phase field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Phase field is set to the value of the last call.
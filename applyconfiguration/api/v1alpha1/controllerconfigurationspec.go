@@ -53,6 +53,13 @@ type ControllerConfigurationSpecApplyConfiguration struct {
 	// WebRequestCommitStatus contains the configuration for the WebRequestCommitStatus controller,
 	// including WorkQueue settings that control reconciliation behavior.
 	WebRequestCommitStatus *WebRequestCommitStatusConfigurationApplyConfiguration `json:"webRequestCommitStatus,omitempty"`
+	// // GitRepository contains the configuration for the GitRepository controller.
+	GitRepository *GitRepositoryConfigurationApplyConfiguration `json:"gitRepository,omitempty"`
+	// ScmProvider contains the configuration for the ScmProvider and ClusterScmProvider controllers.
+	ScmProvider *ScmProviderConfigurationApplyConfiguration `json:"scmProvider,omitempty"`
+	// AdaptivePolling configures how the ChangeTransferPolicy and PromotionStrategy controllers lengthen their requeue
+	// intervals while webhook deliveries for a repository are received.
+	AdaptivePolling *AdaptivePollingConfigurationApplyConfiguration `json:"adaptivePolling,omitempty"`
 }
 
 // ControllerConfigurationSpecApplyConfiguration constructs a declarative configuration of the ControllerConfigurationSpec type for use with
@@ -124,3 +131,27 @@ func (b *ControllerConfigurationSpecApplyConfiguration) WithWebRequestCommitStat
 	b.WebRequestCommitStatus = value
 	return b
 }
+
+// WithGitRepository sets the GitRepository field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GitRepository field is set to the value of the last call.
+func (b *ControllerConfigurationSpecApplyConfiguration) WithGitRepository(value *GitRepositoryConfigurationApplyConfiguration) *ControllerConfigurationSpecApplyConfiguration {
+	b.GitRepository = value
+	return b
+}
+
+// WithScmProvider sets the ScmProvider field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScmProvider field is set to the value of the last call.
+func (b *ControllerConfigurationSpecApplyConfiguration) WithScmProvider(value *ScmProviderConfigurationApplyConfiguration) *ControllerConfigurationSpecApplyConfiguration {
+	b.ScmProvider = value
+	return b
+}
+
+// WithAdaptivePolling sets the AdaptivePolling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AdaptivePolling field is set to the value of the last call.
+func (b *ControllerConfigurationSpecApplyConfiguration) WithAdaptivePolling(value *AdaptivePollingConfigurationApplyConfiguration) *ControllerConfigurationSpecApplyConfiguration {
+	b.AdaptivePolling = value
+	return b
+}
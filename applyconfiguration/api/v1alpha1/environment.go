@@ -17,6 +17,11 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	apiv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // EnvironmentApplyConfiguration represents a declarative configuration of the Environment type for use
 // with apply.
 //
@@ -27,6 +32,11 @@ type EnvironmentApplyConfiguration struct {
 	// AutoMerge determines whether the dry commit should be automatically merged into the next branch in the sequence.
 	// If false, the dry commit will be proposed but not merged.
 	AutoMerge *bool `json:"autoMerge,omitempty"`
+	// ProposedBranchTemplate overrides spec.proposedBranchTemplate for this environment.
+	ProposedBranchTemplate *string `json:"proposedBranchTemplate,omitempty"`
+	// PullRequest is merged over spec.pullRequest for the pull requests of this environment: its values win, and its
+	// labels and reviewers are added to spec.pullRequest's.
+	PullRequest *PullRequestTemplateSpecApplyConfiguration `json:"pullRequest,omitempty"`
 	// ActiveCommitStatuses are commit statuses describing an actively running dry commit. If an active commit status
 	// is failing for an environment, subsequent environments will not deploy the failing commit.
 	//
@@ -40,6 +50,15 @@ type EnvironmentApplyConfiguration struct {
 	// The commit statuses specified in this field apply to this environment only. You can also specify commit statuses
 	// for all environments in the `spec.proposedCommitStatuses` field.
 	ProposedCommitStatuses []CommitStatusSelectorApplyConfiguration `json:"proposedCommitStatuses,omitempty"`
+	// ReconcileInterval is passed to the environment's ChangeTransferPolicy, where it overrides the controller's default
+	// requeue duration. Use it to poll busy environments more often without raising git traffic for every environment.
+	ReconcileInterval *v1.Duration `json:"reconcileInterval,omitempty"`
+	// ResolveDivergence is passed to the environment's ChangeTransferPolicy, where it decides what happens when the
+	// proposed branch diverged from the commits the controller previously saw, either reset or manual.
+	ResolveDivergence *apiv1alpha1.DivergenceResolution `json:"resolveDivergence,omitempty"`
+	// AutoRevert reverts a promotion to this environment automatically when the environment's active commit statuses
+	// start failing shortly after it.
+	AutoRevert *AutoRevertApplyConfiguration `json:"autoRevert,omitempty"`
 }
 
 // EnvironmentApplyConfiguration constructs a declarative configuration of the Environment type for use with
@@ -64,6 +83,22 @@ func (b *EnvironmentApplyConfiguration) WithAutoMerge(value bool) *EnvironmentAp
 	return b
 }
 
+// WithProposedBranchTemplate sets the ProposedBranchTemplate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProposedBranchTemplate field is set to the value of the last call.
+func (b *EnvironmentApplyConfiguration) WithProposedBranchTemplate(value string) *EnvironmentApplyConfiguration {
+	b.ProposedBranchTemplate = &value
+	return b
+}
+
+// WithPullRequest sets the PullRequest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PullRequest field is set to the value of the last call.
+func (b *EnvironmentApplyConfiguration) WithPullRequest(value *PullRequestTemplateSpecApplyConfiguration) *EnvironmentApplyConfiguration {
+	b.PullRequest = value
+	return b
+}
+
 // WithActiveCommitStatuses adds the given value to the ActiveCommitStatuses field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the ActiveCommitStatuses field.
@@ -89,3 +124,27 @@ func (b *EnvironmentApplyConfiguration) WithProposedCommitStatuses(values ...*Co
 	}
 	return b
 }
+
+// WithReconcileInterval sets the ReconcileInterval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReconcileInterval field is set to the value of the last call.
+func (b *EnvironmentApplyConfiguration) WithReconcileInterval(value v1.Duration) *EnvironmentApplyConfiguration {
+	b.ReconcileInterval = &value
+	return b
+}
+
+// WithResolveDivergence sets the ResolveDivergence field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResolveDivergence field is set to the value of the last call.
+func (b *EnvironmentApplyConfiguration) WithResolveDivergence(value apiv1alpha1.DivergenceResolution) *EnvironmentApplyConfiguration {
+	b.ResolveDivergence = &value
+	return b
+}
+
+// WithAutoRevert sets the AutoRevert field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AutoRevert field is set to the value of the last call.
+func (b *EnvironmentApplyConfiguration) WithAutoRevert(value *AutoRevertApplyConfiguration) *EnvironmentApplyConfiguration {
+	b.AutoRevert = value
+	return b
+}
@@ -30,6 +30,9 @@ type EnvironmentStatusApplyConfiguration struct {
 	Active *CommitBranchStateApplyConfiguration `json:"active,omitempty"`
 	// PullRequest is the state of the pull request that was created for this environment.
 	PullRequest *PullRequestCommonStatusApplyConfiguration `json:"pullRequest,omitempty"`
+	// EffectivelyPromotedDrySha is the proposed dry sha when it does not change the environment's hydrated manifests,
+	// so it counts as promoted without a pull request. It is copied from the environment's ChangeTransferPolicy.
+	EffectivelyPromotedDrySha *string `json:"effectivelyPromotedDrySha,omitempty"`
 	// LastHealthyDryShas is a list of dry commits that were observed to be healthy in the environment.
 	LastHealthyDryShas []HealthyDryShasApplyConfiguration `json:"lastHealthyDryShas,omitempty"`
 	// History defines the history of promoted changes done by the PromotionStrategy for each environment.
@@ -38,6 +41,14 @@ type EnvironmentStatusApplyConfiguration struct {
 	// History is constructed on a best-effort basis and should be used for informational purposes only.
 	// History is in reverse chronological order (newest is first).
 	History []HistoryApplyConfiguration `json:"history,omitempty"`
+	// AutoRevert is the state of the automatic revert of the dry commit active in the environment. It is only set when
+	// autoRevert is enabled for the environment.
+	AutoRevert *AutoRevertStatusApplyConfiguration `json:"autoRevert,omitempty"`
+	// EmergencyRevert is the hydrated commit that was reverted directly on the environment's active branch by a
+	// RevertCommit with target hydrated. While it is set, the active branch has diverged from the proposed branch on
+	// purpose and auto-merge is held for the environment. It is cleared once a different dry commit is hydrated to the
+	// proposed branch.
+	EmergencyRevert *EmergencyRevertStatusApplyConfiguration `json:"emergencyRevert,omitempty"`
 }
 
 // EnvironmentStatusApplyConfiguration constructs a declarative configuration of the EnvironmentStatus type for use with
@@ -78,6 +89,14 @@ func (b *EnvironmentStatusApplyConfiguration) WithPullRequest(value *PullRequest
 	return b
 }
 
+// WithEffectivelyPromotedDrySha sets the EffectivelyPromotedDrySha field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EffectivelyPromotedDrySha field is set to the value of the last call.
+func (b *EnvironmentStatusApplyConfiguration) WithEffectivelyPromotedDrySha(value string) *EnvironmentStatusApplyConfiguration {
+	b.EffectivelyPromotedDrySha = &value
+	return b
+}
+
 // WithLastHealthyDryShas adds the given value to the LastHealthyDryShas field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the LastHealthyDryShas field.
@@ -103,3 +122,19 @@ func (b *EnvironmentStatusApplyConfiguration) WithHistory(values ...*HistoryAppl
 	}
 	return b
 }
+
+// WithAutoRevert sets the AutoRevert field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AutoRevert field is set to the value of the last call.
+func (b *EnvironmentStatusApplyConfiguration) WithAutoRevert(value *AutoRevertStatusApplyConfiguration) *EnvironmentStatusApplyConfiguration {
+	b.AutoRevert = value
+	return b
+}
+
+// WithEmergencyRevert sets the EmergencyRevert field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EmergencyRevert field is set to the value of the last call.
+func (b *EnvironmentStatusApplyConfiguration) WithEmergencyRevert(value *EmergencyRevertStatusApplyConfiguration) *EnvironmentStatusApplyConfiguration {
+	b.EmergencyRevert = value
+	return b
+}
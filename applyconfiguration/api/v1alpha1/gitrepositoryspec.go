@@ -24,14 +24,39 @@ package v1alpha1
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 // GitRepositorySpec defines the desired state of GitRepository
 type GitRepositorySpecApplyConfiguration struct {
-	GitHub         *GitHubRepoApplyConfiguration                 `json:"github,omitempty"`
-	GitLab         *GitLabRepoApplyConfiguration                 `json:"gitlab,omitempty"`
-	Forgejo        *ForgejoRepoApplyConfiguration                `json:"forgejo,omitempty"`
-	Gitea          *GiteaRepoApplyConfiguration                  `json:"gitea,omitempty"`
-	BitbucketCloud *BitbucketCloudRepoApplyConfiguration         `json:"bitbucketCloud,omitempty"`
-	AzureDevOps    *AzureDevOpsRepoApplyConfiguration            `json:"azureDevOps,omitempty"`
-	Fake           *FakeRepoApplyConfiguration                   `json:"fake,omitempty"`
+	GitHub         *GitHubRepoApplyConfiguration         `json:"github,omitempty"`
+	GitLab         *GitLabRepoApplyConfiguration         `json:"gitlab,omitempty"`
+	Forgejo        *ForgejoRepoApplyConfiguration        `json:"forgejo,omitempty"`
+	Gitea          *GiteaRepoApplyConfiguration          `json:"gitea,omitempty"`
+	BitbucketCloud *BitbucketCloudRepoApplyConfiguration `json:"bitbucketCloud,omitempty"`
+	AzureDevOps    *AzureDevOpsRepoApplyConfiguration    `json:"azureDevOps,omitempty"`
+	Fake           *FakeRepoApplyConfiguration           `json:"fake,omitempty"`
+	// Url is the URL the repository is cloned, fetched and pushed with, instead of identifying it by the owner and
+	// name of one of the SCM specific fields. The ScmProvider's credentials are used with it. It is rejected by
+	// ScmProviders that need the owner and name for their API, such as GitHub.
+	Url            *string                                       `json:"url,omitempty"`
 	ScmProviderRef *ScmProviderObjectReferenceApplyConfiguration `json:"scmProviderRef,omitempty"`
+	// CloneDepth overrides the ControllerConfiguration clone depth for this repository. It is the number of commits
+	// fetched when the repository is cloned, zero clones the full history.
+	CloneDepth *int32 `json:"cloneDepth,omitempty"`
+	// CommitSigning signs the commits the promoter creates in this repository, such as the merge commits that resolve
+	// conflicts between the proposed and active branches, so that they pass signature verification on protected
+	// branches.
+	CommitSigning *CommitSigningApplyConfiguration `json:"commitSigning,omitempty"`
+	// GitIdentity overrides the ScmProvider's and the controller's git identity for the commits the promoter creates in
+	// this repository.
+	GitIdentity *GitIdentityApplyConfiguration `json:"gitIdentity,omitempty"`
+	// SparseCheckout limits the files checked out in the promoter's clones of this repository. When unset, the clones
+	// check out every file.
+	SparseCheckout *SparseCheckoutApplyConfiguration `json:"sparseCheckout,omitempty"`
+	// ManageWebhooks makes the controller register a webhook on the repository that notifies the promoter's webhook
+	// receiver, and keep it registered. It is only supported for GitHub, GitLab and fake repositories, and the
+	// ScmProvider's credentials must be allowed to manage the repository's webhooks.
+	ManageWebhooks *ManageWebhooksApplyConfiguration `json:"manageWebhooks,omitempty"`
+	// PropagateLabels lists the keys of the labels and annotations of this GitRepository that are copied to the
+	// ChangeTransferPolicies, PullRequests and CommitStatuses the promoter creates for its PromotionStrategies, and
+	// kept in sync with it. Keys in the promoter.argoproj.io domain are never copied.
+	PropagateLabels []string `json:"propagateLabels,omitempty"`
 }
 
 // GitRepositorySpecApplyConfiguration constructs a declarative configuration of the GitRepositorySpec type for use with
@@ -96,6 +121,14 @@ func (b *GitRepositorySpecApplyConfiguration) WithFake(value *FakeRepoApplyConfi
 	return b
 }
 
+// WithUrl sets the Url field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Url field is set to the value of the last call.
+func (b *GitRepositorySpecApplyConfiguration) WithUrl(value string) *GitRepositorySpecApplyConfiguration {
+	b.Url = &value
+	return b
+}
+
 // WithScmProviderRef sets the ScmProviderRef field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ScmProviderRef field is set to the value of the last call.
@@ -103,3 +136,53 @@ func (b *GitRepositorySpecApplyConfiguration) WithScmProviderRef(value *ScmProvi
 	b.ScmProviderRef = value
 	return b
 }
+
+// WithCloneDepth sets the CloneDepth field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CloneDepth field is set to the value of the last call.
+func (b *GitRepositorySpecApplyConfiguration) WithCloneDepth(value int32) *GitRepositorySpecApplyConfiguration {
+	b.CloneDepth = &value
+	return b
+}
+
+// WithCommitSigning sets the CommitSigning field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CommitSigning field is set to the value of the last call.
+func (b *GitRepositorySpecApplyConfiguration) WithCommitSigning(value *CommitSigningApplyConfiguration) *GitRepositorySpecApplyConfiguration {
+	b.CommitSigning = value
+	return b
+}
+
+// WithGitIdentity sets the GitIdentity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GitIdentity field is set to the value of the last call.
+func (b *GitRepositorySpecApplyConfiguration) WithGitIdentity(value *GitIdentityApplyConfiguration) *GitRepositorySpecApplyConfiguration {
+	b.GitIdentity = value
+	return b
+}
+
+// WithSparseCheckout sets the SparseCheckout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SparseCheckout field is set to the value of the last call.
+func (b *GitRepositorySpecApplyConfiguration) WithSparseCheckout(value *SparseCheckoutApplyConfiguration) *GitRepositorySpecApplyConfiguration {
+	b.SparseCheckout = value
+	return b
+}
+
+// WithManageWebhooks sets the ManageWebhooks field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ManageWebhooks field is set to the value of the last call.
+func (b *GitRepositorySpecApplyConfiguration) WithManageWebhooks(value *ManageWebhooksApplyConfiguration) *GitRepositorySpecApplyConfiguration {
+	b.ManageWebhooks = value
+	return b
+}
+
+// WithPropagateLabels adds the given value to the PropagateLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PropagateLabels field.
+func (b *GitRepositorySpecApplyConfiguration) WithPropagateLabels(values ...string) *GitRepositorySpecApplyConfiguration {
+	for i := range values {
+		b.PropagateLabels = append(b.PropagateLabels, values[i])
+	}
+	return b
+}
@@ -18,6 +18,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
@@ -33,6 +34,26 @@ type GitRepositoryStatusApplyConfiguration struct {
 	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
 	// Conditions Represents the observations of the current state.
 	Conditions []v1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+	// DefaultBranch is the repository's default branch, as reported by the SCM.
+	DefaultBranch *string `json:"defaultBranch,omitempty"`
+	// HttpsCloneUrl is the URL to clone the repository over HTTPS, as reported by the SCM.
+	HttpsCloneUrl *string `json:"httpsCloneUrl,omitempty"`
+	// SshCloneUrl is the URL to clone the repository over SSH, as reported by the SCM.
+	SshCloneUrl *string `json:"sshCloneUrl,omitempty"`
+	// RepositoryID is the SCM's internal ID of the repository, such as the numeric project ID in GitLab. It is empty for
+	// SCMs whose repositories are only looked up with git.
+	RepositoryID *string `json:"repositoryId,omitempty"`
+	// WebhookID is the SCM's ID of the webhook registered for spec.manageWebhooks.
+	WebhookID *string `json:"webhookId,omitempty"`
+	// GitLab holds the ID of the GitLab project, looked up for spec.gitlab so that the GitLab provider doesn't look
+	// it up for every API call.
+	GitLab *GitLabRepositoryStatusApplyConfiguration `json:"gitlab,omitempty"`
+	// AzureDevOps holds the IDs of the Azure DevOps project and repository, looked up for spec.azureDevOps.
+	AzureDevOps *AzureDevOpsRepositoryStatusApplyConfiguration `json:"azureDevOps,omitempty"`
+	// LastWebhookDelivery is when a replica of the controller last received a verified webhook delivery for the
+	// repository. It is refreshed at most once a minute, so that every replica and shard of the controller polls less
+	// while webhooks are delivered.
+	LastWebhookDelivery *metav1.Time `json:"lastWebhookDelivery,omitempty"`
 }
 
 // GitRepositoryStatusApplyConfiguration constructs a declarative configuration of the GitRepositoryStatus type for use with
@@ -61,3 +82,67 @@ func (b *GitRepositoryStatusApplyConfiguration) WithConditions(values ...*v1.Con
 	}
 	return b
 }
+
+// WithDefaultBranch sets the DefaultBranch field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultBranch field is set to the value of the last call.
+func (b *GitRepositoryStatusApplyConfiguration) WithDefaultBranch(value string) *GitRepositoryStatusApplyConfiguration {
+	b.DefaultBranch = &value
+	return b
+}
+
+// WithHttpsCloneUrl sets the HttpsCloneUrl field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HttpsCloneUrl field is set to the value of the last call.
+func (b *GitRepositoryStatusApplyConfiguration) WithHttpsCloneUrl(value string) *GitRepositoryStatusApplyConfiguration {
+	b.HttpsCloneUrl = &value
+	return b
+}
+
+// WithSshCloneUrl sets the SshCloneUrl field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SshCloneUrl field is set to the value of the last call.
+func (b *GitRepositoryStatusApplyConfiguration) WithSshCloneUrl(value string) *GitRepositoryStatusApplyConfiguration {
+	b.SshCloneUrl = &value
+	return b
+}
+
+// WithRepositoryID sets the RepositoryID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RepositoryID field is set to the value of the last call.
+func (b *GitRepositoryStatusApplyConfiguration) WithRepositoryID(value string) *GitRepositoryStatusApplyConfiguration {
+	b.RepositoryID = &value
+	return b
+}
+
+// WithWebhookID sets the WebhookID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WebhookID field is set to the value of the last call.
+func (b *GitRepositoryStatusApplyConfiguration) WithWebhookID(value string) *GitRepositoryStatusApplyConfiguration {
+	b.WebhookID = &value
+	return b
+}
+
+// WithGitLab sets the GitLab field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GitLab field is set to the value of the last call.
+func (b *GitRepositoryStatusApplyConfiguration) WithGitLab(value *GitLabRepositoryStatusApplyConfiguration) *GitRepositoryStatusApplyConfiguration {
+	b.GitLab = value
+	return b
+}
+
+// WithAzureDevOps sets the AzureDevOps field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AzureDevOps field is set to the value of the last call.
+func (b *GitRepositoryStatusApplyConfiguration) WithAzureDevOps(value *AzureDevOpsRepositoryStatusApplyConfiguration) *GitRepositoryStatusApplyConfiguration {
+	b.AzureDevOps = value
+	return b
+}
+
+// WithLastWebhookDelivery sets the LastWebhookDelivery field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastWebhookDelivery field is set to the value of the last call.
+func (b *GitRepositoryStatusApplyConfiguration) WithLastWebhookDelivery(value metav1.Time) *GitRepositoryStatusApplyConfiguration {
+	b.LastWebhookDelivery = &value
+	return b
+}
@@ -17,6 +17,10 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	apiv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
 // HistoryApplyConfiguration represents a declarative configuration of the History type for use
 // with apply.
 //
@@ -28,6 +32,17 @@ type HistoryApplyConfiguration struct {
 	Active *CommitBranchStateApplyConfiguration `json:"active,omitempty"`
 	// PullRequest is the state of the pull request that was created for this ChangeTransferPolicy.
 	PullRequest *PullRequestCommonStatusApplyConfiguration `json:"pullRequest,omitempty"`
+	// AutoRevert is the name of the RevertCommit that the PromotionStrategy created to revert this promotion
+	// automatically, because the environment's active commit statuses started failing shortly after it. It is only set
+	// in the history of a PromotionStrategy.
+	AutoRevert *string `json:"autoRevert,omitempty"`
+	// Kind is revert for a promotion that rolled the environment back for a RevertCommit, and empty for a forward
+	// promotion. It is only set in the history of a PromotionStrategy.
+	Kind *apiv1alpha1.HistoryKind `json:"kind,omitempty"`
+	// RevertCommitRef is the RevertCommit that a revert was promoted for.
+	RevertCommitRef *ObjectReferenceApplyConfiguration `json:"revertCommitRef,omitempty"`
+	// RevertedSha is the commit that a revert reverted, the sha of its RevertCommit.
+	RevertedSha *string `json:"revertedSha,omitempty"`
 }
 
 // HistoryApplyConfiguration constructs a declarative configuration of the History type for use with
@@ -59,3 +74,35 @@ func (b *HistoryApplyConfiguration) WithPullRequest(value *PullRequestCommonStat
 	b.PullRequest = value
 	return b
 }
+
+// WithAutoRevert sets the AutoRevert field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AutoRevert field is set to the value of the last call.
+func (b *HistoryApplyConfiguration) WithAutoRevert(value string) *HistoryApplyConfiguration {
+	b.AutoRevert = &value
+	return b
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *HistoryApplyConfiguration) WithKind(value apiv1alpha1.HistoryKind) *HistoryApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithRevertCommitRef sets the RevertCommitRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RevertCommitRef field is set to the value of the last call.
+func (b *HistoryApplyConfiguration) WithRevertCommitRef(value *ObjectReferenceApplyConfiguration) *HistoryApplyConfiguration {
+	b.RevertCommitRef = value
+	return b
+}
+
+// WithRevertedSha sets the RevertedSha field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RevertedSha field is set to the value of the last call.
+func (b *HistoryApplyConfiguration) WithRevertedSha(value string) *HistoryApplyConfiguration {
+	b.RevertedSha = &value
+	return b
+}
@@ -39,8 +39,21 @@ type PromotionStrategySpecApplyConfiguration struct {
 	// The commit statuses specified in this field apply to all environments in the promotion sequence. You can also
 	// specify commit statuses for individual environments in the `environments` field.
 	ProposedCommitStatuses []CommitStatusSelectorApplyConfiguration `json:"proposedCommitStatuses,omitempty"`
+	// ProposedBranchTemplate is a Go template that renders the name of each environment's proposed branch, which is the
+	// branch the hydrator writes proposed changes to. It is rendered with .Branch set to the environment's branch, and
+	// defaults to "{{ .Branch }}-next". Environments can override it with their own proposedBranchTemplate.
+	//
+	// The rendered name is stored on the environment's ChangeTransferPolicy and cannot change once it was created.
+	ProposedBranchTemplate *string `json:"proposedBranchTemplate,omitempty"`
+	// PullRequest is merged into the pull requests the promoter opens for every environment. Environments can
+	// override it with their own pullRequest.
+	PullRequest *PullRequestTemplateSpecApplyConfiguration `json:"pullRequest,omitempty"`
 	// Environments is the sequence of environments that a dry commit will be promoted through.
 	Environments []EnvironmentApplyConfiguration `json:"environments,omitempty"`
+	// PropagateLabels lists the keys of the labels and annotations of this PromotionStrategy that are copied to the
+	// ChangeTransferPolicies, PullRequests and CommitStatuses the promoter creates for it, and kept in sync with it.
+	// They take precedence over the GitRepository's. Keys in the promoter.argoproj.io domain are never copied.
+	PropagateLabels []string `json:"propagateLabels,omitempty"`
 }
 
 // PromotionStrategySpecApplyConfiguration constructs a declarative configuration of the PromotionStrategySpec type for use with
@@ -83,6 +96,22 @@ func (b *PromotionStrategySpecApplyConfiguration) WithProposedCommitStatuses(val
 	return b
 }
 
+// WithProposedBranchTemplate sets the ProposedBranchTemplate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProposedBranchTemplate field is set to the value of the last call.
+func (b *PromotionStrategySpecApplyConfiguration) WithProposedBranchTemplate(value string) *PromotionStrategySpecApplyConfiguration {
+	b.ProposedBranchTemplate = &value
+	return b
+}
+
+// WithPullRequest sets the PullRequest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PullRequest field is set to the value of the last call.
+func (b *PromotionStrategySpecApplyConfiguration) WithPullRequest(value *PullRequestTemplateSpecApplyConfiguration) *PromotionStrategySpecApplyConfiguration {
+	b.PullRequest = value
+	return b
+}
+
 // WithEnvironments adds the given value to the Environments field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Environments field.
@@ -95,3 +124,13 @@ func (b *PromotionStrategySpecApplyConfiguration) WithEnvironments(values ...*En
 	}
 	return b
 }
+
+// WithPropagateLabels adds the given value to the PropagateLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PropagateLabels field.
+func (b *PromotionStrategySpecApplyConfiguration) WithPropagateLabels(values ...string) *PromotionStrategySpecApplyConfiguration {
+	for i := range values {
+		b.PropagateLabels = append(b.PropagateLabels, values[i])
+	}
+	return b
+}
@@ -18,6 +18,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	apiv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
@@ -33,6 +34,17 @@ type PromotionStrategyStatusApplyConfiguration struct {
 	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
 	// Environments holds the status of each environment in the promotion sequence.
 	Environments []EnvironmentStatusApplyConfiguration `json:"environments,omitempty"`
+	// Summary is the active dry commit of each environment in the promotion sequence, abbreviated, such as
+	// "environment/dev=abc1234, environment/prod=9f8e7d6". It is for display.
+	Summary *string `json:"summary,omitempty"`
+	// LastEnvironmentState is whether the last environment in the promotion sequence runs the change proposed for it.
+	// It is for display.
+	LastEnvironmentState *apiv1alpha1.EnvironmentPromotionState `json:"lastEnvironmentState,omitempty"`
+	// LastEnvironmentDryShortSha is the abbreviated active dry commit of the last environment in the promotion
+	// sequence. It is for display.
+	LastEnvironmentDryShortSha *string `json:"lastEnvironmentDryShortSha,omitempty"`
+	// Polling shows how often the PromotionStrategy is polled and whether webhooks are delivered for its repository.
+	Polling *PollingStatusApplyConfiguration `json:"polling,omitempty"`
 	// Conditions Represents the observations of the current state.
 	Conditions []v1.ConditionApplyConfiguration `json:"conditions,omitempty"`
 }
@@ -64,6 +76,38 @@ func (b *PromotionStrategyStatusApplyConfiguration) WithEnvironments(values ...*
 	return b
 }
 
+// WithSummary sets the Summary field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Summary field is set to the value of the last call.
+func (b *PromotionStrategyStatusApplyConfiguration) WithSummary(value string) *PromotionStrategyStatusApplyConfiguration {
+	b.Summary = &value
+	return b
+}
+
+// WithLastEnvironmentState sets the LastEnvironmentState field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastEnvironmentState field is set to the value of the last call.
+func (b *PromotionStrategyStatusApplyConfiguration) WithLastEnvironmentState(value apiv1alpha1.EnvironmentPromotionState) *PromotionStrategyStatusApplyConfiguration {
+	b.LastEnvironmentState = &value
+	return b
+}
+
+// WithLastEnvironmentDryShortSha sets the LastEnvironmentDryShortSha field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastEnvironmentDryShortSha field is set to the value of the last call.
+func (b *PromotionStrategyStatusApplyConfiguration) WithLastEnvironmentDryShortSha(value string) *PromotionStrategyStatusApplyConfiguration {
+	b.LastEnvironmentDryShortSha = &value
+	return b
+}
+
+// WithPolling sets the Polling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Polling field is set to the value of the last call.
+func (b *PromotionStrategyStatusApplyConfiguration) WithPolling(value *PollingStatusApplyConfiguration) *PromotionStrategyStatusApplyConfiguration {
+	b.Polling = value
+	return b
+}
+
 // WithConditions adds the given value to the Conditions field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Conditions field.
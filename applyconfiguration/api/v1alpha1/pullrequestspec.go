@@ -47,6 +47,15 @@ type PullRequestSpecApplyConfiguration struct {
 	// State of the pull request (closed, merged, or open). Must always be "open" when creating a new pull request.
 	// This value may not be changed to "closed" or "merged" unless the pull request status.id is set.
 	State *apiv1alpha1.PullRequestState `json:"state,omitempty"`
+	// Labels are added to the pull request on the SCM. Labels that were added to it on the SCM are left alone.
+	Labels []string `json:"labels,omitempty"`
+	// Reviewers are requested to review the pull request when it is opened. Teams are given as organization/team.
+	Reviewers []string `json:"reviewers,omitempty"`
+	// MergeStrategy is how the pull request is merged, either merge or squash. Defaults to merge.
+	MergeStrategy *apiv1alpha1.PullRequestMergeStrategy `json:"mergeStrategy,omitempty"`
+	// Draft opens the pull request as a draft. Draft pull requests are not merged automatically: they have to be
+	// marked as ready and merged on the SCM.
+	Draft *bool `json:"draft,omitempty"`
 }
 
 // PullRequestSpecApplyConfiguration constructs a declarative configuration of the PullRequestSpec type for use with
@@ -118,3 +127,39 @@ func (b *PullRequestSpecApplyConfiguration) WithState(value apiv1alpha1.PullRequ
 	b.State = &value
 	return b
 }
+
+// WithLabels adds the given value to the Labels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Labels field.
+func (b *PullRequestSpecApplyConfiguration) WithLabels(values ...string) *PullRequestSpecApplyConfiguration {
+	for i := range values {
+		b.Labels = append(b.Labels, values[i])
+	}
+	return b
+}
+
+// WithReviewers adds the given value to the Reviewers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Reviewers field.
+func (b *PullRequestSpecApplyConfiguration) WithReviewers(values ...string) *PullRequestSpecApplyConfiguration {
+	for i := range values {
+		b.Reviewers = append(b.Reviewers, values[i])
+	}
+	return b
+}
+
+// WithMergeStrategy sets the MergeStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MergeStrategy field is set to the value of the last call.
+func (b *PullRequestSpecApplyConfiguration) WithMergeStrategy(value apiv1alpha1.PullRequestMergeStrategy) *PullRequestSpecApplyConfiguration {
+	b.MergeStrategy = &value
+	return b
+}
+
+// WithDraft sets the Draft field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Draft field is set to the value of the last call.
+func (b *PullRequestSpecApplyConfiguration) WithDraft(value bool) *PullRequestSpecApplyConfiguration {
+	b.Draft = &value
+	return b
+}
@@ -49,6 +49,15 @@ type PullRequestStatusApplyConfiguration struct {
 	// The PullRequest resource will be deleted after this flag is set when possible, but the status is
 	// preserved in the owning ChangeTransferPolicy to maintain a record.
 	ExternallyMergedOrClosed *bool `json:"externallyMergedOrClosed,omitempty"`
+	// Labels are the spec.labels the controller last added to the pull request on the SCM. Labels that are removed from
+	// spec.labels are removed from the pull request, the ones added on the SCM are left alone.
+	Labels []string `json:"labels,omitempty"`
+	// Reviewers are the spec.reviewers the controller last requested reviews from. The review requests of reviewers
+	// that are removed from spec.reviewers are removed from the pull request.
+	Reviewers []string `json:"reviewers,omitempty"`
+	// Draft is the spec.draft the pull request was last opened or updated with on the SCM. The pull request is only
+	// converted to or from a draft when spec.draft changes, so marking it as ready on the SCM is left alone.
+	Draft *bool `json:"draft,omitempty"`
 	// Conditions Represents the observations of the current state.
 	Conditions []metav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
 }
@@ -107,6 +116,34 @@ func (b *PullRequestStatusApplyConfiguration) WithExternallyMergedOrClosed(value
 	return b
 }
 
+// WithLabels adds the given value to the Labels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Labels field.
+func (b *PullRequestStatusApplyConfiguration) WithLabels(values ...string) *PullRequestStatusApplyConfiguration {
+	for i := range values {
+		b.Labels = append(b.Labels, values[i])
+	}
+	return b
+}
+
+// WithReviewers adds the given value to the Reviewers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Reviewers field.
+func (b *PullRequestStatusApplyConfiguration) WithReviewers(values ...string) *PullRequestStatusApplyConfiguration {
+	for i := range values {
+		b.Reviewers = append(b.Reviewers, values[i])
+	}
+	return b
+}
+
+// WithDraft sets the Draft field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Draft field is set to the value of the last call.
+func (b *PullRequestStatusApplyConfiguration) WithDraft(value bool) *PullRequestStatusApplyConfiguration {
+	b.Draft = &value
+	return b
+}
+
 // WithConditions adds the given value to the Conditions field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Conditions field.
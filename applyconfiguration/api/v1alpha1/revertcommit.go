@@ -18,7 +18,6 @@ limitations under the License.
 package v1alpha1
 
 import (
-	apiv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
@@ -31,8 +30,8 @@ import (
 type RevertCommitApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
-	Spec                             *RevertCommitSpecApplyConfiguration `json:"spec,omitempty"`
-	Status                           *apiv1alpha1.RevertCommitStatus     `json:"status,omitempty"`
+	Spec                             *RevertCommitSpecApplyConfiguration   `json:"spec,omitempty"`
+	Status                           *RevertCommitStatusApplyConfiguration `json:"status,omitempty"`
 }
 
 // RevertCommit constructs a declarative configuration of the RevertCommit type for use with
@@ -217,8 +216,8 @@ func (b *RevertCommitApplyConfiguration) WithSpec(value *RevertCommitSpecApplyCo
 // WithStatus sets the Status field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Status field is set to the value of the last call.
-func (b *RevertCommitApplyConfiguration) WithStatus(value apiv1alpha1.RevertCommitStatus) *RevertCommitApplyConfiguration {
-	b.Status = &value
+func (b *RevertCommitApplyConfiguration) WithStatus(value *RevertCommitStatusApplyConfiguration) *RevertCommitApplyConfiguration {
+	b.Status = value
 	return b
 }
 
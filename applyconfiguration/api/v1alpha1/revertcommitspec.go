@@ -14,9 +14,12 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 // Code generated by controller-gen-v0.20. DO NOT EDIT.
-
 package v1alpha1
 
+import (
+	apiv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
 // RevertCommitSpecApplyConfiguration represents a declarative configuration of the RevertCommitSpec type for use
 // with apply.
 //
@@ -24,8 +27,46 @@ package v1alpha1
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 // RevertCommitSpec defines the desired state of RevertCommit
 type RevertCommitSpecApplyConfiguration struct {
-	// Foo is an example field of RevertCommit. Edit revertcommit_types.go to remove/update
-	Foo *string `json:"foo,omitempty"`
+	// PromotionStrategyRef is a reference to the PromotionStrategy whose repository contains the commit to revert.
+	PromotionStrategyRef *ObjectReferenceApplyConfiguration `json:"promotionStrategyRef,omitempty"`
+	// DryBranch is the branch that contains the commit to revert. The revert pull request targets this branch, so the
+	// revert is hydrated and promoted through the environments like any other change. When target is hydrated, it is
+	// the dry branch the environment is hydrated from.
+	DryBranch *string `json:"dryBranch,omitempty"`
+	// Sha is the commit to revert, a dry commit unless target is hydrated. A merge commit is reverted relative to its
+	// first parent.
+	// Supports both SHA-1 (40 chars) and SHA-256 (64 chars) Git hash formats.
+	Sha *string `json:"sha,omitempty"`
+	// Environments are the active branches of the environments to revert the commit in. When set, the dry branch is
+	// left alone: each environment is instead returned to the manifests it ran before the commit was promoted to it.
+	// They are proposed on the environment's proposed branch and promoted through its usual commit statuses. Anything
+	// promoted to the environment after the commit is rolled back as well. Every environment must be part of the
+	// PromotionStrategy. When target is hydrated, it must be exactly the one environment whose active branch
+	// contains spec.sha.
+	Environments []string `json:"environments,omitempty"`
+	// Target is the kind of commit spec.sha is. With dry, the default, it is a dry commit. With hydrated, it is a
+	// hydrated commit that is reverted directly on the active branch of the environment in spec.environments, without
+	// waiting for the revert to be hydrated. This is a break-glass path for when hydration itself is broken: the revert
+	// is merged through a pull request, so branch protection still applies, but the environment's proposed commit
+	// statuses are not. The revert commit carries an Emergency-revert trailer, and the PromotionStrategy holds
+	// auto-merge for the environment until a different dry commit is hydrated to it.
+	Target *apiv1alpha1.RevertTarget `json:"target,omitempty"`
+	// ConfirmEmergency must be true when target is hydrated, to acknowledge that the revert bypasses hydration.
+	ConfirmEmergency *bool `json:"confirmEmergency,omitempty"`
+	// MaxDistanceFromTip is how many commits may be on top of spec.sha before it is checked whether they change the
+	// same paths. If they do, the commit isn't reverted unless force is true. Defaults to 0: only the tip of the branch
+	// is reverted without the check.
+	MaxDistanceFromTip *int `json:"maxDistanceFromTip,omitempty"`
+	// Force reverts spec.sha even if newer commits change the same paths. The revert may still conflict.
+	Force *bool `json:"force,omitempty"`
+	// CommitMessageTemplate is a Go template that renders the message of the revert commit instead of git's default.
+	// Its first line is also the title of the revert pull request, and the rest its description. It is rendered with
+	// .Sha, .ShortSha, .Subject and .Author of the reverted commit, .Name and .Namespace of the RevertCommit, and
+	// .TemplateFields. Sprig functions are available except env, expandenv and getHostByName.
+	CommitMessageTemplate *string `json:"commitMessageTemplate,omitempty"`
+	// TemplateFields are free-form values for commitMessageTemplate, such as an incident ticket. A field the template
+	// uses must be set.
+	TemplateFields map[string]string `json:"templateFields,omitempty"`
 }
 
 // RevertCommitSpecApplyConfiguration constructs a declarative configuration of the RevertCommitSpec type for use with
@@ -34,10 +75,90 @@ func RevertCommitSpec() *RevertCommitSpecApplyConfiguration {
 	return &RevertCommitSpecApplyConfiguration{}
 }
 
-// WithFoo sets the Foo field in the declarative configuration to the given value
+// WithPromotionStrategyRef sets the PromotionStrategyRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PromotionStrategyRef field is set to the value of the last call.
+func (b *RevertCommitSpecApplyConfiguration) WithPromotionStrategyRef(value *ObjectReferenceApplyConfiguration) *RevertCommitSpecApplyConfiguration {
+	b.PromotionStrategyRef = value
+	return b
+}
+
+// WithDryBranch sets the DryBranch field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DryBranch field is set to the value of the last call.
+func (b *RevertCommitSpecApplyConfiguration) WithDryBranch(value string) *RevertCommitSpecApplyConfiguration {
+	b.DryBranch = &value
+	return b
+}
+
+// WithSha sets the Sha field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Sha field is set to the value of the last call.
+func (b *RevertCommitSpecApplyConfiguration) WithSha(value string) *RevertCommitSpecApplyConfiguration {
+	b.Sha = &value
+	return b
+}
+
+// WithEnvironments adds the given value to the Environments field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Environments field.
+func (b *RevertCommitSpecApplyConfiguration) WithEnvironments(values ...string) *RevertCommitSpecApplyConfiguration {
+	for i := range values {
+		b.Environments = append(b.Environments, values[i])
+	}
+	return b
+}
+
+// WithTarget sets the Target field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Target field is set to the value of the last call.
+func (b *RevertCommitSpecApplyConfiguration) WithTarget(value apiv1alpha1.RevertTarget) *RevertCommitSpecApplyConfiguration {
+	b.Target = &value
+	return b
+}
+
+// WithConfirmEmergency sets the ConfirmEmergency field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConfirmEmergency field is set to the value of the last call.
+func (b *RevertCommitSpecApplyConfiguration) WithConfirmEmergency(value bool) *RevertCommitSpecApplyConfiguration {
+	b.ConfirmEmergency = &value
+	return b
+}
+
+// WithMaxDistanceFromTip sets the MaxDistanceFromTip field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxDistanceFromTip field is set to the value of the last call.
+func (b *RevertCommitSpecApplyConfiguration) WithMaxDistanceFromTip(value int) *RevertCommitSpecApplyConfiguration {
+	b.MaxDistanceFromTip = &value
+	return b
+}
+
+// WithForce sets the Force field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
-// If called multiple times, the Foo field is set to the value of the last call.
-func (b *RevertCommitSpecApplyConfiguration) WithFoo(value string) *RevertCommitSpecApplyConfiguration {
-	b.Foo = &value
+// If called multiple times, the Force field is set to the value of the last call.
+func (b *RevertCommitSpecApplyConfiguration) WithForce(value bool) *RevertCommitSpecApplyConfiguration {
+	b.Force = &value
+	return b
+}
+
+// WithCommitMessageTemplate sets the CommitMessageTemplate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CommitMessageTemplate field is set to the value of the last call.
+func (b *RevertCommitSpecApplyConfiguration) WithCommitMessageTemplate(value string) *RevertCommitSpecApplyConfiguration {
+	b.CommitMessageTemplate = &value
+	return b
+}
+
+// WithTemplateFields puts the entries into the TemplateFields field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the TemplateFields field,
+// overwriting an existing map entries in TemplateFields field with the same key.
+func (b *RevertCommitSpecApplyConfiguration) WithTemplateFields(entries map[string]string) *RevertCommitSpecApplyConfiguration {
+	if b.TemplateFields == nil && len(entries) > 0 {
+		b.TemplateFields = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.TemplateFields[k] = v
+	}
 	return b
 }
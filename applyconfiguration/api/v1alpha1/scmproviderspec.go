@@ -43,6 +43,9 @@ type ScmProviderSpecApplyConfiguration struct {
 	AzureDevOps *AzureDevOpsApplyConfiguration `json:"azureDevOps,omitempty"`
 	// Fake required configuration for Fake as the SCM provider
 	Fake *FakeApplyConfiguration `json:"fake,omitempty"`
+	// GitIdentity overrides the controller's git identity for the commits the promoter creates in repositories that use
+	// this provider. A GitRepository's git identity takes precedence.
+	GitIdentity *GitIdentityApplyConfiguration `json:"gitIdentity,omitempty"`
 }
 
 // ScmProviderSpecApplyConfiguration constructs a declarative configuration of the ScmProviderSpec type for use with
@@ -114,3 +117,11 @@ func (b *ScmProviderSpecApplyConfiguration) WithFake(value *FakeApplyConfigurati
 	b.Fake = value
 	return b
 }
+
+// WithGitIdentity sets the GitIdentity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GitIdentity field is set to the value of the last call.
+func (b *ScmProviderSpecApplyConfiguration) WithGitIdentity(value *GitIdentityApplyConfiguration) *ScmProviderSpecApplyConfiguration {
+	b.GitIdentity = value
+	return b
+}
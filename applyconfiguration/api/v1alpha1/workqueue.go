@@ -42,7 +42,8 @@ type WorkQueueApplyConfiguration struct {
 	MaxConcurrentReconciles *int `json:"maxConcurrentReconciles,omitempty"`
 	// RateLimiter defines the rate limiting strategy for the controller's work queue.
 	// Rate limiting controls how quickly failed reconciliations are retried and helps
-	// prevent overwhelming external APIs or systems.
+	// prevent overwhelming external APIs or systems. If it is not set, the controller uses the rate limiter
+	// configured with the controller's --rate-limiter-* flags.
 	RateLimiter *RateLimiterApplyConfiguration `json:"rateLimiter,omitempty"`
 }
 
@@ -31,6 +31,8 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=promoter.argoproj.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("AdaptivePollingConfiguration"):
+		return &apiv1alpha1.AdaptivePollingConfigurationApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ApplicationsSelected"):
 		return &apiv1alpha1.ApplicationsSelectedApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ArgoCDCommitStatus"):
@@ -41,10 +43,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.ArgoCDCommitStatusSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ArgoCDCommitStatusStatus"):
 		return &apiv1alpha1.ArgoCDCommitStatusStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AutoRevert"):
+		return &apiv1alpha1.AutoRevertApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AutoRevertStatus"):
+		return &apiv1alpha1.AutoRevertStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("AzureDevOps"):
 		return &apiv1alpha1.AzureDevOpsApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("AzureDevOpsRepo"):
 		return &apiv1alpha1.AzureDevOpsRepoApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AzureDevOpsRepositoryStatus"):
+		return &apiv1alpha1.AzureDevOpsRepositoryStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("BasicAuth"):
 		return &apiv1alpha1.BasicAuthApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("BearerAuth"):
@@ -75,6 +83,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.CommitMetadataApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("CommitShaState"):
 		return &apiv1alpha1.CommitShaStateApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("CommitSigning"):
+		return &apiv1alpha1.CommitSigningApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("CommitStatus"):
 		return &apiv1alpha1.CommitStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("CommitStatusConfiguration"):
@@ -89,6 +99,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.ControllerConfigurationApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ControllerConfigurationSpec"):
 		return &apiv1alpha1.ControllerConfigurationSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("EmergencyRevertStatus"):
+		return &apiv1alpha1.EmergencyRevertStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Environment"):
 		return &apiv1alpha1.EnvironmentApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("EnvironmentStatus"):
@@ -123,12 +135,18 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.GitHubApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("GitHubRepo"):
 		return &apiv1alpha1.GitHubRepoApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("GitIdentity"):
+		return &apiv1alpha1.GitIdentityApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("GitLab"):
 		return &apiv1alpha1.GitLabApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("GitLabRepo"):
 		return &apiv1alpha1.GitLabRepoApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("GitLabRepositoryStatus"):
+		return &apiv1alpha1.GitLabRepositoryStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("GitRepository"):
 		return &apiv1alpha1.GitRepositoryApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("GitRepositoryConfiguration"):
+		return &apiv1alpha1.GitRepositoryConfigurationApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("GitRepositorySpec"):
 		return &apiv1alpha1.GitRepositorySpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("GitRepositoryStatus"):
@@ -143,6 +161,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.HTTPRequestSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("HydratorMetadata"):
 		return &apiv1alpha1.HydratorMetadataApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("LsRemoteState"):
+		return &apiv1alpha1.LsRemoteStateApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ManageWebhooks"):
+		return &apiv1alpha1.ManageWebhooksApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ModeSpec"):
 		return &apiv1alpha1.ModeSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("OAuth2Auth"):
@@ -153,6 +175,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.OutputSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PollingModeSpec"):
 		return &apiv1alpha1.PollingModeSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PollingStatus"):
+		return &apiv1alpha1.PollingStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PromotionStrategy"):
 		return &apiv1alpha1.PromotionStrategyApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PromotionStrategyConfiguration"):
@@ -173,6 +197,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.PullRequestStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PullRequestTemplate"):
 		return &apiv1alpha1.PullRequestTemplateApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PullRequestTemplateSpec"):
+		return &apiv1alpha1.PullRequestTemplateSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RateLimiter"):
 		return &apiv1alpha1.RateLimiterApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RateLimiterTypes"):
@@ -181,18 +207,26 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.ResponseOutputSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RevertCommit"):
 		return &apiv1alpha1.RevertCommitApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RevertCommitEnvironmentStatus"):
+		return &apiv1alpha1.RevertCommitEnvironmentStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RevertCommitSpec"):
 		return &apiv1alpha1.RevertCommitSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RevertCommitStatus"):
+		return &apiv1alpha1.RevertCommitStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RevisionReference"):
 		return &apiv1alpha1.RevisionReferenceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ScmProvider"):
 		return &apiv1alpha1.ScmProviderApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScmProviderConfiguration"):
+		return &apiv1alpha1.ScmProviderConfigurationApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ScmProviderObjectReference"):
 		return &apiv1alpha1.ScmProviderObjectReferenceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ScmProviderSpec"):
 		return &apiv1alpha1.ScmProviderSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ScmProviderStatus"):
 		return &apiv1alpha1.ScmProviderStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SparseCheckout"):
+		return &apiv1alpha1.SparseCheckoutApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("SuccessSpec"):
 		return &apiv1alpha1.SuccessSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("TimedCommitStatus"):
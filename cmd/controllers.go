@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// allControllers is the value of --controllers that enables every controller.
+const allControllers = "all"
+
+// controllerNames are the names of the controllers --controllers accepts, in the order they are listed in its help.
+var controllerNames = []string{
+	"argocdcommitstatus",
+	"changetransferpolicy",
+	"clusterscmprovider",
+	"commitstatus",
+	"controllerconfiguration",
+	"gitcommitstatus",
+	"gitrepository",
+	"promotionstrategy",
+	"pullrequest",
+	"revertcommit",
+	"scmprovider",
+	"timedcommitstatus",
+	"webrequestcommitstatus",
+}
+
+// controllersFlags is the flag that selects the controllers to run.
+type controllersFlags struct {
+	controllers []string
+}
+
+// bind adds the --controllers flag to flags.
+func (c *controllersFlags) bind(flags *pflag.FlagSet) {
+	flags.StringSliceVar(&c.controllers, "controllers", []string{allControllers},
+		"The controllers to run, so that they can be split between deployments, such as one running the controllers "+
+			"calling the SCMs' APIs and another the controllers cloning repositories. Either \"all\" or any of "+
+			strings.Join(controllerNames, ", ")+". Resources are only reconciled by the deployments running their "+
+			"controller, and each is only requeued by the other controllers of its own deployment.")
+}
+
+// validate returns an error if --controllers names a controller that doesn't exist, or no controller.
+func (c *controllersFlags) validate() error {
+	if len(c.controllers) == 0 {
+		return errors.New("--controllers must name at least one controller")
+	}
+	for _, name := range c.controllers {
+		if name != allControllers && !slices.Contains(controllerNames, name) {
+			return fmt.Errorf("--controllers: unknown controller %q, must be \"all\" or any of %s",
+				name, strings.Join(controllerNames, ", "))
+		}
+	}
+	return nil
+}
+
+// enabled returns whether the controller called name runs.
+func (c *controllersFlags) enabled(name string) bool {
+	return slices.Contains(c.controllers, allControllers) || slices.Contains(c.controllers, name)
+}
+
+// controllerSetup sets up one of the controllers with the manager.
+type controllerSetup struct {
+	// name is the controller's name in --controllers.
+	name string
+	// callsSCM is whether the controller calls the SCMs, so that it can't do its work when they can't be reached.
+	callsSCM bool
+	setup    func() error
+}
+
+// setupControllers sets up the controllers of setups that --controllers enables, in order, and returns them.
+func setupControllers(setups []controllerSetup, c controllersFlags) ([]controllerSetup, error) {
+	var enabled []controllerSetup
+	for _, s := range setups {
+		if !c.enabled(s.name) {
+			continue
+		}
+		if err := s.setup(); err != nil {
+			return nil, fmt.Errorf("unable to create %s controller: %w", s.name, err)
+		}
+		enabled = append(enabled, s)
+	}
+	return enabled, nil
+}
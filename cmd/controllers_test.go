@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestControllersFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name: "subset",
+			args: []string{"--controllers=pullrequest,commitstatus"},
+		},
+		{
+			name: "all and a controller",
+			args: []string{"--controllers=all,pullrequest"},
+		},
+		{
+			name: "unknown controller",
+			args: []string{"--controllers=pullrequest,proposedcommit"},
+			err:  `unknown controller "proposedcommit"`,
+		},
+		{
+			name: "no controller",
+			args: []string{"--controllers="},
+			err:  "must name at least one controller",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var c controllersFlags
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			c.bind(flags)
+			if err := flags.Parse(test.args); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+
+			err := c.validate()
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestControllersFlagsHelpListsEveryController(t *testing.T) {
+	t.Parallel()
+
+	var c controllersFlags
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	c.bind(flags)
+	usage := flags.Lookup("controllers").Usage
+	for _, name := range controllerNames {
+		if !strings.Contains(usage, name) {
+			t.Errorf("expected the help of --controllers to list %q, got %q", name, usage)
+		}
+	}
+}
+
+func TestSetupControllers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		controllers []string
+		expected    []string
+		callsSCM    bool
+	}{
+		{
+			name:        "all",
+			controllers: []string{allControllers},
+			expected:    []string{"pullrequest", "changetransferpolicy", "commitstatus", "promotionstrategy", "timedcommitstatus"},
+			callsSCM:    true,
+		},
+		{
+			name:        "SCM-facing controllers",
+			controllers: []string{"pullrequest", "commitstatus"},
+			expected:    []string{"pullrequest", "commitstatus"},
+			callsSCM:    true,
+		},
+		{
+			name:        "controllers that don't call the SCMs",
+			controllers: []string{"timedcommitstatus", "promotionstrategy"},
+			expected:    []string{"promotionstrategy", "timedcommitstatus"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var setUp []string
+			setup := func(name string, callsSCM bool) controllerSetup {
+				return controllerSetup{name: name, callsSCM: callsSCM, setup: func() error {
+					setUp = append(setUp, name)
+					return nil
+				}}
+			}
+			enabled, err := setupControllers([]controllerSetup{
+				setup("pullrequest", true),
+				setup("changetransferpolicy", true),
+				setup("commitstatus", true),
+				setup("promotionstrategy", false),
+				setup("timedcommitstatus", false),
+			}, controllersFlags{controllers: test.controllers})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(setUp, test.expected) {
+				t.Errorf("expected the controllers %v to be set up, got %v", test.expected, setUp)
+			}
+			var names []string
+			for _, c := range enabled {
+				names = append(names, c.name)
+			}
+			if !slices.Equal(names, test.expected) {
+				t.Errorf("expected the controllers %v to be enabled, got %v", test.expected, names)
+			}
+			if callsSCM := slices.ContainsFunc(enabled, func(c controllerSetup) bool { return c.callsSCM }); callsSCM != test.callsSCM {
+				t.Errorf("expected an enabled controller to call the SCMs to be %t", test.callsSCM)
+			}
+		})
+	}
+}
+
+func TestSetupControllersFails(t *testing.T) {
+	t.Parallel()
+
+	failed := errors.New("failed to watch")
+	_, err := setupControllers([]controllerSetup{
+		{name: "pullrequest", setup: func() error { return failed }},
+		{name: "commitstatus", setup: func() error {
+			t.Error("expected the controllers after the failing one not to be set up")
+			return nil
+		}},
+	}, controllersFlags{controllers: []string{allControllers}})
+	if !errors.Is(err, failed) || !strings.Contains(err.Error(), "pullrequest") {
+		t.Errorf("expected the error to wrap the controller's error and name it, got %v", err)
+	}
+}
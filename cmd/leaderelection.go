@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/tools/leaderelection"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// defaultLeaseDuration, defaultRenewDeadline and defaultRetryPeriod are controller-runtime's defaults.
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+)
+
+// leaderElectionFlags are the flags that tune leader election.
+type leaderElectionFlags struct {
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+	retryPeriod   time.Duration
+}
+
+// bind adds the leader election flags to flags.
+func (l *leaderElectionFlags) bind(flags *pflag.FlagSet) {
+	flags.DurationVar(&l.leaseDuration, "leader-elect-lease-duration", defaultLeaseDuration,
+		"How long a standby controller waits before taking over from a leader that stopped renewing its lease without "+
+			"releasing it, such as one whose node failed. A leader that stops normally releases the lease, so that a "+
+			"standby takes over within --leader-elect-retry-period.")
+	flags.DurationVar(&l.renewDeadline, "leader-elect-renew-deadline", defaultRenewDeadline,
+		"How long the leader keeps trying to renew its lease before it stops leading. Must be shorter than "+
+			"--leader-elect-lease-duration.")
+	flags.DurationVar(&l.retryPeriod, "leader-elect-retry-period", defaultRetryPeriod,
+		"How often the leader renews its lease, and how often standby controllers try to acquire it.")
+}
+
+// validate returns an error if the leader election flags can't work together.
+func (l *leaderElectionFlags) validate() error {
+	if l.retryPeriod <= 0 {
+		return fmt.Errorf("--leader-elect-retry-period %s must be positive", l.retryPeriod)
+	}
+	if l.renewDeadline <= time.Duration(leaderelection.JitterFactor*float64(l.retryPeriod)) {
+		return fmt.Errorf("--leader-elect-renew-deadline %s must be longer than %v times --leader-elect-retry-period %s",
+			l.renewDeadline, leaderelection.JitterFactor, l.retryPeriod)
+	}
+	if l.leaseDuration <= l.renewDeadline {
+		return fmt.Errorf("--leader-elect-lease-duration %s must be longer than --leader-elect-renew-deadline %s",
+			l.leaseDuration, l.renewDeadline)
+	}
+	return nil
+}
+
+// apply sets the leader election options of opts from the flags. The leader releases its lease when the manager
+// stops, which is safe because nothing runs once the manager's Start returns: the cleanups on shutdown, such as
+// removing the cached clones, are runnables that the manager stops before it releases the lease.
+func (l *leaderElectionFlags) apply(opts *ctrl.Options) {
+	opts.LeaseDuration = &l.leaseDuration
+	opts.RenewDeadline = &l.renewDeadline
+	opts.RetryPeriod = &l.retryPeriod
+	opts.LeaderElectionReleaseOnCancel = true
+}
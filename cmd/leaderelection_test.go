@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestLeaderElectionFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name: "faster failover",
+			args: []string{"--leader-elect-lease-duration=6s", "--leader-elect-renew-deadline=4s", "--leader-elect-retry-period=1s"},
+		},
+		{
+			name: "renew deadline as long as the lease duration",
+			args: []string{"--leader-elect-lease-duration=10s"},
+			err:  "must be longer than --leader-elect-renew-deadline",
+		},
+		{
+			name: "retry period as long as the renew deadline",
+			args: []string{"--leader-elect-retry-period=10s"},
+			err:  "must be longer than 1.2 times --leader-elect-retry-period",
+		},
+		{
+			name: "no retry period",
+			args: []string{"--leader-elect-retry-period=0s"},
+			err:  "must be positive",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var l leaderElectionFlags
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			l.bind(flags)
+			if err := flags.Parse(test.args); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+
+			err := l.validate()
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var opts ctrl.Options
+			l.apply(&opts)
+			if *opts.LeaseDuration != l.leaseDuration || *opts.RenewDeadline != l.renewDeadline || *opts.RetryPeriod != l.retryPeriod {
+				t.Errorf("expected the options to have the flags' durations, got %s, %s and %s", *opts.LeaseDuration, *opts.RenewDeadline, *opts.RetryPeriod)
+			}
+			if !opts.LeaderElectionReleaseOnCancel {
+				t.Error("expected the leader to release its lease on cancel")
+			}
+		})
+	}
+}
+
+// TestLeaderElectionFailover runs two candidates with the options of the leader election flags, like two replicas of
+// the controller, and checks that the standby takes over soon after the leader stops, rather than after the lease
+// expires.
+func TestLeaderElectionFailover(t *testing.T) {
+	t.Parallel()
+
+	l := leaderElectionFlags{leaseDuration: 5 * time.Second, renewDeadline: 3 * time.Second, retryPeriod: 100 * time.Millisecond}
+	if err := l.validate(); err != nil {
+		t.Fatalf("invalid flags: %v", err)
+	}
+	var opts ctrl.Options
+	l.apply(&opts)
+
+	clientset := fake.NewClientset()
+	// run runs a candidate until ctx is done, and returns a channel that is closed once it leads.
+	run := func(ctx context.Context, identity string) (leading, stopped chan struct{}) {
+		leading = make(chan struct{})
+		stopped = make(chan struct{})
+		elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+			Lock: &resourcelock.LeaseLock{
+				LeaseMeta:  metav1.ObjectMeta{Namespace: "promoter-system", Name: "b21a50c7.argoproj.io"},
+				Client:     clientset.CoordinationV1(),
+				LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+			},
+			LeaseDuration:   *opts.LeaseDuration,
+			RenewDeadline:   *opts.RenewDeadline,
+			RetryPeriod:     *opts.RetryPeriod,
+			ReleaseOnCancel: opts.LeaderElectionReleaseOnCancel,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(context.Context) { close(leading) },
+				OnStoppedLeading: func() {},
+			},
+		})
+		if err != nil {
+			t.Fatalf("failed to create leader elector: %v", err)
+		}
+		go func() {
+			defer close(stopped)
+			elector.Run(ctx)
+		}()
+		return leading, stopped
+	}
+
+	leaderCtx, stopLeader := context.WithCancel(context.Background())
+	defer stopLeader()
+	leaderLeading, leaderStopped := run(leaderCtx, "leader")
+	select {
+	case <-leaderLeading:
+	case <-time.After(l.leaseDuration):
+		t.Fatal("the first candidate didn't become the leader")
+	}
+
+	standbyCtx, stopStandby := context.WithCancel(context.Background())
+	defer stopStandby()
+	standbyLeading, _ := run(standbyCtx, "standby")
+	select {
+	case <-standbyLeading:
+		t.Fatal("the standby became the leader while the leader was running")
+	case <-time.After(5 * l.retryPeriod):
+	}
+
+	stopLeader()
+	<-leaderStopped
+	stopped := time.Now()
+	select {
+	case <-standbyLeading:
+	case <-time.After(l.leaseDuration):
+		t.Fatalf("the standby didn't take over within the lease duration of %s", l.leaseDuration)
+	}
+	// The standby tries to acquire the lease every retry period, with some jitter.
+	if failover := time.Since(stopped); failover > 3*l.retryPeriod {
+		t.Errorf("expected the standby to take over within about %s, took %s", l.retryPeriod, failover)
+	}
+}
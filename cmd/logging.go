@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+const (
+	logFormatJSON    = "json"
+	logFormatConsole = "console"
+)
+
+// logFlags are the --log-format and --log-level flags. They are shorthands layered over the zap flags, which still
+// configure the details of the logger.
+type logFlags struct {
+	format string
+	level  string
+}
+
+// newLogOptions returns the default options of the logger: console encoded, in development mode, at the info level.
+func newLogOptions() zap.Options {
+	return zap.Options{
+		Development: true,
+		Level:       zapcore.InfoLevel, // default to info; use --log-level=debug or =5 for verbose
+		TimeEncoder: zapcore.RFC3339NanoTimeEncoder,
+	}
+}
+
+// bind adds the zap flags of opts and the log flags to flags.
+func (l *logFlags) bind(flags *pflag.FlagSet, opts *zap.Options) {
+	// Zap only operates on go-type flags. Cobra doesn't give us direct access to those flags.
+	// So we apply the zap flags to a temp go flags set and then transfer them to the cobra flags.
+	tmpZapFlagSet := flag.NewFlagSet("", flag.ContinueOnError)
+	opts.BindFlags(tmpZapFlagSet)
+	// Transfer flags from the temporary FlagSet to cobra's pflag.FlagSet
+	tmpZapFlagSet.VisitAll(func(f *flag.Flag) {
+		flags.AddGoFlag(f)
+	})
+
+	flags.StringVar(&l.format, "log-format", "",
+		"The format of the logs, json or console. json also turns off the development mode of --zap-devel unless it is "+
+			"set. Overrides --zap-encoder. Defaults to console.")
+	flags.StringVar(&l.level, "log-level", "",
+		"The level of the logs: debug, info, error, or an integer for increasingly verbose debug logs, such as 4 for the "+
+			"logs of every reconcile. Overrides --zap-log-level. Defaults to info.")
+}
+
+// apply sets the zap flags in flags, which were parsed, from the log flags.
+func (l *logFlags) apply(flags *pflag.FlagSet) error {
+	switch l.format {
+	case "":
+	case logFormatJSON, logFormatConsole:
+		if l.format == logFormatJSON && !flags.Changed("zap-devel") {
+			if err := flags.Set("zap-devel", "false"); err != nil {
+				return fmt.Errorf("failed to turn off development mode: %w", err)
+			}
+		}
+		if err := flags.Set("zap-encoder", l.format); err != nil {
+			return fmt.Errorf("invalid log format %q: %w", l.format, err)
+		}
+	default:
+		return fmt.Errorf("invalid log format %q: must be %s or %s", l.format, logFormatJSON, logFormatConsole)
+	}
+
+	if l.level != "" {
+		if err := flags.Set("zap-log-level", l.level); err != nil {
+			return fmt.Errorf("invalid log level %q: %w", l.level, err)
+		}
+	}
+	return nil
+}
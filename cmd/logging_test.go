@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+func TestLogFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		args        []string
+		json        bool
+		development bool
+		verbosity   int
+		err         string
+	}{
+		{
+			name:        "defaults",
+			development: true,
+		},
+		{
+			name: "json",
+			args: []string{"--log-format=json"},
+			json: true,
+		},
+		{
+			name:        "json in development mode",
+			args:        []string{"--log-format=json", "--zap-devel=true"},
+			json:        true,
+			development: true,
+		},
+		{
+			name:        "console",
+			args:        []string{"--log-format=console"},
+			development: true,
+		},
+		{
+			name: "zap encoder",
+			args: []string{"--zap-encoder=json", "--zap-devel=false"},
+			json: true,
+		},
+		{
+			name: "log format overrides the zap encoder",
+			args: []string{"--zap-encoder=console", "--log-format=json"},
+			json: true,
+		},
+		{
+			name:        "verbose",
+			args:        []string{"--log-level=4"},
+			development: true,
+			verbosity:   4,
+		},
+		{
+			name:        "log level overrides the zap log level",
+			args:        []string{"--zap-log-level=5", "--log-level=info"},
+			development: true,
+		},
+		{
+			name: "invalid format",
+			args: []string{"--log-format=logfmt"},
+			err:  `invalid log format "logfmt"`,
+		},
+		{
+			name: "invalid level",
+			args: []string{"--log-level=loud"},
+			err:  `invalid log level "loud"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			flags := pflag.NewFlagSet("", pflag.ContinueOnError)
+			opts := newLogOptions()
+			var logs logFlags
+			logs.bind(flags, &opts)
+			if err := flags.Parse(test.args); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+
+			err := logs.apply(flags)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if opts.Development != test.development {
+				t.Errorf("expected development mode %t, got %t", test.development, opts.Development)
+			}
+
+			var out bytes.Buffer
+			logger := zap.New(zap.UseFlagOptions(&opts), zap.WriteTo(&out))
+			logger.Info("info")
+			logger.V(test.verbosity).Info("verbose")
+			logger.V(test.verbosity + 1).Info("too verbose")
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			if len(lines) != 2 {
+				t.Fatalf("expected the info and verbose lines, got %q", out.String())
+			}
+			for _, line := range lines {
+				if isJSON := strings.HasPrefix(line, "{"); isJSON != test.json {
+					t.Errorf("expected JSON %t, got line %q", test.json, line)
+				}
+			}
+		})
+	}
+}
@@ -20,17 +20,22 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
-	"flag"
 	"fmt"
+	"maps"
 	"os"
 	"runtime/debug"
+	"slices"
 	"syscall"
+	"time"
 
-	"go.uber.org/zap/zapcore"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 
 	"github.com/argoproj-labs/gitops-promoter/cmd/demo"
+	"github.com/argoproj-labs/gitops-promoter/internal/audit"
+	"github.com/argoproj-labs/gitops-promoter/internal/cloudevents"
 	"github.com/argoproj-labs/gitops-promoter/internal/controller"
+	"github.com/argoproj-labs/gitops-promoter/internal/git"
+	"github.com/argoproj-labs/gitops-promoter/internal/health"
 	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 	"github.com/argoproj-labs/gitops-promoter/internal/webserver"
@@ -41,18 +46,26 @@ import (
 	"github.com/spf13/pflag"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/github"
 	"github.com/argoproj-labs/gitops-promoter/internal/settings"
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
-	"github.com/argoproj-labs/gitops-promoter/internal/utils/gitpaths"
+	webhookv1alpha1 "github.com/argoproj-labs/gitops-promoter/internal/webhook/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/webhookreceiver"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -61,58 +74,187 @@ import (
 	//+kubebuilder:scaffold:imports
 )
 
+// gracefulShutdownMargin is how much longer than the shutdown drain period the manager waits for its runnables to stop,
+// so that the caches and servers stop after the drained reconciles.
+const gracefulShutdownMargin = 10 * time.Second
+
 var (
 	scheme   = utils.GetScheme()
 	setupLog = ctrl.Log.WithName("setup")
 )
 
+// controllerFlags are the flags of the controller command.
+type controllerFlags struct {
+	metricsAddr                   string
+	webhookReceiverAddr           string
+	webhookReceiverConfig         webhookreceiver.Config
+	cloudEventsConfig             cloudevents.Config
+	auditConfig                   audit.Config
+	tracingConfig                 tracing.Config
+	enableLeaderElection          bool
+	leaderElection                leaderElectionFlags
+	controllers                   controllersFlags
+	sharding                      shardingFlags
+	probeAddr                     string
+	secureMetrics                 bool
+	enableHTTP2                   bool
+	enableAdmissionWebhooks       bool
+	webhookServer                 webhookServerFlags
+	pprofAddr                     string
+	gitSlowCommandThreshold       time.Duration
+	gitOperationTimeout           time.Duration
+	gitIdentity                   git.Identity
+	enableGitLFS                  bool
+	propagateLabels               []string
+	gitRepositoryRequeueDuration  time.Duration
+	scmProviderRequeueDuration    time.Duration
+	eventRateLimitInterval        time.Duration
+	maxFailingScmProviderFraction float64
+	namespaces                    []string
+	gitCloneDir                   string
+	gitCloneDepth                 int32
+	shutdownDrainPeriod           time.Duration
+	rateLimiterConfig             settings.RateLimiterConfig
+}
+
 func newControllerCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
-	var metricsAddr string
-	var enableLeaderElection bool
-	var probeAddr string
-	var secureMetrics bool
-	var enableHTTP2 bool
-	var pprofAddr string
+	var flags controllerFlags
 
 	cmd := &cobra.Command{
 		Use:   "controller",
 		Short: "GitOps Promoter controller",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runController(
-				metricsAddr,
-				probeAddr,
-				pprofAddr,
-				enableLeaderElection,
-				secureMetrics,
-				enableHTTP2,
-				clientConfig,
-			)
+			return runController(flags, clientConfig)
 		},
 	}
 
-	cmd.Flags().StringVar(&metricsAddr, "metrics-bind-address", ":9080", "The address the metric endpoint binds to.")
-	cmd.Flags().StringVar(&probeAddr, "health-probe-bind-address", ":9081", "The address the probe endpoint binds to.")
-	cmd.Flags().StringVar(&pprofAddr, "pprof-bind-address", "",
+	cmd.Flags().StringVar(&flags.metricsAddr, "metrics-bind-address", ":9080", "The address the metric endpoint binds to.")
+	cmd.Flags().StringVar(&flags.webhookReceiverAddr, "webhook-receiver-bind-address", fmt.Sprintf(":%d", constants.WebhookReceiverPort),
+		"The address the webhook receiver binds to. SCM push webhooks sent to it trigger reconciles of the "+
+			"ChangeTransferPolicies tracking the pushed branch.")
+	cmd.Flags().StringVar(&flags.webhookReceiverConfig.SecretName, "webhook-secret-name", "",
+		"Name of a Secret in the controller's namespace whose \"webhookSecret\" key is the secret GitHub and Bitbucket "+
+			"webhook deliveries are signed with and GitLab webhook deliveries send in the X-Gitlab-Token header. If set, "+
+			"GitHub and Bitbucket deliveries without a valid signature and GitLab deliveries without a valid X-Gitlab-Token "+
+			"header are rejected.")
+	cmd.Flags().Int64Var(&flags.webhookReceiverConfig.MaxBodySize, "webhook-receiver-max-body-size", webhookreceiver.DefaultMaxBodySize,
+		"The maximum size in bytes of a webhook delivery's body. Larger deliveries are rejected. Set to 0 to disable.")
+	cmd.Flags().DurationVar(&flags.webhookReceiverConfig.MaxDeliveryAge, "webhook-receiver-max-delivery-age", webhookreceiver.DefaultMaxDeliveryAge,
+		"GitHub webhook deliveries older than this, according to their delivery ID, are rejected. Set to 0 to disable.")
+	cmd.Flags().IPNetSliceVar(&flags.webhookReceiverConfig.BitbucketAllowedNetworks, "webhook-bitbucket-allowed-cidrs", nil,
+		"CIDRs that unsigned Bitbucket webhook deliveries are accepted from, for Bitbucket Cloud plans that can't sign "+
+			"deliveries. If set, unsigned Bitbucket deliveries from other addresses are rejected.")
+	cmd.Flags().StringVar(&flags.webhookReceiverConfig.TLSCertFile, "webhook-receiver-tls-cert-file", "",
+		"Path of the certificate the webhook receiver serves TLS with. Requires --webhook-receiver-tls-key-file. The "+
+			"receiver serves plain HTTP if unset. The certificate and key are reloaded when their files change.")
+	cmd.Flags().StringVar(&flags.webhookReceiverConfig.TLSKeyFile, "webhook-receiver-tls-key-file", "",
+		"Path of the key of the webhook receiver's TLS certificate.")
+	cmd.Flags().StringVar(&flags.webhookReceiverConfig.ClientCAFile, "webhook-receiver-client-ca-file", "",
+		"Path of a CA bundle. If set, the webhook receiver requires clients to present a certificate signed by one of "+
+			"its CAs. Requires TLS.")
+	cmd.Flags().StringVar(&flags.webhookReceiverConfig.BearerTokenFile, "webhook-receiver-bearer-token-file", "",
+		"Path of a file holding a token that GitLab, Gitea, Forgejo and Azure DevOps webhook deliveries must send in an "+
+			"\"Authorization: Bearer\" header. GitHub and Bitbucket deliveries are verified with their signatures instead.")
+	cmd.Flags().StringVar(&flags.cloudEventsConfig.SinkURL, "cloudevents-sink-url", "",
+		"URL of a CloudEvents sink that pull requests being opened and merged, promotions being blocked by failing "+
+			"commit statuses and completed reverts are sent to in the HTTP binary content mode. If unset, no CloudEvents "+
+			"are sent.")
+	cmd.Flags().IntVar(&flags.cloudEventsConfig.BufferSize, "cloudevents-buffer-size", cloudevents.DefaultBufferSize,
+		"The number of CloudEvents held while they wait to be sent. Events emitted while the buffer is full are dropped.")
+	cmd.Flags().IntVar(&flags.auditConfig.ConfigMapRecords, "audit-configmap-records", 0,
+		"The number of audit records of merged promotions kept in a ConfigMap next to each PromotionStrategy, the "+
+			"oldest being dropped first. If 0, no audit ConfigMaps are written.")
+	cmd.Flags().StringVar(&flags.auditConfig.HTTPURL, "audit-http-url", "",
+		"URL that audit records of merged promotions are POSTed to as JSON lines. If unset, no audit records are POSTed.")
+	cmd.Flags().IntVar(&flags.auditConfig.BufferSize, "audit-buffer-size", audit.DefaultBufferSize,
+		"The number of audit records each audit sink holds while they wait to be written. Records recorded while the "+
+			"buffer is full are dropped.")
+	cmd.Flags().StringVar(&flags.tracingConfig.Endpoint, "tracing-otlp-endpoint", "",
+		"URL of an OTLP/HTTP endpoint, such as http://otel-collector:4318/v1/traces, that traces of reconciles, git "+
+			"commands and SCM API requests are exported to. If unset, the standard OTEL_EXPORTER_OTLP_ENDPOINT and "+
+			"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment variables are used, and if those are unset too, tracing is "+
+			"disabled.")
+	cmd.Flags().StringVar(&flags.probeAddr, "health-probe-bind-address", ":9081", "The address the probe endpoint binds to.")
+	cmd.Flags().StringVar(&flags.pprofAddr, "pprof-bind-address", "",
 		"The address the pprof endpoint binds to. If unset, pprof is disabled.")
-	cmd.Flags().BoolVar(&enableLeaderElection, "leader-elect", false,
+	cmd.Flags().BoolVar(&flags.enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
-	cmd.Flags().BoolVar(&secureMetrics, "metrics-secure", false, "If set the metrics endpoint is served securely")
-	cmd.Flags().BoolVar(&enableHTTP2, "enable-http2", false,
-		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flags.leaderElection.bind(cmd.Flags())
+	flags.controllers.bind(cmd.Flags())
+	flags.sharding.bind(cmd.Flags())
+	cmd.Flags().BoolVar(&flags.secureMetrics, "metrics-secure", false,
+		"If set, the metrics endpoint is served over HTTPS and only to clients whose bearer token the API server "+
+			"authenticates and whose user is allowed to get /metrics, such as with the metrics-reader ClusterRole.")
+	cmd.Flags().BoolVar(&flags.enableHTTP2, "enable-http2", false,
+		"If set, HTTP/2 will be enabled for the metrics, webhook and webhook receiver servers")
+	cmd.Flags().BoolVar(&flags.enableAdmissionWebhooks, "enable-admission-webhooks", false,
+		"If set, the admission webhooks validating ScmProviders, ClusterScmProviders, GitRepositories, "+
+			"ChangeTransferPolicies, PromotionStrategies, PullRequests and RevertCommits, defaulting PromotionStrategies "+
+			"and PullRequests and converting PullRequests, ChangeTransferPolicies and PromotionStrategies between "+
+			"v1alpha1 and v1alpha2 are served. Requires a serving certificate, see config/webhook and config/certmanager.")
+	flags.webhookServer.bind(cmd.Flags())
+	cmd.Flags().DurationVar(&flags.gitSlowCommandThreshold, "git-slow-command-threshold", git.DefaultSlowCommandThreshold,
+		"Git commands that run longer than this are logged with their arguments. Set to 0 to disable.")
+	cmd.Flags().DurationVar(&flags.gitOperationTimeout, "git-operation-timeout", git.DefaultOperationTimeout,
+		"Git commands that run longer than this are killed and retried with a backoff. Set to 0 to disable.")
+	cmd.Flags().StringVar(&flags.gitIdentity.Name, "git-identity-name", "",
+		"Author and committer name of the commits the promoter creates, unless a GitRepository or ScmProvider sets one. "+
+			"Defaults to \"GitOps Promoter\".")
+	cmd.Flags().StringVar(&flags.gitIdentity.Email, "git-identity-email", "",
+		"Author and committer email of the commits the promoter creates, unless a GitRepository or ScmProvider sets one. "+
+			"Defaults to \"GitOpsPromoter@argoproj.io\".")
+	cmd.Flags().BoolVar(&flags.enableGitLFS, "enable-git-lfs", false,
+		"Set up the promoter's clones for Git LFS, so that checkouts download LFS objects and pushes upload them. "+
+			"Requires git-lfs to be installed.")
+	cmd.Flags().StringSliceVar(&flags.propagateLabels, "propagate-labels", nil,
+		"Keys of the labels and annotations copied from GitRepositories, PromotionStrategies and ChangeTransferPolicies "+
+			"to the resources the promoter creates for them, in addition to the keys in their propagateLabels field. "+
+			"Keys in the promoter.argoproj.io domain are never copied.")
+	cmd.Flags().DurationVar(&flags.gitRepositoryRequeueDuration, "git-repository-requeue-duration", 0,
+		"How often GitRepositories are checked to exist and be accessible. When set, overrides the ControllerConfiguration's "+
+			"spec.gitRepository.accessCheckInterval, which defaults to 5m.")
+	cmd.Flags().Int32Var(&flags.gitCloneDepth, "git-clone-depth", 0,
+		"Number of commits fetched when the controller clones a repository, unless the ControllerConfiguration sets "+
+			"spec.changeTransferPolicy.cloneDepth or the GitRepository sets spec.cloneDepth. Set to 0 to clone the full history.")
+	cmd.Flags().DurationVar(&flags.scmProviderRequeueDuration, "scm-provider-requeue-duration", 0,
+		"How often the secrets and credentials of ScmProviders and ClusterScmProviders are checked with their SCM. When set, "+
+			"overrides the ControllerConfiguration's spec.scmProvider.credentialsCheckInterval, which defaults to 5m.")
+	cmd.Flags().DurationVar(&flags.eventRateLimitInterval, "event-rate-limit-interval", utils.DefaultEventRateLimitInterval,
+		"Kubernetes events that are the same as one recorded for the same resource within this interval, such as the "+
+			"events of resources that are requeued while they wait, are dropped. Events that keep being repeated are recorded once per interval with how long they have been going on. "+
+			"Set to 0 to record every event.")
+	cmd.Flags().StringVar(&flags.gitCloneDir, "git-clone-dir", git.DefaultCloneDir(),
+		"The directory repositories are cloned in. It must only be used by this controller: anything in it that the "+
+			"controller didn't create is removed at startup, such as the clones of a previous process that was killed.")
+	cmd.Flags().DurationVar(&flags.rateLimiterConfig.BaseDelay, "rate-limiter-base-delay", settings.DefaultRateLimiterBaseDelay,
+		"How long a resource is requeued after its reconcile first fails. The delay doubles with each failure. Used by "+
+			"the controllers whose workQueue in the ControllerConfiguration doesn't set a rateLimiter.")
+	cmd.Flags().DurationVar(&flags.rateLimiterConfig.MaxDelay, "rate-limiter-max-delay", settings.DefaultRateLimiterMaxDelay,
+		"The longest a resource is requeued after its reconcile failed. Used by the controllers whose workQueue in the "+
+			"ControllerConfiguration doesn't set a rateLimiter.")
+	cmd.Flags().Float64Var(&flags.rateLimiterConfig.QPS, "rate-limiter-qps", settings.DefaultRateLimiterQPS,
+		"How many resources each controller requeues per second overall. Used by the controllers whose workQueue in "+
+			"the ControllerConfiguration doesn't set a rateLimiter.")
+	cmd.Flags().IntVar(&flags.rateLimiterConfig.Burst, "rate-limiter-burst", settings.DefaultRateLimiterBurst,
+		"How many resources each controller may requeue at once beyond --rate-limiter-qps. Used by the controllers "+
+			"whose workQueue in the ControllerConfiguration doesn't set a rateLimiter.")
+	cmd.Flags().DurationVar(&flags.shutdownDrainPeriod, "shutdown-drain-period", utils.DefaultShutdownDrainPeriod,
+		"How long the reconciles that are running when the controller is asked to stop are given to finish, such as one "+
+			"that merged a pull request and still has to update the status of its resources. No new reconciles start "+
+			"during this period. The pod's termination grace period should be longer.")
+	cmd.Flags().StringSliceVar(&flags.namespaces, "namespaces", nil,
+		"Namespaces the controller watches and reconciles resources in, in addition to its own namespace, which holds "+
+			"the ControllerConfiguration and the Secrets of ClusterScmProviders. If empty, all namespaces are watched.")
+	cmd.Flags().Float64Var(&flags.maxFailingScmProviderFraction, "readiness-max-failing-scm-provider-fraction", health.DefaultMaxFailingScmProviderFraction,
+		"The readiness check fails when more than this fraction of the ScmProviders and ClusterScmProviders have a False "+
+			"Ready condition, or when every GitHub App installation fails to get a git token. Set to 1 to only consider "+
+			"git tokens.")
 
 	return cmd
 }
 
-func runController(
-	metricsAddr string,
-	probeAddr string,
-	pprofAddr string,
-	enableLeaderElection bool,
-	secureMetrics bool,
-	enableHTTP2 bool,
-	clientConfig clientcmd.ClientConfig,
-) error {
+func runController(flags controllerFlags, clientConfig clientcmd.ClientConfig) error {
 	controllerNamespace, _, err := clientConfig.Namespace()
 	if err != nil {
 		setupLog.Error(err, "failed to get namespace")
@@ -140,13 +282,54 @@ func runController(
 	}
 
 	tlsOpts := []func(*tls.Config){}
-	if !enableHTTP2 {
+	if !flags.enableHTTP2 {
 		tlsOpts = append(tlsOpts, disableHTTP2)
 	}
 
-	webhookServer := webhook.NewServer(webhook.Options{
+	webhookServerOptions := webhook.Options{
 		TLSOpts: tlsOpts,
-	})
+	}
+	flags.webhookServer.apply(&webhookServerOptions)
+	webhookServer := webhook.NewServer(webhookServerOptions)
+
+	flags.webhookReceiverConfig.TLSOpts = tlsOpts
+	if err := flags.webhookReceiverConfig.Validate(); err != nil {
+		panic(fmt.Errorf("invalid webhook receiver configuration: %w", err))
+	}
+	if err := flags.cloudEventsConfig.Validate(); err != nil {
+		panic(fmt.Errorf("invalid CloudEvents configuration: %w", err))
+	}
+	if err := flags.auditConfig.Validate(); err != nil {
+		panic(fmt.Errorf("invalid audit configuration: %w", err))
+	}
+	if err := flags.tracingConfig.Validate(); err != nil {
+		panic(fmt.Errorf("invalid tracing configuration: %w", err))
+	}
+	if err := flags.leaderElection.validate(); err != nil {
+		panic(fmt.Errorf("invalid leader election configuration: %w", err))
+	}
+	if err := flags.rateLimiterConfig.Validate(); err != nil {
+		panic(fmt.Errorf("invalid rate limiter configuration: %w", err))
+	}
+	if err := flags.controllers.validate(); err != nil {
+		panic(fmt.Errorf("invalid controllers configuration: %w", err))
+	}
+	if err := flags.sharding.validate(); err != nil {
+		panic(fmt.Errorf("invalid sharding configuration: %w", err))
+	}
+	if flags.gitCloneDepth < 0 {
+		panic(fmt.Errorf("invalid git configuration: --git-clone-depth %d must not be negative", flags.gitCloneDepth))
+	}
+	if flags.enableAdmissionWebhooks {
+		if err := flags.webhookServer.validate(time.Now()); err != nil {
+			panic(fmt.Errorf("invalid admission webhook server configuration: %w", err))
+		}
+	}
+
+	metricsFilterProvider := metrics.ScrapeLogFilterProvider()
+	if flags.secureMetrics {
+		metricsFilterProvider = metrics.AuthorizedFilterProvider(metricsFilterProvider)
+	}
 
 	// Create the kubeconfig provider with options
 	providerOpts := kubeconfigprovider.Options{
@@ -163,31 +346,44 @@ func runController(
 	// Create the provider first, then the manager with the provider
 	provider := kubeconfigprovider.New(providerOpts)
 
-	mcMgr, err := mcmanager.New(ctrl.GetConfigOrDie(), provider, ctrl.Options{
+	// The controller only caches, and so only watches and reconciles, the resources of the namespaces it is scoped to.
+	// The field indexers and the webhook receiver read from the cache, so they only see those resources too. Of the
+	// Secrets, only the kubeconfig Secrets the provider watches are cached, the others are read through
+	// referencedSecrets.
+	cacheOptions := cache.Options{
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.Secret{}: utils.SecretCacheOptions(providerOpts.Namespace, providerOpts.KubeconfigSecretLabel),
+		},
+	}
+	if len(flags.namespaces) > 0 {
+		cacheOptions.DefaultNamespaces = map[string]cache.Config{controllerNamespace: {}}
+		for _, namespace := range flags.namespaces {
+			cacheOptions.DefaultNamespaces[namespace] = cache.Config{}
+		}
+		setupLog.Info("watching namespaces", "namespaces", slices.Sorted(maps.Keys(cacheOptions.DefaultNamespaces)))
+	}
+
+	mgrOptions := ctrl.Options{
 		Scheme: scheme,
+		Cache:  cacheOptions,
 		Metrics: metricsserver.Options{
-			BindAddress:    metricsAddr,
-			SecureServing:  secureMetrics,
+			BindAddress:    flags.metricsAddr,
+			SecureServing:  flags.secureMetrics,
 			TLSOpts:        tlsOpts,
-			FilterProvider: metrics.ScrapeLogFilterProvider(),
+			FilterProvider: metricsFilterProvider,
 		},
 		WebhookServer:          webhookServer,
-		HealthProbeBindAddress: probeAddr,
-		PprofBindAddress:       pprofAddr,
-		LeaderElection:         enableLeaderElection,
+		HealthProbeBindAddress: flags.probeAddr,
+		PprofBindAddress:       flags.pprofAddr,
+		LeaderElection:         flags.enableLeaderElection,
 		LeaderElectionID:       "b21a50c7.argoproj.io",
-		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
-		// when the Manager ends. This requires the binary to immediately end when the
-		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
-		// speeds up voluntary leader transitions as the new leader don't have to wait
-		// LeaseDuration time first.
-		//
-		// In the default scaffold provided, the program ends immediately after
-		// the manager stops, so would be fine to enable this option. However,
-		// if you are doing or is intended to do any operation such as perform cleanups
-		// after the manager stops then its usage might be unsafe.
-		// LeaderElectionReleaseOnCancel: true,
-	})
+		// Give the running reconciles the drain period to finish before the manager gives up on stopping them.
+		GracefulShutdownTimeout: ptr.To(flags.shutdownDrainPeriod + gracefulShutdownMargin),
+	}
+	flags.leaderElection.apply(&mgrOptions)
+	flags.sharding.apply(&mgrOptions)
+
+	mcMgr, err := mcmanager.New(ctrl.GetConfigOrDie(), provider, mgrOptions)
 	if err != nil {
 		panic(fmt.Errorf("unable to start manager: %w", err))
 	}
@@ -200,125 +396,250 @@ func runController(
 	if err := localManager.Add(metrics.NewResourceCountRunnable(localManager.GetCache())); err != nil {
 		panic(fmt.Errorf("unable to add resource count metrics runnable: %w", err))
 	}
+	if err := localManager.Add(metrics.NewGitCacheRunnable()); err != nil {
+		panic(fmt.Errorf("unable to add git cache metrics runnable: %w", err))
+	}
+
+	git.SetSlowCommandThreshold(flags.gitSlowCommandThreshold)
+	git.SetOperationTimeout(flags.gitOperationTimeout)
+	git.SetDefaultIdentity(flags.gitIdentity)
+	git.SetLFSEnabled(flags.enableGitLFS)
+	if err := git.SetCloneDir(flags.gitCloneDir); err != nil {
+		panic(fmt.Errorf("unable to set git clone directory: %w", err))
+	}
+	utils.SetPropagatedKeys(flags.propagateLabels)
+	utils.SetShutdownDrainPeriod(flags.shutdownDrainPeriod)
+	utils.SetShard(flags.sharding.shard, flags.sharding.totalShards)
 
 	settingsMgr := settings.NewManager(localManager.GetClient(), localManager.GetAPIReader(), settings.ManagerConfig{
-		ControllerNamespace: controllerNamespace,
+		ControllerNamespace:          controllerNamespace,
+		GitRepositoryRequeueDuration: flags.gitRepositoryRequeueDuration,
+		ScmProviderRequeueDuration:   flags.scmProviderRequeueDuration,
+		RateLimiter:                  flags.rateLimiterConfig,
+		CloneDepth:                   flags.gitCloneDepth,
 	})
 
-	processSignalsCtx := ctrl.SetupSignalHandler()
+	if err := localManager.Add(git.NewCloneSweeper(settingsMgr.GetChangeTransferPolicyCloneIdleTimeout)); err != nil {
+		panic(fmt.Errorf("unable to add clone sweeper: %w", err))
+	}
+	if err := localManager.Add(git.NewCloneCleaner()); err != nil {
+		panic(fmt.Errorf("unable to add clone cleaner: %w", err))
+	}
 
-	if err = (&controller.PullRequestReconciler{
-		Client:      localManager.GetClient(),
-		Scheme:      localManager.GetScheme(),
-		Recorder:    localManager.GetEventRecorder("PullRequest"),
-		SettingsMgr: settingsMgr,
-	}).SetupWithManager(processSignalsCtx, localManager); err != nil {
-		panic(fmt.Errorf("unable to create PullRequest controller: %w", err))
+	cloudEventsEmitter := cloudevents.NewEmitter(flags.cloudEventsConfig)
+	if cloudEventsEmitter != nil {
+		if err := localManager.Add(cloudEventsEmitter); err != nil {
+			panic(fmt.Errorf("unable to add CloudEvents emitter: %w", err))
+		}
 	}
-	if err = (&controller.RevertCommitReconciler{
-		Client:   localManager.GetClient(),
-		Scheme:   localManager.GetScheme(),
-		Recorder: localManager.GetEventRecorder("RevertCommit"),
-	}).SetupWithManager(processSignalsCtx, localManager); err != nil {
-		panic(fmt.Errorf("unable to create RevertCommit controller: %w", err))
+
+	auditRecorder := audit.NewRecorder(flags.auditConfig.BufferSize,
+		audit.NewSinks(flags.auditConfig, localManager.GetClient(), localManager.GetAPIReader())...)
+	if auditRecorder != nil {
+		if err := localManager.Add(auditRecorder); err != nil {
+			panic(fmt.Errorf("unable to add audit recorder: %w", err))
+		}
 	}
 
-	// ChangeTransferPolicy controller must be set up first so we can
-	// get the enqueue function to pass to other controllers.
-	ctpReconciler := &controller.ChangeTransferPolicyReconciler{
-		Client:      localManager.GetClient(),
-		Scheme:      localManager.GetScheme(),
-		Recorder:    localManager.GetEventRecorder("ChangeTransferPolicy"),
-		SettingsMgr: settingsMgr,
+	tracingProvider, err := tracing.NewProvider(context.Background(), flags.tracingConfig)
+	if err != nil {
+		panic(fmt.Errorf("unable to create tracing provider: %w", err))
 	}
-	if err = ctpReconciler.SetupWithManager(processSignalsCtx, localManager); err != nil {
-		panic(fmt.Errorf("unable to create ChangeTransferPolicy controller: %w", err))
+	if tracingProvider != nil {
+		if err := localManager.Add(tracingProvider); err != nil {
+			panic(fmt.Errorf("unable to add tracing provider: %w", err))
+		}
 	}
 
-	if err = (&controller.CommitStatusReconciler{
-		Client:      localManager.GetClient(),
-		Scheme:      localManager.GetScheme(),
-		Recorder:    localManager.GetEventRecorder("CommitStatus"),
-		SettingsMgr: settingsMgr,
-		EnqueueCTP:  ctpReconciler.GetEnqueueFunc(),
-	}).SetupWithManager(processSignalsCtx, localManager); err != nil {
-		panic(fmt.Errorf("unable to create CommitStatus controller: %w", err))
+	processSignalsCtx := ctrl.SetupSignalHandler()
+
+	// The reconcilers record their events through a rate limited recorder, so that resources requeued while they wait
+	// don't record the same event on every reconcile, only a summary of it once per interval.
+	eventRecorder := func(name string) events.EventRecorder {
+		return utils.NewRateLimitedRecorder(localManager.GetEventRecorder(name), flags.eventRateLimitInterval)
 	}
 
-	if err = (&controller.PromotionStrategyReconciler{
-		Client:      localManager.GetClient(),
-		Scheme:      localManager.GetScheme(),
-		Recorder:    localManager.GetEventRecorder("PromotionStrategy"),
-		SettingsMgr: settingsMgr,
-		EnqueueCTP:  ctpReconciler.GetEnqueueFunc(),
-	}).SetupWithManager(processSignalsCtx, localManager); err != nil {
-		panic(fmt.Errorf("unable to create PromotionStrategy controller: %w", err))
-	}
-	if err = (&controller.ScmProviderReconciler{
-		Client:   localManager.GetClient(),
-		Scheme:   localManager.GetScheme(),
-		Recorder: localManager.GetEventRecorder("ScmProvider"),
-	}).SetupWithManager(processSignalsCtx, localManager); err != nil {
-		panic(fmt.Errorf("unable to create ScmProvider controller: %w", err))
-	}
-	if err = (&controller.GitRepositoryReconciler{
-		Client:   localManager.GetClient(),
-		Scheme:   localManager.GetScheme(),
-		Recorder: localManager.GetEventRecorder("GitRepository"),
-	}).SetupWithManager(processSignalsCtx, localManager); err != nil {
-		panic(fmt.Errorf("unable to create GitRepository controller: %w", err))
-	}
-
-	if err = (&controller.ArgoCDCommitStatusReconciler{
-		Manager:            mcMgr,
-		SettingsMgr:        settingsMgr,
-		KubeConfigProvider: provider,
-		Recorder:           localManager.GetEventRecorder("ArgoCDCommitStatus"),
-	}).SetupWithManager(processSignalsCtx, mcMgr); err != nil {
-		panic(fmt.Errorf("unable to create ArgoCDCommitStatus controller: %w", err))
-	}
-	if err = (&controller.ControllerConfigurationReconciler{
-		Client: localManager.GetClient(),
-		Scheme: localManager.GetScheme(),
-	}).SetupWithManager(processSignalsCtx, localManager); err != nil {
-		panic(fmt.Errorf("unable to create ControllerConfiguration controller: %w", err))
-	}
-	if err = (&controller.ClusterScmProviderReconciler{
-		Client:      localManager.GetClient(),
-		Scheme:      localManager.GetScheme(),
-		Recorder:    localManager.GetEventRecorder("ClusterScmProvider"),
-		SettingsMgr: settingsMgr,
-	}).SetupWithManager(processSignalsCtx, localManager); err != nil {
-		panic(fmt.Errorf("unable to create ClusterScmProvider controller: %w", err))
+	// The reconcilers and the webhook receiver read the Secrets the promoter's resources reference from an informer for
+	// each of them, started the first time it's read, rather than from the manager's cache.
+	clientset, err := kubernetes.NewForConfig(localManager.GetConfig())
+	if err != nil {
+		panic(fmt.Errorf("unable to create Kubernetes clientset: %w", err))
 	}
-	if err := (&controller.TimedCommitStatusReconciler{
-		Client:      localManager.GetClient(),
-		Scheme:      localManager.GetScheme(),
-		Recorder:    localManager.GetEventRecorder("TimedCommitStatus"),
-		SettingsMgr: settingsMgr,
-		EnqueueCTP:  ctpReconciler.GetEnqueueFunc(),
-	}).SetupWithManager(processSignalsCtx, localManager); err != nil {
-		panic("unable to create TimedCommitStatus controller")
+	referencedSecrets := utils.NewReferencedSecrets(clientset)
+	if err := localManager.Add(referencedSecrets); err != nil {
+		panic(fmt.Errorf("unable to add referenced Secrets informers: %w", err))
 	}
-	if err := (&controller.GitCommitStatusReconciler{
-		Client:      localManager.GetClient(),
+	k8sClient := utils.NewSecretClient(localManager.GetClient(), referencedSecrets)
+
+	prReconciler := &controller.PullRequestReconciler{
+		Client:      k8sClient,
 		Scheme:      localManager.GetScheme(),
-		Recorder:    localManager.GetEventRecorder("GitCommitStatus"),
+		Recorder:    eventRecorder("PullRequest"),
 		SettingsMgr: settingsMgr,
-		EnqueueCTP:  ctpReconciler.GetEnqueueFunc(),
-	}).SetupWithManager(processSignalsCtx, localManager); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "GitCommitStatus")
-		panic(fmt.Errorf("unable to create GitCommitStatus controller: %w", err))
+		CloudEvents: cloudEventsEmitter,
 	}
-	if err := (&controller.WebRequestCommitStatusReconciler{
-		Client:      localManager.GetClient(),
-		Scheme:      localManager.GetScheme(),
-		Recorder:    localManager.GetEventRecorder("WebRequestCommitStatus"),
-		SettingsMgr: settingsMgr,
-		EnqueueCTP:  ctpReconciler.GetEnqueueFunc(),
-	}).SetupWithManager(processSignalsCtx, localManager); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "WebRequestCommitStatus")
-		panic(fmt.Errorf("unable to create WebRequestCommitStatus controller: %w", err))
+
+	// The webhook receiver records the verified deliveries for each repository, the ChangeTransferPolicy and
+	// PromotionStrategy controllers poll less often while they are fresh.
+	webhookDeliveries := webhookreceiver.NewDeliveryTracker()
+
+	// The controllers that push to the repositories share the git operations' push locks.
+	gitRepoManager := git.NewGitRepoManager()
+
+	// ChangeTransferPolicy controller must be set up first so we can
+	// get the enqueue function to pass to other controllers. If it isn't enabled, the enqueue function is nil and the
+	// other controllers don't enqueue ChangeTransferPolicies.
+	ctpReconciler := &controller.ChangeTransferPolicyReconciler{
+		Client:            k8sClient,
+		Scheme:            localManager.GetScheme(),
+		Recorder:          eventRecorder("ChangeTransferPolicy"),
+		SettingsMgr:       settingsMgr,
+		GitRepoManager:    gitRepoManager,
+		WebhookDeliveries: webhookDeliveries,
+	}
+	psReconciler := &controller.PromotionStrategyReconciler{
+		Client:            k8sClient,
+		Scheme:            localManager.GetScheme(),
+		Recorder:          eventRecorder("PromotionStrategy"),
+		SettingsMgr:       settingsMgr,
+		WebhookDeliveries: webhookDeliveries,
+		CloudEvents:       cloudEventsEmitter,
+		Audit:             auditRecorder,
+	}
+
+	enabledControllers, err := setupControllers([]controllerSetup{
+		{name: "pullrequest", callsSCM: true, setup: func() error {
+			return prReconciler.SetupWithManager(processSignalsCtx, localManager)
+		}},
+		{name: "revertcommit", callsSCM: true, setup: func() error {
+			return (&controller.RevertCommitReconciler{
+				Client:         k8sClient,
+				Scheme:         localManager.GetScheme(),
+				Recorder:       eventRecorder("RevertCommit"),
+				SettingsMgr:    settingsMgr,
+				GitRepoManager: gitRepoManager,
+				CloudEvents:    cloudEventsEmitter,
+			}).SetupWithManager(processSignalsCtx, localManager)
+		}},
+		{name: "changetransferpolicy", callsSCM: true, setup: func() error {
+			return ctpReconciler.SetupWithManager(processSignalsCtx, localManager)
+		}},
+		{name: "commitstatus", callsSCM: true, setup: func() error {
+			return (&controller.CommitStatusReconciler{
+				Client:      k8sClient,
+				Scheme:      localManager.GetScheme(),
+				Recorder:    eventRecorder("CommitStatus"),
+				SettingsMgr: settingsMgr,
+				EnqueueCTP:  ctpReconciler.GetEnqueueFunc(),
+			}).SetupWithManager(processSignalsCtx, localManager)
+		}},
+		{name: "promotionstrategy", setup: func() error {
+			psReconciler.EnqueueCTP = ctpReconciler.GetEnqueueFunc()
+			return psReconciler.SetupWithManager(processSignalsCtx, localManager)
+		}},
+		{name: "scmprovider", callsSCM: true, setup: func() error {
+			return (&controller.ScmProviderReconciler{
+				Client:      k8sClient,
+				Scheme:      localManager.GetScheme(),
+				Recorder:    eventRecorder("ScmProvider"),
+				SettingsMgr: settingsMgr,
+				Secrets:     referencedSecrets,
+			}).SetupWithManager(processSignalsCtx, localManager)
+		}},
+		{name: "gitrepository", callsSCM: true, setup: func() error {
+			return (&controller.GitRepositoryReconciler{
+				Client:      k8sClient,
+				Scheme:      localManager.GetScheme(),
+				Recorder:    eventRecorder("GitRepository"),
+				SettingsMgr: settingsMgr,
+				Secrets:     referencedSecrets,
+			}).SetupWithManager(processSignalsCtx, localManager)
+		}},
+		{name: "argocdcommitstatus", callsSCM: true, setup: func() error {
+			return (&controller.ArgoCDCommitStatusReconciler{
+				Manager:            mcMgr,
+				SettingsMgr:        settingsMgr,
+				KubeConfigProvider: provider,
+				Recorder:           eventRecorder("ArgoCDCommitStatus"),
+			}).SetupWithManager(processSignalsCtx, mcMgr)
+		}},
+		{name: "controllerconfiguration", setup: func() error {
+			return (&controller.ControllerConfigurationReconciler{
+				Client:   k8sClient,
+				Scheme:   localManager.GetScheme(),
+				Recorder: eventRecorder("ControllerConfiguration"),
+			}).SetupWithManager(processSignalsCtx, localManager)
+		}},
+		{name: "clusterscmprovider", callsSCM: true, setup: func() error {
+			return (&controller.ClusterScmProviderReconciler{
+				Client:      k8sClient,
+				Scheme:      localManager.GetScheme(),
+				Recorder:    eventRecorder("ClusterScmProvider"),
+				SettingsMgr: settingsMgr,
+				Secrets:     referencedSecrets,
+			}).SetupWithManager(processSignalsCtx, localManager)
+		}},
+		{name: "timedcommitstatus", setup: func() error {
+			return (&controller.TimedCommitStatusReconciler{
+				Client:      k8sClient,
+				Scheme:      localManager.GetScheme(),
+				Recorder:    eventRecorder("TimedCommitStatus"),
+				SettingsMgr: settingsMgr,
+				EnqueueCTP:  ctpReconciler.GetEnqueueFunc(),
+			}).SetupWithManager(processSignalsCtx, localManager)
+		}},
+		{name: "gitcommitstatus", setup: func() error {
+			return (&controller.GitCommitStatusReconciler{
+				Client:      k8sClient,
+				Scheme:      localManager.GetScheme(),
+				Recorder:    eventRecorder("GitCommitStatus"),
+				SettingsMgr: settingsMgr,
+				EnqueueCTP:  ctpReconciler.GetEnqueueFunc(),
+			}).SetupWithManager(processSignalsCtx, localManager)
+		}},
+		{name: "webrequestcommitstatus", callsSCM: true, setup: func() error {
+			return (&controller.WebRequestCommitStatusReconciler{
+				Client:      k8sClient,
+				Scheme:      localManager.GetScheme(),
+				Recorder:    eventRecorder("WebRequestCommitStatus"),
+				SettingsMgr: settingsMgr,
+				EnqueueCTP:  ctpReconciler.GetEnqueueFunc(),
+			}).SetupWithManager(processSignalsCtx, localManager)
+		}},
+	}, flags.controllers)
+	if err != nil {
+		panic(err)
+	}
+	enabledNames := make([]string, 0, len(enabledControllers))
+	for _, c := range enabledControllers {
+		enabledNames = append(enabledNames, c.name)
+	}
+	setupLog.Info("enabled controllers", "controllers", enabledNames)
+	if flags.sharding.totalShards > 1 {
+		setupLog.Info("reconciling a shard of the resources", "shard", flags.sharding.shard, "totalShards", flags.sharding.totalShards)
+	}
+	if flags.enableAdmissionWebhooks {
+		if err := webhookv1alpha1.SetupScmProviderWebhookWithManager(localManager); err != nil {
+			panic(fmt.Errorf("unable to create ScmProvider webhook: %w", err))
+		}
+		if err := webhookv1alpha1.SetupClusterScmProviderWebhookWithManager(localManager, controllerNamespace); err != nil {
+			panic(fmt.Errorf("unable to create ClusterScmProvider webhook: %w", err))
+		}
+		if err := webhookv1alpha1.SetupGitRepositoryWebhookWithManager(localManager); err != nil {
+			panic(fmt.Errorf("unable to create GitRepository webhook: %w", err))
+		}
+		if err := webhookv1alpha1.SetupPullRequestWebhookWithManager(localManager); err != nil {
+			panic(fmt.Errorf("unable to create PullRequest webhook: %w", err))
+		}
+		if err := webhookv1alpha1.SetupChangeTransferPolicyWebhookWithManager(localManager); err != nil {
+			panic(fmt.Errorf("unable to create ChangeTransferPolicy webhook: %w", err))
+		}
+		if err := webhookv1alpha1.SetupPromotionStrategyWebhookWithManager(localManager); err != nil {
+			panic(fmt.Errorf("unable to create PromotionStrategy webhook: %w", err))
+		}
+		if err := webhookv1alpha1.SetupRevertCommitWebhookWithManager(localManager); err != nil {
+			panic(fmt.Errorf("unable to create RevertCommit webhook: %w", err))
+		}
 	}
 	//+kubebuilder:scaffold:builder
 
@@ -328,8 +649,18 @@ func runController(
 	if err := localManager.AddReadyzCheck("readyz", healthz.Ping); err != nil {
 		panic(fmt.Errorf("unable to set up ready check: %w", err))
 	}
+	// The readiness only depends on the SCMs if one of the enabled controllers calls them.
+	if slices.ContainsFunc(enabledControllers, func(c controllerSetup) bool { return c.callsSCM }) {
+		scmConnectivityChecker := health.NewSCMConnectivityChecker(localManager.GetClient(), flags.maxFailingScmProviderFraction, github.GitTokenFailures)
+		if err := localManager.AddReadyzCheck("scm-connectivity", scmConnectivityChecker.Check); err != nil {
+			panic(fmt.Errorf("unable to set up SCM connectivity ready check: %w", err))
+		}
+	}
 
-	whr := webhookreceiver.NewWebhookReceiver(localManager, webhookreceiver.EnqueueFunc(ctpReconciler.GetEnqueueFunc()))
+	flags.webhookReceiverConfig.SecretNamespace = controllerNamespace
+	whr := webhookreceiver.NewWebhookReceiver(localManager, k8sClient, flags.webhookReceiverConfig,
+		webhookreceiver.EnqueueFunc(ctpReconciler.GetEnqueueFunc()), webhookreceiver.EnqueueFunc(prReconciler.GetEnqueueFunc()),
+		webhookreceiver.EnqueueFunc(psReconciler.GetEnqueueFunc()), webhookDeliveries)
 
 	g, ctx := errgroup.WithContext(processSignalsCtx)
 
@@ -348,8 +679,7 @@ func runController(
 	})
 
 	g.Go(func() error {
-		//nolint:lll // long line due to function call with multiple parameters
-		if err := ignoreCanceled(whr.Start(processSignalsCtx, fmt.Sprintf(":%d", constants.WebhookReceiverPort))); err != nil {
+		if err := ignoreCanceled(whr.Start(processSignalsCtx, flags.webhookReceiverAddr)); err != nil {
 			setupLog.Error(err, "unable to start webhook receiver")
 			return err
 		}
@@ -364,14 +694,6 @@ func runController(
 		}
 	}
 
-	setupLog.Info("Cleaning up cloned directories")
-	for _, path := range gitpaths.GetValues() {
-		err := os.RemoveAll(path)
-		if err != nil {
-			setupLog.Error(err, "failed to cleanup directory")
-		}
-		setupLog.Info("cleaning directory", "directory", path)
-	}
 	return nil
 }
 
@@ -430,16 +752,17 @@ func newDashboardCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 func newCommand() *cobra.Command {
 	var clientConfig clientcmd.ClientConfig
 
-	opts := zap.Options{
-		Development: true,
-		Level:       zapcore.InfoLevel, // default to info; use --zap-log-level=debug or =5 for verbose
-		TimeEncoder: zapcore.RFC3339NanoTimeEncoder,
-	}
+	opts := newLogOptions()
+	var logs logFlags
 
 	cmd := &cobra.Command{
 		Use:   "promoter",
 		Short: "GitOps Promoter",
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := logs.apply(cmd.Flags()); err != nil {
+				return err
+			}
+
 			// Create the zap logger
 			zapLogger := zap.New(zap.UseFlagOptions(&opts))
 
@@ -447,19 +770,12 @@ func newCommand() *cobra.Command {
 			ctrl.SetLogger(zapLogger)
 
 			// Configure klog to use the same zap logger so all logs (including k8s client-go)
-			// use the same format (JSON when --zap-encoder=json is set)
+			// use the same format (JSON when --log-format=json is set)
 			klog.SetLogger(zapLogger)
+			return nil
 		},
 	}
-
-	// Zap only operates on go-type flags. Cobra doesn't give us direct access to those flags.
-	// So we apply the zap flags to a temp go flags set and then transfer them to the cobra flags.
-	tmpZapFlagSet := flag.NewFlagSet("", flag.ContinueOnError)
-	opts.BindFlags(tmpZapFlagSet)
-	// Transfer flags from the temporary FlagSet to cobra's pflag.FlagSet
-	tmpZapFlagSet.VisitAll(func(f *flag.Flag) {
-		cmd.PersistentFlags().AddGoFlag(f)
-	})
+	logs.bind(cmd.PersistentFlags(), &opts)
 
 	clientConfig = addKubectlFlags(cmd.PersistentFlags())
 	cmd.AddCommand(newControllerCommand(clientConfig))
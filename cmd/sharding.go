@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// shardingFlags are the flags that split the resources between several controllers.
+type shardingFlags struct {
+	shard       int
+	totalShards int
+}
+
+// bind adds the sharding flags to flags.
+func (s *shardingFlags) bind(flags *pflag.FlagSet) {
+	flags.IntVar(&s.shard, "shard", 0,
+		"The shard of the resources this controller reconciles, from 0 to --total-shards minus 1.")
+	flags.IntVar(&s.totalShards, "total-shards", 1,
+		"The number of controllers the resources are split between, each running with its own --shard. All the "+
+			"resources of a GitRepository, such as its PromotionStrategies and their ChangeTransferPolicies, "+
+			"PullRequests and CommitStatuses, are reconciled by the same shard. With --leader-elect, the replicas of each "+
+			"shard elect their own leader.")
+}
+
+// validate returns an error if --shard isn't one of the --total-shards shards.
+func (s *shardingFlags) validate() error {
+	if s.totalShards < 1 {
+		return fmt.Errorf("--total-shards %d must be at least 1", s.totalShards)
+	}
+	if s.shard < 0 || s.shard >= s.totalShards {
+		return fmt.Errorf("--shard %d must be between 0 and --total-shards %d minus 1", s.shard, s.totalShards)
+	}
+	return nil
+}
+
+// apply sets the leader election ID of opts for the shard, so that each shard has its own leader.
+func (s *shardingFlags) apply(opts *ctrl.Options) {
+	if s.totalShards > 1 {
+		opts.LeaderElectionID = fmt.Sprintf("%s-shard-%d", opts.LeaderElectionID, s.shard)
+	}
+}
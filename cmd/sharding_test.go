@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestShardingFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		args             []string
+		err              string
+		leaderElectionID string
+	}{
+		{
+			name:             "defaults",
+			leaderElectionID: "b21a50c7.argoproj.io",
+		},
+		{
+			name:             "last shard",
+			args:             []string{"--shard=2", "--total-shards=3"},
+			leaderElectionID: "b21a50c7.argoproj.io-shard-2",
+		},
+		{
+			name: "shard out of range",
+			args: []string{"--shard=3", "--total-shards=3"},
+			err:  "must be between 0 and --total-shards 3 minus 1",
+		},
+		{
+			name: "negative shard",
+			args: []string{"--shard=-1", "--total-shards=3"},
+			err:  "must be between 0 and --total-shards 3 minus 1",
+		},
+		{
+			name: "no shards",
+			args: []string{"--total-shards=0"},
+			err:  "must be at least 1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var s shardingFlags
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			s.bind(flags)
+			if err := flags.Parse(test.args); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+
+			err := s.validate()
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			opts := ctrl.Options{LeaderElectionID: "b21a50c7.argoproj.io"}
+			s.apply(&opts)
+			if opts.LeaderElectionID != test.leaderElectionID {
+				t.Errorf("expected the leader election ID %q, got %q", test.leaderElectionID, opts.LeaderElectionID)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// webhookServerFlags are the flags that configure the certificate the admission webhook server serves.
+type webhookServerFlags struct {
+	certDir  string
+	certName string
+	keyName  string
+}
+
+// bind adds the webhook server flags to flags.
+func (w *webhookServerFlags) bind(flags *pflag.FlagSet) {
+	flags.StringVar(&w.certDir, "webhook-cert-dir", filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs"),
+		"The directory holding the certificate and key the admission webhook server serves, such as the mount of the "+
+			"Secret cert-manager issues. They are reloaded when their files change, so that the certificate can be "+
+			"rotated without a restart.")
+	flags.StringVar(&w.certName, "webhook-cert-name", "tls.crt",
+		"The name of the admission webhook server's certificate file in --webhook-cert-dir.")
+	flags.StringVar(&w.keyName, "webhook-key-name", "tls.key",
+		"The name of the admission webhook server's key file in --webhook-cert-dir.")
+}
+
+// validate returns an error if the certificate and key can't be loaded, or if the certificate isn't valid at now, so
+// that the controller fails at startup instead of when the API server calls the webhooks.
+func (w *webhookServerFlags) validate(now time.Time) error {
+	certPath := filepath.Join(w.certDir, w.certName)
+	keyPath := filepath.Join(w.certDir, w.keyName)
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load the webhook server certificate %s and key %s: %w", certPath, keyPath, err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("failed to parse the webhook server certificate %s: %w", certPath, err)
+	}
+	if now.After(leaf.NotAfter) {
+		return fmt.Errorf("the webhook server certificate %s expired at %s", certPath, leaf.NotAfter.Format(time.RFC3339))
+	}
+	if now.Before(leaf.NotBefore) {
+		return fmt.Errorf("the webhook server certificate %s is not valid before %s", certPath, leaf.NotBefore.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// apply sets the certificate options of opts from the flags. The webhook server watches the certificate and key files
+// and reloads them when they change.
+func (w *webhookServerFlags) apply(opts *webhook.Options) {
+	opts.CertDir = w.certDir
+	opts.CertName = w.certName
+	opts.KeyName = w.keyName
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// writeCertificate writes a self-signed certificate valid from notBefore to notAfter and its key to dir, as tls.crt and
+// tls.key.
+func writeCertificate(t *testing.T, dir string, notBefore, notAfter time.Time) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "webhook-service.promoter-system.svc"},
+		DNSNames:     []string{"webhook-service.promoter-system.svc"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "tls.crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "tls.key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+}
+
+func TestWebhookServerFlagsValidate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, dir string)
+		err   string
+	}{
+		{
+			name: "valid certificate",
+			setup: func(t *testing.T, dir string) {
+				t.Helper()
+				writeCertificate(t, dir, now.Add(-time.Hour), now.Add(time.Hour))
+			},
+		},
+		{
+			name:  "missing certificate",
+			setup: func(*testing.T, string) {},
+			err:   "failed to load the webhook server certificate",
+		},
+		{
+			name: "key of another certificate",
+			setup: func(t *testing.T, dir string) {
+				t.Helper()
+				writeCertificate(t, dir, now.Add(-time.Hour), now.Add(time.Hour))
+				other := t.TempDir()
+				writeCertificate(t, other, now.Add(-time.Hour), now.Add(time.Hour))
+				key, err := os.ReadFile(filepath.Join(other, "tls.key"))
+				if err != nil {
+					t.Fatalf("failed to read key: %v", err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, "tls.key"), key, 0o600); err != nil {
+					t.Fatalf("failed to write key: %v", err)
+				}
+			},
+			err: "failed to load the webhook server certificate",
+		},
+		{
+			name: "expired certificate",
+			setup: func(t *testing.T, dir string) {
+				t.Helper()
+				writeCertificate(t, dir, now.Add(-2*time.Hour), now.Add(-time.Hour))
+			},
+			err: "expired at",
+		},
+		{
+			name: "certificate not valid yet",
+			setup: func(t *testing.T, dir string) {
+				t.Helper()
+				writeCertificate(t, dir, now.Add(time.Hour), now.Add(2*time.Hour))
+			},
+			err: "is not valid before",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			test.setup(t, dir)
+
+			var w webhookServerFlags
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			w.bind(flags)
+			if err := flags.Parse([]string{"--webhook-cert-dir=" + dir}); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+
+			err := w.validate(now)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWebhookServerFlagsApply(t *testing.T) {
+	t.Parallel()
+
+	var w webhookServerFlags
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	w.bind(flags)
+	if err := flags.Parse([]string{"--webhook-cert-dir=/certs", "--webhook-cert-name=cert.pem", "--webhook-key-name=key.pem"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+
+	var opts webhook.Options
+	w.apply(&opts)
+	if opts.CertDir != "/certs" || opts.CertName != "cert.pem" || opts.KeyName != "key.pem" {
+		t.Errorf("expected the options to have the flags' paths, got %q, %q and %q", opts.CertDir, opts.CertName, opts.KeyName)
+	}
+}
@@ -0,0 +1,268 @@
+// Package audit keeps an audit trail of the promotion decisions the PromotionStrategy controller observes, such as pull
+// requests being merged, in sinks that outlive the Kubernetes events of the promotions.
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+)
+
+// RecordType is the kind of decision a Record records.
+type RecordType string
+
+const (
+	// TypePromotionMerged is recorded when a pull request promoting a change to an environment that merges
+	// automatically is merged.
+	TypePromotionMerged RecordType = "PromotionMerged"
+	// TypeManualApprovalConsumed is recorded when a pull request promoting a change to an environment that doesn't merge
+	// automatically is merged, which is how a promotion is approved manually.
+	TypeManualApprovalConsumed RecordType = "ManualApprovalConsumed"
+)
+
+const (
+	// ActorPromoter is the actor of a pull request the promoter merged, through its PullRequest resource.
+	ActorPromoter = "gitops-promoter"
+	// ActorSCM is the actor of a pull request merged on the SCM outside the promoter, such as by a person approving the
+	// promotion. The SCM records who merged it.
+	ActorSCM = "scm"
+)
+
+const (
+	// DefaultBufferSize is the default number of records each sink holds while they wait to be written.
+	DefaultBufferSize = 1000
+
+	// maxBatchSize is the most records written to a sink at once.
+	maxBatchSize = 100
+)
+
+// defaultRetryBackoff is how often writing records to a sink is attempted, and how long to wait between the attempts,
+// before they are dropped: 8 attempts over about 2 minutes.
+var defaultRetryBackoff = wait.Backoff{
+	Duration: time.Second,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    8,
+	Cap:      2 * time.Minute,
+}
+
+// Gate is the phase of a commit status that gated a promotion when it was decided.
+type Gate struct {
+	// Key is the key of the commit status.
+	Key string `json:"key"`
+	// Phase is the phase of the commit status.
+	Phase string `json:"phase"`
+}
+
+// Record is an audited promotion decision.
+type Record struct {
+	// Type is the kind of decision.
+	Type RecordType `json:"type"`
+	// Time is when the controller observed the decision.
+	Time time.Time `json:"time"`
+	// Namespace is the namespace of the PromotionStrategy.
+	Namespace string `json:"namespace"`
+	// PromotionStrategy is the name of the PromotionStrategy.
+	PromotionStrategy string `json:"promotionStrategy"`
+	// Environment is the branch of the environment.
+	Environment string `json:"environment"`
+	// DrySha is the dry commit that was promoted.
+	DrySha string `json:"drySha"`
+	// HydratedSha is the hydrated commit of the proposed branch that was promoted.
+	HydratedSha string `json:"hydratedSha,omitempty"`
+	// PullRequestURL is the URL of the pull request on the SCM.
+	PullRequestURL string `json:"pullRequestUrl,omitempty"`
+	// PullRequestCreationTime is when the pull request was opened.
+	PullRequestCreationTime *time.Time `json:"pullRequestCreationTime,omitempty"`
+	// MergeTime is when the pull request was merged, if the promoter merged it.
+	MergeTime *time.Time `json:"mergeTime,omitempty"`
+	// Gates are the commit statuses of the proposed change as they were last evaluated before it was merged.
+	Gates []Gate `json:"gates,omitempty"`
+	// Actor is who made the decision, ActorPromoter or ActorSCM.
+	Actor string `json:"actor"`
+}
+
+// Sink is where records are written to.
+type Sink interface {
+	// Name returns the name of the sink, used in logs and metrics.
+	Name() string
+	// Write writes the records, in the order they were recorded. The records are written again if it returns an error,
+	// so a sink must tolerate writing some of them twice.
+	Write(ctx context.Context, records []Record) error
+}
+
+// Config configures the sinks the Recorder writes records to.
+type Config struct {
+	// ConfigMapRecords is the number of records kept in the audit ConfigMap of each PromotionStrategy. No records are
+	// kept in ConfigMaps if it is 0.
+	ConfigMapRecords int
+	// HTTPURL is the URL records are POSTed to as JSON lines. No records are POSTed if it is empty.
+	HTTPURL string
+	// BufferSize is the number of records each sink holds while they wait to be written. Records recorded while the
+	// buffer is full are dropped.
+	BufferSize int
+}
+
+// Enabled returns whether a sink is configured.
+func (c Config) Enabled() bool {
+	return c.ConfigMapRecords > 0 || c.HTTPURL != ""
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c Config) Validate() error {
+	if c.ConfigMapRecords < 0 {
+		return errors.New("number of ConfigMap records must not be negative")
+	}
+	if c.HTTPURL != "" {
+		httpURL, err := url.Parse(c.HTTPURL)
+		if err != nil {
+			return fmt.Errorf("invalid HTTP URL: %w", err)
+		}
+		if httpURL.Scheme != "http" && httpURL.Scheme != "https" || httpURL.Host == "" {
+			return fmt.Errorf("HTTP URL %q must be an absolute http or https URL", c.HTTPURL)
+		}
+	}
+	if c.Enabled() && c.BufferSize <= 0 {
+		return errors.New("buffer size must be positive")
+	}
+	return nil
+}
+
+// NewSinks returns the sinks the configuration enables. The ConfigMap sink writes with c and reads with reader, which
+// should read from the API server, so that the ConfigMaps aren't cached.
+func NewSinks(config Config, c client.Client, reader client.Reader) []Sink {
+	var sinks []Sink
+	if config.ConfigMapRecords > 0 {
+		sinks = append(sinks, NewConfigMapSink(c, reader, config.ConfigMapRecords))
+	}
+	if config.HTTPURL != "" {
+		sinks = append(sinks, NewHTTPSink(config.HTTPURL))
+	}
+	return sinks
+}
+
+// queue is the buffer of records waiting to be written to a sink.
+type queue struct {
+	sink    Sink
+	records chan Record
+}
+
+// Recorder writes records to its sinks in the background, so that a slow or unavailable sink never slows down or fails
+// a reconcile. Each sink has its own buffer, and records recorded while it is full are dropped. Records a sink fails to
+// write are retried with a backoff, and dropped once the retries are exhausted. Records still buffered when the manager
+// stops are lost. A nil Recorder records nothing.
+type Recorder struct {
+	queues  []queue
+	backoff wait.Backoff
+}
+
+// NewRecorder creates a Recorder that writes to the sinks, holding up to bufferSize records for each, or returns nil if
+// there are no sinks. It must be added to the manager, which starts writing the records.
+func NewRecorder(bufferSize int, sinks ...Sink) *Recorder {
+	if len(sinks) == 0 {
+		return nil
+	}
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	r := &Recorder{backoff: defaultRetryBackoff}
+	for _, sink := range sinks {
+		r.queues = append(r.queues, queue{sink: sink, records: make(chan Record, bufferSize)})
+	}
+	return r
+}
+
+// Record queues the record to be written to each sink without waiting for it. It is dropped for the sinks whose buffer
+// is full.
+func (r *Recorder) Record(ctx context.Context, record Record) {
+	if r == nil {
+		return
+	}
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+	for _, q := range r.queues {
+		select {
+		case q.records <- record:
+		default:
+			metrics.RecordAuditRecordsDropped(q.sink.Name(), metrics.AuditRecordDropBufferFull, 1)
+			log.FromContext(ctx).Info("Audit buffer is full, dropping record", "sink", q.sink.Name(), "type", record.Type,
+				"promotionStrategy", record.PromotionStrategy, "environment", record.Environment, "drySha", record.DrySha)
+		}
+	}
+}
+
+// Start implements manager.Runnable. It writes the recorded records to the sinks until ctx is done.
+func (r *Recorder) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("audit")
+	var wg sync.WaitGroup
+	for _, q := range r.queues {
+		wg.Go(func() {
+			r.write(ctx, logger.WithValues("sink", q.sink.Name()), q)
+		})
+	}
+	wg.Wait()
+	return nil
+}
+
+// write writes the records of the queue to its sink in batches until ctx is done.
+func (r *Recorder) write(ctx context.Context, logger logr.Logger, q queue) {
+	for {
+		var batch []Record
+		select {
+		case <-ctx.Done():
+			return
+		case record := <-q.records:
+			batch = append(batch, record)
+		}
+		// Write the records that were recorded while the previous batch was written along with the first.
+	drain:
+		for len(batch) < maxBatchSize {
+			select {
+			case record := <-q.records:
+				batch = append(batch, record)
+			default:
+				break drain
+			}
+		}
+
+		if err := r.writeWithRetries(ctx, logger, q.sink, batch); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			metrics.RecordAuditRecordsDropped(q.sink.Name(), metrics.AuditRecordDropWriteFailed, len(batch))
+			logger.Error(err, "failed to write audit records, dropping them", "records", len(batch))
+		}
+	}
+}
+
+// writeWithRetries writes the batch to the sink, retrying with the Recorder's backoff until it succeeds, the retries
+// are exhausted or ctx is done. It returns the last error if the batch wasn't written.
+func (r *Recorder) writeWithRetries(ctx context.Context, logger logr.Logger, sink Sink, batch []Record) error {
+	backoff := r.backoff
+	for {
+		err := sink.Write(ctx, batch)
+		if err == nil {
+			return nil
+		}
+		if backoff.Steps <= 1 {
+			return err
+		}
+		logger.V(4).Info("Failed to write audit records, retrying", "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff.Step()):
+		}
+	}
+}
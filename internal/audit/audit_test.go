@@ -0,0 +1,217 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// fakeSink is a Sink that fails the first failures writes and records the batches it writes.
+type fakeSink struct {
+	name     string
+	failures int
+
+	mu      sync.Mutex
+	writes  int
+	written [][]Record
+}
+
+func (s *fakeSink) Name() string {
+	return s.name
+}
+
+func (s *fakeSink) Write(ctx context.Context, records []Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writes++
+	if s.writes <= s.failures {
+		return errors.New("unavailable")
+	}
+	s.written = append(s.written, records)
+	return nil
+}
+
+func (s *fakeSink) records() []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var records []Record
+	for _, batch := range s.written {
+		records = append(records, batch...)
+	}
+	return records
+}
+
+// droppedRecords returns the value of audit_records_dropped_total for the sink and reason.
+func droppedRecords(sink, reason string) float64 {
+	families, err := crmetrics.Registry.Gather()
+	Expect(err).NotTo(HaveOccurred())
+	for _, family := range families {
+		if family.GetName() != "audit_records_dropped_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["sink"] == sink && labels["reason"] == reason {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+var _ = Describe("Recorder", func() {
+	start := func(recorder *Recorder) {
+		recorder.backoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+		ctx, cancel := context.WithCancel(context.Background())
+		stopped := make(chan struct{})
+		DeferCleanup(func() {
+			cancel()
+			Eventually(stopped).Should(BeClosed())
+		})
+		go func() {
+			defer GinkgoRecover()
+			defer close(stopped)
+			Expect(recorder.Start(ctx)).To(Succeed())
+		}()
+	}
+	record := func(drySha string) Record {
+		return Record{Type: TypePromotionMerged, Namespace: "default", PromotionStrategy: "demo", Environment: "environment/production", DrySha: drySha}
+	}
+
+	It("records nothing without sinks", func() {
+		recorder := NewRecorder(10)
+		Expect(recorder).To(BeNil())
+		recorder.Record(context.Background(), record("abc123"))
+	})
+
+	It("writes the records to every sink in order", func() {
+		first := &fakeSink{name: "first"}
+		second := &fakeSink{name: "second"}
+		recorder := NewRecorder(10, first, second)
+		start(recorder)
+
+		recorder.Record(context.Background(), record("abc123"))
+		recorder.Record(context.Background(), record("def456"))
+
+		for _, sink := range []*fakeSink{first, second} {
+			Eventually(sink.records).Should(HaveLen(2))
+			Expect(sink.records()[0].DrySha).To(Equal("abc123"))
+			Expect(sink.records()[0].Time).NotTo(BeZero())
+			Expect(sink.records()[1].DrySha).To(Equal("def456"))
+		}
+	})
+
+	It("retries records a sink fails to write", func() {
+		sink := &fakeSink{name: "flaky", failures: 2}
+		recorder := NewRecorder(10, sink)
+		start(recorder)
+
+		recorder.Record(context.Background(), record("abc123"))
+
+		Eventually(sink.records).Should(HaveLen(1))
+		Expect(droppedRecords("flaky", "write_failed")).To(BeZero())
+	})
+
+	It("drops records once the retries are exhausted, and keeps writing the next ones", func() {
+		sink := &fakeSink{name: "failing", failures: 3}
+		recorder := NewRecorder(10, sink)
+		start(recorder)
+
+		recorder.Record(context.Background(), record("abc123"))
+		Eventually(func() float64 { return droppedRecords("failing", "write_failed") }).Should(Equal(1.0))
+
+		recorder.Record(context.Background(), record("def456"))
+		Eventually(sink.records).Should(HaveLen(1))
+		Expect(sink.records()[0].DrySha).To(Equal("def456"))
+	})
+
+	It("drops records while a sink's buffer is full without blocking", func() {
+		sink := &fakeSink{name: "slow"}
+		recorder := NewRecorder(1, sink)
+
+		// The records wait in the buffer until the recorder starts.
+		recorder.Record(context.Background(), record("abc123"))
+		recorder.Record(context.Background(), record("def456"))
+		Expect(droppedRecords("slow", "buffer_full")).To(Equal(1.0))
+
+		start(recorder)
+		Eventually(sink.records).Should(HaveLen(1))
+		Expect(sink.records()[0].DrySha).To(Equal("abc123"))
+	})
+})
+
+var _ = Describe("HTTPSink", func() {
+	It("POSTs the records as JSON lines", func() {
+		received := make(chan []Record, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.Header.Get("Content-Type")).To(Equal("application/x-ndjson"))
+			var records []Record
+			scanner := bufio.NewScanner(r.Body)
+			for scanner.Scan() {
+				var record Record
+				Expect(json.Unmarshal(scanner.Bytes(), &record)).To(Succeed())
+				records = append(records, record)
+			}
+			received <- records
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		DeferCleanup(server.Close)
+
+		merged := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+		Expect(NewHTTPSink(server.URL).Write(context.Background(), []Record{
+			{Type: TypePromotionMerged, PromotionStrategy: "demo", DrySha: "abc123", Gates: []Gate{{Key: "e2e-tests", Phase: "success"}}, Actor: ActorPromoter, Time: merged},
+			{Type: TypeManualApprovalConsumed, PromotionStrategy: "demo", DrySha: "def456", Actor: ActorSCM, Time: merged},
+		})).To(Succeed())
+
+		var records []Record
+		Eventually(received).Should(Receive(&records))
+		Expect(records).To(HaveLen(2))
+		Expect(records[0].Gates).To(Equal([]Gate{{Key: "e2e-tests", Phase: "success"}}))
+		Expect(records[0].Time).To(BeTemporally("==", merged))
+		Expect(records[1].Type).To(Equal(TypeManualApprovalConsumed))
+		Expect(records[1].Actor).To(Equal(ActorSCM))
+	})
+
+	It("fails when the endpoint doesn't accept the records", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		DeferCleanup(server.Close)
+
+		Expect(NewHTTPSink(server.URL).Write(context.Background(), []Record{{DrySha: "abc123"}})).
+			To(MatchError(ContainSubstring("status 503")))
+	})
+})
+
+var _ = Describe("Config", func() {
+	DescribeTable("validates",
+		func(config Config, valid bool) {
+			if valid {
+				Expect(config.Validate()).To(Succeed())
+			} else {
+				Expect(config.Validate()).NotTo(Succeed())
+			}
+		},
+		Entry("disabled", Config{}, true),
+		Entry("ConfigMap sink", Config{ConfigMapRecords: 100, BufferSize: 10}, true),
+		Entry("HTTP sink", Config{HTTPURL: "https://collector.example.com/audit", BufferSize: 10}, true),
+		Entry("negative number of records", Config{ConfigMapRecords: -1}, false),
+		Entry("relative URL", Config{HTTPURL: "/audit", BufferSize: 10}, false),
+		Entry("no buffer", Config{ConfigMapRecords: 100}, false),
+	)
+})
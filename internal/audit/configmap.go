@@ -0,0 +1,128 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+// ConfigMapRecordsKey is the key of the audit ConfigMap's data that holds the records, one JSON object per line, oldest
+// first.
+const ConfigMapRecordsKey = "records.jsonl"
+
+// ConfigMapName returns the name of the audit ConfigMap of the PromotionStrategy, in the PromotionStrategy's namespace.
+func ConfigMapName(ctx context.Context, promotionStrategy string) string {
+	return utils.KubeSafeUniqueName(ctx, promotionStrategy+"-audit")
+}
+
+// ConfigMapSink keeps the most recent records of each PromotionStrategy in a ConfigMap next to it, which has no owner
+// reference so that the records outlive the PromotionStrategy.
+type ConfigMapSink struct {
+	client  client.Client
+	reader  client.Reader
+	records int
+}
+
+// NewConfigMapSink returns a ConfigMapSink that keeps up to records records for each PromotionStrategy. It writes the
+// ConfigMaps with c and reads them with reader.
+func NewConfigMapSink(c client.Client, reader client.Reader, records int) *ConfigMapSink {
+	return &ConfigMapSink{client: c, reader: reader, records: records}
+}
+
+// Name implements Sink.
+func (s *ConfigMapSink) Name() string {
+	return "configmap"
+}
+
+// Write implements Sink. It appends the records to the ConfigMaps of their PromotionStrategies, dropping the oldest
+// records beyond the limit. Records a ConfigMap already holds are not appended again.
+func (s *ConfigMapSink) Write(ctx context.Context, records []Record) error {
+	type strategy struct{ namespace, name string }
+	var strategies []strategy
+	lines := map[strategy][]string{}
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit record: %w", err)
+		}
+		key := strategy{namespace: record.Namespace, name: record.PromotionStrategy}
+		if _, ok := lines[key]; !ok {
+			strategies = append(strategies, key)
+		}
+		lines[key] = append(lines[key], string(line))
+	}
+
+	for _, key := range strategies {
+		if err := s.append(ctx, key.namespace, key.name, lines[key]); err != nil {
+			return fmt.Errorf("failed to append audit records of PromotionStrategy %s/%s: %w", key.namespace, key.name, err)
+		}
+	}
+	return nil
+}
+
+// append appends the lines to the audit ConfigMap of the PromotionStrategy, creating it if it doesn't exist.
+func (s *ConfigMapSink) append(ctx context.Context, namespace, promotionStrategy string, lines []string) error {
+	key := client.ObjectKey{Namespace: namespace, Name: ConfigMapName(ctx, promotionStrategy)}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error { //nolint:wrapcheck // RetryOnConflict returns wrapped error
+		var cm corev1.ConfigMap
+		err := s.reader.Get(ctx, key, &cm)
+		if k8serrors.IsNotFound(err) {
+			cm = corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+					Labels: map[string]string{
+						promoterv1alpha1.PromotionStrategyLabel: utils.KubeSafeLabel(promotionStrategy),
+					},
+				},
+				Data: map[string]string{ConfigMapRecordsKey: s.ring(nil, lines)},
+			}
+			if err := s.client.Create(ctx, &cm); err != nil {
+				return fmt.Errorf("failed to create ConfigMap %q: %w", key.Name, err)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get ConfigMap %q: %w", key.Name, err)
+		}
+
+		var existing []string
+		if data := strings.TrimSuffix(cm.Data[ConfigMapRecordsKey], "\n"); data != "" {
+			existing = strings.Split(data, "\n")
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[ConfigMapRecordsKey] = s.ring(existing, lines)
+		if err := s.client.Update(ctx, &cm); err != nil {
+			return fmt.Errorf("failed to update ConfigMap %q: %w", key.Name, err)
+		}
+		return nil
+	})
+}
+
+// ring returns the data of a ConfigMap holding the existing lines followed by those of lines it doesn't hold yet,
+// without the oldest lines beyond the sink's limit.
+func (s *ConfigMapSink) ring(existing, lines []string) string {
+	all := slices.Clone(existing)
+	for _, line := range lines {
+		if !slices.Contains(existing, line) {
+			all = append(all, line)
+		}
+	}
+	if len(all) > s.records {
+		all = all[len(all)-s.records:]
+	}
+	return strings.Join(all, "\n") + "\n"
+}
@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+var _ = Describe("ConfigMapSink", func() {
+	var c client.Client
+
+	BeforeEach(func() {
+		c = fake.NewClientBuilder().WithScheme(utils.GetScheme()).Build()
+	})
+
+	record := func(promotionStrategy, drySha string) Record {
+		return Record{Type: TypePromotionMerged, Namespace: "default", PromotionStrategy: promotionStrategy, DrySha: drySha, Actor: ActorPromoter}
+	}
+	storedDryShas := func(promotionStrategy string) []string {
+		var cm corev1.ConfigMap
+		Expect(c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: ConfigMapName(context.Background(), promotionStrategy)}, &cm)).To(Succeed())
+		Expect(cm.Labels).To(HaveKeyWithValue(promoterv1alpha1.PromotionStrategyLabel, promotionStrategy))
+		Expect(cm.OwnerReferences).To(BeEmpty())
+		Expect(cm.Data[ConfigMapRecordsKey]).To(HaveSuffix("\n"))
+
+		var dryShas []string
+		for line := range strings.Lines(cm.Data[ConfigMapRecordsKey]) {
+			var stored Record
+			Expect(json.Unmarshal([]byte(line), &stored)).To(Succeed())
+			Expect(stored.PromotionStrategy).To(Equal(promotionStrategy))
+			dryShas = append(dryShas, stored.DrySha)
+		}
+		return dryShas
+	}
+
+	It("appends the records to a ConfigMap per PromotionStrategy", func() {
+		sink := NewConfigMapSink(c, c, 10)
+		Expect(sink.Write(context.Background(), []Record{record("demo", "aaa"), record("other", "bbb"), record("demo", "ccc")})).To(Succeed())
+		Expect(sink.Write(context.Background(), []Record{record("demo", "ddd")})).To(Succeed())
+
+		Expect(storedDryShas("demo")).To(Equal([]string{"aaa", "ccc", "ddd"}))
+		Expect(storedDryShas("other")).To(Equal([]string{"bbb"}))
+	})
+
+	It("keeps the most recent records", func() {
+		sink := NewConfigMapSink(c, c, 2)
+		Expect(sink.Write(context.Background(), []Record{record("demo", "aaa"), record("demo", "bbb"), record("demo", "ccc")})).To(Succeed())
+		Expect(storedDryShas("demo")).To(Equal([]string{"bbb", "ccc"}))
+
+		Expect(sink.Write(context.Background(), []Record{record("demo", "ddd")})).To(Succeed())
+		Expect(storedDryShas("demo")).To(Equal([]string{"ccc", "ddd"}))
+	})
+
+	It("doesn't append records it already holds when they are written again", func() {
+		sink := NewConfigMapSink(c, c, 10)
+		Expect(sink.Write(context.Background(), []Record{record("demo", "aaa")})).To(Succeed())
+		Expect(sink.Write(context.Background(), []Record{record("demo", "aaa"), record("demo", "bbb")})).To(Succeed())
+		Expect(storedDryShas("demo")).To(Equal([]string{"aaa", "bbb"}))
+	})
+})
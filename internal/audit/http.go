@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// httpTimeout is how long POSTing records to the HTTP endpoint may take.
+const httpTimeout = 10 * time.Second
+
+// HTTPSink POSTs records to an HTTP endpoint as JSON lines, such as the HTTP input of a log collector.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPSink returns an HTTPSink that POSTs records to url.
+func NewHTTPSink(url string) *HTTPSink {
+	return &HTTPSink{url: url, client: &http.Client{Timeout: httpTimeout}}
+}
+
+// Name implements Sink.
+func (s *HTTPSink) Name() string {
+	return "http"
+}
+
+// Write implements Sink. It POSTs the records in one request with the application/x-ndjson content type, one JSON
+// object per line. The endpoint must respond with a 2xx status.
+func (s *HTTPSink) Write(ctx context.Context, records []Record) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf("failed to marshal audit record: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, &body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send audit records: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package audit
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestAudit(t *testing.T) {
+	t.Parallel()
+
+	RegisterFailHandler(Fail)
+
+	c, _ := GinkgoConfiguration()
+
+	RunSpecs(t, "Audit Suite", c)
+}
@@ -0,0 +1,214 @@
+// Package cloudevents publishes the lifecycle of promotions, such as pull requests being opened and merged, to a
+// CloudEvents sink.
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+)
+
+// The types of the events the promoter emits.
+const (
+	// TypePullRequestOpened is emitted when a pull request promoting a change to an environment is opened.
+	TypePullRequestOpened = "io.argoproj.promoter.pullrequest.opened"
+	// TypePromotionMerged is emitted when a pull request promoting a change to an environment is merged.
+	TypePromotionMerged = "io.argoproj.promoter.promotion.merged"
+	// TypePromotionBlocked is emitted when a commit status of a change proposed for an environment fails, so that the
+	// change isn't promoted.
+	TypePromotionBlocked = "io.argoproj.promoter.promotion.blocked"
+	// TypeRollbackCompleted is emitted when a RevertCommit reverted its commit.
+	TypeRollbackCompleted = "io.argoproj.promoter.rollback.completed"
+)
+
+const (
+	// DefaultBufferSize is the default number of events the emitter holds while they wait to be sent.
+	DefaultBufferSize = 1000
+
+	// specVersion is the version of the CloudEvents specification the events follow.
+	specVersion = "1.0"
+	// sendTimeout is how long sending an event to the sink may take.
+	sendTimeout = 10 * time.Second
+)
+
+// Config configures where the Emitter sends events to.
+type Config struct {
+	// SinkURL is the URL events are POSTed to in the HTTP binary content mode. No events are emitted if it is empty.
+	SinkURL string
+	// BufferSize is the number of events held while they wait to be sent. Events emitted while the buffer is full are
+	// dropped.
+	BufferSize int
+}
+
+// Enabled returns whether a sink is configured.
+func (c Config) Enabled() bool {
+	return c.SinkURL != ""
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c Config) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	sinkURL, err := url.Parse(c.SinkURL)
+	if err != nil {
+		return fmt.Errorf("invalid sink URL: %w", err)
+	}
+	if sinkURL.Scheme != "http" && sinkURL.Scheme != "https" || sinkURL.Host == "" {
+		return fmt.Errorf("sink URL %q must be an absolute http or https URL", c.SinkURL)
+	}
+	if c.BufferSize <= 0 {
+		return errors.New("buffer size must be positive")
+	}
+	return nil
+}
+
+// Data is the data of an event.
+type Data struct {
+	// Namespace is the namespace of the PromotionStrategy.
+	Namespace string `json:"namespace"`
+	// PromotionStrategy is the name of the PromotionStrategy.
+	PromotionStrategy string `json:"promotionStrategy"`
+	// Environment is the branch of the environment. It is empty for a RevertCommit that reverted a commit on the dry
+	// branch.
+	Environment string `json:"environment,omitempty"`
+	// DrySha is the dry commit being promoted, or for TypeRollbackCompleted, the dry commit that was reverted.
+	DrySha string `json:"drySha,omitempty"`
+	// HydratedSha is the hydrated commit being promoted, or for TypeRollbackCompleted, the hydrated commit that was
+	// reverted.
+	HydratedSha string `json:"hydratedSha,omitempty"`
+	// PullRequestURL is the URL of the pull request on the SCM, or for TypeRollbackCompleted, of the revert pull
+	// request.
+	PullRequestURL string `json:"pullRequestUrl,omitempty"`
+	// PullRequestCreationTime is when the pull request was opened.
+	PullRequestCreationTime *time.Time `json:"pullRequestCreationTime,omitempty"`
+	// FailingCommitStatuses are the keys of the failing commit statuses that block the promotion.
+	FailingCommitStatuses []string `json:"failingCommitStatuses,omitempty"`
+	// RevertCommit is the name of the RevertCommit that reverted the commit.
+	RevertCommit string `json:"revertCommit,omitempty"`
+	// Time is when the transition happened.
+	Time time.Time `json:"time"`
+}
+
+// Event is an event of a promotion.
+type Event struct {
+	// Type is the type of the event, one of the Type constants.
+	Type string
+	// Data is the data of the event.
+	Data Data
+}
+
+// source returns the source of the event, the path of its PromotionStrategy in the Kubernetes API.
+func (e Event) source() string {
+	return fmt.Sprintf("/apis/promoter.argoproj.io/v1alpha1/namespaces/%s/promotionstrategies/%s", e.Data.Namespace, e.Data.PromotionStrategy)
+}
+
+// subject returns the subject of the event, the PromotionStrategy and the environment.
+func (e Event) subject() string {
+	if e.Data.Environment == "" {
+		return e.Data.PromotionStrategy
+	}
+	return e.Data.PromotionStrategy + "/" + e.Data.Environment
+}
+
+// Emitter sends events to a CloudEvents sink in the background, so that a slow or unavailable sink never slows down or
+// fails a reconcile. Events are buffered up to the configured size and dropped when the buffer is full. Events the
+// sink doesn't accept are not retried. A nil Emitter emits nothing.
+type Emitter struct {
+	sinkURL string
+	client  *http.Client
+	events  chan Event
+}
+
+// NewEmitter creates an Emitter for the configuration, or returns nil if no sink is configured. It must be added to the
+// manager, which starts sending the events.
+func NewEmitter(config Config) *Emitter {
+	if !config.Enabled() {
+		return nil
+	}
+	bufferSize := config.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Emitter{
+		sinkURL: config.SinkURL,
+		client:  &http.Client{Timeout: sendTimeout},
+		events:  make(chan Event, bufferSize),
+	}
+}
+
+// Emit queues the event to be sent without waiting for it. It is dropped if the buffer is full.
+func (e *Emitter) Emit(ctx context.Context, event Event) {
+	if e == nil {
+		return
+	}
+	if event.Data.Time.IsZero() {
+		event.Data.Time = time.Now()
+	}
+	select {
+	case e.events <- event:
+	default:
+		metrics.RecordCloudEventDropped(event.Type)
+		log.FromContext(ctx).Info("CloudEvents buffer is full, dropping event", "type", event.Type, "subject", event.subject())
+	}
+}
+
+// Start implements manager.Runnable. It sends the emitted events until ctx is done.
+func (e *Emitter) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("cloudevents")
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-e.events:
+			err := e.send(ctx, event)
+			metrics.RecordCloudEventSent(event.Type, err == nil)
+			if err != nil {
+				logger.Error(err, "failed to send CloudEvent", "type", event.Type, "subject", event.subject())
+			}
+		}
+	}
+}
+
+// send POSTs the event to the sink in the HTTP binary content mode: the attributes are headers and the data is the
+// body.
+func (e *Emitter) send(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.sinkURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Ce-Specversion", specVersion)
+	req.Header.Set("Ce-Id", uuid.NewString())
+	req.Header.Set("Ce-Type", event.Type)
+	req.Header.Set("Ce-Source", event.source())
+	req.Header.Set("Ce-Subject", event.subject())
+	req.Header.Set("Ce-Time", event.Data.Time.UTC().Format(time.RFC3339Nano))
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send event: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sink responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
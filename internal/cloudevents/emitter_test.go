@@ -0,0 +1,133 @@
+package cloudevents_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/cloudevents"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type receivedEvent struct {
+	header http.Header
+	data   cloudevents.Data
+}
+
+var _ = Describe("Emitter", func() {
+	var (
+		sink     *httptest.Server
+		received chan receivedEvent
+		status   int
+	)
+
+	BeforeEach(func() {
+		received = make(chan receivedEvent, 10)
+		status = http.StatusAccepted
+		sink = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			var data cloudevents.Data
+			Expect(json.Unmarshal(body, &data)).To(Succeed())
+			received <- receivedEvent{header: r.Header, data: data}
+			w.WriteHeader(status)
+		}))
+		DeferCleanup(sink.Close)
+	})
+
+	start := func(emitter *cloudevents.Emitter) {
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		go func() {
+			defer GinkgoRecover()
+			Expect(emitter.Start(ctx)).To(Succeed())
+		}()
+	}
+
+	It("sends events in the binary content mode", func() {
+		emitter := cloudevents.NewEmitter(cloudevents.Config{SinkURL: sink.URL, BufferSize: 10})
+		start(emitter)
+
+		merged := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+		emitter.Emit(context.Background(), cloudevents.Event{
+			Type: cloudevents.TypePromotionMerged,
+			Data: cloudevents.Data{
+				Namespace:         "default",
+				PromotionStrategy: "demo",
+				Environment:       "environment/production",
+				DrySha:            "abc123",
+				HydratedSha:       "def456",
+				PullRequestURL:    "https://github.com/example/demo/pull/7",
+				Time:              merged,
+			},
+		})
+
+		var event receivedEvent
+		Eventually(received).Should(Receive(&event))
+		Expect(event.header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(event.header.Get("Ce-Specversion")).To(Equal("1.0"))
+		Expect(event.header.Get("Ce-Id")).NotTo(BeEmpty())
+		Expect(event.header.Get("Ce-Type")).To(Equal("io.argoproj.promoter.promotion.merged"))
+		Expect(event.header.Get("Ce-Source")).To(Equal("/apis/promoter.argoproj.io/v1alpha1/namespaces/default/promotionstrategies/demo"))
+		Expect(event.header.Get("Ce-Subject")).To(Equal("demo/environment/production"))
+		Expect(event.header.Get("Ce-Time")).To(Equal("2026-03-04T05:06:07Z"))
+		Expect(event.data.DrySha).To(Equal("abc123"))
+		Expect(event.data.HydratedSha).To(Equal("def456"))
+		Expect(event.data.PullRequestURL).To(Equal("https://github.com/example/demo/pull/7"))
+		Expect(event.data.Time).To(BeTemporally("==", merged))
+	})
+
+	It("keeps sending after the sink rejects an event", func() {
+		status = http.StatusInternalServerError
+		emitter := cloudevents.NewEmitter(cloudevents.Config{SinkURL: sink.URL, BufferSize: 10})
+		start(emitter)
+
+		emitter.Emit(context.Background(), cloudevents.Event{Type: cloudevents.TypePromotionBlocked, Data: cloudevents.Data{PromotionStrategy: "demo"}})
+		emitter.Emit(context.Background(), cloudevents.Event{Type: cloudevents.TypeRollbackCompleted, Data: cloudevents.Data{PromotionStrategy: "demo"}})
+
+		var event receivedEvent
+		Eventually(received).Should(Receive(&event))
+		Expect(event.header.Get("Ce-Subject")).To(Equal("demo"))
+		Expect(event.data.Time).NotTo(BeZero())
+		Eventually(received).Should(Receive(&event))
+		Expect(event.header.Get("Ce-Type")).To(Equal("io.argoproj.promoter.rollback.completed"))
+	})
+
+	It("drops events while the buffer is full", func() {
+		emitter := cloudevents.NewEmitter(cloudevents.Config{SinkURL: sink.URL, BufferSize: 1})
+		emitter.Emit(context.Background(), cloudevents.Event{Type: cloudevents.TypePullRequestOpened, Data: cloudevents.Data{PromotionStrategy: "first"}})
+		emitter.Emit(context.Background(), cloudevents.Event{Type: cloudevents.TypePullRequestOpened, Data: cloudevents.Data{PromotionStrategy: "second"}})
+		start(emitter)
+
+		var event receivedEvent
+		Eventually(received).Should(Receive(&event))
+		Expect(event.data.PromotionStrategy).To(Equal("first"))
+		Consistently(received, 200*time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("emits nothing without a sink", func() {
+		emitter := cloudevents.NewEmitter(cloudevents.Config{})
+		Expect(emitter).To(BeNil())
+		emitter.Emit(context.Background(), cloudevents.Event{Type: cloudevents.TypePromotionMerged})
+	})
+})
+
+var _ = DescribeTable("Config.Validate",
+	func(config cloudevents.Config, valid bool) {
+		if valid {
+			Expect(config.Validate()).To(Succeed())
+		} else {
+			Expect(config.Validate()).NotTo(Succeed())
+		}
+	},
+	Entry("without a sink", cloudevents.Config{}, true),
+	Entry("with an http sink", cloudevents.Config{SinkURL: "http://broker.knative-eventing.svc/default/default", BufferSize: 1}, true),
+	Entry("with an https sink", cloudevents.Config{SinkURL: "https://events.example.com", BufferSize: 1}, true),
+	Entry("with a relative sink", cloudevents.Config{SinkURL: "/events", BufferSize: 1}, false),
+	Entry("with a sink of another scheme", cloudevents.Config{SinkURL: "ftp://events.example.com", BufferSize: 1}, false),
+	Entry("without a buffer", cloudevents.Config{SinkURL: "https://events.example.com"}, false),
+)
@@ -0,0 +1,18 @@
+package cloudevents_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCloudEvents(t *testing.T) {
+	t.Parallel()
+
+	RegisterFailHandler(Fail)
+
+	c, _ := GinkgoConfiguration()
+
+	RunSpecs(t, "CloudEvents Suite", c)
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/argoproj-labs/gitops-promoter/internal/gitauth"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms"
 	"github.com/argoproj-labs/gitops-promoter/internal/settings"
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/argocd"
 	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
@@ -111,7 +112,7 @@ type ApplicationsInEnvironment struct {
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.1/pkg/reconcile
 func (r *ArgoCDCommitStatusReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (result ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
-	logger.Info("Reconciling ArgoCDCommitStatus", "cluster", req.ClusterName, "namespace", req.Namespace, "name", req.Name)
+	logger.V(4).Info("Reconciling ArgoCDCommitStatus", "cluster", req.ClusterName, "namespace", req.Namespace, "name", req.Name)
 	startTime := time.Now()
 
 	var argoCDCommitStatus promoterv1alpha1.ArgoCDCommitStatus
@@ -146,7 +147,7 @@ func (r *ArgoCDCommitStatusReconciler) Reconcile(ctx context.Context, req mcreco
 		clusters = append(clusters, mcmanager.LocalCluster)
 	}
 	for _, clusterName := range clusters {
-		logger.Info("Fetching Argo CD applications from cluster", "cluster", clusterName)
+		logger.V(4).Info("Fetching Argo CD applications from cluster", "cluster", clusterName)
 		cluster, err := r.Manager.GetCluster(ctx, clusterName)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to get cluster: %w", err)
@@ -454,7 +455,7 @@ func lookupArgoCDCommitStatusFromArgoCDApplication(mgr mcmanager.Manager) mchand
 					logger.Error(err, "failed to parse label selector")
 				}
 				if err == nil && selector.Matches(labels.Set(application.GetLabels())) {
-					logger.Info("ArgoCD application caused ArgoCDCommitStatus to reconcile",
+					logger.V(4).Info("ArgoCD application caused ArgoCDCommitStatus to reconcile",
 						"app-namespace", argoCDApplication.GetNamespace(), "application", argoCDApplication.GetName(),
 						"argocdcommitstatus", argoCDCommitStatus.Namespace+"/"+argoCDCommitStatus.Name)
 
@@ -514,7 +515,7 @@ func (r *ArgoCDCommitStatusReconciler) SetupWithManager(ctx context.Context, mcM
 			mcbuilder.WithEngageWithLocalCluster(watchLocalApplications),
 			mcbuilder.WithEngageWithProviderClusters(true),
 			mcbuilder.WithPredicates(applicationPredicate)).
-		Complete(r)
+		Complete(utils.NewDrainingReconciler(utils.NewShardedReconciler(r.localClient, &promoterv1alpha1.ArgoCDCommitStatus{}, tracing.NewReconciler("ArgoCDCommitStatus", r))))
 	if err != nil {
 		return fmt.Errorf("failed to create controller: %w", err)
 	}
@@ -606,8 +607,9 @@ func (r *ArgoCDCommitStatusReconciler) updateAggregatedCommitStatus(ctx context.
 	// Build the apply configuration
 	commitStatusApply := acv1alpha1.CommitStatus(resourceName, argoCDCommitStatus.Namespace).
 		WithLabels(map[string]string{
-			promoterv1alpha1.CommitStatusLabel: "argocd-health",
-			promoterv1alpha1.EnvironmentLabel:  utils.KubeSafeLabel(targetBranch),
+			promoterv1alpha1.CommitStatusLabel:         "argocd-health",
+			promoterv1alpha1.EnvironmentLabel:          utils.KubeSafeLabel(targetBranch),
+			promoterv1alpha1.PromotionStrategyUIDLabel: string(promotionStrategy.UID),
 		}).
 		WithOwnerReferences(acmetav1.OwnerReference().
 			WithAPIVersion(gvk.GroupVersion().String()).
@@ -626,7 +628,7 @@ func (r *ArgoCDCommitStatusReconciler) updateAggregatedCommitStatus(ctx context.
 		return nil, fmt.Errorf("failed to apply CommitStatus object: %w", err)
 	}
 
-	logger.Info("Applied CommitStatus", "name", resourceName, "targetBranch", targetBranch, "sha", sha, "phase", phase, "description", desc)
+	logger.V(4).Info("Applied CommitStatus", "name", resourceName, "targetBranch", targetBranch, "sha", sha, "phase", phase, "description", desc)
 
 	return commitStatus, nil
 }
@@ -269,10 +269,7 @@ var _ = Describe("ArgoCDCommitStatus Controller", func() {
 
 			_, err = runGitCmd(ctx, workTreePath, "clone", testGitRepoCloneURL(gitRepo), ".")
 			Expect(err).ToNot(HaveOccurred())
-			_, err = runGitCmd(ctx, workTreePath, "config", "user.name", "testuser")
-			Expect(err).ToNot(HaveOccurred())
-			_, err = runGitCmd(ctx, workTreePath, "config", "user.email", "testemail@test.com")
-			Expect(err).ToNot(HaveOccurred())
+			Expect(configureTestIdentity(ctx, workTreePath)).To(Succeed())
 
 			// Checkout the staging branch
 			_, err = runGitCmd(ctx, workTreePath, "checkout", testBranchStaging)
@@ -17,12 +17,15 @@ limitations under the License.
 package controller
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
@@ -31,13 +34,19 @@ import (
 
 	"github.com/argoproj-labs/gitops-promoter/internal/git"
 	"github.com/argoproj-labs/gitops-promoter/internal/gitauth"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
 	"github.com/argoproj-labs/gitops-promoter/internal/settings"
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+	"github.com/argoproj-labs/gitops-promoter/internal/webhookreceiver"
+	corev1 "k8s.io/api/core/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	acmetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
@@ -58,6 +67,13 @@ import (
 	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 )
 
+// maxBranchMissingRequeue caps how long a ChangeTransferPolicy whose active branch is missing waits between checks.
+const maxBranchMissingRequeue = 30 * time.Minute
+
+// maxCommitBodyLength is the number of characters of a commit body that is kept in the status. Commit bodies can be
+// arbitrarily long, and the status of every environment is copied into the PromotionStrategy.
+const maxCommitBodyLength = 4096
+
 // CTPEnqueueFunc is a function type that can be used to enqueue CTP reconcile requests
 // without modifying the CTP object. This is used by other controllers (like PromotionStrategy)
 // to trigger CTP reconciliation without causing object conflicts.
@@ -70,11 +86,49 @@ type ChangeTransferPolicyReconciler struct {
 	Recorder    events.EventRecorder
 	SettingsMgr *settings.Manager
 
+	// GitRepoManager provides the git operations of the environments.
+	GitRepoManager git.GitRepoManager
+
+	// WebhookDeliveries records the verified webhook deliveries for each GitRepository. While they are fresh, the
+	// requeue interval is lengthened. It may be nil.
+	WebhookDeliveries *webhookreceiver.DeliveryTracker
+
 	// enqueueFunc is set during SetupWithManager and can be retrieved via GetEnqueueFunc.
 	// It allows other controllers to enqueue CTP reconcile requests.
 	enqueueFunc CTPEnqueueFunc
+
+	// lfsChecked maps the namespace and name of a ChangeTransferPolicy to its lfsCheck. Entries are removed when the
+	// ChangeTransferPolicy is deleted.
+	lfsChecked sync.Map
+
+	// intervalRaised maps the namespace and name of a ChangeTransferPolicy to the intervalRaise it was last warned
+	// about. Entries are removed when the ChangeTransferPolicy is deleted.
+	intervalRaised sync.Map
 }
 
+// intervalRaise is a spec.reconcileInterval of a ChangeTransferPolicy that was raised to the minimum.
+type intervalRaise struct {
+	// uid and generation identify the spec the reconcileInterval was raised for, so that a changed or recreated
+	// ChangeTransferPolicy is warned about again.
+	uid        types.UID
+	generation int64
+	// minInterval is the minimum the reconcileInterval was raised to.
+	minInterval time.Duration
+}
+
+// lfsCheck is the last Git LFS check of a ChangeTransferPolicy.
+type lfsCheck struct {
+	// uid is the UID of the ChangeTransferPolicy, so that a ChangeTransferPolicy recreated with the same name is checked
+	// again.
+	uid types.UID
+	// sha is the proposed hydrated sha that was last checked for Git LFS usage, or lfsWarned once the GitLFSDisabled
+	// event was emitted.
+	sha string
+}
+
+// lfsWarned is stored in lfsCheck.sha once the GitLFSDisabled event was emitted.
+const lfsWarned = "warned"
+
 // GetEnqueueFunc returns a function that can be used to enqueue CTP reconcile requests.
 // This should be called after SetupWithManager has been called.
 func (r *ChangeTransferPolicyReconciler) GetEnqueueFunc() CTPEnqueueFunc {
@@ -86,6 +140,7 @@ func (r *ChangeTransferPolicyReconciler) GetEnqueueFunc() CTPEnqueueFunc {
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=changetransferpolicies/finalizers,verbs=update
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=pullrequests,verbs=get;list;watch;create;update;patch
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=pullrequests/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -98,7 +153,7 @@ func (r *ChangeTransferPolicyReconciler) GetEnqueueFunc() CTPEnqueueFunc {
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.17.2/pkg/reconcile
 func (r *ChangeTransferPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
-	logger.Info("Reconciling ChangeTransferPolicy")
+	logger.V(4).Info("Reconciling ChangeTransferPolicy")
 	startTime := time.Now()
 
 	var ctp promoterv1alpha1.ChangeTransferPolicy
@@ -109,12 +164,19 @@ func (r *ChangeTransferPolicyReconciler) Reconcile(ctx context.Context, req ctrl
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
 			logger.Info("ChangeTransferPolicy not found")
+			r.lfsChecked.Delete(req.NamespacedName)
+			r.intervalRaised.Delete(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 
 		logger.Error(err, "failed to get ChangeTransferPolicy")
 		return ctrl.Result{}, fmt.Errorf("failed to get ChangeTransferPolicy: %w", err)
 	}
+	defer func() {
+		// Runs before the status is applied, on every exit path from here on.
+		ctp.Status.ActiveDryShortSha = ctp.Status.Active.DryShaShort()
+		ctp.Status.ProposedDryShortSha = ctp.Status.Proposed.DryShaShort()
+	}()
 
 	if deleted, err := r.handleFinalizer(ctx, &ctp); err != nil || deleted {
 		return ctrl.Result{}, err
@@ -126,6 +188,9 @@ func (r *ChangeTransferPolicyReconciler) Reconcile(ctx context.Context, req ctrl
 		return ctrl.Result{}, fmt.Errorf("failed to handle PR finalizer removal: %w", err)
 	}
 
+	// The git state in the status can only be reused if the last reconcile of this generation succeeded.
+	lastReconcileSucceeded := utils.IsReady(&ctp)
+
 	// Remove any existing Ready condition. We want to start fresh.
 	meta.RemoveStatusCondition(ctp.GetConditions(), string(promoterConditions.Ready))
 
@@ -142,36 +207,137 @@ func (r *ChangeTransferPolicyReconciler) Reconcile(ctx context.Context, req ctrl
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to get GitRepository: %w", err)
 	}
-	gitOperations := git.NewEnvironmentOperations(gitRepo, gitAuthProvider, ctp.Spec.ActiveBranch)
-
-	// TODO: could probably short circuit the clone and use an ls-remote to compare the sha's of the current ctp status,
-	// this would help with slamming the git provider with clone requests on controller restarts.
-
-	err = gitOperations.CloneRepo(ctx)
+	if wait, result, err := waitForGitRepository(ctx, r.SettingsMgr, &ctp, gitRepo); wait {
+		return result, err
+	}
+	cloneDepth, err := r.SettingsMgr.GetChangeTransferPolicyCloneDepth(ctx)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to clone repo %q: %w", ctp.Spec.RepositoryReference.Name, err)
+		return ctrl.Result{}, fmt.Errorf("failed to get clone depth: %w", err)
+	}
+	if gitRepo.Spec.CloneDepth != nil {
+		cloneDepth = *gitRepo.Spec.CloneDepth
 	}
+	gitOperations := r.GitRepoManager.EnvironmentOperations(gitRepo, gitAuthProvider, ctp.Spec.ActiveBranch, int(cloneDepth))
+	if gitRepo.Spec.CommitSigning != nil {
+		signingKey, err := getCommitSigningKey(ctx, r.Client, gitRepo)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to get commit signing key for GitRepository %q: %w", gitRepo.Name, err)
+		}
+		gitOperations.SetCommitSigningKey(signingKey)
+	}
+	gitOperations.SetIdentity(gitIdentity(scmProvider, gitRepo))
 
-	// Fetch git notes for hydrator metadata (used to track hydration completion)
-	err = gitOperations.FetchNotes(ctx)
-	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to fetch git notes: %w", err)
+	// While the active branch is missing, a cheap ls-remote tells whether it was created, so there's no need to clone
+	// and fetch on every requeue. A spec change always goes through the full reconcile.
+	if branchMissing := meta.FindStatusCondition(ctp.Status.Conditions, string(promoterConditions.BranchMissing)); branchMissing != nil && branchMissing.ObservedGeneration == ctp.Generation {
+		exists, err := git.RemoteBranchExists(ctx, gitAuthProvider, gitRepo, ctp.Spec.ActiveBranch)
+		if err == nil && !exists {
+			logger.V(4).Info("Active branch is still missing", "branch", ctp.Spec.ActiveBranch)
+			return r.activeBranchMissing(ctx, &ctp)
+		}
 	}
 
-	err = r.calculateStatus(ctx, &ctp, gitOperations)
-	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to calculate ChangeTransferPolicy status: %w", err)
+	// Most reconciles find the branches unchanged. A single ls-remote tells whether anything changed since the last
+	// reconcile, in which case the fetch and the rest of the git work are skipped.
+	previousLsRemote := ctp.Status.LastLsRemote
+	lsRemote := r.lsRemote(ctx, gitAuthProvider, gitRepo, &ctp)
+	if lsRemote != nil {
+		ctp.Status.LastLsRemote = lsRemote
 	}
+	gitUnchanged := lastReconcileSucceeded && gitOperations.IsCloned() && branchesUnchanged(&ctp, previousLsRemote, lsRemote)
 
-	err = r.gitMergeStrategyOurs(ctx, gitOperations, &ctp)
-	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to git merge for conflict resolution: %w", err)
+	if gitUnchanged {
+		logger.V(4).Info("Branches are unchanged since the last reconcile, skipping fetch",
+			"activeSha", lsRemote.ActiveSha, "proposedSha", lsRemote.ProposedSha)
+		err = r.setCommitStatusAndPullRequestState(ctx, &ctp)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to calculate ChangeTransferPolicy status: %w", err)
+		}
+	} else {
+		err = gitOperations.CloneRepo(ctx)
+		if err != nil && git.IsRetryable(err) {
+			// A timeout or dropped connection usually goes away on its own, retry with the work queue's backoff.
+			return ctrl.Result{}, fmt.Errorf("failed to clone repo %q: %w", ctp.Spec.RepositoryReference.Name, err)
+		}
+		if err != nil {
+			// Cloning usually fails because of credentials or connectivity, which only the user can fix. Report it on the
+			// Ready condition so it shows up on the ChangeTransferPolicy and the PromotionStrategy.
+			logger.Error(err, "failed to clone repo", "repo", ctp.Spec.RepositoryReference.Name)
+			return r.notReady(ctx, &ctp, promoterConditions.CloneFailed, fmt.Sprintf("Failed to clone repository %q: %s", ctp.Spec.RepositoryReference.Name, err))
+		}
+
+		// Fetch git notes for hydrator metadata (used to track hydration completion)
+		err = gitOperations.FetchNotes(ctx)
+		if git.IsTerminal(err) {
+			return r.gitOperationFailed(ctx, &ctp, "fetch git notes", err)
+		}
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to fetch git notes: %w", err)
+		}
+
+		err = r.calculateStatus(ctx, &ctp, gitOperations)
+		if err != nil {
+			var invalidMetadataErr *git.InvalidHydratorMetadataError
+			var branchNotFoundErr *git.BranchNotFoundError
+			var divergedErr *ProposedBranchDivergedError
+			var reason promoterConditions.CommonReason
+			var message string
+			switch {
+			case errors.As(err, &invalidMetadataErr):
+				// Without valid metadata we cannot tell which dry commit a branch holds, so don't open or merge pull
+				// requests. Surface the problem as a condition instead of an error so the PromotionStrategy reports which
+				// branch is broken.
+				logger.Info("Hydrator metadata is invalid", "branch", invalidMetadataErr.Branch, "reason", invalidMetadataErr.Reason)
+				reason = promoterConditions.MetadataInvalid
+				message = invalidMetadataErr.Error()
+			case errors.As(err, &divergedErr):
+				// spec.resolveDivergence is manual, promoting from a branch with unexpected content could ship changes
+				// nobody reviewed, so wait until someone fixes the branch.
+				logger.Info("Proposed branch diverged, waiting for it to be fixed", "branch", divergedErr.Branch)
+				reason = promoterConditions.HistoryDiverged
+				message = divergedErr.Error()
+			case errors.As(err, &branchNotFoundErr) && branchNotFoundErr.Branch == ctp.Spec.ActiveBranch:
+				// The active branch is the environment itself, the controller can't guess where it should start from.
+				logger.Info("Active branch does not exist", "branch", ctp.Spec.ActiveBranch)
+				return r.activeBranchMissing(ctx, &ctp)
+			case git.IsTerminal(err):
+				return r.gitOperationFailed(ctx, &ctp, "calculate status", err)
+			default:
+				return ctrl.Result{}, fmt.Errorf("failed to calculate ChangeTransferPolicy status: %w", err)
+			}
+			return r.notReady(ctx, &ctp, reason, message)
+		}
+
+		if missing := unresolvedBranchShas(&ctp); len(missing) > 0 {
+			// The PromotionStrategy compares these shas across environments, so it must not act on a partial status.
+			logger.Info("Branch shas are not resolved", "fields", missing)
+			return r.notReady(ctx, &ctp, promoterConditions.BranchShasUnresolved, fmt.Sprintf("Could not resolve %s", strings.Join(missing, ", ")))
+		}
+
+		if !git.LFSEnabled() {
+			r.warnIfLFSUsed(ctx, &ctp, gitOperations)
+		}
+
+		err = r.gitMergeStrategyOurs(ctx, gitOperations, &ctp)
+		if err != nil {
+			var signatureRejectedErr *git.CommitSignatureRejectedError
+			if errors.As(err, &signatureRejectedErr) {
+				// Branch protection requires signed commits, retrying won't help until the signing configuration is fixed.
+				logger.Info("Push was rejected because of the commit signature", "branch", signatureRejectedErr.Branch)
+				return r.notReady(ctx, &ctp, promoterConditions.CommitSignatureRejected, fmt.Sprintf("Push to branch %q was rejected because of the merge commit's signature, check spec.commitSigning of GitRepository %q: %s", signatureRejectedErr.Branch, gitRepo.Name, signatureRejectedErr.Err))
+			}
+			if git.IsTerminal(err) {
+				return r.gitOperationFailed(ctx, &ctp, "merge for conflict resolution", err)
+			}
+			return ctrl.Result{}, fmt.Errorf("failed to git merge for conflict resolution: %w", err)
+		}
 	}
 
 	pr, err := r.creatOrUpdatePullRequest(ctx, &ctp)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to set promotion state: %w", err)
 	}
+	setPullRequestCreatedCondition(&ctp, pr)
 
 	if pr != nil {
 		utils.InheritNotReadyConditionFromObjects(&ctp, promoterConditions.PullRequestNotReady, pr)
@@ -186,12 +352,19 @@ func (r *ChangeTransferPolicyReconciler) Reconcile(ctx context.Context, req ctrl
 		utils.InheritNotReadyConditionFromObjects(&ctp, promoterConditions.PullRequestNotReady, pr)
 	}
 
-	// calculateHistory is done at a best effort so we do not return any errors here, we just log them instead.
-	r.calculateHistory(ctx, &ctp, gitOperations)
+	if !gitUnchanged {
+		// calculateHistory is done at a best effort so we do not return any errors here, we just log them instead.
+		r.calculateHistory(ctx, &ctp, gitOperations)
+	}
 
-	requeueDuration, err := settings.GetRequeueDuration[promoterv1alpha1.ChangeTransferPolicyConfiguration](ctx, r.SettingsMgr)
+	requeueDuration, err := r.reconcileInterval(ctx, &ctp)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to get global promotion configuration: %w", err)
+		return ctrl.Result{}, err
+	}
+	gitRepoKey := client.ObjectKey{Namespace: ctp.Namespace, Name: ctp.Spec.RepositoryReference.Name}
+	requeueDuration, ctp.Status.Polling, err = adaptiveRequeue(ctx, r.Client, r.SettingsMgr, r.WebhookDeliveries, gitRepoKey, requeueDuration)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
 	return ctrl.Result{
@@ -199,6 +372,232 @@ func (r *ChangeTransferPolicyReconciler) Reconcile(ctx context.Context, req ctrl
 	}, nil
 }
 
+// lsRemote runs a single ls-remote of the active and proposed branches and the hydrator notes ref. A failure is logged
+// and returns nil, the branches are then fetched as usual and report the actual problem.
+func (r *ChangeTransferPolicyReconciler) lsRemote(ctx context.Context, gap scms.GitOperationsProvider, gitRepo *promoterv1alpha1.GitRepository, ctp *promoterv1alpha1.ChangeTransferPolicy) *promoterv1alpha1.LsRemoteState {
+	activeRef := "refs/heads/" + ctp.Spec.ActiveBranch
+	proposedRef := "refs/heads/" + ctp.Spec.ProposedBranch
+	shas, err := git.LsRemoteRefs(ctx, gap, gitRepo, activeRef, proposedRef, git.HydratorNotesRef)
+	if err != nil {
+		log.FromContext(ctx).Info("Could not ls-remote the branches, fetching them instead", "err", err.Error())
+		return nil
+	}
+	return &promoterv1alpha1.LsRemoteState{
+		Time:        metav1.Now(),
+		ActiveSha:   shas[activeRef],
+		ProposedSha: shas[proposedRef],
+		NotesSha:    shas[git.HydratorNotesRef],
+	}
+}
+
+// branchesUnchanged reports whether the ls-remote found the same refs as the previous one, and whether the branches
+// still point at the hydrated shas in the status.
+func branchesUnchanged(ctp *promoterv1alpha1.ChangeTransferPolicy, previous, current *promoterv1alpha1.LsRemoteState) bool {
+	if previous == nil || current == nil {
+		return false
+	}
+	return current.ActiveSha == previous.ActiveSha &&
+		current.ProposedSha == previous.ProposedSha &&
+		current.NotesSha == previous.NotesSha &&
+		current.ActiveSha == ctp.Status.Active.Hydrated.Sha &&
+		current.ProposedSha == ctp.Status.Proposed.Hydrated.Sha
+}
+
+// reconcileInterval returns how long to wait before reconciling the ChangeTransferPolicy again after a successful
+// reconcile. spec.reconcileInterval overrides the controller's requeue duration, but is raised to the configured
+// minimum so that a single resource can't poll its repository too often. Raising it records a Warning event, so that the
+// owner of the resource finds out why it isn't reconciled as often as they asked, once for each generation of the spec.
+func (r *ChangeTransferPolicyReconciler) reconcileInterval(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy) (time.Duration, error) {
+	if ctp.Spec.ReconcileInterval == nil {
+		requeueDuration, err := settings.GetRequeueDuration[promoterv1alpha1.ChangeTransferPolicyConfiguration](ctx, r.SettingsMgr)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get global promotion configuration: %w", err)
+		}
+		return requeueDuration, nil
+	}
+
+	minInterval, err := r.SettingsMgr.GetChangeTransferPolicyMinReconcileInterval(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get minimum reconcile interval: %w", err)
+	}
+	if ctp.Spec.ReconcileInterval.Duration < minInterval {
+		// Only warn the first time the reconcileInterval of a spec is raised, not on every reconcile.
+		raise := intervalRaise{uid: ctp.UID, generation: ctp.Generation, minInterval: minInterval}
+		if previous, loaded := r.intervalRaised.Swap(client.ObjectKeyFromObject(ctp), raise); loaded && previous == raise {
+			return minInterval, nil
+		}
+		log.FromContext(ctx).Info("reconcileInterval is below the configured minimum, using the minimum instead",
+			"reconcileInterval", ctp.Spec.ReconcileInterval.Duration, "minReconcileInterval", minInterval)
+		r.Recorder.Eventf(ctp, nil, "Warning", constants.ReconcileIntervalRaisedReason, "Requeueing", constants.ReconcileIntervalRaisedMessage,
+			ctp.Spec.ReconcileInterval.Duration, minInterval, minInterval)
+		return minInterval, nil
+	}
+	return ctp.Spec.ReconcileInterval.Duration, nil
+}
+
+// notReady sets the Ready condition to False with the given reason and requeues the ChangeTransferPolicy. It is used
+// for problems that need the user's attention, so that they show up on the resource instead of as a retried error.
+func (r *ChangeTransferPolicyReconciler) notReady(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy, reason promoterConditions.CommonReason, message string) (ctrl.Result, error) {
+	meta.SetStatusCondition(ctp.GetConditions(), metav1.Condition{
+		Type:               string(promoterConditions.Ready),
+		Status:             metav1.ConditionFalse,
+		Reason:             string(reason),
+		Message:            message,
+		ObservedGeneration: ctp.Generation,
+	})
+
+	requeueDuration, err := settings.GetRequeueDuration[promoterv1alpha1.ChangeTransferPolicyConfiguration](ctx, r.SettingsMgr)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get global promotion configuration: %w", err)
+	}
+	return ctrl.Result{RequeueAfter: requeueDuration}, nil
+}
+
+// gitOperationFailed reports a git failure that retrying won't fix, such as rejected credentials or a full disk, on the
+// Ready condition. Instead of the work queue's fast backoff, the ChangeTransferPolicy is requeued after the configured
+// requeue duration. Transient failures are returned as errors by the caller, so that they are retried promptly.
+func (r *ChangeTransferPolicyReconciler) gitOperationFailed(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy, operation string, err error) (ctrl.Result, error) {
+	kind := git.ErrorKindOf(err)
+	log.FromContext(ctx).Error(err, "Git operation failed, not retrying until the next requeue", "operation", operation, "kind", kind)
+	return r.notReady(ctx, ctp, promoterConditions.GitOperationFailed, fmt.Sprintf("Failed to %s (%s): %s", operation, kind, err))
+}
+
+// activeBranchMissing reports an active branch that does not exist on the remote, e.g. because the environment branch
+// was never created or the branch was renamed. The BranchMissing condition names the missing ref, and the Warning event
+// is only emitted when the condition is first set, not on every requeue. While the branch stays missing, each requeue
+// waits as long as the branch has been missing so far, up to maxBranchMissingRequeue. A spec change starts over.
+func (r *ChangeTransferPolicyReconciler) activeBranchMissing(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy) (ctrl.Result, error) {
+	ref := "refs/heads/" + ctp.Spec.ActiveBranch
+
+	previous := meta.FindStatusCondition(ctp.Status.Conditions, string(promoterConditions.BranchMissing))
+	if previous != nil && previous.ObservedGeneration != ctp.Generation {
+		// Reset the last transition time so that the backoff starts over for the new spec.
+		meta.RemoveStatusCondition(ctp.GetConditions(), string(promoterConditions.BranchMissing))
+		previous = nil
+	}
+	if previous == nil {
+		r.Recorder.Eventf(ctp, nil, "Warning", constants.BranchMissingReason, "CheckingBranch", constants.BranchMissingMessage, ref, ctp.Spec.RepositoryReference.Name)
+	}
+	meta.SetStatusCondition(ctp.GetConditions(), metav1.Condition{
+		Type:               string(promoterConditions.BranchMissing),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(promoterConditions.RefNotFound),
+		Message:            fmt.Sprintf("Ref %q does not exist on the remote", ref),
+		ObservedGeneration: ctp.Generation,
+	})
+
+	result, err := r.notReady(ctx, ctp, promoterConditions.ActiveBranchMissing, fmt.Sprintf("Active branch %q does not exist on the remote, create it to start promoting to this environment", ctp.Spec.ActiveBranch))
+	if err != nil {
+		return result, err
+	}
+	missingFor := time.Since(meta.FindStatusCondition(ctp.Status.Conditions, string(promoterConditions.BranchMissing)).LastTransitionTime.Time)
+	if missingFor > result.RequeueAfter {
+		result.RequeueAfter = min(missingFor, maxBranchMissingRequeue)
+	}
+	return result, nil
+}
+
+// getCommitSigningKey returns the key that signs the commits created in the GitRepository, read from the secret
+// referenced by its commit signing configuration.
+func getCommitSigningKey(ctx context.Context, c client.Client, gitRepo *promoterv1alpha1.GitRepository) (*git.CommitSigningKey, error) {
+	var secret corev1.Secret
+	err := c.Get(ctx, client.ObjectKey{Namespace: gitRepo.Namespace, Name: gitRepo.Spec.CommitSigning.SecretRef.Name}, &secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %q: %w", gitRepo.Spec.CommitSigning.SecretRef.Name, err)
+	}
+	key, err := git.NewCommitSigningKey(gitRepo.Spec.CommitSigning.Format, &secret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid commit signing key: %w", err)
+	}
+	return key, nil
+}
+
+// gitIdentity returns the git identity configured for the repository. Each field of the GitRepository's identity takes
+// precedence over the ScmProvider's, fields that neither sets are left empty so that the controller's default is used.
+func gitIdentity(scmProvider promoterv1alpha1.GenericScmProvider, gitRepo *promoterv1alpha1.GitRepository) git.Identity {
+	var identity git.Identity
+	for _, configured := range []*promoterv1alpha1.GitIdentity{gitRepo.Spec.GitIdentity, scmProvider.GetSpec().GitIdentity} {
+		if configured == nil {
+			continue
+		}
+		identity.Name = cmp.Or(identity.Name, configured.Name)
+		identity.Email = cmp.Or(identity.Email, configured.Email)
+	}
+	return identity
+}
+
+// warnIfLFSUsed emits a Warning event if the proposed branch stores files in Git LFS while LFS is disabled, because the
+// clone only has the pointer files then. The event is emitted once per ChangeTransferPolicy, and each proposed hydrated
+// commit is checked only once.
+func (r *ChangeTransferPolicyReconciler) warnIfLFSUsed(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy, gitOperations *git.EnvironmentOperations) {
+	key := client.ObjectKeyFromObject(ctp)
+	sha := ctp.Status.Proposed.Hydrated.Sha
+	if checked, ok := r.lfsChecked.Load(key); ok {
+		//nolint:forcetypeassert // sync.Map stores lfsCheck values, type is guaranteed
+		if check := checked.(lfsCheck); check.uid == ctp.UID && (check.sha == lfsWarned || check.sha == sha) {
+			return
+		}
+	}
+
+	usesLFS, err := gitOperations.UsesLFS(ctx, sha)
+	if err != nil {
+		// Only a warning depends on it, try again on the next reconcile.
+		log.FromContext(ctx).V(4).Info("Could not check whether the proposed branch uses Git LFS", "err", err)
+		return
+	}
+	if !usesLFS {
+		r.lfsChecked.Store(key, lfsCheck{uid: ctp.UID, sha: sha})
+		return
+	}
+	r.Recorder.Eventf(ctp, nil, "Warning", constants.GitLFSDisabledReason, "CheckingBranch", constants.GitLFSDisabledMessage, ctp.Spec.ProposedBranch)
+	r.lfsChecked.Store(key, lfsCheck{uid: ctp.UID, sha: lfsWarned})
+}
+
+// unresolvedBranchShas returns the names of the status sha fields that calculateStatus should have resolved but left
+// empty. The active dry sha is allowed to be empty because nothing may have been promoted to the active branch yet, and
+// so is the proposed dry sha while the proposed branch still points at the active branch.
+func unresolvedBranchShas(ctp *promoterv1alpha1.ChangeTransferPolicy) []string {
+	var missing []string
+	if ctp.Status.Active.Hydrated.Sha == "" {
+		missing = append(missing, "active hydrated sha")
+	}
+	if ctp.Status.Proposed.Hydrated.Sha == "" {
+		missing = append(missing, "proposed hydrated sha")
+	}
+	if ctp.Status.Proposed.Dry.Sha == "" && ctp.Status.Proposed.Hydrated.Sha != ctp.Status.Active.Hydrated.Sha {
+		missing = append(missing, "proposed dry sha")
+	}
+	return missing
+}
+
+// setPullRequestCreatedCondition records whether a pull request is open for the proposed change. pr is the pull request
+// returned by creatOrUpdatePullRequest, which is nil when there is nothing to promote.
+func setPullRequestCreatedCondition(ctp *promoterv1alpha1.ChangeTransferPolicy, pr *promoterv1alpha1.PullRequest) {
+	condition := metav1.Condition{
+		Type:               string(promoterConditions.PullRequestCreated),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(promoterConditions.PullRequestOpen),
+		ObservedGeneration: ctp.Generation,
+	}
+	switch {
+	case pr != nil:
+		condition.Message = fmt.Sprintf("Pull request %q promotes dry sha %s", pr.Name, ctp.Status.Proposed.DryShaShort())
+	case ctp.Status.EffectivelyPromotedDrySha != "" && ctp.Status.EffectivelyPromotedDrySha == ctp.Status.Proposed.Dry.Sha:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(promoterConditions.NothingToPromote)
+		condition.Message = fmt.Sprintf("Proposed dry sha %s doesn't change the hydrated manifests", ctp.Status.Proposed.DryShaShort())
+	case ctp.Status.ProposedDryShaSuperseded:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(promoterConditions.SupersededByRevert)
+		condition.Message = fmt.Sprintf("Proposed dry sha %s was superseded upstream", ctp.Status.Proposed.DryShaShort())
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(promoterConditions.NothingToPromote)
+		condition.Message = fmt.Sprintf("Active branch %q already has dry sha %s", ctp.Spec.ActiveBranch, ctp.Status.Active.DryShaShort())
+	}
+	meta.SetStatusCondition(ctp.GetConditions(), condition)
+}
+
 // calculateHistory this function calculates the history by getting the first parents on the active branch and using the trailers to reconstruct the history.
 // calculateHistory calculates the history by getting the first parents on the active branch and using the trailers to reconstruct the history.
 // This function is best effort and will log errors but continue processing if it encounters issues with individual commits. This is because history is stored in git
@@ -441,6 +840,22 @@ func removeKnownTrailers(input string) string {
 	return result
 }
 
+// truncateCommitBody shortens a commit body to maxCommitBodyLength characters.
+func truncateCommitBody(body string) string {
+	return utils.TruncateString(body, maxCommitBodyLength)
+}
+
+// truncateCommitBodies shortens the body of a commit and the bodies of the commits it references with
+// truncateCommitBody.
+func truncateCommitBodies(state *promoterv1alpha1.CommitShaState) {
+	state.Body = truncateCommitBody(state.Body)
+	for i := range state.References {
+		if state.References[i].Commit != nil {
+			state.References[i].Commit.Body = truncateCommitBody(state.References[i].Commit.Body)
+		}
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ChangeTransferPolicyReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	// This index gets used by the CommitStatus controller and the webhook server to find the ChangeTransferPolicy to trigger reconcile
@@ -461,6 +876,18 @@ func (r *ChangeTransferPolicyReconciler) SetupWithManager(ctx context.Context, m
 		return fmt.Errorf("failed to set field index for .status.active.hydrated.sha: %w", err)
 	}
 
+	// This gets used by the webhook server to find the ChangeTransferPolicies tracking a pushed branch
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &promoterv1alpha1.ChangeTransferPolicy{}, ".spec.gitRepositoryRef.branch", func(rawObj client.Object) []string {
+		//nolint:forcetypeassert // type is guaranteed by the IndexField API
+		ctp := rawObj.(*promoterv1alpha1.ChangeTransferPolicy)
+		return []string{
+			utils.GetRepositoryBranchIndexKey(ctp.Spec.RepositoryReference.Name, ctp.Spec.ProposedBranch),
+			utils.GetRepositoryBranchIndexKey(ctp.Spec.RepositoryReference.Name, ctp.Spec.ActiveBranch),
+		}
+	}); err != nil {
+		return fmt.Errorf("failed to set field index for .spec.gitRepositoryRef.branch: %w", err)
+	}
+
 	// Use Direct methods to read configuration from the API server without cache during setup.
 	// The cache is not started during SetupWithManager, so we must use the non-cached API reader.
 	rateLimiter, err := settings.GetRateLimiterDirect[promoterv1alpha1.ChangeTransferPolicyConfiguration, ctrl.Request](ctx, r.SettingsMgr)
@@ -505,6 +932,8 @@ func (r *ChangeTransferPolicyReconciler) SetupWithManager(ctx context.Context, m
 				// Webhooks trigger reconciliations by bumping an annotation.
 				// TODO: use a custom predicate to only trigger on the specific annotation change.
 				predicate.AnnotationChangedPredicate{},
+				// Label changes are propagated to the PullRequests.
+				predicate.LabelChangedPredicate{},
 			))).
 		// This controller intentionally doesn't have a .Owns for CommitStatuses. Every reconcile of a CommitStatus
 		// checks whether it needs to update a related ChangeTransferPolicy by setting an annotation. Avoiding .Owns
@@ -514,33 +943,70 @@ func (r *ChangeTransferPolicyReconciler) SetupWithManager(ctx context.Context, m
 		// The handler.EnqueueRequestForObject extracts the namespace/name from the GenericEvent.
 		WatchesRawSource(source.Channel(externalEnqueueChan, &handler.EnqueueRequestForObject{})).
 		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles, RateLimiter: rateLimiter}).
-		Complete(r)
+		Complete(utils.NewDrainingReconciler(utils.NewShardedReconciler(mgr.GetClient(), &promoterv1alpha1.ChangeTransferPolicy{}, tracing.NewReconciler("ChangeTransferPolicy", r))))
 	if err != nil {
 		return fmt.Errorf("failed to create controller: %w", err)
 	}
 	return nil
 }
 
+// getProposedBranchShas returns the SHAs of the proposed branch. On a new environment the proposed branch may not exist
+// yet, in that case it is created from the tip of the active branch so the hydrator has somewhere to push and the
+// environment shows up as having nothing to promote.
+func (r *ChangeTransferPolicyReconciler) getProposedBranchShas(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy, gitOperations *git.EnvironmentOperations, activeShas git.BranchShas) (git.BranchShas, error) {
+	logger := log.FromContext(ctx)
+
+	proposedShas, err := gitOperations.GetBranchShas(ctx, ctp.Spec.ProposedBranch)
+	var branchNotFoundErr *git.BranchNotFoundError
+	if err == nil || !errors.As(err, &branchNotFoundErr) {
+		return proposedShas, err //nolint:wrapcheck // wrapped by the caller
+	}
+
+	logger.Info("Proposed branch does not exist, creating it from the active branch", "proposedBranch", ctp.Spec.ProposedBranch, "activeBranch", ctp.Spec.ActiveBranch)
+	err = gitOperations.CreateBranch(ctx, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
+	if err != nil {
+		return git.BranchShas{}, fmt.Errorf("failed to create missing proposed branch: %w", err)
+	}
+	r.Recorder.Eventf(ctp, nil, "Normal", constants.ProposedBranchCreatedReason, "CreatingProposedBranch", constants.ProposedBranchCreatedMessage, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch, activeShas.Hydrated)
+
+	proposedShas, err = gitOperations.GetBranchShas(ctx, ctp.Spec.ProposedBranch)
+	if err != nil {
+		return git.BranchShas{}, fmt.Errorf("failed to get SHAs for created proposed branch: %w", err)
+	}
+	return proposedShas, nil
+}
+
 func (r *ChangeTransferPolicyReconciler) calculateStatus(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy, gitOperations *git.EnvironmentOperations) error {
 	logger := log.FromContext(ctx)
 
 	// TODO: consider parallelizing parts of this function that are network-bound work.
 
-	proposedShas, err := gitOperations.GetBranchShas(ctx, ctp.Spec.ProposedBranch)
+	activeShas, err := gitOperations.GetBranchShas(ctx, ctp.Spec.ActiveBranch)
+	if err != nil {
+		return fmt.Errorf("failed to get SHAs for active branch %q: %w", ctp.Spec.ActiveBranch, err)
+	}
+
+	meta.RemoveStatusCondition(ctp.GetConditions(), string(promoterConditions.BranchMissing))
+	r.setActiveBranchRewritten(ctx, ctp, gitOperations, activeShas.Hydrated)
+
+	proposedShas, err := r.getProposedBranchShas(ctx, ctp, gitOperations, activeShas)
 	if err != nil {
-		// If the proposed branch doesn't exist, it's likely because the hydrator hasn't run yet
-		if strings.Contains(err.Error(), "couldn't find remote ref") {
-			return fmt.Errorf("failed to get SHAs for proposed branch %q: %w (this branch may not exist yet - check if your hydrator is running and has processed this branch)", ctp.Spec.ProposedBranch, err)
-		}
 		return fmt.Errorf("failed to get SHAs for proposed branch %q: %w", ctp.Spec.ProposedBranch, err)
 	}
 
-	activeShas, err := gitOperations.GetBranchShas(ctx, ctp.Spec.ActiveBranch)
+	proposedShas, err = r.handleProposedBranchDivergence(ctx, ctp, gitOperations, activeShas.Hydrated, proposedShas)
 	if err != nil {
-		return fmt.Errorf("failed to get SHAs for active branch %q: %w", ctp.Spec.ActiveBranch, err)
+		return err
+	}
+
+	// The hydrator writes hydrator.metadata to every commit on the proposed branch. The active branch may legitimately
+	// have none yet, e.g. when it was just created and nothing has been promoted to it, and a proposed branch that still
+	// points at the active branch has nothing to promote.
+	if proposedShas.Dry == "" && proposedShas.Hydrated != activeShas.Hydrated {
+		return &git.InvalidHydratorMetadataError{Branch: ctp.Spec.ProposedBranch, Reason: "hydrator.metadata file not found"}
 	}
 
-	logger.Info("Branch SHAs", "branchShas", map[string]git.BranchShas{
+	logger.V(4).Info("Branch SHAs", "branchShas", map[string]git.BranchShas{
 		ctp.Spec.ActiveBranch:   activeShas,
 		ctp.Spec.ProposedBranch: proposedShas,
 	})
@@ -550,7 +1016,20 @@ func (r *ChangeTransferPolicyReconciler) calculateStatus(ctx context.Context, ct
 		return fmt.Errorf("failed to set commit metadata: %w", err)
 	}
 
-	err = r.setCommitStatusState(ctx, &ctp.Status.Active, ctp.Spec.ActiveCommitStatuses)
+	r.setProposedDryShaSuperseded(ctx, ctp, gitOperations)
+
+	err = r.setEffectivelyPromotedDrySha(ctx, ctp, gitOperations)
+	if err != nil {
+		return fmt.Errorf("failed to compare proposed and active trees: %w", err)
+	}
+
+	return r.setCommitStatusAndPullRequestState(ctx, ctp)
+}
+
+// setCommitStatusAndPullRequestState sets the parts of the status that don't come from git: the commit statuses of the
+// active and proposed commits and the state of the pull request.
+func (r *ChangeTransferPolicyReconciler) setCommitStatusAndPullRequestState(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy) error {
+	err := r.setCommitStatusState(ctx, &ctp.Status.Active, ctp.Spec.ActiveCommitStatuses)
 	if err != nil {
 		var tooManyMatchingShaError *TooManyMatchingShaError
 		if errors.As(err, &tooManyMatchingShaError) {
@@ -576,6 +1055,23 @@ func (r *ChangeTransferPolicyReconciler) calculateStatus(ctx context.Context, ct
 	return nil
 }
 
+// ProposedBranchDivergedError indicates that the proposed branch diverged from the commits previously seen on it and
+// spec.resolveDivergence is manual, so promotion waits until someone fixes the branch.
+type ProposedBranchDivergedError struct {
+	// Branch is the proposed branch.
+	Branch string
+	// PreviousSha is the last proposed hydrated commit the controller saw.
+	PreviousSha string
+	// Sha is the commit the proposed branch points at now.
+	Sha string
+}
+
+// Error implements the error interface for ProposedBranchDivergedError.
+func (e *ProposedBranchDivergedError) Error() string {
+	return fmt.Sprintf("proposed branch %q diverged, %s has neither %s nor the active branch in its history, merge %s into the branch or reset the branch to it",
+		e.Branch, e.Sha, e.PreviousSha, e.PreviousSha)
+}
+
 // NewTooManyMatchingShaError creates a new TooManyMatchingShaError. This error indicates that there are too many
 // commit status resources matching the given SHA and key.
 func NewTooManyMatchingShaError(commitStatusKey string, commitStatuses []promoterv1alpha1.CommitStatus) error {
@@ -637,6 +1133,11 @@ func (r *ChangeTransferPolicyReconciler) setCommitMetadata(ctx context.Context,
 	}
 	ctp.Status.Proposed.Hydrated = proposedCommitMetadata
 
+	truncateCommitBodies(&ctp.Status.Active.Dry)
+	truncateCommitBodies(&ctp.Status.Proposed.Dry)
+	truncateCommitBodies(&ctp.Status.Active.Hydrated)
+	truncateCommitBodies(&ctp.Status.Proposed.Hydrated)
+
 	// Read the git note for the proposed hydrated commit to get the Note.DrySha.
 	// This is used by downstream environments to verify that hydration is complete
 	// for a given dry commit before allowing promotion.
@@ -654,6 +1155,195 @@ func (r *ChangeTransferPolicyReconciler) setCommitMetadata(ctx context.Context,
 	return nil
 }
 
+// setProposedDryShaSuperseded records whether the proposed dry commit was reverted or removed upstream. The newest dry
+// commit the hydrator has processed for the proposed branch (from the git note) is used as the upstream head. A proposed
+// dry commit is only superseded when it is no longer an ancestor of (or equal to) that head, or when a commit up to that
+// head reverts it, so newer dry commits alone never mark it superseded. Failures are logged and treated as not superseded so a pull request is never closed on a guess.
+func (r *ChangeTransferPolicyReconciler) setProposedDryShaSuperseded(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy, gitOperations *git.EnvironmentOperations) {
+	logger := log.FromContext(ctx)
+
+	ctp.Status.ProposedDryShaSuperseded = false
+
+	proposedDrySha := ctp.Status.Proposed.Dry.Sha
+	headDrySha := getNoteDrySha(ctp.Status.Proposed.Note)
+	if proposedDrySha == "" || headDrySha == "" || proposedDrySha == headDrySha {
+		return
+	}
+
+	isAncestor, err := gitOperations.IsAncestor(ctx, proposedDrySha, headDrySha)
+	if err != nil {
+		logger.V(4).Info("could not determine if proposed dry sha is still upstream, assuming it is",
+			"proposedDrySha", proposedDrySha, "noteDrySha", headDrySha, "err", err)
+		return
+	}
+
+	if !isAncestor {
+		ctp.Status.ProposedDryShaSuperseded = true
+		logger.Info("Proposed dry sha is no longer in the upstream history",
+			"proposedDrySha", proposedDrySha, "noteDrySha", headDrySha)
+		return
+	}
+
+	// A dry commit that was reverted with a new commit is still an ancestor of the head, the revert is recognized by
+	// its message.
+	revertedBy, err := gitOperations.RevertedBy(ctx, proposedDrySha, headDrySha)
+	if err != nil {
+		logger.V(4).Info("could not determine if proposed dry sha was reverted upstream, assuming it wasn't",
+			"proposedDrySha", proposedDrySha, "noteDrySha", headDrySha, "err", err)
+		return
+	}
+	if revertedBy != "" {
+		ctp.Status.ProposedDryShaSuperseded = true
+		logger.Info("Proposed dry sha was reverted upstream",
+			"proposedDrySha", proposedDrySha, "revertDrySha", revertedBy, "noteDrySha", headDrySha)
+	}
+}
+
+// setEffectivelyPromotedDrySha records the proposed dry sha as promoted when the proposed branch has the same hydrated
+// tree as the active branch, so that no empty pull request is opened and merged for it. The AlwaysOpenPullRequests
+// setting turns this off for users who want a pull request for every dry commit.
+func (r *ChangeTransferPolicyReconciler) setEffectivelyPromotedDrySha(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy, gitOperations *git.EnvironmentOperations) error {
+	previous := ctp.Status.EffectivelyPromotedDrySha
+	ctp.Status.EffectivelyPromotedDrySha = ""
+
+	if ctp.Status.Proposed.Dry.Sha == "" || ctp.Status.Proposed.Dry.Sha == ctp.Status.Active.Dry.Sha {
+		return nil
+	}
+
+	alwaysOpenPullRequests, err := r.SettingsMgr.GetChangeTransferPolicyAlwaysOpenPullRequests(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get always open pull requests setting: %w", err)
+	}
+	if alwaysOpenPullRequests {
+		return nil
+	}
+
+	sameTree, err := gitOperations.HasSameTree(ctx, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
+	if err != nil {
+		return fmt.Errorf("failed to compare trees of %q and %q: %w", ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch, err)
+	}
+	if !sameTree {
+		return nil
+	}
+
+	ctp.Status.EffectivelyPromotedDrySha = ctp.Status.Proposed.Dry.Sha
+	if previous != ctp.Status.EffectivelyPromotedDrySha {
+		log.FromContext(ctx).Info("Proposed dry sha doesn't change the hydrated manifests", "proposedDrySha", ctp.Status.Proposed.Dry.Sha)
+		r.Recorder.Eventf(ctp, nil, "Normal", constants.NoChangesToPromoteReason, "EvaluatingPromotion", constants.NoChangesToPromoteMessage, ctp.Status.Proposed.DryShaShort(), ctp.Spec.ActiveBranch)
+	}
+	return nil
+}
+
+// setActiveBranchRewritten detects a force-push or other history rewrite of the active branch by checking that the
+// previously observed active hydrated commit is still in the branch's history. A rewrite sets the ActiveBranchRewritten
+// condition, which stays until the active branch moves forward normally again, so the PromotionStrategy can decide
+// whether promotions should proceed. Failures are logged and leave the condition unchanged.
+func (r *ChangeTransferPolicyReconciler) setActiveBranchRewritten(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy, gitOperations *git.EnvironmentOperations, activeHydratedSha string) {
+	logger := log.FromContext(ctx)
+
+	previousSha := ctp.Status.Active.Hydrated.Sha
+	if previousSha == "" || previousSha == activeHydratedSha {
+		return
+	}
+
+	isAncestor, err := gitOperations.IsAncestor(ctx, previousSha, activeHydratedSha)
+	if err != nil {
+		logger.V(4).Info("could not determine if the active branch was rewritten",
+			"previousSha", previousSha, "activeSha", activeHydratedSha, "err", err)
+		return
+	}
+
+	if isAncestor {
+		meta.RemoveStatusCondition(ctp.GetConditions(), string(promoterConditions.ActiveBranchRewritten))
+		return
+	}
+
+	logger.Info("Active branch history was rewritten", "branch", ctp.Spec.ActiveBranch, "previousSha", previousSha, "activeSha", activeHydratedSha)
+	meta.SetStatusCondition(ctp.GetConditions(), metav1.Condition{
+		Type:               string(promoterConditions.ActiveBranchRewritten),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(promoterConditions.HistoryRewritten),
+		Message:            fmt.Sprintf("Active branch %q was rewritten, %s is no longer in its history (now at %s)", ctp.Spec.ActiveBranch, previousSha, activeHydratedSha),
+		ObservedGeneration: ctp.Generation,
+	})
+}
+
+// handleProposedBranchDivergence detects a proposed branch that left the hydrator's lineage, e.g. because a commit was
+// pushed to it by hand and the hydrator later wrote on top of something else. The branch diverged if its tip neither
+// contains the previously observed proposed commit, nor is contained in it, nor contains the tip of the active branch
+// that a rebuilt proposed branch starts from. A divergence sets the ProposedBranchDiverged condition and is then handled
+// according to spec.resolveDivergence, it returns the proposed shas to continue with. Like setActiveBranchRewritten, the
+// condition stays until the branch moves forward normally again, and failures to inspect the history are only logged.
+func (r *ChangeTransferPolicyReconciler) handleProposedBranchDivergence(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy, gitOperations *git.EnvironmentOperations, activeHydratedSha string, proposedShas git.BranchShas) (git.BranchShas, error) {
+	logger := log.FromContext(ctx)
+
+	previousSha := ctp.Status.Proposed.Hydrated.Sha
+	if previousSha == "" || previousSha == proposedShas.Hydrated {
+		return proposedShas, nil
+	}
+
+	diverged, err := isProposedBranchDiverged(ctx, gitOperations, previousSha, proposedShas.Hydrated, activeHydratedSha)
+	if err != nil {
+		logger.V(4).Info("could not determine if the proposed branch diverged",
+			"previousSha", previousSha, "proposedSha", proposedShas.Hydrated, "err", err)
+		return proposedShas, nil
+	}
+	if !diverged {
+		meta.RemoveStatusCondition(ctp.GetConditions(), string(promoterConditions.ProposedBranchDiverged))
+		return proposedShas, nil
+	}
+
+	logger.Info("Proposed branch diverged", "branch", ctp.Spec.ProposedBranch, "previousSha", previousSha, "proposedSha", proposedShas.Hydrated)
+	message := fmt.Sprintf("Proposed branch %q diverged, %s has neither the previous proposed commit %s nor the active branch %q in its history",
+		ctp.Spec.ProposedBranch, proposedShas.Hydrated, previousSha, ctp.Spec.ActiveBranch)
+	if condition := meta.FindStatusCondition(ctp.Status.Conditions, string(promoterConditions.ProposedBranchDiverged)); condition == nil || condition.Message != message {
+		r.Recorder.Eventf(ctp, nil, "Warning", constants.ProposedBranchDivergedReason, "EvaluatingPromotion", constants.ProposedBranchDivergedMessage,
+			ctp.Spec.ProposedBranch, proposedShas.Hydrated, previousSha, ctp.Spec.ActiveBranch)
+	}
+	meta.SetStatusCondition(ctp.GetConditions(), metav1.Condition{
+		Type:               string(promoterConditions.ProposedBranchDiverged),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(promoterConditions.HistoryDiverged),
+		Message:            message,
+		ObservedGeneration: ctp.Generation,
+	})
+
+	switch ctp.Spec.ResolveDivergence {
+	case promoterv1alpha1.DivergenceResolutionReset:
+		err = gitOperations.ResetBranch(ctx, ctp.Spec.ProposedBranch, previousSha, proposedShas.Hydrated)
+		if err != nil {
+			return git.BranchShas{}, fmt.Errorf("failed to reset diverged proposed branch %q: %w", ctp.Spec.ProposedBranch, err)
+		}
+		r.Recorder.Eventf(ctp, nil, "Normal", constants.ProposedBranchResetReason, "ResettingProposedBranch", constants.ProposedBranchResetMessage,
+			ctp.Spec.ProposedBranch, proposedShas.Hydrated, previousSha)
+		meta.RemoveStatusCondition(ctp.GetConditions(), string(promoterConditions.ProposedBranchDiverged))
+
+		proposedShas, err = gitOperations.GetBranchShas(ctx, ctp.Spec.ProposedBranch)
+		if err != nil {
+			return git.BranchShas{}, fmt.Errorf("failed to get SHAs for reset proposed branch %q: %w", ctp.Spec.ProposedBranch, err)
+		}
+	case promoterv1alpha1.DivergenceResolutionManual:
+		return git.BranchShas{}, &ProposedBranchDivergedError{Branch: ctp.Spec.ProposedBranch, PreviousSha: previousSha, Sha: proposedShas.Hydrated}
+	}
+	return proposedShas, nil
+}
+
+// isProposedBranchDiverged reports whether proposedSha is outside the lineage of the proposed branch, see
+// handleProposedBranchDivergence.
+func isProposedBranchDiverged(ctx context.Context, gitOperations *git.EnvironmentOperations, previousSha, proposedSha, activeSha string) (bool, error) {
+	// The previous sha is checked last, it may have to be fetched again if the branch no longer contains it.
+	for _, pair := range [][2]string{{previousSha, proposedSha}, {activeSha, proposedSha}, {proposedSha, previousSha}} {
+		isAncestor, err := gitOperations.IsAncestor(ctx, pair[0], pair[1])
+		if err != nil {
+			return false, fmt.Errorf("failed to check if %q is an ancestor of %q: %w", pair[0], pair[1], err)
+		}
+		if isAncestor {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // setCommitStatusState sets the hydrated and dry SHAs and commit times for the target commit branch state and sets the
 // commit statuses.
 func (r *ChangeTransferPolicyReconciler) setCommitStatusState(ctx context.Context, targetCommitBranchState *promoterv1alpha1.CommitBranchState, commitStatuses []promoterv1alpha1.CommitStatusSelector) error {
@@ -717,7 +1407,7 @@ func (r *ChangeTransferPolicyReconciler) setCommitStatusState(ctx context.Contex
 			phase = promoterv1alpha1.CommitPhasePending
 			// We might not want to event here because of the potential for a lot of events, when say Argo CD is slow at updating the status
 		}
-		logger.Info("CommitStatus State",
+		logger.V(4).Info("CommitStatus State",
 			"key", status.Key,
 			"sha", targetCommitBranchState.Hydrated.Sha,
 			"phase", phase,
@@ -867,16 +1557,82 @@ func (r *ChangeTransferPolicyReconciler) handlePRFinalizerRemoval(ctx context.Co
 	return nil
 }
 
+// ctpPullRequestLabels returns the labels set on PullRequests owned by this ChangeTransferPolicy. The promotion
+// strategy UID label is only included once the PromotionStrategy controller has labeled the ChangeTransferPolicy.
+func ctpPullRequestLabels(ctp *promoterv1alpha1.ChangeTransferPolicy) map[string]string {
+	prLabels := map[string]string{
+		promoterv1alpha1.PromotionStrategyLabel:    utils.KubeSafeLabel(ctp.Labels[promoterv1alpha1.PromotionStrategyLabel]),
+		promoterv1alpha1.ChangeTransferPolicyLabel: utils.KubeSafeLabel(ctp.Name),
+		promoterv1alpha1.EnvironmentLabel:          utils.KubeSafeLabel(ctp.Spec.ActiveBranch),
+	}
+	if uid := ctp.Labels[promoterv1alpha1.PromotionStrategyUIDLabel]; uid != "" {
+		prLabels[promoterv1alpha1.PromotionStrategyUIDLabel] = uid
+	}
+	return prLabels
+}
+
 // ctpPullRequestListOptions returns list options for PullRequests owned by this ChangeTransferPolicy.
 func ctpPullRequestListOptions(ctp *promoterv1alpha1.ChangeTransferPolicy) *client.ListOptions {
 	return &client.ListOptions{
-		Namespace: ctp.Namespace,
-		LabelSelector: labels.SelectorFromSet(map[string]string{
-			promoterv1alpha1.PromotionStrategyLabel:    utils.KubeSafeLabel(ctp.Labels[promoterv1alpha1.PromotionStrategyLabel]),
-			promoterv1alpha1.ChangeTransferPolicyLabel: utils.KubeSafeLabel(ctp.Name),
-			promoterv1alpha1.EnvironmentLabel:          utils.KubeSafeLabel(ctp.Spec.ActiveBranch),
-		}),
+		Namespace:     ctp.Namespace,
+		LabelSelector: labels.SelectorFromSet(ctpPullRequestLabels(ctp)),
+	}
+}
+
+// closeOpenPullRequests closes the open PullRequests of the ChangeTransferPolicy, for when its proposed dry sha is
+// effectively promoted without one.
+func (r *ChangeTransferPolicyReconciler) closeOpenPullRequests(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy) error {
+	logger := log.FromContext(ctx)
+
+	var prList promoterv1alpha1.PullRequestList
+	if err := r.List(ctx, &prList, ctpPullRequestListOptions(ctp)); err != nil {
+		return fmt.Errorf("failed to list PullRequests: %w", err)
+	}
+
+	for _, pr := range prList.Items {
+		// Only open PullRequests are closed: merged and closed are terminal, the admission webhook rejects changing a
+		// PullRequest out of them.
+		if pr.Spec.State != promoterv1alpha1.PullRequestOpen || pr.Status.State != promoterv1alpha1.PullRequestOpen || pr.Status.ID == "" {
+			continue
+		}
+		if err := verifyPullRequestOwnerChain(&pr, ctp); err != nil {
+			logger.Error(err, "Skipping PullRequest with unexpected owner", "pullRequest", pr.Name)
+			continue
+		}
+
+		prApply := acv1alpha1.PullRequest(pr.Name, pr.Namespace).
+			WithSpec(acv1alpha1.PullRequestSpec().WithState(promoterv1alpha1.PullRequestClosed))
+		prObj := &promoterv1alpha1.PullRequest{}
+		prObj.Name = pr.Name
+		prObj.Namespace = pr.Namespace
+		if err := r.Patch(ctx, prObj, utils.ApplyPatch{ApplyConfig: prApply}, client.FieldOwner(constants.ChangeTransferPolicyControllerFieldOwner), client.ForceOwnership); err != nil {
+			return fmt.Errorf("failed to close PullRequest %q: %w", pr.Name, err)
+		}
+
+		logger.Info("Closed pull request, proposed dry sha doesn't change the hydrated manifests",
+			"pullRequest", pr.Name,
+			"proposedDrySha", ctp.Status.Proposed.Dry.Sha)
+		r.Recorder.Eventf(ctp, nil, "Normal", constants.NoChangesToPromoteReason, "ClosingPullRequest", constants.NoChangesToPromotePullRequestClosedMessage, pr.Name, ctp.Status.Proposed.DryShaShort(), ctp.Spec.ActiveBranch)
+	}
+	return nil
+}
+
+// verifyPullRequestOwnerChain returns an error unless the PullRequest is controlled by the ChangeTransferPolicy and
+// the ChangeTransferPolicy is controlled by the PromotionStrategy recorded in its promotion strategy UID label. It
+// guards against acting on a PullRequest that belongs to another PromotionStrategy.
+func verifyPullRequestOwnerChain(pr *promoterv1alpha1.PullRequest, ctp *promoterv1alpha1.ChangeTransferPolicy) error {
+	if !metav1.IsControlledBy(pr, ctp) {
+		return fmt.Errorf("PullRequest %q is not controlled by ChangeTransferPolicy %q", pr.Name, ctp.Name)
 	}
+	uid := ctp.Labels[promoterv1alpha1.PromotionStrategyUIDLabel]
+	if uid == "" {
+		return nil
+	}
+	owner := metav1.GetControllerOf(ctp)
+	if owner == nil || owner.Kind != promoterv1alpha1.PromotionStrategyKind || string(owner.UID) != uid {
+		return fmt.Errorf("ChangeTransferPolicy %q is not controlled by PromotionStrategy with UID %q", ctp.Name, uid)
+	}
+	return nil
 }
 
 // ownerReferenceToApply maps a live OwnerReference into an apply configuration fragment.
@@ -906,7 +1662,9 @@ func pullRequestApplyOwnedByChangeTransferPolicy(pr *promoterv1alpha1.PullReques
 		WithTargetBranch(pr.Spec.TargetBranch).
 		WithSourceBranch(pr.Spec.SourceBranch).
 		WithMergeSha(pr.Spec.MergeSha).
-		WithState(specState)
+		WithState(specState).
+		WithLabels(pr.Spec.Labels...).
+		WithReviewers(pr.Spec.Reviewers...)
 
 	if pr.Spec.Description != "" {
 		prSpec = prSpec.WithDescription(pr.Spec.Description)
@@ -914,9 +1672,16 @@ func pullRequestApplyOwnedByChangeTransferPolicy(pr *promoterv1alpha1.PullReques
 	if pr.Spec.Commit.Message != "" {
 		prSpec = prSpec.WithCommit(acv1alpha1.CommitConfiguration().WithMessage(pr.Spec.Commit.Message))
 	}
+	if pr.Spec.MergeStrategy != "" {
+		prSpec = prSpec.WithMergeStrategy(pr.Spec.MergeStrategy)
+	}
+	if pr.Spec.Draft {
+		prSpec = prSpec.WithDraft(true)
+	}
 
 	prApply := acv1alpha1.PullRequest(pr.Name, pr.Namespace).
-		WithLabels(pr.Labels)
+		WithLabels(pr.Labels).
+		WithAnnotations(pr.Annotations)
 
 	for i := range pr.OwnerReferences {
 		prApply = prApply.WithOwnerReferences(ownerReferenceToApply(pr.OwnerReferences[i]))
@@ -1019,32 +1784,31 @@ func (r *ChangeTransferPolicyReconciler) creatOrUpdatePullRequest(ctx context.Co
 		return nil, nil
 	}
 
+	if ctp.Status.ProposedDryShaSuperseded {
+		// The proposed change was reverted upstream, the PromotionStrategy closes any pull request that is still open.
+		logger.Info("Not opening pull request - proposed dry sha was superseded upstream",
+			"proposedDrySha", ctp.Status.Proposed.Dry.Sha)
+		return nil, nil
+	}
+
+	if ctp.Status.EffectivelyPromotedDrySha != "" && ctp.Status.EffectivelyPromotedDrySha == ctp.Status.Proposed.Dry.Sha {
+		// Merging would not change the active branch's manifests, the PromotionStrategy treats the change as promoted.
+		// A pull request opened for an earlier dry sha would only merge an empty change, close it.
+		logger.V(4).Info("Not opening pull request - proposed dry sha doesn't change the hydrated manifests",
+			"proposedDrySha", ctp.Status.Proposed.Dry.Sha)
+		return nil, r.closeOpenPullRequests(ctx, ctp)
+	}
+
 	logger.V(4).Info("Proposed dry sha, does not match active", "proposedDrySha", ctp.Status.Proposed.Dry.Sha, "activeDrySha", ctp.Status.Active.Dry.Sha)
 	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, r.Client, client.ObjectKey{Namespace: ctp.Namespace, Name: ctp.Spec.RepositoryReference.Name})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get GitRepository %q: %w", ctp.Spec.RepositoryReference.Name, err)
 	}
 
-	var prName string
-	switch {
-	case gitRepo.Spec.GitHub != nil:
-		prName = utils.GetPullRequestName(gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
-	case gitRepo.Spec.GitLab != nil:
-		prName = utils.GetPullRequestName(gitRepo.Spec.GitLab.Namespace, gitRepo.Spec.GitLab.Name, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
-	case gitRepo.Spec.Forgejo != nil:
-		prName = utils.GetPullRequestName(gitRepo.Spec.Forgejo.Owner, gitRepo.Spec.Forgejo.Name, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
-	case gitRepo.Spec.Gitea != nil:
-		prName = utils.GetPullRequestName(gitRepo.Spec.Gitea.Owner, gitRepo.Spec.Gitea.Name, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
-	case gitRepo.Spec.Fake != nil:
-		prName = utils.GetPullRequestName(gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
-	case gitRepo.Spec.BitbucketCloud != nil:
-		prName = utils.GetPullRequestName(gitRepo.Spec.BitbucketCloud.Owner, gitRepo.Spec.BitbucketCloud.Name, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
-	case gitRepo.Spec.AzureDevOps != nil:
-		prName = utils.GetPullRequestName(gitRepo.Spec.AzureDevOps.Project, gitRepo.Spec.AzureDevOps.Name, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
-	default:
-		return nil, errors.New("unsupported git repository type")
+	prName, err := pullRequestName(gitRepo, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
+	if err != nil {
+		return nil, err
 	}
-
 	prName = utils.KubeSafeUniqueName(ctx, prName)
 
 	ps, err := r.getPromotionStrategy(ctx, ctp)
@@ -1057,6 +1821,15 @@ func (r *ChangeTransferPolicyReconciler) creatOrUpdatePullRequest(ctx context.Co
 		return nil, fmt.Errorf("failed to get pull request template from settings: %w", err)
 	}
 
+	// The PromotionStrategy's pull request template overrides the ControllerConfiguration's.
+	prTemplate := ptr.Deref(ctp.Spec.PullRequest, promoterv1alpha1.PullRequestTemplateSpec{})
+	if prTemplate.Title != "" {
+		templatePullRequestTemplate.Title = prTemplate.Title
+	}
+	if prTemplate.Description != "" {
+		templatePullRequestTemplate.Description = prTemplate.Description
+	}
+
 	// Template receives the current CTP and its PromotionStrategy.
 	templateData := map[string]any{
 		"ChangeTransferPolicy": ctp,
@@ -1066,6 +1839,7 @@ func (r *ChangeTransferPolicyReconciler) creatOrUpdatePullRequest(ctx context.Co
 	if err != nil {
 		return nil, fmt.Errorf("failed to template pull request: %w", err)
 	}
+	title = prTemplate.TitlePrefix + title
 
 	// Check if the PR already exists to determine the commit message
 	existingPR := &promoterv1alpha1.PullRequest{}
@@ -1076,6 +1850,11 @@ func (r *ChangeTransferPolicyReconciler) creatOrUpdatePullRequest(ctx context.Co
 		}
 		prExists = false
 	}
+	// PullRequests are named after the repository and branches, so another PromotionStrategy promoting the same branches
+	// of the same repository owns a PullRequest with the same name. Applying ours would take it over.
+	if owner := metav1.GetControllerOf(existingPR); prExists && owner != nil && owner.UID != ctp.UID {
+		return nil, fmt.Errorf("PullRequest %q is controlled by %s %q, not by ChangeTransferPolicy %q", prName, owner.Kind, owner.Name, ctp.Name)
+	}
 
 	// Build owner reference
 	kind := reflect.TypeOf(promoterv1alpha1.ChangeTransferPolicy{}).Name()
@@ -1117,13 +1896,33 @@ func (r *ChangeTransferPolicyReconciler) creatOrUpdatePullRequest(ctx context.Co
 		prState = existingPR.Spec.State
 	}
 
+	// The ChangeTransferPolicy's propagated labels and annotations can't overwrite the labels its PullRequests are
+	// looked up by.
+	prLabels, prAnnotations := utils.PropagatedMetadata(ctp, ctp.Spec.PropagateLabels)
+	maps.Copy(prLabels, ctpPullRequestLabels(ctp))
+
+	prSpec := acv1alpha1.PullRequestSpec().
+		WithRepositoryReference(acv1alpha1.ObjectReference().WithName(ctp.Spec.RepositoryReference.Name)).
+		WithTitle(title).
+		WithTargetBranch(ctp.Spec.ActiveBranch).
+		WithSourceBranch(ctp.Spec.ProposedBranch).
+		WithDescription(description).
+		WithCommit(acv1alpha1.CommitConfiguration().WithMessage(commitMessage)).
+		WithMergeSha(ctp.Status.Proposed.Hydrated.Sha).
+		WithState(prState).
+		WithLabels(prTemplate.Labels...).
+		WithReviewers(prTemplate.Reviewers...)
+	if prTemplate.MergeStrategy != "" {
+		prSpec = prSpec.WithMergeStrategy(prTemplate.MergeStrategy)
+	}
+	if ptr.Deref(prTemplate.Draft, false) {
+		prSpec = prSpec.WithDraft(true)
+	}
+
 	// Build the apply configuration
 	prApply := acv1alpha1.PullRequest(prName, ctp.Namespace).
-		WithLabels(map[string]string{
-			promoterv1alpha1.PromotionStrategyLabel:    utils.KubeSafeLabel(ctp.Labels[promoterv1alpha1.PromotionStrategyLabel]),
-			promoterv1alpha1.ChangeTransferPolicyLabel: utils.KubeSafeLabel(ctp.Name),
-			promoterv1alpha1.EnvironmentLabel:          utils.KubeSafeLabel(ctp.Spec.ActiveBranch),
-		}).
+		WithLabels(prLabels).
+		WithAnnotations(prAnnotations).
 		WithOwnerReferences(acmetav1.OwnerReference().
 			WithAPIVersion(gvk.GroupVersion().String()).
 			WithKind(gvk.Kind).
@@ -1132,15 +1931,7 @@ func (r *ChangeTransferPolicyReconciler) creatOrUpdatePullRequest(ctx context.Co
 			WithController(true).
 			WithBlockOwnerDeletion(true)).
 		WithFinalizers(promoterv1alpha1.ChangeTransferPolicyPullRequestFinalizer).
-		WithSpec(acv1alpha1.PullRequestSpec().
-			WithRepositoryReference(acv1alpha1.ObjectReference().WithName(ctp.Spec.RepositoryReference.Name)).
-			WithTitle(title).
-			WithTargetBranch(ctp.Spec.ActiveBranch).
-			WithSourceBranch(ctp.Spec.ProposedBranch).
-			WithDescription(description).
-			WithCommit(acv1alpha1.CommitConfiguration().WithMessage(commitMessage)).
-			WithMergeSha(ctp.Status.Proposed.Hydrated.Sha).
-			WithState(prState))
+		WithSpec(prSpec)
 
 	// Apply using Server-Side Apply with Patch to get the result directly
 	pr := &promoterv1alpha1.PullRequest{}
@@ -1161,6 +1952,32 @@ func (r *ChangeTransferPolicyReconciler) creatOrUpdatePullRequest(ctx context.Co
 	return pr, nil
 }
 
+// pullRequestName returns the name of the PullRequest that merges sourceBranch into targetBranch in the GitRepository,
+// before it is made safe to use as a resource name.
+func pullRequestName(gitRepo *promoterv1alpha1.GitRepository, sourceBranch, targetBranch string) (string, error) {
+	switch {
+	case gitRepo.Spec.GitHub != nil:
+		return utils.GetPullRequestName(gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, sourceBranch, targetBranch), nil
+	case gitRepo.Spec.GitLab != nil:
+		return utils.GetPullRequestName(gitRepo.Spec.GitLab.Namespace, gitRepo.Spec.GitLab.Name, sourceBranch, targetBranch), nil
+	case gitRepo.Spec.Forgejo != nil:
+		return utils.GetPullRequestName(gitRepo.Spec.Forgejo.Owner, gitRepo.Spec.Forgejo.Name, sourceBranch, targetBranch), nil
+	case gitRepo.Spec.Gitea != nil:
+		return utils.GetPullRequestName(gitRepo.Spec.Gitea.Owner, gitRepo.Spec.Gitea.Name, sourceBranch, targetBranch), nil
+	case gitRepo.Spec.Fake != nil:
+		return utils.GetPullRequestName(gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name, sourceBranch, targetBranch), nil
+	case gitRepo.Spec.BitbucketCloud != nil:
+		return utils.GetPullRequestName(gitRepo.Spec.BitbucketCloud.Owner, gitRepo.Spec.BitbucketCloud.Name, sourceBranch, targetBranch), nil
+	case gitRepo.Spec.AzureDevOps != nil:
+		return utils.GetPullRequestName(gitRepo.Spec.AzureDevOps.Project, gitRepo.Spec.AzureDevOps.Name, sourceBranch, targetBranch), nil
+	case gitRepo.Spec.Url != "":
+		owner, name := utils.GetRepositoryOwnerAndNameFromUrl(gitRepo.Spec.Url)
+		return utils.GetPullRequestName(owner, name, sourceBranch, targetBranch), nil
+	default:
+		return "", errors.New("unsupported git repository type")
+	}
+}
+
 // mergePullRequests tries to merge the pull request if all the checks have passed and the environment is set to auto merge.
 func (r *ChangeTransferPolicyReconciler) mergePullRequests(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy) (*promoterv1alpha1.PullRequest, error) {
 	logger := log.FromContext(ctx)
@@ -1176,16 +1993,14 @@ func (r *ChangeTransferPolicyReconciler) mergePullRequests(ctx context.Context,
 		return nil, nil
 	}
 
+	if ctp.Status.ProposedDryShaSuperseded {
+		logger.Info("Not merging pull request - proposed dry sha was superseded upstream", "proposedDrySha", ctp.Status.Proposed.Dry.Sha)
+		return nil, nil
+	}
+
 	prl := promoterv1alpha1.PullRequestList{}
 	// Find the PRs that match the proposed commit and the environment. There should only be one.
-	err := r.List(ctx, &prl, &client.ListOptions{
-		Namespace: ctp.Namespace,
-		LabelSelector: labels.SelectorFromSet(map[string]string{
-			promoterv1alpha1.PromotionStrategyLabel:    utils.KubeSafeLabel(ctp.Labels[promoterv1alpha1.PromotionStrategyLabel]),
-			promoterv1alpha1.ChangeTransferPolicyLabel: utils.KubeSafeLabel(ctp.Name),
-			promoterv1alpha1.EnvironmentLabel:          utils.KubeSafeLabel(ctp.Spec.ActiveBranch),
-		}),
-	})
+	err := r.List(ctx, &prl, ctpPullRequestListOptions(ctp))
 	if err != nil {
 		return nil, fmt.Errorf("failed to list PullRequests for ChangeTransferPolicy %s and Environment %s: %w", ctp.Name, ctp.Spec.ActiveBranch, err)
 	}
@@ -1200,6 +2015,9 @@ func (r *ChangeTransferPolicyReconciler) mergePullRequests(ctx context.Context,
 
 	// We found 1 pull request process it.
 	pullRequest := prl.Items[0]
+	if err := verifyPullRequestOwnerChain(&pullRequest, ctp); err != nil {
+		return nil, fmt.Errorf("refusing to merge PullRequest: %w", err)
+	}
 	if pullRequest.Status.State == promoterv1alpha1.PullRequestOpen {
 		logger.Info("Commit status checks passed", "branch", ctp.Spec.ActiveBranch,
 			"activeCommitStatuses", ctp.Status.Active.CommitStatuses,
@@ -1219,6 +2037,12 @@ func (r *ChangeTransferPolicyReconciler) mergePullRequests(ctx context.Context,
 		return &pullRequest, nil
 	}
 
+	if pullRequest.Spec.Draft {
+		// Draft pull requests are merged on the SCM once someone marked them as ready.
+		logger.V(4).Info("Not merging pull request - it is a draft", "pr", pullRequest.Name)
+		return &pullRequest, nil
+	}
+
 	if pullRequest.Status.ID == "" {
 		// We could rely on XValidation to catch the missing ID when setting the PR to merged, but this gives a
 		// better error message.
@@ -1244,6 +2068,11 @@ func (r *ChangeTransferPolicyReconciler) mergePullRequests(ctx context.Context,
 		return &pullRequest, fmt.Errorf("failed to apply PR %q state to merged: %w", pullRequest.Name, err)
 	}
 	r.Recorder.Eventf(ctp, nil, "Normal", constants.PullRequestMergedReason, "MergingPullRequest", constants.PullRequestMergedMessage, pr.Name)
+	psName := ctp.Labels[promoterv1alpha1.PromotionStrategyLabel]
+	if owner := metav1.GetControllerOf(ctp); owner != nil && owner.Kind == reflect.TypeOf(promoterv1alpha1.PromotionStrategy{}).Name() {
+		psName = owner.Name
+	}
+	metrics.RecordPromotionMerge(ctp.Namespace, psName, ctp.Spec.ActiveBranch)
 	logger.Info("Merged pull request")
 	return pr, nil
 }
@@ -1253,7 +2082,7 @@ func (r *ChangeTransferPolicyReconciler) mergePullRequests(ctx context.Context,
 // the proposed branch is the source of truth.
 func (r *ChangeTransferPolicyReconciler) gitMergeStrategyOurs(ctx context.Context, gitOperations *git.EnvironmentOperations, ctp *promoterv1alpha1.ChangeTransferPolicy) error {
 	logger := log.FromContext(ctx)
-	logger.Info("Testing for conflicts between branches", "proposed", ctp.Spec.ProposedBranch, "active", ctp.Spec.ActiveBranch)
+	logger.V(4).Info("Testing for conflicts between branches", "proposed", ctp.Spec.ProposedBranch, "active", ctp.Spec.ActiveBranch)
 
 	// Check if there's a conflict between branches
 	hasConflict, err := gitOperations.HasConflict(ctx, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
@@ -1318,6 +2147,12 @@ func (r *ChangeTransferPolicyReconciler) handleFinalizer(ctx context.Context, ct
 		return false, fmt.Errorf("failed to clean up PullRequest finalizers for deleted ChangeTransferPolicy: %w", err)
 	}
 
+	// Remove the environment's clone, nothing else uses it. This is best effort, the clone sweeper removes it later if
+	// this fails.
+	if err := git.RemoveEnvironmentClone(ctx, ctp.Namespace, ctp.Spec.RepositoryReference.Name, ctp.Spec.ActiveBranch); err != nil {
+		log.FromContext(ctx).Error(err, "failed to remove clone of deleted ChangeTransferPolicy")
+	}
+
 	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error { //nolint:wrapcheck // RetryOnConflict returns wrapped error
 		if err := r.Get(ctx, client.ObjectKeyFromObject(ctp), ctp); err != nil {
 			return err //nolint:wrapcheck // error will be wrapped by caller
@@ -21,19 +21,27 @@ import (
 	_ "embed"
 	"fmt"
 	"os"
+	"path"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/settings"
+	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 //go:embed testdata/ChangeTransferPolicy.yaml
@@ -105,8 +113,15 @@ var _ = Describe("ChangeTransferPolicy Controller", func() {
 					err = k8sClient.Get(ctx, typeNamespacedName, changeTransferPolicy)
 					g.Expect(err).To(Succeed())
 					g.Expect(changeTransferPolicy.Status.Proposed.Dry.Sha).To(Equal(fullSha))
+					g.Expect(changeTransferPolicy.Status.ProposedDryShortSha).To(Equal(fullSha[:7]))
+					g.Expect(changeTransferPolicy.Status.ActiveDryShortSha).To(Equal(changeTransferPolicy.Status.Active.DryShaShort()))
 					g.Expect(changeTransferPolicy.Status.Active.Hydrated.Sha).ToNot(Equal(""))
 					g.Expect(changeTransferPolicy.Status.Proposed.Hydrated.Sha).ToNot(Equal(""))
+					g.Expect(changeTransferPolicy.Status.LastLsRemote).NotTo(BeNil())
+					g.Expect(changeTransferPolicy.Status.LastLsRemote.ActiveSha).To(Equal(changeTransferPolicy.Status.Active.Hydrated.Sha))
+					g.Expect(changeTransferPolicy.Status.LastLsRemote.ProposedSha).To(Equal(changeTransferPolicy.Status.Proposed.Hydrated.Sha))
+					g.Expect(changeTransferPolicy.Status.Polling).NotTo(BeNil())
+					g.Expect(changeTransferPolicy.Status.Polling.RequeueInterval.Duration).To(BeNumerically(">", 0))
 				}, constants.EventuallyTimeout).Should(Succeed())
 
 				Eventually(func(g Gomega) {
@@ -556,6 +571,473 @@ var _ = Describe("ChangeTransferPolicy Controller", func() {
 			})
 		})
 
+		Context("When the environment branches exist without proposed branches", func() {
+			var name string
+			var scmSecret *v1.Secret
+			var scmProvider *promoterv1alpha1.ScmProvider
+			var gitRepo *promoterv1alpha1.GitRepository
+			var changeTransferPolicy *promoterv1alpha1.ChangeTransferPolicy
+			var typeNamespacedName types.NamespacedName
+
+			BeforeEach(func() {
+				name, scmSecret, scmProvider, gitRepo, _, changeTransferPolicy = changeTransferPolicyResources(ctx, "ctp-missing-proposed", "default")
+
+				typeNamespacedName = types.NamespacedName{
+					Name:      name,
+					Namespace: "default",
+				}
+
+				By("Deleting the proposed branches so only the environment branches remain")
+				gitPath, err := os.MkdirTemp("", "*")
+				Expect(err).NotTo(HaveOccurred())
+				defer func() {
+					Expect(os.RemoveAll(gitPath)).To(Succeed())
+				}()
+				_, err = runGitCmd(ctx, gitPath, "clone", testGitRepoCloneURL(gitRepo), ".")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "push", "origin", "--delete", testBranchDevelopmentNext, testBranchStagingNext, testBranchProductionNext)
+				Expect(err).NotTo(HaveOccurred())
+
+				changeTransferPolicy.Spec.ProposedBranch = testBranchDevelopmentNext
+				changeTransferPolicy.Spec.ActiveBranch = testBranchDevelopment
+				changeTransferPolicy.Spec.AutoMerge = ptr.To(false)
+
+				Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+				Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+				Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				By("Cleaning up resources")
+				Expect(k8sClient.Delete(ctx, changeTransferPolicy)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, gitRepo)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, scmProvider)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, scmSecret)).To(Succeed())
+			})
+
+			It("should create the proposed branch from the active branch", func() {
+				Expect(k8sClient.Create(ctx, changeTransferPolicy)).To(Succeed())
+
+				Eventually(func(g Gomega) {
+					out, err := runGitCmd(ctx, "", "ls-remote", "--heads", testGitRepoCloneURL(gitRepo), testBranchDevelopment, testBranchDevelopmentNext)
+					g.Expect(err).NotTo(HaveOccurred())
+					shas := map[string]string{}
+					for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+						sha, ref, _ := strings.Cut(line, "\t")
+						shas[strings.TrimPrefix(ref, "refs/heads/")] = sha
+					}
+					g.Expect(shas).To(HaveKey(testBranchDevelopmentNext))
+					g.Expect(shas[testBranchDevelopmentNext]).To(Equal(shas[testBranchDevelopment]))
+				}, constants.EventuallyTimeout).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, typeNamespacedName, changeTransferPolicy)).To(Succeed())
+					g.Expect(changeTransferPolicy.Status.Proposed.Hydrated.Sha).ToNot(BeEmpty())
+					g.Expect(changeTransferPolicy.Status.Proposed.Hydrated.Sha).To(Equal(changeTransferPolicy.Status.Active.Hydrated.Sha))
+					prCreated := meta.FindStatusCondition(changeTransferPolicy.Status.Conditions, string(promoterConditions.PullRequestCreated))
+					g.Expect(prCreated).ToNot(BeNil())
+					g.Expect(prCreated.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(prCreated.Reason).To(Equal(string(promoterConditions.NothingToPromote)))
+				}, constants.EventuallyTimeout).Should(Succeed())
+
+				By("Checking that the other environments were left alone")
+				out, err := runGitCmd(ctx, "", "ls-remote", "--heads", testGitRepoCloneURL(gitRepo), testBranchStagingNext)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(strings.TrimSpace(out)).To(BeEmpty())
+			})
+
+			It("should report ActiveBranchMissing when the active branch does not exist", func() {
+				changeTransferPolicy.Spec.ActiveBranch = "environment/does-not-exist"
+				Expect(k8sClient.Create(ctx, changeTransferPolicy)).To(Succeed())
+
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, typeNamespacedName, changeTransferPolicy)).To(Succeed())
+					ready := meta.FindStatusCondition(changeTransferPolicy.Status.Conditions, string(promoterConditions.Ready))
+					g.Expect(ready).ToNot(BeNil())
+					g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(ready.Reason).To(Equal(string(promoterConditions.ActiveBranchMissing)))
+					g.Expect(ready.Message).To(ContainSubstring("environment/does-not-exist"))
+
+					branchMissing := meta.FindStatusCondition(changeTransferPolicy.Status.Conditions, string(promoterConditions.BranchMissing))
+					g.Expect(branchMissing).ToNot(BeNil())
+					g.Expect(branchMissing.Status).To(Equal(metav1.ConditionTrue))
+					g.Expect(branchMissing.Reason).To(Equal(string(promoterConditions.RefNotFound)))
+					g.Expect(branchMissing.Message).To(ContainSubstring("refs/heads/environment/does-not-exist"))
+				}, constants.EventuallyTimeout).Should(Succeed())
+
+				out, err := runGitCmd(ctx, "", "ls-remote", "--heads", testGitRepoCloneURL(gitRepo), testBranchDevelopmentNext)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(strings.TrimSpace(out)).To(BeEmpty())
+			})
+
+			It("should report CloneFailed when the repository cannot be cloned", func() {
+				missingRepo := gitRepo.DeepCopy()
+				missingRepo.ObjectMeta = metav1.ObjectMeta{Name: name + "-missing", Namespace: "default"}
+				missingRepo.Spec.Fake.Name = "does-not-exist"
+				Expect(k8sClient.Create(ctx, missingRepo)).To(Succeed())
+				defer func() {
+					Expect(k8sClient.Delete(ctx, missingRepo)).To(Succeed())
+				}()
+
+				changeTransferPolicy.Spec.RepositoryReference.Name = missingRepo.Name
+				Expect(k8sClient.Create(ctx, changeTransferPolicy)).To(Succeed())
+
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, typeNamespacedName, changeTransferPolicy)).To(Succeed())
+					ready := meta.FindStatusCondition(changeTransferPolicy.Status.Conditions, string(promoterConditions.Ready))
+					g.Expect(ready).ToNot(BeNil())
+					g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(ready.Reason).To(Equal(string(promoterConditions.CloneFailed)))
+					g.Expect(ready.ObservedGeneration).To(Equal(changeTransferPolicy.Generation))
+				}, constants.EventuallyTimeout).Should(Succeed())
+			})
+		})
+
+		Context("When the active branch is force-pushed", func() {
+			var name string
+			var scmSecret *v1.Secret
+			var scmProvider *promoterv1alpha1.ScmProvider
+			var gitRepo *promoterv1alpha1.GitRepository
+			var changeTransferPolicy *promoterv1alpha1.ChangeTransferPolicy
+			var typeNamespacedName types.NamespacedName
+			var gitPath string
+
+			BeforeEach(func() {
+				name, scmSecret, scmProvider, gitRepo, _, changeTransferPolicy = changeTransferPolicyResources(ctx, "ctp-active-rewritten", "default")
+
+				typeNamespacedName = types.NamespacedName{
+					Name:      name,
+					Namespace: "default",
+				}
+
+				changeTransferPolicy.Spec.ProposedBranch = testBranchDevelopmentNext
+				changeTransferPolicy.Spec.ActiveBranch = testBranchDevelopment
+				changeTransferPolicy.Spec.AutoMerge = ptr.To(false)
+
+				Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+				Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+				Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+				Expect(k8sClient.Create(ctx, changeTransferPolicy)).To(Succeed())
+
+				var err error
+				gitPath, err = os.MkdirTemp("", "*")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "clone", testGitRepoCloneURL(gitRepo), ".")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(configureTestIdentity(ctx, gitPath)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				By("Cleaning up resources")
+				Expect(os.RemoveAll(gitPath)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, changeTransferPolicy)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, gitRepo)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, scmProvider)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, scmSecret)).To(Succeed())
+			})
+
+			It("should report ActiveBranchRewritten and recover without wiping the clone", func() {
+				var originalActiveSha string
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, typeNamespacedName, changeTransferPolicy)).To(Succeed())
+					originalActiveSha = changeTransferPolicy.Status.Active.Hydrated.Sha
+					g.Expect(originalActiveSha).ToNot(BeEmpty())
+				}, constants.EventuallyTimeout).Should(Succeed())
+
+				By("Force-pushing a rewritten history to the active branch")
+				_, err := runGitCmd(ctx, gitPath, "checkout", "--orphan", "rewritten")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "commit", "--allow-empty", "-m", "rewritten active history")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "push", "--force", "origin", "rewritten:"+testBranchDevelopment)
+				Expect(err).NotTo(HaveOccurred())
+				rewrittenSha, err := runGitCmd(ctx, gitPath, "rev-parse", "HEAD")
+				Expect(err).NotTo(HaveOccurred())
+				rewrittenSha = strings.TrimSpace(rewrittenSha)
+
+				By("Force-pushing the proposed branch the way the hydrator rebuilds it")
+				err = os.WriteFile(path.Join(gitPath, "hydrator.metadata"), []byte(fmt.Sprintf("{\"drySha\": %q}", rewrittenSha)), 0o644)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "add", "hydrator.metadata")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "commit", "-m", "rebuilt proposed history")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "push", "--force", "origin", "rewritten:"+testBranchDevelopmentNext)
+				Expect(err).NotTo(HaveOccurred())
+				rebuiltProposedSha, err := runGitCmd(ctx, gitPath, "rev-parse", "HEAD")
+				Expect(err).NotTo(HaveOccurred())
+				rebuiltProposedSha = strings.TrimSpace(rebuiltProposedSha)
+
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, typeNamespacedName, changeTransferPolicy)).To(Succeed())
+					g.Expect(changeTransferPolicy.Status.Active.Hydrated.Sha).To(Equal(rewrittenSha))
+					g.Expect(changeTransferPolicy.Status.Proposed.Hydrated.Sha).To(Equal(rebuiltProposedSha))
+					rewritten := meta.FindStatusCondition(changeTransferPolicy.Status.Conditions, string(promoterConditions.ActiveBranchRewritten))
+					g.Expect(rewritten).ToNot(BeNil())
+					g.Expect(rewritten.Status).To(Equal(metav1.ConditionTrue))
+					g.Expect(rewritten.Reason).To(Equal(string(promoterConditions.HistoryRewritten)))
+					g.Expect(rewritten.Message).To(ContainSubstring(originalActiveSha))
+				}, constants.EventuallyTimeout).Should(Succeed())
+
+				By("Moving the active branch forward normally")
+				_, err = runGitCmd(ctx, gitPath, "push", "origin", "rewritten:"+testBranchDevelopment)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, typeNamespacedName, changeTransferPolicy)).To(Succeed())
+					g.Expect(changeTransferPolicy.Status.Active.Hydrated.Sha).To(Equal(rebuiltProposedSha))
+					g.Expect(meta.FindStatusCondition(changeTransferPolicy.Status.Conditions, string(promoterConditions.ActiveBranchRewritten))).To(BeNil())
+				}, constants.EventuallyTimeout).Should(Succeed())
+			})
+		})
+
+		Context("When the proposed branch diverges", func() {
+			var name string
+			var scmSecret *v1.Secret
+			var scmProvider *promoterv1alpha1.ScmProvider
+			var gitRepo *promoterv1alpha1.GitRepository
+			var changeTransferPolicy *promoterv1alpha1.ChangeTransferPolicy
+			var typeNamespacedName types.NamespacedName
+			var gitPath string
+
+			BeforeEach(func() {
+				name, scmSecret, scmProvider, gitRepo, _, changeTransferPolicy = changeTransferPolicyResources(ctx, "ctp-proposed-diverged", "default")
+
+				typeNamespacedName = types.NamespacedName{
+					Name:      name,
+					Namespace: "default",
+				}
+
+				changeTransferPolicy.Spec.ProposedBranch = testBranchDevelopmentNext
+				changeTransferPolicy.Spec.ActiveBranch = testBranchDevelopment
+				changeTransferPolicy.Spec.AutoMerge = ptr.To(false)
+				changeTransferPolicy.Spec.ResolveDivergence = promoterv1alpha1.DivergenceResolutionManual
+
+				Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+				Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+				Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+				Expect(k8sClient.Create(ctx, changeTransferPolicy)).To(Succeed())
+
+				var err error
+				gitPath, err = os.MkdirTemp("", "*")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "clone", testGitRepoCloneURL(gitRepo), ".")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(configureTestIdentity(ctx, gitPath)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				By("Cleaning up resources")
+				Expect(os.RemoveAll(gitPath)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, changeTransferPolicy)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, gitRepo)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, scmProvider)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, scmSecret)).To(Succeed())
+			})
+
+			It("should block with manual and reset the branch with reset", func() {
+				var previousProposedSha string
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, typeNamespacedName, changeTransferPolicy)).To(Succeed())
+					previousProposedSha = changeTransferPolicy.Status.Proposed.Hydrated.Sha
+					g.Expect(previousProposedSha).ToNot(BeEmpty())
+				}, constants.EventuallyTimeout).Should(Succeed())
+
+				By("Force-pushing an unrelated history to the proposed branch")
+				_, err := runGitCmd(ctx, gitPath, "checkout", "--orphan", "manual-fix")
+				Expect(err).NotTo(HaveOccurred())
+				err = os.WriteFile(path.Join(gitPath, "hydrator.metadata"), []byte(`{"drySha": "0000000000000000000000000000000000000000"}`), 0o644)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "add", "hydrator.metadata")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "commit", "-m", "manual fix")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "push", "--force", "origin", "manual-fix:"+testBranchDevelopmentNext)
+				Expect(err).NotTo(HaveOccurred())
+				divergedSha, err := runGitCmd(ctx, gitPath, "rev-parse", "HEAD")
+				Expect(err).NotTo(HaveOccurred())
+				divergedSha = strings.TrimSpace(divergedSha)
+
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, typeNamespacedName, changeTransferPolicy)).To(Succeed())
+					g.Expect(changeTransferPolicy.Status.Proposed.Hydrated.Sha).To(Equal(previousProposedSha))
+					diverged := meta.FindStatusCondition(changeTransferPolicy.Status.Conditions, string(promoterConditions.ProposedBranchDiverged))
+					g.Expect(diverged).ToNot(BeNil())
+					g.Expect(diverged.Status).To(Equal(metav1.ConditionTrue))
+					g.Expect(diverged.Reason).To(Equal(string(promoterConditions.HistoryDiverged)))
+					g.Expect(diverged.Message).To(ContainSubstring(previousProposedSha))
+					g.Expect(diverged.Message).To(ContainSubstring(divergedSha))
+					ready := meta.FindStatusCondition(changeTransferPolicy.Status.Conditions, string(promoterConditions.Ready))
+					g.Expect(ready).ToNot(BeNil())
+					g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(ready.Reason).To(Equal(string(promoterConditions.HistoryDiverged)))
+				}, constants.EventuallyTimeout).Should(Succeed())
+
+				By("Switching the policy to reset")
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, typeNamespacedName, changeTransferPolicy)).To(Succeed())
+					changeTransferPolicy.Spec.ResolveDivergence = promoterv1alpha1.DivergenceResolutionReset
+					g.Expect(k8sClient.Update(ctx, changeTransferPolicy)).To(Succeed())
+				}, constants.EventuallyTimeout).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, typeNamespacedName, changeTransferPolicy)).To(Succeed())
+					g.Expect(meta.FindStatusCondition(changeTransferPolicy.Status.Conditions, string(promoterConditions.ProposedBranchDiverged))).To(BeNil())
+					g.Expect(meta.IsStatusConditionTrue(changeTransferPolicy.Status.Conditions, string(promoterConditions.Ready))).To(BeTrue())
+					g.Expect(changeTransferPolicy.Status.Proposed.Hydrated.Sha).To(Equal(previousProposedSha))
+					remoteRef, err := runGitCmd(ctx, gitPath, "ls-remote", "origin", "refs/heads/"+testBranchDevelopmentNext)
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(remoteRef).To(HavePrefix(previousProposedSha))
+				}, constants.EventuallyTimeout).Should(Succeed())
+			})
+		})
+
+		Context("When the proposed commit doesn't change the hydrated manifests", func() {
+			var name string
+			var scmSecret *v1.Secret
+			var scmProvider *promoterv1alpha1.ScmProvider
+			var gitRepo *promoterv1alpha1.GitRepository
+			var changeTransferPolicy *promoterv1alpha1.ChangeTransferPolicy
+			var typeNamespacedName types.NamespacedName
+			var gitPath string
+
+			BeforeEach(func() {
+				name, scmSecret, scmProvider, gitRepo, _, changeTransferPolicy = changeTransferPolicyResources(ctx, "ctp-same-tree", "default")
+
+				typeNamespacedName = types.NamespacedName{
+					Name:      name,
+					Namespace: "default",
+				}
+
+				changeTransferPolicy.Spec.ProposedBranch = testBranchDevelopmentNext
+				changeTransferPolicy.Spec.ActiveBranch = testBranchDevelopment
+				changeTransferPolicy.Spec.AutoMerge = ptr.To(false)
+
+				Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+				Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+				Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+				Expect(k8sClient.Create(ctx, changeTransferPolicy)).To(Succeed())
+
+				var err error
+				gitPath, err = os.MkdirTemp("", "*")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "clone", testGitRepoCloneURL(gitRepo), ".")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(configureTestIdentity(ctx, gitPath)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				By("Cleaning up resources")
+				Expect(os.RemoveAll(gitPath)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, changeTransferPolicy)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, gitRepo)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, scmProvider)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, scmSecret)).To(Succeed())
+			})
+
+			It("should record the dry sha as effectively promoted without opening a pull request", func() {
+				By("Adding a dry commit that doesn't change the environment's manifests")
+				defaultBranch, err := runGitCmd(ctx, gitPath, "rev-parse", "--abbrev-ref", "origin/HEAD")
+				Expect(err).NotTo(HaveOccurred())
+				defaultBranch, _ = strings.CutPrefix(strings.TrimSpace(defaultBranch), "origin/")
+				_, err = runGitCmd(ctx, gitPath, "checkout", defaultBranch)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "commit", "--allow-empty", "-m", "bump a version the environment doesn't use")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "push", "origin", defaultBranch)
+				Expect(err).NotTo(HaveOccurred())
+				drySha, err := runGitCmd(ctx, gitPath, "rev-parse", "HEAD")
+				Expect(err).NotTo(HaveOccurred())
+				drySha = strings.TrimSpace(drySha)
+
+				By("Hydrating the proposed branch with only a new hydrator.metadata")
+				_, err = runGitCmd(ctx, gitPath, "checkout", "-B", testBranchDevelopmentNext, "origin/"+testBranchDevelopment)
+				Expect(err).NotTo(HaveOccurred())
+				err = os.WriteFile(path.Join(gitPath, "hydrator.metadata"), []byte(fmt.Sprintf("{\"drySha\": %q}", drySha)), 0o644)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "add", "hydrator.metadata")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "commit", "-m", "hydrate "+drySha)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = runGitCmd(ctx, gitPath, "push", "--force", "origin", testBranchDevelopmentNext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, typeNamespacedName, changeTransferPolicy)).To(Succeed())
+					g.Expect(changeTransferPolicy.Status.Proposed.Dry.Sha).To(Equal(drySha))
+					g.Expect(changeTransferPolicy.Status.EffectivelyPromotedDrySha).To(Equal(drySha))
+					prCreated := meta.FindStatusCondition(changeTransferPolicy.Status.Conditions, string(promoterConditions.PullRequestCreated))
+					g.Expect(prCreated).ToNot(BeNil())
+					g.Expect(prCreated.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(prCreated.Reason).To(Equal(string(promoterConditions.NothingToPromote)))
+				}, constants.EventuallyTimeout).Should(Succeed())
+
+				prName := utils.GetPullRequestName(gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name, changeTransferPolicy.Spec.ProposedBranch, changeTransferPolicy.Spec.ActiveBranch)
+				Consistently(func(g Gomega) {
+					var pr promoterv1alpha1.PullRequest
+					err := k8sClient.Get(ctx, types.NamespacedName{Name: utils.KubeSafeUniqueName(ctx, prName), Namespace: "default"}, &pr)
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}, "2s").Should(Succeed())
+			})
+
+			It("should close the pull request of an earlier dry sha once the proposed tree equals the active tree", func() {
+				defaultBranch, err := runGitCmd(ctx, gitPath, "rev-parse", "--abbrev-ref", "origin/HEAD")
+				Expect(err).NotTo(HaveOccurred())
+				defaultBranch, _ = strings.CutPrefix(strings.TrimSpace(defaultBranch), "origin/")
+
+				// hydrate pushes a dry commit and a proposed branch with its hydrator.metadata on top of the active
+				// branch, and with the manifest if it is set.
+				hydrate := func(manifest string) string {
+					_, err := runGitCmd(ctx, gitPath, "checkout", defaultBranch)
+					Expect(err).NotTo(HaveOccurred())
+					_, err = runGitCmd(ctx, gitPath, "pull", "origin", defaultBranch)
+					Expect(err).NotTo(HaveOccurred())
+					_, err = runGitCmd(ctx, gitPath, "commit", "--allow-empty", "-m", "dry change")
+					Expect(err).NotTo(HaveOccurred())
+					_, err = runGitCmd(ctx, gitPath, "push", "origin", defaultBranch)
+					Expect(err).NotTo(HaveOccurred())
+					drySha, err := runGitCmd(ctx, gitPath, "rev-parse", "HEAD")
+					Expect(err).NotTo(HaveOccurred())
+					drySha = strings.TrimSpace(drySha)
+
+					_, err = runGitCmd(ctx, gitPath, "checkout", "-B", testBranchDevelopmentNext, "origin/"+testBranchDevelopment)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(os.WriteFile(path.Join(gitPath, "hydrator.metadata"), []byte(fmt.Sprintf("{\"drySha\": %q}", drySha)), 0o644)).To(Succeed())
+					if manifest != "" {
+						Expect(os.WriteFile(path.Join(gitPath, "manifests-hydrated.yaml"), []byte(manifest), 0o644)).To(Succeed())
+					}
+					_, err = runGitCmd(ctx, gitPath, "add", ".")
+					Expect(err).NotTo(HaveOccurred())
+					_, err = runGitCmd(ctx, gitPath, "commit", "-m", "hydrate "+drySha)
+					Expect(err).NotTo(HaveOccurred())
+					_, err = runGitCmd(ctx, gitPath, "push", "--force", "origin", testBranchDevelopmentNext)
+					Expect(err).NotTo(HaveOccurred())
+					return drySha
+				}
+
+				By("Proposing a dry commit that changes the environment's manifests")
+				hydrate("{\"kind\": \"ConfigMap\", \"metadata\": {\"name\": \"changed\"}}")
+				prName := utils.KubeSafeUniqueName(ctx, utils.GetPullRequestName(gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name, changeTransferPolicy.Spec.ProposedBranch, changeTransferPolicy.Spec.ActiveBranch))
+				var pr promoterv1alpha1.PullRequest
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: prName, Namespace: "default"}, &pr)).To(Succeed())
+					g.Expect(pr.Status.State).To(Equal(promoterv1alpha1.PullRequestOpen))
+				}, constants.EventuallyTimeout).Should(Succeed())
+
+				By("Proposing a newer dry commit that brings the manifests back to the active ones")
+				drySha := hydrate("")
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, typeNamespacedName, changeTransferPolicy)).To(Succeed())
+					g.Expect(changeTransferPolicy.Status.EffectivelyPromotedDrySha).To(Equal(drySha))
+					err := k8sClient.Get(ctx, types.NamespacedName{Name: prName, Namespace: "default"}, &pr)
+					if !errors.IsNotFound(err) {
+						g.Expect(err).NotTo(HaveOccurred())
+						g.Expect(pr.Spec.State).To(Equal(promoterv1alpha1.PullRequestClosed))
+					}
+				}, constants.EventuallyTimeout).Should(Succeed())
+			})
+		})
+
 		Context("When handling PR lifecycle and finalizers", func() {
 			var name string
 			var scmSecret *v1.Secret
@@ -804,6 +1286,154 @@ var _ = Describe("tooManyPRsError", func() {
 	})
 })
 
+var _ = Describe("truncateCommitBody", func() {
+	It("keeps a body of at most maxCommitBodyLength characters", func() {
+		body := strings.Repeat("a", maxCommitBodyLength)
+		Expect(truncateCommitBody(body)).To(Equal(body))
+	})
+
+	It("truncates a longer body by character without splitting a multi-byte rune", func() {
+		body := strings.Repeat("a", maxCommitBodyLength-1) + "é" + strings.Repeat("b", 10)
+		truncated := truncateCommitBody(body)
+		Expect(truncated).To(Equal(strings.Repeat("a", maxCommitBodyLength-1) + "é"))
+		Expect(utf8.RuneCountInString(truncated)).To(Equal(maxCommitBodyLength))
+		Expect(utf8.ValidString(truncated)).To(BeTrue())
+	})
+
+	It("truncates the bodies of the referenced commits", func() {
+		long := strings.Repeat("a", maxCommitBodyLength+1)
+		state := promoterv1alpha1.CommitShaState{
+			Body: long,
+			References: []promoterv1alpha1.RevisionReference{
+				{Commit: &promoterv1alpha1.CommitMetadata{Body: long}},
+				{},
+			},
+		}
+		truncateCommitBodies(&state)
+		Expect(state.Body).To(HaveLen(maxCommitBodyLength))
+		Expect(state.References[0].Commit.Body).To(HaveLen(maxCommitBodyLength))
+		Expect(state.References[1].Commit).To(BeNil())
+	})
+})
+
+var _ = Describe("reconcileInterval", func() {
+	var reconciler *ChangeTransferPolicyReconciler
+	var recorder *events.FakeRecorder
+
+	BeforeEach(func() {
+		recorder = events.NewFakeRecorder(10)
+		reconciler = &ChangeTransferPolicyReconciler{
+			Recorder:    recorder,
+			SettingsMgr: settings.NewManager(k8sClient, k8sClient, settings.ManagerConfig{ControllerNamespace: "default"}),
+		}
+	})
+
+	It("uses the controller's requeue duration when the spec doesn't set one", func() {
+		interval, err := reconciler.reconcileInterval(ctx, &promoterv1alpha1.ChangeTransferPolicy{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(interval).To(Equal(5 * time.Minute))
+	})
+
+	It("uses the spec's reconcileInterval", func() {
+		ctp := &promoterv1alpha1.ChangeTransferPolicy{
+			Spec: promoterv1alpha1.ChangeTransferPolicySpec{ReconcileInterval: &metav1.Duration{Duration: 15 * time.Second}},
+		}
+		interval, err := reconciler.reconcileInterval(ctx, ctp)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(interval).To(Equal(15 * time.Second))
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("raises a reconcileInterval below the minimum to the minimum with a warning", func() {
+		ctp := &promoterv1alpha1.ChangeTransferPolicy{
+			Spec: promoterv1alpha1.ChangeTransferPolicySpec{ReconcileInterval: &metav1.Duration{Duration: time.Second}},
+		}
+		interval, err := reconciler.reconcileInterval(ctx, ctp)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(interval).To(Equal(settings.DefaultMinReconcileInterval))
+		Expect(recorder.Events).To(Receive(And(
+			ContainSubstring("Warning "+constants.ReconcileIntervalRaisedReason),
+			ContainSubstring("reconcileInterval 1s is below"),
+		)))
+	})
+
+	It("only warns about a raised reconcileInterval once for each generation", func() {
+		ctp := &promoterv1alpha1.ChangeTransferPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "raised", Namespace: "default", UID: "uid", Generation: 1},
+			Spec:       promoterv1alpha1.ChangeTransferPolicySpec{ReconcileInterval: &metav1.Duration{Duration: time.Second}},
+		}
+		for range 2 {
+			interval, err := reconciler.reconcileInterval(ctx, ctp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(interval).To(Equal(settings.DefaultMinReconcileInterval))
+		}
+		Expect(recorder.Events).To(HaveLen(1))
+		<-recorder.Events
+
+		ctp.Generation = 2
+		_, err := reconciler.reconcileInterval(ctx, ctp)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recorder.Events).To(Receive(ContainSubstring("Warning " + constants.ReconcileIntervalRaisedReason)))
+	})
+})
+
+var _ = Describe("lfsChecked", func() {
+	It("forgets the Git LFS check of a deleted ChangeTransferPolicy", func() {
+		reconciler := &ChangeTransferPolicyReconciler{
+			Client:      k8sClient,
+			Recorder:    events.NewFakeRecorder(10),
+			SettingsMgr: settings.NewManager(k8sClient, k8sClient, settings.ManagerConfig{ControllerNamespace: "default"}),
+		}
+		key := types.NamespacedName{Namespace: "default", Name: "deleted-" + randomString(5)}
+		reconciler.lfsChecked.Store(key, lfsCheck{uid: "uid", sha: lfsWarned})
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		_, ok := reconciler.lfsChecked.Load(key)
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("branchesUnchanged", func() {
+	var ctp *promoterv1alpha1.ChangeTransferPolicy
+	var previous *promoterv1alpha1.LsRemoteState
+
+	BeforeEach(func() {
+		ctp = &promoterv1alpha1.ChangeTransferPolicy{}
+		ctp.Status.Active.Hydrated.Sha = "1111111111111111111111111111111111111111"
+		ctp.Status.Proposed.Hydrated.Sha = "2222222222222222222222222222222222222222"
+		previous = &promoterv1alpha1.LsRemoteState{
+			ActiveSha:   ctp.Status.Active.Hydrated.Sha,
+			ProposedSha: ctp.Status.Proposed.Hydrated.Sha,
+			NotesSha:    "3333333333333333333333333333333333333333",
+		}
+	})
+
+	It("is true when the refs match the previous ls-remote and the status", func() {
+		Expect(branchesUnchanged(ctp, previous, previous.DeepCopy())).To(BeTrue())
+	})
+
+	It("is false without a previous or current ls-remote", func() {
+		Expect(branchesUnchanged(ctp, nil, previous)).To(BeFalse())
+		Expect(branchesUnchanged(ctp, previous, nil)).To(BeFalse())
+	})
+
+	It("is false when a branch or the notes moved", func() {
+		current := previous.DeepCopy()
+		current.ProposedSha = "4444444444444444444444444444444444444444"
+		Expect(branchesUnchanged(ctp, previous, current)).To(BeFalse())
+
+		current = previous.DeepCopy()
+		current.NotesSha = "4444444444444444444444444444444444444444"
+		Expect(branchesUnchanged(ctp, previous, current)).To(BeFalse())
+	})
+
+	It("is false when the status doesn't match the branches", func() {
+		ctp.Status.Active.Hydrated.Sha = "4444444444444444444444444444444444444444"
+		Expect(branchesUnchanged(ctp, previous, previous.DeepCopy())).To(BeFalse())
+	})
+})
+
 //nolint:unparam // namespace is always "default" in tests but kept for consistency with other test helpers
 func changeTransferPolicyResources(ctx context.Context, name, namespace string) (string, *v1.Secret, *promoterv1alpha1.ScmProvider, *promoterv1alpha1.GitRepository, *promoterv1alpha1.CommitStatus, *promoterv1alpha1.ChangeTransferPolicy) {
 	name = name + "-" + utils.KubeSafeUniqueName(ctx, randomString(15))
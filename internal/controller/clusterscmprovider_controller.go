@@ -28,11 +28,14 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/settings"
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
 	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
@@ -44,6 +47,9 @@ type ClusterScmProviderReconciler struct {
 	Scheme      *runtime.Scheme
 	Recorder    events.EventRecorder
 	SettingsMgr *settings.Manager
+	// Secrets watches the Secrets the reconciler reads through its client. If nil, the Secrets of the manager's cache
+	// are watched instead.
+	Secrets *utils.ReferencedSecrets
 }
 
 // +kubebuilder:rbac:groups=promoter.argoproj.io,resources=clusterscmproviders,verbs=get;list;watch;create;update;patch;delete
@@ -55,12 +61,14 @@ type ClusterScmProviderReconciler struct {
 // move the current state of the cluster closer to the desired state.
 func (r *ClusterScmProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
-	logger.Info("Reconciling ClusterScmProvider")
+	logger.V(4).Info("Reconciling ClusterScmProvider")
 	startTime := time.Now()
 
 	var clusterScmProvider promoterv1alpha1.ClusterScmProvider
+	// The credentials are checked periodically, only record an event when the result of the check changes.
+	recorder := utils.NewReadyTransitionRecorder(r.Recorder)
 	// This function applies the resource status via Server-Side Apply at the end of the reconciliation. Don't write status manually.
-	defer utils.HandleReconciliationResult(ctx, startTime, &clusterScmProvider, r.Client, r.Recorder, constants.ClusterScmProviderControllerFieldOwner, &result, &err)
+	defer utils.HandleReconciliationResult(ctx, startTime, &clusterScmProvider, r.Client, recorder, constants.ClusterScmProviderControllerFieldOwner, &result, &err)
 
 	if err := r.Get(ctx, req.NamespacedName, &clusterScmProvider); err != nil {
 		if k8serrors.IsNotFound(err) {
@@ -69,6 +77,7 @@ func (r *ClusterScmProviderReconciler) Reconcile(ctx context.Context, req ctrl.R
 		}
 		return ctrl.Result{}, fmt.Errorf("failed to get ClusterScmProvider: %w", err)
 	}
+	recorder.SetPrevious(clusterScmProvider.Status.Conditions)
 
 	// Remove any existing Ready condition. We want to start fresh.
 	meta.RemoveStatusCondition(clusterScmProvider.GetConditions(), string(promoterConditions.Ready))
@@ -82,7 +91,16 @@ func (r *ClusterScmProviderReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, fmt.Errorf("failed to ensure Secret finalizer: %w", err)
 	}
 
-	return ctrl.Result{}, nil
+	if err := validateScmProviderCredentials(ctx, r.Client, &clusterScmProvider, r.SettingsMgr.GetControllerNamespace()); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Check the credentials again later, they may expire or be revoked on the SCM. Changes to the Secret are watched.
+	requeueDuration, err := r.SettingsMgr.GetScmProviderRequeueDuration(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get credentials check interval: %w", err)
+	}
+	return ctrl.Result{RequeueAfter: requeueDuration}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -90,7 +108,9 @@ func (r *ClusterScmProviderReconciler) SetupWithManager(ctx context.Context, mgr
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&promoterv1alpha1.ClusterScmProvider{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Named("clusterscmprovider").
-		Complete(r)
+		WatchesRawSource(secretSource(mgr, r.Secrets, r.enqueueClusterScmProvidersForSecret())).
+		WithOptions(controller.Options{RateLimiter: settings.GetDefaultRateLimiter[ctrl.Request](r.SettingsMgr)}).
+		Complete(utils.NewDrainingReconciler(utils.NewShardedReconciler(mgr.GetClient(), &promoterv1alpha1.ClusterScmProvider{}, tracing.NewReconciler("ClusterScmProvider", r))))
 	if err != nil {
 		return fmt.Errorf("failed to create controller: %w", err)
 	}
@@ -98,6 +118,30 @@ func (r *ClusterScmProviderReconciler) SetupWithManager(ctx context.Context, mgr
 	return nil
 }
 
+// enqueueClusterScmProvidersForSecret returns a handler that enqueues the ClusterScmProviders that reference a Secret of
+// the controller's namespace when it changes, so that their credentials are checked again.
+func (r *ClusterScmProviderReconciler) enqueueClusterScmProvidersForSecret() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []ctrl.Request {
+		if obj.GetNamespace() != r.SettingsMgr.GetControllerNamespace() {
+			return nil
+		}
+
+		var clusterScmProviders promoterv1alpha1.ClusterScmProviderList
+		if err := r.List(ctx, &clusterScmProviders); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list ClusterScmProvider resources")
+			return nil
+		}
+
+		var requests []ctrl.Request
+		for _, clusterScmProvider := range clusterScmProviders.Items {
+			if clusterScmProvider.Spec.SecretRef != nil && clusterScmProvider.Spec.SecretRef.Name == obj.GetName() {
+				requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&clusterScmProvider)})
+			}
+		}
+		return requests
+	})
+}
+
 func (r *ClusterScmProviderReconciler) handleFinalizer(ctx context.Context, clusterScmProvider *promoterv1alpha1.ClusterScmProvider) (bool, error) {
 	// Check for dependent GitRepositories across all namespaces before allowing deletion
 	checkDependencies := func() ([]string, error) {
@@ -129,6 +173,7 @@ func (r *ClusterScmProviderReconciler) handleFinalizer(ctx context.Context, clus
 		promoterv1alpha1.ClusterScmProviderFinalizer,
 		"ClusterScmProvider",
 		checkDependencies,
+		nil,
 	)
 
 	// If we're being deleted and finalizer was removed, also remove Secret finalizer
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	bitbucket_cloud "github.com/argoproj-labs/gitops-promoter/internal/scms/bitbucket_cloud"
@@ -35,6 +36,7 @@ import (
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/azuredevops"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/github"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/gitlab"
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
 	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
@@ -74,7 +76,7 @@ type CommitStatusReconciler struct {
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.17.2/pkg/reconcile
 func (r *CommitStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
-	logger.Info("Reconciling CommitStatus", "name", req.Name)
+	logger.V(4).Info("Reconciling CommitStatus", "name", req.Name)
 	startTime := time.Now()
 
 	var cs promoterv1alpha1.CommitStatus
@@ -91,27 +93,43 @@ func (r *CommitStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		logger.Error(err, "failed to get CommitStatus")
 		return ctrl.Result{}, fmt.Errorf("failed to get CommitStatus %q: %w", req.Name, err)
 	}
+	defer func() {
+		// Runs before the status is applied, on every exit path from here on.
+		cs.Status.ShortSha = cs.Status.Sha[:min(len(cs.Status.Sha), 7)]
+	}()
 
 	// Remove any existing Ready condition. We want to start fresh.
 	meta.RemoveStatusCondition(cs.GetConditions(), string(promoterConditions.Ready))
 
 	// empty phase should be impossible due to schema validation
 	if cs.Spec.Sha == "" || cs.Spec.Phase == "" {
-		logger.Info("Skip setting commit status, missing sha or phase", "sha", cs.Spec.Sha, "phase", cs.Spec.Phase)
+		logger.V(4).Info("Skip setting commit status, missing sha or phase", "sha", cs.Spec.Sha, "phase", cs.Spec.Phase)
 		return ctrl.Result{}, nil
 	}
 
-	commitStatusProvider, err := r.getCommitStatusProvider(ctx, cs)
-	if err != nil || commitStatusProvider == nil {
-		return ctrl.Result{}, fmt.Errorf("failed to get CommitStatus provider: %w", err)
+	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, r.Client, client.ObjectKey{Namespace: cs.Namespace, Name: cs.Spec.RepositoryReference.Name})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+	if wait, result, err := waitForGitRepository(ctx, r.SettingsMgr, &cs, gitRepo); wait {
+		return result, err
 	}
 
 	// We need the old sha to trigger the reconcile of the change transfer policy
 	oldSha := cs.Status.Sha
 
-	_, err = commitStatusProvider.Set(ctx, &cs)
-	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to set CommitStatus state for %q: %w", req.Name, err)
+	if _, ingested := cs.Labels[promoterv1alpha1.IngestedCommitStatusLabel]; ingested {
+		// The webhook receiver created it from a status the SCM reported, the SCM already has it.
+		cs.Status.Sha = cs.Spec.Sha
+		cs.Status.Phase = cs.Spec.Phase
+	} else {
+		commitStatusProvider, providerErr := r.getCommitStatusProvider(ctx, cs)
+		if providerErr != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to get CommitStatus provider: %w", providerErr)
+		}
+		if _, err = commitStatusProvider.Set(ctx, &cs); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set CommitStatus state for %q: %w", req.Name, err)
+		}
 	}
 
 	err = r.triggerReconcileChangeTransferPolicy(ctx, cs, oldSha, cs.Spec.Sha)
@@ -119,16 +137,24 @@ func (r *CommitStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, fmt.Errorf("failed to trigger reconcile of ChangeTransferPolicy via CommitStatus: %w", err)
 	}
 
-	r.Recorder.Eventf(&cs, nil, "Normal", constants.CommitStatusSetReason, "SettingCommitStatus", "Commit status %s set to %s for hash %s", cs.Name, cs.Spec.Phase, cs.Spec.Sha)
+	r.Recorder.Eventf(&cs, nil, "Normal", constants.CommitStatusSetReason, "SettingCommitStatus", constants.CommitStatusSetMessage, cs.Name, cs.Spec.Phase, cs.Spec.Sha)
 
 	return ctrl.Result{}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *CommitStatusReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
-	err := ctrl.NewControllerManagedBy(mgr).
+	// Use Direct methods to read configuration from the API server without cache during setup.
+	// The cache is not started during SetupWithManager, so we must use the non-cached API reader.
+	rateLimiter, err := settings.GetRateLimiterDirect[promoterv1alpha1.CommitStatusConfiguration, ctrl.Request](ctx, r.SettingsMgr)
+	if err != nil {
+		return fmt.Errorf("failed to get CommitStatus rate limiter: %w", err)
+	}
+
+	err = ctrl.NewControllerManagedBy(mgr).
 		For(&promoterv1alpha1.CommitStatus{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Complete(r)
+		WithOptions(controller.Options{RateLimiter: rateLimiter}).
+		Complete(utils.NewDrainingReconciler(utils.NewShardedReconciler(mgr.GetClient(), &promoterv1alpha1.CommitStatus{}, tracing.NewReconciler("CommitStatus", r))))
 	if err != nil {
 		return fmt.Errorf("failed to create controller: %w", err)
 	}
@@ -193,7 +219,7 @@ func (r *CommitStatusReconciler) getCommitStatusProvider(ctx context.Context, co
 		//nolint:wrapcheck // error wrapping not needed for fake provider
 		return fake.NewFakeCommitStatusProvider(*secret)
 	default:
-		return nil, nil
+		return nil, scms.NewUnsupportedScmProviderError(scmProvider)
 	}
 }
 
@@ -244,7 +270,7 @@ func (r *CommitStatusReconciler) triggerReconcileChangeTransferPolicy(ctx contex
 
 	ctpList := utils.UpsertChangeTransferPolicyList(ctpListActiveOldSha.Items, ctpListActiveNewSha.Items, ctpListProposedOldSha.Items, ctpListProposedNewSha.Items)
 
-	logger.Info("ChangeTransferPolicy list", "count", len(ctpList), "oldSha", oldSha, "newSha", newSha)
+	logger.V(4).Info("ChangeTransferPolicy list", "count", len(ctpList), "oldSha", oldSha, "newSha", newSha)
 	for _, ctp := range ctpList {
 		// Use the enqueue function to trigger reconciliation
 		if r.EnqueueCTP != nil {
@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 )
 
@@ -220,8 +221,12 @@ var _ = Describe("CommitStatus Controller", func() {
 			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
 			Expect(k8sClient.Create(ctx, commitStatus)).To(Succeed())
 
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+			By("Checking that the status has the abbreviated sha kubectl displays")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, typeNamespacedName, commitStatus)).To(Succeed())
+				g.Expect(commitStatus.Status.Sha).To(Equal("abcdef1234567890abcdef1234567890abcdef12"))
+				g.Expect(commitStatus.Status.ShortSha).To(Equal("abcdef1"))
+			}, constants.EventuallyTimeout).Should(Succeed())
 		})
 	})
 
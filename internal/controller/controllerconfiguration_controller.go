@@ -20,7 +20,9 @@ import (
 	"context"
 	"fmt"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,32 +30,46 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/settings"
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
+	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 )
 
 // ControllerConfigurationReconciler reconciles a ControllerConfiguration object
 // revive:disable:exported // The name starting with "Controller" is fine. That's the kind name.
 type ControllerConfigurationReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=promoter.argoproj.io,resources=controllerconfigurations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=promoter.argoproj.io,resources=controllerconfigurations/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=promoter.argoproj.io,resources=controllerconfigurations/finalizers,verbs=update
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the ControllerConfiguration object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
-//
-// For more details, check Reconcile and its Result here:
-// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.20.2/pkg/reconcile
+// Reconcile validates a ControllerConfiguration whenever its spec changes. The controllers read the
+// ControllerConfiguration through the settings.Manager whenever they need it, so changes take effect without a restart.
+// An invalid ControllerConfiguration is ignored by the settings.Manager, which keeps using the last valid one, and a
+// Warning event is recorded on it here.
 func (r *ControllerConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = log.FromContext(ctx)
+	logger := log.FromContext(ctx)
 
-	// TODO(user): your logic here
+	var controllerConfiguration promoterv1alpha1.ControllerConfiguration
+	if err := r.Get(ctx, req.NamespacedName, &controllerConfiguration); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get ControllerConfiguration: %w", err)
+	}
+
+	if err := settings.ValidateControllerConfiguration(&controllerConfiguration.Spec); err != nil {
+		logger.Info("Invalid ControllerConfiguration, the controllers keep using the last valid one", "error", err.Error())
+		r.Recorder.Eventf(&controllerConfiguration, nil, "Warning", constants.InvalidConfigurationReason, "Validating",
+			constants.InvalidConfigurationMessage, err.Error())
+		return ctrl.Result{}, nil
+	}
+	logger.V(4).Info("Validated ControllerConfiguration")
 
 	return ctrl.Result{}, nil
 }
@@ -63,7 +79,7 @@ func (r *ControllerConfigurationReconciler) SetupWithManager(ctx context.Context
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&promoterv1alpha1.ControllerConfiguration{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Named("controllerconfiguration").
-		Complete(r)
+		Complete(utils.NewDrainingReconciler(utils.NewShardedReconciler(mgr.GetClient(), &promoterv1alpha1.ControllerConfiguration{}, tracing.NewReconciler("ControllerConfiguration", r))))
 	if err != nil {
 		return fmt.Errorf("failed to create controller: %w", err)
 	}
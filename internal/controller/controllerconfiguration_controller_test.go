@@ -24,9 +24,11 @@ import (
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 )
 
 //go:embed testdata/ControllerConfiguration.yaml
@@ -84,5 +86,28 @@ var _ = Describe("ControllerConfiguration Controller", func() {
 			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
+
+		It("should record an event on an invalid configuration without failing", func() {
+			resource := &promoterv1alpha1.ControllerConfiguration{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Spec.PullRequest.Template.Title = "Promote {{ .ChangeTransferPolicy.Name"
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+			recorder := events.NewFakeRecorder(10)
+			controllerReconciler := &ControllerConfigurationReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(And(
+				ContainSubstring(constants.InvalidConfigurationReason),
+				ContainSubstring("pullRequest.template.title"),
+			)))
+		})
 	})
 })
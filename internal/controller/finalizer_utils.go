@@ -142,6 +142,7 @@ func removeSecretFinalizerForProvider(
 // - Adds finalizer on resource creation
 // - Checks for dependent resources before allowing deletion
 // - Emits events and metrics when deletion is blocked
+// - Runs cleanup, if not nil, when no dependencies exist, keeping the finalizer if it fails so that it is retried
 // - Removes finalizer when no dependencies exist
 func handleResourceFinalizerWithDependencies(
 	ctx context.Context,
@@ -151,6 +152,7 @@ func handleResourceFinalizerWithDependencies(
 	finalizer string,
 	resourceType string,
 	checkDependencies func() ([]string, error),
+	cleanup func() error,
 ) (deleted bool, err error) {
 	logger := log.FromContext(ctx)
 
@@ -209,6 +211,12 @@ func handleResourceFinalizerWithDependencies(
 		return true, errors.New(errMsg)
 	}
 
+	if cleanup != nil {
+		if err := cleanup(); err != nil {
+			return true, fmt.Errorf("failed to clean up before removing finalizer: %w", err)
+		}
+	}
+
 	// No dependencies, remove finalizer with retry logic
 	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
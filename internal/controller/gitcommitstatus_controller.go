@@ -25,6 +25,7 @@ import (
 
 	"github.com/argoproj-labs/gitops-promoter/internal/git"
 	"github.com/argoproj-labs/gitops-promoter/internal/settings"
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
 	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
@@ -77,7 +78,7 @@ type GitCommitStatusReconciler struct {
 // 4. Creates/updates a CommitStatus resource with the validation result
 func (r *GitCommitStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
-	logger.Info("Reconciling GitCommitStatus", "name", req.Name)
+	logger.V(4).Info("Reconciling GitCommitStatus", "name", req.Name)
 	startTime := time.Now()
 
 	var gcs promoterv1alpha1.GitCommitStatus
@@ -151,7 +152,7 @@ func (r *GitCommitStatusReconciler) SetupWithManager(ctx context.Context, mgr ct
 			MaxConcurrentReconciles: maxConcurrentReconciles,
 			RateLimiter:             rateLimiter,
 		}).
-		Complete(r)
+		Complete(utils.NewDrainingReconciler(utils.NewShardedReconciler(mgr.GetClient(), &promoterv1alpha1.GitCommitStatus{}, tracing.NewReconciler("GitCommitStatus", r))))
 	if err != nil {
 		return fmt.Errorf("failed to create controller: %w", err)
 	}
@@ -296,7 +297,7 @@ func (r *GitCommitStatusReconciler) processEnvironments(ctx context.Context, gcs
 		}
 		commitStatuses = append(commitStatuses, cs)
 
-		logger.Info("Processed environment validation",
+		logger.V(4).Info("Processed environment validation",
 			"branch", branch,
 			"proposedSha", proposedSha,
 			"targetedSha", shaToValidate,
@@ -446,6 +447,7 @@ func (r *GitCommitStatusReconciler) upsertCommitStatus(ctx context.Context, gcs
 		commitStatus.Labels["promoter.argoproj.io/git-commit-status"] = utils.KubeSafeLabel(gcs.Name)
 		commitStatus.Labels[promoterv1alpha1.EnvironmentLabel] = utils.KubeSafeLabel(branch)
 		commitStatus.Labels[promoterv1alpha1.CommitStatusLabel] = validationName
+		commitStatus.Labels[promoterv1alpha1.PromotionStrategyUIDLabel] = string(ps.UID)
 
 		// Convert phase string to CommitStatusPhase
 		var commitPhase promoterv1alpha1.CommitStatusPhase
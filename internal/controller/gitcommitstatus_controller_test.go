@@ -677,10 +677,7 @@ var _ = Describe("GitCommitStatus Controller", Ordered, func() {
 			_, err = runGitCmd(ctx, gitPath, "clone", "--verbose", "--progress", "--filter=blob:none", repoURL, ".")
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err = runGitCmd(ctx, gitPath, "config", "user.name", "testuser")
-			Expect(err).NotTo(HaveOccurred())
-			_, err = runGitCmd(ctx, gitPath, "config", "user.email", "testmail@test.com")
-			Expect(err).NotTo(HaveOccurred())
+			Expect(configureTestIdentity(ctx, gitPath)).To(Succeed())
 
 			// Checkout the staging active branch
 			_, err = runGitCmd(ctx, gitPath, "checkout", "-B", testBranchStaging, "origin/"+testBranchStaging)
@@ -18,47 +18,75 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/git"
+	"github.com/argoproj-labs/gitops-promoter/internal/gitauth"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/azuredevops"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/fake"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/github"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/gitlab"
+	"github.com/argoproj-labs/gitops-promoter/internal/settings"
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
 	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+	"github.com/argoproj-labs/gitops-promoter/internal/webhookreceiver"
 )
 
 // GitRepositoryReconciler reconciles a GitRepository object
 type GitRepositoryReconciler struct {
 	client.Client
-	Scheme   *runtime.Scheme
-	Recorder events.EventRecorder
+	Scheme      *runtime.Scheme
+	Recorder    events.EventRecorder
+	SettingsMgr *settings.Manager
+	// Secrets watches the Secrets the reconciler reads through its client. If nil, the Secrets of the manager's cache
+	// are watched instead.
+	Secrets *utils.ReferencedSecrets
 }
 
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=gitrepositories,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=gitrepositories/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=gitrepositories/finalizers,verbs=update
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=pullrequests,verbs=get;list;watch
+//+kubebuilder:rbac:groups=promoter.argoproj.io,resources=changetransferpolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups=promoter.argoproj.io,resources=scmproviders,verbs=get;list;watch
+//+kubebuilder:rbac:groups=promoter.argoproj.io,resources=clusterscmproviders,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *GitRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
-	logger.Info("Reconciling GitRepository")
+	logger.V(4).Info("Reconciling GitRepository")
 	startTime := time.Now()
 
 	var gitRepo promoterv1alpha1.GitRepository
+	// The repository is checked periodically, only record an event when the result of the check changes.
+	recorder := utils.NewReadyTransitionRecorder(r.Recorder)
 	// This function applies the resource status via Server-Side Apply at the end of the reconciliation. Don't write status manually.
-	defer utils.HandleReconciliationResult(ctx, startTime, &gitRepo, r.Client, r.Recorder, constants.GitRepositoryControllerFieldOwner, &result, &err)
+	defer utils.HandleReconciliationResult(ctx, startTime, &gitRepo, r.Client, recorder, constants.GitRepositoryControllerFieldOwner, &result, &err)
 
 	if err := r.Get(ctx, req.NamespacedName, &gitRepo); err != nil {
 		if k8serrors.IsNotFound(err) {
@@ -67,6 +95,7 @@ func (r *GitRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 		return ctrl.Result{}, fmt.Errorf("failed to get GitRepository: %w", err)
 	}
+	recorder.SetPrevious(gitRepo.Status.Conditions)
 
 	// Remove any existing Ready condition. We want to start fresh.
 	meta.RemoveStatusCondition(gitRepo.GetConditions(), string(promoterConditions.Ready))
@@ -74,43 +103,491 @@ func (r *GitRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	if deleted, err := r.handleFinalizer(ctx, &gitRepo); err != nil || deleted {
 		return ctrl.Result{}, err
 	}
+	if !gitRepo.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	accessCheckInterval, err := r.SettingsMgr.GetGitRepositoryAccessCheckInterval(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get access check interval: %w", err)
+	}
+
+	if gitRepo.Status.ObservedGeneration != gitRepo.Generation {
+		// The clone URLs the other controllers read from the status were looked up for an earlier spec, which may name
+		// another repository.
+		gitRepo.Status.HttpsCloneUrl, gitRepo.Status.SshCloneUrl = "", ""
+	}
+
+	// A repository that doesn't exist or can't be accessed is not an error of the reconcile, retrying sooner won't
+	// help. The Ready condition tells the controllers that use the repository to wait for the next check, and the
+	// repository details from the last successful lookup are kept.
+	repository, err := r.getRepository(ctx, &gitRepo)
+	var notFoundErr *scms.RepositoryNotFoundError
+	var accessDeniedErr *scms.RepositoryAccessDeniedError
+	var urlNotSupportedErr *urlNotSupportedError
+	switch {
+	case errors.As(err, &urlNotSupportedErr):
+		logger.Info("Repository URL not supported by ScmProvider", "scmProvider", urlNotSupportedErr.scmProvider)
+		utils.SetReadyCondition(&gitRepo, metav1.ConditionFalse, promoterConditions.UrlNotSupported, urlNotSupportedErr.Error())
+		// Only a change of the GitRepository or its ScmProvider can fix this.
+		return ctrl.Result{}, nil
+	case errors.As(err, &notFoundErr):
+		logger.Info("Repository not found", "message", notFoundErr.Message)
+		utils.SetReadyCondition(&gitRepo, metav1.ConditionFalse, promoterConditions.NotFound, notFoundErr.Message)
+	case errors.As(err, &accessDeniedErr):
+		logger.Info("Repository access denied", "message", accessDeniedErr.Message)
+		utils.SetReadyCondition(&gitRepo, metav1.ConditionFalse, promoterConditions.AccessDenied, accessDeniedErr.Message)
+	case err != nil:
+		return ctrl.Result{}, fmt.Errorf("failed to get repository: %w", err)
+	default:
+		gitRepo.Status.DefaultBranch = repository.DefaultBranch
+		gitRepo.Status.HttpsCloneUrl = repository.HttpsCloneUrl
+		gitRepo.Status.SshCloneUrl = repository.SshCloneUrl
+		gitRepo.Status.RepositoryID = repository.ID
+		if err := setProviderRepositoryIDs(&gitRepo, repository); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if repository.Archived {
+			// Nothing can be promoted in an archived repository, the controllers that use it wait until it is
+			// unarchived, which the next check finds.
+			logger.Info("Repository is archived")
+			utils.SetReadyCondition(&gitRepo, metav1.ConditionFalse, promoterConditions.RepositoryArchived, "The repository is archived or read-only on the SCM, unarchive it to resume promotions.")
+			return ctrl.Result{RequeueAfter: accessCheckInterval}, nil
+		}
+
+		if err := r.reconcileWebhook(ctx, &gitRepo); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile webhook: %w", err)
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: accessCheckInterval}, nil
+}
+
+// urlNotSupportedError indicates that a GitRepository identified by spec.url references an ScmProvider whose SCM API
+// needs the repository's owner and name, e.g. to open pull requests.
+type urlNotSupportedError struct {
+	scmProvider string
+}
+
+func (e *urlNotSupportedError) Error() string {
+	return fmt.Sprintf("ScmProvider %q needs the repository's owner and name, set them in the SCM specific field instead of spec.url", e.scmProvider)
+}
+
+// reconcileWebhook registers the webhook requested by spec.manageWebhooks on the SCM, or deletes the webhook the
+// controller registered before if it isn't requested anymore. The webhook is updated on every reconcile, which also
+// creates it again if it was deleted on the SCM and picks up changes to its secret. Credentials that aren't allowed to
+// manage webhooks are reported in the WebhookRegistered condition instead of failing the reconcile.
+func (r *GitRepositoryReconciler) reconcileWebhook(ctx context.Context, gitRepo *promoterv1alpha1.GitRepository) error {
+	logger := log.FromContext(ctx)
+
+	if gitRepo.Spec.ManageWebhooks == nil {
+		meta.RemoveStatusCondition(gitRepo.GetConditions(), string(promoterConditions.WebhookRegistered))
+		if gitRepo.Status.WebhookID == "" {
+			return nil
+		}
+		if err := r.deleteWebhook(ctx, gitRepo); err != nil {
+			return err
+		}
+		logger.Info("Deleted webhook", "webhookID", gitRepo.Status.WebhookID)
+		gitRepo.Status.WebhookID = ""
+		return nil
+	}
+
+	provider, err := r.getWebhookProvider(ctx, gitRepo)
+	if err != nil {
+		return err
+	}
+	if provider == nil {
+		meta.SetStatusCondition(gitRepo.GetConditions(), metav1.Condition{
+			Type:               string(promoterConditions.WebhookRegistered),
+			Status:             metav1.ConditionFalse,
+			Reason:             string(promoterConditions.WebhookNotSupported),
+			Message:            "Managing webhooks is only supported for GitHub, GitLab and fake repositories",
+			ObservedGeneration: gitRepo.Generation,
+		})
+		return nil
+	}
+
+	webhook := scms.Webhook{
+		Url:    gitRepo.Spec.ManageWebhooks.Url,
+		Events: gitRepo.Spec.ManageWebhooks.Events,
+	}
+	if len(webhook.Events) == 0 {
+		webhook.Events = []promoterv1alpha1.WebhookEvent{promoterv1alpha1.WebhookEventPush, promoterv1alpha1.WebhookEventPullRequest, promoterv1alpha1.WebhookEventStatus}
+	}
+	if gitRepo.Spec.ManageWebhooks.SecretRef != nil {
+		var secret v1.Secret
+		if err := r.Get(ctx, client.ObjectKey{Namespace: gitRepo.Namespace, Name: gitRepo.Spec.ManageWebhooks.SecretRef.Name}, &secret); err != nil {
+			return fmt.Errorf("failed to get webhook secret %q: %w", gitRepo.Spec.ManageWebhooks.SecretRef.Name, err)
+		}
+		value, ok := secret.Data[promoterv1alpha1.WebhookSecretKey]
+		if !ok || len(value) == 0 {
+			return fmt.Errorf("webhook secret %q has no %q key", secret.Name, promoterv1alpha1.WebhookSecretKey)
+		}
+		webhook.Secret = string(value)
+	}
+
+	id, err := provider.Ensure(ctx, *gitRepo, gitRepo.Status.WebhookID, webhook)
+	var accessDeniedErr *scms.WebhookAccessDeniedError
+	if errors.As(err, &accessDeniedErr) {
+		logger.Info("Webhook access denied", "message", accessDeniedErr.Message)
+		if meta.SetStatusCondition(gitRepo.GetConditions(), metav1.Condition{
+			Type:               string(promoterConditions.WebhookRegistered),
+			Status:             metav1.ConditionFalse,
+			Reason:             string(promoterConditions.WebhookAccessDenied),
+			Message:            accessDeniedErr.Message,
+			ObservedGeneration: gitRepo.Generation,
+		}) {
+			r.Recorder.Eventf(gitRepo, nil, "Warning", constants.WebhookAccessDeniedReason, "RegisteringWebhook", constants.WebhookAccessDeniedMessage, accessDeniedErr.Message)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to register webhook: %w", err)
+	}
+
+	if id != gitRepo.Status.WebhookID {
+		logger.Info("Registered webhook", "webhookID", id, "url", webhook.Url)
+		r.Recorder.Eventf(gitRepo, nil, "Normal", constants.WebhookRegisteredReason, "RegisteringWebhook", constants.WebhookRegisteredMessage, id, webhook.Events, webhook.Url)
+	}
+	gitRepo.Status.WebhookID = id
+	meta.SetStatusCondition(gitRepo.GetConditions(), metav1.Condition{
+		Type:               string(promoterConditions.WebhookRegistered),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(promoterConditions.WebhookUpToDate),
+		Message:            fmt.Sprintf("Webhook %s sends events to %s", id, webhook.Url),
+		ObservedGeneration: gitRepo.Generation,
+	})
+	return nil
+}
+
+// deleteWebhook deletes the webhook the controller registered for the GitRepository from the SCM.
+func (r *GitRepositoryReconciler) deleteWebhook(ctx context.Context, gitRepo *promoterv1alpha1.GitRepository) error {
+	provider, err := r.getWebhookProvider(ctx, gitRepo)
+	if err != nil {
+		return err
+	}
+	if provider == nil {
+		return nil
+	}
+	if err := provider.Delete(ctx, *gitRepo, gitRepo.Status.WebhookID); err != nil {
+		return fmt.Errorf("failed to delete webhook %q: %w", gitRepo.Status.WebhookID, err)
+	}
+	return nil
+}
+
+// getWebhookProvider returns the webhook provider for the GitRepository's SCM, or nil if the controller can't manage
+// webhooks on it.
+func (r *GitRepositoryReconciler) getWebhookProvider(ctx context.Context, gitRepo *promoterv1alpha1.GitRepository) (scms.WebhookProvider, error) {
+	scmProvider, secret, err := utils.GetScmProviderAndSecretFromRepositoryReference(ctx, r.Client, r.SettingsMgr.GetControllerNamespace(), promoterv1alpha1.ObjectReference{Name: gitRepo.Name}, gitRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ScmProvider and secret: %w", err)
+	}
+
+	switch {
+	case scmProvider.GetSpec().GitHub != nil:
+		provider, err := github.NewGithubWebhookProvider(ctx, scmProvider, *secret, gitRepo.Spec.GitHub.Owner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitHub webhook provider: %w", err)
+		}
+		return provider, nil
+	case scmProvider.GetSpec().GitLab != nil:
+		provider, err := gitlab.NewGitlabWebhookProvider(*secret, scmProvider.GetSpec().GitLab.Domain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitLab webhook provider: %w", err)
+		}
+		return provider, nil
+	case scmProvider.GetSpec().Fake != nil:
+		return fake.NewFakeWebhookProvider(), nil
+	default:
+		return nil, nil
+	}
+}
+
+// getRepository looks up the repository with the credentials of its ScmProvider, which also checks that it exists and
+// can be accessed. SCMs with a repository API are asked directly, the others are looked up with git, in which case the
+// clone URLs are the ones the controller uses and there is no repository ID.
+func (r *GitRepositoryReconciler) getRepository(ctx context.Context, gitRepo *promoterv1alpha1.GitRepository) (*scms.Repository, error) {
+	scmProvider, secret, err := utils.GetScmProviderAndSecretFromRepositoryReference(ctx, r.Client, r.SettingsMgr.GetControllerNamespace(), promoterv1alpha1.ObjectReference{Name: gitRepo.Name}, gitRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ScmProvider and secret: %w", err)
+	}
+
+	if gitRepo.Spec.Url != "" && scmProvider.GetSpec().Fake == nil {
+		return nil, &urlNotSupportedError{scmProvider: scmProvider.GetName()}
+	}
+
+	var provider scms.RepositoryProvider
+	switch {
+	case scmProvider.GetSpec().GitHub != nil:
+		provider, err = github.NewGithubRepositoryProvider(ctx, scmProvider, *secret, gitRepo.Spec.GitHub.Owner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitHub repository provider: %w", err)
+		}
+	case scmProvider.GetSpec().GitLab != nil:
+		provider, err = gitlab.NewGitlabRepositoryProvider(*secret, scmProvider.GetSpec().GitLab.Domain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitLab repository provider: %w", err)
+		}
+	case scmProvider.GetSpec().AzureDevOps != nil:
+		provider, err = azuredevops.NewAzdoRepositoryProvider(ctx, scmProvider, *secret, scmProvider.GetSpec().AzureDevOps.Organization)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Azure DevOps repository provider: %w", err)
+		}
+	}
+	if provider != nil {
+		return provider.GetRepository(ctx, *gitRepo) //nolint:wrapcheck // the caller tells the provider's errors apart
+	}
+
+	gitAuthProvider, err := gitauth.CreateGitOperationsProvider(ctx, r.Client, scmProvider, secret, client.ObjectKeyFromObject(gitRepo))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git auth provider for ScmProvider %q: %w", scmProvider.GetName(), err)
+	}
+	repository, err := git.GetRemoteRepository(ctx, gitAuthProvider, gitRepo)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // the caller tells the provider's errors apart
+	}
+	repository.HttpsCloneUrl, repository.SshCloneUrl = gitauth.CloneUrls(gitAuthProvider, *gitRepo)
+	if scmProvider.GetSpec().Fake != nil {
+		// The fake SCM has no API to look the repository up with, only whether it is archived.
+		repository.Archived = fake.RepositoryArchived(*gitRepo)
+	}
+	return repository, nil
+}
 
-	return ctrl.Result{}, nil
+// setProviderRepositoryIDs keeps the IDs of the repository that the provider of the GitRepository's SCM uses for its API
+// calls in the status, together with the names they were looked up for. The providers only use them while the names
+// in the spec are the same, so they never use the IDs of a repository the GitRepository was moved away from.
+func setProviderRepositoryIDs(gitRepo *promoterv1alpha1.GitRepository, repository *scms.Repository) error {
+	gitRepo.Status.GitLab = nil
+	gitRepo.Status.AzureDevOps = nil
+	switch {
+	case gitRepo.Spec.GitLab != nil && repository.ID != "":
+		projectID, err := strconv.Atoi(repository.ID)
+		if err != nil {
+			return fmt.Errorf("failed to parse GitLab project ID %q: %w", repository.ID, err)
+		}
+		gitRepo.Status.GitLab = &promoterv1alpha1.GitLabRepositoryStatus{
+			Namespace: gitRepo.Spec.GitLab.Namespace,
+			Name:      gitRepo.Spec.GitLab.Name,
+			ProjectID: projectID,
+		}
+	case gitRepo.Spec.AzureDevOps != nil && repository.ID != "" && repository.ProjectID != "":
+		gitRepo.Status.AzureDevOps = &promoterv1alpha1.AzureDevOpsRepositoryStatus{
+			Project:      gitRepo.Spec.AzureDevOps.Project,
+			Name:         gitRepo.Spec.AzureDevOps.Name,
+			ProjectID:    repository.ProjectID,
+			RepositoryID: repository.ID,
+		}
+	}
+	return nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *GitRepositoryReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	// This gets used by the webhook server to find the GitRepositories of the repository an event is for. The keys are
+	// scoped to the provider, so that the same owner and name on another SCM doesn't match. The fake SCM is indexed for
+	// each of the providers, since the tests send their events for it.
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &promoterv1alpha1.GitRepository{}, ".spec.fullName", func(rawObj client.Object) []string {
+		//nolint:forcetypeassert // type is guaranteed by the IndexField API
+		gitRepo := rawObj.(*promoterv1alpha1.GitRepository)
+		switch {
+		case gitRepo.Spec.GitHub != nil:
+			return []string{utils.GetRepositoryFullNameIndexKey(webhookreceiver.ProviderGitHub, gitRepo.Spec.GitHub.Owner+"/"+gitRepo.Spec.GitHub.Name)}
+		case gitRepo.Spec.GitLab != nil:
+			return []string{utils.GetRepositoryFullNameIndexKey(webhookreceiver.ProviderGitLab, gitRepo.Spec.GitLab.Namespace+"/"+gitRepo.Spec.GitLab.Name)}
+		case gitRepo.Spec.BitbucketCloud != nil:
+			return []string{utils.GetRepositoryFullNameIndexKey(webhookreceiver.ProviderBitbucketCloud, gitRepo.Spec.BitbucketCloud.Owner+"/"+gitRepo.Spec.BitbucketCloud.Name)}
+		case gitRepo.Spec.Forgejo != nil:
+			return []string{utils.GetRepositoryFullNameIndexKey(webhookreceiver.ProviderForgejo, gitRepo.Spec.Forgejo.Owner+"/"+gitRepo.Spec.Forgejo.Name)}
+		case gitRepo.Spec.Gitea != nil:
+			return []string{utils.GetRepositoryFullNameIndexKey(webhookreceiver.ProviderGitea, gitRepo.Spec.Gitea.Owner+"/"+gitRepo.Spec.Gitea.Name)}
+		case gitRepo.Spec.AzureDevOps != nil:
+			return []string{utils.GetRepositoryFullNameIndexKey(webhookreceiver.ProviderAzureDevops, gitRepo.Spec.AzureDevOps.Project+"/"+gitRepo.Spec.AzureDevOps.Name)}
+		case gitRepo.Spec.Fake != nil:
+			fullName := gitRepo.Spec.Fake.Owner + "/" + gitRepo.Spec.Fake.Name
+			var keys []string
+			for _, provider := range []string{
+				webhookreceiver.ProviderGitHub, webhookreceiver.ProviderGitLab, webhookreceiver.ProviderForgejo, webhookreceiver.ProviderGitea,
+				webhookreceiver.ProviderBitbucketCloud, webhookreceiver.ProviderBitbucketDataCenter, webhookreceiver.ProviderAzureDevops,
+			} {
+				keys = append(keys, utils.GetRepositoryFullNameIndexKey(provider, fullName))
+			}
+			return keys
+		default:
+			return nil
+		}
+	}); err != nil {
+		return fmt.Errorf("failed to set field index for .spec.fullName: %w", err)
+	}
+
 	err := ctrl.NewControllerManagedBy(mgr).
-		For(&promoterv1alpha1.GitRepository{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Complete(r)
+		// Annotation changes are reconciled for the force-delete-after annotation of a GitRepository whose deletion is blocked.
+		For(&promoterv1alpha1.GitRepository{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Watches(&promoterv1alpha1.ScmProvider{}, r.enqueueGitRepositoriesForScmProvider(), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&promoterv1alpha1.ClusterScmProvider{}, r.enqueueGitRepositoriesForScmProvider(), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WatchesRawSource(secretSource(mgr, r.Secrets, r.enqueueGitRepositoriesForSecret())).
+		WithOptions(controller.Options{RateLimiter: settings.GetDefaultRateLimiter[ctrl.Request](r.SettingsMgr)}).
+		Complete(utils.NewDrainingReconciler(utils.NewShardedReconciler(mgr.GetClient(), &promoterv1alpha1.GitRepository{}, tracing.NewReconciler("GitRepository", r))))
 	if err != nil {
 		return fmt.Errorf("failed to create controller: %w", err)
 	}
 	return nil
 }
 
-func (r *GitRepositoryReconciler) handleFinalizer(ctx context.Context, gitRepo *promoterv1alpha1.GitRepository) (bool, error) {
-	// Check for dependent PullRequests before allowing deletion
-	checkDependencies := func() ([]string, error) {
-		var pullRequests promoterv1alpha1.PullRequestList
-		if err := r.List(ctx, &pullRequests, client.InNamespace(gitRepo.Namespace)); err != nil {
-			return nil, fmt.Errorf("failed to list PullRequests: %w", err)
+// enqueueGitRepositoriesForScmProvider returns a handler that enqueues all GitRepository resources that reference an
+// ScmProvider or ClusterScmProvider when it changes, so that access is checked again with its new configuration.
+func (r *GitRepositoryReconciler) enqueueGitRepositoriesForScmProvider() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []ctrl.Request {
+		var kind string
+		var listOpts []client.ListOption
+		switch obj.(type) {
+		case *promoterv1alpha1.ScmProvider:
+			kind = promoterv1alpha1.ScmProviderKind
+			listOpts = append(listOpts, client.InNamespace(obj.GetNamespace()))
+		case *promoterv1alpha1.ClusterScmProvider:
+			kind = promoterv1alpha1.ClusterScmProviderKind
+		default:
+			return nil
+		}
+
+		var gitRepos promoterv1alpha1.GitRepositoryList
+		if err := r.List(ctx, &gitRepos, listOpts...); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list GitRepository resources")
+			return nil
+		}
+
+		var requests []ctrl.Request
+		for _, gitRepo := range gitRepos.Items {
+			if gitRepo.Spec.ScmProviderRef.Kind == kind && gitRepo.Spec.ScmProviderRef.Name == obj.GetName() {
+				requests = append(requests, ctrl.Request{
+					NamespacedName: client.ObjectKeyFromObject(&gitRepo),
+				})
+			}
+		}
+
+		return requests
+	})
+}
+
+// enqueueGitRepositoriesForSecret returns a handler that enqueues the GitRepositories that use a Secret when it changes:
+// those whose ScmProvider or ClusterScmProvider references it, and those whose webhook or commit signing key it holds.
+func (r *GitRepositoryReconciler) enqueueGitRepositoriesForSecret() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []ctrl.Request {
+		logger := log.FromContext(ctx)
+
+		// The ScmProviders that reference the Secret, by kind.
+		scmProviders := map[string][]string{}
+		var scmProviderList promoterv1alpha1.ScmProviderList
+		if err := r.List(ctx, &scmProviderList, client.InNamespace(obj.GetNamespace())); err != nil {
+			logger.Error(err, "failed to list ScmProvider resources")
+			return nil
+		}
+		for _, scmProvider := range scmProviderList.Items {
+			if scmProvider.Spec.SecretRef != nil && scmProvider.Spec.SecretRef.Name == obj.GetName() {
+				scmProviders[promoterv1alpha1.ScmProviderKind] = append(scmProviders[promoterv1alpha1.ScmProviderKind], scmProvider.Name)
+			}
+		}
+		listOpts := []client.ListOption{client.InNamespace(obj.GetNamespace())}
+		if obj.GetNamespace() == r.SettingsMgr.GetControllerNamespace() {
+			var clusterScmProviderList promoterv1alpha1.ClusterScmProviderList
+			if err := r.List(ctx, &clusterScmProviderList); err != nil {
+				logger.Error(err, "failed to list ClusterScmProvider resources")
+				return nil
+			}
+			for _, clusterScmProvider := range clusterScmProviderList.Items {
+				if clusterScmProvider.Spec.SecretRef != nil && clusterScmProvider.Spec.SecretRef.Name == obj.GetName() {
+					scmProviders[promoterv1alpha1.ClusterScmProviderKind] = append(scmProviders[promoterv1alpha1.ClusterScmProviderKind], clusterScmProvider.Name)
+				}
+			}
+			// GitRepositories of every namespace may use a ClusterScmProvider.
+			if len(scmProviders[promoterv1alpha1.ClusterScmProviderKind]) > 0 {
+				listOpts = nil
+			}
+		}
+
+		var gitRepos promoterv1alpha1.GitRepositoryList
+		if err := r.List(ctx, &gitRepos, listOpts...); err != nil {
+			logger.Error(err, "failed to list GitRepository resources")
+			return nil
 		}
 
-		var dependentPRs []string
-		for _, pr := range pullRequests.Items {
-			// Skip PullRequests that are also being deleted (allows cascade deletion)
-			if !pr.DeletionTimestamp.IsZero() {
-				continue
+		var requests []ctrl.Request
+		for _, gitRepo := range gitRepos.Items {
+			var usesSecret bool
+			if gitRepo.Spec.ScmProviderRef.Kind == promoterv1alpha1.ClusterScmProviderKind {
+				usesSecret = slices.Contains(scmProviders[promoterv1alpha1.ClusterScmProviderKind], gitRepo.Spec.ScmProviderRef.Name)
+			}
+			if gitRepo.Namespace == obj.GetNamespace() {
+				usesSecret = usesSecret ||
+					gitRepo.Spec.ScmProviderRef.Kind != promoterv1alpha1.ClusterScmProviderKind &&
+						slices.Contains(scmProviders[promoterv1alpha1.ScmProviderKind], gitRepo.Spec.ScmProviderRef.Name) ||
+					gitRepo.Spec.ManageWebhooks != nil && gitRepo.Spec.ManageWebhooks.SecretRef != nil && gitRepo.Spec.ManageWebhooks.SecretRef.Name == obj.GetName() ||
+					gitRepo.Spec.CommitSigning != nil && gitRepo.Spec.CommitSigning.SecretRef.Name == obj.GetName()
 			}
-			if pr.Spec.RepositoryReference.Name == gitRepo.Name {
-				dependentPRs = append(dependentPRs, pr.Name)
+			if usesSecret {
+				requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gitRepo)})
 			}
 		}
-		return dependentPRs, nil
+		return requests
+	})
+}
+
+// waitForGitRepository reports whether obj has to wait until its GitRepository exists, can be accessed and is not
+// archived, as found by the GitRepository controller, or until a spec.url its ScmProvider doesn't support is
+// replaced. If so, the Ready condition of obj is set to False with the GitRepository's message, and the returned
+// result reconciles obj again once the repository was checked again. A GitRepository that wasn't checked yet, or
+// whose check failed for another reason, doesn't hold up obj.
+func waitForGitRepository(ctx context.Context, settingsMgr *settings.Manager, obj utils.StatusConditionUpdater, gitRepo *promoterv1alpha1.GitRepository) (bool, ctrl.Result, error) {
+	ready := utils.GetReadyCondition(gitRepo)
+	if ready == nil || ready.Status != metav1.ConditionFalse ||
+		(ready.Reason != string(promoterConditions.NotFound) && ready.Reason != string(promoterConditions.AccessDenied) &&
+			ready.Reason != string(promoterConditions.UrlNotSupported) && ready.Reason != string(promoterConditions.RepositoryArchived)) {
+		return false, ctrl.Result{}, nil
+	}
+
+	accessCheckInterval, err := settingsMgr.GetGitRepositoryAccessCheckInterval(ctx)
+	if err != nil {
+		return true, ctrl.Result{}, fmt.Errorf("failed to get access check interval: %w", err)
+	}
+
+	log.FromContext(ctx).Info("GitRepository is not ready, skipping reconcile", "gitRepository", gitRepo.Name, "reason", ready.Reason)
+	utils.SetReadyCondition(obj, metav1.ConditionFalse, promoterConditions.GitRepositoryNotReady, fmt.Sprintf("GitRepository %q is not ready (%s): %s", gitRepo.Name, ready.Reason, ready.Message))
+	return true, ctrl.Result{RequeueAfter: accessCheckInterval}, nil
+}
+
+func (r *GitRepositoryReconciler) handleFinalizer(ctx context.Context, gitRepo *promoterv1alpha1.GitRepository) (bool, error) {
+	// Check for dependent PullRequests and ChangeTransferPolicies before allowing deletion, unless the deletion is
+	// forced.
+	checkDependencies := func() ([]string, error) {
+		dependents, err := r.listDependents(ctx, gitRepo)
+		if err != nil || len(dependents) == 0 {
+			return dependents, err
+		}
+		slices.Sort(dependents)
+
+		forced, err := forceDeletionDue(gitRepo)
+		if err != nil {
+			return nil, err
+		}
+		if forced {
+			log.FromContext(ctx).Info("Forcing deletion of GitRepository with dependent resources", "dependents", dependents)
+			r.Recorder.Eventf(gitRepo, nil, "Warning", constants.DeletionForcedReason, "Deletion", constants.DeletionForcedMessage, strings.Join(dependents, ", "))
+			return nil, nil
+		}
+
+		meta.SetStatusCondition(gitRepo.GetConditions(), metav1.Condition{
+			Type:               string(promoterConditions.DeletionBlocked),
+			Status:             metav1.ConditionTrue,
+			Reason:             string(promoterConditions.DependentResourcesExist),
+			Message:            "Waiting for the deletion of " + strings.Join(dependents, ", "),
+			ObservedGeneration: gitRepo.Generation,
+		})
+		return dependents, nil
 	}
 
-	return handleResourceFinalizerWithDependencies(
+	deleted, err := handleResourceFinalizerWithDependencies(
 		ctx,
 		r.Client,
 		r.Recorder,
@@ -118,5 +595,64 @@ func (r *GitRepositoryReconciler) handleFinalizer(ctx context.Context, gitRepo *
 		promoterv1alpha1.GitRepositoryFinalizer,
 		"GitRepository",
 		checkDependencies,
+		// The webhook is deleted before the finalizer is removed, so that a delete the SCM rejects is retried instead
+		// of leaving the webhook behind.
+		func() error { return r.deleteManagedWebhook(ctx, gitRepo) },
 	)
+	if deleted && err == nil {
+		// Remove the repository's clones once nothing depends on it anymore. This is best effort, the clone sweeper
+		// removes them later if this fails.
+		if err := git.RemoveRepositoryClones(ctx, gitRepo.Namespace, gitRepo.Name); err != nil {
+			log.FromContext(ctx).Error(err, "failed to remove clones of deleted GitRepository")
+		}
+	}
+	return deleted, err
+}
+
+// deleteManagedWebhook deletes the webhook the controller registered for the GitRepository being deleted, if any.
+func (r *GitRepositoryReconciler) deleteManagedWebhook(ctx context.Context, gitRepo *promoterv1alpha1.GitRepository) error {
+	if gitRepo.Status.WebhookID == "" {
+		return nil
+	}
+	return r.deleteWebhook(ctx, gitRepo)
+}
+
+// listDependents returns the PullRequests and ChangeTransferPolicies that use the GitRepository and are not being
+// deleted themselves, which allows cascade deletion. PullRequests need the repository to close themselves on the SCM.
+func (r *GitRepositoryReconciler) listDependents(ctx context.Context, gitRepo *promoterv1alpha1.GitRepository) ([]string, error) {
+	var pullRequests promoterv1alpha1.PullRequestList
+	if err := r.List(ctx, &pullRequests, client.InNamespace(gitRepo.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list PullRequests: %w", err)
+	}
+	var ctps promoterv1alpha1.ChangeTransferPolicyList
+	if err := r.List(ctx, &ctps, client.InNamespace(gitRepo.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list ChangeTransferPolicies: %w", err)
+	}
+
+	var dependents []string
+	for _, pr := range pullRequests.Items {
+		if pr.DeletionTimestamp.IsZero() && pr.Spec.RepositoryReference.Name == gitRepo.Name {
+			dependents = append(dependents, "PullRequest/"+pr.Name)
+		}
+	}
+	for _, ctp := range ctps.Items {
+		if ctp.DeletionTimestamp.IsZero() && ctp.Spec.RepositoryReference.Name == gitRepo.Name {
+			dependents = append(dependents, "ChangeTransferPolicy/"+ctp.Name)
+		}
+	}
+	return dependents, nil
+}
+
+// forceDeletionDue returns whether the time in the ForceDeleteAfterAnnotation of a GitRepository being deleted has
+// passed since its deletion was requested.
+func forceDeletionDue(gitRepo *promoterv1alpha1.GitRepository) (bool, error) {
+	value, ok := gitRepo.Annotations[promoterv1alpha1.ForceDeleteAfterAnnotation]
+	if !ok {
+		return false, nil
+	}
+	after, err := time.ParseDuration(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse annotation %s: %w", promoterv1alpha1.ForceDeleteAfterAnnotation, err)
+	}
+	return time.Since(gitRepo.DeletionTimestamp.Time) >= after, nil
 }
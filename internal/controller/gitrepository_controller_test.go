@@ -17,18 +17,35 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	_ "embed"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/fake"
+	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 )
 
 //go:embed testdata/GitRepository.yaml
@@ -97,4 +114,797 @@ var _ = Describe("GitRepository Controller", func() {
 			}, constants.EventuallyTimeout).Should(Succeed())
 		})
 	})
+
+	Context("When checking access to the repository", func() {
+		ctx := context.Background()
+
+		It("should be Ready once the repository is accessible and hold up the resources that use it when it is not", func() {
+			_, scmSecret, scmProvider, gitRepo, pullRequest := pullRequestResources(ctx, "access-check")
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, pullRequest)
+				_ = k8sClient.Delete(ctx, gitRepo)
+				_ = k8sClient.Delete(ctx, scmProvider)
+				_ = k8sClient.Delete(ctx, scmSecret)
+			})
+
+			By("Waiting for the repository to be found accessible")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)).To(Succeed())
+				ready := meta.FindStatusCondition(gitRepo.Status.Conditions, string(promoterConditions.Ready))
+				g.Expect(ready).NotTo(BeNil())
+				g.Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+				g.Expect(gitRepo.Status.HttpsCloneUrl).To(HavePrefix("http://localhost:"))
+				g.Expect(gitRepo.Status.SshCloneUrl).To(HavePrefix("ssh://git@localhost/"))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Reporting the repository as not found, as the SCM would for a deleted repository")
+			meta.SetStatusCondition(&gitRepo.Status.Conditions, metav1.Condition{
+				Type:               string(promoterConditions.Ready),
+				Status:             metav1.ConditionFalse,
+				Reason:             string(promoterConditions.NotFound),
+				Message:            "Repository not found.",
+				ObservedGeneration: gitRepo.Generation,
+			})
+			Expect(k8sClient.Status().Update(ctx, gitRepo)).To(Succeed())
+
+			By("Creating a PullRequest for the repository")
+			Expect(k8sClient.Create(ctx, pullRequest)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pullRequest), pullRequest)).To(Succeed())
+				ready := meta.FindStatusCondition(pullRequest.Status.Conditions, string(promoterConditions.Ready))
+				g.Expect(ready).NotTo(BeNil())
+				g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(ready.Reason).To(Equal(string(promoterConditions.GitRepositoryNotReady)))
+				g.Expect(ready.Message).To(ContainSubstring("Repository not found."))
+				g.Expect(pullRequest.Status.ID).To(BeEmpty())
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+	})
+
+	Context("When the repository is archived", func() {
+		ctx := context.Background()
+
+		It("should hold up the resources that use it until it is unarchived", func() {
+			_, scmSecret, scmProvider, gitRepo, pullRequest := pullRequestResources(ctx, "archived")
+			fake.SetRepositoryArchived(*gitRepo, true)
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			DeferCleanup(func() {
+				fake.SetRepositoryArchived(*gitRepo, false)
+				_ = k8sClient.Delete(ctx, pullRequest)
+				_ = k8sClient.Delete(ctx, gitRepo)
+				_ = k8sClient.Delete(ctx, scmProvider)
+				_ = k8sClient.Delete(ctx, scmSecret)
+			})
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)).To(Succeed())
+				ready := meta.FindStatusCondition(gitRepo.Status.Conditions, string(promoterConditions.Ready))
+				g.Expect(ready).NotTo(BeNil())
+				g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(ready.Reason).To(Equal(string(promoterConditions.RepositoryArchived)))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Creating a PullRequest for the archived repository")
+			Expect(k8sClient.Create(ctx, pullRequest)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pullRequest), pullRequest)).To(Succeed())
+				ready := meta.FindStatusCondition(pullRequest.Status.Conditions, string(promoterConditions.Ready))
+				g.Expect(ready).NotTo(BeNil())
+				g.Expect(ready.Reason).To(Equal(string(promoterConditions.GitRepositoryNotReady)))
+				g.Expect(pullRequest.Status.ID).To(BeEmpty())
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Unarchiving the repository and checking it again")
+			fake.SetRepositoryArchived(*gitRepo, false)
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)).To(Succeed())
+				gitRepo.Spec.CloneDepth = ptr.To[int32](1)
+				g.Expect(k8sClient.Update(ctx, gitRepo)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)).To(Succeed())
+				ready := meta.FindStatusCondition(gitRepo.Status.Conditions, string(promoterConditions.Ready))
+				g.Expect(ready).NotTo(BeNil())
+				g.Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+				g.Expect(utils.IsReady(gitRepo)).To(BeTrue())
+				g.Expect(gitRepo.Status.ObservedGeneration).To(Equal(gitRepo.Generation))
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+	})
+
+	Context("When deleting a repository that ChangeTransferPolicies use", func() {
+		ctx := context.Background()
+
+		It("should block the deletion until forced by the annotation", func() {
+			_, scmSecret, scmProvider, gitRepo, _, ctp := changeTransferPolicyResources(ctx, "deletion-blocked", "default")
+			webhookSecret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      gitRepo.Name + "-webhook",
+					Namespace: gitRepo.Namespace,
+				},
+				Data: map[string][]byte{
+					promoterv1alpha1.WebhookSecretKey: []byte("hmac-secret"),
+				},
+			}
+			gitRepo.Spec.ManageWebhooks = &promoterv1alpha1.ManageWebhooks{
+				Url:       "https://promoter.example.com/",
+				SecretRef: &v1.LocalObjectReference{Name: webhookSecret.Name},
+				Events:    []promoterv1alpha1.WebhookEvent{promoterv1alpha1.WebhookEventPush},
+			}
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, webhookSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			Expect(k8sClient.Create(ctx, ctp)).To(Succeed())
+			DeferCleanup(func() {
+				fake.FailWebhookDeletes(*gitRepo, nil)
+				_ = k8sClient.Delete(ctx, ctp)
+				_ = k8sClient.Delete(ctx, gitRepo)
+				_ = k8sClient.Delete(ctx, scmProvider)
+				_ = k8sClient.Delete(ctx, webhookSecret)
+				_ = k8sClient.Delete(ctx, scmSecret)
+			})
+
+			var webhookID string
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)).To(Succeed())
+				g.Expect(gitRepo.Finalizers).To(ContainElement(promoterv1alpha1.GitRepositoryFinalizer))
+				g.Expect(gitRepo.Status.WebhookID).NotTo(BeEmpty())
+				webhookID = gitRepo.Status.WebhookID
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Deleting the GitRepository while the ChangeTransferPolicy still uses it")
+			Expect(k8sClient.Delete(ctx, gitRepo)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)).To(Succeed())
+				blocked := meta.FindStatusCondition(gitRepo.Status.Conditions, string(promoterConditions.DeletionBlocked))
+				g.Expect(blocked).NotTo(BeNil())
+				g.Expect(blocked.Status).To(Equal(metav1.ConditionTrue))
+				g.Expect(blocked.Reason).To(Equal(string(promoterConditions.DependentResourcesExist)))
+				g.Expect(blocked.Message).To(ContainSubstring("ChangeTransferPolicy/" + ctp.Name))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Forcing the deletion with the annotation while the SCM rejects the delete of the webhook")
+			fake.FailWebhookDeletes(*gitRepo, fmt.Errorf("webhook delete rejected"))
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)).To(Succeed())
+				if gitRepo.Annotations == nil {
+					gitRepo.Annotations = map[string]string{}
+				}
+				gitRepo.Annotations[promoterv1alpha1.ForceDeleteAfterAnnotation] = "0s"
+				g.Expect(k8sClient.Update(ctx, gitRepo)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Consistently(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)).To(Succeed())
+				_, ok := fake.GetWebhook(*gitRepo, webhookID)
+				g.Expect(ok).To(BeTrue())
+			}, "2s").Should(Succeed())
+
+			By("Letting the SCM delete the webhook")
+			fake.FailWebhookDeletes(*gitRepo, nil)
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			_, ok := fake.GetWebhook(*gitRepo, webhookID)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("When the repository is identified by its URL", func() {
+		ctx := context.Background()
+
+		It("should not allow a URL together with an owner and name", func() {
+			_, _, _, gitRepo, _ := pullRequestResources(ctx, "url-and-fake")
+			gitRepo.Spec.Url = "http://localhost:" + gitServerPort + "/url-and-fake/url-and-fake"
+			err := k8sClient.Create(ctx, gitRepo)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("exactly one of the fields"))
+		})
+
+		It("should use the URL for git operations", func() {
+			_, scmSecret, scmProvider, gitRepo, _ := pullRequestResources(ctx, "url-only")
+			repoURL := "http://localhost:" + gitServerPort + "/" + gitRepo.Spec.Fake.Owner + "/" + gitRepo.Spec.Fake.Name
+			gitRepo.Spec.Fake = nil
+			gitRepo.Spec.Url = repoURL
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, gitRepo)
+				_ = k8sClient.Delete(ctx, scmProvider)
+				_ = k8sClient.Delete(ctx, scmSecret)
+			})
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)).To(Succeed())
+				ready := meta.FindStatusCondition(gitRepo.Status.Conditions, string(promoterConditions.Ready))
+				g.Expect(ready).NotTo(BeNil())
+				g.Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+				g.Expect(gitRepo.Status.HttpsCloneUrl).To(Equal(repoURL))
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+
+		It("should not be Ready when the ScmProvider needs the owner and name", func() {
+			name := "url-github-" + utils.KubeSafeUniqueName(ctx, randomString(15))
+			scmProvider := &promoterv1alpha1.ScmProvider{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: promoterv1alpha1.ScmProviderSpec{
+					SecretRef: &v1.LocalObjectReference{Name: name},
+					GitHub:    &promoterv1alpha1.GitHub{AppID: 1},
+				},
+			}
+			gitRepo := &promoterv1alpha1.GitRepository{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: promoterv1alpha1.GitRepositorySpec{
+					Url: "https://git.example.com/owner/repo.git",
+					ScmProviderRef: promoterv1alpha1.ScmProviderObjectReference{
+						Kind: promoterv1alpha1.ScmProviderKind,
+						Name: name,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, gitRepo)
+				_ = k8sClient.Delete(ctx, scmProvider)
+			})
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)).To(Succeed())
+				ready := meta.FindStatusCondition(gitRepo.Status.Conditions, string(promoterConditions.Ready))
+				g.Expect(ready).NotTo(BeNil())
+				g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(ready.Reason).To(Equal(string(promoterConditions.UrlNotSupported)))
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+	})
+
+	Context("When validating the repository's identifiers", func() {
+		ctx := context.Background()
+
+		DescribeTable("should reject the GitRepository naming the invalid field",
+			func(setRepo func(*promoterv1alpha1.GitRepositorySpec), fieldPath string) {
+				_, _, _, gitRepo, _ := pullRequestResources(ctx, "invalid-repo")
+				gitRepo.Spec.Fake = nil
+				setRepo(&gitRepo.Spec)
+				err := k8sClient.Create(ctx, gitRepo)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(fieldPath))
+			},
+			Entry("a fake name with a slash", func(spec *promoterv1alpha1.GitRepositorySpec) {
+				spec.Fake = &promoterv1alpha1.FakeRepo{Owner: "owner", Name: "group/repo"}
+			}, "spec.fake.name"),
+			Entry("an empty GitHub owner", func(spec *promoterv1alpha1.GitRepositorySpec) {
+				spec.GitHub = &promoterv1alpha1.GitHubRepo{Name: "repo"}
+			}, "spec.github.owner"),
+			Entry("a GitLab project slug with a space", func(spec *promoterv1alpha1.GitRepositorySpec) {
+				spec.GitLab = &promoterv1alpha1.GitLabRepo{Namespace: "group", Name: "my project"}
+			}, "spec.gitlab.name"),
+			Entry("a Forgejo owner with a space", func(spec *promoterv1alpha1.GitRepositorySpec) {
+				spec.Forgejo = &promoterv1alpha1.ForgejoRepo{Owner: "the owner", Name: "repo"}
+			}, "spec.forgejo.owner"),
+			Entry("an empty Gitea name", func(spec *promoterv1alpha1.GitRepositorySpec) {
+				spec.Gitea = &promoterv1alpha1.GiteaRepo{Owner: "owner"}
+			}, "spec.gitea.name"),
+			Entry("an Azure DevOps name with a slash", func(spec *promoterv1alpha1.GitRepositorySpec) {
+				spec.AzureDevOps = &promoterv1alpha1.AzureDevOpsRepo{Project: "project", Name: "a/b"}
+			}, "spec.azureDevOps.name"),
+			Entry("an empty ScmProvider name", func(spec *promoterv1alpha1.GitRepositorySpec) {
+				spec.Fake = &promoterv1alpha1.FakeRepo{Owner: "owner", Name: "repo"}
+				spec.ScmProviderRef.Name = ""
+			}, "spec.scmProviderRef.name"),
+		)
+	})
+
+	Context("When managing the repository's webhook", func() {
+		ctx := context.Background()
+
+		It("should register the webhook, register it again when it is deleted on the SCM and delete it when unmanaged", func() {
+			_, scmSecret, scmProvider, gitRepo, _ := pullRequestResources(ctx, "manage-webhooks")
+			webhookSecret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      gitRepo.Name + "-webhook",
+					Namespace: gitRepo.Namespace,
+				},
+				Data: map[string][]byte{
+					promoterv1alpha1.WebhookSecretKey: []byte("hmac-secret"),
+				},
+			}
+			gitRepo.Spec.ManageWebhooks = &promoterv1alpha1.ManageWebhooks{
+				Url:       "https://promoter.example.com/",
+				SecretRef: &v1.LocalObjectReference{Name: webhookSecret.Name},
+				Events:    []promoterv1alpha1.WebhookEvent{promoterv1alpha1.WebhookEventPush},
+			}
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, webhookSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, gitRepo)
+				_ = k8sClient.Delete(ctx, scmProvider)
+				_ = k8sClient.Delete(ctx, webhookSecret)
+				_ = k8sClient.Delete(ctx, scmSecret)
+			})
+
+			By("Waiting for the webhook to be registered")
+			var webhookID string
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)).To(Succeed())
+				registered := meta.FindStatusCondition(gitRepo.Status.Conditions, string(promoterConditions.WebhookRegistered))
+				g.Expect(registered).NotTo(BeNil())
+				g.Expect(registered.Status).To(Equal(metav1.ConditionTrue))
+				g.Expect(gitRepo.Status.WebhookID).NotTo(BeEmpty())
+				webhook, ok := fake.GetWebhook(*gitRepo, gitRepo.Status.WebhookID)
+				g.Expect(ok).To(BeTrue())
+				g.Expect(webhook.Url).To(Equal("https://promoter.example.com/"))
+				g.Expect(webhook.Secret).To(Equal("hmac-secret"))
+				g.Expect(webhook.Events).To(ConsistOf(promoterv1alpha1.WebhookEventPush))
+				webhookID = gitRepo.Status.WebhookID
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Deleting the webhook on the SCM and changing its URL")
+			fake.DeleteWebhook(*gitRepo, webhookID)
+			gitRepo.Spec.ManageWebhooks.Url = "https://promoter.example.com/webhook"
+			Expect(k8sClient.Update(ctx, gitRepo)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)).To(Succeed())
+				g.Expect(gitRepo.Status.WebhookID).NotTo(Equal(webhookID))
+				webhook, ok := fake.GetWebhook(*gitRepo, gitRepo.Status.WebhookID)
+				g.Expect(ok).To(BeTrue())
+				g.Expect(webhook.Url).To(Equal("https://promoter.example.com/webhook"))
+				webhookID = gitRepo.Status.WebhookID
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("No longer managing the webhook")
+			gitRepo.Spec.ManageWebhooks = nil
+			Expect(k8sClient.Update(ctx, gitRepo)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)).To(Succeed())
+				g.Expect(gitRepo.Status.WebhookID).To(BeEmpty())
+				g.Expect(meta.FindStatusCondition(gitRepo.Status.Conditions, string(promoterConditions.WebhookRegistered))).To(BeNil())
+				_, ok := fake.GetWebhook(*gitRepo, webhookID)
+				g.Expect(ok).To(BeFalse())
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+
+		It("should keep the GitRepository until its webhook is deleted on the SCM", func() {
+			_, scmSecret, scmProvider, gitRepo, _ := pullRequestResources(ctx, "delete-webhook")
+			webhookSecret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      gitRepo.Name + "-webhook",
+					Namespace: gitRepo.Namespace,
+				},
+				Data: map[string][]byte{
+					promoterv1alpha1.WebhookSecretKey: []byte("hmac-secret"),
+				},
+			}
+			gitRepo.Spec.ManageWebhooks = &promoterv1alpha1.ManageWebhooks{
+				Url:       "https://promoter.example.com/",
+				SecretRef: &v1.LocalObjectReference{Name: webhookSecret.Name},
+				Events:    []promoterv1alpha1.WebhookEvent{promoterv1alpha1.WebhookEventPush},
+			}
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, webhookSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			DeferCleanup(func() {
+				fake.FailWebhookDeletes(*gitRepo, nil)
+				_ = k8sClient.Delete(ctx, gitRepo)
+				_ = k8sClient.Delete(ctx, scmProvider)
+				_ = k8sClient.Delete(ctx, webhookSecret)
+				_ = k8sClient.Delete(ctx, scmSecret)
+			})
+
+			var webhookID string
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)).To(Succeed())
+				g.Expect(gitRepo.Status.WebhookID).NotTo(BeEmpty())
+				webhookID = gitRepo.Status.WebhookID
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Deleting the GitRepository while the SCM rejects the delete of its webhook")
+			fake.FailWebhookDeletes(*gitRepo, fmt.Errorf("webhook delete rejected"))
+			Expect(k8sClient.Delete(ctx, gitRepo)).To(Succeed())
+			Consistently(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)).To(Succeed())
+				g.Expect(gitRepo.Finalizers).To(ContainElement(promoterv1alpha1.GitRepositoryFinalizer))
+				_, ok := fake.GetWebhook(*gitRepo, webhookID)
+				g.Expect(ok).To(BeTrue())
+			}, "2s").Should(Succeed())
+
+			By("Letting the SCM delete the webhook")
+			fake.FailWebhookDeletes(*gitRepo, nil)
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			_, ok := fake.GetWebhook(*gitRepo, webhookID)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should only accept GitHub deliveries for the repository signed with the webhook secret", func() {
+			_, scmSecret, scmProvider, gitRepo, _ := pullRequestResources(ctx, "webhook-signature")
+			webhookSecret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      gitRepo.Name + "-webhook",
+					Namespace: gitRepo.Namespace,
+				},
+				Data: map[string][]byte{
+					promoterv1alpha1.WebhookSecretKey: []byte("hmac-secret"),
+				},
+			}
+			gitRepo.Spec.ManageWebhooks = &promoterv1alpha1.ManageWebhooks{
+				Url:       "https://promoter.example.com/",
+				SecretRef: &v1.LocalObjectReference{Name: webhookSecret.Name},
+			}
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, webhookSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, gitRepo)
+				_ = k8sClient.Delete(ctx, scmProvider)
+				_ = k8sClient.Delete(ctx, webhookSecret)
+				_ = k8sClient.Delete(ctx, scmSecret)
+			})
+
+			body := []byte(fmt.Sprintf(`{"ref":"refs/heads/environment/development","repository":{"full_name":%q}}`,
+				gitRepo.Spec.Fake.Owner+"/"+gitRepo.Spec.Fake.Name))
+			mac := hmac.New(sha256.New, []byte("hmac-secret"))
+			mac.Write(body)
+			signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+			send := func(signature, deliveryID string) int {
+				req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://localhost:%d/", webhookReceiverPort), bytes.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("X-Github-Event", "push")
+				if deliveryID != "" {
+					req.Header.Set("X-Github-Delivery", deliveryID)
+				}
+				if signature != "" {
+					req.Header.Set("X-Hub-Signature-256", signature)
+				}
+				resp, err := http.DefaultClient.Do(req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Body.Close()).To(Succeed())
+				return resp.StatusCode
+			}
+
+			By("Rejecting unsigned deliveries and deliveries signed with another secret")
+			// The receiver reads the GitRepository and Secret from the cache, wait for them to be there.
+			Eventually(func(g Gomega) {
+				g.Expect(send("", timeBasedDeliveryID())).To(Equal(http.StatusUnauthorized))
+			}, constants.EventuallyTimeout).Should(Succeed())
+			mac = hmac.New(sha256.New, []byte("another-secret"))
+			mac.Write(body)
+			Expect(send("sha256="+hex.EncodeToString(mac.Sum(nil)), timeBasedDeliveryID())).To(Equal(http.StatusUnauthorized))
+
+			By("Rejecting signed deliveries without a time based delivery ID")
+			Expect(send(signature, "")).To(Equal(http.StatusBadRequest))
+			Expect(send(signature, uuid.NewString())).To(Equal(http.StatusBadRequest))
+
+			By("Accepting the signed delivery once")
+			deliveryID := timeBasedDeliveryID()
+			Expect(send(signature, deliveryID)).To(Equal(http.StatusAccepted))
+			Expect(send(signature, deliveryID)).To(Equal(http.StatusConflict))
+		})
+
+		It("should only accept GitLab deliveries for the repository with the webhook secret as their token", func() {
+			_, scmSecret, scmProvider, gitRepo, _ := pullRequestResources(ctx, "webhook-token")
+			webhookSecret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      gitRepo.Name + "-webhook",
+					Namespace: gitRepo.Namespace,
+				},
+				Data: map[string][]byte{
+					promoterv1alpha1.WebhookSecretKey: []byte("gitlab-token"),
+				},
+			}
+			gitRepo.Spec.ManageWebhooks = &promoterv1alpha1.ManageWebhooks{
+				Url:       "https://promoter.example.com/",
+				SecretRef: &v1.LocalObjectReference{Name: webhookSecret.Name},
+			}
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, webhookSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, gitRepo)
+				_ = k8sClient.Delete(ctx, scmProvider)
+				_ = k8sClient.Delete(ctx, webhookSecret)
+				_ = k8sClient.Delete(ctx, scmSecret)
+			})
+
+			var payload map[string]any
+			Expect(json.Unmarshal(testGitLabPushHookEvent, &payload)).To(Succeed())
+			payload["project"].(map[string]any)["path_with_namespace"] = gitRepo.Spec.Fake.Owner + "/" + gitRepo.Spec.Fake.Name
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Rejecting deliveries without the token or with another one")
+			// The receiver reads the GitRepository and Secret from the cache, wait for them to be there.
+			Eventually(func(g Gomega) {
+				g.Expect(postWebhookDelivery(ctx, map[string]string{"X-Gitlab-Event": "Push Hook"}, body)).To(Equal(http.StatusUnauthorized))
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Expect(postWebhookDelivery(ctx, map[string]string{
+				"X-Gitlab-Event": "Push Hook",
+				"X-Gitlab-Token": "another-token",
+			}, body)).To(Equal(http.StatusUnauthorized))
+
+			By("Accepting deliveries with the token")
+			Expect(postWebhookDelivery(ctx, map[string]string{
+				"X-Gitlab-Event": "Push Hook",
+				"X-Gitlab-Token": "gitlab-token",
+			}, body)).To(Equal(http.StatusAccepted))
+		})
+
+		It("should only accept Bitbucket deliveries for the repository signed with the webhook secret", func() {
+			_, scmSecret, scmProvider, gitRepo, _ := pullRequestResources(ctx, "webhook-bitbucket")
+			webhookSecret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      gitRepo.Name + "-webhook",
+					Namespace: gitRepo.Namespace,
+				},
+				Data: map[string][]byte{
+					promoterv1alpha1.WebhookSecretKey: []byte("bitbucket-secret"),
+				},
+			}
+			gitRepo.Spec.ManageWebhooks = &promoterv1alpha1.ManageWebhooks{
+				Url:       "https://promoter.example.com/",
+				SecretRef: &v1.LocalObjectReference{Name: webhookSecret.Name},
+			}
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, webhookSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, gitRepo)
+				_ = k8sClient.Delete(ctx, scmProvider)
+				_ = k8sClient.Delete(ctx, webhookSecret)
+				_ = k8sClient.Delete(ctx, scmSecret)
+			})
+
+			var payload map[string]any
+			Expect(json.Unmarshal(testBitbucketCloudPushEvent, &payload)).To(Succeed())
+			payload["repository"].(map[string]any)["full_name"] = gitRepo.Spec.Fake.Owner + "/" + gitRepo.Spec.Fake.Name
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+			headers := func(secret string) map[string]string {
+				headers := map[string]string{"X-Event-Key": "repo:push", "X-Hook-UUID": "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e"}
+				if secret != "" {
+					mac := hmac.New(sha256.New, []byte(secret))
+					mac.Write(body)
+					headers["X-Hub-Signature"] = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+				}
+				return headers
+			}
+
+			By("Rejecting unsigned deliveries and deliveries signed with another secret")
+			// The receiver reads the GitRepository and Secret from the cache, wait for them to be there.
+			Eventually(func(g Gomega) {
+				g.Expect(postWebhookDelivery(ctx, headers(""), body)).To(Equal(http.StatusUnauthorized))
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Expect(postWebhookDelivery(ctx, headers("another-secret"), body)).To(Equal(http.StatusUnauthorized))
+
+			By("Accepting signed deliveries")
+			Expect(postWebhookDelivery(ctx, headers("bitbucket-secret"), body)).To(Equal(http.StatusAccepted))
+
+			By("Persisting the verified delivery on the GitRepository for the other replicas")
+			Eventually(func(g Gomega) {
+				var got promoterv1alpha1.GitRepository
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), &got)).To(Succeed())
+				g.Expect(got.Status.LastWebhookDelivery).NotTo(BeNil())
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Rejecting deliveries from outside the allowed networks, signed or not")
+			Expect(postWebhookDeliveryToPort(ctx, allowlistWebhookReceiverPort, headers("bitbucket-secret"), body)).To(Equal(http.StatusForbidden))
+			Expect(postWebhookDeliveryToPort(ctx, allowlistWebhookReceiverPort, headers(""), body)).To(Equal(http.StatusForbidden))
+		})
+
+		It("should not apply the webhook secret of a repository to deliveries of another SCM for the same full name", func() {
+			name := "webhook-scoped-" + utils.KubeSafeUniqueName(ctx, randomString(15))
+			webhookSecret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: name + "-webhook", Namespace: "default"},
+				Data: map[string][]byte{
+					promoterv1alpha1.WebhookSecretKey: []byte("gitlab-token"),
+				},
+			}
+			// The GitRepository is only read by the receiver, its ScmProvider doesn't need to exist.
+			gitRepo := &promoterv1alpha1.GitRepository{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: promoterv1alpha1.GitRepositorySpec{
+					GitLab:         &promoterv1alpha1.GitLabRepo{Namespace: name, Name: "repo"},
+					ScmProviderRef: promoterv1alpha1.ScmProviderObjectReference{Kind: promoterv1alpha1.ScmProviderKind, Name: name},
+					ManageWebhooks: &promoterv1alpha1.ManageWebhooks{
+						Url:       "https://promoter.example.com/",
+						SecretRef: &v1.LocalObjectReference{Name: webhookSecret.Name},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, webhookSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, gitRepo)
+				_ = k8sClient.Delete(ctx, webhookSecret)
+			})
+
+			var payload map[string]any
+			Expect(json.Unmarshal(testGitLabPushHookEvent, &payload)).To(Succeed())
+			payload["project"].(map[string]any)["path_with_namespace"] = name + "/repo"
+			gitLabBody, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Rejecting GitLab deliveries for the repository without its token")
+			// The receiver reads the GitRepository and Secret from the cache, wait for them to be there.
+			Eventually(func(g Gomega) {
+				g.Expect(postWebhookDelivery(ctx, map[string]string{"X-Gitlab-Event": "Push Hook"}, gitLabBody)).To(Equal(http.StatusUnauthorized))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Accepting unsigned GitHub deliveries for a repository with the same full name")
+			gitHubBody := []byte(fmt.Sprintf(`{"ref":"refs/heads/environment/development","repository":{"full_name":%q}}`, name+"/repo"))
+			Expect(postWebhookDelivery(ctx, map[string]string{
+				"X-Github-Event":    "push",
+				"X-Github-Delivery": uuid.NewString(),
+			}, gitHubBody)).To(Equal(http.StatusAccepted))
+		})
+
+		It("should only route deliveries verified with the webhook secret of a repository within that repository", func() {
+			name := "webhook-route-" + utils.KubeSafeUniqueName(ctx, randomString(15))
+			hash := sha256.Sum256([]byte(name))
+			proposedSha := hex.EncodeToString(hash[:20])
+
+			// The GitRepositories are only read by the receiver, their ScmProvider doesn't need to exist.
+			for _, repo := range []string{"a", "b"} {
+				webhookSecret := &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: name + "-" + repo + "-webhook", Namespace: "default"},
+					Data: map[string][]byte{
+						promoterv1alpha1.WebhookSecretKey: []byte("secret-" + repo),
+					},
+				}
+				gitRepo := &promoterv1alpha1.GitRepository{
+					ObjectMeta: metav1.ObjectMeta{Name: name + "-" + repo, Namespace: "default"},
+					Spec: promoterv1alpha1.GitRepositorySpec{
+						Fake:           &promoterv1alpha1.FakeRepo{Owner: name, Name: repo},
+						ScmProviderRef: promoterv1alpha1.ScmProviderObjectReference{Kind: promoterv1alpha1.ScmProviderKind, Name: name},
+						ManageWebhooks: &promoterv1alpha1.ManageWebhooks{
+							Url:       "https://promoter.example.com/",
+							SecretRef: &v1.LocalObjectReference{Name: webhookSecret.Name},
+						},
+					},
+				}
+				Expect(k8sClient.Create(ctx, webhookSecret)).To(Succeed())
+				Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+				DeferCleanup(func() {
+					_ = k8sClient.Delete(ctx, gitRepo)
+					_ = k8sClient.Delete(ctx, webhookSecret)
+				})
+			}
+			ctp := &promoterv1alpha1.ChangeTransferPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name + "-b", Namespace: "default"},
+				Spec: promoterv1alpha1.ChangeTransferPolicySpec{
+					RepositoryReference: promoterv1alpha1.ObjectReference{Name: name + "-b"},
+					ProposedBranch:      "environment/development-next",
+					ActiveBranch:        "environment/development",
+				},
+			}
+			Expect(k8sClient.Create(ctx, ctp)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, ctp)
+			})
+
+			push := func(fullName, secret string) int {
+				body := []byte(fmt.Sprintf(`{"ref":"refs/heads/environment/development-next","before":%q,"pusher":{"name":"promoter"},"repository":{"full_name":%q}}`,
+					proposedSha, fullName))
+				headers := map[string]string{"X-Github-Event": "push", "X-Github-Delivery": timeBasedDeliveryID()}
+				if secret != "" {
+					mac := hmac.New(sha256.New, []byte(secret))
+					mac.Write(body)
+					headers["X-Hub-Signature-256"] = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+				}
+				return postWebhookDelivery(ctx, headers, body)
+			}
+
+			By("Routing a push over the proposed sha signed with the secret of the ChangeTransferPolicy's GitRepository to it")
+			Eventually(func(g Gomega) {
+				// The ChangeTransferPolicy can't be reconciled without its ScmProvider, the test sets its proposed sha.
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ctp), ctp)).To(Succeed())
+				if ctp.Status.Proposed.Hydrated.Sha != proposedSha {
+					ctp.Status.Proposed.Hydrated.Sha = proposedSha
+					g.Expect(k8sClient.Status().Update(ctx, ctp)).To(Succeed())
+				}
+				g.Expect(push(name+"/b", "secret-b")).To(Equal(http.StatusNoContent))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Not routing a push over the proposed sha signed with the secret of another GitRepository to it")
+			Expect(push(name+"/a", "secret-a")).To(Equal(http.StatusAccepted))
+
+			By("Not routing an unverified push over the proposed sha to it")
+			Expect(push(name+"/c", "")).To(Equal(http.StatusAccepted))
+		})
+
+		It("should only accept Gitea, Forgejo and Azure DevOps deliveries for the repository with the webhook secret", func() {
+			name := "webhook-gitea-" + utils.KubeSafeUniqueName(ctx, randomString(15))
+			webhookSecret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: name + "-webhook", Namespace: "default"},
+				Data: map[string][]byte{
+					promoterv1alpha1.WebhookSecretKey: []byte("webhook-secret"),
+				},
+			}
+			// The GitRepository is only read by the receiver, its ScmProvider doesn't need to exist. Fake repositories
+			// are indexed for every provider.
+			gitRepo := &promoterv1alpha1.GitRepository{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: promoterv1alpha1.GitRepositorySpec{
+					Fake:           &promoterv1alpha1.FakeRepo{Owner: name, Name: "repo"},
+					ScmProviderRef: promoterv1alpha1.ScmProviderObjectReference{Kind: promoterv1alpha1.ScmProviderKind, Name: name},
+					ManageWebhooks: &promoterv1alpha1.ManageWebhooks{
+						Url:       "https://promoter.example.com/",
+						SecretRef: &v1.LocalObjectReference{Name: webhookSecret.Name},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, webhookSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, gitRepo)
+				_ = k8sClient.Delete(ctx, webhookSecret)
+			})
+
+			giteaBody := []byte(fmt.Sprintf(`{"ref":"refs/heads/environment/development","before":"0000000000000000000000000000000000000000","pusher":{"login":"promoter"},"repository":{"full_name":%q}}`,
+				name+"/repo"))
+			sign := func(secret string) string {
+				mac := hmac.New(sha256.New, []byte(secret))
+				mac.Write(giteaBody)
+				return hex.EncodeToString(mac.Sum(nil))
+			}
+
+			By("Rejecting unsigned Gitea and Forgejo deliveries")
+			// The receiver reads the GitRepository and Secret from the cache, wait for them to be there.
+			Eventually(func(g Gomega) {
+				g.Expect(postWebhookDelivery(ctx, map[string]string{"X-Gitea-Event": "push"}, giteaBody)).To(Equal(http.StatusUnauthorized))
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Expect(postWebhookDelivery(ctx, map[string]string{"X-Forgejo-Event": "push"}, giteaBody)).To(Equal(http.StatusUnauthorized))
+			Expect(postWebhookDelivery(ctx, map[string]string{
+				"X-Gitea-Event":     "push",
+				"X-Gitea-Signature": sign("another-secret"),
+			}, giteaBody)).To(Equal(http.StatusUnauthorized))
+
+			By("Accepting Gitea and Forgejo deliveries signed with the secret")
+			Expect(postWebhookDelivery(ctx, map[string]string{
+				"X-Gitea-Event":     "push",
+				"X-Gitea-Signature": sign("webhook-secret"),
+			}, giteaBody)).To(Equal(http.StatusAccepted))
+			Expect(postWebhookDelivery(ctx, map[string]string{
+				"X-Forgejo-Event":     "push",
+				"X-Forgejo-Signature": sign("webhook-secret"),
+			}, giteaBody)).To(Equal(http.StatusAccepted))
+
+			azureBody := []byte(fmt.Sprintf(`{"eventType":"git.push","publisherId":"tfs","resource":{"repository":{"name":"repo","project":{"name":%q}}}}`, name))
+			basicAuth := func(password string) map[string]string {
+				return map[string]string{"Authorization": "Basic " + base64.StdEncoding.EncodeToString([]byte("promoter:"+password))}
+			}
+
+			By("Rejecting Azure DevOps deliveries without the secret as their basic auth password")
+			Expect(postWebhookDelivery(ctx, nil, azureBody)).To(Equal(http.StatusUnauthorized))
+			Expect(postWebhookDelivery(ctx, basicAuth("another-secret"), azureBody)).To(Equal(http.StatusUnauthorized))
+
+			By("Accepting Azure DevOps deliveries with the secret as their basic auth password")
+			Expect(postWebhookDelivery(ctx, basicAuth("webhook-secret"), azureBody)).To(Equal(http.StatusAccepted))
+		})
+	})
 })
+
+// timeBasedDeliveryID returns a delivery ID like GitHub's, which the receiver takes the time of signed deliveries from.
+func timeBasedDeliveryID() string {
+	id, err := uuid.NewUUID()
+	Expect(err).NotTo(HaveOccurred())
+	return id.String()
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/settings"
+	"github.com/argoproj-labs/gitops-promoter/internal/webhookreceiver"
+)
+
+// adaptiveRequeue returns interval, lengthened up to the configured adaptivePolling.maxRequeueDuration while verified
+// webhook deliveries for the GitRepository are fresh, and the PollingStatus that shows it. The last delivery is the
+// latest of the one this replica received and the one any replica or shard persisted on the GitRepository's status.
+// The PollingStatus only shows the persisted delivery and whether the interval is lengthened, not how long until the
+// deliveries go stale, so that it doesn't change on every reconcile.
+func adaptiveRequeue(ctx context.Context, c client.Reader, settingsMgr *settings.Manager, deliveries *webhookreceiver.DeliveryTracker, gitRepoKey client.ObjectKey, interval time.Duration) (time.Duration, *promoterv1alpha1.PollingStatus, error) {
+	maxInterval, err := settingsMgr.GetAdaptivePollingMaxRequeueDuration(ctx)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get adaptive polling max requeue duration: %w", err)
+	}
+	freshness, err := settingsMgr.GetAdaptivePollingWebhookFreshness(ctx)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get adaptive polling webhook freshness: %w", err)
+	}
+
+	var gitRepo promoterv1alpha1.GitRepository
+	if err := c.Get(ctx, gitRepoKey, &gitRepo); err != nil && !k8serrors.IsNotFound(err) {
+		return 0, nil, fmt.Errorf("failed to get GitRepository %q for adaptive polling: %w", gitRepoKey.Name, err)
+	}
+	persisted := gitRepo.Status.LastWebhookDelivery
+
+	now := time.Now()
+	lastDelivery := deliveries.LastDelivery(gitRepoKey)
+	if persisted != nil && persisted.After(lastDelivery) {
+		lastDelivery = persisted.Time
+	}
+	requeue := webhookreceiver.AdaptiveRequeueInterval(interval, maxInterval, freshness, lastDelivery, now)
+
+	status := &promoterv1alpha1.PollingStatus{
+		RequeueInterval:     metav1.Duration{Duration: interval},
+		LastWebhookDelivery: persisted,
+	}
+	if requeue != interval {
+		log.FromContext(ctx).V(4).Info("webhook deliveries are fresh, lengthening requeue interval",
+			"interval", interval, "requeueInterval", requeue, "lastWebhookDelivery", lastDelivery)
+		status.RequeueInterval = metav1.Duration{Duration: maxInterval}
+	}
+	if !lastDelivery.IsZero() {
+		status.WebhooksFresh = now.Sub(lastDelivery) < freshness
+	}
+	return requeue, status, nil
+}
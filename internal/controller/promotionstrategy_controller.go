@@ -19,7 +19,10 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,19 +31,28 @@ import (
 
 	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	acv1alpha1 "github.com/argoproj-labs/gitops-promoter/applyconfiguration/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/audit"
+	"github.com/argoproj-labs/gitops-promoter/internal/cloudevents"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
 	"github.com/argoproj-labs/gitops-promoter/internal/settings"
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
 	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+	"github.com/argoproj-labs/gitops-promoter/internal/webhookreceiver"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	acmetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -52,6 +64,11 @@ type ctpEnqueueState struct {
 	hasScheduledRetry bool
 }
 
+// PromotionStrategyEnqueueFunc is a function type that can be used to enqueue PromotionStrategy reconcile requests
+// without modifying the PromotionStrategy object. This is used by the webhook receiver when the SCM reports a change of
+// a commit status or check run.
+type PromotionStrategyEnqueueFunc func(namespace, name string)
+
 // PromotionStrategyReconciler reconciles a PromotionStrategy object
 type PromotionStrategyReconciler struct {
 	client.Client
@@ -62,17 +79,39 @@ type PromotionStrategyReconciler struct {
 	// EnqueueCTP is a function to enqueue CTP reconcile requests without modifying the CTP object.
 	EnqueueCTP CTPEnqueueFunc
 
+	// WebhookDeliveries records the verified webhook deliveries for each GitRepository. While they are fresh, the
+	// requeue interval is lengthened. It may be nil.
+	WebhookDeliveries *webhookreceiver.DeliveryTracker
+
+	// CloudEvents publishes the promotions that are blocked by failing commit statuses. It may be nil.
+	CloudEvents *cloudevents.Emitter
+
+	// Audit records the merged promotions in the audit trail. It may be nil.
+	Audit *audit.Recorder
+
 	// enqueueStates tracks rate limiting state for out-of-sync CTP enqueues.
 	// Key is client.ObjectKey of the CTP. Protected by enqueueStateMutex.
 	enqueueStates     map[client.ObjectKey]*ctpEnqueueState
 	enqueueStateMutex sync.Mutex
+
+	// enqueueFunc is set during SetupWithManager and can be retrieved via GetEnqueueFunc.
+	enqueueFunc PromotionStrategyEnqueueFunc
+}
+
+// GetEnqueueFunc returns a function that can be used to enqueue PromotionStrategy reconcile requests.
+// This should be called after SetupWithManager has been called.
+func (r *PromotionStrategyReconciler) GetEnqueueFunc() PromotionStrategyEnqueueFunc {
+	return r.enqueueFunc
 }
 
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=promotionstrategies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=promotionstrategies/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=promotionstrategies/finalizers,verbs=update
+//+kubebuilder:rbac:groups=promoter.argoproj.io,resources=revertcommits,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=promoter.argoproj.io,resources=gitrepositories,verbs=get;list;watch
 
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
@@ -83,7 +122,7 @@ type PromotionStrategyReconciler struct {
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.17.2/pkg/reconcile
 func (r *PromotionStrategyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
-	logger.Info("Reconciling PromotionStrategy")
+	logger.V(4).Info("Reconciling PromotionStrategy")
 	startTime := time.Now()
 
 	var ps promoterv1alpha1.PromotionStrategy
@@ -94,6 +133,7 @@ func (r *PromotionStrategyReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			logger.Info("PromotionStrategy not found")
+			metrics.DeletePromotionStrategyMetrics(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "failed to get PromotionStrategy")
@@ -109,11 +149,40 @@ func (r *PromotionStrategyReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	// Remove any existing Ready condition. We want to start fresh.
 	meta.RemoveStatusCondition(ps.GetConditions(), string(promoterConditions.Ready))
 
+	proposedBranches, err := renderProposedBranches(&ps)
+	if err == nil {
+		err = r.checkProposedBranchesUnchanged(ctx, &ps, proposedBranches)
+	}
+	if err != nil {
+		// Only a change to the spec or to the ChangeTransferPolicies can fix this, and both trigger a reconcile.
+		logger.Info("Proposed branches are invalid", "reason", err.Error())
+		meta.SetStatusCondition(ps.GetConditions(), metav1.Condition{
+			Type:               string(promoterConditions.Ready),
+			Status:             metav1.ConditionFalse,
+			Reason:             string(promoterConditions.ProposedBranchInvalid),
+			Message:            err.Error(),
+			ObservedGeneration: ps.Generation,
+		})
+		return ctrl.Result{}, nil
+	}
+
+	emergencyReverts, err := r.emergencyReverts(ctx, &ps)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get emergency reverts: %w", err)
+	}
+
+	// The GitRepository's labels and annotations are propagated to the ChangeTransferPolicies. If it doesn't exist, the
+	// ChangeTransferPolicies report it.
+	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, r.Client, client.ObjectKey{Namespace: ps.Namespace, Name: ps.Spec.RepositoryReference.Name})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+
 	// If a ChangeTransferPolicy does not exist, create it otherwise get it and store the ChangeTransferPolicy in a slice with the same order as ps.Spec.Environments.
 	ctps := make([]*promoterv1alpha1.ChangeTransferPolicy, len(ps.Spec.Environments))
 	for i, environment := range ps.Spec.Environments {
 		var ctp *promoterv1alpha1.ChangeTransferPolicy
-		ctp, err = r.upsertChangeTransferPolicy(ctx, &ps, environment)
+		ctp, err = r.upsertChangeTransferPolicy(ctx, &ps, gitRepo, environment, proposedBranches[i], emergencyReverts[environment.Branch] != nil)
 		if err != nil {
 			logger.Error(err, "failed to upsert ChangeTransferPolicy")
 			return ctrl.Result{}, fmt.Errorf("failed to create ChangeTransferPolicy for branch %q: %w", environment.Branch, err)
@@ -128,7 +197,28 @@ func (r *PromotionStrategyReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 
 	// Calculate the status of the PromotionStrategy. Updates ps in place.
+	previousEnvironments := ps.Status.Environments
 	r.calculateStatus(&ps, ctps)
+	r.setEmergencyReverts(&ps, emergencyReverts)
+	r.emitBlockedPromotions(ctx, &ps, previousEnvironments)
+	recordMergedPullRequests(&ps, previousEnvironments)
+	r.auditMergedPullRequests(ctx, &ps, previousEnvironments)
+	recordPromotionGating(&ps, previousEnvironments)
+
+	err = r.markRevertHistory(ctx, &ps)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to mark reverts in history: %w", err)
+	}
+
+	autoRevertRequeue, err := r.autoRevertFailedPromotions(ctx, &ps)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to revert failed promotions: %w", err)
+	}
+
+	err = r.closeSupersededPullRequests(ctx, &ps, ctps)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to close superseded pull requests: %w", err)
+	}
 
 	err = r.updatePreviousEnvironmentCommitStatus(ctx, &ps, ctps)
 	if err != nil {
@@ -147,6 +237,14 @@ func (r *PromotionStrategyReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to get requeue duration for PromotionStrategy %q: %w", ps.Name, err)
 	}
+	gitRepoKey := client.ObjectKey{Namespace: ps.Namespace, Name: ps.Spec.RepositoryReference.Name}
+	requeueDuration, ps.Status.Polling, err = adaptiveRequeue(ctx, r.Client, r.SettingsMgr, r.WebhookDeliveries, gitRepoKey, requeueDuration)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if autoRevertRequeue > 0 && autoRevertRequeue < requeueDuration {
+		requeueDuration = autoRevertRequeue
+	}
 
 	return ctrl.Result{
 		Requeue:      true,
@@ -176,22 +274,69 @@ func (r *PromotionStrategyReconciler) SetupWithManager(ctx context.Context, mgr
 		return fmt.Errorf("failed to get PromotionStrategy max concurrent reconciles: %w", err)
 	}
 
+	// Create a channel for external enqueue requests, like the one of the ChangeTransferPolicy controller.
+	externalEnqueueChan := make(chan event.GenericEvent, 1024)
+	r.enqueueFunc = func(namespace, name string) {
+		ps := &promoterv1alpha1.PromotionStrategy{}
+		ps.SetNamespace(namespace)
+		ps.SetName(name)
+
+		select {
+		case externalEnqueueChan <- event.GenericEvent{Object: ps}:
+			// Sent successfully
+		default:
+			// Channel is full, log a warning and block until space is available
+			log.FromContext(ctx).Info("PromotionStrategy enqueue channel is full, blocking until space is available",
+				"namespace", namespace, "name", name)
+			externalEnqueueChan <- event.GenericEvent{Object: ps}
+		}
+	}
+
 	err = ctrl.NewControllerManagedBy(mgr).
-		For(&promoterv1alpha1.PromotionStrategy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Label and annotation changes are propagated to the ChangeTransferPolicies.
+		For(&promoterv1alpha1.PromotionStrategy{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+		))).
 		Owns(&promoterv1alpha1.ChangeTransferPolicy{}).
+		Watches(&promoterv1alpha1.RevertCommit{}, r.enqueuePromotionStrategyForRevertCommit()).
+		// The GitRepository's label and annotation changes are propagated to the ChangeTransferPolicies too.
+		Watches(&promoterv1alpha1.GitRepository{}, r.enqueuePromotionStrategiesForGitRepository(), builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+		))).
+		// Watch for external enqueue requests from the webhook receiver.
+		WatchesRawSource(source.Channel(externalEnqueueChan, &handler.EnqueueRequestForObject{})).
 		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles, RateLimiter: rateLimiter}).
-		Complete(r)
+		Complete(utils.NewDrainingReconciler(utils.NewShardedReconciler(mgr.GetClient(), &promoterv1alpha1.PromotionStrategy{}, tracing.NewReconciler("PromotionStrategy", r))))
 	if err != nil {
 		return fmt.Errorf("failed to create controller: %w", err)
 	}
 	return nil
 }
 
-func (r *PromotionStrategyReconciler) upsertChangeTransferPolicy(ctx context.Context, ps *promoterv1alpha1.PromotionStrategy, environment promoterv1alpha1.Environment) (*promoterv1alpha1.ChangeTransferPolicy, error) {
+// upsertChangeTransferPolicy applies the ChangeTransferPolicy of the environment. When holdAutoMerge is true, auto-merge
+// is disabled regardless of the environment's setting. gitRepo is nil if the GitRepository doesn't exist.
+func (r *PromotionStrategyReconciler) upsertChangeTransferPolicy(ctx context.Context, ps *promoterv1alpha1.PromotionStrategy, gitRepo *promoterv1alpha1.GitRepository, environment promoterv1alpha1.Environment, proposedBranch string, holdAutoMerge bool) (*promoterv1alpha1.ChangeTransferPolicy, error) {
 	logger := log.FromContext(ctx)
 
 	ctpName := utils.KubeSafeUniqueName(ctx, utils.GetChangeTransferPolicyName(ps.Name, environment.Branch))
 
+	// Never move an existing ChangeTransferPolicy to another repository: its pull requests, clone and commit statuses
+	// would be left behind in the old one. The repository of a PromotionStrategy is immutable when the admission
+	// webhooks are enabled, this covers PromotionStrategies that were changed without them.
+	var existingCTP promoterv1alpha1.ChangeTransferPolicy
+	err := r.Get(ctx, client.ObjectKey{Namespace: ps.Namespace, Name: ctpName}, &existingCTP)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get ChangeTransferPolicy %q: %w", ctpName, err)
+	}
+	if err == nil && existingCTP.Spec.RepositoryReference.Name != ps.Spec.RepositoryReference.Name {
+		return nil, fmt.Errorf("ChangeTransferPolicy %q belongs to GitRepository %q, not %q: the repository of a PromotionStrategy can't be changed, create a new PromotionStrategy instead",
+			ctpName, existingCTP.Spec.RepositoryReference.Name, ps.Spec.RepositoryReference.Name)
+	}
+
 	// Build owner reference
 	kind := reflect.TypeOf(promoterv1alpha1.PromotionStrategy{}).Name()
 	gvk := promoterv1alpha1.GroupVersion.WithKind(kind)
@@ -234,7 +379,7 @@ func (r *PromotionStrategyReconciler) upsertChangeTransferPolicy(ctx context.Con
 	// Build the spec
 	ctpSpec := acv1alpha1.ChangeTransferPolicySpec().
 		WithRepositoryReference(acv1alpha1.ObjectReference().WithName(ps.Spec.RepositoryReference.Name)).
-		WithProposedBranch(fmt.Sprintf("%s-%s", environment.Branch, "next")).
+		WithProposedBranch(proposedBranch).
 		WithActiveBranch(environment.Branch).
 		WithActiveCommitStatuses(activeCommitStatuses...).
 		WithProposedCommitStatuses(proposedCommitStatuses...)
@@ -242,13 +387,46 @@ func (r *PromotionStrategyReconciler) upsertChangeTransferPolicy(ctx context.Con
 	if environment.AutoMerge != nil {
 		ctpSpec = ctpSpec.WithAutoMerge(*environment.AutoMerge)
 	}
+	if holdAutoMerge {
+		// The active branch was reverted directly, merging the proposed branch would promote the reverted change again.
+		ctpSpec = ctpSpec.WithAutoMerge(false)
+	}
+
+	if environment.ReconcileInterval != nil {
+		ctpSpec = ctpSpec.WithReconcileInterval(*environment.ReconcileInterval)
+	}
+
+	if environment.ResolveDivergence != "" {
+		ctpSpec = ctpSpec.WithResolveDivergence(environment.ResolveDivergence)
+	}
+
+	if pullRequest := ps.GetPullRequestTemplate(environment); pullRequest != nil {
+		ctpSpec = ctpSpec.WithPullRequest(pullRequestTemplateApplyConfiguration(pullRequest))
+	}
+
+	// Propagate the GitRepository's and the PromotionStrategy's labels and annotations, the PromotionStrategy's taking
+	// precedence. The ChangeTransferPolicy propagates all of them further to its PullRequests and CommitStatuses.
+	ctpLabels, ctpAnnotations := map[string]string{}, map[string]string{}
+	propagateKeys := slices.Clone(ps.Spec.PropagateLabels)
+	if gitRepo != nil {
+		ctpLabels, ctpAnnotations = utils.PropagatedMetadata(gitRepo, gitRepo.Spec.PropagateLabels)
+		propagateKeys = append(propagateKeys, gitRepo.Spec.PropagateLabels...)
+	}
+	psLabels, psAnnotations := utils.PropagatedMetadata(ps, ps.Spec.PropagateLabels)
+	maps.Copy(ctpLabels, psLabels)
+	maps.Copy(ctpAnnotations, psAnnotations)
+	maps.Copy(ctpLabels, map[string]string{
+		promoterv1alpha1.PromotionStrategyLabel:    utils.KubeSafeLabel(ps.Name),
+		promoterv1alpha1.PromotionStrategyUIDLabel: string(ps.UID),
+		promoterv1alpha1.EnvironmentLabel:          utils.KubeSafeLabel(environment.Branch),
+	})
+	slices.Sort(propagateKeys)
+	ctpSpec = ctpSpec.WithPropagateLabels(slices.Compact(propagateKeys)...)
 
 	// Build the apply configuration
 	ctpApply := acv1alpha1.ChangeTransferPolicy(ctpName, ps.Namespace).
-		WithLabels(map[string]string{
-			promoterv1alpha1.PromotionStrategyLabel: utils.KubeSafeLabel(ps.Name),
-			promoterv1alpha1.EnvironmentLabel:       utils.KubeSafeLabel(environment.Branch),
-		}).
+		WithLabels(ctpLabels).
+		WithAnnotations(ctpAnnotations).
 		WithOwnerReferences(acmetav1.OwnerReference().
 			WithAPIVersion(gvk.GroupVersion().String()).
 			WithKind(gvk.Kind).
@@ -271,6 +449,177 @@ func (r *PromotionStrategyReconciler) upsertChangeTransferPolicy(ctx context.Con
 	return ctp, nil
 }
 
+// pullRequestTemplateApplyConfiguration returns the apply configuration of the pull request template, leaving out the
+// fields that aren't set.
+func pullRequestTemplateApplyConfiguration(pullRequest *promoterv1alpha1.PullRequestTemplateSpec) *acv1alpha1.PullRequestTemplateSpecApplyConfiguration {
+	apply := acv1alpha1.PullRequestTemplateSpec().
+		WithLabels(pullRequest.Labels...).
+		WithReviewers(pullRequest.Reviewers...)
+	if pullRequest.TitlePrefix != "" {
+		apply = apply.WithTitlePrefix(pullRequest.TitlePrefix)
+	}
+	if pullRequest.Title != "" {
+		apply = apply.WithTitle(pullRequest.Title)
+	}
+	if pullRequest.Description != "" {
+		apply = apply.WithDescription(pullRequest.Description)
+	}
+	if pullRequest.MergeStrategy != "" {
+		apply = apply.WithMergeStrategy(pullRequest.MergeStrategy)
+	}
+	if pullRequest.Draft != nil {
+		apply = apply.WithDraft(*pullRequest.Draft)
+	}
+	return apply
+}
+
+// emergencyReverts returns the emergency reverts of the PromotionStrategy's environments, keyed by active branch. An
+// environment has an emergency revert from the moment a RevertCommit with target hydrated reverted a commit for it
+// until the RevertCommit is deleted or a different dry commit is proposed for the environment. It doesn't depend on the
+// phase of the revert pull request: one merged on the SCM may well be recorded as closed. The newest RevertCommit wins
+// if there are several.
+func (r *PromotionStrategyReconciler) emergencyReverts(ctx context.Context, ps *promoterv1alpha1.PromotionStrategy) (map[string]*promoterv1alpha1.EmergencyRevertStatus, error) {
+	var rcList promoterv1alpha1.RevertCommitList
+	if err := r.List(ctx, &rcList, client.InNamespace(ps.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list RevertCommits: %w", err)
+	}
+	slices.SortFunc(rcList.Items, func(a, b promoterv1alpha1.RevertCommit) int {
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	})
+
+	emergencyReverts := map[string]*promoterv1alpha1.EmergencyRevertStatus{}
+	for _, rc := range rcList.Items {
+		if rc.Spec.PromotionStrategyRef.Name != ps.Name || rc.Spec.Target != promoterv1alpha1.RevertTargetHydrated || len(rc.Spec.Environments) != 1 {
+			continue
+		}
+		if rc.Status.ObservedGeneration != rc.Generation || rc.Status.RevertSha == "" || !rc.DeletionTimestamp.IsZero() {
+			continue
+		}
+		branch := rc.Spec.Environments[0]
+
+		var ctp promoterv1alpha1.ChangeTransferPolicy
+		ctpName := utils.KubeSafeUniqueName(ctx, utils.GetChangeTransferPolicyName(ps.Name, branch))
+		if err := r.Get(ctx, client.ObjectKey{Namespace: ps.Namespace, Name: ctpName}, &ctp); err != nil && !k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get ChangeTransferPolicy %q: %w", ctpName, err)
+		}
+		if ctp.Status.Proposed.Dry.Sha != rc.Status.DrySha {
+			// A different dry commit was hydrated since, the hydrator has caught up with the revert.
+			continue
+		}
+		emergencyReverts[branch] = &promoterv1alpha1.EmergencyRevertStatus{
+			RevertCommit: rc.Name,
+			HydratedSha:  rc.Spec.Sha,
+			DrySha:       rc.Status.DrySha,
+		}
+	}
+	return emergencyReverts, nil
+}
+
+// setEmergencyReverts records the emergency revert of each environment in its status, and emits an event for each new
+// one.
+func (r *PromotionStrategyReconciler) setEmergencyReverts(ps *promoterv1alpha1.PromotionStrategy, emergencyReverts map[string]*promoterv1alpha1.EmergencyRevertStatus) {
+	for i := range ps.Status.Environments {
+		envStatus := &ps.Status.Environments[i]
+		emergencyRevert := emergencyReverts[envStatus.Branch]
+		if emergencyRevert != nil && (envStatus.EmergencyRevert == nil || envStatus.EmergencyRevert.RevertCommit != emergencyRevert.RevertCommit) {
+			r.Recorder.Eventf(ps, nil, "Warning", constants.EmergencyRevertDivergedReason, "HoldingAutoMerge", constants.EmergencyRevertDivergedMessage, emergencyRevert.RevertCommit, emergencyRevert.HydratedSha, envStatus.Branch, emergencyRevert.DrySha)
+		}
+		envStatus.EmergencyRevert = emergencyRevert
+	}
+}
+
+// enqueuePromotionStrategyForRevertCommit returns a handler that enqueues the PromotionStrategy of a RevertCommit with
+// target hydrated when the RevertCommit changes, so that its emergency revert is recorded.
+func (r *PromotionStrategyReconciler) enqueuePromotionStrategyForRevertCommit() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []ctrl.Request {
+		rc, ok := obj.(*promoterv1alpha1.RevertCommit)
+		if !ok || rc.Spec.Target != promoterv1alpha1.RevertTargetHydrated {
+			return nil
+		}
+		return []ctrl.Request{{NamespacedName: client.ObjectKey{Namespace: rc.Namespace, Name: rc.Spec.PromotionStrategyRef.Name}}}
+	})
+}
+
+// enqueuePromotionStrategiesForGitRepository returns a handler that enqueues the PromotionStrategies of a
+// GitRepository when it changes, so that its labels and annotations are propagated without waiting for a requeue.
+func (r *PromotionStrategyReconciler) enqueuePromotionStrategiesForGitRepository() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []ctrl.Request {
+		var psList promoterv1alpha1.PromotionStrategyList
+		if err := r.List(ctx, &psList, client.InNamespace(obj.GetNamespace())); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list PromotionStrategy resources")
+			return nil
+		}
+
+		var requests []ctrl.Request
+		for _, ps := range psList.Items {
+			if ps.Spec.RepositoryReference.Name == obj.GetName() {
+				requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&ps)})
+			}
+		}
+		return requests
+	})
+}
+
+// proposedBranchTemplateData is the data a proposed branch template is rendered with.
+type proposedBranchTemplateData struct {
+	// Branch is the environment's active branch.
+	Branch string
+}
+
+// renderProposedBranches renders the proposed branch name of every environment, in the same order as
+// ps.Spec.Environments. It returns an error if a template can't be rendered into a usable branch name, or if two
+// environments would share a branch.
+func renderProposedBranches(ps *promoterv1alpha1.PromotionStrategy) ([]string, error) {
+	activeBranches := make(map[string]bool, len(ps.Spec.Environments))
+	for _, environment := range ps.Spec.Environments {
+		activeBranches[environment.Branch] = true
+	}
+
+	proposedBranches := make([]string, len(ps.Spec.Environments))
+	renderedBy := make(map[string]string, len(ps.Spec.Environments))
+	for i, environment := range ps.Spec.Environments {
+		proposedBranch, err := utils.RenderStringTemplate(ps.GetProposedBranchTemplate(environment), proposedBranchTemplateData{Branch: environment.Branch}, "missingkey=error")
+		if err != nil {
+			return nil, fmt.Errorf("failed to render proposed branch template for environment %q: %w", environment.Branch, err)
+		}
+		proposedBranch = strings.TrimSpace(proposedBranch)
+		if proposedBranch == "" || strings.ContainsAny(proposedBranch, " \t\n~^:?*[\\") {
+			return nil, fmt.Errorf("proposed branch template for environment %q rendered invalid branch name %q", environment.Branch, proposedBranch)
+		}
+		if activeBranches[proposedBranch] {
+			return nil, fmt.Errorf("proposed branch %q of environment %q is the active branch of an environment", proposedBranch, environment.Branch)
+		}
+		if other, found := renderedBy[proposedBranch]; found {
+			return nil, fmt.Errorf("environments %q and %q both use proposed branch %q", other, environment.Branch, proposedBranch)
+		}
+		renderedBy[proposedBranch] = environment.Branch
+		proposedBranches[i] = proposedBranch
+	}
+	return proposedBranches, nil
+}
+
+// checkProposedBranchesUnchanged returns an error if an existing ChangeTransferPolicy uses a different proposed branch
+// than the one rendered for its environment. Switching in place would orphan the open pull request and the hydrator's
+// output on the old branch, so the user has to delete the ChangeTransferPolicy to migrate it to the new branch.
+func (r *PromotionStrategyReconciler) checkProposedBranchesUnchanged(ctx context.Context, ps *promoterv1alpha1.PromotionStrategy, proposedBranches []string) error {
+	for i, environment := range ps.Spec.Environments {
+		var ctp promoterv1alpha1.ChangeTransferPolicy
+		ctpName := utils.KubeSafeUniqueName(ctx, utils.GetChangeTransferPolicyName(ps.Name, environment.Branch))
+		err := r.Get(ctx, client.ObjectKey{Namespace: ps.Namespace, Name: ctpName}, &ctp)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get ChangeTransferPolicy %q: %w", ctpName, err)
+		}
+		if ctp.Spec.ProposedBranch != "" && ctp.Spec.ProposedBranch != proposedBranches[i] {
+			return fmt.Errorf("environment %q uses proposed branch %q but the template now renders %q, delete ChangeTransferPolicy %q to switch to the new branch",
+				environment.Branch, ctp.Spec.ProposedBranch, proposedBranches[i], ctpName)
+		}
+	}
+	return nil
+}
+
 // cleanupOrphanedChangeTransferPolicies deletes ChangeTransferPolicies that are owned by this PromotionStrategy
 // but are not in the current list of valid CTPs (i.e., they correspond to removed or renamed environments).
 //
@@ -331,7 +680,8 @@ func (r *PromotionStrategyReconciler) cleanupOrphanedChangeTransferPolicies(ctx
 
 // calculateStatus calculates the status of the PromotionStrategy based on the ChangeTransferPolicies.
 // ps.Spec.Environments must be the same length and in the same order as ctps.
-// This function updates ps.Status.Environments to be the same length and order as ps.Spec.Environments.
+// This function updates ps.Status.Environments to be the same length and order as ps.Spec.Environments, and sets the
+// active dry sha gauges of the environments.
 func (r *PromotionStrategyReconciler) calculateStatus(ps *promoterv1alpha1.PromotionStrategy, ctps []*promoterv1alpha1.ChangeTransferPolicy) {
 	// Reconstruct current environment state based on ps.Environments order. Dropped environments will effectively be
 	// deleted, and new environments will be added as empty statuses. Those new environments will be populated in the
@@ -353,7 +703,8 @@ func (r *PromotionStrategyReconciler) calculateStatus(ps *promoterv1alpha1.Promo
 		ps.Status.Environments[i].Active = ctp.Status.Active
 		ps.Status.Environments[i].Proposed = ctp.Status.Proposed
 		ps.Status.Environments[i].PullRequest = ctp.Status.PullRequest
-		ps.Status.Environments[i].History = ctp.Status.History
+		ps.Status.Environments[i].EffectivelyPromotedDrySha = ctp.Status.EffectivelyPromotedDrySha
+		ps.Status.Environments[i].History = withHistoryMarks(ctp.Status.History, ps.Status.Environments[i].History)
 
 		// TODO: actually implement keeping track of healthy dry sha's
 		// We only want to keep the last 10 healthy dry sha's
@@ -363,6 +714,582 @@ func (r *PromotionStrategyReconciler) calculateStatus(ps *promoterv1alpha1.Promo
 	}
 
 	utils.InheritNotReadyConditionFromObjects(ps, promoterConditions.ChangeTransferPolicyNotReady, ctps...)
+
+	setActiveBranchRewrittenCondition(ps, ctps)
+	setStatusSummary(ps)
+
+	activeDryShas := make(map[string]string, len(ps.Status.Environments))
+	for i := range ps.Status.Environments {
+		if envStatus := &ps.Status.Environments[i]; envStatus.Active.Dry.Sha != "" {
+			activeDryShas[envStatus.Branch] = envStatus.Active.DryShaShort()
+		}
+	}
+	metrics.SetPromotionActiveDryShas(ps, activeDryShas)
+}
+
+// setStatusSummary sets the status fields kubectl displays: the active dry commit of every environment, and the
+// state and active dry commit of the last one.
+func setStatusSummary(ps *promoterv1alpha1.PromotionStrategy) {
+	environments := make([]string, 0, len(ps.Status.Environments))
+	for _, envStatus := range ps.Status.Environments {
+		environments = append(environments, envStatus.Branch+"="+envStatus.Active.DryShaShort())
+	}
+	ps.Status.Summary = strings.Join(environments, ", ")
+
+	if len(ps.Status.Environments) == 0 {
+		ps.Status.LastEnvironmentState = ""
+		ps.Status.LastEnvironmentDryShortSha = ""
+		return
+	}
+	last := &ps.Status.Environments[len(ps.Status.Environments)-1]
+	ps.Status.LastEnvironmentDryShortSha = last.Active.DryShaShort()
+	switch {
+	case len(blockingCommitStatusKeys(last)) > 0:
+		ps.Status.LastEnvironmentState = promoterv1alpha1.EnvironmentBlocked
+	case last.Proposed.Dry.Sha != "" && last.Proposed.Dry.Sha != last.Active.Dry.Sha &&
+		last.Proposed.Dry.Sha != last.EffectivelyPromotedDrySha:
+		ps.Status.LastEnvironmentState = promoterv1alpha1.EnvironmentPromoting
+	default:
+		ps.Status.LastEnvironmentState = promoterv1alpha1.EnvironmentPromoted
+	}
+}
+
+// withHistoryMarks returns history with the marks the PromotionStrategy added to the matching entries of
+// previousHistory, which are matched by their proposed hydrated sha. The history of a ChangeTransferPolicy doesn't know
+// about automatic reverts or which promotions were reverts.
+func withHistoryMarks(history, previousHistory []promoterv1alpha1.History) []promoterv1alpha1.History {
+	previousEntries := map[string]promoterv1alpha1.History{}
+	for _, entry := range previousHistory {
+		if (entry.AutoRevert != "" || entry.Kind != "") && entry.Proposed.Hydrated.Sha != "" {
+			previousEntries[entry.Proposed.Hydrated.Sha] = entry
+		}
+	}
+	if len(previousEntries) == 0 {
+		return history
+	}
+
+	marked := slices.Clone(history)
+	for i := range marked {
+		previous, found := previousEntries[marked[i].Proposed.Hydrated.Sha]
+		if marked[i].Proposed.Hydrated.Sha == "" || !found {
+			continue
+		}
+		marked[i].AutoRevert = previous.AutoRevert
+		marked[i].Kind = previous.Kind
+		marked[i].RevertCommitRef = previous.RevertCommitRef
+		marked[i].RevertedSha = previous.RevertedSha
+	}
+	return marked
+}
+
+// markRevertHistory marks the history entries that promoted a revert of a RevertCommit referencing the
+// PromotionStrategy. A revert on the dry branch is recognized by its revert commit or by the Revert-commit trailer of
+// the dry commit, which the revert pull request adds to its merge commit. A revert scoped to environments is recognized
+// by the commit it proposed.
+func (r *PromotionStrategyReconciler) markRevertHistory(ctx context.Context, ps *promoterv1alpha1.PromotionStrategy) error {
+	var rcList promoterv1alpha1.RevertCommitList
+	if err := r.List(ctx, &rcList, client.InNamespace(ps.Namespace)); err != nil {
+		return fmt.Errorf("failed to list RevertCommits: %w", err)
+	}
+
+	for i := range ps.Status.Environments {
+		envStatus := &ps.Status.Environments[i]
+		for j := range envStatus.History {
+			entry := &envStatus.History[j]
+			if entry.Kind != "" {
+				continue
+			}
+			for _, rc := range rcList.Items {
+				if rc.Spec.PromotionStrategyRef.Name != ps.Name || !isRevertHistoryEntry(&rc, envStatus.Branch, entry) {
+					continue
+				}
+				entry.Kind = promoterv1alpha1.HistoryKindRevert
+				entry.RevertCommitRef = &promoterv1alpha1.ObjectReference{Name: rc.Name}
+				entry.RevertedSha = rc.Spec.Sha
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// isRevertHistoryEntry reports whether the history entry of the environment with the given active branch promoted the
+// revert of the RevertCommit.
+func isRevertHistoryEntry(rc *promoterv1alpha1.RevertCommit, branch string, entry *promoterv1alpha1.History) bool {
+	if rc.Spec.Target == promoterv1alpha1.RevertTargetHydrated {
+		// An emergency revert is merged straight into the active branch, it is never promoted.
+		return false
+	}
+	if len(rc.Spec.Environments) > 0 {
+		for _, environment := range rc.Status.Environments {
+			if environment.Branch == branch && environment.ProposedSha != "" && environment.ProposedSha == entry.Proposed.Hydrated.Sha {
+				return true
+			}
+		}
+		return false
+	}
+	if rc.Status.RevertSha != "" && entry.Active.Dry.Sha == rc.Status.RevertSha {
+		return true
+	}
+	trailer := constants.TrailerRevertCommit + ": " + rc.Name
+	for line := range strings.Lines(entry.Active.Dry.Body) {
+		if strings.TrimSpace(line) == trailer {
+			return true
+		}
+	}
+	return false
+}
+
+// autoRevertFailedPromotions reverts the dry commit active in each environment with autoRevert enabled, if an active
+// commit status started failing within autoRevert.within of its promotion and is still failing after
+// autoRevert.debounce. The revert is done by a RevertCommit scoped to the environment, so it is proposed and has to pass
+// the environment's proposed commit statuses like any other change. It returns how long until the next running
+// debounce period ends, or zero if there is none.
+func (r *PromotionStrategyReconciler) autoRevertFailedPromotions(ctx context.Context, ps *promoterv1alpha1.PromotionStrategy) (time.Duration, error) {
+	logger := log.FromContext(ctx)
+
+	var requeueAfter time.Duration
+	for i, environment := range ps.Spec.Environments {
+		envStatus := &ps.Status.Environments[i]
+		drySha := envStatus.Active.Dry.Sha
+		if environment.AutoRevert == nil || !environment.AutoRevert.Enabled || drySha == "" {
+			envStatus.AutoRevert = nil
+			continue
+		}
+		if envStatus.AutoRevert == nil || envStatus.AutoRevert.DrySha != drySha {
+			envStatus.AutoRevert = &promoterv1alpha1.AutoRevertStatus{DrySha: drySha}
+		}
+		autoRevert := envStatus.AutoRevert
+		if autoRevert.RevertCommit != "" {
+			// At most one automatic revert per dry commit.
+			markAutoRevertedPromotion(envStatus)
+			continue
+		}
+
+		failing := failingCommitStatusKeys(envStatus.Active.CommitStatuses)
+		if len(failing) == 0 {
+			autoRevert.FailingSince = nil
+			continue
+		}
+
+		debounce := environment.AutoRevert.Debounce.Duration
+		now := metav1.Now()
+		if autoRevert.FailingSince == nil {
+			promotedAt := promotionTime(envStatus.History, drySha)
+			if promotedAt.IsZero() || now.Sub(promotedAt.Time) > environment.AutoRevert.Within.Duration {
+				// Only failures that start shortly after a promotion done by the controller lead to a revert.
+				continue
+			}
+			autoRevert.FailingSince = &now
+
+			skipReason, err := r.autoRevertSkipReason(ctx, ps, envStatus)
+			if err != nil {
+				return 0, err
+			}
+			if skipReason != "" {
+				logger.Info("Not reverting failed promotion", "environment", envStatus.Branch, "drySha", drySha, "reason", skipReason)
+				r.Recorder.Eventf(ps, nil, "Warning", constants.AutoRevertSkippedReason, "RevertingPromotion", constants.AutoRevertSkippedMessage, drySha, envStatus.Branch, skipReason)
+				continue
+			}
+			r.Recorder.Eventf(ps, nil, "Warning", constants.ActiveCommitStatusFailingReason, "RevertingPromotion", constants.ActiveCommitStatusFailingMessage, strings.Join(failing, ", "), envStatus.Branch, drySha, debounce)
+		}
+
+		if remaining := debounce - now.Sub(autoRevert.FailingSince.Time); remaining > 0 {
+			if requeueAfter == 0 || remaining < requeueAfter {
+				requeueAfter = remaining
+			}
+			continue
+		}
+
+		skipReason, err := r.autoRevertSkipReason(ctx, ps, envStatus)
+		if err != nil {
+			return 0, err
+		}
+		if skipReason != "" {
+			// Already reported when the failure was first seen.
+			continue
+		}
+
+		rcName, err := r.createAutoRevertCommit(ctx, ps, environment, drySha)
+		if err != nil {
+			return 0, err
+		}
+		autoRevert.RevertCommit = rcName
+		markAutoRevertedPromotion(envStatus)
+
+		logger.Info("Reverting failed promotion", "environment", envStatus.Branch, "drySha", drySha, "revertCommit", rcName, "failingCommitStatuses", failing)
+		r.Recorder.Eventf(ps, nil, "Normal", constants.AutoRevertCreatedReason, "RevertingPromotion", constants.AutoRevertCreatedMessage, rcName, drySha, envStatus.Branch, strings.Join(failing, ", "))
+	}
+
+	return requeueAfter, nil
+}
+
+// failingCommitStatusKeys returns the keys of the commit statuses in the failure phase.
+func failingCommitStatusKeys(commitStatuses []promoterv1alpha1.ChangeRequestPolicyCommitStatusPhase) []string {
+	var keys []string
+	for _, cs := range commitStatuses {
+		if cs.Phase == string(promoterv1alpha1.CommitPhaseFailure) {
+			keys = append(keys, cs.Key)
+		}
+	}
+	return keys
+}
+
+// blockingCommitStatusKeys returns the keys of the failing commit statuses of the change proposed for the environment,
+// or nil if no change is proposed.
+func blockingCommitStatusKeys(envStatus *promoterv1alpha1.EnvironmentStatus) []string {
+	if envStatus.Proposed.Dry.Sha == "" || envStatus.Proposed.Dry.Sha == envStatus.Active.Dry.Sha {
+		return nil
+	}
+	return failingCommitStatusKeys(envStatus.Proposed.CommitStatuses)
+}
+
+// emitBlockedPromotions records an event and emits a CloudEvent for each environment whose proposed change became
+// blocked by a failing commit status since previousEnvironments, the environment statuses before they were calculated.
+func (r *PromotionStrategyReconciler) emitBlockedPromotions(ctx context.Context, ps *promoterv1alpha1.PromotionStrategy, previousEnvironments []promoterv1alpha1.EnvironmentStatus) {
+	for i := range ps.Status.Environments {
+		envStatus := &ps.Status.Environments[i]
+		failing := blockingCommitStatusKeys(envStatus)
+		if len(failing) == 0 {
+			continue
+		}
+		previous := slices.IndexFunc(previousEnvironments, func(previous promoterv1alpha1.EnvironmentStatus) bool {
+			return previous.Branch == envStatus.Branch
+		})
+		if previous >= 0 && previousEnvironments[previous].Proposed.Dry.Sha == envStatus.Proposed.Dry.Sha &&
+			len(blockingCommitStatusKeys(&previousEnvironments[previous])) > 0 {
+			// Already blocked when the status was last calculated.
+			continue
+		}
+		r.Recorder.Eventf(ps, nil, "Warning", constants.PromotionBlockedReason, "EvaluatingPromotion", constants.PromotionBlockedMessage,
+			envStatus.Proposed.DryShaShort(), envStatus.Branch, strings.Join(failing, ", "))
+		data := cloudevents.Data{
+			Namespace:             ps.Namespace,
+			PromotionStrategy:     ps.Name,
+			Environment:           envStatus.Branch,
+			DrySha:                envStatus.Proposed.Dry.Sha,
+			HydratedSha:           envStatus.Proposed.Hydrated.Sha,
+			FailingCommitStatuses: failing,
+		}
+		if envStatus.PullRequest != nil {
+			data.PullRequestURL = envStatus.PullRequest.Url
+		}
+		r.CloudEvents.Emit(ctx, cloudevents.Event{Type: cloudevents.TypePromotionBlocked, Data: data})
+	}
+}
+
+// recordMergedPullRequests records how long the pull requests that were merged since previousEnvironments, the
+// environment statuses before they were calculated, had been open. A pull request was merged when the dry commit it
+// proposed became active. This also counts pull requests merged outside the controller, and only observes each merge
+// once, since the persisted status already shows it after a restart.
+func recordMergedPullRequests(ps *promoterv1alpha1.PromotionStrategy, previousEnvironments []promoterv1alpha1.EnvironmentStatus) {
+	for i := range ps.Status.Environments {
+		envStatus := &ps.Status.Environments[i]
+		previousStatus := mergedPullRequest(envStatus, previousEnvironments)
+		if previousStatus == nil {
+			continue
+		}
+
+		mergedAt := promotionTime(envStatus.History, envStatus.Active.Dry.Sha)
+		if mergedAt.IsZero() {
+			// Pull requests merged outside the controller have no merge time trailer.
+			mergedAt = metav1.Now()
+		}
+		metrics.RecordPullRequestOpenDuration(ps, envStatus.Branch, mergedAt.Sub(previousStatus.PullRequest.PRCreationTime.Time))
+	}
+}
+
+// auditMergedPullRequests records the pull requests that were merged since previousEnvironments, the environment
+// statuses before they were calculated, in the audit trail, with the commit statuses that gated them. A merge to an
+// environment that doesn't merge automatically is recorded as a consumed manual approval.
+func (r *PromotionStrategyReconciler) auditMergedPullRequests(ctx context.Context, ps *promoterv1alpha1.PromotionStrategy, previousEnvironments []promoterv1alpha1.EnvironmentStatus) {
+	for i := range ps.Status.Environments {
+		envStatus := &ps.Status.Environments[i]
+		previousStatus := mergedPullRequest(envStatus, previousEnvironments)
+		if previousStatus == nil {
+			continue
+		}
+
+		record := audit.Record{
+			Type:                    audit.TypePromotionMerged,
+			Namespace:               ps.Namespace,
+			PromotionStrategy:       ps.Name,
+			Environment:             envStatus.Branch,
+			DrySha:                  previousStatus.Proposed.Dry.Sha,
+			HydratedSha:             previousStatus.Proposed.Hydrated.Sha,
+			PullRequestURL:          previousStatus.PullRequest.Url,
+			PullRequestCreationTime: ptr.To(previousStatus.PullRequest.PRCreationTime.Time),
+			Actor:                   audit.ActorPromoter,
+		}
+		if !ptr.Deref(ps.Spec.Environments[i].AutoMerge, true) {
+			record.Type = audit.TypeManualApprovalConsumed
+		}
+		if mergedAt := promotionTime(envStatus.History, envStatus.Active.Dry.Sha); mergedAt.IsZero() {
+			// Pull requests merged outside the controller have no merge time trailer.
+			record.Actor = audit.ActorSCM
+		} else {
+			record.MergeTime = ptr.To(mergedAt.Time)
+		}
+		for _, cs := range previousStatus.Proposed.CommitStatuses {
+			record.Gates = append(record.Gates, audit.Gate{Key: cs.Key, Phase: cs.Phase})
+		}
+		r.Audit.Record(ctx, record)
+	}
+}
+
+// mergedPullRequest returns the status of the environment in previousEnvironments, the environment statuses before
+// they were calculated, if the pull request it proposed was merged since, or nil. A pull request was merged when the
+// dry commit it proposed became active.
+func mergedPullRequest(envStatus *promoterv1alpha1.EnvironmentStatus, previousEnvironments []promoterv1alpha1.EnvironmentStatus) *promoterv1alpha1.EnvironmentStatus {
+	previous := slices.IndexFunc(previousEnvironments, func(previous promoterv1alpha1.EnvironmentStatus) bool {
+		return previous.Branch == envStatus.Branch
+	})
+	if previous < 0 {
+		return nil
+	}
+	previousStatus := &previousEnvironments[previous]
+	if previousStatus.PullRequest == nil || previousStatus.PullRequest.PRCreationTime.IsZero() ||
+		previousStatus.PullRequest.State == promoterv1alpha1.PullRequestClosed {
+		return nil
+	}
+	if previousStatus.Proposed.Dry.Sha == "" || previousStatus.Proposed.Dry.Sha == previousStatus.Active.Dry.Sha ||
+		envStatus.Active.Dry.Sha != previousStatus.Proposed.Dry.Sha {
+		return nil
+	}
+	return previousStatus
+}
+
+// recordPromotionGating records the environments that are blocked, those whose proposed change became blocked since
+// previousEnvironments, the environment statuses before they were calculated, and the number of promotion pull
+// requests that are open.
+func recordPromotionGating(ps *promoterv1alpha1.PromotionStrategy, previousEnvironments []promoterv1alpha1.EnvironmentStatus) {
+	openPullRequests := 0
+	blockedEnvironments := map[string]metrics.PromotionBlockedReason{}
+	for i := range ps.Status.Environments {
+		envStatus := &ps.Status.Environments[i]
+		if envStatus.PullRequest != nil && envStatus.PullRequest.State == promoterv1alpha1.PullRequestOpen {
+			openPullRequests++
+		}
+
+		reason, blocked := promotionBlockedReason(ps.Spec.Environments[i], envStatus)
+		if !blocked {
+			continue
+		}
+		blockedEnvironments[envStatus.Branch] = reason
+		previous := slices.IndexFunc(previousEnvironments, func(previous promoterv1alpha1.EnvironmentStatus) bool {
+			return previous.Branch == envStatus.Branch
+		})
+		if previous >= 0 && previousEnvironments[previous].Proposed.Dry.Sha == envStatus.Proposed.Dry.Sha {
+			if previousReason, previousBlocked := promotionBlockedReason(ps.Spec.Environments[i], &previousEnvironments[previous]); previousBlocked && previousReason == reason {
+				// Already blocked for the same reason when the status was last calculated.
+				continue
+			}
+		}
+		metrics.RecordPromotionBlocked(ps, envStatus.Branch, reason)
+	}
+	metrics.SetPromotionOpenPullRequests(ps, openPullRequests)
+	metrics.SetPromotionBlockedEnvironments(ps, blockedEnvironments, time.Now())
+}
+
+// promotionBlockedReason returns why the change proposed for the environment isn't merged, in the order the
+// ChangeTransferPolicy checks them, or false if no change is proposed or nothing blocks it.
+func promotionBlockedReason(environment promoterv1alpha1.Environment, envStatus *promoterv1alpha1.EnvironmentStatus) (metrics.PromotionBlockedReason, bool) {
+	if envStatus.Proposed.Dry.Sha == "" || envStatus.Proposed.Dry.Sha == envStatus.Active.Dry.Sha ||
+		envStatus.Proposed.Dry.Sha == envStatus.EffectivelyPromotedDrySha {
+		return "", false
+	}
+	for _, cs := range envStatus.Proposed.CommitStatuses {
+		if cs.Key == promoterv1alpha1.PreviousEnvironmentCommitStatusKey && cs.Phase != string(promoterv1alpha1.CommitPhaseSuccess) {
+			return metrics.PromotionBlockedPreviousEnvironment, true
+		}
+	}
+	for _, cs := range envStatus.Proposed.CommitStatuses {
+		if cs.Phase != string(promoterv1alpha1.CommitPhaseSuccess) {
+			return metrics.PromotionBlockedProposedCommitStatus, true
+		}
+	}
+	if envStatus.EmergencyRevert != nil {
+		return metrics.PromotionBlockedEmergencyRevert, true
+	}
+	if !ptr.Deref(environment.AutoMerge, true) {
+		return metrics.PromotionBlockedManualMerge, true
+	}
+	return "", false
+}
+
+// promotionTime returns when the pull request that last promoted drySha to the environment was merged, according to
+// history, or the zero time if history doesn't record it.
+func promotionTime(history []promoterv1alpha1.History, drySha string) metav1.Time {
+	for _, entry := range history {
+		if entry.Active.Dry.Sha == drySha {
+			if entry.PullRequest == nil {
+				return metav1.Time{}
+			}
+			return entry.PullRequest.PRMergeTime
+		}
+	}
+	return metav1.Time{}
+}
+
+// markAutoRevertedPromotion marks the newest history entry of the environment's active dry commit with the
+// RevertCommit that reverts it automatically.
+func markAutoRevertedPromotion(envStatus *promoterv1alpha1.EnvironmentStatus) {
+	for i, entry := range envStatus.History {
+		if entry.Active.Dry.Sha != envStatus.AutoRevert.DrySha {
+			continue
+		}
+		if entry.AutoRevert != envStatus.AutoRevert.RevertCommit {
+			// The history may be shared with the ChangeTransferPolicy.
+			envStatus.History = slices.Clone(envStatus.History)
+			envStatus.History[i].AutoRevert = envStatus.AutoRevert.RevertCommit
+		}
+		return
+	}
+}
+
+// autoRevertSkipReason returns why the dry commit active in the environment must not be reverted automatically, or an
+// empty string if it may be. A revert is never reverted automatically: neither a commit that reverts another commit,
+// nor a dry commit that a RevertCommit restored.
+func (r *PromotionStrategyReconciler) autoRevertSkipReason(ctx context.Context, ps *promoterv1alpha1.PromotionStrategy, envStatus *promoterv1alpha1.EnvironmentStatus) (string, error) {
+	if strings.HasPrefix(envStatus.Active.Dry.Subject, `Revert "`) {
+		return "it reverts another commit", nil
+	}
+
+	var rcList promoterv1alpha1.RevertCommitList
+	if err := r.List(ctx, &rcList, client.InNamespace(ps.Namespace)); err != nil {
+		return "", fmt.Errorf("failed to list RevertCommits: %w", err)
+	}
+	for _, rc := range rcList.Items {
+		if rc.Spec.PromotionStrategyRef.Name != ps.Name {
+			continue
+		}
+		if rc.Status.RevertSha == envStatus.Active.Dry.Sha {
+			return fmt.Sprintf("it was created by RevertCommit %q", rc.Name), nil
+		}
+		for _, environment := range rc.Status.Environments {
+			if environment.Branch == envStatus.Branch && environment.RestoredDrySha == envStatus.Active.Dry.Sha {
+				return fmt.Sprintf("it was restored by RevertCommit %q", rc.Name), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// createAutoRevertCommit creates the RevertCommit that reverts drySha in the environment and returns its name. The
+// name is derived from the environment and drySha, so a dry commit is reverted at most once per environment even if
+// the status of an earlier revert was lost.
+func (r *PromotionStrategyReconciler) createAutoRevertCommit(ctx context.Context, ps *promoterv1alpha1.PromotionStrategy, environment promoterv1alpha1.Environment, drySha string) (string, error) {
+	kind := reflect.TypeOf(promoterv1alpha1.PromotionStrategy{}).Name()
+	gvk := promoterv1alpha1.GroupVersion.WithKind(kind)
+
+	rc := &promoterv1alpha1.RevertCommit{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.KubeSafeUniqueName(ctx, fmt.Sprintf("%s-%s-auto-revert-%s", ps.Name, environment.Branch, drySha)),
+			Namespace: ps.Namespace,
+			Labels: map[string]string{
+				promoterv1alpha1.PromotionStrategyLabel:    utils.KubeSafeLabel(ps.Name),
+				promoterv1alpha1.PromotionStrategyUIDLabel: string(ps.UID),
+				promoterv1alpha1.EnvironmentLabel:          utils.KubeSafeLabel(environment.Branch),
+			},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ps, gvk)},
+		},
+		Spec: promoterv1alpha1.RevertCommitSpec{
+			PromotionStrategyRef: promoterv1alpha1.ObjectReference{Name: ps.Name},
+			DryBranch:            environment.AutoRevert.DryBranch,
+			Sha:                  drySha,
+			Environments:         []string{environment.Branch},
+		},
+	}
+	if err := r.Create(ctx, rc); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return "", fmt.Errorf("failed to create RevertCommit %q: %w", rc.Name, err)
+	}
+	return rc.Name, nil
+}
+
+// setActiveBranchRewrittenCondition records which environments had their active branch history rewritten upstream, as
+// reported by the ActiveBranchRewritten condition of their ChangeTransferPolicies.
+func setActiveBranchRewrittenCondition(ps *promoterv1alpha1.PromotionStrategy, ctps []*promoterv1alpha1.ChangeTransferPolicy) {
+	rewrittenBranches := []string{}
+	for _, ctp := range ctps {
+		if meta.IsStatusConditionTrue(ctp.Status.Conditions, string(promoterConditions.ActiveBranchRewritten)) {
+			rewrittenBranches = append(rewrittenBranches, ctp.Spec.ActiveBranch)
+		}
+	}
+
+	if len(rewrittenBranches) == 0 {
+		meta.RemoveStatusCondition(ps.GetConditions(), string(promoterConditions.ActiveBranchRewritten))
+		return
+	}
+
+	meta.SetStatusCondition(ps.GetConditions(), metav1.Condition{
+		Type:               string(promoterConditions.ActiveBranchRewritten),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(promoterConditions.HistoryRewritten),
+		Message:            "Active branch history was rewritten upstream for environments: " + strings.Join(rewrittenBranches, ", "),
+		ObservedGeneration: ps.Generation,
+	})
+}
+
+// closeSupersededPullRequests closes the open pull requests of environments whose proposed dry commit was reverted or
+// removed upstream, and records SupersededByRevert in the PromotionStrategy status. Pull requests are never closed just
+// because newer dry commits exist, see ChangeTransferPolicyStatus.ProposedDryShaSuperseded.
+func (r *PromotionStrategyReconciler) closeSupersededPullRequests(ctx context.Context, ps *promoterv1alpha1.PromotionStrategy, ctps []*promoterv1alpha1.ChangeTransferPolicy) error {
+	logger := log.FromContext(ctx)
+
+	supersededBranches := []string{}
+	for _, ctp := range ctps {
+		if !ctp.Status.ProposedDryShaSuperseded {
+			continue
+		}
+		supersededBranches = append(supersededBranches, ctp.Spec.ActiveBranch)
+
+		var prList promoterv1alpha1.PullRequestList
+		if err := r.List(ctx, &prList, ctpPullRequestListOptions(ctp)); err != nil {
+			return fmt.Errorf("failed to list PullRequests for ChangeTransferPolicy %q: %w", ctp.Name, err)
+		}
+
+		for _, pr := range prList.Items {
+			// Only open PullRequests are closed: merged and closed are terminal, the admission webhook rejects
+			// changing a PullRequest out of them.
+			if pr.Spec.State != promoterv1alpha1.PullRequestOpen || pr.Status.State != promoterv1alpha1.PullRequestOpen || pr.Status.ID == "" {
+				continue
+			}
+			if err := verifyPullRequestOwnerChain(&pr, ctp); err != nil {
+				logger.Error(err, "Skipping PullRequest with unexpected owner", "pullRequest", pr.Name)
+				continue
+			}
+
+			prApply := acv1alpha1.PullRequest(pr.Name, pr.Namespace).
+				WithSpec(acv1alpha1.PullRequestSpec().WithState(promoterv1alpha1.PullRequestClosed))
+			prObj := &promoterv1alpha1.PullRequest{}
+			prObj.Name = pr.Name
+			prObj.Namespace = pr.Namespace
+			if err := r.Patch(ctx, prObj, utils.ApplyPatch{ApplyConfig: prApply}, client.FieldOwner(constants.PromotionStrategyControllerFieldOwner), client.ForceOwnership); err != nil {
+				return fmt.Errorf("failed to close superseded PullRequest %q: %w", pr.Name, err)
+			}
+
+			logger.Info("Closed pull request, proposed dry sha was reverted upstream",
+				"pullRequest", pr.Name,
+				"activeBranch", ctp.Spec.ActiveBranch,
+				"proposedDrySha", ctp.Status.Proposed.Dry.Sha)
+			r.Recorder.Eventf(ps, nil, "Normal", constants.SupersededByRevertReason, "ClosingPullRequest", constants.SupersededByRevertMessage, pr.Name, ctp.Spec.ActiveBranch, ctp.Status.Proposed.Dry.Sha)
+		}
+	}
+
+	if len(supersededBranches) == 0 {
+		meta.RemoveStatusCondition(ps.GetConditions(), string(promoterConditions.Superseded))
+		return nil
+	}
+
+	meta.SetStatusCondition(ps.GetConditions(), metav1.Condition{
+		Type:               string(promoterConditions.Superseded),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(promoterConditions.SupersededByRevert),
+		Message:            "Proposed dry commit was reverted upstream for environments: " + strings.Join(supersededBranches, ", "),
+		ObservedGeneration: ps.Generation,
+	})
+
+	return nil
 }
 
 // enqueueOutOfSyncCTPs checks if all CTPs have the same effective dry SHA
@@ -551,7 +1478,7 @@ func (r *PromotionStrategyReconciler) handleRateLimitedEnqueue(
 	}
 }
 
-func (r *PromotionStrategyReconciler) createOrUpdatePreviousEnvironmentCommitStatus(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy, phase promoterv1alpha1.CommitStatusPhase, pendingReason string, previousEnvironmentBranch string, previousCRPCSPhases []promoterv1alpha1.ChangeRequestPolicyCommitStatusPhase) (*promoterv1alpha1.CommitStatus, error) {
+func (r *PromotionStrategyReconciler) createOrUpdatePreviousEnvironmentCommitStatus(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy, phase promoterv1alpha1.CommitStatusPhase, pendingReason string, previousEnvironmentBranch string, previousActiveHydratedSha string, previousCRPCSPhases []promoterv1alpha1.ChangeRequestPolicyCommitStatusPhase) (*promoterv1alpha1.CommitStatus, error) {
 	logger := log.FromContext(ctx)
 
 	// TODO: do we like this name proposed-<name>?
@@ -580,14 +1507,16 @@ func (r *PromotionStrategyReconciler) createOrUpdatePreviousEnvironmentCommitSta
 		description = pendingReason
 	}
 
+	csLabels, csAnnotations := utils.PropagatedMetadata(ctp, ctp.Spec.PropagateLabels)
+	csLabels[promoterv1alpha1.CommitStatusLabel] = promoterv1alpha1.PreviousEnvironmentCommitStatusKey
+	csLabels[promoterv1alpha1.PromotionStrategyUIDLabel] = ctp.Labels[promoterv1alpha1.PromotionStrategyUIDLabel]
+	csAnnotations[promoterv1alpha1.CommitStatusPreviousEnvironmentStatusesAnnotation] = string(yamlStatusMap)
+	csAnnotations[promoterv1alpha1.CommitStatusCopyFromShaAnnotation] = previousActiveHydratedSha
+
 	// Build the apply configuration
 	commitStatusApply := acv1alpha1.CommitStatus(csName, ctp.Namespace).
-		WithLabels(map[string]string{
-			promoterv1alpha1.CommitStatusLabel: promoterv1alpha1.PreviousEnvironmentCommitStatusKey,
-		}).
-		WithAnnotations(map[string]string{
-			promoterv1alpha1.CommitStatusPreviousEnvironmentStatusesAnnotation: string(yamlStatusMap),
-		}).
+		WithLabels(csLabels).
+		WithAnnotations(csAnnotations).
 		WithOwnerReferences(acmetav1.OwnerReference().
 			WithAPIVersion(gvk.GroupVersion().String()).
 			WithKind(gvk.Kind).
@@ -624,6 +1553,15 @@ func (r *PromotionStrategyReconciler) updatePreviousEnvironmentCommitStatus(ctx
 	// Go through each environment and copy any commit statuses from the previous environment if the previous environment's running dry commit is the same as the
 	// currently processing environments proposed dry sha.
 	// We then look at the status of the current environment and if all checks have passed and the environment is set to auto merge, we merge the pull request.
+	copies, err := r.previousEnvironmentCommitStatuses(ctx, ps, ctps)
+	if err != nil {
+		return err
+	}
+	var rcList promoterv1alpha1.RevertCommitList
+	if err := r.List(ctx, &rcList, client.InNamespace(ps.Namespace)); err != nil {
+		return fmt.Errorf("failed to list RevertCommits: %w", err)
+	}
+
 	commitStatuses := make([]*promoterv1alpha1.CommitStatus, 0, len(ctps))
 	for i, ctp := range ctps {
 		if i == 0 {
@@ -639,6 +1577,17 @@ func (r *PromotionStrategyReconciler) updatePreviousEnvironmentCommitStatus(ctx
 		previousEnvironmentStatus := ps.Status.Environments[i-1]
 		currentEnvironmentStatus := ps.Status.Environments[i]
 
+		// Skip until the ChangeTransferPolicy has resolved its branch shas, comparing an empty or partial status would
+		// report a phase for the wrong change. Its Ready condition is already inherited by the PromotionStrategy.
+		if !changeTransferPolicyShasResolved(ctp) {
+			logger.V(4).Info("Skipping previous environment commit status update - ChangeTransferPolicy has not resolved its branch shas",
+				"activeBranch", ctp.Spec.ActiveBranch)
+			metrics.RecordPreviousEnvironmentCopy(ps, metrics.PreviousEnvironmentCopySkipped)
+			// The commit status that is left may still gate the proposed change.
+			r.checkPreviousEnvironmentCommitStatusStale(ps, ctp, ctps[i-1], copies[ctp.Name])
+			continue
+		}
+
 		// Skip if there's no proposed change in the current environment (i.e., active and proposed are the same).
 		// In this case, there's no PR to put a commit status on, so we shouldn't create/update one.
 		// This prevents updating commit status on already-merged PRs when the previous environment state changes.
@@ -650,6 +1599,16 @@ func (r *PromotionStrategyReconciler) updatePreviousEnvironmentCommitStatus(ctx
 				"previousEnvironmentActiveDrySha", previousEnvironmentStatus.Active.Dry.Sha,
 				"currentEnvironmentActiveDrySha", ctp.Status.Proposed.Dry.Sha,
 			)
+			metrics.RecordPreviousEnvironmentCopy(ps, metrics.PreviousEnvironmentCopySkipped)
+			continue
+		}
+
+		// Skip if the proposed change doesn't change the current environment's manifests, no pull request is opened for it.
+		if ctp.Status.EffectivelyPromotedDrySha != "" && ctp.Status.EffectivelyPromotedDrySha == ctp.Status.Proposed.Dry.Sha {
+			logger.V(4).Info("Skipping previous environment commit status update - proposed change is effectively promoted",
+				"activeBranch", ctp.Spec.ActiveBranch,
+				"proposedDrySha", ctp.Status.Proposed.Dry.Sha)
+			metrics.RecordPreviousEnvironmentCopy(ps, metrics.PreviousEnvironmentCopySkipped)
 			continue
 		}
 
@@ -670,7 +1629,18 @@ func (r *PromotionStrategyReconciler) updatePreviousEnvironmentCommitStatus(ctx
 		// This handles cases like dev -> staging -> prod where:
 		// - A change affects dev and prod but staging is a no-op
 		// - We need to ensure dev has been hydrated, promoted, AND is healthy before prod can promote
-		isPending, pendingReason := isPreviousEnvironmentPending(precedingEnvStatuses, currentEnvHydratedForDrySha, currentEnvironmentStatus.Active.Dry.CommitTime)
+		var isPending bool
+		var pendingReason string
+		if rcName := environmentRevertRestoring(rcList.Items, ps.Name, ctp.Spec.ActiveBranch, ctp.Status.Proposed.Dry.Sha); rcName != "" {
+			// The preceding environments never promote the restored dry commit again, waiting for them would hold the
+			// revert forever.
+			logger.V(4).Info("Not waiting for the previous environments - the proposed change is an environment revert",
+				"activeBranch", ctp.Spec.ActiveBranch,
+				"proposedDrySha", ctp.Status.Proposed.Dry.Sha,
+				"revertCommit", rcName)
+		} else {
+			isPending, pendingReason = isPreviousEnvironmentPending(precedingEnvStatuses, currentEnvHydratedForDrySha, currentEnvironmentStatus.Active.Dry.CommitTime)
+		}
 
 		commitStatusPhase := promoterv1alpha1.CommitPhaseSuccess
 		if isPending {
@@ -691,18 +1661,103 @@ func (r *PromotionStrategyReconciler) updatePreviousEnvironmentCommitStatus(ctx
 
 		// Since there is at least one configured active check, and since this is not the first environment,
 		// we should not create a commit status for the previous environment.
-		cs, err := r.createOrUpdatePreviousEnvironmentCommitStatus(ctx, ctp, commitStatusPhase, pendingReason, previousEnvironmentStatus.Branch, ctps[i-1].Status.Active.CommitStatuses)
+		cs, err := r.createOrUpdatePreviousEnvironmentCommitStatus(ctx, ctp, commitStatusPhase, pendingReason, previousEnvironmentStatus.Branch, ctps[i-1].Status.Active.Hydrated.Sha, ctps[i-1].Status.Active.CommitStatuses)
 		if err != nil {
 			return fmt.Errorf("failed to create or update previous environment commit status for branch %s: %w", ctp.Spec.ActiveBranch, err)
 		}
+		metrics.RecordPreviousEnvironmentCopy(ps, previousEnvironmentCopyResult(copies[ctp.Name], cs))
+		copies[ctp.Name] = cs
 		commitStatuses = append(commitStatuses, cs)
 	}
 
+	liveCopies := make(map[string]int, len(ctps))
+	for _, ctp := range ctps {
+		liveCopies[ctp.Spec.ActiveBranch] = 0
+		if copies[ctp.Name] != nil {
+			liveCopies[ctp.Spec.ActiveBranch] = 1
+		}
+	}
+	metrics.SetPreviousEnvironmentLiveCopies(ps, liveCopies)
+
 	utils.InheritNotReadyConditionFromObjects(ps, promoterConditions.PreviousEnvironmentCommitStatusNotReady, commitStatuses...)
 
 	return nil
 }
 
+// previousEnvironmentCommitStatuses returns the previous environment commit statuses that exist for the
+// ChangeTransferPolicies, by the name of the ChangeTransferPolicy that owns them.
+func (r *PromotionStrategyReconciler) previousEnvironmentCommitStatuses(ctx context.Context, ps *promoterv1alpha1.PromotionStrategy, ctps []*promoterv1alpha1.ChangeTransferPolicy) (map[string]*promoterv1alpha1.CommitStatus, error) {
+	var commitStatuses promoterv1alpha1.CommitStatusList
+	if err := r.List(ctx, &commitStatuses, client.InNamespace(ps.Namespace), client.MatchingLabels{
+		promoterv1alpha1.CommitStatusLabel: promoterv1alpha1.PreviousEnvironmentCommitStatusKey,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list previous environment CommitStatuses: %w", err)
+	}
+
+	copies := make(map[string]*promoterv1alpha1.CommitStatus, len(ctps))
+	for i := range commitStatuses.Items {
+		cs := &commitStatuses.Items[i]
+		owner := metav1.GetControllerOf(cs)
+		if owner == nil || owner.Kind != "ChangeTransferPolicy" {
+			continue
+		}
+		if slices.ContainsFunc(ctps, func(ctp *promoterv1alpha1.ChangeTransferPolicy) bool { return ctp.UID == owner.UID }) {
+			copies[owner.Name] = cs
+		}
+	}
+	return copies, nil
+}
+
+// previousEnvironmentCopyResult returns what applying the previous environment commit status did, from the commit
+// status before it was applied, or nil if there was none, and after. A commit status from a stale cache can be counted
+// as updated when it was unchanged.
+func previousEnvironmentCopyResult(existing, applied *promoterv1alpha1.CommitStatus) metrics.PreviousEnvironmentCopyResult {
+	switch {
+	case existing == nil:
+		return metrics.PreviousEnvironmentCopyCreated
+	case existing.ResourceVersion != applied.ResourceVersion:
+		return metrics.PreviousEnvironmentCopyUpdated
+	default:
+		return metrics.PreviousEnvironmentCopyUnchanged
+	}
+}
+
+// checkPreviousEnvironmentCommitStatusStale records a Warning event and counts the previous environment commit status
+// cs of the ChangeTransferPolicy as stale if it wasn't updated but still gates the proposed change, and it was copied
+// from an active hydrated sha of previousCTP, the ChangeTransferPolicy of the previous environment, that is no longer
+// active. The environment then gates on the commit statuses of a commit the previous environment no longer runs.
+func (r *PromotionStrategyReconciler) checkPreviousEnvironmentCommitStatusStale(ps *promoterv1alpha1.PromotionStrategy, ctp, previousCTP *promoterv1alpha1.ChangeTransferPolicy, cs *promoterv1alpha1.CommitStatus) {
+	if cs == nil || cs.Spec.Sha != ctp.Status.Proposed.Hydrated.Sha {
+		return
+	}
+	// Commit statuses copied before the sha was recorded can't be told to be stale.
+	copyFromSha := cs.Annotations[promoterv1alpha1.CommitStatusCopyFromShaAnnotation]
+	previousActiveHydratedSha := previousCTP.Status.Active.Hydrated.Sha
+	if copyFromSha == "" || previousActiveHydratedSha == "" || copyFromSha == previousActiveHydratedSha {
+		return
+	}
+	metrics.RecordPreviousEnvironmentCopy(ps, metrics.PreviousEnvironmentCopyStale)
+	r.Recorder.Eventf(ps, nil, "Warning", constants.PreviousEnvironmentCommitStatusStaleReason, "CopyingCommitStatuses",
+		constants.PreviousEnvironmentCommitStatusStaleMessage, cs.Name, ctp.Spec.ActiveBranch, copyFromSha,
+		previousCTP.Spec.ActiveBranch, previousActiveHydratedSha)
+}
+
+// changeTransferPolicyShasResolved returns true if the ChangeTransferPolicy's Ready condition shows that its status
+// shas were calculated. A ChangeTransferPolicy that is not Ready for other reasons, such as an unfinished pull request,
+// still has usable shas.
+func changeTransferPolicyShasResolved(ctp *promoterv1alpha1.ChangeTransferPolicy) bool {
+	ready := meta.FindStatusCondition(ctp.Status.Conditions, string(promoterConditions.Ready))
+	if ready == nil {
+		return false
+	}
+	switch promoterConditions.CommonReason(ready.Reason) {
+	case promoterConditions.CloneFailed, promoterConditions.ActiveBranchMissing, promoterConditions.MetadataInvalid, promoterConditions.BranchShasUnresolved:
+		return false
+	default:
+		return true
+	}
+}
+
 // getNoteDrySha safely returns the DrySha from a HydratorMetadata pointer, or empty string if nil.
 func getNoteDrySha(note *promoterv1alpha1.HydratorMetadata) string {
 	if note == nil {
@@ -721,6 +1776,22 @@ func getEffectiveHydratedDrySha(envStatus promoterv1alpha1.EnvironmentStatus) st
 	return envStatus.Proposed.Dry.Sha
 }
 
+// environmentRevertRestoring returns the name of the RevertCommit of the PromotionStrategy named psName that proposed
+// restoring proposedDrySha in the environment with the given active branch, or an empty string if there is none.
+func environmentRevertRestoring(rcs []promoterv1alpha1.RevertCommit, psName, activeBranch, proposedDrySha string) string {
+	for _, rc := range rcs {
+		if rc.Spec.PromotionStrategyRef.Name != psName {
+			continue
+		}
+		for _, environment := range rc.Status.Environments {
+			if environment.Branch == activeBranch && environment.ProposedSha != "" && environment.RestoredDrySha == proposedDrySha {
+				return rc.Name
+			}
+		}
+	}
+	return ""
+}
+
 // isPreviousEnvironmentPending recursively checks preceding environments (from last to first) to verify:
 // 1. The environment has been hydrated for the target dry SHA
 // 2. If the environment has real changes (not a no-op), it has been promoted and is healthy
@@ -761,14 +1832,17 @@ func isPreviousEnvironmentPending(precedingEnvStatuses []promoterv1alpha1.Enviro
 	// Check if this environment is a no-op (git note updated but no new commit).
 	// A no-op is when Note.DrySha differs from Proposed.Dry.Sha - the git note was updated
 	// to a newer dry SHA, but hydrator.metadata still has the old value because no new commit was created.
-	envIsNoOp := envHydratedForDrySha != envProposedDrySha
+	// An environment whose proposed commit has the same tree as its active branch is effectively promoted without a
+	// pull request and is treated like a no-op.
+	envEffectivelyPromoted := envStatus.EffectivelyPromotedDrySha != "" && envStatus.EffectivelyPromotedDrySha == envProposedDrySha
+	envIsNoOp := envHydratedForDrySha != envProposedDrySha || envEffectivelyPromoted
 
 	// Check if this environment has pending changes (PR not yet merged).
 	// This catches the case where:
 	// - Commit 1 changed this env (autoMerge=false, PR not merged)
 	// - Commit 2 did NOT change this env (no-op for commit 2)
 	// - Downstream envs should still wait for commit 1's PR to be merged
-	envHasPendingChanges := envStatus.Active.Dry.Sha != envProposedDrySha
+	envHasPendingChanges := envStatus.Active.Dry.Sha != envProposedDrySha && !envEffectivelyPromoted
 
 	// Only recurse (skip this environment) if it's a no-op AND has no pending changes.
 	// If it's not a no-op OR has pending changes, we need to wait for it.
@@ -17,15 +17,20 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
 	"context"
 	_ "embed"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"path"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/argoproj-labs/gitops-promoter/internal/audit"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/argocd"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -40,8 +45,10 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 )
@@ -49,6 +56,24 @@ import (
 //go:embed testdata/PromotionStrategy.yaml
 var testPromotionStrategyYAML string
 
+//go:embed testdata/GitLabPushHookEvent.json
+var testGitLabPushHookEvent []byte
+
+//go:embed testdata/GitLabPipelineHookEvent.json
+var testGitLabPipelineHookEvent []byte
+
+//go:embed testdata/GitLabJobHookEvent.json
+var testGitLabJobHookEvent []byte
+
+//go:embed testdata/BitbucketCloudPushEvent.json
+var testBitbucketCloudPushEvent []byte
+
+//go:embed testdata/BitbucketCloudCommitStatusUpdatedEvent.json
+var testBitbucketCloudCommitStatusUpdatedEvent []byte
+
+//go:embed testdata/BitbucketDataCenterRefsChangedEvent.json
+var testBitbucketDataCenterRefsChangedEvent []byte
+
 var _ = Describe("PromotionStrategy Controller", func() {
 	var ctx context.Context
 
@@ -242,6 +267,13 @@ var _ = Describe("PromotionStrategy Controller", func() {
 					g.Expect(promotionStrategy.Status.Environments[2].Proposed.Hydrated.Sha).To(Equal(ctpProd.Status.Proposed.Hydrated.Sha))
 					// Success due to PromotionStrategy not having any CommitStatuses configured
 					g.Expect(utils.AreCommitStatusesPassing(promotionStrategy.Status.Environments[2].Proposed.CommitStatuses)).To(BeTrue())
+
+					g.Expect(promotionStrategy.Status.Summary).To(Equal(fmt.Sprintf("%s=%s, %s=%s, %s=%s",
+						testBranchDevelopment, ctpDev.Status.Active.DryShaShort(),
+						testBranchStaging, ctpStaging.Status.Active.DryShaShort(),
+						testBranchProduction, ctpProd.Status.Active.DryShaShort())))
+					g.Expect(promotionStrategy.Status.LastEnvironmentDryShortSha).To(Equal(ctpProd.Status.Active.DryShaShort()))
+					g.Expect(promotionStrategy.Status.LastEnvironmentState).To(Equal(promoterv1alpha1.EnvironmentPromoted))
 				}, constants.EventuallyTimeout).Should(Succeed())
 
 				By("Adding a pending commit")
@@ -257,17 +289,34 @@ var _ = Describe("PromotionStrategy Controller", func() {
 					}, &ctpDev)
 					g.Expect(err).To(Succeed())
 
+					g.Expect(ctpDev.Status.Proposed.Dry.Author).To(Equal(testAuthor()))
 					g.Expect(ctpDev.Status.Proposed.Dry.Subject).To(Equal("this is a change to bump image"))
 					g.Expect(ctpDev.Status.Proposed.Dry.Body).To(Equal("This is the body\nThis is a newline"))
 					g.Expect(ctpDev.Status.Proposed.Dry.References[0].Commit.Subject).To(Equal("This is a fix for an upstream issue"))
 					g.Expect(ctpDev.Status.Proposed.Dry.References[0].Commit.Body).To(Equal("This is a body of the commit"))
 					g.Expect(ctpDev.Status.Proposed.Dry.References[0].Commit.Sha).To(Equal("c4c862564afe56abf8cc8ac683eee3dc8bf96108"))
 
+					g.Expect(ctpDev.Status.Proposed.Hydrated.Author).To(Equal(testIdentity.Name))
 					g.Expect(ctpDev.Status.Proposed.Hydrated.Subject).To(Equal("added pending commit from dry sha"))
 					g.Expect(ctpDev.Status.Proposed.Hydrated.Body).To(ContainSubstring(""))
 
 					g.Expect(ctpDev.Status.Active.Hydrated.Subject).To(ContainSubstring("Promote"))
 					g.Expect(ctpDev.Status.Active.Hydrated.Body).To(ContainSubstring("This PR is promoting the environment"))
+					g.Expect(ctpDev.Status.Active.Hydrated.Body).NotTo(ContainSubstring(constants.TrailerPullRequestID))
+				}, constants.EventuallyTimeout).Should(Succeed())
+
+				By("Checking that the PromotionStrategy status has the same commit metadata as the CTP")
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, typeNamespacedName, promotionStrategy)
+					g.Expect(err).To(Succeed())
+
+					g.Expect(promotionStrategy.Status.Environments[0].Proposed.Dry.Sha).To(Equal(ctpDev.Status.Proposed.Dry.Sha))
+					g.Expect(promotionStrategy.Status.Environments[0].Proposed.Dry.Author).To(Equal(testAuthor()))
+					g.Expect(promotionStrategy.Status.Environments[0].Proposed.Dry.Subject).To(Equal("this is a change to bump image"))
+					g.Expect(promotionStrategy.Status.Environments[0].Proposed.Dry.Body).To(Equal("This is the body\nThis is a newline"))
+					g.Expect(promotionStrategy.Status.Environments[0].Proposed.Dry.References).To(HaveLen(1))
+					g.Expect(promotionStrategy.Status.Environments[0].Proposed.Hydrated.Author).To(Equal(testIdentity.Name))
+					g.Expect(promotionStrategy.Status.Environments[0].Proposed.Hydrated.Subject).To(Equal("added pending commit from dry sha"))
 				}, constants.EventuallyTimeout).Should(Succeed())
 
 				By("Checking that the pull request for the development, staging, and production environments are closed")
@@ -1145,6 +1194,20 @@ var _ = Describe("PromotionStrategy Controller", func() {
 
 			It("should successfully reconcile the resource", func() {
 				// Skip("Skipping test because of flakiness")
+				psLabels := map[string]string{"namespace": typeNamespacedName.Namespace, "promotion_strategy": name}
+				environmentLabels := func(environment int) map[string]string {
+					return map[string]string{"namespace": typeNamespacedName.Namespace, "promotion_strategy": name, "environment": promotionStrategy.Spec.Environments[environment].Branch}
+				}
+				blockedByPreviousEnvironment := func(environment int) float64 {
+					labels := environmentLabels(environment)
+					labels["reason"] = string(metrics.PromotionBlockedPreviousEnvironment)
+					return metricValue("promotion_blocked_total", labels)
+				}
+				stagingBlockedBefore := blockedByPreviousEnvironment(1)
+				prodBlockedBefore := blockedByPreviousEnvironment(2)
+				stagingMergesBefore := metricValue("promotion_merges_total", environmentLabels(1))
+				prodMergesBefore := metricValue("promotion_merges_total", environmentLabels(2))
+
 				By("Checking that all the ChangeTransferPolicies and PRs are created and in their proper state")
 				Eventually(func(g Gomega) {
 					// Make sure ctp's are created and the associated PRs
@@ -1200,6 +1263,21 @@ var _ = Describe("PromotionStrategy Controller", func() {
 					g.Expect(err).To(Succeed())
 				}, constants.EventuallyTimeout).Should(Succeed())
 
+				By("Checking that the pull requests are opened and recorded as events")
+				Eventually(func(g Gomega) {
+					g.Expect(eventReasons(ctx, "ChangeTransferPolicy", ctpStaging.Namespace, ctpStaging.Name)).To(ContainElement(constants.PullRequestCreatedReason))
+					g.Expect(eventReasons(ctx, "PullRequest", pullRequestStaging.Namespace, pullRequestStaging.Name)).To(ContainElement(constants.PullRequestOpenedReason))
+					g.Expect(eventReasons(ctx, "PullRequest", pullRequestProd.Namespace, pullRequestProd.Name)).To(ContainElement(constants.PullRequestOpenedReason))
+				}, constants.EventuallyTimeout).Should(Succeed())
+
+				By("Checking that staging and production are counted as blocked by their previous environment")
+				Eventually(func(g Gomega) {
+					g.Expect(blockedByPreviousEnvironment(1)).To(BeNumerically(">", stagingBlockedBefore))
+					g.Expect(blockedByPreviousEnvironment(2)).To(BeNumerically(">", prodBlockedBefore))
+					g.Expect(metricValue("promotion_environment_blocked", psLabels)).To(BeNumerically(">=", 1))
+					g.Expect(metricValue("promotion_open_pull_requests", psLabels)).To(Equal(2.0))
+				}, constants.EventuallyTimeout).Should(Succeed())
+
 				Eventually(func(g Gomega) {
 					// Check that the ChangeTransferPolicy for development has an active dry shas that match the expected dry sha meaning the git merge/push succeeded
 					err := k8sClient.Get(ctx, types.NamespacedName{
@@ -1305,6 +1383,21 @@ var _ = Describe("PromotionStrategy Controller", func() {
 					g.Expect(err).To(HaveOccurred())
 					g.Expect(errors.IsNotFound(err)).To(BeTrue())
 				}, constants.EventuallyTimeout).Should(Succeed())
+
+				By("Checking that the merges are recorded as events")
+				Eventually(func(g Gomega) {
+					g.Expect(eventReasons(ctx, "ChangeTransferPolicy", ctpStaging.Namespace, ctpStaging.Name)).To(ContainElement(constants.PullRequestMergedReason))
+					g.Expect(eventReasons(ctx, "PullRequest", pullRequestStaging.Namespace, pullRequestStaging.Name)).To(ContainElement(constants.PullRequestMergedReason))
+					g.Expect(eventReasons(ctx, "PullRequest", pullRequestProd.Namespace, pullRequestProd.Name)).To(ContainElement(constants.PullRequestMergedReason))
+				}, constants.EventuallyTimeout).Should(Succeed())
+
+				By("Checking that the merges are counted and no pull request is left open")
+				Expect(metricValue("promotion_merges_total", environmentLabels(1))).To(BeNumerically(">=", stagingMergesBefore+1))
+				Expect(metricValue("promotion_merges_total", environmentLabels(2))).To(BeNumerically(">=", prodMergesBefore+1))
+				Eventually(func(g Gomega) {
+					g.Expect(metricValue("promotion_open_pull_requests", psLabels)).To(BeZero())
+					g.Expect(metricValue("promotion_environment_blocked", psLabels)).To(BeZero())
+				}, constants.EventuallyTimeout).Should(Succeed())
 			})
 		})
 
@@ -1739,11 +1832,11 @@ var _ = Describe("PromotionStrategy Controller", func() {
 					g.Expect(promotionStrategy.Status.Environments[0].Proposed.CommitStatuses[0].Url).To(Equal(proposedCommitStatusDevelopment.Spec.Url))
 
 					for _, environment := range promotionStrategy.Status.Environments {
-						g.Expect(environment.Proposed.Dry.Author).To(Equal("testuser <testmail@test.com>"))
+						g.Expect(environment.Proposed.Dry.Author).To(Equal(testAuthor()))
 						g.Expect(environment.Proposed.Dry.Subject).To(Equal("added fake manifests commit with timestamp"))
 						g.Expect(environment.Proposed.Dry.Body).To(Equal(""))
 
-						g.Expect(environment.Proposed.Hydrated.Author).To(Equal("testuser"))
+						g.Expect(environment.Proposed.Hydrated.Author).To(Equal(testIdentity.Name))
 						g.Expect(environment.Proposed.Hydrated.Subject).To(ContainSubstring("added pending commit from dry sha"))
 						g.Expect(environment.Proposed.Hydrated.Body).To(Equal(""))
 
@@ -1754,7 +1847,7 @@ var _ = Describe("PromotionStrategy Controller", func() {
 						g.Expect(environment.Proposed.Dry.References[0].Commit.Sha).To(Equal("c4c862564afe56abf8cc8ac683eee3dc8bf96108"))
 					}
 
-					g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.Author).To(Equal("testuser <testmail@test.com>"))
+					g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.Author).To(Equal(testAuthor()))
 					g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.Subject).To(Equal("added fake manifests commit with timestamp"))
 					g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.Body).To(Equal(""))
 
@@ -1771,7 +1864,7 @@ var _ = Describe("PromotionStrategy Controller", func() {
 					g.Expect(promotionStrategy.Status.Environments[1].Active.Dry.Author).To(Equal(""))
 					g.Expect(promotionStrategy.Status.Environments[1].Active.Dry.Subject).To(Equal(""))
 					g.Expect(promotionStrategy.Status.Environments[1].Active.Dry.Body).To(Equal(""))
-					g.Expect(promotionStrategy.Status.Environments[1].Active.Hydrated.Author).To(Equal("testuser"))
+					g.Expect(promotionStrategy.Status.Environments[1].Active.Hydrated.Author).To(Equal(testIdentity.Name))
 					g.Expect(promotionStrategy.Status.Environments[1].Active.Hydrated.Subject).To(ContainSubstring("initial empty commit"))
 					g.Expect(promotionStrategy.Status.Environments[1].Active.Hydrated.Body).To(Equal(""))
 				}, constants.EventuallyTimeout).Should(Succeed())
@@ -2754,11 +2847,11 @@ var _ = Describe("PromotionStrategy Controller", func() {
 					g.Expect(promotionStrategy.Status.Environments[0].Proposed.CommitStatuses[0].Url).To(Equal(proposedCommitStatusDevelopment.Spec.Url))
 
 					for _, environment := range promotionStrategy.Status.Environments {
-						g.Expect(environment.Proposed.Dry.Author).To(Equal("testuser <testmail@test.com>"))
+						g.Expect(environment.Proposed.Dry.Author).To(Equal(testAuthor()))
 						g.Expect(environment.Proposed.Dry.Subject).To(Equal("added fake manifests commit with timestamp"))
 						g.Expect(environment.Proposed.Dry.Body).To(Equal(""))
 
-						g.Expect(environment.Proposed.Hydrated.Author).To(Equal("testuser"))
+						g.Expect(environment.Proposed.Hydrated.Author).To(Equal(testIdentity.Name))
 						g.Expect(environment.Proposed.Hydrated.Subject).To(ContainSubstring("added pending commit from dry sha"))
 						g.Expect(environment.Proposed.Hydrated.Body).To(Equal(""))
 
@@ -2769,7 +2862,7 @@ var _ = Describe("PromotionStrategy Controller", func() {
 						g.Expect(environment.Proposed.Dry.References[0].Commit.Sha).To(Equal("c4c862564afe56abf8cc8ac683eee3dc8bf96108"))
 					}
 
-					g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.Author).To(Equal("testuser <testmail@test.com>"))
+					g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.Author).To(Equal(testAuthor()))
 					g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.Subject).To(Equal("added fake manifests commit with timestamp"))
 					g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.Body).To(Equal(""))
 
@@ -2783,7 +2876,7 @@ var _ = Describe("PromotionStrategy Controller", func() {
 					g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.References[0].Commit.RepoURL).To(Equal("https://github.com/upstream/repo"))
 					g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.References[0].Commit.Sha).To(Equal("c4c862564afe56abf8cc8ac683eee3dc8bf96108"))
 
-					g.Expect(promotionStrategy.Status.Environments[1].Active.Dry.Author).To(Equal("testuser <testmail@test.com>"))
+					g.Expect(promotionStrategy.Status.Environments[1].Active.Dry.Author).To(Equal(testAuthor()))
 					g.Expect(promotionStrategy.Status.Environments[1].Active.Dry.Subject).To(Equal("added fake manifests commit with timestamp"))
 					g.Expect(promotionStrategy.Status.Environments[1].Active.Dry.Body).To(Equal(""))
 					g.Expect(promotionStrategy.Status.Environments[1].Active.Hydrated.Author).To(Equal("GitOps Promoter"))
@@ -3163,6 +3256,486 @@ var _ = Describe("PromotionStrategy Bug Tests", func() {
 		})
 	})
 
+	Context("When propagating labels and annotations", func() {
+		var name string
+		var gitRepo *promoterv1alpha1.GitRepository
+		var promotionStrategy *promoterv1alpha1.PromotionStrategy
+		var ctpKey types.NamespacedName
+
+		BeforeEach(func() {
+			var scmSecret *v1.Secret
+			var scmProvider *promoterv1alpha1.ScmProvider
+			name, scmSecret, scmProvider, gitRepo, _, _, promotionStrategy = promotionStrategyResource(ctx, "promotion-strategy-propagate-labels", "default")
+			setupInitialTestGitRepoOnServer(ctx, gitRepo)
+
+			gitRepo.Labels = map[string]string{
+				"cost-center":                     "1234",
+				promoterv1alpha1.EnvironmentLabel: "overwritten",
+			}
+			gitRepo.Spec.PropagateLabels = []string{"cost-center", promoterv1alpha1.EnvironmentLabel}
+			promotionStrategy.Labels = map[string]string{"team": "payments"}
+			promotionStrategy.Annotations = map[string]string{"example.com/owner": "payments@example.com"}
+			promotionStrategy.Spec.PropagateLabels = []string{"team", "example.com/owner"}
+			ctpKey = types.NamespacedName{
+				Name:      utils.KubeSafeUniqueName(ctx, utils.GetChangeTransferPolicyName(name, testBranchDevelopment)),
+				Namespace: "default",
+			}
+
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			Expect(k8sClient.Create(ctx, promotionStrategy)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, promotionStrategy)
+		})
+
+		It("should keep the ChangeTransferPolicies' labels and annotations in sync", func() {
+			By("Checking that the labels and annotations are added")
+			Eventually(func(g Gomega) {
+				var ctp promoterv1alpha1.ChangeTransferPolicy
+				g.Expect(k8sClient.Get(ctx, ctpKey, &ctp)).To(Succeed())
+				g.Expect(ctp.Labels).To(HaveKeyWithValue("team", "payments"))
+				g.Expect(ctp.Labels).To(HaveKeyWithValue("cost-center", "1234"))
+				g.Expect(ctp.Labels).To(HaveKeyWithValue(promoterv1alpha1.EnvironmentLabel, utils.KubeSafeLabel(testBranchDevelopment)))
+				g.Expect(ctp.Annotations).To(HaveKeyWithValue("example.com/owner", "payments@example.com"))
+				g.Expect(ctp.Spec.PropagateLabels).To(Equal([]string{"cost-center", "example.com/owner", promoterv1alpha1.EnvironmentLabel, "team"}))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Changing a propagated label")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, promotionStrategy)).To(Succeed())
+				promotionStrategy.Labels["team"] = "checkout"
+				g.Expect(k8sClient.Update(ctx, promotionStrategy)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Eventually(func(g Gomega) {
+				var ctp promoterv1alpha1.ChangeTransferPolicy
+				g.Expect(k8sClient.Get(ctx, ctpKey, &ctp)).To(Succeed())
+				g.Expect(ctp.Labels).To(HaveKeyWithValue("team", "checkout"))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Removing a propagated label")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, promotionStrategy)).To(Succeed())
+				delete(promotionStrategy.Labels, "team")
+				g.Expect(k8sClient.Update(ctx, promotionStrategy)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Eventually(func(g Gomega) {
+				var ctp promoterv1alpha1.ChangeTransferPolicy
+				g.Expect(k8sClient.Get(ctx, ctpKey, &ctp)).To(Succeed())
+				g.Expect(ctp.Labels).NotTo(HaveKey("team"))
+				g.Expect(ctp.Labels).To(HaveKeyWithValue("cost-center", "1234"))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Changing a propagated label of the GitRepository")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitRepo), gitRepo)).To(Succeed())
+				gitRepo.Labels["cost-center"] = "5678"
+				g.Expect(k8sClient.Update(ctx, gitRepo)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Eventually(func(g Gomega) {
+				var ctp promoterv1alpha1.ChangeTransferPolicy
+				g.Expect(k8sClient.Get(ctx, ctpKey, &ctp)).To(Succeed())
+				g.Expect(ctp.Labels).To(HaveKeyWithValue("cost-center", "5678"))
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+	})
+
+	Context("When configuring the pull requests with a template", func() {
+		var name string
+		var gitRepo *promoterv1alpha1.GitRepository
+		var promotionStrategy *promoterv1alpha1.PromotionStrategy
+
+		BeforeEach(func() {
+			var scmSecret *v1.Secret
+			var scmProvider *promoterv1alpha1.ScmProvider
+			name, scmSecret, scmProvider, gitRepo, _, _, promotionStrategy = promotionStrategyResource(ctx, "promotion-strategy-pr-template", "default")
+			setupInitialTestGitRepoOnServer(ctx, gitRepo)
+
+			// Never passes, so that the pull requests stay open.
+			promotionStrategy.Spec.ProposedCommitStatuses = []promoterv1alpha1.CommitStatusSelector{{Key: "test-validation"}}
+			promotionStrategy.Spec.PullRequest = &promoterv1alpha1.PullRequestTemplateSpec{
+				TitlePrefix: "[promote] ",
+				Labels:      []string{"promotion"},
+				Reviewers:   []string{"alice"},
+			}
+			promotionStrategy.Spec.Environments = []promoterv1alpha1.Environment{
+				{
+					Branch: testBranchDevelopment,
+					PullRequest: &promoterv1alpha1.PullRequestTemplateSpec{
+						TitlePrefix:   "[dev] ",
+						Labels:        []string{"dev", "promotion"},
+						MergeStrategy: promoterv1alpha1.PullRequestMergeStrategySquash,
+					},
+				},
+				{Branch: testBranchStaging},
+			}
+
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, promotionStrategy)
+		})
+
+		It("should merge the environment's template over the PromotionStrategy's and keep the pull requests in sync", func() {
+			gitPath, err := os.MkdirTemp("", "*")
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = os.RemoveAll(gitPath) }()
+			makeChangeAndHydrateRepo(gitPath, gitRepo, "", "")
+			Expect(k8sClient.Create(ctx, promotionStrategy)).To(Succeed())
+
+			getPullRequest := func(g Gomega, branch string) promoterv1alpha1.PullRequest {
+				var ctp promoterv1alpha1.ChangeTransferPolicy
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Name:      utils.KubeSafeUniqueName(ctx, utils.GetChangeTransferPolicyName(name, branch)),
+					Namespace: "default",
+				}, &ctp)).To(Succeed())
+				var pr promoterv1alpha1.PullRequest
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Name:      utils.KubeSafeUniqueName(ctx, utils.GetPullRequestName(gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)),
+					Namespace: "default",
+				}, &pr)).To(Succeed())
+				return pr
+			}
+
+			By("Checking that the environment's values win and the labels and reviewers are combined")
+			Eventually(func(g Gomega) {
+				pr := getPullRequest(g, testBranchDevelopment)
+				g.Expect(pr.Spec.Title).To(HavePrefix("[dev] "))
+				g.Expect(pr.Spec.Labels).To(Equal([]string{"promotion", "dev"}))
+				g.Expect(pr.Spec.Reviewers).To(Equal([]string{"alice"}))
+				g.Expect(pr.Spec.MergeStrategy).To(Equal(promoterv1alpha1.PullRequestMergeStrategySquash))
+
+				pr = getPullRequest(g, testBranchStaging)
+				g.Expect(pr.Spec.Title).To(HavePrefix("[promote] "))
+				g.Expect(pr.Spec.Labels).To(Equal([]string{"promotion"}))
+				g.Expect(pr.Spec.MergeStrategy).To(BeEmpty())
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Changing the PromotionStrategy's template")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, promotionStrategy)).To(Succeed())
+				promotionStrategy.Spec.PullRequest.Title = "Deploy {{ .ChangeTransferPolicy.Spec.ActiveBranch }}"
+				promotionStrategy.Spec.PullRequest.Labels = []string{"promotion", "needs-review"}
+				g.Expect(k8sClient.Update(ctx, promotionStrategy)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Eventually(func(g Gomega) {
+				pr := getPullRequest(g, testBranchDevelopment)
+				g.Expect(pr.Spec.Title).To(Equal("[dev] Deploy " + testBranchDevelopment))
+				g.Expect(pr.Spec.Labels).To(Equal([]string{"promotion", "needs-review", "dev"}))
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+	})
+
+	Context("When GitHub sends status and check_run events", func() {
+		var promotionStrategy *promoterv1alpha1.PromotionStrategy
+		var ctpKey types.NamespacedName
+
+		BeforeEach(func() {
+			var name string
+			var scmSecret *v1.Secret
+			var scmProvider *promoterv1alpha1.ScmProvider
+			var gitRepo *promoterv1alpha1.GitRepository
+			name, scmSecret, scmProvider, gitRepo, _, _, promotionStrategy = promotionStrategyResource(ctx, "promotion-strategy-commit-check-event", "default")
+			// The SCM reports the status as "ci/tests", which is gated on as the label safe key "ci-tests".
+			promotionStrategy.Spec.ProposedCommitStatuses = []promoterv1alpha1.CommitStatusSelector{{Key: "ci-tests"}}
+			setupInitialTestGitRepoOnServer(ctx, gitRepo)
+			ctpKey = types.NamespacedName{
+				Name:      utils.KubeSafeUniqueName(ctx, utils.GetChangeTransferPolicyName(name, testBranchDevelopment)),
+				Namespace: "default",
+			}
+
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			Expect(k8sClient.Create(ctx, promotionStrategy)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, promotionStrategy)
+		})
+
+		sendEvent := func(eventName string, payload map[string]any) int {
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://localhost:%d/", webhookReceiverPort), bytes.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Github-Event", eventName)
+			req.Header.Set("X-Github-Delivery", fmt.Sprintf("%s-event-%d", eventName, time.Now().UnixNano()))
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+			return resp.StatusCode
+		}
+
+		It("should ingest the status and check run and reconcile the ChangeTransferPolicy and PromotionStrategy of the commit", func() {
+			var ctp promoterv1alpha1.ChangeTransferPolicy
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, ctpKey, &ctp)).To(Succeed())
+				g.Expect(ctp.Status.Proposed.Hydrated.Sha).NotTo(BeEmpty())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			sha := ctp.Status.Proposed.Hydrated.Sha
+
+			// expectGate checks the ingested CommitStatus of the commit, and that the ChangeTransferPolicy and the
+			// PromotionStrategy report its phase for the key.
+			expectGate := func(phase promoterv1alpha1.CommitStatusPhase, description string) {
+				Eventually(func(g Gomega) {
+					var csList promoterv1alpha1.CommitStatusList
+					g.Expect(k8sClient.List(ctx, &csList, client.InNamespace("default"),
+						client.MatchingLabels{promoterv1alpha1.CommitStatusLabel: "ci-tests"})).To(Succeed())
+					var ingested []promoterv1alpha1.CommitStatus
+					for _, cs := range csList.Items {
+						if cs.Spec.Sha == sha {
+							ingested = append(ingested, cs)
+						}
+					}
+					g.Expect(ingested).To(HaveLen(1))
+					cs := ingested[0]
+					g.Expect(cs.Labels).To(HaveKeyWithValue(promoterv1alpha1.IngestedCommitStatusLabel, "github"))
+					g.Expect(cs.Spec.Name).To(Equal("ci/tests"))
+					g.Expect(cs.Spec.Phase).To(Equal(phase))
+					g.Expect(cs.Spec.Description).To(Equal(description))
+					g.Expect(metav1.IsControlledBy(&cs, &ctp)).To(BeFalse(), "the CommitStatus is owned, not controlled, by the ChangeTransferPolicy")
+					g.Expect(cs.OwnerReferences).To(ContainElement(HaveField("UID", ctp.UID)))
+
+					g.Expect(k8sClient.Get(ctx, ctpKey, &ctp)).To(Succeed())
+					g.Expect(ctp.Status.Proposed.CommitStatuses).To(ContainElement(promoterv1alpha1.ChangeRequestPolicyCommitStatusPhase{
+						Key: "ci-tests", Phase: string(phase), Url: "https://ci.example.com/" + sha, Description: description,
+					}))
+
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(promotionStrategy), promotionStrategy)).To(Succeed())
+					g.Expect(promotionStrategy.Status.Environments).NotTo(BeEmpty())
+					g.Expect(promotionStrategy.Status.Environments[0].Proposed.CommitStatuses).To(ContainElement(
+						HaveField("Phase", string(phase))))
+				}, constants.EventuallyTimeout).Should(Succeed())
+			}
+
+			By("Waiting for the ChangeTransferPolicy to wait for the status")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, ctpKey, &ctp)).To(Succeed())
+				g.Expect(ctp.Status.Proposed.CommitStatuses).To(ContainElement(HaveField("Key", "ci-tests")))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Sending a status event for the proposed hydrated commit")
+			Expect(sendEvent("status", map[string]any{
+				"sha":         sha,
+				"state":       "success",
+				"context":     "ci/tests",
+				"target_url":  "https://ci.example.com/" + sha,
+				"description": "Tests passed",
+			})).To(Equal(http.StatusNoContent))
+			expectGate(promoterv1alpha1.CommitPhaseSuccess, "Tests passed")
+
+			By("Sending a check_run event of the same name for the proposed hydrated commit")
+			Expect(sendEvent("check_run", map[string]any{
+				"action": "completed",
+				"check_run": map[string]any{
+					"name":        "ci/tests",
+					"head_sha":    sha,
+					"status":      "completed",
+					"conclusion":  "failure",
+					"details_url": "https://ci.example.com/" + sha,
+					"output":      map[string]any{"title": "Tests failed"},
+				},
+			})).To(Equal(http.StatusNoContent))
+			expectGate(promoterv1alpha1.CommitPhaseFailure, "Tests failed")
+
+			By("Not ingesting checks the ChangeTransferPolicy doesn't gate on")
+			Expect(sendEvent("status", map[string]any{
+				"sha":     sha,
+				"state":   "success",
+				"context": "ci/lint",
+			})).To(Equal(http.StatusNoContent))
+			Consistently(func(g Gomega) {
+				var csList promoterv1alpha1.CommitStatusList
+				g.Expect(k8sClient.List(ctx, &csList, client.InNamespace("default"),
+					client.MatchingLabels{promoterv1alpha1.CommitStatusLabel: "ci-lint"})).To(Succeed())
+				g.Expect(csList.Items).To(BeEmpty())
+			}, 2*time.Second).Should(Succeed())
+		})
+
+		It("should ignore events for commits it doesn't track", func() {
+			Expect(sendEvent("status", map[string]any{
+				"sha":     "0000000000000000000000000000000000000000",
+				"state":   "success",
+				"context": "ci/tests",
+			})).To(Equal(http.StatusAccepted))
+		})
+	})
+
+	Context("When GitLab sends push, pipeline and job events", func() {
+		var gitRepo *promoterv1alpha1.GitRepository
+		var promotionStrategy *promoterv1alpha1.PromotionStrategy
+		var ctpKey types.NamespacedName
+
+		BeforeEach(func() {
+			var name string
+			var scmSecret *v1.Secret
+			var scmProvider *promoterv1alpha1.ScmProvider
+			name, scmSecret, scmProvider, gitRepo, _, _, promotionStrategy = promotionStrategyResource(ctx, "promotion-strategy-gitlab-event", "default")
+			setupInitialTestGitRepoOnServer(ctx, gitRepo)
+			ctpKey = types.NamespacedName{
+				Name:      utils.KubeSafeUniqueName(ctx, utils.GetChangeTransferPolicyName(name, testBranchDevelopment)),
+				Namespace: "default",
+			}
+
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			Expect(k8sClient.Create(ctx, promotionStrategy)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, promotionStrategy)
+		})
+
+		// recordedEvent returns the recorded payload with the project set to the test's repository.
+		recordedEvent := func(recorded []byte, modify func(payload map[string]any)) []byte {
+			var payload map[string]any
+			Expect(json.Unmarshal(recorded, &payload)).To(Succeed())
+			payload["project"].(map[string]any)["path_with_namespace"] = gitRepo.Spec.Fake.Owner + "/" + gitRepo.Spec.Fake.Name
+			modify(payload)
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+			return body
+		}
+
+		It("should enqueue the ChangeTransferPolicy of the pushed branch and the PromotionStrategy owning the commit", func() {
+			var ctp promoterv1alpha1.ChangeTransferPolicy
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, ctpKey, &ctp)).To(Succeed())
+				g.Expect(ctp.Status.Proposed.Hydrated.Sha).NotTo(BeEmpty())
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Sending a Push Hook for the proposed branch")
+			body := recordedEvent(testGitLabPushHookEvent, func(payload map[string]any) {
+				payload["ref"] = "refs/heads/" + ctp.Spec.ProposedBranch
+			})
+			Expect(postWebhookDelivery(ctx, map[string]string{"X-Gitlab-Event": "Push Hook"}, body)).To(Equal(http.StatusNoContent))
+
+			By("Sending a Pipeline Hook for the proposed hydrated commit")
+			body = recordedEvent(testGitLabPipelineHookEvent, func(payload map[string]any) {
+				payload["object_attributes"].(map[string]any)["sha"] = ctp.Status.Proposed.Hydrated.Sha
+			})
+			Expect(postWebhookDelivery(ctx, map[string]string{"X-Gitlab-Event": "Pipeline Hook"}, body)).To(Equal(http.StatusNoContent))
+
+			By("Sending a Job Hook for the proposed hydrated commit")
+			body = recordedEvent(testGitLabJobHookEvent, func(payload map[string]any) {
+				payload["sha"] = ctp.Status.Proposed.Hydrated.Sha
+			})
+			Expect(postWebhookDelivery(ctx, map[string]string{"X-Gitlab-Event": "Job Hook"}, body)).To(Equal(http.StatusNoContent))
+		})
+
+		It("should ignore events for branches and commits it doesn't track", func() {
+			body := recordedEvent(testGitLabPushHookEvent, func(payload map[string]any) {
+				payload["ref"] = "refs/heads/feature/untracked"
+			})
+			Expect(postWebhookDelivery(ctx, map[string]string{"X-Gitlab-Event": "Push Hook"}, body)).To(Equal(http.StatusAccepted))
+
+			body = recordedEvent(testGitLabPipelineHookEvent, func(payload map[string]any) {})
+			Expect(postWebhookDelivery(ctx, map[string]string{"X-Gitlab-Event": "Pipeline Hook"}, body)).To(Equal(http.StatusAccepted))
+		})
+	})
+
+	Context("When Bitbucket sends push and commit status events", func() {
+		var gitRepo *promoterv1alpha1.GitRepository
+		var promotionStrategy *promoterv1alpha1.PromotionStrategy
+		var ctpKey types.NamespacedName
+
+		cloudHeaders := func(eventKey string) map[string]string {
+			return map[string]string{"X-Event-Key": eventKey, "X-Hook-UUID": "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e"}
+		}
+		dataCenterHeaders := func(eventKey string) map[string]string {
+			return map[string]string{"X-Event-Key": eventKey, "X-Request-Id": "5b7f3a2c-9d4e-4f1a-8c6b-0e2d1f3a4b5c"}
+		}
+
+		BeforeEach(func() {
+			var name string
+			var scmSecret *v1.Secret
+			var scmProvider *promoterv1alpha1.ScmProvider
+			name, scmSecret, scmProvider, gitRepo, _, _, promotionStrategy = promotionStrategyResource(ctx, "promotion-strategy-bitbucket-event", "default")
+			setupInitialTestGitRepoOnServer(ctx, gitRepo)
+			ctpKey = types.NamespacedName{
+				Name:      utils.KubeSafeUniqueName(ctx, utils.GetChangeTransferPolicyName(name, testBranchDevelopment)),
+				Namespace: "default",
+			}
+
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			Expect(k8sClient.Create(ctx, promotionStrategy)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, promotionStrategy)
+		})
+
+		// cloudEvent returns the recorded Bitbucket Cloud payload with the repository set to the test's repository.
+		cloudEvent := func(recorded []byte, modify func(payload map[string]any)) []byte {
+			var payload map[string]any
+			Expect(json.Unmarshal(recorded, &payload)).To(Succeed())
+			payload["repository"].(map[string]any)["full_name"] = gitRepo.Spec.Fake.Owner + "/" + gitRepo.Spec.Fake.Name
+			modify(payload)
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+			return body
+		}
+
+		// dataCenterPush returns the recorded Bitbucket Data Center repo:refs_changed payload for a push to the ref of
+		// the test's repository.
+		dataCenterPush := func(ref string) []byte {
+			var payload map[string]any
+			Expect(json.Unmarshal(testBitbucketDataCenterRefsChangedEvent, &payload)).To(Succeed())
+			repository := payload["repository"].(map[string]any)
+			repository["slug"] = gitRepo.Spec.Fake.Name
+			repository["project"].(map[string]any)["key"] = gitRepo.Spec.Fake.Owner
+			payload["changes"].([]any)[0].(map[string]any)["refId"] = ref
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+			return body
+		}
+
+		It("should enqueue the ChangeTransferPolicy of the pushed branch and the PromotionStrategy owning the commit", func() {
+			var ctp promoterv1alpha1.ChangeTransferPolicy
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, ctpKey, &ctp)).To(Succeed())
+				g.Expect(ctp.Status.Proposed.Hydrated.Sha).NotTo(BeEmpty())
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Sending a Bitbucket Cloud repo:push for the proposed branch")
+			body := cloudEvent(testBitbucketCloudPushEvent, func(payload map[string]any) {
+				change := payload["push"].(map[string]any)["changes"].([]any)[0].(map[string]any)
+				change["new"].(map[string]any)["name"] = ctp.Spec.ProposedBranch
+			})
+			Expect(postWebhookDelivery(ctx, cloudHeaders("repo:push"), body)).To(Equal(http.StatusNoContent))
+
+			By("Sending a Bitbucket Data Center repo:refs_changed for the proposed branch")
+			body = dataCenterPush("refs/heads/" + ctp.Spec.ProposedBranch)
+			Expect(postWebhookDelivery(ctx, dataCenterHeaders("repo:refs_changed"), body)).To(Equal(http.StatusNoContent))
+
+			By("Sending a Bitbucket Cloud repo:commit_status_updated for the proposed hydrated commit")
+			body = cloudEvent(testBitbucketCloudCommitStatusUpdatedEvent, func(payload map[string]any) {
+				payload["commit_status"].(map[string]any)["commit"].(map[string]any)["hash"] = ctp.Status.Proposed.Hydrated.Sha
+			})
+			Expect(postWebhookDelivery(ctx, cloudHeaders("repo:commit_status_updated"), body)).To(Equal(http.StatusNoContent))
+		})
+
+		It("should ignore events for branches and commits it doesn't track and events it doesn't handle", func() {
+			body := dataCenterPush("refs/heads/feature/untracked")
+			Expect(postWebhookDelivery(ctx, dataCenterHeaders("repo:refs_changed"), body)).To(Equal(http.StatusAccepted))
+
+			body = cloudEvent(testBitbucketCloudCommitStatusUpdatedEvent, func(payload map[string]any) {})
+			Expect(postWebhookDelivery(ctx, cloudHeaders("repo:commit_status_updated"), body)).To(Equal(http.StatusAccepted))
+
+			Expect(postWebhookDelivery(ctx, dataCenterHeaders("diagnostics:ping"), []byte(`{"test":true}`))).To(Equal(http.StatusAccepted))
+		})
+	})
+
 	Context("When environment branch names are changed", func() {
 		Context("When cleaning up orphaned CTPs", func() {
 			var name string
@@ -3507,6 +4080,157 @@ var _ = Describe("PromotionStrategy Bug Tests", func() {
 		})
 	})
 
+	Context("When two PromotionStrategies in the same namespace share a branch name", func() {
+		const sharedBranchGateKey = "shared-branch-gate"
+		var gitRepo *promoterv1alpha1.GitRepository
+		var promotionStrategyA, promotionStrategyB *promoterv1alpha1.PromotionStrategy
+		var gateCommitStatusA *promoterv1alpha1.CommitStatus
+
+		BeforeEach(func() {
+			By("Creating two PromotionStrategies promoting the same environment branch of the same repository")
+			var scmSecret *v1.Secret
+			var scmProvider *promoterv1alpha1.ScmProvider
+			_, scmSecret, scmProvider, gitRepo, gateCommitStatusA, _, promotionStrategyA = promotionStrategyResource(ctx, "promotion-strategy-shared-branch-a", "default")
+			_, _, _, _, _, _, promotionStrategyB = promotionStrategyResource(ctx, "promotion-strategy-shared-branch-b", "default")
+			promotionStrategyB.Spec.RepositoryReference = promotionStrategyA.Spec.RepositoryReference
+
+			setupInitialTestGitRepoOnServer(ctx, gitRepo)
+
+			// The first PromotionStrategy merges once its proposed commit status passes. The second merges without any
+			// gate, so it would merge the first one's pull request straight away if it ever acted on it.
+			promotionStrategyA.Spec.Environments = []promoterv1alpha1.Environment{
+				{Branch: testBranchDevelopment, AutoMerge: ptr.To(true)},
+			}
+			promotionStrategyA.Spec.ProposedCommitStatuses = []promoterv1alpha1.CommitStatusSelector{
+				{Key: sharedBranchGateKey},
+			}
+			gateCommitStatusA.Spec.Name = sharedBranchGateKey
+			gateCommitStatusA.Labels = map[string]string{
+				promoterv1alpha1.CommitStatusLabel: sharedBranchGateKey,
+			}
+			promotionStrategyB.Spec.Environments = []promoterv1alpha1.Environment{
+				{Branch: testBranchDevelopment, AutoMerge: ptr.To(true)},
+			}
+
+			for _, obj := range []client.Object{scmSecret, scmProvider, gitRepo} {
+				Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			By("Cleaning up resources")
+			_ = k8sClient.Delete(ctx, promotionStrategyA)
+			_ = k8sClient.Delete(ctx, promotionStrategyB)
+			_ = k8sClient.Delete(ctx, gateCommitStatusA)
+		})
+
+		It("should only list, merge and close the ChangeTransferPolicies and pull requests it owns", func() {
+			By("Hydrating the repository then creating the first PromotionStrategy")
+			gitPath, err := os.MkdirTemp("", "*")
+			Expect(err).NotTo(HaveOccurred())
+			makeChangeAndHydrateRepo(gitPath, gitRepo, "", "")
+			_ = os.RemoveAll(gitPath)
+			Expect(k8sClient.Create(ctx, promotionStrategyA)).To(Succeed())
+
+			ctpAName := utils.KubeSafeUniqueName(ctx, utils.GetChangeTransferPolicyName(promotionStrategyA.Name, testBranchDevelopment))
+			ctpBName := utils.KubeSafeUniqueName(ctx, utils.GetChangeTransferPolicyName(promotionStrategyB.Name, testBranchDevelopment))
+			prName := utils.KubeSafeUniqueName(ctx, utils.GetPullRequestName(gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name, testBranchDevelopmentNext, testBranchDevelopment))
+			var ctpA, ctpB promoterv1alpha1.ChangeTransferPolicy
+			var pullRequest promoterv1alpha1.PullRequest
+
+			By("Waiting for the first PromotionStrategy to open its pull request")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: ctpAName, Namespace: "default"}, &ctpA)).To(Succeed())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: prName, Namespace: "default"}, &pullRequest)).To(Succeed())
+				g.Expect(pullRequest.Status.State).To(Equal(promoterv1alpha1.PullRequestOpen))
+				g.Expect(pullRequest.Status.ID).NotTo(BeEmpty())
+				g.Expect(verifyPullRequestOwnerChain(&pullRequest, &ctpA)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			pullRequestID := pullRequest.Status.ID
+
+			By("Creating the second PromotionStrategy")
+			Expect(k8sClient.Create(ctx, promotionStrategyB)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: ctpBName, Namespace: "default"}, &ctpB)).To(Succeed())
+				g.Expect(ctpB.Labels).To(HaveKeyWithValue(promoterv1alpha1.PromotionStrategyUIDLabel, string(promotionStrategyB.UID)))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Checking that each PromotionStrategy only lists its own ChangeTransferPolicy")
+			for ps, ctpName := range map[*promoterv1alpha1.PromotionStrategy]string{promotionStrategyA: ctpAName, promotionStrategyB: ctpBName} {
+				var ctpList promoterv1alpha1.ChangeTransferPolicyList
+				Expect(k8sClient.List(ctx, &ctpList, client.InNamespace("default"), client.MatchingLabels{
+					promoterv1alpha1.PromotionStrategyUIDLabel: string(ps.UID),
+				})).To(Succeed())
+				Expect(ctpList.Items).To(HaveLen(1))
+				Expect(ctpList.Items[0].Name).To(Equal(ctpName))
+			}
+
+			By("Checking that the second PromotionStrategy never merges or references the gated pull request")
+			Consistently(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: ctpAName, Namespace: "default"}, &ctpA)).To(Succeed())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: ctpBName, Namespace: "default"}, &ctpB)).To(Succeed())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: prName, Namespace: "default"}, &pullRequest)).To(Succeed())
+				g.Expect(pullRequest.Spec.State).To(Equal(promoterv1alpha1.PullRequestOpen))
+				g.Expect(pullRequest.Status.State).To(Equal(promoterv1alpha1.PullRequestOpen))
+				g.Expect(pullRequest.Labels).To(HaveKeyWithValue(promoterv1alpha1.PromotionStrategyUIDLabel, string(promotionStrategyA.UID)))
+				g.Expect(verifyPullRequestOwnerChain(&pullRequest, &ctpA)).To(Succeed())
+				g.Expect(verifyPullRequestOwnerChain(&pullRequest, &ctpB)).ToNot(Succeed())
+
+				var prList promoterv1alpha1.PullRequestList
+				g.Expect(k8sClient.List(ctx, &prList, ctpPullRequestListOptions(&ctpA))).To(Succeed())
+				g.Expect(prList.Items).To(HaveLen(1))
+				g.Expect(k8sClient.List(ctx, &prList, ctpPullRequestListOptions(&ctpB))).To(Succeed())
+				g.Expect(prList.Items).To(BeEmpty())
+				g.Expect(ctpB.Status.PullRequest).To(BeNil())
+			}, 5*time.Second, 500*time.Millisecond).Should(Succeed())
+
+			By("Removing the environment from the second PromotionStrategy")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(promotionStrategyB), promotionStrategyB)).To(Succeed())
+				promotionStrategyB.Spec.Environments = []promoterv1alpha1.Environment{
+					{Branch: testBranchStaging, AutoMerge: ptr.To(false)},
+				}
+				g.Expect(k8sClient.Update(ctx, promotionStrategyB)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: ctpBName, Namespace: "default"}, &ctpB)
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Checking that the first PromotionStrategy's ChangeTransferPolicy and pull request are neither deleted nor closed")
+			Consistently(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: ctpAName, Namespace: "default"}, &ctpA)).To(Succeed())
+				g.Expect(ctpA.DeletionTimestamp.IsZero()).To(BeTrue())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: prName, Namespace: "default"}, &pullRequest)).To(Succeed())
+				g.Expect(pullRequest.Spec.State).To(Equal(promoterv1alpha1.PullRequestOpen))
+				g.Expect(pullRequest.Status.State).To(Equal(promoterv1alpha1.PullRequestOpen))
+				g.Expect(verifyPullRequestOwnerChain(&pullRequest, &ctpA)).To(Succeed())
+			}, 5*time.Second, 500*time.Millisecond).Should(Succeed())
+
+			By("Passing the first PromotionStrategy's gate")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: ctpAName, Namespace: "default"}, &ctpA)).To(Succeed())
+				g.Expect(ctpA.Status.Proposed.Hydrated.Sha).NotTo(BeEmpty())
+				_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, gateCommitStatusA, func() error {
+					gateCommitStatusA.Spec.Sha = ctpA.Status.Proposed.Hydrated.Sha
+					gateCommitStatusA.Spec.Phase = promoterv1alpha1.CommitPhaseSuccess
+					return nil
+				})
+				g.Expect(err).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Checking that the first PromotionStrategy merges its own pull request")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: ctpAName, Namespace: "default"}, &ctpA)).To(Succeed())
+				g.Expect(ctpA.Status.PullRequest).NotTo(BeNil())
+				g.Expect(ctpA.Status.PullRequest.ID).To(Equal(pullRequestID))
+				g.Expect(ctpA.Status.PullRequest.State).To(Equal(promoterv1alpha1.PullRequestMerged))
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: prName, Namespace: "default"}, &pullRequest)
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+	})
+
 	Context("Out-of-order hydration protection", func() {
 		// This test verifies that the system correctly blocks downstream environments
 		// from promoting when upstream environments haven't been hydrated yet.
@@ -4499,6 +5223,24 @@ var _ = Describe("PromotionStrategy Bug Tests", func() {
 				Expect(reason).To(BeEmpty())
 			})
 
+			It("recurses through effectively promoted envs", func() {
+				env1 := makeEnv("env1", "ABC", "ABC", "ABC", newerTime, string(promoterv1alpha1.CommitPhaseSuccess)) // merged, healthy
+				env2 := makeEnv("env2", "OLD", "ABC", "ABC", olderTime, string(promoterv1alpha1.CommitPhaseSuccess)) // same tree as active
+				env2.EffectivelyPromotedDrySha = "ABC"
+				env3 := makeEnv("env3", "OLD", "ABC", "ABC", olderTime, string(promoterv1alpha1.CommitPhaseSuccess))
+
+				isPending, reason := isPreviousEnvironmentPending([]promoterv1alpha1.EnvironmentStatus{env1, env2}, getEffectiveHydratedDrySha(env3), env3.Active.Dry.CommitTime)
+
+				Expect(isPending).To(BeFalse())
+				Expect(reason).To(BeEmpty())
+
+				env1.Active.CommitStatuses[0].Phase = string(promoterv1alpha1.CommitPhasePending)
+				isPending, reason = isPreviousEnvironmentPending([]promoterv1alpha1.EnvironmentStatus{env1, env2}, getEffectiveHydratedDrySha(env3), env3.Active.Dry.CommitTime)
+
+				Expect(isPending).To(BeTrue())
+				Expect(reason).To(Equal(`Waiting for "env1" environment's "health" commit status to be successful`))
+			})
+
 			// Regression test: newer no-op dry SHA causes premature promotion through all envs.
 			//
 			// Scenario (dev → staging → prod, all with activeCommitStatuses: [argocd-health]):
@@ -4976,4 +5718,503 @@ var _ = Describe("PromotionStrategy Bug Tests", func() {
 			Expect(lastEnqueuedName).To(Equal("ctp-2"), "ctp-2 should be the last enqueued")
 		})
 	})
+
+	Context("autoRevertFailedPromotions", func() {
+		const drySha = "1111111111111111111111111111111111111111"
+
+		var (
+			ps         *promoterv1alpha1.PromotionStrategy
+			recorder   *events.FakeRecorder
+			reconciler *PromotionStrategyReconciler
+		)
+
+		BeforeEach(func() {
+			ps = &promoterv1alpha1.PromotionStrategy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "auto-revert-" + utils.KubeSafeUniqueName(ctx, randomString(15)),
+					Namespace: "default",
+				},
+				Spec: promoterv1alpha1.PromotionStrategySpec{
+					RepositoryReference: promoterv1alpha1.ObjectReference{Name: "auto-revert"},
+					Environments: []promoterv1alpha1.Environment{{
+						Branch: "environment/production",
+						AutoRevert: &promoterv1alpha1.AutoRevert{
+							Enabled:   true,
+							DryBranch: "main",
+							Within:    metav1.Duration{Duration: time.Hour},
+						},
+					}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, ps)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, ps)).To(Succeed())
+			})
+
+			ps.Status.Environments = []promoterv1alpha1.EnvironmentStatus{{
+				Branch: "environment/production",
+				Active: promoterv1alpha1.CommitBranchState{
+					Dry: promoterv1alpha1.CommitShaState{Sha: drySha, Subject: "Raise the replica count"},
+					CommitStatuses: []promoterv1alpha1.ChangeRequestPolicyCommitStatusPhase{
+						{Key: "argocd-health", Phase: string(promoterv1alpha1.CommitPhaseFailure)},
+					},
+				},
+				History: []promoterv1alpha1.History{{
+					Active:   promoterv1alpha1.CommitBranchState{Dry: promoterv1alpha1.CommitShaState{Sha: drySha}},
+					Proposed: promoterv1alpha1.CommitBranchStateHistoryProposed{Hydrated: promoterv1alpha1.CommitShaState{Sha: "2222222222222222222222222222222222222222"}},
+					PullRequest: &promoterv1alpha1.PullRequestCommonStatus{
+						PRMergeTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+					},
+				}},
+			}}
+
+			recorder = events.NewFakeRecorder(10)
+			reconciler = &PromotionStrategyReconciler{Client: k8sClient, Recorder: recorder}
+		})
+
+		It("should revert the promotion once when its active commit statuses keep failing", func() {
+			requeueAfter, err := reconciler.autoRevertFailedPromotions(ctx, ps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(BeZero())
+
+			autoRevert := ps.Status.Environments[0].AutoRevert
+			Expect(autoRevert).NotTo(BeNil())
+			Expect(autoRevert.DrySha).To(Equal(drySha))
+			Expect(autoRevert.FailingSince).NotTo(BeNil())
+			Expect(autoRevert.RevertCommit).NotTo(BeEmpty())
+			Expect(ps.Status.Environments[0].History[0].AutoRevert).To(Equal(autoRevert.RevertCommit))
+
+			var rc promoterv1alpha1.RevertCommit
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: autoRevert.RevertCommit, Namespace: ps.Namespace}, &rc)).To(Succeed())
+			Expect(rc.Spec.Sha).To(Equal(drySha))
+			Expect(rc.Spec.DryBranch).To(Equal("main"))
+			Expect(rc.Spec.Environments).To(Equal([]string{"environment/production"}))
+			Expect(rc.Spec.PromotionStrategyRef.Name).To(Equal(ps.Name))
+			Expect(metav1.IsControlledBy(&rc, ps)).To(BeTrue())
+			Expect(recorder.Events).To(Receive(ContainSubstring(constants.ActiveCommitStatusFailingReason)))
+			Expect(recorder.Events).To(Receive(ContainSubstring(constants.AutoRevertCreatedReason)))
+
+			// A lost status doesn't lead to a second revert of the same dry commit.
+			ps.Status.Environments[0].AutoRevert = nil
+			_, err = reconciler.autoRevertFailedPromotions(ctx, ps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ps.Status.Environments[0].AutoRevert.RevertCommit).To(Equal(rc.Name))
+
+			var rcList promoterv1alpha1.RevertCommitList
+			Expect(k8sClient.List(ctx, &rcList, client.InNamespace(ps.Namespace), client.MatchingLabels{
+				promoterv1alpha1.PromotionStrategyLabel: utils.KubeSafeLabel(ps.Name),
+			})).To(Succeed())
+			Expect(rcList.Items).To(HaveLen(1))
+			Expect(k8sClient.Delete(ctx, &rc)).To(Succeed())
+		})
+
+		It("should not hold the revert on the environments before it", func() {
+			_, err := reconciler.autoRevertFailedPromotions(ctx, ps)
+			Expect(err).NotTo(HaveOccurred())
+			var rc promoterv1alpha1.RevertCommit
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: ps.Status.Environments[0].AutoRevert.RevertCommit, Namespace: ps.Namespace}, &rc)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, &rc)).To(Succeed())
+			})
+
+			const restoredDrySha = "3333333333333333333333333333333333333333"
+			rc.Status.Environments = []promoterv1alpha1.RevertCommitEnvironmentStatus{{
+				Branch:         "environment/production",
+				RestoredDrySha: restoredDrySha,
+				ProposedSha:    "4444444444444444444444444444444444444444",
+			}}
+			rcs := []promoterv1alpha1.RevertCommit{rc}
+			Expect(environmentRevertRestoring(rcs, ps.Name, "environment/production", restoredDrySha)).To(Equal(rc.Name))
+			Expect(environmentRevertRestoring(rcs, ps.Name, "environment/production", drySha)).To(BeEmpty())
+			Expect(environmentRevertRestoring(rcs, ps.Name, "environment/staging", restoredDrySha)).To(BeEmpty())
+			Expect(environmentRevertRestoring(rcs, "other", "environment/production", restoredDrySha)).To(BeEmpty())
+		})
+
+		It("should wait for the debounce period before reverting", func() {
+			ps.Spec.Environments[0].AutoRevert.Debounce = metav1.Duration{Duration: 10 * time.Minute}
+
+			requeueAfter, err := reconciler.autoRevertFailedPromotions(ctx, ps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(BeNumerically(">", 9*time.Minute))
+			Expect(requeueAfter).To(BeNumerically("<=", 10*time.Minute))
+			Expect(ps.Status.Environments[0].AutoRevert.FailingSince).NotTo(BeNil())
+			Expect(ps.Status.Environments[0].AutoRevert.RevertCommit).To(BeEmpty())
+
+			// Failures that stop before the debounce period ends are forgotten.
+			ps.Status.Environments[0].Active.CommitStatuses[0].Phase = string(promoterv1alpha1.CommitPhaseSuccess)
+			_, err = reconciler.autoRevertFailedPromotions(ctx, ps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ps.Status.Environments[0].AutoRevert.FailingSince).To(BeNil())
+		})
+
+		It("should not revert failures that start long after the promotion", func() {
+			ps.Status.Environments[0].History[0].PullRequest.PRMergeTime = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+
+			_, err := reconciler.autoRevertFailedPromotions(ctx, ps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ps.Status.Environments[0].AutoRevert.FailingSince).To(BeNil())
+			Expect(ps.Status.Environments[0].AutoRevert.RevertCommit).To(BeEmpty())
+		})
+
+		It("should never revert a revert", func() {
+			ps.Status.Environments[0].Active.Dry.Subject = `Revert "Raise the replica count"`
+
+			_, err := reconciler.autoRevertFailedPromotions(ctx, ps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ps.Status.Environments[0].AutoRevert.RevertCommit).To(BeEmpty())
+			Expect(recorder.Events).To(Receive(ContainSubstring(constants.AutoRevertSkippedReason)))
+		})
+	})
+
+	Context("renderProposedBranches", func() {
+		makePromotionStrategy := func(template string, environments ...promoterv1alpha1.Environment) *promoterv1alpha1.PromotionStrategy {
+			return &promoterv1alpha1.PromotionStrategy{
+				Spec: promoterv1alpha1.PromotionStrategySpec{
+					ProposedBranchTemplate: template,
+					Environments:           environments,
+				},
+			}
+		}
+
+		It("should default to the -next suffix", func() {
+			ps := makePromotionStrategy("", promoterv1alpha1.Environment{Branch: "environment/dev"}, promoterv1alpha1.Environment{Branch: "environment/prod"})
+			branches, err := renderProposedBranches(ps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(branches).To(Equal([]string{"environment/dev-next", "environment/prod-next"}))
+		})
+
+		It("should render the strategy template with per-environment overrides", func() {
+			ps := makePromotionStrategy("promote/{{ .Branch }}",
+				promoterv1alpha1.Environment{Branch: "dev"},
+				promoterv1alpha1.Environment{Branch: "prod", ProposedBranchTemplate: "{{ .Branch }}-staged"})
+			branches, err := renderProposedBranches(ps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(branches).To(Equal([]string{"promote/dev", "prod-staged"}))
+		})
+
+		It("should reject templates that render the same branch for two environments", func() {
+			ps := makePromotionStrategy("promote", promoterv1alpha1.Environment{Branch: "dev"}, promoterv1alpha1.Environment{Branch: "prod"})
+			_, err := renderProposedBranches(ps)
+			Expect(err).To(MatchError(ContainSubstring(`both use proposed branch "promote"`)))
+		})
+
+		It("should reject a proposed branch that is another environment's active branch", func() {
+			ps := makePromotionStrategy("",
+				promoterv1alpha1.Environment{Branch: "dev", ProposedBranchTemplate: "prod"},
+				promoterv1alpha1.Environment{Branch: "prod"})
+			_, err := renderProposedBranches(ps)
+			Expect(err).To(MatchError(ContainSubstring("is the active branch of an environment")))
+		})
+
+		It("should reject templates that fail to render or render an invalid name", func() {
+			_, err := renderProposedBranches(makePromotionStrategy("{{ .Missing }}", promoterv1alpha1.Environment{Branch: "dev"}))
+			Expect(err).To(HaveOccurred())
+
+			_, err = renderProposedBranches(makePromotionStrategy("{{ .Branch }} next", promoterv1alpha1.Environment{Branch: "dev"}))
+			Expect(err).To(MatchError(ContainSubstring("invalid branch name")))
+		})
+	})
+
+	Context("recordMergedPullRequests", func() {
+		openDurationCount := func(psName, environment string) float64 {
+			return metricValue("pull_request_open_duration_seconds", map[string]string{"promotion_strategy": psName, "environment": environment})
+		}
+		proposing := func(activeDrySha, proposedDrySha string) promoterv1alpha1.EnvironmentStatus {
+			envStatus := promoterv1alpha1.EnvironmentStatus{
+				Branch: "environment/dev",
+				PullRequest: &promoterv1alpha1.PullRequestCommonStatus{
+					ID:             "1",
+					State:          promoterv1alpha1.PullRequestOpen,
+					PRCreationTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+				},
+			}
+			envStatus.Active.Dry.Sha = activeDrySha
+			envStatus.Proposed.Dry.Sha = proposedDrySha
+			return envStatus
+		}
+
+		It("should record a pull request once, when its dry commit becomes active", func() {
+			ps := &promoterv1alpha1.PromotionStrategy{ObjectMeta: metav1.ObjectMeta{Name: "record-merged", Namespace: "default"}}
+			previous := []promoterv1alpha1.EnvironmentStatus{proposing("aaa", "bbb")}
+
+			ps.Status.Environments = []promoterv1alpha1.EnvironmentStatus{proposing("aaa", "bbb")}
+			recordMergedPullRequests(ps, previous)
+			Expect(openDurationCount("record-merged", "environment/dev")).To(BeZero())
+
+			// Merged outside the controller: the pull request status is kept, and there's no merge time in history.
+			merged := proposing("bbb", "bbb")
+			merged.PullRequest.State = ""
+			merged.PullRequest.ExternallyMergedOrClosed = ptr.To(true)
+			ps.Status.Environments = []promoterv1alpha1.EnvironmentStatus{merged}
+			recordMergedPullRequests(ps, previous)
+			Expect(openDurationCount("record-merged", "environment/dev")).To(Equal(1.0))
+
+			// The next reconcile, or one after a restart, starts from the persisted status.
+			recordMergedPullRequests(ps, ps.DeepCopy().Status.Environments)
+			Expect(openDurationCount("record-merged", "environment/dev")).To(Equal(1.0))
+		})
+
+		It("should not record closed pull requests", func() {
+			ps := &promoterv1alpha1.PromotionStrategy{ObjectMeta: metav1.ObjectMeta{Name: "record-closed", Namespace: "default"}}
+			closed := proposing("aaa", "bbb")
+			closed.PullRequest.State = promoterv1alpha1.PullRequestClosed
+			ps.Status.Environments = []promoterv1alpha1.EnvironmentStatus{proposing("bbb", "bbb")}
+			recordMergedPullRequests(ps, []promoterv1alpha1.EnvironmentStatus{closed})
+			Expect(openDurationCount("record-closed", "environment/dev")).To(BeZero())
+		})
+	})
+
+	Context("emitBlockedPromotions", func() {
+		It("should record a PromotionBlocked event once per blocked dry commit", func() {
+			recorder := events.NewFakeRecorder(10)
+			reconciler := &PromotionStrategyReconciler{Recorder: recorder}
+			ps := &promoterv1alpha1.PromotionStrategy{ObjectMeta: metav1.ObjectMeta{Name: "blocked", Namespace: "default"}}
+			blocked := promoterv1alpha1.EnvironmentStatus{Branch: "environment/staging"}
+			blocked.Active.Dry.Sha = "aaaaaaaaaa"
+			blocked.Proposed.Dry.Sha = "bbbbbbbbbb"
+			blocked.Proposed.CommitStatuses = []promoterv1alpha1.ChangeRequestPolicyCommitStatusPhase{
+				{Key: "e2e-tests", Phase: string(promoterv1alpha1.CommitPhaseFailure)},
+			}
+
+			ps.Status.Environments = []promoterv1alpha1.EnvironmentStatus{blocked}
+			reconciler.emitBlockedPromotions(ctx, ps, nil)
+			Expect(recorder.Events).To(Receive(Equal("Warning PromotionBlocked Promotion of dry sha bbbbbbb to environment environment/staging is blocked by failing commit statuses e2e-tests")))
+
+			// Still blocked on the next reconcile.
+			reconciler.emitBlockedPromotions(ctx, ps, ps.DeepCopy().Status.Environments)
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+
+	Context("updatePreviousEnvironmentCommitStatus", func() {
+		sha := func(c string) string { return strings.Repeat(c, 40) }
+		copies := func(psName string, result metrics.PreviousEnvironmentCopyResult) float64 {
+			return metricValue("previous_environment_commit_status_copies_total", map[string]string{"promotion_strategy": psName, "result": string(result)})
+		}
+		liveCopies := func(psName, environment string) float64 {
+			return metricValue("previous_environment_commit_status_live_copies", map[string]string{"promotion_strategy": psName, "environment": environment})
+		}
+		makeCTP := func(psName, branch, activeSha, proposedSha string) *promoterv1alpha1.ChangeTransferPolicy {
+			ctp := &promoterv1alpha1.ChangeTransferPolicy{ObjectMeta: metav1.ObjectMeta{
+				Name:      psName + "-" + strings.ReplaceAll(branch, "/", "-"),
+				Namespace: "default",
+				UID:       types.UID(psName + "-" + branch),
+			}}
+			ctp.Spec.ActiveBranch = branch
+			ctp.Spec.RepositoryReference.Name = "repo"
+			ctp.Status.Active.Dry.Sha = activeSha
+			ctp.Status.Active.Hydrated.Sha = activeSha
+			ctp.Status.Proposed.Dry.Sha = proposedSha
+			ctp.Status.Proposed.Hydrated.Sha = proposedSha
+			meta.SetStatusCondition(&ctp.Status.Conditions, metav1.Condition{
+				Type: string(promoterConditions.Ready), Status: metav1.ConditionTrue, Reason: string(promoterConditions.ReconciliationSuccess),
+			})
+			return ctp
+		}
+		// setup returns a PromotionStrategy whose dev environment runs sha a, and whose prod environment proposes sha b.
+		setup := func(psName string) (*PromotionStrategyReconciler, *events.FakeRecorder, *promoterv1alpha1.PromotionStrategy, []*promoterv1alpha1.ChangeTransferPolicy) {
+			recorder := events.NewFakeRecorder(10)
+			reconciler := &PromotionStrategyReconciler{Client: k8sClient, Recorder: recorder}
+			ps := &promoterv1alpha1.PromotionStrategy{ObjectMeta: metav1.ObjectMeta{Name: psName, Namespace: "default"}}
+			ps.Spec.ActiveCommitStatuses = []promoterv1alpha1.CommitStatusSelector{{Key: "healthy"}}
+			ps.Spec.Environments = []promoterv1alpha1.Environment{{Branch: "environment/dev"}, {Branch: "environment/prod"}}
+			ctps := []*promoterv1alpha1.ChangeTransferPolicy{
+				makeCTP(psName, "environment/dev", sha("a"), sha("a")),
+				makeCTP(psName, "environment/prod", sha("c"), sha("b")),
+			}
+			return reconciler, recorder, ps, ctps
+		}
+		update := func(reconciler *PromotionStrategyReconciler, ps *promoterv1alpha1.PromotionStrategy, ctps []*promoterv1alpha1.ChangeTransferPolicy) {
+			reconciler.calculateStatus(ps, ctps)
+			Expect(reconciler.updatePreviousEnvironmentCommitStatus(ctx, ps, ctps)).To(Succeed())
+		}
+
+		It("should count created, unchanged and updated copies and the live copies", func() {
+			reconciler, _, ps, ctps := setup("copies-applied")
+
+			update(reconciler, ps, ctps)
+			Expect(copies("copies-applied", metrics.PreviousEnvironmentCopyCreated)).To(Equal(1.0))
+			Expect(liveCopies("copies-applied", "environment/dev")).To(BeZero())
+			Expect(liveCopies("copies-applied", "environment/prod")).To(Equal(1.0))
+
+			var cs promoterv1alpha1.CommitStatus
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: utils.KubeSafeUniqueName(ctx, promoterv1alpha1.PreviousEnvProposedCommitPrefixNameLabel+ctps[1].Name)}, &cs)).To(Succeed())
+			Expect(cs.Annotations).To(HaveKeyWithValue(promoterv1alpha1.CommitStatusCopyFromShaAnnotation, sha("a")))
+
+			update(reconciler, ps, ctps)
+			Expect(copies("copies-applied", metrics.PreviousEnvironmentCopyUnchanged)).To(Equal(1.0))
+
+			// The previous environment promoted another commit.
+			ctps[0].Status.Active.Hydrated.Sha = sha("d")
+			update(reconciler, ps, ctps)
+			Expect(copies("copies-applied", metrics.PreviousEnvironmentCopyUpdated)).To(Equal(1.0))
+			Expect(copies("copies-applied", metrics.PreviousEnvironmentCopyCreated)).To(Equal(1.0))
+		})
+
+		It("should count skipped copies of environments that propose no change", func() {
+			reconciler, _, ps, ctps := setup("copies-no-change")
+			ctps[1].Status.Proposed = ctps[1].Status.Active
+
+			update(reconciler, ps, ctps)
+			Expect(copies("copies-no-change", metrics.PreviousEnvironmentCopySkipped)).To(Equal(1.0))
+			Expect(copies("copies-no-change", metrics.PreviousEnvironmentCopyCreated)).To(BeZero())
+			Expect(liveCopies("copies-no-change", "environment/prod")).To(BeZero())
+		})
+
+		It("should count skipped copies of changes that are effectively promoted", func() {
+			reconciler, _, ps, ctps := setup("copies-effectively-promoted")
+			ctps[1].Status.EffectivelyPromotedDrySha = ctps[1].Status.Proposed.Dry.Sha
+
+			update(reconciler, ps, ctps)
+			Expect(copies("copies-effectively-promoted", metrics.PreviousEnvironmentCopySkipped)).To(Equal(1.0))
+			Expect(copies("copies-effectively-promoted", metrics.PreviousEnvironmentCopyCreated)).To(BeZero())
+		})
+
+		It("should detect a copy that still gates the proposed change but can't be updated as stale", func() {
+			reconciler, recorder, ps, ctps := setup("copies-stale")
+			update(reconciler, ps, ctps)
+			Expect(copies("copies-stale", metrics.PreviousEnvironmentCopyCreated)).To(Equal(1.0))
+
+			// The previous environment promoted another commit, while the ChangeTransferPolicy can't resolve its shas.
+			ctps[0].Status.Active.Hydrated.Sha = sha("d")
+			meta.SetStatusCondition(&ctps[1].Status.Conditions, metav1.Condition{
+				Type: string(promoterConditions.Ready), Status: metav1.ConditionFalse, Reason: string(promoterConditions.BranchShasUnresolved),
+			})
+			update(reconciler, ps, ctps)
+			Expect(copies("copies-stale", metrics.PreviousEnvironmentCopySkipped)).To(Equal(1.0))
+			Expect(copies("copies-stale", metrics.PreviousEnvironmentCopyStale)).To(Equal(1.0))
+			Expect(liveCopies("copies-stale", "environment/prod")).To(Equal(1.0))
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning PreviousEnvironmentCommitStatusStale ")))
+		})
+
+		It("should not detect a skipped copy that is up to date as stale", func() {
+			reconciler, recorder, ps, ctps := setup("copies-unresolved")
+			update(reconciler, ps, ctps)
+
+			meta.SetStatusCondition(&ctps[1].Status.Conditions, metav1.Condition{
+				Type: string(promoterConditions.Ready), Status: metav1.ConditionFalse, Reason: string(promoterConditions.BranchShasUnresolved),
+			})
+			update(reconciler, ps, ctps)
+			Expect(copies("copies-unresolved", metrics.PreviousEnvironmentCopySkipped)).To(Equal(1.0))
+			Expect(copies("copies-unresolved", metrics.PreviousEnvironmentCopyStale)).To(BeZero())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+
+	Context("auditMergedPullRequests", func() {
+		It("should record merged pull requests in the audit trail with their gates and actor", func() {
+			sink := &channelAuditSink{records: make(chan audit.Record, 10)}
+			auditRecorder := audit.NewRecorder(10, sink)
+			auditCtx, cancel := context.WithCancel(ctx)
+			DeferCleanup(cancel)
+			go func() {
+				defer GinkgoRecover()
+				Expect(auditRecorder.Start(auditCtx)).To(Succeed())
+			}()
+			reconciler := &PromotionStrategyReconciler{Audit: auditRecorder}
+
+			ps := &promoterv1alpha1.PromotionStrategy{ObjectMeta: metav1.ObjectMeta{Name: "audited", Namespace: "default"}}
+			ps.Spec.Environments = []promoterv1alpha1.Environment{{Branch: "environment/dev"}, {Branch: "environment/prod", AutoMerge: ptr.To(false)}}
+			proposing := func(branch string) promoterv1alpha1.EnvironmentStatus {
+				envStatus := promoterv1alpha1.EnvironmentStatus{
+					Branch: branch,
+					PullRequest: &promoterv1alpha1.PullRequestCommonStatus{
+						State:          promoterv1alpha1.PullRequestOpen,
+						PRCreationTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+						Url:            "https://github.com/example/demo/pull/1",
+					},
+				}
+				envStatus.Active.Dry.Sha = "aaa"
+				envStatus.Proposed.Dry.Sha = "bbb"
+				envStatus.Proposed.Hydrated.Sha = "ccc"
+				envStatus.Proposed.CommitStatuses = []promoterv1alpha1.ChangeRequestPolicyCommitStatusPhase{
+					{Key: "e2e-tests", Phase: string(promoterv1alpha1.CommitPhaseSuccess)},
+				}
+				return envStatus
+			}
+			previous := []promoterv1alpha1.EnvironmentStatus{proposing("environment/dev"), proposing("environment/prod")}
+
+			// The controller merged dev, and prod was merged on the SCM.
+			mergeTime := metav1.NewTime(time.Now().Truncate(time.Second))
+			dev := proposing("environment/dev")
+			dev.Active.Dry.Sha = "bbb"
+			dev.History = []promoterv1alpha1.History{{PullRequest: &promoterv1alpha1.PullRequestCommonStatus{PRMergeTime: mergeTime}}}
+			dev.History[0].Active.Dry.Sha = "bbb"
+			prod := proposing("environment/prod")
+			prod.Active.Dry.Sha = "bbb"
+			ps.Status.Environments = []promoterv1alpha1.EnvironmentStatus{dev, prod}
+			reconciler.auditMergedPullRequests(ctx, ps, previous)
+
+			var record audit.Record
+			Eventually(sink.records).Should(Receive(&record))
+			Expect(record.Type).To(Equal(audit.TypePromotionMerged))
+			Expect(record.Environment).To(Equal("environment/dev"))
+			Expect(record.DrySha).To(Equal("bbb"))
+			Expect(record.HydratedSha).To(Equal("ccc"))
+			Expect(record.PullRequestURL).To(Equal("https://github.com/example/demo/pull/1"))
+			Expect(record.MergeTime).To(HaveValue(BeTemporally("==", mergeTime.Time)))
+			Expect(record.Gates).To(Equal([]audit.Gate{{Key: "e2e-tests", Phase: "success"}}))
+			Expect(record.Actor).To(Equal(audit.ActorPromoter))
+
+			Eventually(sink.records).Should(Receive(&record))
+			Expect(record.Type).To(Equal(audit.TypeManualApprovalConsumed))
+			Expect(record.Environment).To(Equal("environment/prod"))
+			Expect(record.MergeTime).To(BeNil())
+			Expect(record.Actor).To(Equal(audit.ActorSCM))
+
+			// The next reconcile starts from the persisted status.
+			reconciler.auditMergedPullRequests(ctx, ps, ps.DeepCopy().Status.Environments)
+			Consistently(sink.records, 100*time.Millisecond).ShouldNot(Receive())
+		})
+	})
 })
+
+// channelAuditSink is an audit.Sink that sends the records it writes to a channel.
+type channelAuditSink struct {
+	records chan audit.Record
+}
+
+func (s *channelAuditSink) Name() string {
+	return "channel"
+}
+
+func (s *channelAuditSink) Write(_ context.Context, records []audit.Record) error {
+	for _, record := range records {
+		s.records <- record
+	}
+	return nil
+}
+
+// metricValue returns the value of the counter or gauge, or the sample count of the histogram, with the name and labels
+// in the controller-runtime metrics registry, or 0 if there is none.
+func metricValue(name string, labels map[string]string) float64 {
+	families, err := crmetrics.Registry.Gather()
+	Expect(err).NotTo(HaveOccurred())
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			metricLabels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				metricLabels[label.GetName()] = label.GetValue()
+			}
+			matches := true
+			for key, value := range labels {
+				matches = matches && metricLabels[key] == value
+			}
+			if !matches {
+				continue
+			}
+			switch {
+			case metric.GetCounter() != nil:
+				return metric.GetCounter().GetValue()
+			case metric.GetGauge() != nil:
+				return metric.GetGauge().GetValue()
+			case metric.GetHistogram() != nil:
+				return float64(metric.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	return 0
+}
@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"slices"
 	"strings"
 	"time"
@@ -29,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/cloudevents"
 	"github.com/argoproj-labs/gitops-promoter/internal/git"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms"
 	bitbucket_cloud "github.com/argoproj-labs/gitops-promoter/internal/scms/bitbucket_cloud"
@@ -37,6 +39,7 @@ import (
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/gitea"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/github"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/gitlab"
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
 	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
@@ -51,16 +54,34 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
+// PullRequestEnqueueFunc is a function type that can be used to enqueue PullRequest reconcile requests without
+// modifying the PullRequest object. This is used by the webhook receiver when the SCM reports a change of a pull request.
+type PullRequestEnqueueFunc func(namespace, name string)
+
 // PullRequestReconciler reconciles a PullRequest object
 type PullRequestReconciler struct {
 	client.Client
 	Scheme      *runtime.Scheme
 	Recorder    events.EventRecorder
 	SettingsMgr *settings.Manager
+
+	// CloudEvents publishes the pull requests that are opened and merged. It may be nil.
+	CloudEvents *cloudevents.Emitter
+
+	// enqueueFunc is set during SetupWithManager and can be retrieved via GetEnqueueFunc.
+	enqueueFunc PullRequestEnqueueFunc
+}
+
+// GetEnqueueFunc returns a function that can be used to enqueue PullRequest reconcile requests.
+// This should be called after SetupWithManager has been called.
+func (r *PullRequestReconciler) GetEnqueueFunc() PullRequestEnqueueFunc {
+	return r.enqueueFunc
 }
 
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=pullrequests,verbs=get;list;watch;create;update;patch;delete
@@ -74,7 +95,7 @@ type PullRequestReconciler struct {
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.17.2/pkg/reconcile
 func (r *PullRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
-	logger.Info("Reconciling PullRequest")
+	logger.V(4).Info("Reconciling PullRequest")
 	startTime := time.Now()
 
 	var pr promoterv1alpha1.PullRequest
@@ -97,7 +118,15 @@ func (r *PullRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
-	provider, err := r.getPullRequestProvider(ctx, pr)
+	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, r.Client, client.ObjectKey{Namespace: pr.Namespace, Name: pr.Spec.RepositoryReference.Name})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+	if wait, result, err := waitForGitRepository(ctx, r.SettingsMgr, &pr, gitRepo); wait {
+		return result, err
+	}
+
+	provider, scmProvider, err := r.getPullRequestProvider(ctx, pr)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to get PullRequest provider: %w", err)
 	}
@@ -132,6 +161,21 @@ func (r *PullRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{RequeueAfter: 1 * time.Microsecond}, nil
 	}
 
+	// The webhook rejects these fields for the SCMs that don't support them, but it is optional. Pull requests that
+	// use them anyway are neither opened nor updated, merging and closing them still works.
+	if pr.Spec.State == promoterv1alpha1.PullRequestOpen {
+		if unsupported := scms.UnsupportedPullRequestFields(scmProvider.GetSpec(), pr.Spec.Labels, pr.Spec.Reviewers, pr.Spec.Draft); len(unsupported) > 0 {
+			meta.SetStatusCondition(pr.GetConditions(), metav1.Condition{
+				Type:               string(promoterConditions.Ready),
+				Status:             metav1.ConditionFalse,
+				Reason:             string(promoterConditions.PullRequestFieldsNotSupported),
+				Message:            fmt.Sprintf("The pull requests of the SCM provider don't support %s", strings.Join(unsupported, ", ")),
+				ObservedGeneration: pr.Generation,
+			})
+			return ctrl.Result{}, nil
+		}
+	}
+
 	// Handle state transitions
 	cleanupRequired, err := r.handleStateTransitions(ctx, &pr, provider)
 	if err != nil {
@@ -150,7 +194,7 @@ func (r *PullRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{RequeueAfter: 1 * time.Microsecond}, nil
 	}
 
-	logger.Info("no known state transitions needed", "specState", pr.Spec.State, "statusState", pr.Status.State)
+	logger.V(4).Info("no known state transitions needed", "specState", pr.Spec.State, "statusState", pr.Status.State)
 
 	requeueDuration, err := settings.GetRequeueDuration[promoterv1alpha1.PullRequestConfiguration](ctx, r.SettingsMgr)
 	if err != nil {
@@ -210,7 +254,7 @@ func (r *PullRequestReconciler) cleanupTerminalStates(ctx context.Context, pr *p
 func (r *PullRequestReconciler) syncStateFromProvider(ctx context.Context, pr *promoterv1alpha1.PullRequest, provider scms.PullRequestProvider, found bool, prID string, prCreationTime time.Time) (bool, error) {
 	logger := log.FromContext(ctx)
 
-	logger.Info("Checking for open PR on provider")
+	logger.V(4).Info("Checking for open PR on provider")
 
 	// Calculate the state of the PR based on the provider, if found we have to be open
 	if found {
@@ -265,11 +309,11 @@ func (r *PullRequestReconciler) syncStateFromProvider(ctx context.Context, pr *p
 func (r *PullRequestReconciler) handleStateTransitions(ctx context.Context, pr *promoterv1alpha1.PullRequest, provider scms.PullRequestProvider) (bool, error) {
 	logger := log.FromContext(ctx)
 
-	logger.Info("Reconciling PullRequest state", "desired", pr.Spec.State, "current", pr.Status.State)
+	logger.V(4).Info("Reconciling PullRequest state", "desired", pr.Spec.State, "current", pr.Status.State)
 
 	if pr.Status.State == pr.Spec.State {
-		logger.Info("Updating PullRequest")
-		if err := r.updatePullRequest(ctx, *pr, provider); err != nil {
+		logger.V(4).Info("Updating PullRequest")
+		if err := r.updatePullRequest(ctx, pr, provider); err != nil {
 			return false, fmt.Errorf("failed to update pull request: %w", err) // Top-level wrap for update errors
 		}
 		return false, nil
@@ -283,12 +327,14 @@ func (r *PullRequestReconciler) handleStateTransitions(ctx context.Context, pr *
 			if err := r.createPullRequest(ctx, pr, provider); err != nil {
 				return false, fmt.Errorf("failed to create pull request: %w", err) // Top-level wrap for create errors
 			}
+			r.emitPullRequestEvent(ctx, pr, cloudevents.TypePullRequestOpened, pr.Status.PRCreationTime.Time)
 		}
 	case promoterv1alpha1.PullRequestMerged:
 		logger.Info("Merging PullRequest")
 		if err := r.mergePullRequest(ctx, pr, provider); err != nil {
 			return false, fmt.Errorf("failed to merge pull request: %w", err) // Top-level wrap for merge errors
 		}
+		r.emitPullRequestEvent(ctx, pr, cloudevents.TypePromotionMerged, time.Now())
 		return true, nil
 	case promoterv1alpha1.PullRequestClosed:
 		logger.Info("Closing PullRequest")
@@ -335,48 +381,79 @@ func (r *PullRequestReconciler) SetupWithManager(ctx context.Context, mgr ctrl.M
 		return fmt.Errorf("failed to get pull request max concurrent reconciles: %w", err)
 	}
 
+	// This gets used by the webhook server to find the PullRequests of a repository that a pull request event is for
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &promoterv1alpha1.PullRequest{}, ".spec.gitRepositoryRef.name", func(rawObj client.Object) []string {
+		//nolint:forcetypeassert // type is guaranteed by the IndexField API
+		pr := rawObj.(*promoterv1alpha1.PullRequest)
+		return []string{pr.Spec.RepositoryReference.Name}
+	}); err != nil {
+		return fmt.Errorf("failed to set field index for .spec.gitRepositoryRef.name: %w", err)
+	}
+
+	// Create a channel for external enqueue requests, like the one of the ChangeTransferPolicy controller.
+	externalEnqueueChan := make(chan event.GenericEvent, 1024)
+	r.enqueueFunc = func(namespace, name string) {
+		pr := &promoterv1alpha1.PullRequest{}
+		pr.SetNamespace(namespace)
+		pr.SetName(name)
+
+		select {
+		case externalEnqueueChan <- event.GenericEvent{Object: pr}:
+			// Sent successfully
+		default:
+			// Channel is full, log a warning and block until space is available
+			log.FromContext(ctx).Info("PullRequest enqueue channel is full, blocking until space is available",
+				"namespace", namespace, "name", name)
+			externalEnqueueChan <- event.GenericEvent{Object: pr}
+		}
+	}
+
 	err = ctrl.NewControllerManagedBy(mgr).
 		For(&promoterv1alpha1.PullRequest{}, builder.WithPredicates(predicate.Or(
 			predicate.GenerationChangedPredicate{},
 			pullRequestDeletionFinalizerLengthChangedPredicate(),
 		))).
+		// Watch for external enqueue requests from the webhook receiver.
+		WatchesRawSource(source.Channel(externalEnqueueChan, &handler.EnqueueRequestForObject{})).
 		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles, RateLimiter: rateLimiter}).
-		Complete(r)
+		Complete(utils.NewDrainingReconciler(utils.NewShardedReconciler(mgr.GetClient(), &promoterv1alpha1.PullRequest{}, tracing.NewReconciler("PullRequest", r))))
 	if err != nil {
 		return fmt.Errorf("failed to create controller: %w", err)
 	}
 	return nil
 }
 
-func (r *PullRequestReconciler) getPullRequestProvider(ctx context.Context, pr promoterv1alpha1.PullRequest) (scms.PullRequestProvider, error) {
+func (r *PullRequestReconciler) getPullRequestProvider(ctx context.Context, pr promoterv1alpha1.PullRequest) (scms.PullRequestProvider, promoterv1alpha1.GenericScmProvider, error) {
 	scmProvider, secret, err := utils.GetScmProviderAndSecretFromRepositoryReference(ctx, r.Client, r.SettingsMgr.GetControllerNamespace(), pr.Spec.RepositoryReference, &pr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get ScmProvider and secret: %w", err)
+		return nil, nil, fmt.Errorf("failed to get ScmProvider and secret: %w", err)
 	}
 
 	gitRepository, err := utils.GetGitRepositoryFromObjectKey(ctx, r.Client, client.ObjectKey{Namespace: pr.Namespace, Name: pr.Spec.RepositoryReference.Name})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get GitRepository: %w", err)
+		return nil, nil, fmt.Errorf("failed to get GitRepository: %w", err)
 	}
 
+	var provider scms.PullRequestProvider
 	switch {
 	case scmProvider.GetSpec().GitHub != nil:
-		return github.NewGithubPullRequestProvider(ctx, r.Client, scmProvider, *secret, gitRepository.Spec.GitHub.Owner) //nolint:wrapcheck // provider factory returns descriptive errors
+		provider, err = github.NewGithubPullRequestProvider(ctx, r.Client, scmProvider, *secret, gitRepository.Spec.GitHub.Owner)
 	case scmProvider.GetSpec().GitLab != nil:
-		return gitlab.NewGitlabPullRequestProvider(r.Client, *secret, scmProvider.GetSpec().GitLab.Domain) //nolint:wrapcheck // provider factory returns descriptive errors
+		provider, err = gitlab.NewGitlabPullRequestProvider(r.Client, *secret, scmProvider.GetSpec().GitLab.Domain)
 	case scmProvider.GetSpec().BitbucketCloud != nil:
-		return bitbucket_cloud.NewBitbucketCloudPullRequestProvider(r.Client, *secret) //nolint:wrapcheck // provider factory returns descriptive errors
+		provider, err = bitbucket_cloud.NewBitbucketCloudPullRequestProvider(r.Client, *secret)
 	case scmProvider.GetSpec().Forgejo != nil:
-		return forgejo.NewForgejoPullRequestProvider(r.Client, *secret, scmProvider.GetSpec().Forgejo.Domain) //nolint:wrapcheck // provider factory returns descriptive errors
+		provider, err = forgejo.NewForgejoPullRequestProvider(r.Client, *secret, scmProvider.GetSpec().Forgejo.Domain)
 	case scmProvider.GetSpec().Gitea != nil:
-		return gitea.NewGiteaPullRequestProvider(r.Client, *secret, scmProvider.GetSpec().Gitea.Domain) //nolint:wrapcheck // provider factory returns descriptive errors
+		provider, err = gitea.NewGiteaPullRequestProvider(r.Client, *secret, scmProvider.GetSpec().Gitea.Domain)
 	case scmProvider.GetSpec().AzureDevOps != nil:
-		return azuredevops.NewAzdoPullRequestProvider(r.Client, *secret, scmProvider, scmProvider.GetSpec().AzureDevOps.Organization) //nolint:wrapcheck,contextcheck // provider factory returns descriptive errors
+		provider, err = azuredevops.NewAzdoPullRequestProvider(ctx, r.Client, *secret, scmProvider, scmProvider.GetSpec().AzureDevOps.Organization)
 	case scmProvider.GetSpec().Fake != nil:
-		return fake.NewFakePullRequestProvider(r.Client), nil
+		provider = fake.NewFakePullRequestProvider(r.Client)
 	default:
-		return nil, fmt.Errorf("unsupported SCM provider: %s", scmProvider.GetName())
+		err = scms.NewUnsupportedScmProviderError(scmProvider)
 	}
+	return provider, scmProvider, err //nolint:wrapcheck // provider factories return descriptive errors
 }
 
 func (r *PullRequestReconciler) handleFinalizer(ctx context.Context, pr *promoterv1alpha1.PullRequest, provider scms.PullRequestProvider, found bool) (bool, error) {
@@ -429,24 +506,35 @@ func (r *PullRequestReconciler) createPullRequest(ctx context.Context, pr *promo
 	pr.Status.State = promoterv1alpha1.PullRequestOpen
 	pr.Status.PRCreationTime = metav1.Now()
 	pr.Status.ID = id
+	setAppliedPullRequestDetails(pr)
 
 	url, err := provider.GetUrl(ctx, *pr)
 	if err != nil {
 		return fmt.Errorf("failed to get pull request URL: %w", err)
 	}
 	pr.Status.Url = url
+	r.Recorder.Eventf(pr, nil, "Normal", constants.PullRequestOpenedReason, "OpeningPullRequest", constants.PullRequestOpenedMessage, pr.Name, url)
 
 	return nil
 }
 
-func (r *PullRequestReconciler) updatePullRequest(ctx context.Context, pr promoterv1alpha1.PullRequest, provider scms.PullRequestProvider) error {
-	if err := provider.Update(ctx, pr.Spec.Title, pr.Spec.Description, pr); err != nil {
+func (r *PullRequestReconciler) updatePullRequest(ctx context.Context, pr *promoterv1alpha1.PullRequest, provider scms.PullRequestProvider) error {
+	if err := provider.Update(ctx, pr.Spec.Title, pr.Spec.Description, *pr); err != nil {
 		return err //nolint:wrapcheck // Error wrapping handled at top level
 	}
-	r.Recorder.Eventf(&pr, nil, "Normal", constants.PullRequestUpdatedReason, "UpdatingPullRequest", "Pull Request %s updated", pr.Name)
+	setAppliedPullRequestDetails(pr)
+	r.Recorder.Eventf(pr, nil, "Normal", constants.PullRequestUpdatedReason, "UpdatingPullRequest", constants.PullRequestUpdatedMessage, pr.Name)
 	return nil
 }
 
+// setAppliedPullRequestDetails records the labels, reviewers and draft the pull request was opened or updated with,
+// so that the next update only changes the ones that changed in the spec since.
+func setAppliedPullRequestDetails(pr *promoterv1alpha1.PullRequest) {
+	pr.Status.Labels = slices.Clone(pr.Spec.Labels)
+	pr.Status.Reviewers = slices.Clone(pr.Spec.Reviewers)
+	pr.Status.Draft = pr.Spec.Draft
+}
+
 func (r *PullRequestReconciler) mergePullRequest(ctx context.Context, pr *promoterv1alpha1.PullRequest, provider scms.PullRequestProvider) error {
 	mergedTime := metav1.Now()
 
@@ -467,9 +555,42 @@ func (r *PullRequestReconciler) mergePullRequest(ctx context.Context, pr *promot
 		return err //nolint:wrapcheck // Error wrapping handled at top level
 	}
 	pr.Status.State = promoterv1alpha1.PullRequestMerged
+	r.Recorder.Eventf(pr, nil, "Normal", constants.PullRequestMergedReason, "MergingPullRequest", constants.PullRequestMergedMessage, pr.Name)
 	return nil
 }
 
+// emitPullRequestEvent emits a CloudEvent of the type for the pull request. The PromotionStrategy and the dry sha are
+// looked up through the ChangeTransferPolicy owning the pull request. If it can't be found, the PromotionStrategy is
+// taken from the pull request's label, whose value may be truncated.
+func (r *PullRequestReconciler) emitPullRequestEvent(ctx context.Context, pr *promoterv1alpha1.PullRequest, eventType string, eventTime time.Time) {
+	if r.CloudEvents == nil {
+		return
+	}
+	data := cloudevents.Data{
+		Namespace:         pr.Namespace,
+		PromotionStrategy: pr.Labels[promoterv1alpha1.PromotionStrategyLabel],
+		Environment:       pr.Spec.TargetBranch,
+		HydratedSha:       pr.Spec.MergeSha,
+		PullRequestURL:    pr.Status.Url,
+		Time:              eventTime,
+	}
+	if !pr.Status.PRCreationTime.IsZero() {
+		data.PullRequestCreationTime = &pr.Status.PRCreationTime.Time
+	}
+	if owner := metav1.GetControllerOf(pr); owner != nil && owner.Kind == reflect.TypeOf(promoterv1alpha1.ChangeTransferPolicy{}).Name() {
+		var ctp promoterv1alpha1.ChangeTransferPolicy
+		if err := r.Get(ctx, client.ObjectKey{Namespace: pr.Namespace, Name: owner.Name}, &ctp); err != nil {
+			log.FromContext(ctx).V(4).Info("unable to get the ChangeTransferPolicy of the CloudEvent", "error", err)
+		} else {
+			data.DrySha = ctp.Status.Proposed.Dry.Sha
+			if psOwner := metav1.GetControllerOf(&ctp); psOwner != nil && psOwner.Kind == reflect.TypeOf(promoterv1alpha1.PromotionStrategy{}).Name() {
+				data.PromotionStrategy = psOwner.Name
+			}
+		}
+	}
+	r.CloudEvents.Emit(ctx, cloudevents.Event{Type: eventType, Data: data})
+}
+
 type trailers map[string]string
 
 func (t trailers) String() string {
@@ -495,5 +616,6 @@ func (r *PullRequestReconciler) closePullRequest(ctx context.Context, pr *promot
 		return err //nolint:wrapcheck // Error wrapping handled at top level
 	}
 	pr.Status.State = promoterv1alpha1.PullRequestClosed
+	r.Recorder.Eventf(pr, nil, "Normal", constants.PullRequestClosedReason, "ClosingPullRequest", constants.PullRequestClosedMessage, pr.Name)
 	return nil
 }
@@ -17,12 +17,20 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
 	"context"
 	_ "embed"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/argoproj-labs/gitops-promoter/internal/git"
+	"github.com/argoproj-labs/gitops-promoter/internal/settings"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -30,17 +38,37 @@ import (
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/fake"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+	"github.com/argoproj-labs/gitops-promoter/internal/webhookreceiver"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 )
 
 //go:embed testdata/PullRequest.yaml
 var testPullRequestYAML string
 
+//go:embed testdata/GitHubPullRequestClosedEvent.json
+var testGitHubPullRequestClosedEvent []byte
+
+//go:embed testdata/GitLabMergeRequestHookEvent.json
+var testGitLabMergeRequestHookEvent []byte
+
+//go:embed testdata/BitbucketCloudPullRequestFulfilledEvent.json
+var testBitbucketCloudPullRequestFulfilledEvent []byte
+
+//go:embed testdata/BitbucketDataCenterPullRequestMergedEvent.json
+var testBitbucketDataCenterPullRequestMergedEvent []byte
+
 var _ = Describe("PullRequest Controller", func() {
 	var ctx context.Context
 
@@ -891,6 +919,290 @@ var _ = Describe("PullRequest Controller", func() {
 		})
 	})
 
+	Context("When GitHub sends a pull_request event for a merged PullRequest", func() {
+		var name string
+		var scmSecret *v1.Secret
+		var scmProvider *promoterv1alpha1.ScmProvider
+		var gitRepo *promoterv1alpha1.GitRepository
+		var pullRequest *promoterv1alpha1.PullRequest
+		var typeNamespacedName types.NamespacedName
+
+		BeforeEach(func() {
+			By("Creating test resources")
+			name, scmSecret, scmProvider, gitRepo, pullRequest = pullRequestResources(ctx, "pull-request-event")
+
+			typeNamespacedName = types.NamespacedName{
+				Name:      name,
+				Namespace: "default",
+			}
+
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			Expect(k8sClient.Create(ctx, pullRequest)).To(Succeed())
+
+			By("Waiting for PullRequest to be open")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, typeNamespacedName, pullRequest)).To(Succeed())
+				g.Expect(pullRequest.Status.State).To(Equal(promoterv1alpha1.PullRequestOpen))
+				g.Expect(pullRequest.Status.ID).ToNot(BeEmpty())
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+
+		It("should reconcile the PullRequest without waiting for the requeue", func() {
+			By("Holding the PullRequest with a finalizer, so that its status can be read after it is cleaned up")
+			const holdFinalizer = "test.promoter.argoproj.io/hold"
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, typeNamespacedName, pullRequest)).To(Succeed())
+				controllerutil.AddFinalizer(pullRequest, holdFinalizer)
+				g.Expect(k8sClient.Update(ctx, pullRequest)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			DeferCleanup(func() {
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, typeNamespacedName, pullRequest)
+					if k8serrors.IsNotFound(err) {
+						return
+					}
+					g.Expect(err).NotTo(HaveOccurred())
+					controllerutil.RemoveFinalizer(pullRequest, holdFinalizer)
+					g.Expect(k8sClient.Update(ctx, pullRequest)).To(Succeed())
+				}, constants.EventuallyTimeout).Should(Succeed())
+			})
+
+			By("Merging the pull request on the SCM")
+			fakeProvider := fake.NewFakePullRequestProvider(k8sClient)
+			Expect(fakeProvider.DeletePullRequest(ctx, *pullRequest)).To(Succeed())
+
+			By("Sending the recorded merged event for the pull request")
+			var payload map[string]any
+			Expect(json.Unmarshal(testGitHubPullRequestClosedEvent, &payload)).To(Succeed())
+			number, err := strconv.Atoi(pullRequest.Status.ID)
+			Expect(err).NotTo(HaveOccurred())
+			payload["number"] = number
+			payload["repository"].(map[string]any)["full_name"] = gitRepo.Spec.Fake.Owner + "/" + gitRepo.Spec.Fake.Name
+			payload["pull_request"].(map[string]any)["head"].(map[string]any)["ref"] = pullRequest.Spec.SourceBranch
+			payload["pull_request"].(map[string]any)["base"].(map[string]any)["ref"] = pullRequest.Spec.TargetBranch
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://localhost:%d/", webhookReceiverPort), bytes.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Github-Event", "pull_request")
+			req.Header.Set("X-Github-Delivery", "pull-request-event-"+name)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+			Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+
+			By("Verifying the PullRequest's status transitions from open to externally merged or closed")
+			// The PullRequest's requeue duration is longer than the timeout, only the event can trigger the reconcile
+			// that notices the merge. It is deleted right after ExternallyMergedOrClosed is set, the hold finalizer
+			// keeps it around to read the status.
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, typeNamespacedName, pullRequest)).To(Succeed())
+				g.Expect(pullRequest.Status.ExternallyMergedOrClosed).To(HaveValue(BeTrue()))
+				g.Expect(pullRequest.Status.State).To(BeEmpty(), "the state of an externally merged or closed PullRequest is unknown")
+				g.Expect(pullRequest.DeletionTimestamp.IsZero()).To(BeFalse(), "the PullRequest should be cleaned up")
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+
+		It("should ignore events for pull requests it doesn't manage", func() {
+			var payload map[string]any
+			Expect(json.Unmarshal(testGitHubPullRequestClosedEvent, &payload)).To(Succeed())
+			payload["repository"].(map[string]any)["full_name"] = "unknown/" + name
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://localhost:%d/", webhookReceiverPort), bytes.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("X-Github-Event", "pull_request")
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+			Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+		})
+	})
+
+	Context("When GitLab sends a Merge Request Hook for a merged PullRequest", func() {
+		var name string
+		var scmSecret *v1.Secret
+		var scmProvider *promoterv1alpha1.ScmProvider
+		var gitRepo *promoterv1alpha1.GitRepository
+		var pullRequest *promoterv1alpha1.PullRequest
+		var typeNamespacedName types.NamespacedName
+
+		BeforeEach(func() {
+			By("Creating test resources")
+			name, scmSecret, scmProvider, gitRepo, pullRequest = pullRequestResources(ctx, "merge-request-hook")
+
+			typeNamespacedName = types.NamespacedName{
+				Name:      name,
+				Namespace: "default",
+			}
+
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			Expect(k8sClient.Create(ctx, pullRequest)).To(Succeed())
+
+			By("Waiting for PullRequest to be open")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, typeNamespacedName, pullRequest)).To(Succeed())
+				g.Expect(pullRequest.Status.State).To(Equal(promoterv1alpha1.PullRequestOpen))
+				g.Expect(pullRequest.Status.ID).ToNot(BeEmpty())
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+
+		It("should reconcile the PullRequest without waiting for the requeue", func() {
+			By("Holding the PullRequest with a finalizer, so that its status can be read after it is cleaned up")
+			const holdFinalizer = "test.promoter.argoproj.io/hold"
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, typeNamespacedName, pullRequest)).To(Succeed())
+				controllerutil.AddFinalizer(pullRequest, holdFinalizer)
+				g.Expect(k8sClient.Update(ctx, pullRequest)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			DeferCleanup(func() {
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, typeNamespacedName, pullRequest)
+					if k8serrors.IsNotFound(err) {
+						return
+					}
+					g.Expect(err).NotTo(HaveOccurred())
+					controllerutil.RemoveFinalizer(pullRequest, holdFinalizer)
+					g.Expect(k8sClient.Update(ctx, pullRequest)).To(Succeed())
+				}, constants.EventuallyTimeout).Should(Succeed())
+			})
+
+			By("Merging the pull request on the SCM")
+			fakeProvider := fake.NewFakePullRequestProvider(k8sClient)
+			Expect(fakeProvider.DeletePullRequest(ctx, *pullRequest)).To(Succeed())
+
+			By("Sending the recorded merge event for the merge request")
+			var payload map[string]any
+			Expect(json.Unmarshal(testGitLabMergeRequestHookEvent, &payload)).To(Succeed())
+			iid, err := strconv.Atoi(pullRequest.Status.ID)
+			Expect(err).NotTo(HaveOccurred())
+			payload["project"].(map[string]any)["path_with_namespace"] = gitRepo.Spec.Fake.Owner + "/" + gitRepo.Spec.Fake.Name
+			attributes := payload["object_attributes"].(map[string]any)
+			attributes["iid"] = iid
+			attributes["source_branch"] = pullRequest.Spec.SourceBranch
+			attributes["target_branch"] = pullRequest.Spec.TargetBranch
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(postWebhookDelivery(ctx, map[string]string{"X-Gitlab-Event": "Merge Request Hook"}, body)).To(Equal(http.StatusNoContent))
+
+			By("Verifying the PullRequest is deleted once it is marked as externally merged")
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, typeNamespacedName, pullRequest)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+
+		It("should ignore actions that don't change the merge request's state", func() {
+			var payload map[string]any
+			Expect(json.Unmarshal(testGitLabMergeRequestHookEvent, &payload)).To(Succeed())
+			payload["project"].(map[string]any)["path_with_namespace"] = gitRepo.Spec.Fake.Owner + "/" + gitRepo.Spec.Fake.Name
+			payload["object_attributes"].(map[string]any)["action"] = "approved"
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(postWebhookDelivery(ctx, map[string]string{"X-Gitlab-Event": "Merge Request Hook"}, body)).To(Equal(http.StatusAccepted))
+		})
+	})
+
+	Context("When Bitbucket sends a pull request event for a merged PullRequest", func() {
+		var name string
+		var scmSecret *v1.Secret
+		var scmProvider *promoterv1alpha1.ScmProvider
+		var gitRepo *promoterv1alpha1.GitRepository
+		var pullRequest *promoterv1alpha1.PullRequest
+		var typeNamespacedName types.NamespacedName
+
+		BeforeEach(func() {
+			By("Creating test resources")
+			name, scmSecret, scmProvider, gitRepo, pullRequest = pullRequestResources(ctx, "bitbucket-pr-event")
+
+			typeNamespacedName = types.NamespacedName{
+				Name:      name,
+				Namespace: "default",
+			}
+
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			Expect(k8sClient.Create(ctx, pullRequest)).To(Succeed())
+
+			By("Waiting for PullRequest to be open")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, typeNamespacedName, pullRequest)).To(Succeed())
+				g.Expect(pullRequest.Status.State).To(Equal(promoterv1alpha1.PullRequestOpen))
+				g.Expect(pullRequest.Status.ID).ToNot(BeEmpty())
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Merging the pull request on the SCM")
+			fakeProvider := fake.NewFakePullRequestProvider(k8sClient)
+			Expect(fakeProvider.DeletePullRequest(ctx, *pullRequest)).To(Succeed())
+		})
+
+		expectDeleted := func() {
+			By("Verifying the PullRequest is deleted once it is marked as externally merged")
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, typeNamespacedName, pullRequest)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}, constants.EventuallyTimeout).Should(Succeed())
+		}
+
+		It("should reconcile the PullRequest on a Bitbucket Cloud pullrequest:fulfilled event", func() {
+			var payload map[string]any
+			Expect(json.Unmarshal(testBitbucketCloudPullRequestFulfilledEvent, &payload)).To(Succeed())
+			id, err := strconv.Atoi(pullRequest.Status.ID)
+			Expect(err).NotTo(HaveOccurred())
+			payload["repository"].(map[string]any)["full_name"] = gitRepo.Spec.Fake.Owner + "/" + gitRepo.Spec.Fake.Name
+			pr := payload["pullrequest"].(map[string]any)
+			pr["id"] = id
+			pr["source"].(map[string]any)["branch"] = map[string]any{"name": pullRequest.Spec.SourceBranch}
+			pr["destination"].(map[string]any)["branch"] = map[string]any{"name": pullRequest.Spec.TargetBranch}
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(postWebhookDelivery(ctx, map[string]string{
+				"X-Event-Key": "pullrequest:fulfilled",
+				"X-Hook-UUID": "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e",
+			}, body)).To(Equal(http.StatusNoContent))
+
+			expectDeleted()
+		})
+
+		It("should reconcile the PullRequest on a Bitbucket Data Center pr:merged event", func() {
+			var payload map[string]any
+			Expect(json.Unmarshal(testBitbucketDataCenterPullRequestMergedEvent, &payload)).To(Succeed())
+			id, err := strconv.Atoi(pullRequest.Status.ID)
+			Expect(err).NotTo(HaveOccurred())
+			pr := payload["pullRequest"].(map[string]any)
+			pr["id"] = id
+			pr["fromRef"].(map[string]any)["displayId"] = pullRequest.Spec.SourceBranch
+			toRef := pr["toRef"].(map[string]any)
+			toRef["displayId"] = pullRequest.Spec.TargetBranch
+			repository := toRef["repository"].(map[string]any)
+			repository["slug"] = gitRepo.Spec.Fake.Name
+			repository["project"].(map[string]any)["key"] = gitRepo.Spec.Fake.Owner
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(postWebhookDelivery(ctx, map[string]string{
+				"X-Event-Key":  "pr:merged",
+				"X-Request-Id": "5b7f3a2c-9d4e-4f1a-8c6b-0e2d1f3a4b5c",
+			}, body)).To(Equal(http.StatusNoContent))
+
+			expectDeleted()
+		})
+
+		It("should ignore events it doesn't handle", func() {
+			Expect(postWebhookDelivery(ctx, map[string]string{
+				"X-Event-Key": "pullrequest:comment_created",
+				"X-Hook-UUID": "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e",
+			}, testBitbucketCloudPullRequestFulfilledEvent)).To(Equal(http.StatusAccepted))
+		})
+	})
+
 	Context("When deleting a PullRequest that already has an SCM PR but is blocked by another finalizer", func() {
 		const blockingFinalizer = "promoter.argoproj.io/test-will-not-remove"
 
@@ -1006,6 +1318,120 @@ var _ = Describe("PullRequest Controller", func() {
 	})
 })
 
+var _ = Describe("PullRequest merge on shutdown", func() {
+	It("should record a merge that is in flight when the manager stops", func() {
+		By("Starting a separate test environment, so that the suite's manager doesn't reconcile its resources")
+		shutdownTestEnv, shutdownCfg, shutdownClient := createAndStartTestEnv()
+		DeferCleanup(func() {
+			Expect(shutdownTestEnv.Stop()).To(Succeed())
+		})
+
+		controllerConfiguration, err := loadShippedControllerConfigurationForTests("default", settings.ControllerConfigurationName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(shutdownClient.Create(ctx, controllerConfiguration)).To(Succeed())
+
+		name, scmSecret, scmProvider, gitRepo, _, ctp := changeTransferPolicyResources(ctx, "ctp-merge-on-shutdown", "default")
+		ctp.Spec.ProposedBranch = testBranchDevelopmentNext
+		ctp.Spec.ActiveBranch = testBranchDevelopment
+		ctp.Spec.AutoMerge = ptr.To(true)
+		for _, obj := range []client.Object{scmSecret, scmProvider, gitRepo, ctp} {
+			Expect(shutdownClient.Create(ctx, obj)).To(Succeed())
+		}
+		prName := utils.KubeSafeUniqueName(ctx, utils.GetPullRequestName(gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name, testBranchDevelopmentNext, testBranchDevelopment))
+
+		By("Stopping the manager once the fake SCM merged the pull request")
+		mgrCtx, stopMgr := context.WithCancel(ctx)
+		DeferCleanup(stopMgr)
+		merged := make(chan struct{})
+		var mergeOnce sync.Once
+		fake.SetMergeHook(func(_ context.Context, pullRequest promoterv1alpha1.PullRequest) {
+			if pullRequest.Spec.RepositoryReference.Name != gitRepo.Name {
+				return
+			}
+			// Stop the manager as if the pod got SIGTERM while the merge was in flight, after the SCM merged it but
+			// before the PullRequest status records it.
+			mergeOnce.Do(func() {
+				close(merged)
+				stopMgr()
+			})
+		})
+		DeferCleanup(func() { fake.SetMergeHook(nil) })
+		stopped := startMergeTestManager(mgrCtx, shutdownCfg)
+
+		gitPath, err := os.MkdirTemp("", "*")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, gitPath)
+		drySha, _ := makeChangeAndHydrateRepo(gitPath, gitRepo, "", "")
+
+		Eventually(merged, constants.EventuallyTimeout).Should(BeClosed())
+		Eventually(stopped, constants.EventuallyTimeout).Should(BeClosed())
+
+		By("Checking that the PullRequest status recorded the merge before the manager stopped")
+		var pr promoterv1alpha1.PullRequest
+		Expect(shutdownClient.Get(ctx, types.NamespacedName{Name: prName, Namespace: "default"}, &pr)).To(Succeed())
+		Expect(pr.Spec.State).To(Equal(promoterv1alpha1.PullRequestMerged))
+		Expect(pr.Status.State).To(Equal(promoterv1alpha1.PullRequestMerged))
+
+		By("Restarting the manager and checking that the ChangeTransferPolicy status records the merge")
+		restartCtx, stopRestarted := context.WithCancel(ctx)
+		restarted := startMergeTestManager(restartCtx, shutdownCfg)
+		DeferCleanup(func() {
+			stopRestarted()
+			Eventually(restarted, constants.EventuallyTimeout).Should(BeClosed())
+		})
+
+		Eventually(func(g Gomega) {
+			g.Expect(shutdownClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, ctp)).To(Succeed())
+			g.Expect(ctp.Status.PullRequest).NotTo(BeNil())
+			g.Expect(ctp.Status.PullRequest.State).To(Equal(promoterv1alpha1.PullRequestMerged))
+			g.Expect(ctp.Status.Active.Dry.Sha).To(Equal(drySha))
+		}, constants.EventuallyTimeout).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			err := shutdownClient.Get(ctx, types.NamespacedName{Name: prName, Namespace: "default"}, &pr)
+			g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		}, constants.EventuallyTimeout).Should(Succeed())
+	})
+})
+
+// startMergeTestManager starts a manager that runs the ChangeTransferPolicy and PullRequest controllers against cfg
+// until ctx is done. The returned channel is closed once the manager stopped.
+func startMergeTestManager(ctx context.Context, cfg *rest.Config) <-chan struct{} {
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+		// The suite's manager runs controllers with the same names.
+		Controller: config.Controller{SkipNameValidation: ptr.To(true)},
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	settingsMgr := settings.NewManager(mgr.GetClient(), mgr.GetAPIReader(), settings.ManagerConfig{
+		ControllerNamespace: "default",
+	})
+	Expect((&ChangeTransferPolicyReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		Recorder:          mgr.GetEventRecorder("ChangeTransferPolicy"),
+		SettingsMgr:       settingsMgr,
+		GitRepoManager:    git.NewGitRepoManager(),
+		WebhookDeliveries: webhookreceiver.NewDeliveryTracker(),
+	}).SetupWithManager(ctx, mgr)).To(Succeed())
+	Expect((&PullRequestReconciler{
+		Client:      mgr.GetClient(),
+		Scheme:      mgr.GetScheme(),
+		Recorder:    mgr.GetEventRecorder("PullRequest"),
+		SettingsMgr: settingsMgr,
+	}).SetupWithManager(ctx, mgr)).To(Succeed())
+
+	stopped := make(chan struct{})
+	go func() {
+		defer GinkgoRecover()
+		defer close(stopped)
+		Expect(mgr.Start(ctx)).To(Succeed())
+	}()
+	return stopped
+}
+
 func pullRequestResources(ctx context.Context, name string) (string, *v1.Secret, *promoterv1alpha1.ScmProvider, *promoterv1alpha1.GitRepository, *promoterv1alpha1.PullRequest) {
 	name = name + "-" + utils.KubeSafeUniqueName(ctx, randomString(15))
 	gitRepo := &promoterv1alpha1.GitRepository{
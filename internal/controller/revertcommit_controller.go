@@ -18,55 +18,814 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
+	"time"
 
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	acmetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/retry"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	acv1alpha1 "github.com/argoproj-labs/gitops-promoter/applyconfiguration/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/cloudevents"
+	"github.com/argoproj-labs/gitops-promoter/internal/git"
+	"github.com/argoproj-labs/gitops-promoter/internal/gitauth"
+	"github.com/argoproj-labs/gitops-promoter/internal/settings"
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
+	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
+	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 )
 
 // RevertCommitReconciler reconciles a RevertCommit object
 type RevertCommitReconciler struct {
 	client.Client
-	Scheme   *runtime.Scheme
-	Recorder events.EventRecorder
+	Scheme      *runtime.Scheme
+	Recorder    events.EventRecorder
+	SettingsMgr *settings.Manager
+
+	// GitRepoManager provides the git operations of the dry branch and the environments.
+	GitRepoManager git.GitRepoManager
+
+	// CloudEvents publishes the reverts that complete. It may be nil.
+	CloudEvents *cloudevents.Emitter
 }
 
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=revertcommits,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=revertcommits/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=revertcommits/finalizers,verbs=update
+//+kubebuilder:rbac:groups=promoter.argoproj.io,resources=pullrequests,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the RevertCommit object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
+// Reconcile reverts the dry commit of a RevertCommit on a branch of its own and opens a PullRequest that merges the
+// revert into the dry branch, from where it is hydrated and promoted like any other change. A RevertCommit scoped to
+// environments leaves the dry branch alone and reverts the commit in each of those environments instead. A RevertCommit
+// with target hydrated reverts a hydrated commit directly on its environment's active branch, through a pull request
+// into that branch.
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.17.2/pkg/reconcile
-func (r *RevertCommitReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = log.FromContext(ctx)
+func (r *RevertCommitReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	logger := log.FromContext(ctx)
+	logger.V(4).Info("Reconciling RevertCommit")
+	startTime := time.Now()
+
+	var rc promoterv1alpha1.RevertCommit
+	// This function applies the resource status via Server-Side Apply at the end of the reconciliation. Don't write status manually.
+	defer utils.HandleReconciliationResult(ctx, startTime, &rc, r.Client, r.Recorder, constants.RevertCommitControllerFieldOwner, &result, &err)
+
+	err = r.Get(ctx, req.NamespacedName, &rc, &client.GetOptions{})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			logger.Info("RevertCommit not found")
+			return ctrl.Result{}, nil
+		}
+
+		logger.Error(err, "failed to get RevertCommit")
+		return ctrl.Result{}, fmt.Errorf("failed to get RevertCommit: %w", err)
+	}
+
+	if deleted, err := r.handleFinalizer(ctx, &rc); err != nil || deleted {
+		return ctrl.Result{}, err
+	}
+
+	if rc.Status.ObservedGeneration == rc.Generation && rc.Status.Phase.IsTerminal() {
+		// A finished revert is never redone, only a spec change starts over. Its conditions are kept as they are.
+		logger.V(4).Info("RevertCommit is finished", "phase", rc.Status.Phase)
+		return ctrl.Result{}, nil
+	}
+
+	// Remove any existing Ready condition. We want to start fresh.
+	meta.RemoveStatusCondition(rc.GetConditions(), string(promoterConditions.Ready))
+
+	previousPhase := rc.Status.Phase
+	defer func() {
+		// Runs before the status is applied, on every exit path from here on.
+		r.setPhaseConditions(&rc, previousPhase)
+		r.emitRollbackCompleted(ctx, &rc, previousPhase)
+	}()
+
+	rc.Status.ShortSha = rc.Spec.Sha[:7]
+	if rc.Status.ObservedGeneration != rc.Generation {
+		// The revert and the pull request of an earlier generation may be for a different commit or branch.
+		rc.Status.Phase = ""
+		rc.Status.RevertSha = ""
+		rc.Status.DrySha = ""
+		rc.Status.PullRequestName = ""
+		rc.Status.PullRequest = nil
+		rc.Status.Url = ""
+		rc.Status.Environments = nil
+	}
 
-	// TODO(user): your logic here
+	var ps promoterv1alpha1.PromotionStrategy
+	err = r.Get(ctx, client.ObjectKey{Namespace: rc.Namespace, Name: rc.Spec.PromotionStrategyRef.Name}, &ps)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get PromotionStrategy %q: %w", rc.Spec.PromotionStrategyRef.Name, err)
+	}
+	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, r.Client, client.ObjectKey{Namespace: rc.Namespace, Name: ps.Spec.RepositoryReference.Name})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+	if wait, result, err := waitForGitRepository(ctx, r.SettingsMgr, &rc, gitRepo); wait {
+		return result, err
+	}
+
+	hydrated := rc.Spec.Target == promoterv1alpha1.RevertTargetHydrated
+	if len(rc.Spec.Environments) > 0 && !hydrated {
+		return ctrl.Result{}, r.revertEnvironments(ctx, &rc, &ps, gitRepo)
+	}
+
+	targetBranch := revertTargetBranch(&rc)
+	if hydrated {
+		if _, environment := utils.GetEnvironmentByBranch(ps, targetBranch); environment == nil {
+			// Retrying won't help until the RevertCommit or the PromotionStrategy is changed.
+			rc.Status.Phase = promoterv1alpha1.RevertCommitPhaseReverting
+			meta.SetStatusCondition(rc.GetConditions(), metav1.Condition{
+				Type:               string(promoterConditions.Ready),
+				Status:             metav1.ConditionFalse,
+				Reason:             string(promoterConditions.EnvironmentNotFound),
+				Message:            fmt.Sprintf("Environment %q is not part of PromotionStrategy %q", targetBranch, ps.Name),
+				ObservedGeneration: rc.Generation,
+			})
+			return ctrl.Result{}, nil
+		}
+	}
+
+	revertBranch := revertCommitBranch(&rc)
+	prName, err := pullRequestName(gitRepo, revertBranch, targetBranch)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	prName = utils.KubeSafeUniqueName(ctx, prName)
+
+	existingPR := &promoterv1alpha1.PullRequest{}
+	prExists := true
+	if err = r.Get(ctx, client.ObjectKey{Namespace: rc.Namespace, Name: prName}, existingPR); err != nil {
+		if !k8s_errors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to get PullRequest %q: %w", prName, err)
+		}
+		prExists = false
+	}
+
+	if !prExists && rc.Status.PullRequest != nil && rc.Status.PullRequest.ID != "" {
+		// The PullRequest controller deletes the PullRequest once it is merged or closed, the revert is done.
+		merged, err := r.revertPullRequestMerged(ctx, &rc, &ps, gitRepo, targetBranch)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		logger.V(4).Info("Revert pull request is finished", "pullRequest", prName, "merged", merged)
+		rc.Status.Phase = promoterv1alpha1.RevertCommitPhaseClosed
+		if merged {
+			rc.Status.Phase = promoterv1alpha1.RevertCommitPhaseMerged
+			rc.Status.PullRequest.State = promoterv1alpha1.PullRequestMerged
+			r.Recorder.Eventf(&rc, nil, "Normal", constants.RevertMergedReason, "MergingRevert", constants.RevertMergedMessage, rc.Status.PullRequest.Url, targetBranch)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	var gitOperations *git.EnvironmentOperations
+	if rc.Status.RevertSha == "" || !prExists {
+		rc.Status.Phase = promoterv1alpha1.RevertCommitPhaseCloning
+		gitOperations, err = r.cloneDryBranch(ctx, &rc, &ps, gitRepo)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if rc.Status.RevertSha == "" {
+		rc.Status.Phase = promoterv1alpha1.RevertCommitPhaseReverting
+		if !rc.Spec.Force {
+			// Reverting a commit that newer commits build on tends to conflict or to revert the wrong thing, warn early.
+			err = gitOperations.CheckSuperseded(ctx, rc.Spec.Sha, targetBranch, rc.Spec.MaxDistanceFromTip)
+			var supersededErr *git.SupersededTargetError
+			if errors.As(err, &supersededErr) {
+				meta.SetStatusCondition(rc.GetConditions(), metav1.Condition{
+					Type:               string(promoterConditions.Ready),
+					Status:             metav1.ConditionFalse,
+					Reason:             string(promoterConditions.SupersededTargetRequiresForce),
+					Message:            supersededErr.Error() + "; set spec.force to revert it anyway",
+					ObservedGeneration: rc.Generation,
+				})
+				return ctrl.Result{}, nil
+			}
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to check whether commit %q is superseded: %w", rc.Spec.Sha, err)
+			}
+		}
+		// Revert-commit lets the PromotionStrategy recognize the revert in the history of the environments.
+		trailers := map[string]string{constants.TrailerRevertCommit: rc.Name}
+		if hydrated {
+			// Tells the hydrator that the environment branch no longer matches what it hydrated.
+			trailers[constants.TrailerEmergencyRevert] = rc.Spec.Sha
+		}
+		var templateErr error
+		var revertMessage git.RevertMessageFunc
+		if rc.Spec.CommitMessageTemplate != "" {
+			revertMessage = func(reverted promoterv1alpha1.CommitShaState) (string, error) {
+				message, err := utils.RenderRevertCommitMessage(&rc, reverted)
+				templateErr = err
+				return message, err
+			}
+		}
+		revertSha, err := gitOperations.Revert(ctx, rc.Spec.Sha, targetBranch, revertBranch, revertMessage, trailers)
+		if err != nil {
+			if templateErr != nil {
+				// Retrying won't help until the template is fixed.
+				meta.SetStatusCondition(rc.GetConditions(), metav1.Condition{
+					Type:               string(promoterConditions.Ready),
+					Status:             metav1.ConditionFalse,
+					Reason:             string(promoterConditions.CommitMessageTemplateInvalid),
+					Message:            templateErr.Error(),
+					ObservedGeneration: rc.Generation,
+				})
+				return ctrl.Result{}, nil
+			}
+			var conflictErr *git.RevertConflictError
+			if errors.As(err, &conflictErr) {
+				// Retrying won't resolve the conflict, someone has to revert the commit by hand.
+				rc.Status.Phase = promoterv1alpha1.RevertCommitPhaseConflicted
+				meta.SetStatusCondition(rc.GetConditions(), metav1.Condition{
+					Type:               string(promoterConditions.Ready),
+					Status:             metav1.ConditionFalse,
+					Reason:             string(promoterConditions.RevertConflict),
+					Message:            conflictErr.Error(),
+					ObservedGeneration: rc.Generation,
+				})
+				meta.SetStatusCondition(rc.GetConditions(), metav1.Condition{
+					Type:               string(promoterConditions.Conflicted),
+					Status:             metav1.ConditionTrue,
+					Reason:             string(promoterConditions.RevertConflict),
+					Message:            conflictErr.Error(),
+					ObservedGeneration: rc.Generation,
+				})
+				return ctrl.Result{}, nil
+			}
+			var notOnBranchErr *git.CommitNotOnBranchError
+			if errors.As(err, &notOnBranchErr) {
+				// The commit was dropped from the dry branch, e.g. by rewriting its history, there is nothing to revert.
+				rc.Status.Phase = promoterv1alpha1.RevertCommitPhaseSuperseded
+				meta.SetStatusCondition(rc.GetConditions(), metav1.Condition{
+					Type:               string(promoterConditions.Ready),
+					Status:             metav1.ConditionFalse,
+					Reason:             string(promoterConditions.CommitSuperseded),
+					Message:            notOnBranchErr.Error(),
+					ObservedGeneration: rc.Generation,
+				})
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("failed to revert commit %q: %w", rc.Spec.Sha, err)
+		}
+		if hydrated {
+			hydratedFrom, err := gitOperations.GetShaMetadataFromFile(ctx, rc.Spec.Sha)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to get commit metadata for hydrated SHA %q: %w", rc.Spec.Sha, err)
+			}
+			rc.Status.DrySha = hydratedFrom.Sha
+		}
+		rc.Status.RevertSha = revertSha
+		r.Recorder.Eventf(&rc, nil, "Normal", constants.RevertCreatedReason, "Reverting", constants.RevertCreatedMessage, rc.Spec.Sha, targetBranch, revertSha, revertBranch)
+	}
+	rc.Status.RevertBranch = revertBranch
+
+	pr, err := r.applyPullRequest(ctx, &rc, &ps, prName, existingPR, prExists, gitOperations)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	rc.Status.PullRequestName = pr.Name
+	rc.Status.PullRequest = &promoterv1alpha1.PullRequestCommonStatus{
+		ID:                       pr.Status.ID,
+		State:                    pr.Status.State,
+		PRCreationTime:           pr.Status.PRCreationTime,
+		Url:                      pr.Status.Url,
+		ExternallyMergedOrClosed: pr.Status.ExternallyMergedOrClosed,
+	}
+	rc.Status.Url = pr.Status.Url
+	rc.Status.Phase = promoterv1alpha1.RevertCommitPhasePullRequestOpen
 
 	return ctrl.Result{}, nil
 }
 
+// setPhaseConditions sets the Conflicted and Completed conditions from the phase of the RevertCommit and emits an event
+// if the phase changed from previousPhase. A conflicting revert sets the Conflicted condition itself, since only it has
+// the conflicting files.
+func (r *RevertCommitReconciler) setPhaseConditions(rc *promoterv1alpha1.RevertCommit, previousPhase promoterv1alpha1.RevertCommitPhase) {
+	if rc.Status.Phase != promoterv1alpha1.RevertCommitPhaseConflicted {
+		meta.SetStatusCondition(rc.GetConditions(), metav1.Condition{
+			Type:               string(promoterConditions.Conflicted),
+			Status:             metav1.ConditionFalse,
+			Reason:             string(promoterConditions.NoConflict),
+			Message:            fmt.Sprintf("Reverting commit %s doesn't conflict", rc.Status.ShortSha),
+			ObservedGeneration: rc.Generation,
+		})
+	}
+
+	completed := metav1.Condition{
+		Type:               string(promoterConditions.Completed),
+		Status:             metav1.ConditionFalse,
+		Reason:             string(promoterConditions.RevertInProgress),
+		Message:            fmt.Sprintf("Revert of commit %s is in phase %s", rc.Status.ShortSha, rc.Status.Phase),
+		ObservedGeneration: rc.Generation,
+	}
+	switch rc.Status.Phase {
+	case promoterv1alpha1.RevertCommitPhaseMerged:
+		completed.Status = metav1.ConditionTrue
+		completed.Reason = string(promoterConditions.PullRequestMerged)
+		completed.Message = fmt.Sprintf("Revert pull request %q was merged", rc.Status.PullRequestName)
+	case promoterv1alpha1.RevertCommitPhaseReverted:
+		completed.Status = metav1.ConditionTrue
+		completed.Reason = string(promoterConditions.EnvironmentsReverted)
+		completed.Message = fmt.Sprintf("Commit %s was reverted in every environment", rc.Status.ShortSha)
+	case promoterv1alpha1.RevertCommitPhaseClosed:
+		completed.Reason = string(promoterConditions.PullRequestClosed)
+		completed.Message = fmt.Sprintf("Revert pull request %q was closed without being merged", rc.Status.PullRequestName)
+	case promoterv1alpha1.RevertCommitPhaseConflicted:
+		completed.Reason = string(promoterConditions.RevertConflict)
+		completed.Message = fmt.Sprintf("Commit %s can't be reverted without resolving conflicts", rc.Status.ShortSha)
+	case promoterv1alpha1.RevertCommitPhaseSuperseded:
+		completed.Reason = string(promoterConditions.CommitSuperseded)
+		completed.Message = fmt.Sprintf("Commit %s is no longer on branch %q", rc.Status.ShortSha, revertTargetBranch(rc))
+	}
+	meta.SetStatusCondition(rc.GetConditions(), completed)
+
+	if rc.Status.Phase != previousPhase && rc.Status.Phase != "" {
+		eventType := "Normal"
+		switch rc.Status.Phase {
+		case promoterv1alpha1.RevertCommitPhaseConflicted, promoterv1alpha1.RevertCommitPhaseSuperseded, promoterv1alpha1.RevertCommitPhaseClosed:
+			eventType = "Warning"
+		}
+		r.Recorder.Eventf(rc, nil, eventType, constants.RevertCommitPhaseChangedReason, "Reconciling", constants.RevertCommitPhaseChangedMessage, rc.Status.Phase)
+	}
+}
+
+// emitRollbackCompleted emits a CloudEvent for each environment of the RevertCommit, or a single one without an
+// environment for a revert of the dry branch, if its commit was reverted since previousPhase.
+func (r *RevertCommitReconciler) emitRollbackCompleted(ctx context.Context, rc *promoterv1alpha1.RevertCommit, previousPhase promoterv1alpha1.RevertCommitPhase) {
+	if r.CloudEvents == nil || rc.Status.Phase == previousPhase ||
+		rc.Status.Phase != promoterv1alpha1.RevertCommitPhaseMerged && rc.Status.Phase != promoterv1alpha1.RevertCommitPhaseReverted {
+		return
+	}
+	data := cloudevents.Data{
+		Namespace:         rc.Namespace,
+		PromotionStrategy: rc.Spec.PromotionStrategyRef.Name,
+		DrySha:            rc.Spec.Sha,
+		PullRequestURL:    rc.Status.Url,
+		RevertCommit:      rc.Name,
+		Time:              time.Now(),
+	}
+	if rc.Spec.Target == promoterv1alpha1.RevertTargetHydrated {
+		data.DrySha = rc.Status.DrySha
+		data.HydratedSha = rc.Spec.Sha
+	}
+	environments := rc.Spec.Environments
+	if len(environments) == 0 {
+		environments = []string{""}
+	}
+	for _, environment := range environments {
+		data.Environment = environment
+		r.CloudEvents.Emit(ctx, cloudevents.Event{Type: cloudevents.TypeRollbackCompleted, Data: data})
+	}
+}
+
+// handleFinalizer ensures RevertCommitFinalizer is on the RevertCommit while it exists, so that deleting it first
+// cleans up what the revert left on the SCM, see cleanupRevert. A revert whose pull request was merged has nothing left
+// to clean up.
+//
+// The bool is true when Reconcile should not continue because the RevertCommit is being deleted.
+func (r *RevertCommitReconciler) handleFinalizer(ctx context.Context, rc *promoterv1alpha1.RevertCommit) (bool, error) {
+	finalizer := promoterv1alpha1.RevertCommitFinalizer
+
+	if rc.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(rc, finalizer) {
+			// Not being deleted and already has finalizer, nothing to do.
+			return false, nil
+		}
+
+		// Finalizer is missing, add it.
+		return false, retry.RetryOnConflict(retry.DefaultRetry, func() error { //nolint:wrapcheck // RetryOnConflict returns wrapped error
+			if err := r.Get(ctx, client.ObjectKeyFromObject(rc), rc); err != nil {
+				return err //nolint:wrapcheck // error will be wrapped by caller
+			}
+			if controllerutil.AddFinalizer(rc, finalizer) {
+				return r.Update(ctx, rc)
+			}
+			return nil
+		})
+	}
+
+	// If we're here, the object is being deleted
+	if !controllerutil.ContainsFinalizer(rc, finalizer) {
+		// Finalizer already removed; still skip normal reconcile while terminating.
+		return true, nil
+	}
+
+	merged := rc.Status.Phase == promoterv1alpha1.RevertCommitPhaseMerged ||
+		rc.Status.PullRequest != nil && rc.Status.PullRequest.State == promoterv1alpha1.PullRequestMerged
+	if merged {
+		log.FromContext(ctx).Info("Revert pull request was merged, nothing to clean up")
+	} else {
+		cleanedUp, err := r.cleanupRevert(ctx, rc)
+		if err != nil {
+			return true, fmt.Errorf("failed to clean up deleted RevertCommit: %w", err)
+		}
+		if !cleanedUp {
+			// The PullRequests are still closing, deleting them triggers another reconcile through the Owns watch.
+			return true, nil
+		}
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error { //nolint:wrapcheck // RetryOnConflict returns wrapped error
+		if err := r.Get(ctx, client.ObjectKeyFromObject(rc), rc); err != nil {
+			return err //nolint:wrapcheck // error will be wrapped by caller
+		}
+		if controllerutil.RemoveFinalizer(rc, finalizer) {
+			return r.Update(ctx, rc) //nolint:wrapcheck // error will be wrapped by caller
+		}
+		return nil
+	}); err != nil {
+		return true, fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+	return true, nil
+}
+
+// cleanupRevert closes the revert pull request by deleting the PullRequests owned by the RevertCommit, whose own
+// finalizers close them on the SCM. Once they are gone, it deletes the revert branch. It returns false while
+// PullRequests are still being deleted. The revert branch is left behind if the repository can no longer be reached
+// because the PromotionStrategy or the GitRepository is gone.
+func (r *RevertCommitReconciler) cleanupRevert(ctx context.Context, rc *promoterv1alpha1.RevertCommit) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	var prList promoterv1alpha1.PullRequestList
+	if err := r.List(ctx, &prList, client.InNamespace(rc.Namespace)); err != nil {
+		return false, fmt.Errorf("failed to list PullRequests: %w", err)
+	}
+	closing := false
+	for _, pr := range prList.Items {
+		if !metav1.IsControlledBy(&pr, rc) {
+			continue
+		}
+		closing = true
+		if !pr.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, &pr); err != nil && !k8s_errors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete PullRequest %q: %w", pr.Name, err)
+		}
+		logger.Info("Deleted revert PullRequest", "pullRequest", pr.Name)
+	}
+	if closing {
+		return false, nil
+	}
+
+	if rc.Status.RevertBranch == "" {
+		return true, nil
+	}
+
+	var ps promoterv1alpha1.PromotionStrategy
+	if err := r.Get(ctx, client.ObjectKey{Namespace: rc.Namespace, Name: rc.Spec.PromotionStrategyRef.Name}, &ps); err != nil {
+		if k8s_errors.IsNotFound(err) {
+			logger.Info("PromotionStrategy is gone, leaving the revert branch behind", "branch", rc.Status.RevertBranch)
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get PromotionStrategy %q: %w", rc.Spec.PromotionStrategyRef.Name, err)
+	}
+	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, r.Client, client.ObjectKey{Namespace: rc.Namespace, Name: ps.Spec.RepositoryReference.Name})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			logger.Info("GitRepository is gone, leaving the revert branch behind", "branch", rc.Status.RevertBranch)
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+
+	gitOperations, err := r.cloneDryBranch(ctx, rc, &ps, gitRepo)
+	if err != nil {
+		return false, err
+	}
+	if err := gitOperations.DeleteBranch(ctx, rc.Status.RevertBranch); err != nil {
+		return false, fmt.Errorf("failed to delete revert branch: %w", err)
+	}
+	return true, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *RevertCommitReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&promoterv1alpha1.RevertCommit{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Complete(r)
+		Owns(&promoterv1alpha1.PullRequest{}).
+		Watches(&promoterv1alpha1.PromotionStrategy{}, r.enqueueRevertCommitForPromotionStrategy()).
+		WithOptions(controller.Options{RateLimiter: settings.GetDefaultRateLimiter[ctrl.Request](r.SettingsMgr)}).
+		Complete(utils.NewDrainingReconciler(utils.NewShardedReconciler(mgr.GetClient(), &promoterv1alpha1.RevertCommit{}, tracing.NewReconciler("RevertCommit", r))))
 	if err != nil {
 		return fmt.Errorf("failed to create controller: %w", err)
 	}
 	return nil
 }
+
+// enqueueRevertCommitForPromotionStrategy returns a handler that enqueues all RevertCommit resources that reference a
+// PromotionStrategy when that PromotionStrategy changes, so that the progress of environment reverts is picked up.
+func (r *RevertCommitReconciler) enqueueRevertCommitForPromotionStrategy() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []ctrl.Request {
+		ps, ok := obj.(*promoterv1alpha1.PromotionStrategy)
+		if !ok {
+			return nil
+		}
+
+		var rcList promoterv1alpha1.RevertCommitList
+		if err := r.List(ctx, &rcList, client.InNamespace(ps.Namespace)); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list RevertCommit resources")
+			return nil
+		}
+
+		var requests []ctrl.Request
+		for _, rc := range rcList.Items {
+			if rc.Spec.PromotionStrategyRef.Name == ps.Name && len(rc.Spec.Environments) > 0 {
+				requests = append(requests, ctrl.Request{
+					NamespacedName: client.ObjectKeyFromObject(&rc),
+				})
+			}
+		}
+
+		return requests
+	})
+}
+
+// revertEnvironments returns each environment of spec.environments to the manifests it ran before spec.sha was
+// promoted to it. The manifests are proposed on the environment's proposed branch, from where its
+// ChangeTransferPolicy promotes them like any other proposed change. Environments progress independently, so a
+// failure in one environment doesn't hold up the others.
+func (r *RevertCommitReconciler) revertEnvironments(ctx context.Context, rc *promoterv1alpha1.RevertCommit, ps *promoterv1alpha1.PromotionStrategy, gitRepo *promoterv1alpha1.GitRepository) error {
+	proposedBranches, err := renderProposedBranches(ps)
+	if err != nil {
+		return fmt.Errorf("failed to render proposed branches of PromotionStrategy %q: %w", ps.Name, err)
+	}
+	proposedBranchByEnvironment := make(map[string]string, len(ps.Spec.Environments))
+	for i, environment := range ps.Spec.Environments {
+		proposedBranchByEnvironment[environment.Branch] = proposedBranches[i]
+	}
+
+	var unknownEnvironments []string
+	for _, branch := range rc.Spec.Environments {
+		if _, found := proposedBranchByEnvironment[branch]; !found {
+			unknownEnvironments = append(unknownEnvironments, fmt.Sprintf("%q", branch))
+		}
+	}
+	if len(unknownEnvironments) > 0 {
+		// Retrying won't help until the RevertCommit or the PromotionStrategy is changed.
+		rc.Status.Phase = promoterv1alpha1.RevertCommitPhaseReverting
+		meta.SetStatusCondition(rc.GetConditions(), metav1.Condition{
+			Type:               string(promoterConditions.Ready),
+			Status:             metav1.ConditionFalse,
+			Reason:             string(promoterConditions.EnvironmentNotFound),
+			Message:            fmt.Sprintf("Environments %s are not part of PromotionStrategy %q", strings.Join(unknownEnvironments, ", "), ps.Name),
+			ObservedGeneration: rc.Generation,
+		})
+		return nil
+	}
+
+	var gitOperations *git.EnvironmentOperations
+	environments := make([]promoterv1alpha1.RevertCommitEnvironmentStatus, 0, len(rc.Spec.Environments))
+	var errs []error
+	for _, branch := range rc.Spec.Environments {
+		environment := promoterv1alpha1.RevertCommitEnvironmentStatus{Branch: branch}
+		for _, existing := range rc.Status.Environments {
+			if existing.Branch == branch {
+				environment = existing
+			}
+		}
+
+		if environment.ProposedSha == "" && !environment.Reverted {
+			if gitOperations == nil {
+				rc.Status.Phase = promoterv1alpha1.RevertCommitPhaseCloning
+				gitOperations, err = r.cloneDryBranch(ctx, rc, ps, gitRepo)
+				if err != nil {
+					return err
+				}
+				rc.Status.Phase = promoterv1alpha1.RevertCommitPhaseReverting
+			}
+			if err := r.proposeRestoredManifests(ctx, rc, gitOperations, &environment, proposedBranchByEnvironment[branch]); err != nil {
+				errs = append(errs, fmt.Errorf("failed to revert commit %q in environment %q: %w", rc.Spec.Sha, branch, err))
+			}
+		}
+
+		if environment.ProposedSha != "" && !environment.Reverted {
+			for _, environmentStatus := range ps.Status.Environments {
+				if environmentStatus.Branch == branch && environmentStatus.Active.Dry.Sha == environment.RestoredDrySha {
+					environment.Reverted = true
+				}
+			}
+		}
+		environments = append(environments, environment)
+	}
+	rc.Status.Environments = environments
+
+	if len(errs) > 0 {
+		rc.Status.Phase = promoterv1alpha1.RevertCommitPhaseReverting
+		return errors.Join(errs...)
+	}
+	rc.Status.Phase = promoterv1alpha1.RevertCommitPhaseReverted
+	for _, environment := range environments {
+		if !environment.Reverted {
+			rc.Status.Phase = promoterv1alpha1.RevertCommitPhasePromoting
+		}
+	}
+	return nil
+}
+
+// proposeRestoredManifests commits the manifests the environment ran before spec.sha was promoted to it to the
+// environment's proposed branch and records the result in environment. An environment that spec.sha was never promoted
+// to is already reverted.
+func (r *RevertCommitReconciler) proposeRestoredManifests(ctx context.Context, rc *promoterv1alpha1.RevertCommit, gitOperations *git.EnvironmentOperations, environment *promoterv1alpha1.RevertCommitEnvironmentStatus, proposedBranch string) error {
+	logger := log.FromContext(ctx)
+
+	restoredSha, err := gitOperations.HydratedStateBefore(ctx, environment.Branch, rc.Spec.Sha)
+	if err != nil {
+		return fmt.Errorf("failed to find the manifests the environment ran before the commit: %w", err)
+	}
+	if restoredSha == "" {
+		logger.Info("Commit was never promoted to the environment, nothing to revert", "environment", environment.Branch)
+		environment.Reverted = true
+		return nil
+	}
+	restoredDry, err := gitOperations.GetShaMetadataFromFile(ctx, restoredSha)
+	if err != nil {
+		return fmt.Errorf("failed to get commit metadata for hydrated SHA %q: %w", restoredSha, err)
+	}
+
+	message := fmt.Sprintf("Revert %s in %s\n\nThis restores the manifests of %s, hydrated from %s, requested by RevertCommit %s/%s.",
+		rc.Spec.Sha[:7], environment.Branch, restoredSha, restoredDry.Sha, rc.Namespace, rc.Name)
+	proposedSha, err := gitOperations.RestoreTree(ctx, proposedBranch, restoredSha, message)
+	if err != nil {
+		return fmt.Errorf("failed to propose the manifests of %q on %q: %w", restoredSha, proposedBranch, err)
+	}
+
+	environment.RestoredHydratedSha = restoredSha
+	environment.RestoredDrySha = restoredDry.Sha
+	environment.ProposedSha = proposedSha
+	r.Recorder.Eventf(rc, nil, "Normal", constants.RevertProposedReason, "ProposingRevert", constants.RevertProposedMessage, restoredSha, proposedBranch, rc.Spec.Sha, environment.Branch)
+	return nil
+}
+
+// revertPullRequestMerged reports whether the revert pull request of the RevertCommit, which no longer exists, was
+// merged into targetBranch. The state last recorded for it is only trusted if it is merged: a pull request merged on
+// the SCM disappears while it is still recorded as open. Otherwise the commits that landed on targetBranch after
+// spec.sha are searched for the Revert-commit trailer, which the revert commit carries and the merge commit of the pull
+// request repeats, so merges, squashes and rebases are all recognized.
+func (r *RevertCommitReconciler) revertPullRequestMerged(ctx context.Context, rc *promoterv1alpha1.RevertCommit, ps *promoterv1alpha1.PromotionStrategy, gitRepo *promoterv1alpha1.GitRepository, targetBranch string) (bool, error) {
+	if rc.Status.PullRequest.State == promoterv1alpha1.PullRequestMerged {
+		return true, nil
+	}
+	gitOperations, err := r.cloneDryBranch(ctx, rc, ps, gitRepo)
+	if err != nil {
+		return false, err
+	}
+	mergedSha, err := gitOperations.CommitWithTrailer(ctx, rc.Spec.Sha, targetBranch, constants.TrailerRevertCommit, rc.Name)
+	if err != nil {
+		return false, fmt.Errorf("failed to look for the revert of commit %q on branch %q: %w", rc.Spec.Sha, targetBranch, err)
+	}
+	return mergedSha != "", nil
+}
+
+// revertTargetBranch returns the branch the commit of the RevertCommit is reverted on: the dry branch, or the active
+// branch of its environment when target is hydrated.
+func revertTargetBranch(rc *promoterv1alpha1.RevertCommit) string {
+	if rc.Spec.Target == promoterv1alpha1.RevertTargetHydrated && len(rc.Spec.Environments) > 0 {
+		return rc.Spec.Environments[0]
+	}
+	return rc.Spec.DryBranch
+}
+
+// revertCommitBranch returns the branch the revert commit of the RevertCommit is pushed to.
+func revertCommitBranch(rc *promoterv1alpha1.RevertCommit) string {
+	return fmt.Sprintf("promoter-revert/%s/%s", rc.Namespace, rc.Name)
+}
+
+// cloneDryBranch returns the operations for the clone of the dry branch, cloning the repository if needed.
+func (r *RevertCommitReconciler) cloneDryBranch(ctx context.Context, rc *promoterv1alpha1.RevertCommit, ps *promoterv1alpha1.PromotionStrategy, gitRepo *promoterv1alpha1.GitRepository) (*git.EnvironmentOperations, error) {
+	scmProvider, secret, err := utils.GetScmProviderAndSecretFromRepositoryReference(ctx, r.Client, r.SettingsMgr.GetControllerNamespace(), ps.Spec.RepositoryReference, rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ScmProvider and secret for repo %q: %w", ps.Spec.RepositoryReference.Name, err)
+	}
+	gitAuthProvider, err := gitauth.CreateGitOperationsProvider(ctx, r.Client, scmProvider, secret, client.ObjectKey{Namespace: rc.Namespace, Name: ps.Spec.RepositoryReference.Name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git auth provider for ScmProvider %q: %w", scmProvider.GetName(), err)
+	}
+
+	// Reverting walks the history of the dry branch, so the clone is never shallow.
+	gitOperations := r.GitRepoManager.EnvironmentOperations(gitRepo, gitAuthProvider, rc.Spec.DryBranch, 0)
+	if gitRepo.Spec.CommitSigning != nil {
+		signingKey, err := getCommitSigningKey(ctx, r.Client, gitRepo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commit signing key for GitRepository %q: %w", gitRepo.Name, err)
+		}
+		gitOperations.SetCommitSigningKey(signingKey)
+	}
+	gitOperations.SetIdentity(gitIdentity(scmProvider, gitRepo))
+
+	if err := gitOperations.CloneRepo(ctx); err != nil {
+		return nil, fmt.Errorf("failed to clone repo %q: %w", gitRepo.Name, err)
+	}
+	return gitOperations, nil
+}
+
+// applyPullRequest creates or updates the PullRequest that merges the revert branch into the branch the commit is
+// reverted on, see revertTargetBranch. The title is the subject of the revert commit, read from the clone if
+// gitOperations is set. Otherwise the revert commit is the one the existing pull request was created for, which keeps
+// its title. An existing pull request also keeps its state.
+func (r *RevertCommitReconciler) applyPullRequest(ctx context.Context, rc *promoterv1alpha1.RevertCommit, ps *promoterv1alpha1.PromotionStrategy, prName string, existingPR *promoterv1alpha1.PullRequest, prExists bool, gitOperations *git.EnvironmentOperations) (*promoterv1alpha1.PullRequest, error) {
+	logger := log.FromContext(ctx)
+
+	targetBranch := revertTargetBranch(rc)
+	title := existingPR.Spec.Title
+	description := existingPR.Spec.Description
+	if gitOperations != nil {
+		subject, err := gitOperations.GetShaSubject(ctx, rc.Status.RevertSha)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get subject of revert commit %q: %w", rc.Status.RevertSha, err)
+		}
+		title = subject
+
+		description = fmt.Sprintf("This reverts commit %s on %s, requested by RevertCommit %s/%s.", rc.Spec.Sha, targetBranch, rc.Namespace, rc.Name)
+		if rc.Spec.Target == promoterv1alpha1.RevertTargetHydrated {
+			description = fmt.Sprintf("This is an emergency revert of hydrated commit %s directly on %s, requested by RevertCommit %s/%s. "+
+				"It bypasses hydration and the environment's proposed commit statuses.", rc.Spec.Sha, targetBranch, rc.Namespace, rc.Name)
+		}
+		if rc.Spec.CommitMessageTemplate != "" {
+			reverted, err := gitOperations.GetShaMetadataFromGit(ctx, rc.Spec.Sha)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get metadata of commit %q: %w", rc.Spec.Sha, err)
+			}
+			message, err := utils.RenderRevertCommitMessage(rc, reverted)
+			if err != nil {
+				return nil, err
+			}
+			// The first line is the subject of the revert commit, which is already the title.
+			if _, body, _ := strings.Cut(message, "\n"); strings.TrimSpace(body) != "" {
+				description = strings.TrimSpace(body)
+			}
+		}
+	}
+	prState := promoterv1alpha1.PullRequestOpen
+	if prExists {
+		prState = existingPR.Spec.State
+	}
+
+	// The trailer carries over to the merge commit, in case the revert commit itself doesn't end up on the branch.
+	commitMessage, err := git.AddTrailerToCommitMessage(ctx, fmt.Sprintf("%s\n\n%s", title, description), constants.TrailerRevertCommit, rc.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add trailer to the commit message of PullRequest %q: %w", prName, err)
+	}
+
+	kind := reflect.TypeOf(promoterv1alpha1.RevertCommit{}).Name()
+	gvk := promoterv1alpha1.GroupVersion.WithKind(kind)
+
+	prApply := acv1alpha1.PullRequest(prName, rc.Namespace).
+		WithLabels(map[string]string{
+			promoterv1alpha1.PromotionStrategyLabel:    utils.KubeSafeLabel(ps.Name),
+			promoterv1alpha1.PromotionStrategyUIDLabel: string(ps.UID),
+		}).
+		WithOwnerReferences(acmetav1.OwnerReference().
+			WithAPIVersion(gvk.GroupVersion().String()).
+			WithKind(gvk.Kind).
+			WithName(rc.Name).
+			WithUID(rc.UID).
+			WithController(true).
+			WithBlockOwnerDeletion(true)).
+		WithSpec(acv1alpha1.PullRequestSpec().
+			WithRepositoryReference(acv1alpha1.ObjectReference().WithName(ps.Spec.RepositoryReference.Name)).
+			WithTitle(title).
+			WithTargetBranch(targetBranch).
+			WithSourceBranch(rc.Status.RevertBranch).
+			WithDescription(description).
+			WithCommit(acv1alpha1.CommitConfiguration().WithMessage(commitMessage)).
+			WithMergeSha(rc.Status.RevertSha).
+			WithState(prState))
+
+	pr := &promoterv1alpha1.PullRequest{}
+	pr.Name = prName
+	pr.Namespace = rc.Namespace
+	if err := r.Patch(ctx, pr, utils.ApplyPatch{ApplyConfig: prApply}, client.FieldOwner(constants.RevertCommitControllerFieldOwner), client.ForceOwnership); err != nil {
+		return nil, fmt.Errorf("failed to apply PullRequest %q: %w", prName, err)
+	}
+
+	if !prExists {
+		r.Recorder.Eventf(rc, nil, "Normal", constants.PullRequestCreatedReason, "CreatingPullRequest", constants.PullRequestCreatedMessage, pr.Name)
+		logger.Info("Created revert pull request", "pullRequest", pr.Name, "revertSha", rc.Status.RevertSha)
+	}
+	return pr, nil
+}
@@ -18,67 +18,727 @@ package controller
 
 import (
 	"context"
+	_ "embed"
+	"os"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/git"
+	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
+	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 )
 
+//go:embed testdata/RevertCommit.yaml
+var testRevertCommitYAML string
+
 var _ = Describe("RevertCommit Controller", func() {
-	Context("When reconciling a resource", func() {
-		const resourceName = "test-resource"
+	Context("When unmarshalling the test data", func() {
+		It("should unmarshal the RevertCommit resource", func() {
+			err := unmarshalYamlStrict(testRevertCommitYAML, &promoterv1alpha1.RevertCommit{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("When reverting a dry commit", Ordered, func() {
+		var (
+			ctx               context.Context
+			name              string
+			scmSecret         *v1.Secret
+			scmProvider       *promoterv1alpha1.ScmProvider
+			gitRepo           *promoterv1alpha1.GitRepository
+			promotionStrategy *promoterv1alpha1.PromotionStrategy
+			gitPath           string
+			dryBranch         string
+		)
+
+		BeforeAll(func() {
+			ctx = context.Background()
 
-		ctx := context.Background()
+			By("Setting up test git repository and resources")
+			name, scmSecret, scmProvider, gitRepo, _, _, promotionStrategy = promotionStrategyResource(ctx, "revert-commit-test", "default")
+			setupInitialTestGitRepoOnServer(ctx, gitRepo)
 
-		typeNamespacedName := types.NamespacedName{
-			Name:      resourceName,
-			Namespace: "default", // TODO(user):Modify as needed
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			Expect(k8sClient.Create(ctx, promotionStrategy)).To(Succeed())
+
+			var err error
+			gitPath, err = cloneTestRepo(ctx, gitRepo)
+			Expect(err).NotTo(HaveOccurred())
+			dryBranch, err = runGitCmd(ctx, gitPath, "rev-parse", "--abbrev-ref", "origin/HEAD")
+			Expect(err).NotTo(HaveOccurred())
+			dryBranch = strings.TrimPrefix(strings.TrimSpace(dryBranch), "origin/")
+		})
+
+		AfterAll(func() {
+			By("Cleaning up test resources")
+			_ = os.RemoveAll(gitPath)
+			_ = k8sClient.Delete(ctx, promotionStrategy)
+			_ = k8sClient.Delete(ctx, gitRepo)
+			_ = k8sClient.Delete(ctx, scmProvider)
+			_ = k8sClient.Delete(ctx, scmSecret)
+		})
+
+		revertCommit := func(rcName, sha string) *promoterv1alpha1.RevertCommit {
+			rc := &promoterv1alpha1.RevertCommit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      rcName,
+					Namespace: "default",
+				},
+				Spec: promoterv1alpha1.RevertCommitSpec{
+					PromotionStrategyRef: promoterv1alpha1.ObjectReference{Name: name},
+					DryBranch:            dryBranch,
+					Sha:                  sha,
+				},
+			}
+			Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, rc)
+			})
+			return rc
 		}
-		revertcommit := &promoterv1alpha1.RevertCommit{}
-
-		BeforeEach(func() {
-			By("creating the custom resource for the Kind RevertCommit")
-			err := k8sClient.Get(ctx, typeNamespacedName, revertcommit)
-			if err != nil && errors.IsNotFound(err) {
-				resource := &promoterv1alpha1.RevertCommit{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      resourceName,
-						Namespace: "default",
-					},
-					// TODO(user): Specify other spec details if needed.
-				}
-				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+		It("should open a pull request that reverts the commit and promote the revert to development", func() {
+			drySha, err := makeDryCommit(ctx, gitPath, "change to revert")
+			Expect(err).NotTo(HaveOccurred())
+
+			rc := revertCommit(name+"-revert", drySha)
+
+			By("Waiting for the revert commit and its pull request")
+			var pr promoterv1alpha1.PullRequest
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rc.Name, Namespace: rc.Namespace}, rc)).To(Succeed())
+				g.Expect(rc.Status.RevertSha).NotTo(BeEmpty())
+				g.Expect(rc.Status.RevertBranch).To(Equal("promoter-revert/default/" + rc.Name))
+				g.Expect(rc.Status.PullRequestName).NotTo(BeEmpty())
+				g.Expect(rc.Status.PullRequest).NotTo(BeNil())
+				g.Expect(rc.Status.PullRequest.ID).NotTo(BeEmpty())
+				g.Expect(rc.Status.Phase).To(Equal(promoterv1alpha1.RevertCommitPhasePullRequestOpen))
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rc.Status.PullRequestName, Namespace: rc.Namespace}, &pr)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			Expect(pr.Spec.Title).To(Equal(`Revert "change to revert"`))
+			Expect(pr.Spec.SourceBranch).To(Equal(rc.Status.RevertBranch))
+			Expect(pr.Spec.TargetBranch).To(Equal(dryBranch))
+			Expect(pr.Spec.MergeSha).To(Equal(rc.Status.RevertSha))
+			Expect(metav1.IsControlledBy(&pr, rc)).To(BeTrue())
+			Expect(rc.Status.Url).To(Equal(rc.Status.PullRequest.Url))
+			Expect(rc.Status.ShortSha).To(Equal(drySha[:7]))
+			Expect(meta.IsStatusConditionFalse(rc.Status.Conditions, string(promoterConditions.Conflicted))).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(rc.Status.Conditions, string(promoterConditions.Completed))).To(BeTrue())
+
+			_, err = runGitCmd(ctx, gitPath, "fetch", "origin")
+			Expect(err).NotTo(HaveOccurred())
+			parent, err := runGitCmd(ctx, gitPath, "rev-parse", "origin/"+rc.Status.RevertBranch+"^")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.TrimSpace(parent)).To(Equal(drySha))
+
+			By("Merging the revert pull request")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: pr.Name, Namespace: pr.Namespace}, &pr)).To(Succeed())
+				pr.Spec.State = promoterv1alpha1.PullRequestMerged
+				g.Expect(k8sClient.Update(ctx, &pr)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: pr.Name, Namespace: pr.Namespace}, &promoterv1alpha1.PullRequest{})
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rc.Name, Namespace: rc.Namespace}, rc)).To(Succeed())
+				g.Expect(rc.Status.PullRequest).NotTo(BeNil())
+				g.Expect(rc.Status.PullRequest.State).To(Equal(promoterv1alpha1.PullRequestMerged))
+				g.Expect(rc.Status.Phase).To(Equal(promoterv1alpha1.RevertCommitPhaseMerged))
+				completed := meta.FindStatusCondition(rc.Status.Conditions, string(promoterConditions.Completed))
+				g.Expect(completed).NotTo(BeNil())
+				g.Expect(completed.Status).To(Equal(metav1.ConditionTrue))
+				g.Expect(completed.Reason).To(Equal(string(promoterConditions.PullRequestMerged)))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			_, err = runGitCmd(ctx, gitPath, "fetch", "origin")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = runGitCmd(ctx, gitPath, "merge-base", "--is-ancestor", rc.Status.RevertSha, "origin/"+dryBranch)
+			Expect(err).NotTo(HaveOccurred(), "the dry branch should contain the revert commit")
+			revertedDrySha, err := runGitCmd(ctx, gitPath, "rev-parse", "origin/"+dryBranch)
+			Expect(err).NotTo(HaveOccurred())
+			revertedDrySha = strings.TrimSpace(revertedDrySha)
+
+			revertedDryBody, err := runGitCmd(ctx, gitPath, "log", "-1", "--format=%b", revertedDrySha)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revertedDryBody).To(ContainSubstring(constants.TrailerRevertCommit + ": " + rc.Name))
+
+			By("Hydrating the reverted dry commit to development")
+			Expect(hydrateEnvironmentWithBody(ctx, gitPath, testBranchDevelopmentNext, revertedDrySha, "hydrate the revert", revertedDryBody)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: promotionStrategy.Name, Namespace: promotionStrategy.Namespace}, promotionStrategy)).To(Succeed())
+				g.Expect(promotionStrategy.Status.Environments).NotTo(BeEmpty())
+				g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.Sha).To(Equal(revertedDrySha))
+				history := promotionStrategy.Status.Environments[0].History
+				g.Expect(history).NotTo(BeEmpty())
+				g.Expect(history[0].Active.Dry.Sha).To(Equal(revertedDrySha))
+				g.Expect(history[0].Kind).To(Equal(promoterv1alpha1.HistoryKindRevert))
+				g.Expect(history[0].RevertCommitRef).To(Equal(&promoterv1alpha1.ObjectReference{Name: rc.Name}))
+				g.Expect(history[0].RevertedSha).To(Equal(drySha))
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+
+		It("should recognize a revert pull request that was merged on the SCM", func() {
+			drySha, err := makeDryCommit(ctx, gitPath, "change to revert on the SCM")
+			Expect(err).NotTo(HaveOccurred())
+
+			rc := revertCommit(name+"-scm-merge", drySha)
+
+			By("Waiting for the revert pull request")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rc.Name, Namespace: rc.Namespace}, rc)).To(Succeed())
+				g.Expect(rc.Status.Phase).To(Equal(promoterv1alpha1.RevertCommitPhasePullRequestOpen))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Merging the revert branch on the SCM, which makes the pull request disappear while it is recorded as open")
+			_, err = runGitCmd(ctx, gitPath, "fetch", "origin")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = runGitCmd(ctx, gitPath, "checkout", "-B", dryBranch, "origin/"+dryBranch)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = runGitCmd(ctx, gitPath, "merge", "--no-ff", "-m", "Merge the revert", "origin/"+rc.Status.RevertBranch)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = runGitCmd(ctx, gitPath, "push", "origin", dryBranch)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rc.Status.PullRequest.State).To(Equal(promoterv1alpha1.PullRequestOpen))
+			Expect(k8sClient.Delete(ctx, &promoterv1alpha1.PullRequest{ObjectMeta: metav1.ObjectMeta{Name: rc.Status.PullRequestName, Namespace: rc.Namespace}})).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rc.Name, Namespace: rc.Namespace}, rc)).To(Succeed())
+				g.Expect(rc.Status.Phase).To(Equal(promoterv1alpha1.RevertCommitPhaseMerged))
+				g.Expect(rc.Status.PullRequest.State).To(Equal(promoterv1alpha1.PullRequestMerged))
+				g.Expect(eventReasons(ctx, "RevertCommit", rc.Namespace, rc.Name)).To(ContainElement(constants.RevertMergedReason))
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+
+		It("should require force to revert a superseded commit and then report the conflict", func() {
+			conflictingSha, err := makeDryCommit(ctx, gitPath, "change that is changed again")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = makeDryCommit(ctx, gitPath, "change to the same file")
+			Expect(err).NotTo(HaveOccurred())
+
+			rc := revertCommit(name+"-conflict", conflictingSha)
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rc.Name, Namespace: rc.Namespace}, rc)).To(Succeed())
+				ready := meta.FindStatusCondition(rc.Status.Conditions, string(promoterConditions.Ready))
+				g.Expect(ready).NotTo(BeNil())
+				g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(ready.Reason).To(Equal(string(promoterConditions.SupersededTargetRequiresForce)))
+				g.Expect(ready.Message).To(ContainSubstring("manifests-fake.yaml"))
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Expect(rc.Status.RevertSha).To(BeEmpty())
+			Expect(rc.Status.Phase).To(Equal(promoterv1alpha1.RevertCommitPhaseReverting))
+
+			By("Forcing the revert")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rc.Name, Namespace: rc.Namespace}, rc)).To(Succeed())
+				rc.Spec.Force = true
+				g.Expect(k8sClient.Update(ctx, rc)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rc.Name, Namespace: rc.Namespace}, rc)).To(Succeed())
+				ready := meta.FindStatusCondition(rc.Status.Conditions, string(promoterConditions.Ready))
+				g.Expect(ready).NotTo(BeNil())
+				g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(ready.Reason).To(Equal(string(promoterConditions.RevertConflict)))
+				g.Expect(ready.Message).To(ContainSubstring("manifests-fake.yaml"))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			Expect(rc.Status.RevertSha).To(BeEmpty())
+			Expect(rc.Status.PullRequestName).To(BeEmpty())
+			Expect(rc.Status.Phase).To(Equal(promoterv1alpha1.RevertCommitPhaseConflicted))
+			Expect(meta.IsStatusConditionTrue(rc.Status.Conditions, string(promoterConditions.Conflicted))).To(BeTrue())
+			completed := meta.FindStatusCondition(rc.Status.Conditions, string(promoterConditions.Completed))
+			Expect(completed).NotTo(BeNil())
+			Expect(completed.Status).To(Equal(metav1.ConditionFalse))
+			Expect(completed.Reason).To(Equal(string(promoterConditions.RevertConflict)))
+		})
+
+		It("should return only the selected environments to their earlier manifests", func() {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: promotionStrategy.Name, Namespace: promotionStrategy.Namespace}, promotionStrategy)).To(Succeed())
+			earlierDrySha := promotionStrategy.Status.Environments[0].Active.Dry.Sha
+			Expect(earlierDrySha).NotTo(BeEmpty())
+
+			By("Promoting a bad change to development")
+			badSha, err := makeDryCommit(ctx, gitPath, "bad change")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hydrateEnvironment(ctx, gitPath, testBranchDevelopmentNext, badSha, "hydrate the bad change")).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: promotionStrategy.Name, Namespace: promotionStrategy.Namespace}, promotionStrategy)).To(Succeed())
+				g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.Sha).To(Equal(badSha))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			rc := &promoterv1alpha1.RevertCommit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name + "-environments",
+					Namespace: "default",
+				},
+				Spec: promoterv1alpha1.RevertCommitSpec{
+					PromotionStrategyRef: promoterv1alpha1.ObjectReference{Name: name},
+					DryBranch:            dryBranch,
+					Sha:                  badSha,
+					Environments:         []string{testBranchDevelopment, testBranchProduction},
+				},
 			}
+			Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, rc)
+			})
+
+			By("Waiting for development to be promoted back to the earlier manifests")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rc.Name, Namespace: rc.Namespace}, rc)).To(Succeed())
+				g.Expect(rc.Status.Environments).To(HaveLen(2))
+				development := rc.Status.Environments[0]
+				g.Expect(development.Branch).To(Equal(testBranchDevelopment))
+				g.Expect(development.RestoredDrySha).To(Equal(earlierDrySha))
+				g.Expect(development.ProposedSha).NotTo(BeEmpty())
+				g.Expect(development.Reverted).To(BeTrue())
+				g.Expect(rc.Status.Phase).To(Equal(promoterv1alpha1.RevertCommitPhaseReverted))
+				g.Expect(meta.IsStatusConditionTrue(rc.Status.Conditions, string(promoterConditions.Completed))).To(BeTrue())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: promotionStrategy.Name, Namespace: promotionStrategy.Namespace}, promotionStrategy)).To(Succeed())
+				g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.Sha).To(Equal(earlierDrySha))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Checking that the promotion back is recorded as a revert")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: promotionStrategy.Name, Namespace: promotionStrategy.Namespace}, promotionStrategy)).To(Succeed())
+				history := promotionStrategy.Status.Environments[0].History
+				g.Expect(history).NotTo(BeEmpty())
+				g.Expect(history[0].Proposed.Hydrated.Sha).To(Equal(rc.Status.Environments[0].ProposedSha))
+				g.Expect(history[0].Kind).To(Equal(promoterv1alpha1.HistoryKindRevert))
+				g.Expect(history[0].RevertCommitRef).To(Equal(&promoterv1alpha1.ObjectReference{Name: rc.Name}))
+				g.Expect(history[0].RevertedSha).To(Equal(badSha))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			// The bad change never reached production, so there is nothing to revert there.
+			production := rc.Status.Environments[1]
+			Expect(production.Branch).To(Equal(testBranchProduction))
+			Expect(production.ProposedSha).To(BeEmpty())
+			Expect(production.Reverted).To(BeTrue())
+
+			_, err = runGitCmd(ctx, gitPath, "fetch", "origin")
+			Expect(err).NotTo(HaveOccurred())
+			drySha, err := runGitCmd(ctx, gitPath, "rev-parse", "origin/"+dryBranch)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.TrimSpace(drySha)).To(Equal(badSha), "the dry branch should be left alone")
 		})
 
-		AfterEach(func() {
-			// TODO(user): Cleanup logic after each test, like removing the resource instance.
-			resource := &promoterv1alpha1.RevertCommit{}
-			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+		It("should return a later environment to its earlier manifests without waiting for the environments before it", func() {
+			const gateKey = "revert-gate"
+
+			By("Gating staging on the health of development")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: promotionStrategy.Name, Namespace: promotionStrategy.Namespace}, promotionStrategy)).To(Succeed())
+				promotionStrategy.Spec.ActiveCommitStatuses = []promoterv1alpha1.CommitStatusSelector{{Key: gateKey}}
+				g.Expect(k8sClient.Update(ctx, promotionStrategy)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			DeferCleanup(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: promotionStrategy.Name, Namespace: promotionStrategy.Namespace}, promotionStrategy)).To(Succeed())
+					promotionStrategy.Spec.ActiveCommitStatuses = nil
+					g.Expect(k8sClient.Update(ctx, promotionStrategy)).To(Succeed())
+				}, constants.EventuallyTimeout).Should(Succeed())
+			})
+			earlierDrySha := promotionStrategy.Status.Environments[1].Active.Dry.Sha
+			Expect(earlierDrySha).NotTo(BeEmpty())
+
+			By("Promoting a bad change to development and staging")
+			badSha, err := makeDryCommit(ctx, gitPath, "bad change for staging")
 			Expect(err).NotTo(HaveOccurred())
+			Expect(hydrateEnvironment(ctx, gitPath, testBranchDevelopmentNext, badSha, "hydrate the bad change")).To(Succeed())
+			Expect(hydrateEnvironment(ctx, gitPath, testBranchStagingNext, badSha, "hydrate the bad change")).To(Succeed())
+			var developmentSha string
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: promotionStrategy.Name, Namespace: promotionStrategy.Namespace}, promotionStrategy)).To(Succeed())
+				g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.Sha).To(Equal(badSha))
+				developmentSha = promotionStrategy.Status.Environments[0].Active.Hydrated.Sha
+			}, constants.EventuallyTimeout).Should(Succeed())
+			healthy := &promoterv1alpha1.CommitStatus{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name + "-revert-gate",
+					Namespace: "default",
+					Labels:    map[string]string{promoterv1alpha1.CommitStatusLabel: gateKey},
+				},
+				Spec: promoterv1alpha1.CommitStatusSpec{
+					RepositoryReference: promoterv1alpha1.ObjectReference{Name: gitRepo.Name},
+					Sha:                 developmentSha,
+					Name:                gateKey,
+					Phase:               promoterv1alpha1.CommitPhaseSuccess,
+				},
+			}
+			Expect(k8sClient.Create(ctx, healthy)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, healthy)
+			})
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: promotionStrategy.Name, Namespace: promotionStrategy.Namespace}, promotionStrategy)).To(Succeed())
+				g.Expect(promotionStrategy.Status.Environments[1].Active.Dry.Sha).To(Equal(badSha))
+			}, constants.EventuallyTimeout).Should(Succeed())
 
-			By("Cleanup the specific resource instance RevertCommit")
-			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			rc := &promoterv1alpha1.RevertCommit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name + "-staging",
+					Namespace: "default",
+				},
+				Spec: promoterv1alpha1.RevertCommitSpec{
+					PromotionStrategyRef: promoterv1alpha1.ObjectReference{Name: name},
+					DryBranch:            dryBranch,
+					Sha:                  badSha,
+					Environments:         []string{testBranchStaging},
+				},
+			}
+			Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, rc)
+			})
+
+			By("Waiting for staging to be promoted back to the earlier manifests while development keeps the bad change")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rc.Name, Namespace: rc.Namespace}, rc)).To(Succeed())
+				g.Expect(rc.Status.Environments).To(HaveLen(1))
+				g.Expect(rc.Status.Environments[0].RestoredDrySha).To(Equal(earlierDrySha))
+				g.Expect(rc.Status.Environments[0].Reverted).To(BeTrue())
+				g.Expect(rc.Status.Phase).To(Equal(promoterv1alpha1.RevertCommitPhaseReverted))
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: promotionStrategy.Name, Namespace: promotionStrategy.Namespace}, promotionStrategy)).To(Succeed())
+				g.Expect(promotionStrategy.Status.Environments[1].Active.Dry.Sha).To(Equal(earlierDrySha))
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Expect(promotionStrategy.Status.Environments[0].Active.Dry.Sha).To(Equal(badSha))
 		})
-		It("should successfully reconcile the resource", func() {
-			By("Reconciling the created resource")
-			controllerReconciler := &RevertCommitReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+
+		It("should reject environments that are not part of the PromotionStrategy", func() {
+			drySha, err := makeDryCommit(ctx, gitPath, "change for an unknown environment")
+			Expect(err).NotTo(HaveOccurred())
+
+			rc := &promoterv1alpha1.RevertCommit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name + "-unknown-environment",
+					Namespace: "default",
+				},
+				Spec: promoterv1alpha1.RevertCommitSpec{
+					PromotionStrategyRef: promoterv1alpha1.ObjectReference{Name: name},
+					DryBranch:            dryBranch,
+					Sha:                  drySha,
+					Environments:         []string{"environment/unknown"},
+				},
 			}
+			Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, rc)
+			})
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rc.Name, Namespace: rc.Namespace}, rc)).To(Succeed())
+				ready := meta.FindStatusCondition(rc.Status.Conditions, string(promoterConditions.Ready))
+				g.Expect(ready).NotTo(BeNil())
+				g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(ready.Reason).To(Equal(string(promoterConditions.EnvironmentNotFound)))
+				g.Expect(ready.Message).To(ContainSubstring("environment/unknown"))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			Expect(rc.Status.Environments).To(BeEmpty())
+		})
+
+		// waitForRevertPullRequest waits until the RevertCommit opened its pull request and returns the PullRequest.
+		waitForRevertPullRequest := func(rc *promoterv1alpha1.RevertCommit) *promoterv1alpha1.PullRequest {
+			var pr promoterv1alpha1.PullRequest
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rc.Name, Namespace: rc.Namespace}, rc)).To(Succeed())
+				g.Expect(rc.Status.Phase).To(Equal(promoterv1alpha1.RevertCommitPhasePullRequestOpen))
+				g.Expect(rc.Status.PullRequest).NotTo(BeNil())
+				g.Expect(rc.Status.PullRequest.ID).NotTo(BeEmpty())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rc.Status.PullRequestName, Namespace: rc.Namespace}, &pr)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			return &pr
+		}
+
+		// remoteBranchExists reports whether the branch exists on the git server.
+		remoteBranchExists := func(branch string) bool {
+			heads, err := runGitCmd(ctx, gitPath, "ls-remote", "--heads", "origin", branch)
+			Expect(err).NotTo(HaveOccurred())
+			return strings.TrimSpace(heads) != ""
+		}
+
+		It("should close the revert pull request and delete the revert branch when deleted while the pull request is open", func() {
+			drySha, err := makeDryCommit(ctx, gitPath, "change to revert and abandon")
+			Expect(err).NotTo(HaveOccurred())
 
-			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
-				NamespacedName: typeNamespacedName,
+			rc := revertCommit(name+"-delete-open", drySha)
+			pr := waitForRevertPullRequest(rc)
+			Expect(rc.Finalizers).To(ContainElement(promoterv1alpha1.RevertCommitFinalizer))
+			Expect(remoteBranchExists(rc.Status.RevertBranch)).To(BeTrue())
+
+			By("Deleting the RevertCommit")
+			Expect(k8sClient.Delete(ctx, rc)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: pr.Name, Namespace: pr.Namespace}, &promoterv1alpha1.PullRequest{})
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				err = k8sClient.Get(ctx, types.NamespacedName{Name: rc.Name, Namespace: rc.Namespace}, &promoterv1alpha1.RevertCommit{})
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Expect(remoteBranchExists(rc.Status.RevertBranch)).To(BeFalse())
+		})
+
+		It("should skip the cleanup when deleted after the revert pull request was merged", func() {
+			drySha, err := makeDryCommit(ctx, gitPath, "change to revert and keep reverted")
+			Expect(err).NotTo(HaveOccurred())
+
+			rc := revertCommit(name+"-delete-merged", drySha)
+			pr := waitForRevertPullRequest(rc)
+
+			By("Merging the revert pull request")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: pr.Name, Namespace: pr.Namespace}, pr)).To(Succeed())
+				pr.Spec.State = promoterv1alpha1.PullRequestMerged
+				g.Expect(k8sClient.Update(ctx, pr)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rc.Name, Namespace: rc.Namespace}, rc)).To(Succeed())
+				g.Expect(rc.Status.Phase).To(Equal(promoterv1alpha1.RevertCommitPhaseMerged))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Deleting the RevertCommit")
+			Expect(k8sClient.Delete(ctx, rc)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: rc.Name, Namespace: rc.Namespace}, &promoterv1alpha1.RevertCommit{})
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Expect(remoteBranchExists(rc.Status.RevertBranch)).To(BeTrue(), "a merged revert branch is left to the SCM")
+		})
+
+		It("should render the commit message template into the revert commit and its pull request", func() {
+			drySha, err := makeDryCommit(ctx, gitPath, "change to revert with a ticket")
+			Expect(err).NotTo(HaveOccurred())
+
+			rc := &promoterv1alpha1.RevertCommit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name + "-template",
+					Namespace: "default",
+				},
+				Spec: promoterv1alpha1.RevertCommitSpec{
+					PromotionStrategyRef:  promoterv1alpha1.ObjectReference{Name: name},
+					DryBranch:             dryBranch,
+					Sha:                   drySha,
+					CommitMessageTemplate: "[{{ .TemplateFields.ticket }}] Revert {{ .ShortSha }}: {{ .Subject }}\n\nOriginally authored by {{ .Author }}, reverted by {{ .Namespace }}/{{ .Name }}.",
+					TemplateFields:        map[string]string{"ticket": "INC-123"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, rc)
 			})
+
+			pr := waitForRevertPullRequest(rc)
+			Expect(pr.Spec.Title).To(Equal("[INC-123] Revert " + drySha[:7] + ": change to revert with a ticket"))
+			Expect(pr.Spec.Description).To(HavePrefix("Originally authored by "))
+			Expect(pr.Spec.Description).To(HaveSuffix(", reverted by default/" + rc.Name + "."))
+
+			_, err = runGitCmd(ctx, gitPath, "fetch", "origin")
+			Expect(err).NotTo(HaveOccurred())
+			message, err := runGitCmd(ctx, gitPath, "log", "-1", "--format=%B", rc.Status.RevertSha)
 			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+			Expect(message).To(HavePrefix(pr.Spec.Title + "\n\n" + pr.Spec.Description))
+			Expect(message).To(ContainSubstring(constants.TrailerRevertCommit + ": " + rc.Name))
+		})
+
+		It("should reject a commit message template with an unterminated action", func() {
+			rc := &promoterv1alpha1.RevertCommit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name + "-bad-template",
+					Namespace: "default",
+				},
+				Spec: promoterv1alpha1.RevertCommitSpec{
+					PromotionStrategyRef:  promoterv1alpha1.ObjectReference{Name: name},
+					DryBranch:             dryBranch,
+					Sha:                   strings.Repeat("a", 40),
+					CommitMessageTemplate: "Revert {{ .Subject",
+				},
+			}
+			err := k8sClient.Create(ctx, rc)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unterminated {{ action"))
+		})
+
+		It("should reject a hydrated target without confirmEmergency", func() {
+			rc := &promoterv1alpha1.RevertCommit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name + "-unconfirmed",
+					Namespace: "default",
+				},
+				Spec: promoterv1alpha1.RevertCommitSpec{
+					PromotionStrategyRef: promoterv1alpha1.ObjectReference{Name: name},
+					DryBranch:            dryBranch,
+					Sha:                  strings.Repeat("a", 40),
+					Environments:         []string{testBranchDevelopment},
+					Target:               promoterv1alpha1.RevertTargetHydrated,
+				},
+			}
+			err := k8sClient.Create(ctx, rc)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("confirmEmergency must be true"))
+		})
+
+		It("should revert a hydrated commit directly on the environment branch and hold auto-merge", func() {
+			By("Promoting a bad change to development")
+			badSha, err := makeDryCommit(ctx, gitPath, "bad change to revert in an emergency")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hydrateEnvironment(ctx, gitPath, testBranchDevelopmentNext, badSha, "hydrate the emergency")).To(Succeed())
+			var badHydratedSha string
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: promotionStrategy.Name, Namespace: promotionStrategy.Namespace}, promotionStrategy)).To(Succeed())
+				g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.Sha).To(Equal(badSha))
+				badHydratedSha = promotionStrategy.Status.Environments[0].Active.Hydrated.Sha
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			rc := &promoterv1alpha1.RevertCommit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name + "-emergency",
+					Namespace: "default",
+				},
+				Spec: promoterv1alpha1.RevertCommitSpec{
+					PromotionStrategyRef: promoterv1alpha1.ObjectReference{Name: name},
+					DryBranch:            dryBranch,
+					Sha:                  badHydratedSha,
+					Environments:         []string{testBranchDevelopment},
+					Target:               promoterv1alpha1.RevertTargetHydrated,
+					ConfirmEmergency:     true,
+				},
+			}
+			Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, rc)
+			})
+
+			pr := waitForRevertPullRequest(rc)
+			Expect(pr.Spec.TargetBranch).To(Equal(testBranchDevelopment))
+			Expect(pr.Spec.MergeSha).To(Equal(rc.Status.RevertSha))
+			Expect(rc.Status.DrySha).To(Equal(badSha))
+
+			_, err = runGitCmd(ctx, gitPath, "fetch", "origin")
+			Expect(err).NotTo(HaveOccurred())
+			message, err := runGitCmd(ctx, gitPath, "log", "-1", "--format=%B", rc.Status.RevertSha)
+			Expect(err).NotTo(HaveOccurred())
+			trailers, err := git.ParseTrailersFromMessage(ctx, message)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(trailers).To(HaveKeyWithValue(constants.TrailerEmergencyRevert, []string{badHydratedSha}))
+
+			By("Merging the revert pull request")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: pr.Name, Namespace: pr.Namespace}, pr)).To(Succeed())
+				pr.Spec.State = promoterv1alpha1.PullRequestMerged
+				g.Expect(k8sClient.Update(ctx, pr)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			ctpKey := types.NamespacedName{
+				Name:      utils.KubeSafeUniqueName(ctx, utils.GetChangeTransferPolicyName(promotionStrategy.Name, testBranchDevelopment)),
+				Namespace: promotionStrategy.Namespace,
+			}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rc.Name, Namespace: rc.Namespace}, rc)).To(Succeed())
+				g.Expect(rc.Status.Phase).To(Equal(promoterv1alpha1.RevertCommitPhaseMerged))
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: promotionStrategy.Name, Namespace: promotionStrategy.Namespace}, promotionStrategy)).To(Succeed())
+				emergencyRevert := promotionStrategy.Status.Environments[0].EmergencyRevert
+				g.Expect(emergencyRevert).NotTo(BeNil())
+				g.Expect(emergencyRevert.RevertCommit).To(Equal(rc.Name))
+				g.Expect(emergencyRevert.HydratedSha).To(Equal(badHydratedSha))
+				g.Expect(emergencyRevert.DrySha).To(Equal(badSha))
+				var ctp promoterv1alpha1.ChangeTransferPolicy
+				g.Expect(k8sClient.Get(ctx, ctpKey, &ctp)).To(Succeed())
+				g.Expect(ctp.Spec.AutoMerge).To(HaveValue(BeFalse()))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Hydrating a fix to development")
+			fixSha, err := makeDryCommit(ctx, gitPath, "fix after the emergency")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hydrateEnvironment(ctx, gitPath, testBranchDevelopmentNext, fixSha, "hydrate the fix")).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: promotionStrategy.Name, Namespace: promotionStrategy.Namespace}, promotionStrategy)).To(Succeed())
+				g.Expect(promotionStrategy.Status.Environments[0].EmergencyRevert).To(BeNil())
+				g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.Sha).To(Equal(fixSha))
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+
+		It("should hold auto-merge until the RevertCommit is deleted when its pull request is closed", func() {
+			By("Promoting a bad change to development")
+			badSha, err := makeDryCommit(ctx, gitPath, "bad change to hold in an emergency")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hydrateEnvironment(ctx, gitPath, testBranchDevelopmentNext, badSha, "hydrate the emergency")).To(Succeed())
+			var badHydratedSha string
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: promotionStrategy.Name, Namespace: promotionStrategy.Namespace}, promotionStrategy)).To(Succeed())
+				g.Expect(promotionStrategy.Status.Environments[0].Active.Dry.Sha).To(Equal(badSha))
+				badHydratedSha = promotionStrategy.Status.Environments[0].Active.Hydrated.Sha
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			rc := &promoterv1alpha1.RevertCommit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name + "-emergency-closed",
+					Namespace: "default",
+				},
+				Spec: promoterv1alpha1.RevertCommitSpec{
+					PromotionStrategyRef: promoterv1alpha1.ObjectReference{Name: name},
+					DryBranch:            dryBranch,
+					Sha:                  badHydratedSha,
+					Environments:         []string{testBranchDevelopment},
+					Target:               promoterv1alpha1.RevertTargetHydrated,
+					ConfirmEmergency:     true,
+				},
+			}
+			Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, rc)
+			})
+			pr := waitForRevertPullRequest(rc)
+
+			By("Closing the revert pull request")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: pr.Name, Namespace: pr.Namespace}, pr)).To(Succeed())
+				pr.Spec.State = promoterv1alpha1.PullRequestClosed
+				g.Expect(k8sClient.Update(ctx, pr)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			ctpKey := types.NamespacedName{
+				Name:      utils.KubeSafeUniqueName(ctx, utils.GetChangeTransferPolicyName(promotionStrategy.Name, testBranchDevelopment)),
+				Namespace: promotionStrategy.Namespace,
+			}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rc.Name, Namespace: rc.Namespace}, rc)).To(Succeed())
+				g.Expect(rc.Status.Phase).To(Equal(promoterv1alpha1.RevertCommitPhaseClosed))
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: promotionStrategy.Name, Namespace: promotionStrategy.Namespace}, promotionStrategy)).To(Succeed())
+				g.Expect(promotionStrategy.Status.Environments[0].EmergencyRevert).NotTo(BeNil())
+				g.Expect(promotionStrategy.Status.Environments[0].EmergencyRevert.RevertCommit).To(Equal(rc.Name))
+				var ctp promoterv1alpha1.ChangeTransferPolicy
+				g.Expect(k8sClient.Get(ctx, ctpKey, &ctp)).To(Succeed())
+				g.Expect(ctp.Spec.AutoMerge).To(HaveValue(BeFalse()))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Deleting the RevertCommit")
+			Expect(k8sClient.Delete(ctx, rc)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: promotionStrategy.Name, Namespace: promotionStrategy.Namespace}, promotionStrategy)).To(Succeed())
+				g.Expect(promotionStrategy.Status.Environments[0].EmergencyRevert).To(BeNil())
+				var ctp promoterv1alpha1.ChangeTransferPolicy
+				g.Expect(k8sClient.Get(ctx, ctpKey, &ctp)).To(Succeed())
+				g.Expect(ctp.Spec.AutoMerge == nil || *ctp.Spec.AutoMerge).To(BeTrue())
+			}, constants.EventuallyTimeout).Should(Succeed())
 		})
 	})
 })
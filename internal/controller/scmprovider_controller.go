@@ -18,20 +18,37 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/gitauth"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/azuredevops"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/bitbucket_cloud"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/fake"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/forgejo"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/gitea"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/github"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/gitlab"
+	"github.com/argoproj-labs/gitops-promoter/internal/settings"
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
 	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
@@ -40,8 +57,12 @@ import (
 // ScmProviderReconciler reconciles a ScmProvider object
 type ScmProviderReconciler struct {
 	client.Client
-	Scheme   *runtime.Scheme
-	Recorder events.EventRecorder
+	Scheme      *runtime.Scheme
+	Recorder    events.EventRecorder
+	SettingsMgr *settings.Manager
+	// Secrets watches the Secrets the reconciler reads through its client. If nil, the Secrets of the manager's cache
+	// are watched instead.
+	Secrets *utils.ReferencedSecrets
 }
 
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=scmproviders,verbs=get;list;watch;create;update;patch;delete
@@ -54,12 +75,14 @@ type ScmProviderReconciler struct {
 // move the current state of the cluster closer to the desired state.
 func (r *ScmProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
-	logger.Info("Reconciling ScmProvider")
+	logger.V(4).Info("Reconciling ScmProvider")
 	startTime := time.Now()
 
 	var scmProvider promoterv1alpha1.ScmProvider
+	// The credentials are checked periodically, only record an event when the result of the check changes.
+	recorder := utils.NewReadyTransitionRecorder(r.Recorder)
 	// This function applies the resource status via Server-Side Apply at the end of the reconciliation. Don't write status manually.
-	defer utils.HandleReconciliationResult(ctx, startTime, &scmProvider, r.Client, r.Recorder, constants.ScmProviderControllerFieldOwner, &result, &err)
+	defer utils.HandleReconciliationResult(ctx, startTime, &scmProvider, r.Client, recorder, constants.ScmProviderControllerFieldOwner, &result, &err)
 
 	if err := r.Get(ctx, req.NamespacedName, &scmProvider); err != nil {
 		if k8serrors.IsNotFound(err) {
@@ -68,6 +91,7 @@ func (r *ScmProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 		return ctrl.Result{}, fmt.Errorf("failed to get ScmProvider: %w", err)
 	}
+	recorder.SetPrevious(scmProvider.Status.Conditions)
 
 	// Remove any existing Ready condition. We want to start fresh.
 	meta.RemoveStatusCondition(scmProvider.GetConditions(), string(promoterConditions.Ready))
@@ -81,14 +105,144 @@ func (r *ScmProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, fmt.Errorf("failed to ensure Secret finalizer: %w", err)
 	}
 
-	return ctrl.Result{}, nil
+	if err := validateScmProviderCredentials(ctx, r.Client, &scmProvider, scmProvider.Namespace); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Check the credentials again later, they may expire or be revoked on the SCM. Changes to the Secret are watched.
+	requeueDuration, err := r.SettingsMgr.GetScmProviderRequeueDuration(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get credentials check interval: %w", err)
+	}
+	return ctrl.Result{RequeueAfter: requeueDuration}, nil
+}
+
+// secretSource returns the source of the changes to the Secrets read through secrets, or to the Secrets of the manager's
+// cache if secrets is nil.
+func secretSource(mgr ctrl.Manager, secrets *utils.ReferencedSecrets, h handler.EventHandler) source.Source {
+	if secrets == nil {
+		return source.Kind[client.Object](mgr.GetCache(), &v1.Secret{}, h)
+	}
+	return secrets.Source(h)
+}
+
+// enqueueScmProvidersForSecret returns a handler that enqueues the ScmProviders that reference a Secret when it changes,
+// so that their credentials are checked again.
+func (r *ScmProviderReconciler) enqueueScmProvidersForSecret() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []ctrl.Request {
+		var scmProviders promoterv1alpha1.ScmProviderList
+		if err := r.List(ctx, &scmProviders, client.InNamespace(obj.GetNamespace())); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list ScmProvider resources")
+			return nil
+		}
+
+		var requests []ctrl.Request
+		for _, scmProvider := range scmProviders.Items {
+			if scmProvider.Spec.SecretRef != nil && scmProvider.Spec.SecretRef.Name == obj.GetName() {
+				requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&scmProvider)})
+			}
+		}
+		return requests
+	})
+}
+
+// scmProviderWithStatus is an ScmProvider or ClusterScmProvider, whose Ready condition can be set.
+type scmProviderWithStatus interface {
+	promoterv1alpha1.GenericScmProvider
+	utils.StatusConditionUpdater
+}
+
+// validateScmProviderCredentials checks that the Secret of the ScmProvider or ClusterScmProvider exists, has the
+// credentials its SCM needs and that the SCM accepts them. A missing Secret or invalid or rejected credentials are
+// reported in the Ready condition instead of failing the reconcile, since retrying won't fix them. Other errors of the
+// SCM, e.g. it being unreachable, fail the reconcile so that it is retried.
+func validateScmProviderCredentials(ctx context.Context, c client.Client, scmProvider scmProviderWithStatus, secretNamespace string) error {
+	logger := log.FromContext(ctx)
+
+	var secret *v1.Secret
+	if secretRef := scmProvider.GetSpec().SecretRef; secretRef != nil {
+		secret = &v1.Secret{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: secretNamespace, Name: secretRef.Name}, secret); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get Secret: %w", err)
+			}
+			logger.Info("Secret not found", "secret", secretRef.Name)
+			utils.SetReadyCondition(scmProvider, metav1.ConditionFalse, promoterConditions.SecretNotFound, fmt.Sprintf("Secret %q not found in namespace %q", secretRef.Name, secretNamespace))
+			return nil
+		}
+	}
+
+	if err := gitauth.ValidateCredentials(scmProvider, secret); err != nil {
+		logger.Info("Invalid credentials", "message", err.Error())
+		utils.SetReadyCondition(scmProvider, metav1.ConditionFalse, promoterConditions.InvalidCredentials, err.Error())
+		return nil
+	}
+
+	checker, err := newCredentialsChecker(ctx, scmProvider, secret)
+	if err != nil {
+		logger.Info("Invalid credentials", "message", err.Error())
+		utils.SetReadyCondition(scmProvider, metav1.ConditionFalse, promoterConditions.InvalidCredentials, err.Error())
+		return nil
+	}
+	if err := checker.CheckCredentials(ctx); err != nil {
+		var rejectedErr *scms.CredentialsRejectedError
+		if errors.As(err, &rejectedErr) {
+			logger.Info("Credentials rejected by the SCM", "message", rejectedErr.Message)
+			utils.SetReadyCondition(scmProvider, metav1.ConditionFalse, promoterConditions.InvalidCredentials, rejectedErr.Error())
+			return nil
+		}
+		return fmt.Errorf("failed to check the credentials with the SCM: %w", err)
+	}
+	return nil
+}
+
+// newCredentialsChecker creates the credentials checker of the SCM of the ScmProvider or ClusterScmProvider. The secret
+// is only nil for Fake providers, since gitauth.ValidateCredentials rejects the others without one.
+func newCredentialsChecker(ctx context.Context, scmProvider promoterv1alpha1.GenericScmProvider, secret *v1.Secret) (scms.CredentialsChecker, error) {
+	spec := scmProvider.GetSpec()
+	switch {
+	case spec.Fake != nil:
+		return fake.NewFakeCredentialsChecker(scmProvider), nil
+	case spec.GitHub != nil:
+		checker, err := github.NewGithubCredentialsChecker(scmProvider, *secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitHub credentials checker: %w", err)
+		}
+		return checker, nil
+	case spec.GitLab != nil:
+		checker, err := gitlab.NewGitlabCredentialsChecker(*secret, spec.GitLab.Domain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitLab credentials checker: %w", err)
+		}
+		return checker, nil
+	case spec.Forgejo != nil:
+		return forgejo.NewForgejoCredentialsChecker(spec.Forgejo.Domain, *secret), nil
+	case spec.Gitea != nil:
+		return gitea.NewGiteaCredentialsChecker(spec.Gitea.Domain, *secret), nil
+	case spec.BitbucketCloud != nil:
+		checker, err := bitbucket_cloud.NewBitbucketCloudCredentialsChecker(*secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Bitbucket Cloud credentials checker: %w", err)
+		}
+		return checker, nil
+	case spec.AzureDevOps != nil:
+		checker, err := azuredevops.NewAzureDevopsCredentialsChecker(ctx, scmProvider, *secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Azure DevOps credentials checker: %w", err)
+		}
+		return checker, nil
+	default:
+		return nil, scms.NewUnsupportedScmProviderError(scmProvider)
+	}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ScmProviderReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&promoterv1alpha1.ScmProvider{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Complete(r)
+		WatchesRawSource(secretSource(mgr, r.Secrets, r.enqueueScmProvidersForSecret())).
+		WithOptions(controller.Options{RateLimiter: settings.GetDefaultRateLimiter[ctrl.Request](r.SettingsMgr)}).
+		Complete(utils.NewDrainingReconciler(utils.NewShardedReconciler(mgr.GetClient(), &promoterv1alpha1.ScmProvider{}, tracing.NewReconciler("ScmProvider", r))))
 	if err != nil {
 		return fmt.Errorf("failed to create controller: %w", err)
 	}
@@ -125,6 +279,7 @@ func (r *ScmProviderReconciler) handleFinalizer(ctx context.Context, scmProvider
 		promoterv1alpha1.ScmProviderFinalizer,
 		"ScmProvider",
 		checkDependencies,
+		nil,
 	)
 
 	// If we're being deleted and finalizer was removed, also remove Secret finalizer
@@ -22,13 +22,18 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/fake"
+	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 )
 
 //go:embed testdata/ScmProvider.yaml
@@ -90,4 +95,93 @@ var _ = Describe("ScmProvider Controller", func() {
 			}, constants.EventuallyTimeout).Should(Succeed())
 		})
 	})
+
+	Context("When the Secret of the ScmProvider is missing", func() {
+		ctx := context.Background()
+
+		It("should not be Ready until the ScmProvider is fixed", func() {
+			name := "missing-secret-" + utils.KubeSafeUniqueName(ctx, randomString(15))
+			scmProvider := &promoterv1alpha1.ScmProvider{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: promoterv1alpha1.ScmProviderSpec{
+					SecretRef: &v1.LocalObjectReference{Name: name},
+					Fake:      &promoterv1alpha1.Fake{},
+				},
+			}
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, scmProvider)
+			})
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(scmProvider), scmProvider)).To(Succeed())
+				ready := utils.GetReadyCondition(scmProvider)
+				g.Expect(ready).NotTo(BeNil())
+				g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(ready.Reason).To(Equal(string(promoterConditions.SecretNotFound)))
+				g.Expect(ready.ObservedGeneration).To(Equal(scmProvider.Generation))
+				g.Expect(scmProvider.Status.ObservedGeneration).To(Equal(scmProvider.Generation))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Dropping the Secret, which the fake SCM doesn't need")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(scmProvider), scmProvider)).To(Succeed())
+				scmProvider.Spec.SecretRef = nil
+				g.Expect(k8sClient.Update(ctx, scmProvider)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(scmProvider), scmProvider)).To(Succeed())
+				g.Expect(utils.IsReady(scmProvider)).To(BeTrue())
+				g.Expect(scmProvider.Status.ObservedGeneration).To(Equal(scmProvider.Generation))
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+	})
+
+	Context("When the SCM rejects the credentials of the ScmProvider", func() {
+		ctx := context.Background()
+
+		It("should not be Ready until the SCM accepts them", func() {
+			name := "rejected-credentials-" + utils.KubeSafeUniqueName(ctx, randomString(15))
+			secret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("revoked")},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			scmProvider := &promoterv1alpha1.ScmProvider{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: promoterv1alpha1.ScmProviderSpec{
+					SecretRef: &v1.LocalObjectReference{Name: name},
+					Fake:      &promoterv1alpha1.Fake{},
+				},
+			}
+			fake.SetCredentialsRejected(scmProvider, true)
+			DeferCleanup(func() {
+				fake.SetCredentialsRejected(scmProvider, false)
+				_ = k8sClient.Delete(ctx, scmProvider)
+				_ = k8sClient.Delete(ctx, secret)
+			})
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(scmProvider), scmProvider)).To(Succeed())
+				ready := utils.GetReadyCondition(scmProvider)
+				g.Expect(ready).NotTo(BeNil())
+				g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(ready.Reason).To(Equal(string(promoterConditions.InvalidCredentials)))
+				g.Expect(ready.Message).To(ContainSubstring("credentials rejected"))
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Changing the ScmProvider once the SCM accepts the credentials")
+			fake.SetCredentialsRejected(scmProvider, false)
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(scmProvider), scmProvider)).To(Succeed())
+				scmProvider.Spec.Fake.Domain = "fake.example.com"
+				g.Expect(k8sClient.Update(ctx, scmProvider)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(scmProvider), scmProvider)).To(Succeed())
+				g.Expect(utils.IsReady(scmProvider)).To(BeTrue())
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+	})
 })
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
@@ -38,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 
 	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -99,6 +101,10 @@ var (
 	webhookReceiverPort int
 	scheme              = utils.GetScheme()
 	enqueueCTP          CTPEnqueueFunc // Function to enqueue CTP reconciliation requests
+
+	// allowlistWebhookReceiverPort is the port of a webhook receiver that only accepts Bitbucket deliveries from
+	// 192.0.2.0/24, which the tests' deliveries aren't from.
+	allowlistWebhookReceiverPort int
 )
 
 func TestControllers(t *testing.T) {
@@ -187,11 +193,16 @@ var _ = BeforeSuite(func() {
 
 	// ChangeTransferPolicy controller must be set up first so we can
 	// get the enqueue function to pass to other controllers.
+	webhookDeliveries := webhookreceiver.NewDeliveryTracker()
+	gitRepoManager := git.NewGitRepoManager()
+
 	ctpReconciler := &ChangeTransferPolicyReconciler{
-		Client:      k8sManager.GetClient(),
-		Scheme:      k8sManager.GetScheme(),
-		Recorder:    k8sManager.GetEventRecorder("ChangeTransferPolicy"),
-		SettingsMgr: settingsMgr,
+		Client:            k8sManager.GetClient(),
+		Scheme:            k8sManager.GetScheme(),
+		Recorder:          k8sManager.GetEventRecorder("ChangeTransferPolicy"),
+		SettingsMgr:       settingsMgr,
+		GitRepoManager:    gitRepoManager,
+		WebhookDeliveries: webhookDeliveries,
 	}
 	err = ctpReconciler.SetupWithManager(ctx, k8sManager)
 	Expect(err).ToNot(HaveOccurred())
@@ -217,41 +228,48 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(ctx, k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
-	err = (&PromotionStrategyReconciler{
-		Client:      k8sManager.GetClient(),
-		Scheme:      k8sManager.GetScheme(),
-		Recorder:    k8sManager.GetEventRecorder("PromotionStrategy"),
-		SettingsMgr: settingsMgr,
-		EnqueueCTP:  ctpReconciler.GetEnqueueFunc(),
-	}).SetupWithManager(ctx, k8sManager)
+	psReconciler := &PromotionStrategyReconciler{
+		Client:            k8sManager.GetClient(),
+		Scheme:            k8sManager.GetScheme(),
+		Recorder:          k8sManager.GetEventRecorder("PromotionStrategy"),
+		SettingsMgr:       settingsMgr,
+		EnqueueCTP:        ctpReconciler.GetEnqueueFunc(),
+		WebhookDeliveries: webhookDeliveries,
+	}
+	err = psReconciler.SetupWithManager(ctx, k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
-	err = (&PullRequestReconciler{
+	prReconciler := &PullRequestReconciler{
 		Client:      k8sManager.GetClient(),
 		Scheme:      k8sManager.GetScheme(),
 		Recorder:    k8sManager.GetEventRecorder("PullRequest"),
 		SettingsMgr: settingsMgr,
-	}).SetupWithManager(ctx, k8sManager)
+	}
+	err = prReconciler.SetupWithManager(ctx, k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	err = (&RevertCommitReconciler{
-		Client:   k8sManager.GetClient(),
-		Scheme:   k8sManager.GetScheme(),
-		Recorder: k8sManager.GetEventRecorder("RevertCommit"),
+		Client:         k8sManager.GetClient(),
+		Scheme:         k8sManager.GetScheme(),
+		Recorder:       k8sManager.GetEventRecorder("RevertCommit"),
+		SettingsMgr:    settingsMgr,
+		GitRepoManager: gitRepoManager,
 	}).SetupWithManager(ctx, k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	err = (&ScmProviderReconciler{
-		Client:   k8sManager.GetClient(),
-		Scheme:   k8sManager.GetScheme(),
-		Recorder: k8sManager.GetEventRecorder("ScmProvider"),
+		Client:      k8sManager.GetClient(),
+		Scheme:      k8sManager.GetScheme(),
+		Recorder:    k8sManager.GetEventRecorder("ScmProvider"),
+		SettingsMgr: settingsMgr,
 	}).SetupWithManager(ctx, k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	err = (&GitRepositoryReconciler{
-		Client:   k8sManager.GetClient(),
-		Scheme:   k8sManager.GetScheme(),
-		Recorder: k8sManager.GetEventRecorder("GitRepository"),
+		Client:      k8sManager.GetClient(),
+		Scheme:      k8sManager.GetScheme(),
+		Recorder:    k8sManager.GetEventRecorder("GitRepository"),
+		SettingsMgr: settingsMgr,
 	}).SetupWithManager(ctx, k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -290,12 +308,30 @@ var _ = BeforeSuite(func() {
 	Expect(err).ToNot(HaveOccurred())
 
 	webhookReceiverPort = constants.WebhookReceiverPort + GinkgoParallelProcess()
-	whr := webhookreceiver.NewWebhookReceiver(k8sManager, webhookreceiver.EnqueueFunc(ctpReconciler.GetEnqueueFunc()))
+	whr := webhookreceiver.NewWebhookReceiver(k8sManager, k8sManager.GetClient(), webhookreceiver.Config{
+		MaxBodySize:    webhookreceiver.DefaultMaxBodySize,
+		MaxDeliveryAge: webhookreceiver.DefaultMaxDeliveryAge,
+	},
+		webhookreceiver.EnqueueFunc(ctpReconciler.GetEnqueueFunc()), webhookreceiver.EnqueueFunc(prReconciler.GetEnqueueFunc()),
+		webhookreceiver.EnqueueFunc(psReconciler.GetEnqueueFunc()), webhookDeliveries)
 	go func() {
 		err = whr.Start(ctx, fmt.Sprintf(":%d", webhookReceiverPort))
 		Expect(err).ToNot(HaveOccurred(), "failed to start webhook receiver")
 	}()
 
+	allowlistWebhookReceiverPort = constants.WebhookReceiverPort + 100 + GinkgoParallelProcess()
+	_, allowedNetwork, err := net.ParseCIDR("192.0.2.0/24")
+	Expect(err).ToNot(HaveOccurred())
+	allowlistWhr := webhookreceiver.NewWebhookReceiver(k8sManager, k8sManager.GetClient(), webhookreceiver.Config{
+		MaxBodySize:              webhookreceiver.DefaultMaxBodySize,
+		MaxDeliveryAge:           webhookreceiver.DefaultMaxDeliveryAge,
+		BitbucketAllowedNetworks: []net.IPNet{*allowedNetwork},
+	}, nil, nil, nil, nil)
+	go func() {
+		err := allowlistWhr.Start(ctx, fmt.Sprintf(":%d", allowlistWebhookReceiverPort))
+		Expect(err).ToNot(HaveOccurred(), "failed to start webhook receiver with allowed networks")
+	}()
+
 	go func() {
 		defer GinkgoRecover()
 		err = multiClusterManager.Start(ctx)
@@ -434,10 +470,7 @@ func setupInitialTestGitRepoWithoutActiveMetadata(repo *promoterv1alpha1.GitRepo
 	_, err = runGitCmd(ctx, gitPath, "clone", testGitRepoCloneURL(repo), ".")
 	Expect(err).NotTo(HaveOccurred())
 
-	_, err = runGitCmd(ctx, gitPath, "config", "user.name", "testuser")
-	Expect(err).NotTo(HaveOccurred())
-	_, err = runGitCmd(ctx, gitPath, "config", "user.email", "testemail@test.com")
-	Expect(err).NotTo(HaveOccurred())
+	Expect(configureTestIdentity(ctx, gitPath)).To(Succeed())
 
 	_, err = runGitCmd(ctx, gitPath, "commit", "--allow-empty", "-m", "init commit")
 	Expect(err).NotTo(HaveOccurred())
@@ -500,10 +533,7 @@ func setupInitialTestGitRepoOnServer(ctx context.Context, repo *promoterv1alpha1
 	_, err = runGitCmd(ctx, gitPath, "clone", testGitRepoCloneURL(repo), ".")
 	Expect(err).NotTo(HaveOccurred())
 
-	_, err = runGitCmd(ctx, gitPath, "config", "user.name", "testuser")
-	Expect(err).NotTo(HaveOccurred())
-	_, err = runGitCmd(ctx, gitPath, "config", "user.email", "testemail@test.com")
-	Expect(err).NotTo(HaveOccurred())
+	Expect(configureTestIdentity(ctx, gitPath)).To(Succeed())
 
 	f, err := os.Create(path.Join(gitPath, "hydrator.metadata"))
 	Expect(err).NotTo(HaveOccurred())
@@ -566,10 +596,7 @@ func makeChangeAndHydrateRepo(gitPath string, repo *promoterv1alpha1.GitReposito
 	_, err := runGitCmd(ctx, gitPath, "clone", "--verbose", "--progress", "--filter=blob:none", repoURL, ".")
 	Expect(err).NotTo(HaveOccurred())
 
-	_, err = runGitCmd(ctx, gitPath, "config", "user.name", "testuser")
-	Expect(err).NotTo(HaveOccurred())
-	_, err = runGitCmd(ctx, gitPath, "config", "user.email", "testmail@test.com")
-	Expect(err).NotTo(HaveOccurred())
+	Expect(configureTestIdentity(ctx, gitPath)).To(Succeed())
 	_, err = runGitCmd(ctx, gitPath, "config", "pull.rebase", "false")
 	Expect(err).NotTo(HaveOccurred())
 
@@ -640,7 +667,7 @@ func makeChangeAndHydrateRepo(gitPath string, repo *promoterv1alpha1.GitReposito
 		metadata := git.HydratorMetadata{
 			RepoURL: "", // This is not used anywhere, we use the SCM provider's HTTPS URL instead
 			DrySha:  sha,
-			Author:  "testuser <testmail@test.com>",
+			Author:  testAuthor(),
 			Date:    metav1.Now(),
 			Subject: subject,
 			Body:    body,
@@ -724,6 +751,21 @@ func runGitCmd(ctx context.Context, directory string, args ...string) (string, e
 	return stdoutBuf.String(), nil
 }
 
+// eventReasons returns the reasons of the events recorded for the resource of the kind with the namespace and name.
+func eventReasons(ctx context.Context, kind, namespace, name string) ([]string, error) {
+	var eventList eventsv1.EventList
+	if err := k8sClient.List(ctx, &eventList, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	var reasons []string
+	for _, event := range eventList.Items {
+		if event.Regarding.Kind == kind && event.Regarding.Name == name {
+			reasons = append(reasons, event.Reason)
+		}
+	}
+	return reasons, nil
+}
+
 //nolint:unparam // length parameter is intentionally flexible for future use
 func randomString(length int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
@@ -768,7 +810,7 @@ func sendWebhookForPush(ctx context.Context, sha, branch string) {
 	// Set GitHub webhook headers
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-Github-Event", "push")
-	req.Header.Set("X-Github-Delivery", fmt.Sprintf("test-delivery-%d", time.Now().Unix()))
+	req.Header.Set("X-Github-Delivery", fmt.Sprintf("test-delivery-%d", time.Now().UnixNano()))
 
 	// Send the request
 	httpClient := &http.Client{Timeout: 5 * time.Second}
@@ -787,6 +829,26 @@ func sendWebhookForPush(ctx context.Context, sha, branch string) {
 	}
 }
 
+// postWebhookDelivery posts body to the webhook receiver with the headers and returns the response code.
+func postWebhookDelivery(ctx context.Context, headers map[string]string, body []byte) int {
+	return postWebhookDeliveryToPort(ctx, webhookReceiverPort, headers, body)
+}
+
+// postWebhookDeliveryToPort posts body to the webhook receiver listening on port with the headers and returns the
+// response code.
+func postWebhookDeliveryToPort(ctx context.Context, port int, headers map[string]string, body []byte) int {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://localhost:%d/", port), bytes.NewReader(body))
+	Expect(err).NotTo(HaveOccurred())
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.Body.Close()).To(Succeed())
+	return resp.StatusCode
+}
+
 // cloneTestRepo clones the test repo for gitRepo.Spec.Fake and configures git user. Returns the temp directory path.
 func cloneTestRepo(ctx context.Context, repo *promoterv1alpha1.GitRepository) (gitPath string, err error) {
 	gitPath, err = os.MkdirTemp("", "*")
@@ -801,19 +863,33 @@ func cloneTestRepo(ctx context.Context, repo *promoterv1alpha1.GitRepository) (g
 		return "", fmt.Errorf("failed to clone: %w", err)
 	}
 
-	_, err = runGitCmd(ctx, gitPath, "config", "user.name", "testuser")
-	if err != nil {
+	if err := configureTestIdentity(ctx, gitPath); err != nil {
 		_ = os.RemoveAll(gitPath)
-		return "", fmt.Errorf("failed to set user.name: %w", err)
+		return "", err
 	}
 
-	_, err = runGitCmd(ctx, gitPath, "config", "user.email", "testmail@test.com")
-	if err != nil {
-		_ = os.RemoveAll(gitPath)
-		return "", fmt.Errorf("failed to set user.email: %w", err)
+	return gitPath, nil
+}
+
+// testIdentity is the author and committer of the commits the test helpers create. The helpers that hydrate
+// environments also record it as the author of the dry commit in hydrator.metadata, like a hydrator would.
+var testIdentity = git.Identity{Name: "testuser", Email: "testmail@test.com"}
+
+// configureTestIdentity configures testIdentity as the author and committer of the commits created in the clone at
+// gitPath.
+func configureTestIdentity(ctx context.Context, gitPath string) error {
+	if _, err := runGitCmd(ctx, gitPath, "config", "user.name", testIdentity.Name); err != nil {
+		return fmt.Errorf("failed to set user.name: %w", err)
 	}
+	if _, err := runGitCmd(ctx, gitPath, "config", "user.email", testIdentity.Email); err != nil {
+		return fmt.Errorf("failed to set user.email: %w", err)
+	}
+	return nil
+}
 
-	return gitPath, nil
+// testAuthor returns testIdentity in the "name <email>" format of the author in hydrator.metadata.
+func testAuthor() string {
+	return fmt.Sprintf("%s <%s>", testIdentity.Name, testIdentity.Email)
 }
 
 // makeDryCommit creates a new commit on the default branch (main) and returns the dry SHA.
@@ -886,6 +962,12 @@ func makeDryCommit(ctx context.Context, gitPath, commitMessage string) (drySha s
 // hydrator.metadata and a git note. This simulates what a hydrator does.
 // Returns the hydrated commit SHA.
 func hydrateEnvironment(ctx context.Context, gitPath, branch, drySha, commitMessage string) error {
+	return hydrateEnvironmentWithBody(ctx, gitPath, branch, drySha, commitMessage, "")
+}
+
+// hydrateEnvironmentWithBody is hydrateEnvironment with the body of the dry commit in hydrator.metadata, like a
+// hydrator records it.
+func hydrateEnvironmentWithBody(ctx context.Context, gitPath, branch, drySha, commitMessage, body string) error {
 	// Fetch latest and checkout the branch
 	_, err := runGitCmd(ctx, gitPath, "fetch", "origin")
 	if err != nil {
@@ -907,9 +989,10 @@ func hydrateEnvironment(ctx context.Context, gitPath, branch, drySha, commitMess
 	// Create hydrator.metadata
 	metadata := git.HydratorMetadata{
 		DrySha:  drySha,
-		Author:  "testuser <testmail@test.com>",
+		Author:  testAuthor(),
 		Date:    metav1.Now(),
 		Subject: commitMessage,
+		Body:    body,
 	}
 	m, err := json.MarshalIndent(metadata, "", "\t")
 	if err != nil {
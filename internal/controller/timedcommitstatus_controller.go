@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/argoproj-labs/gitops-promoter/internal/settings"
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
 	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
@@ -68,7 +69,7 @@ type TimedCommitStatusReconciler struct {
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.21.0/pkg/reconcile
 func (r *TimedCommitStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
-	logger.Info("Reconciling TimedCommitStatus")
+	logger.V(4).Info("Reconciling TimedCommitStatus")
 	startTime := time.Now()
 
 	var tcs promoterv1alpha1.TimedCommitStatus
@@ -152,7 +153,7 @@ func (r *TimedCommitStatusReconciler) SetupWithManager(ctx context.Context, mgr
 		For(&promoterv1alpha1.TimedCommitStatus{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Watches(&promoterv1alpha1.PromotionStrategy{}, r.enqueueTimedCommitStatusForPromotionStrategy()).
 		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles, RateLimiter: rateLimiter}).
-		Complete(r)
+		Complete(utils.NewDrainingReconciler(utils.NewShardedReconciler(mgr.GetClient(), &promoterv1alpha1.TimedCommitStatus{}, tracing.NewReconciler("TimedCommitStatus", r))))
 	if err != nil {
 		return fmt.Errorf("failed to create controller: %w", err)
 	}
@@ -259,7 +260,7 @@ func (r *TimedCommitStatusReconciler) processEnvironments(ctx context.Context, t
 		}
 		commitStatuses = append(commitStatuses, cs)
 
-		logger.Info("Processed environment time gate",
+		logger.V(4).Info("Processed environment time gate",
 			"branch", envConfig.Branch,
 			"activeSha", currentActiveSha,
 			"phase", phase,
@@ -350,9 +351,10 @@ func (r *TimedCommitStatusReconciler) upsertCommitStatus(ctx context.Context, tc
 	// Build the apply configuration
 	commitStatusApply := acv1alpha1.CommitStatus(commitStatusName, tcs.Namespace).
 		WithLabels(map[string]string{
-			promoterv1alpha1.TimedCommitStatusLabel: utils.KubeSafeLabel(tcs.Name),
-			promoterv1alpha1.EnvironmentLabel:       utils.KubeSafeLabel(branch),
-			promoterv1alpha1.CommitStatusLabel:      "timer",
+			promoterv1alpha1.TimedCommitStatusLabel:    utils.KubeSafeLabel(tcs.Name),
+			promoterv1alpha1.EnvironmentLabel:          utils.KubeSafeLabel(branch),
+			promoterv1alpha1.CommitStatusLabel:         "timer",
+			promoterv1alpha1.PromotionStrategyUIDLabel: string(ps.UID),
 		}).
 		WithOwnerReferences(acmetav1.OwnerReference().
 			WithAPIVersion(gvk.GroupVersion().String()).
@@ -139,6 +139,7 @@ var _ = Describe("TimedCommitStatus Controller", Ordered, func() {
 				}, &cs)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(cs.Spec.Phase).To(Equal(promoterv1alpha1.CommitPhasePending))
+				g.Expect(cs.Labels).To(HaveKeyWithValue(promoterv1alpha1.PromotionStrategyUIDLabel, string(promotionStrategy.UID)))
 				expectedDuration := 1 * time.Hour
 				g.Expect(cs.Spec.Description).To(ContainSubstring(expectedDuration.String()), "Description should include the required duration")
 				g.Expect(cs.Spec.Description).To(ContainSubstring("duration gate to complete on " + testBranchDevelopment))
@@ -0,0 +1,267 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/utils/gitpaths"
+)
+
+// cloneDir is set with SetCloneDir.
+var cloneDir atomic.Pointer[string]
+
+// DefaultCloneDir returns the default directory the clones are created in, in the temporary directory.
+func DefaultCloneDir() string {
+	return filepath.Join(os.TempDir(), "gitops-promoter")
+}
+
+// SetCloneDir sets the directory the clones and their stores are created in, creating it if it doesn't exist. The
+// directory must only be used by one process, CloneCleaner removes everything else in it. If it is empty, which is the
+// default, clones are created directly in the temporary directory and CloneCleaner doesn't remove anything a previous
+// process left.
+func SetCloneDir(dir string) error {
+	if dir == "" {
+		cloneDir.Store(nil)
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create clone directory %q: %w", dir, err)
+	}
+	cloneDir.Store(&dir)
+	return nil
+}
+
+// getCloneDir returns the directory the clones are created in, or an empty string for the temporary directory.
+func getCloneDir() string {
+	if dir := cloneDir.Load(); dir != nil {
+		return *dir
+	}
+	return ""
+}
+
+// RemoveEnvironmentClone removes the cached clone used by the environment with the given active branch of a
+// GitRepository. It is called when the ChangeTransferPolicy that owns the clone is deleted.
+func RemoveEnvironmentClone(ctx context.Context, namespace, name, activeBranch string) error {
+	return removeClones(ctx, func(owner gitpaths.Owner, _ time.Time) bool {
+		return owner.Namespace == namespace && owner.Name == name && owner.ActiveBranch == activeBranch
+	})
+}
+
+// RemoveRepositoryClones removes all cached clones of a GitRepository. It is called when the GitRepository is deleted.
+func RemoveRepositoryClones(ctx context.Context, namespace, name string) error {
+	return removeClones(ctx, func(owner gitpaths.Owner, _ time.Time) bool {
+		return owner.Namespace == namespace && owner.Name == name
+	})
+}
+
+// RemoveIdleClones removes the cached clones that were not used for longer than idleTimeout. A removed clone is cloned
+// again the next time its environment is reconciled. Afterwards, it removes the stores that no clone used for longer
+// than idleTimeout. A store that is still used by a clone is never removed.
+func RemoveIdleClones(ctx context.Context, idleTimeout time.Duration) error {
+	err := removeClones(ctx, func(_ gitpaths.Owner, lastUsed time.Time) bool {
+		return time.Since(lastUsed) > idleTimeout
+	})
+	return errors.Join(err, removeUnusedStores(ctx, idleTimeout))
+}
+
+// removeClones removes the work trees of the cached clones that match and deregisters them from gitpaths. Each clone
+// is checked and removed while holding its lock, so a clone is never removed while a git operation runs in it. An
+// operation that was waiting for the lock finds no clone afterwards and fails, its reconcile clones again. The store
+// the clone shared with the clones of other environments is left to RemoveIdleClones, which removes it once no clone
+// used it for the idle timeout.
+func removeClones(ctx context.Context, matches func(owner gitpaths.Owner, lastUsed time.Time) bool) error {
+	logger := log.FromContext(ctx)
+
+	var errs []error
+	for _, key := range gitpaths.Keys() {
+		func() {
+			defer gitpaths.Lock(key)()
+
+			path := gitpaths.Get(key)
+			if path == "" {
+				// Removed while waiting for the lock.
+				return
+			}
+			owner, _ := gitpaths.GetOwner(key)
+			if !matches(owner, gitpaths.GetLastUsed(key)) {
+				return
+			}
+
+			storeKey := gitpaths.GetCloneStore(key)
+			gitpaths.Delete(key)
+			defer gitpaths.ReleaseStore(storeKey)
+			if err := removeWorktree(storeKey, path); err != nil {
+				errs = append(errs, fmt.Errorf("failed to remove clone %q: %w", path, err))
+				return
+			}
+			logger.Info("Removed cached clone", "directory", path, "gitRepository", owner.Name, "namespace", owner.Namespace,
+				"activeBranch", owner.ActiveBranch)
+		}()
+	}
+	return errors.Join(errs...)
+}
+
+// removeUnusedStores removes the stores that no clone used for longer than idleTimeout. Each store is checked and
+// removed while holding its lock, so a clone that is being added to the store either counts as a user before the check
+// or finds no store and clones a new one.
+func removeUnusedStores(ctx context.Context, idleTimeout time.Duration) error {
+	logger := log.FromContext(ctx)
+
+	var errs []error
+	for _, storeKey := range gitpaths.StoreKeys() {
+		func() {
+			defer gitpaths.LockStore(storeKey)()
+
+			path := gitpaths.DeleteUnusedStore(storeKey, idleTimeout)
+			if path == "" {
+				return
+			}
+			if err := os.RemoveAll(path); err != nil {
+				errs = append(errs, fmt.Errorf("failed to remove store %q: %w", path, err))
+				return
+			}
+			logger.Info("Removed unused store", "directory", path)
+		}()
+	}
+	return errors.Join(errs...)
+}
+
+const defaultCloneSweepInterval = 10 * time.Minute
+
+// CloneSweeper is a manager.Runnable that periodically removes cached clones that were not used for longer than the
+// configured idle timeout, so clones of environments that are no longer reconciled don't fill the disk.
+type CloneSweeper struct {
+	// idleTimeout returns how long a clone may go unused before it is removed. Zero disables the sweeper.
+	idleTimeout func(ctx context.Context) (time.Duration, error)
+	// interval is the delay between sweeps. Zero means defaultCloneSweepInterval.
+	interval time.Duration
+}
+
+// NewCloneSweeper returns a CloneSweeper that reads the idle timeout before every sweep, so changes to the
+// configuration apply without a restart.
+func NewCloneSweeper(idleTimeout func(ctx context.Context) (time.Duration, error)) *CloneSweeper {
+	return &CloneSweeper{idleTimeout: idleTimeout}
+}
+
+// Start implements manager.Runnable.
+func (s *CloneSweeper) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("clone-sweeper")
+	ctx = log.IntoContext(ctx, logger)
+
+	interval := s.interval
+	if interval <= 0 {
+		interval = defaultCloneSweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.sweep(ctx)
+		}
+	}
+}
+
+func (s *CloneSweeper) sweep(ctx context.Context) {
+	logger := log.FromContext(ctx)
+
+	idleTimeout, err := s.idleTimeout(ctx)
+	if err != nil {
+		logger.Error(err, "failed to get clone idle timeout")
+		return
+	}
+	if idleTimeout <= 0 {
+		return
+	}
+	if err := RemoveIdleClones(ctx, idleTimeout); err != nil {
+		logger.Error(err, "failed to remove idle clones")
+	}
+}
+
+// CloneCleaner is a manager.Runnable that keeps the clone directory from filling up across restarts. When it starts, it
+// removes what a previous process left in the clone directory, which it couldn't remove itself if it was killed or
+// crashed. When the manager stops, it removes the cached clones and stores of this process, since their paths are
+// random and a new process can't reuse them.
+type CloneCleaner struct {
+	// started is when the process started. Anything created in the clone directory since belongs to this process.
+	started time.Time
+}
+
+// NewCloneCleaner returns a CloneCleaner. It must be created before the manager starts, so that it tells what
+// belongs to a previous process.
+func NewCloneCleaner() *CloneCleaner {
+	return &CloneCleaner{started: time.Now()}
+}
+
+// Start implements manager.Runnable. It removes the clones left by a previous process, then waits for ctx to be done
+// and removes the clones of this process.
+func (c *CloneCleaner) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("clone-cleaner")
+	ctx = log.IntoContext(ctx, logger)
+
+	if err := removeOrphanedClones(ctx, c.started); err != nil {
+		logger.Error(err, "failed to remove clones left by a previous process")
+	}
+
+	<-ctx.Done()
+	// The reconciles stop at the same time. A clone is removed once the git operation that runs in it, if any, is done,
+	// and a store once no clone uses it anymore.
+	logger.Info("Removing cached clones")
+	err := removeClones(ctx, func(gitpaths.Owner, time.Time) bool { return true })
+	if err = errors.Join(err, removeUnusedStores(ctx, 0)); err != nil {
+		logger.Error(err, "failed to remove cached clones")
+	}
+	return nil
+}
+
+// removeOrphanedClones removes the entries of the clone directory that were last modified before the given time and
+// aren't a clone or store of this process.
+func removeOrphanedClones(ctx context.Context, before time.Time) error {
+	logger := log.FromContext(ctx)
+
+	dir := getCloneDir()
+	if dir == "" {
+		// Everything else in the temporary directory isn't ours to remove.
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read clone directory %q: %w", dir, err)
+	}
+
+	inUse := slices.Concat(gitpaths.GetValues(), gitpaths.GetStorePaths())
+	var errs []error
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if slices.Contains(inUse, path) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if !os.IsNotExist(err) {
+				errs = append(errs, fmt.Errorf("failed to stat %q: %w", path, err))
+			}
+			continue
+		}
+		if !info.ModTime().Before(before) {
+			continue
+		}
+		if err := os.RemoveAll(path); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove %q: %w", path, err))
+			continue
+		}
+		logger.Info("Removed clone left by a previous process", "directory", path)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,132 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorKind classifies why a git command failed, based on what it printed to stderr.
+type ErrorKind string
+
+const (
+	// ErrorKindAuthFailed is used when the remote rejected the credentials or the credentials lack access.
+	ErrorKindAuthFailed ErrorKind = "AuthFailed"
+	// ErrorKindRefNotFound is used when the repository, a ref or a revision does not exist.
+	ErrorKindRefNotFound ErrorKind = "RefNotFound"
+	// ErrorKindNonFastForward is used when a push was rejected because the remote branch moved.
+	ErrorKindNonFastForward ErrorKind = "NonFastForward"
+	// ErrorKindNetworkTimeout is used when the remote could not be reached, the connection was interrupted or the
+	// command timed out.
+	ErrorKindNetworkTimeout ErrorKind = "NetworkTimeout"
+	// ErrorKindDiskFull is used when the clone's volume ran out of space.
+	ErrorKindDiskFull ErrorKind = "DiskFull"
+	// ErrorKindUnknown is used for any other failure.
+	ErrorKindUnknown ErrorKind = "Unknown"
+)
+
+// stderrPatterns maps lowercase fragments of the messages git prints to stderr to the kind of failure they indicate. The
+// error_type of the git metrics is derived from the kind too. The first matching kind wins, so kinds whose messages can
+// appear together with others come first. For example, an HTTP 403 is printed after "unable to access", and a full
+// disk can break a fetch halfway through.
+var stderrPatterns = []struct {
+	kind     ErrorKind
+	messages []string
+}{
+	{ErrorKindDiskFull, []string{"no space left on device", "disk quota exceeded", "not enough space"}},
+	{ErrorKindAuthFailed, []string{"authentication failed", "could not read username", "could not read password", "terminal prompts disabled", "invalid username or password", "the requested url returned error: 401", "the requested url returned error: 403", "permission denied (publickey", "host key verification failed", "denied to"}},
+	{ErrorKindRefNotFound, []string{"couldn't find remote ref", "repository not found", "does not exist", "does not appear to be a git repository", "the requested url returned error: 404", "unknown revision", "not a valid object name", "bad revision", "invalid reference"}},
+	{ErrorKindNonFastForward, []string{"non-fast-forward", "(fetch first)", "stale info", "updates were rejected because"}},
+	{ErrorKindNetworkTimeout, []string{"timed out", "could not resolve host", "connection refused", "connection reset", "failed to connect", "network is unreachable", "early eof", "rpc failed", "the remote end hung up unexpectedly", "the requested url returned error: 5", "tls handshake", "gnutls", "unable to access"}},
+}
+
+// ClassifyStderr returns the kind of failure that a git command's stderr describes.
+func ClassifyStderr(stderr string) ErrorKind {
+	stderr = strings.ToLower(stderr)
+	for _, pattern := range stderrPatterns {
+		for _, message := range pattern.messages {
+			if strings.Contains(stderr, message) {
+				return pattern.kind
+			}
+		}
+	}
+	return ErrorKindUnknown
+}
+
+// CommandError is returned when a git command ran but failed.
+type CommandError struct {
+	// Kind is the classified reason of the failure.
+	Kind ErrorKind
+	// Stderr is what the command printed to stderr.
+	Stderr string
+	// Err is the error of the command, it includes the context's error if the command was killed.
+	Err error
+}
+
+// Error implements the error interface for CommandError.
+func (e *CommandError) Error() string {
+	if e.Stderr == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v: %s", e.Err, e.Stderr)
+}
+
+// Unwrap returns the error of the command.
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetryable reports whether the failure usually goes away without anyone changing the repository or the
+// credentials. A moved remote branch is picked up by the next fetch, and failures that couldn't be classified are
+// retried as before. Authentication failures, missing refs and a full disk need someone to fix them.
+func (e *CommandError) IsRetryable() bool {
+	switch e.Kind {
+	case ErrorKindAuthFailed, ErrorKindRefNotFound, ErrorKindDiskFull:
+		return false
+	default:
+		return true
+	}
+}
+
+// newCommandError classifies the failure of a git command. A command killed because its context ended is always a
+// timeout, whatever it printed before it was killed.
+func newCommandError(ctxErr error, stderr string, err error) *CommandError {
+	kind := ClassifyStderr(stderr)
+	if ctxErr != nil {
+		kind = ErrorKindNetworkTimeout
+	}
+	return &CommandError{Kind: kind, Stderr: stderr, Err: err}
+}
+
+// ErrorKindOf returns the kind of the git command failure in err's chain, or ErrorKindUnknown if err does not come
+// from a git command.
+func ErrorKindOf(err error) ErrorKind {
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Kind
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrorKindNetworkTimeout
+	}
+	return ErrorKindUnknown
+}
+
+// IsRetryable reports whether err comes from a git command that failed for a reason that usually goes away on its own,
+// for example because it timed out or lost its connection to the remote. Such failures should be retried with a
+// backoff instead of being reported as a problem with the repository's configuration. Errors that don't come from a
+// git command, such as a failure to get the credentials, are not retryable.
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var cmdErr *CommandError
+	return errors.As(err, &cmdErr) && cmdErr.IsRetryable()
+}
+
+// IsTerminal reports whether err comes from a git command that failed for a reason that retrying won't fix, such as
+// rejected credentials. Unlike !IsRetryable, it is false for errors that don't come from a git command.
+func IsTerminal(err error) bool {
+	var cmdErr *CommandError
+	return errors.As(err, &cmdErr) && !cmdErr.IsRetryable()
+}
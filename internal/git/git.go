@@ -1,7 +1,8 @@
 // Package git provides operations for managing Git repositories.
 //
 // The EnvironmentOperations struct provides methods for interacting with a particular clone of a repository. It ensures
-// there is a separate clone for each environment to avoid concurrency issues.
+// there is a separate clone for each environment to avoid concurrency issues. The clones of one GitRepository are work
+// trees of a shared store, so the repository's objects are fetched and kept on disk only once.
 //
 // When implementing operations that do not require an environment-specific clone, create a static function that accepts
 // the GitOperationsProvider and the GitRepository as parameters. This avoids the need to manage state to avoid
@@ -10,23 +11,34 @@ package git
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/relvacode/iso8601"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils/gitpaths"
 )
 
@@ -37,6 +49,59 @@ type EnvironmentOperations struct {
 	// activeBranch is used as part of the git path key to make sure there's one clone "per environment". Since there
 	// should be only one CTP for each unique active branch, we shouldn't run into concurrency issues between clones.
 	activeBranch string
+	// cloneDepth is the number of commits fetched when cloning. Zero clones the full history.
+	cloneDepth int
+	// signingKey signs the commits created by the operations. Nil leaves them unsigned.
+	signingKey *CommitSigningKey
+	// identity is the author and committer of the commits created by the operations. Empty fields use the default
+	// identity.
+	identity Identity
+	// manager is the GitRepoManager that created the operations, it holds the push lock of the repository.
+	manager *repoManager
+}
+
+// Identity is the name and email git records as the author and committer of the commits the promoter creates.
+type Identity struct {
+	// Name is the author and committer name.
+	Name string
+	// Email is the author and committer email.
+	Email string
+}
+
+// builtinIdentity is the identity used when no identity is configured.
+var builtinIdentity = Identity{Name: "GitOps Promoter", Email: "GitOpsPromoter@argoproj.io"}
+
+// defaultIdentity is the identity set with SetDefaultIdentity.
+var defaultIdentity atomic.Pointer[Identity]
+
+func init() {
+	defaultIdentity.Store(&builtinIdentity)
+}
+
+// SetDefaultIdentity sets the identity used for the commits the promoter creates, unless the operations were given
+// their own with SetIdentity. Empty fields keep the built-in "GitOps Promoter" identity.
+func SetDefaultIdentity(identity Identity) {
+	defaultIdentity.Store(&Identity{
+		Name:  cmp.Or(identity.Name, builtinIdentity.Name),
+		Email: cmp.Or(identity.Email, builtinIdentity.Email),
+	})
+}
+
+// SetIdentity sets the author and committer of the commits the operations create. Empty fields use the default
+// identity.
+func (g *EnvironmentOperations) SetIdentity(identity Identity) {
+	g.identity = identity
+}
+
+// identityArgs returns the git arguments that set the author and committer of the commits a command creates. The
+// identity is passed to every command that creates commits instead of being stored in the clone, so that a changed
+// configuration applies to clones that already exist.
+func (g *EnvironmentOperations) identityArgs() []string {
+	defaults := defaultIdentity.Load()
+	return []string{
+		"-c", "user.name=" + cmp.Or(g.identity.Name, defaults.Name),
+		"-c", "user.email=" + cmp.Or(g.identity.Email, defaults.Email),
+	}
 }
 
 // HydratorMetadata is an alias to v1alpha1.HydratorMetadata for convenience.
@@ -45,64 +110,519 @@ type HydratorMetadata = v1alpha1.HydratorMetadata
 // HydratorNotesRef is the git notes reference used by hydrators to store metadata about hydrated commits.
 const HydratorNotesRef = "refs/notes/hydrator.metadata"
 
+// drySHAPattern matches a full SHA-1 or SHA-256 commit hash, the same format accepted by the CRD validation for dry SHAs.
+var drySHAPattern = regexp.MustCompile(`^([a-f0-9]{40}|[a-f0-9]{64})$`)
+
+// InvalidHydratorMetadataError indicates that the hydrator.metadata file of a hydrated commit could not be used, either
+// because it is missing where it is required or because its contents are malformed.
+type InvalidHydratorMetadataError struct {
+	// Branch is the hydrated branch the metadata was read from.
+	Branch string
+	// Reason describes what is wrong with the metadata.
+	Reason string
+}
+
+// Error implements the error interface for InvalidHydratorMetadataError.
+func (e *InvalidHydratorMetadataError) Error() string {
+	return fmt.Sprintf("invalid hydrator.metadata on branch %q: %s", e.Branch, e.Reason)
+}
+
+// BranchNotFoundError indicates that a branch does not exist on the remote.
+type BranchNotFoundError struct {
+	// Branch is the branch that could not be found.
+	Branch string
+	// Err is the underlying git error.
+	Err error
+}
+
+// Error implements the error interface for BranchNotFoundError.
+func (e *BranchNotFoundError) Error() string {
+	return fmt.Sprintf("branch %q not found on remote: %v", e.Branch, e.Err)
+}
+
+// Unwrap returns the underlying git error.
+func (e *BranchNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// BranchMovedError indicates that a branch on the remote no longer points at the sha an operation expected, because
+// it was pushed to in the meantime.
+type BranchMovedError struct {
+	// Branch is the branch that moved.
+	Branch string
+	// ExpectedSha is the sha the branch was expected to point at.
+	ExpectedSha string
+	// Sha is the sha the branch points at on the remote, empty if it was deleted.
+	Sha string
+}
+
+// Error implements the error interface for BranchMovedError.
+func (e *BranchMovedError) Error() string {
+	return fmt.Sprintf("branch %q moved from %q to %q on the remote", e.Branch, e.ExpectedSha, e.Sha)
+}
+
+// parseHydratorMetadata unmarshals and validates the contents of a hydrator.metadata file.
+func parseHydratorMetadata(contents string) (HydratorMetadata, error) {
+	var hydratorFile HydratorMetadata
+	if err := json.Unmarshal([]byte(contents), &hydratorFile); err != nil {
+		return HydratorMetadata{}, fmt.Errorf("could not unmarshal metadata file: %w", err)
+	}
+	if hydratorFile.DrySha == "" {
+		return HydratorMetadata{}, errors.New("drySha is missing")
+	}
+	if !drySHAPattern.MatchString(hydratorFile.DrySha) {
+		return HydratorMetadata{}, fmt.Errorf("drySha %q is not a full commit SHA", hydratorFile.DrySha)
+	}
+	return hydratorFile, nil
+}
+
 // NewEnvironmentOperations creates a new EnvironmentOperations instance. The activeBranch parameter is used to differentiate
 // between different environments that might use the same GitRepository and avoid conflicts between concurrent
-// operations.
-func NewEnvironmentOperations(gitRepo *v1alpha1.GitRepository, gap scms.GitOperationsProvider, activeBranch string) *EnvironmentOperations {
-	return &EnvironmentOperations{
-		gap:          gap,
-		gitRepo:      gitRepo,
-		activeBranch: activeBranch,
-	}
+// operations. The cloneDepth parameter is the number of commits fetched when the repository is first cloned, zero
+// clones the full history. It has no effect on a clone that already exists.
+//
+// The operations share the push locks of a GitRepoManager of the package. The controllers get their operations from
+// the GitRepoManager they were given instead.
+func NewEnvironmentOperations(gitRepo *v1alpha1.GitRepository, gap scms.GitOperationsProvider, activeBranch string, cloneDepth int) *EnvironmentOperations {
+	return defaultRepoManager.EnvironmentOperations(gitRepo, gap, activeBranch, cloneDepth)
 }
 
-// CloneRepo clones the gitRepo to a temporary directory if needed. Does nothing if the repo is already cloned.
+// CloneRepo clones the gitRepo to a temporary directory if needed. Does nothing if the repo is already cloned and the
+// clone is healthy. A clone that was deleted or corrupted is removed and cloned again.
+//
+// The clone is a work tree of a bare store that is shared by the clones of every environment of the GitRepository, so
+// the repository's objects are only fetched and kept on disk once. The store is only cloned from the remote when no
+// clone uses it yet.
 func (g *EnvironmentOperations) CloneRepo(ctx context.Context) error {
-	if gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo)+g.activeBranch) != "" {
-		// Already cloned
-		return nil
-	}
+	defer g.lock()()
 
 	logger := log.FromContext(ctx)
+	key := g.cloneKey()
+	storeKey := g.storeKey()
+
+	if existingPath := gitpaths.Get(key); existingPath != "" {
+		err := g.checkCloneHealth(ctx, existingPath)
+		if err == nil {
+			// Already cloned
+			return g.widenSparseCheckout(ctx, existingPath)
+		}
+		logger.Info("Cached clone is unhealthy, cloning again", "directory", existingPath, "reason", err.Error())
+		gitpaths.Delete(key)
+		if err := removeWorktree(storeKey, existingPath); err != nil {
+			logger.Error(err, "failed to remove unhealthy clone", "directory", existingPath)
+		}
+		gitpaths.ReleaseStore(storeKey)
+	}
 
-	path, err := os.MkdirTemp("", "*")
+	// The work tree is added while holding the store's lock, so the store can't be removed before it counts the new
+	// clone as a user. Commands run under this lock must not take it again, so they use runCmd instead of g.runCmd.
+	defer gitpaths.LockStore(storeKey)()
+
+	storePath, err := g.acquireStore(ctx)
 	if err != nil {
+		return err
+	}
+
+	path, err := os.MkdirTemp(getCloneDir(), "*")
+	if err != nil {
+		gitpaths.ReleaseStore(storeKey)
 		return fmt.Errorf("failed to create temp directory: %w", err)
 	}
 	logger.V(4).Info("Created directory", "directory", path)
 
+	sparsePaths := g.sparseCheckoutPaths()
+	if err := g.addWorktree(ctx, storePath, path, sparsePaths); err != nil {
+		// The clone isn't registered yet, nothing else would remove a partial work tree.
+		if removeErr := removeWorktree(storeKey, path); removeErr != nil {
+			logger.Error(removeErr, "failed to remove partial clone", "directory", path)
+		}
+		gitpaths.ReleaseStore(storeKey)
+		return err
+	}
+	logger.V(4).Info("Added work tree for environment", "directory", path, "store", storePath, "activeBranch", g.activeBranch)
+
+	// Record the sparse checkout paths and the store before the path so that any operation that finds the clone also
+	// knows which files it checks out and which store it shares.
+	gitpaths.SetSparsePaths(key, slices.Clone(sparsePaths))
+	gitpaths.SetCloneStore(key, storeKey)
+	gitpaths.SetOwner(key, gitpaths.Owner{
+		Namespace:    g.gitRepo.Namespace,
+		Name:         g.gitRepo.Name,
+		ActiveBranch: g.activeBranch,
+	})
+	gitpaths.Set(key, path)
+
+	return nil
+}
+
+// acquireStore returns the path of the store shared by the clones of the GitRepository and counts a new clone as one of its
+// users. The store is cloned if it doesn't exist yet, with the clone depth of the first environment that needs it. The
+// caller must hold the store's lock.
+func (g *EnvironmentOperations) acquireStore(ctx context.Context) (string, error) {
+	logger := log.FromContext(ctx)
+	storeKey := g.storeKey()
+
+	if path := gitpaths.AcquireStore(storeKey); path != "" {
+		return path, nil
+	}
+
+	path, err := os.MkdirTemp(getCloneDir(), "*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	logger.V(4).Info("Created directory", "directory", path)
+
+	// Branches are fetched into remote-tracking refs like in a regular clone, so every work tree sees the same
+	// origin/<branch>. Automatic garbage collection runs in the foreground, so it never holds the store's files after the
+	// command that started it returned the store's lock.
+	args := []string{
+		"clone", "--bare", "--verbose", "--progress", "--filter=blob:none",
+		"--config", "remote.origin.fetch=+refs/heads/*:refs/remotes/origin/*",
+		"--config", "gc.autoDetach=false",
+	}
+	if g.cloneDepth > 0 {
+		// --depth implies --single-branch, but every environment branch is fetched into this store.
+		args = append(args, "--depth="+strconv.Itoa(g.cloneDepth), "--no-single-branch")
+	}
+	repoURL := withoutPassword(g.gap.GetGitHttpsRepoUrl(*g.gitRepo))
+	args = append(args, repoURL, path)
+
 	start := time.Now()
-	stdout, stderr, err := g.runCmd(ctx, path, "clone", "--verbose", "--progress", "--filter=blob:none", g.gap.GetGitHttpsRepoUrl(*g.gitRepo), path)
-	metrics.RecordGitOperation(g.gitRepo, metrics.GitOperationClone, metrics.GitOperationResultFromError(err), time.Since(start))
+	stdout, stderr, err := runCmd(ctx, g.gap, path, args...)
+	recordGitOperation(g.gitRepo, metrics.GitOperationClone, err, time.Since(start))
 	if err != nil {
-		logger.Error(err, "Cloned repo failed", "repo", g.gap.GetGitHttpsRepoUrl(*g.gitRepo), "stdout", stdout, "stderr", stderr)
-		return err
+		logger.Error(err, "Cloned repo failed", "repo", repoURL, "stdout", stdout, "stderr", stderr)
+		// The store isn't registered yet, nothing else would remove a partial clone.
+		if removeErr := os.RemoveAll(path); removeErr != nil {
+			logger.Error(removeErr, "failed to remove partial clone", "directory", path)
+		}
+		return "", err
 	}
 
-	stdout, stderr, err = g.runCmd(ctx, path, "config", "pull.rebase", "false")
+	stdout, stderr, err = runCmd(ctx, g.gap, path, "config", "pull.rebase", "false")
 	if err != nil {
 		logger.Error(err, "could not set git config", "stdout", stdout, "stderr", stderr)
-		return err
+		if removeErr := os.RemoveAll(path); removeErr != nil {
+			logger.Error(removeErr, "failed to remove partial clone", "directory", path)
+		}
+		return "", err
 	}
-	stdout, stderr, err = g.runCmd(ctx, path, "config", "user.name", "GitOps Promoter")
+	logger.V(4).Info("Cloned repo successful", "repo", repoURL, "depth", g.cloneDepth)
+
+	// Record the depth before the store so that any clone that uses the store also knows whether it is shallow.
+	gitpaths.SetDepth(storeKey, g.cloneDepth)
+	gitpaths.AddStore(storeKey, path)
+	return path, nil
+}
+
+// addWorktree adds the work tree for this environment's clone at path to the store at storePath, checking out only
+// sparsePaths unless they are nil. The work tree starts detached at the remote's default branch, every operation checks
+// out the branch it works on itself. The caller must hold the store's lock.
+func (g *EnvironmentOperations) addWorktree(ctx context.Context, storePath, path string, sparsePaths []string) error {
+	logger := log.FromContext(ctx)
+
+	startCommit, err := g.worktreeStartCommit(ctx, storePath)
 	if err != nil {
-		logger.Error(err, "could not set git config", "stdout", stdout, "stderr", stderr)
 		return err
 	}
+	unborn := startCommit == ""
+	if unborn {
+		// git worktree add needs a commit, and a remote without branches has none. The work tree is added at an empty
+		// commit that no ref points to and then switched to the unborn active branch, like a clone of an empty repository.
+		startCommit, err = g.emptyCommit(ctx, storePath)
+		if err != nil {
+			return err
+		}
+	}
 
-	stdout, stderr, err = g.runCmd(ctx, path, "config", "user.email", "GitOpsPromoter@argoproj.io")
+	args := []string{"worktree", "add", "--detach"}
+	if sparsePaths != nil || unborn {
+		// The files are checked out once the sparse checkout paths are set below.
+		args = append(args, "--no-checkout")
+	}
+	args = append(args, path, startCommit)
+
+	// LFS objects are only downloaded by the checkouts that need them, never for the default branch checked out here,
+	// even if git-lfs was installed globally.
+	stdout, stderr, err := runCmdWithEnv(ctx, g.gap, storePath, []string{"GIT_LFS_SKIP_SMUDGE=1"}, args...)
 	if err != nil {
-		logger.Error(err, "could not set git config", "stdout", stdout, "stderr", stderr)
-		return err
+		logger.Error(err, "could not add work tree", "directory", path, "stdout", stdout, "stderr", stderr)
+		return fmt.Errorf("failed to add work tree: %w", err)
+	}
+
+	if unborn {
+		stdout, stderr, err = runCmd(ctx, g.gap, path, "symbolic-ref", "HEAD", "refs/heads/"+g.activeBranch)
+		if err != nil {
+			logger.Error(err, "could not switch to the active branch", "stdout", stdout, "stderr", stderr)
+			return fmt.Errorf("failed to switch to branch %q: %w", g.activeBranch, err)
+		}
+	}
+
+	if sparsePaths != nil {
+		stdout, stderr, err = runCmd(ctx, g.gap, path, slices.Concat([]string{"sparse-checkout", "set", "--cone"}, sparsePaths)...)
+		if err != nil {
+			logger.Error(err, "could not set sparse checkout paths", "paths", sparsePaths, "stdout", stdout, "stderr", stderr)
+			return fmt.Errorf("failed to set sparse checkout paths: %w", err)
+		}
+		if !unborn {
+			stdout, stderr, err = runCmdWithEnv(ctx, g.gap, path, []string{"GIT_LFS_SKIP_SMUDGE=1"}, "checkout", "--force", "HEAD")
+			if err != nil {
+				logger.Error(err, "could not check out sparse checkout paths", "paths", sparsePaths, "stdout", stdout, "stderr", stderr)
+				return fmt.Errorf("failed to check out sparse checkout paths: %w", err)
+			}
+		}
+	}
+
+	if LFSEnabled() {
+		return g.setupLFS(ctx, path)
+	}
+	return nil
+}
+
+// worktreeStartCommit returns the commit new work trees of the store at storePath start at: the remote's default
+// branch, or any other branch if the remote's HEAD points at a branch that doesn't exist. It returns an empty string if
+// the remote has no branches.
+func (g *EnvironmentOperations) worktreeStartCommit(ctx context.Context, storePath string) (string, error) {
+	stdout, _, err := runCmd(ctx, g.gap, storePath, "rev-parse", "--verify", "--quiet", "HEAD^{commit}")
+	if err == nil {
+		return strings.TrimSpace(stdout), nil
+	}
+
+	stdout, stderr, err := runCmd(ctx, g.gap, storePath, "for-each-ref", "--count=1", "--format=%(objectname)", "refs/remotes/origin/")
+	if err != nil {
+		log.FromContext(ctx).Error(err, "could not list remote branches", "stderr", stderr)
+		return "", fmt.Errorf("failed to list remote branches: %w", err)
+	}
+	return strings.TrimSpace(stdout), nil
+}
+
+// emptyCommit creates a commit with an empty tree in the store at storePath and returns its SHA.
+func (g *EnvironmentOperations) emptyCommit(ctx context.Context, storePath string) (string, error) {
+	logger := log.FromContext(ctx)
+
+	// mktree reads the entries of the tree from stdin, which is empty.
+	tree, stderr, err := runCmd(ctx, g.gap, storePath, "mktree")
+	if err != nil {
+		logger.Error(err, "could not create empty tree", "stderr", stderr)
+		return "", fmt.Errorf("failed to create empty tree: %w", err)
+	}
+	commit, stderr, err := runCmd(ctx, g.gap, storePath, slices.Concat(g.identityArgs(), []string{"commit-tree", "-m", "empty", strings.TrimSpace(tree)})...)
+	if err != nil {
+		logger.Error(err, "could not create empty commit", "stderr", stderr)
+		return "", fmt.Errorf("failed to create empty commit: %w", err)
+	}
+	return strings.TrimSpace(commit), nil
+}
+
+// IsCloned reports whether this environment's clone exists. It doesn't check whether the clone is healthy, CloneRepo
+// does that.
+func (g *EnvironmentOperations) IsCloned() bool {
+	return gitpaths.Get(g.cloneKey()) != ""
+}
+
+// sparseCheckoutPaths returns the directories the GitRepository limits the checkout of its clones to, or nil if every
+// file is checked out.
+func (g *EnvironmentOperations) sparseCheckoutPaths() []string {
+	if g.gitRepo.Spec.SparseCheckout == nil || len(g.gitRepo.Spec.SparseCheckout.Paths) == 0 {
+		return nil
+	}
+	return g.gitRepo.Spec.SparseCheckout.Paths
+}
+
+// widenSparseCheckout makes the existing clone at path check out at least the files the GitRepository asks for. The
+// sparse checkout of a clone is only ever widened: paths that are no longer requested stay checked out, so an operation
+// that was configured with a different set of paths never silently misses files. Files in the root of the repository,
+// such as hydrator.metadata, are part of every cone mode sparse checkout and never need to be added.
+func (g *EnvironmentOperations) widenSparseCheckout(ctx context.Context, path string) error {
+	logger := log.FromContext(ctx)
+	key := g.cloneKey()
+
+	current := gitpaths.GetSparsePaths(key)
+	if current == nil {
+		// The clone already checks out every file.
+		return nil
+	}
+
+	requested := g.sparseCheckoutPaths()
+	if requested == nil {
+		stdout, stderr, err := g.runCmd(ctx, path, "sparse-checkout", "disable")
+		if err != nil {
+			logger.Error(err, "could not disable sparse checkout", "stdout", stdout, "stderr", stderr)
+			return fmt.Errorf("failed to disable sparse checkout: %w", err)
+		}
+		logger.Info("Disabled sparse checkout of cached clone", "directory", path)
+		gitpaths.SetSparsePaths(key, nil)
+		return nil
+	}
+
+	var missing []string
+	for _, p := range requested {
+		if !slices.Contains(current, p) {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	stdout, stderr, err := g.runCmd(ctx, path, slices.Concat([]string{"sparse-checkout", "add"}, missing)...)
+	if err != nil {
+		logger.Error(err, "could not add sparse checkout paths", "paths", missing, "stdout", stdout, "stderr", stderr)
+		return fmt.Errorf("failed to add sparse checkout paths: %w", err)
+	}
+	logger.Info("Widened sparse checkout of cached clone", "directory", path, "paths", missing)
+	gitpaths.SetSparsePaths(key, slices.Concat(current, missing))
+	return nil
+}
+
+// checkCloneHealth returns an error if the clone at path can no longer be used. Since the caller holds the clone's lock,
+// leftover lock files in the clone's own git directory can only come from a git command that was killed, so they are
+// removed instead of failing every later command that needs them. The same goes for the store while holding its lock.
+func (g *EnvironmentOperations) checkCloneHealth(ctx context.Context, path string) error {
+	stdout, stderr, err := g.runCmd(ctx, path, "rev-parse", "--is-inside-work-tree")
+	if err != nil {
+		return fmt.Errorf("not a git repository: %s", strings.TrimSpace(stderr))
+	}
+	if strings.TrimSpace(stdout) != "true" {
+		return errors.New("not a git work tree")
 	}
 
-	logger.V(4).Info("Cloned repo successful", "repo", g.gap.GetGitHttpsRepoUrl(*g.gitRepo))
+	if err := removeStaleLockFiles(ctx, worktreeGitDir(path)); err != nil {
+		return err
+	}
+	storePath := gitpaths.GetStore(g.storeKey())
+	if storePath == "" {
+		return nil
+	}
+	defer gitpaths.LockStore(g.storeKey())()
+	return removeStaleLockFiles(ctx, storePath)
+}
 
-	gitpaths.Set(g.gap.GetGitHttpsRepoUrl(*g.gitRepo)+g.activeBranch, path)
+// worktreeGitDir returns the git directory of the work tree at path. The .git file of a work tree points at its
+// directory inside the store, which holds the work tree's index and HEAD. It returns path/.git if that is not a file
+// pointing elsewhere.
+func worktreeGitDir(path string) string {
+	gitDir := filepath.Join(path, ".git")
+	contents, err := os.ReadFile(gitDir)
+	if err != nil {
+		return gitDir
+	}
+	target, found := strings.CutPrefix(strings.TrimSpace(string(contents)), "gitdir: ")
+	if !found {
+		return gitDir
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(path, target)
+	}
+	return target
+}
 
+// removeStaleLockFiles removes the lock files, such as index.lock, shallow.lock or the lock of a ref, that a killed git
+// command left in the git directory gitDir. git refuses to run commands that need a locked file, so without this a
+// single killed command would make the clone unusable. It must only be called while holding the lock that git commands
+// using gitDir hold: the clone's lock for the git directory of its work tree, or the store's lock for the store. The
+// git directories of the store's other work trees are left to their own clones.
+func removeStaleLockFiles(ctx context.Context, gitDir string) error {
+	var removeErr error
+	err := filepath.WalkDir(gitDir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() && file != gitDir && (d.Name() == "objects" || d.Name() == "worktrees") {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".lock") {
+			return nil
+		}
+		log.FromContext(ctx).Info("Removing stale lock file", "path", file)
+		if err := os.Remove(file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			removeErr = errors.Join(removeErr, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to look for stale lock files: %w", err)
+	}
+	if removeErr != nil {
+		return fmt.Errorf("failed to remove stale lock files: %w", removeErr)
+	}
 	return nil
 }
 
+// removeWorktree removes the work tree at path and the directory the store with the given key keeps for it. git names
+// that directory after the work tree's directory, which is unique since every clone gets its own temporary directory,
+// so it is found even if the work tree's .git file is gone.
+func removeWorktree(storeKey, path string) error {
+	defer gitpaths.LockStore(storeKey)()
+
+	gitDir := worktreeGitDir(path)
+	storePath := gitpaths.GetStore(storeKey)
+	if storePath != "" && !strings.HasPrefix(gitDir, filepath.Join(storePath, "worktrees")+string(filepath.Separator)) {
+		gitDir = filepath.Join(storePath, "worktrees", filepath.Base(path))
+	}
+
+	err := os.RemoveAll(path)
+	if storePath != "" {
+		err = errors.Join(err, os.RemoveAll(gitDir))
+	}
+	return err
+}
+
+// remoteKey returns the canonical form of the remote URL repoURL. The credentials, the case of the host and a trailing
+// slash or .git suffix don't change the remote a URL points at, so they don't change its key either.
+func remoteKey(repoURL string) string {
+	key := strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+	u, err := url.Parse(key)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return key
+	}
+	u.User = nil
+	u.Host = strings.ToLower(u.Host)
+	return u.String()
+}
+
+// storeKey returns the key of the store shared by the clones of every environment of the GitRepository. GitRepositories
+// are never shared, even if they use the same remote: they may belong to different tenants with different credentials,
+// and a busy repository must not hold up the git operations of another. The remote is part of the key, so that a
+// GitRepository whose URL changed gets a new store.
+func (g *EnvironmentOperations) storeKey() string {
+	return g.gitRepo.Namespace + "/" + g.gitRepo.Name + "@" + remoteKey(g.gap.GetGitHttpsRepoUrl(*g.gitRepo))
+}
+
+// cloneKey returns the key of this environment's clone.
+func (g *EnvironmentOperations) cloneKey() string {
+	return g.storeKey() + g.activeBranch
+}
+
+// lock acquires the lock for this environment's clone and returns the function that releases it. Every exported method
+// that runs git commands in the clone holds the lock for its whole duration, so environment operations that share a
+// clone never run git commands in it at the same time. Exported methods must not call each other while holding it.
+// Acquiring and releasing the lock marks the clone as used, so it is not removed by RemoveIdleClones.
+func (g *EnvironmentOperations) lock() func() {
+	key := g.cloneKey()
+	unlock := gitpaths.Lock(key)
+	gitpaths.Touch(key)
+	return func() {
+		gitpaths.Touch(key)
+		unlock()
+	}
+}
+
+// lockForPush acquires the repository's push lock from the GitRepoManager and then the lock for this environment's
+// clone, and returns the function that releases both. Exported methods that push hold it instead of lock, so that
+// pushes from the clones of different environments of one GitRepository never run at the same time.
+func (g *EnvironmentOperations) lockForPush() func() {
+	unlockRepository := g.manager.lockRepository(g.storeKey())
+	unlock := g.lock()
+	return func() {
+		unlock()
+		unlockRepository()
+	}
+}
+
 // BranchShas holds the hydrated and dry commit SHAs for a branch.
 type BranchShas struct {
 	// Dry is the SHA of the commit that was used as the dry source for hydration.
@@ -111,21 +631,36 @@ type BranchShas struct {
 	Hydrated string
 }
 
-// GetBranchShas checks out the given branch, pulls the latest changes, and returns the hydrated and dry SHAs.
+// GetBranchShas checks out the given branch, pulls the latest changes, and returns the hydrated and dry SHAs. The dry
+// SHA is empty if the branch has no hydrator.metadata file. A malformed hydrator.metadata file results in an
+// *InvalidHydratorMetadataError, and a branch that does not exist on the remote results in a *BranchNotFoundError.
 func (g *EnvironmentOperations) GetBranchShas(ctx context.Context, branch string) (BranchShas, error) {
+	defer g.lock()()
+
 	logger := log.FromContext(ctx)
-	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
+	gitPath := gitpaths.Get(g.cloneKey())
 	if gitPath == "" {
 		return BranchShas{}, fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
 	}
 
 	logger.V(4).Info("git path", "path", gitPath)
 
-	// Fetch the branch to ensure we have the latest remote ref
+	// Fetch the branch to ensure we have the latest remote ref. Shallow clones stay shallow so that new commits don't
+	// pull in the branch's full history. The hydrator rebuilds proposed branches and active branches can be force-pushed,
+	// so the remote-tracking ref is always forced to match the remote instead of requiring a fast-forward.
+	fetchArgs := []string{"fetch", "--force"}
+	if depth := gitpaths.GetDepth(g.storeKey()); depth > 0 {
+		fetchArgs = append(fetchArgs, "--depth="+strconv.Itoa(depth))
+	}
+	fetchArgs = append(fetchArgs, "origin", "+refs/heads/"+branch+":refs/remotes/origin/"+branch)
 	start := time.Now()
-	_, stderr, err := g.runCmd(ctx, gitPath, "fetch", "origin", branch)
-	metrics.RecordGitOperation(g.gitRepo, metrics.GitOperationFetch, metrics.GitOperationResultFromError(err), time.Since(start))
+	_, stderr, err := g.runCmd(ctx, gitPath, fetchArgs...)
+	recordGitOperation(g.gitRepo, metrics.GitOperationFetch, err, time.Since(start))
 	if err != nil {
+		if strings.Contains(stderr, "couldn't find remote ref") {
+			logger.Info("Branch not found on remote", "branch", branch)
+			return BranchShas{}, fmt.Errorf("failed to fetch branch %q: %w", branch, &BranchNotFoundError{Branch: branch, Err: err})
+		}
 		logger.Error(err, "could not fetch branch", "gitError", stderr)
 		return BranchShas{}, fmt.Errorf("failed to fetch branch %q: %w", branch, err)
 	}
@@ -154,10 +689,9 @@ func (g *EnvironmentOperations) GetBranchShas(ctx context.Context, branch string
 	}
 	logger.V(4).Info("Got metadata file", "branch", branch)
 
-	var hydratorFile HydratorMetadata
-	err = json.Unmarshal([]byte(metadataFileStdout), &hydratorFile)
+	hydratorFile, err := parseHydratorMetadata(metadataFileStdout)
 	if err != nil {
-		return BranchShas{}, fmt.Errorf("could not unmarshal metadata file: %w", err)
+		return BranchShas{}, &InvalidHydratorMetadataError{Branch: branch, Reason: err.Error()}
 	}
 	shas.Dry = hydratorFile.DrySha
 	logger.V(4).Info("Got dry branch sha", "branch", branch, "sha", shas.Dry)
@@ -167,9 +701,11 @@ func (g *EnvironmentOperations) GetBranchShas(ctx context.Context, branch string
 
 // GetShaMetadataFromFile retrieves commit metadata from the hydrator.metadata file for a given SHA.
 func (g *EnvironmentOperations) GetShaMetadataFromFile(ctx context.Context, sha string) (v1alpha1.CommitShaState, error) {
+	defer g.lock()()
+
 	logger := log.FromContext(ctx)
 
-	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
+	gitPath := gitpaths.Get(g.cloneKey())
 	if gitPath == "" {
 		return v1alpha1.CommitShaState{}, fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
 	}
@@ -181,17 +717,20 @@ func (g *EnvironmentOperations) GetShaMetadataFromFile(ctx context.Context, sha
 	}
 	logger.V(4).Info("Got metadata file", "sha", sha, "file", metadataFileStdout)
 
-	var hydratorFile HydratorMetadata
-	err = json.Unmarshal([]byte(metadataFileStdout), &hydratorFile)
+	hydratorFile, err := parseHydratorMetadata(metadataFileStdout)
 	if err != nil {
-		return v1alpha1.CommitShaState{}, fmt.Errorf("could not unmarshal metadata file: %w", err)
+		return v1alpha1.CommitShaState{}, fmt.Errorf("invalid hydrator.metadata in commit %q: %w", sha, err)
 	}
 
 	// Use the HTTPS URL from the SCM provider instead of the repoURL from hydrator.metadata
 	// to ensure compatibility with the UI which expects HTTP(S) URLs. ArgoCD may use SSH URLs
 	// in its hydrator.metadata which won't work for creating web links.
-	// Strip the .git suffix as the UI appends /commit/{sha} directly.
-	httpsRepoURL := strings.TrimSuffix(g.gap.GetGitHttpsRepoUrl(*g.gitRepo), ".git")
+	// Strip the .git suffix as the UI appends /commit/{sha} directly. The URL the SCM reported in the GitRepository
+	// status is preferred, the provider's URL is only used until the repository has been looked up.
+	httpsRepoURL := scms.RepositoryWebUrl(*g.gitRepo)
+	if httpsRepoURL == "" {
+		httpsRepoURL = strings.TrimSuffix(g.gap.GetGitHttpsRepoUrl(*g.gitRepo), ".git")
+	}
 
 	commitState := v1alpha1.CommitShaState{
 		Sha:        hydratorFile.DrySha,
@@ -208,7 +747,7 @@ func (g *EnvironmentOperations) GetShaMetadataFromFile(ctx context.Context, sha
 
 // GetShaMetadataFromGit retrieves commit metadata by running git commands for a given SHA.
 func (g *EnvironmentOperations) GetShaMetadataFromGit(ctx context.Context, sha string) (v1alpha1.CommitShaState, error) {
-	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
+	gitPath := gitpaths.Get(g.cloneKey())
 	if gitPath == "" {
 		return v1alpha1.CommitShaState{}, fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
 	}
@@ -246,9 +785,11 @@ func (g *EnvironmentOperations) GetShaMetadataFromGit(ctx context.Context, sha s
 
 // GetShaBody retrieves the body of a commit given its SHA.
 func (g *EnvironmentOperations) GetShaBody(ctx context.Context, sha string) (string, error) {
+	defer g.lock()()
+
 	logger := log.FromContext(ctx)
 
-	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
+	gitPath := gitpaths.Get(g.cloneKey())
 	if gitPath == "" {
 		return "", fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
 	}
@@ -265,8 +806,10 @@ func (g *EnvironmentOperations) GetShaBody(ctx context.Context, sha string) (str
 
 // GetShaAuthor retrieves the author of a commit given its SHA.
 func (g *EnvironmentOperations) GetShaAuthor(ctx context.Context, sha string) (string, error) {
+	defer g.lock()()
+
 	logger := log.FromContext(ctx)
-	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
+	gitPath := gitpaths.Get(g.cloneKey())
 	if gitPath == "" {
 		return "", fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
 	}
@@ -283,8 +826,10 @@ func (g *EnvironmentOperations) GetShaAuthor(ctx context.Context, sha string) (s
 
 // GetShaSubject retrieves the subject of a commit given its SHA.
 func (g *EnvironmentOperations) GetShaSubject(ctx context.Context, sha string) (string, error) {
+	defer g.lock()()
+
 	logger := log.FromContext(ctx)
-	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
+	gitPath := gitpaths.Get(g.cloneKey())
 	if gitPath == "" {
 		return "", fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
 	}
@@ -301,8 +846,10 @@ func (g *EnvironmentOperations) GetShaSubject(ctx context.Context, sha string) (
 
 // GetShaTime retrieves the commit time of a commit given its SHA.
 func (g *EnvironmentOperations) GetShaTime(ctx context.Context, sha string) (v1.Time, error) {
+	defer g.lock()()
+
 	logger := log.FromContext(ctx)
-	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
+	gitPath := gitpaths.Get(g.cloneKey())
 	if gitPath == "" {
 		return v1.Time{}, fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
 	}
@@ -329,10 +876,10 @@ func LsRemote(ctx context.Context, gap scms.GitOperationsProvider, gitRepo *v1al
 
 	start := time.Now()
 	args := make([]string, 0, 3+len(branches))
-	args = append(args, "ls-remote", "--heads", gap.GetGitHttpsRepoUrl(*gitRepo))
+	args = append(args, "ls-remote", "--heads", withoutPassword(gap.GetGitHttpsRepoUrl(*gitRepo)))
 	args = append(args, branches...)
 	stdout, stderr, err := runCmd(ctx, gap, "", args...)
-	metrics.RecordGitOperation(gitRepo, metrics.GitOperationLsRemote, metrics.GitOperationResultFromError(err), time.Since(start))
+	recordGitOperation(gitRepo, metrics.GitOperationLsRemote, err, time.Since(start))
 	if err != nil {
 		logger.Error(err, "could not git ls-remote", "gitError", stderr)
 		return nil, err
@@ -370,18 +917,260 @@ func LsRemote(ctx context.Context, gap scms.GitOperationsProvider, gitRepo *v1al
 		shas[branch] = sha
 	}
 
-	logger.Info("ls-remote called", "repoUrl", gap.GetGitHttpsRepoUrl(*gitRepo), "branches", branches, "shas", shas)
+	logger.Info("ls-remote called", "repoUrl", withoutPassword(gap.GetGitHttpsRepoUrl(*gitRepo)), "branches", branches, "shas", shas)
 
 	return shas, nil
 }
 
+// RemoteBranchExists reports whether the branch exists on the remote. It only runs ls-remote for the branch's ref, so
+// it is much cheaper than fetching and doesn't need a clone.
+func RemoteBranchExists(ctx context.Context, gap scms.GitOperationsProvider, gitRepo *v1alpha1.GitRepository, branch string) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	// ls-remote matches patterns against the end of the ref name, so look for the exact ref in the output.
+	ref := "refs/heads/" + branch
+	start := time.Now()
+	stdout, stderr, err := runCmd(ctx, gap, "", "ls-remote", "--heads", withoutPassword(gap.GetGitHttpsRepoUrl(*gitRepo)), ref)
+	recordGitOperation(gitRepo, metrics.GitOperationLsRemote, err, time.Since(start))
+	if err != nil {
+		logger.Error(err, "could not git ls-remote", "gitError", stderr)
+		return false, err
+	}
+	for line := range strings.SplitSeq(strings.TrimSpace(stdout), "\n") {
+		if _, lineRef, found := strings.Cut(line, "\t"); found && lineRef == ref {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetRemoteRepository gets the default branch of the repository from the remote's HEAD with git ls-remote, which also
+// checks that the repository exists and can be read with the provider's credentials. It returns a
+// scms.RepositoryNotFoundError or a scms.RepositoryAccessDeniedError with git's message if the remote refused, and the
+// git error as is otherwise. The clone URLs of the returned repository are not set.
+func GetRemoteRepository(ctx context.Context, gap scms.GitOperationsProvider, gitRepo *v1alpha1.GitRepository) (*scms.Repository, error) {
+	start := time.Now()
+	stdout, stderr, err := runCmd(ctx, gap, "", "ls-remote", "--symref", withoutPassword(gap.GetGitHttpsRepoUrl(*gitRepo)), "HEAD")
+	recordGitOperation(gitRepo, metrics.GitOperationLsRemote, err, time.Since(start))
+	if err != nil {
+		message := strings.TrimSpace(stderr)
+		if message == "" {
+			message = err.Error()
+		}
+		switch ErrorKindOf(err) {
+		case ErrorKindRefNotFound:
+			return nil, &scms.RepositoryNotFoundError{Message: message}
+		case ErrorKindAuthFailed:
+			return nil, &scms.RepositoryAccessDeniedError{Message: message}
+		default:
+			return nil, err
+		}
+	}
+
+	// HEAD of a repository with commits is reported as "ref: refs/heads/<branch>\tHEAD" before its sha.
+	repository := &scms.Repository{}
+	for line := range strings.SplitSeq(strings.TrimSpace(stdout), "\n") {
+		symref, ref, found := strings.Cut(line, "\t")
+		if found && ref == "HEAD" && strings.HasPrefix(symref, "ref: refs/heads/") {
+			repository.DefaultBranch = strings.TrimPrefix(symref, "ref: refs/heads/")
+		}
+	}
+	return repository, nil
+}
+
+// LsRemoteRefs returns the SHAs of the given full ref names, such as refs/heads/main, using a single git ls-remote. Refs
+// that don't exist on the remote are not in the returned map.
+func LsRemoteRefs(ctx context.Context, gap scms.GitOperationsProvider, gitRepo *v1alpha1.GitRepository, refs ...string) (map[string]string, error) {
+	logger := log.FromContext(ctx)
+
+	start := time.Now()
+	args := slices.Concat([]string{"ls-remote", withoutPassword(gap.GetGitHttpsRepoUrl(*gitRepo))}, refs)
+	stdout, stderr, err := runCmd(ctx, gap, "", args...)
+	recordGitOperation(gitRepo, metrics.GitOperationLsRemote, err, time.Since(start))
+	if err != nil {
+		logger.Error(err, "could not git ls-remote", "gitError", stderr)
+		return nil, err
+	}
+
+	// ls-remote matches patterns against the end of the ref name, so only keep the exact refs.
+	shas := make(map[string]string, len(refs))
+	for line := range strings.SplitSeq(strings.TrimSpace(stdout), "\n") {
+		sha, ref, found := strings.Cut(line, "\t")
+		if found && slices.Contains(refs, ref) {
+			shas[ref] = sha
+		}
+	}
+	return shas, nil
+}
+
+// withoutPassword removes any password or token from the userinfo of a repository URL. git stores the clone URL in
+// .git/config, so credentials must never be part of it; they are supplied to each command through GIT_ASKPASS instead.
+func withoutPassword(repoURL string) string {
+	u, err := url.Parse(repoURL)
+	if err != nil || u.User == nil {
+		return repoURL
+	}
+	if _, hasPassword := u.User.Password(); !hasPassword {
+		return repoURL
+	}
+	u.User = url.User(u.User.Username())
+	return u.String()
+}
+
+// recordGitOperation records the metrics of a git operation. A failed operation is also counted by the classified type
+// of its error.
+func recordGitOperation(gitRepo *v1alpha1.GitRepository, operation metrics.GitOperation, err error, duration time.Duration) {
+	metrics.RecordGitOperation(gitRepo, operation, metrics.GitOperationResultFromError(err), duration)
+	if err != nil {
+		metrics.RecordGitOperationFailure(gitRepo, operation, classifyGitError(err))
+	}
+}
+
+// classifyGitError returns the type of failure of a git command's error for the metrics, from the kind of the failure.
+func classifyGitError(err error) metrics.GitErrorType {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return metrics.GitErrorTypeTimeout
+	}
+	switch ErrorKindOf(err) {
+	case ErrorKindAuthFailed:
+		return metrics.GitErrorTypeAuthentication
+	case ErrorKindRefNotFound:
+		return metrics.GitErrorTypeNotFound
+	case ErrorKindNonFastForward:
+		return metrics.GitErrorTypeRejected
+	case ErrorKindNetworkTimeout:
+		return metrics.GitErrorTypeNetwork
+	case ErrorKindDiskFull:
+		return metrics.GitErrorTypeDiskFull
+	default:
+		return metrics.GitErrorTypeUnknown
+	}
+}
+
+// DefaultSlowCommandThreshold is the default duration after which a git command is logged as slow.
+const DefaultSlowCommandThreshold = 10 * time.Second
+
+// slowCommandThreshold is the duration after which a git command is logged as slow, zero disables the log. It is stored
+// as nanoseconds so that it can be read by concurrent reconciles without a lock.
+var slowCommandThreshold atomic.Int64
+
+func init() {
+	slowCommandThreshold.Store(int64(DefaultSlowCommandThreshold))
+}
+
+// SetSlowCommandThreshold sets the duration after which a git command is logged with its arguments. Zero disables the
+// log.
+func SetSlowCommandThreshold(threshold time.Duration) {
+	slowCommandThreshold.Store(int64(threshold))
+}
+
+// DefaultOperationTimeout is the default duration after which a git command is killed.
+const DefaultOperationTimeout = 5 * time.Minute
+
+// operationTimeout is the duration after which a git command is killed, zero disables the timeout. It is stored as
+// nanoseconds so that it can be read by concurrent reconciles without a lock.
+var operationTimeout atomic.Int64
+
+func init() {
+	operationTimeout.Store(int64(DefaultOperationTimeout))
+}
+
+// SetOperationTimeout sets the duration after which a git command is killed, together with the helper processes it
+// started. Zero disables the timeout, commands are then only killed when the reconcile's context ends.
+func SetOperationTimeout(timeout time.Duration) {
+	operationTimeout.Store(int64(timeout))
+}
+
+// logSlowCommand logs a git command that ran longer than the slow command threshold. Credentials are removed from any
+// repository URL in the arguments.
+func logSlowCommand(ctx context.Context, directory string, args []string, duration time.Duration, err error) {
+	threshold := time.Duration(slowCommandThreshold.Load())
+	if threshold <= 0 || duration < threshold {
+		return
+	}
+	log.FromContext(ctx).Info("Slow git command", "args", redactArgs(args), "directory", directory,
+		"duration", duration.String(), "threshold", threshold.String(), "failed", err != nil)
+}
+
+// redactArgs returns the arguments of a git command with the credentials removed from any repository URL.
+func redactArgs(args []string) []string {
+	redactedArgs := make([]string, len(args))
+	for i, arg := range args {
+		redactedArgs[i] = withoutPassword(arg)
+	}
+	return redactedArgs
+}
+
 // runCmd runs a git command in the given directory with the provided arguments and returns stdout, stderr, and error.
 func (g *EnvironmentOperations) runCmd(ctx context.Context, directory string, args ...string) (string, string, error) {
-	return runCmd(ctx, g.gap, directory, args...)
+	return g.runCmdWithEnv(ctx, directory, nil, args...)
+}
+
+// runCmdWithEnv runs a git command like runCmd, with env added to the command's environment. The command holds the
+// store's lock, for reading if it can run alongside the commands of other clones and for writing if it changes the
+// store's refs, config or shallow boundary, since the store is shared with the clones of other environments.
+func (g *EnvironmentOperations) runCmdWithEnv(ctx context.Context, directory string, env []string, args ...string) (string, string, error) {
+	storeKey := g.storeKey()
+	if slices.Contains(sharedStoreCommands, gitCommand(args)) {
+		defer gitpaths.RLockStore(storeKey)()
+		return runCmdWithEnv(ctx, g.gap, directory, env, args...)
+	}
+
+	defer gitpaths.LockStore(storeKey)()
+	stdout, stderr, err := runCmdWithEnv(ctx, g.gap, directory, env, args...)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		// The command holds the store's lock, so any lock file left behind in the store was left by the killed command.
+		if storePath := gitpaths.GetStore(storeKey); storePath != "" {
+			if lockErr := removeStaleLockFiles(ctx, storePath); lockErr != nil {
+				log.FromContext(ctx).Error(lockErr, "failed to clean up after a killed git command", "directory", storePath)
+			}
+		}
+	}
+	return stdout, stderr, err
+}
+
+// sharedStoreCommands are the git commands that don't change the config or shallow boundary of the store, so they run
+// concurrently in the clones of one store. They may still add objects, for example when a partial clone fetches a
+// missing blob, but git writes objects atomically. ls-remote only talks to the remote, and push only updates the
+// remote-tracking refs of the branches it pushed, which git locks one ref at a time.
+var sharedStoreCommands = []string{
+	"cat-file", "diff", "diff-tree", "grep", "log", "ls-remote", "merge-base", "merge-tree", "push", "rev-list",
+	"rev-parse", "show",
+}
+
+// gitCommand returns the git command in args, skipping the -c options that come before it.
+func gitCommand(args []string) string {
+	for i := 0; i < len(args); i++ {
+		if args[i] == "-c" {
+			i++
+			continue
+		}
+		return args[i]
+	}
+	return ""
 }
 
-// runCmd runs a git command with the provided arguments and returns stdout, stderr, and error.
+// runCmd runs a git command with the provided arguments and returns stdout, stderr, and error. The credentials are
+// requested from the provider right before the command starts and handed to git through the askpass script, so each
+// network operation uses a current token instead of one fetched at the start of the reconcile.
 func runCmd(ctx context.Context, gap scms.GitOperationsProvider, directory string, args ...string) (string, string, error) {
+	return runCmdWithEnv(ctx, gap, directory, nil, args...)
+}
+
+// runCmdWithEnv runs a git command like runCmd, with env added to the command's environment. The command runs in a span
+// with its arguments, without credentials.
+func runCmdWithEnv(ctx context.Context, gap scms.GitOperationsProvider, directory string, env []string, args ...string) (string, string, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "git "+gitCommand(args), trace.WithAttributes(
+		attribute.String("git.command", strings.Join(redactArgs(args), " ")),
+		attribute.String("git.directory", directory),
+	))
+	stdout, stderr, err := execCmd(ctx, gap, directory, env, args...)
+	tracing.EndSpan(span, err)
+	return stdout, stderr, err
+}
+
+// execCmd runs a git command for runCmdWithEnv.
+func execCmd(ctx context.Context, gap scms.GitOperationsProvider, directory string, env []string, args ...string) (string, string, error) {
 	user, err := gap.GetUser(ctx)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to get user: %w", err)
@@ -392,7 +1181,17 @@ func runCmd(ctx context.Context, gap scms.GitOperationsProvider, directory strin
 		return "", "", fmt.Errorf("failed to get token: %w", err)
 	}
 
-	cmd := exec.CommandContext(ctx, "git", args...)
+	cmdCtx := ctx
+	if timeout := time.Duration(operationTimeout.Load()); timeout > 0 {
+		var cancel context.CancelFunc
+		cmdCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(cmdCtx, "git", args...)
+	setProcessGroup(cmd)
+	// A helper process that outlives the kill could keep the output pipes open, don't wait for it forever.
+	cmd.WaitDelay = 10 * time.Second
 	cmd.Env = []string{
 		"GIT_ASKPASS=promoter_askpass.sh", // Needs to be on path
 		"GIT_USERNAME=" + user,
@@ -400,22 +1199,48 @@ func runCmd(ctx context.Context, gap scms.GitOperationsProvider, directory strin
 		"PATH=" + os.Getenv("PATH"),
 		"GIT_TERMINAL_PROMPT=0",
 	}
+	cmd.Env = append(cmd.Env, env...)
+	if sshProvider, ok := gap.(scms.GitSSHCommandProvider); ok {
+		sshCommand, cleanup, err := sshProvider.GetGitSSHCommand(ctx)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to prepare ssh credentials: %w", err)
+		}
+		defer cleanup()
+		cmd.Env = append(cmd.Env, "GIT_SSH_COMMAND="+sshCommand)
+	}
 	var stdoutBuf bytes.Buffer
 	var stderrBuf bytes.Buffer
 	cmd.Stdout = &stdoutBuf
 	cmd.Stderr = &stderrBuf
 	cmd.Dir = directory
 
+	start := time.Now()
 	if err = cmd.Start(); err != nil {
 		return "", "failed to start", fmt.Errorf("failed to start git command: %w", err)
 	}
 
-	if err = cmd.Wait(); err != nil {
-		stdErr := stderrBuf.String()
-		if stdErr != "" {
-			return stdoutBuf.String(), stdErr, fmt.Errorf("%w: %s", err, stdErr)
+	err = cmd.Wait()
+	logSlowCommand(ctx, directory, args, time.Since(start), err)
+	if err != nil {
+		ctxErr := cmdCtx.Err()
+		if ctxErr != nil {
+			// The command was killed because it timed out or the reconcile's context ended, keep the cause so callers
+			// can tell it from a git failure.
+			if ctx.Err() == nil {
+				err = fmt.Errorf("git command timed out after %s: %w: %w", time.Since(start).Round(time.Millisecond), ctxErr, err)
+			} else {
+				err = fmt.Errorf("%w: %w", ctxErr, err)
+			}
+			// The caller holds the clone's lock, so any lock file left behind in the clone's git directory was left by
+			// the killed command.
+			if directory != "" {
+				if lockErr := removeStaleLockFiles(ctx, worktreeGitDir(directory)); lockErr != nil {
+					log.FromContext(ctx).Error(lockErr, "failed to clean up after a killed git command", "directory", directory)
+				}
+			}
 		}
-		return stdoutBuf.String(), stdErr, err
+		stdErr := stderrBuf.String()
+		return stdoutBuf.String(), stdErr, newCommandError(ctxErr, stdErr, err)
 	}
 
 	return stdoutBuf.String(), stderrBuf.String(), nil
@@ -425,12 +1250,21 @@ func runCmd(ctx context.Context, gap scms.GitOperationsProvider, directory strin
 // This performs a stateless merge check without modifying the working directory. It assumes that origin/<branch> is
 // currently fetched and updated in the local repository. This should happen via GetBranchShas function earlier in the reconcile.
 func (g *EnvironmentOperations) HasConflict(ctx context.Context, proposedBranch, activeBranch string) (bool, error) {
+	defer g.lock()()
+
 	logger := log.FromContext(ctx)
-	repoPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
+	repoPath := gitpaths.Get(g.cloneKey())
 
 	// Use git merge-tree --write-tree to perform a stateless merge check
 	// With --write-tree, git exits with code 1 if conflicts exist, and writes conflict info to stdout
 	stdout, stderr, err := g.runCmd(ctx, repoPath, "merge-tree", "--write-tree", "origin/"+activeBranch, "origin/"+proposedBranch)
+	for err != nil && !strings.Contains(stdout, "CONFLICT") && g.isShallow(repoPath) {
+		// The merge base may be beyond the shallow boundary, retry with a deeper history.
+		if deepenErr := g.deepen(ctx, repoPath); deepenErr != nil {
+			return false, deepenErr
+		}
+		stdout, stderr, err = g.runCmd(ctx, repoPath, "merge-tree", "--write-tree", "origin/"+activeBranch, "origin/"+proposedBranch)
+	}
 	if err != nil {
 		// Exit code 1 with conflict info in stderr means conflicts were detected
 		if strings.Contains(stdout, "CONFLICT") {
@@ -447,23 +1281,68 @@ func (g *EnvironmentOperations) HasConflict(ctx context.Context, proposedBranch,
 	return false, nil
 }
 
+// HasSameTree reports whether the tips of the proposed and active branches have the same tree, ignoring hydrator.metadata
+// files. The hydrator writes the dry sha to those files, so they differ even when a dry commit didn't change any of the
+// environment's manifests. Both branches must have been fetched via GetBranchShas earlier in the reconciliation.
+func (g *EnvironmentOperations) HasSameTree(ctx context.Context, proposedBranch, activeBranch string) (bool, error) {
+	defer g.lock()()
+
+	logger := log.FromContext(ctx)
+	gitPath := gitpaths.Get(g.cloneKey())
+	if gitPath == "" {
+		return false, fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
+	}
+
+	// diff-tree compares tree entries only, so it doesn't need to download blobs into the partial clone.
+	_, stderr, err := g.runCmd(ctx, gitPath, "diff-tree", "--quiet", "-r", "origin/"+activeBranch, "origin/"+proposedBranch,
+		"--", ".", ":(exclude,glob)**/hydrator.metadata")
+	if err != nil {
+		// diff-tree --quiet exits with 1 when the trees differ, anything else is a failure.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return false, nil
+		}
+		logger.Error(err, "could not compare trees", "proposedBranch", proposedBranch, "activeBranch", activeBranch, "gitError", stderr)
+		return false, fmt.Errorf("failed to compare trees of branches %q and %q: %w", proposedBranch, activeBranch, err)
+	}
+	return true, nil
+}
+
 // MergeWithOursStrategy merges the proposed branch into the active branch using the "ours" strategy.
 // This assumes that both branches have already been fetched via GetBranchShas earlier in the reconciliation,
 // ensuring we merge the exact same refs that were checked for conflicts.
 func (g *EnvironmentOperations) MergeWithOursStrategy(ctx context.Context, proposedBranch, activeBranch string) error {
+	defer g.lockForPush()()
+
 	logger := log.FromContext(ctx)
-	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
+	gitPath := gitpaths.Get(g.cloneKey())
 
 	// Checkout the proposed branch from the already-fetched origin ref
-	// We use the origin ref to ensure we're working with the same commits that were checked for conflicts
-	_, stderr, err := g.runCmd(ctx, gitPath, "checkout", "-B", proposedBranch, "origin/"+proposedBranch)
+	// We use the origin ref to ensure we're working with the same commits that were checked for conflicts. The local
+	// branch is reset to the remote, and --force discards anything left in the work tree by an earlier failed merge, so
+	// a force-pushed proposed branch never leaves the clone in a state that needs to be deleted by hand.
+	_, stderr, err := g.runCmd(ctx, gitPath, "checkout", "--force", "--ignore-other-worktrees", "-B", proposedBranch, "origin/"+proposedBranch)
 	if err != nil {
 		logger.Error(err, "Failed to checkout branch", "branch", proposedBranch, "stderr", stderr)
 		return fmt.Errorf("failed to checkout branch %q: %w", proposedBranch, err)
 	}
 
-	// Perform the merge with "ours" strategy using the already-fetched origin ref
-	_, stderr, err = g.runCmd(ctx, gitPath, "merge", "-s", "ours", "origin/"+activeBranch)
+	// Perform the merge with "ours" strategy using the already-fetched origin ref. The signing configuration is only
+	// passed to this command, so the key is never stored in the clone's config.
+	signingArgs, signingEnv, cleanupSigning, err := g.signingConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to prepare commit signing: %w", err)
+	}
+	defer cleanupSigning()
+	mergeArgs := slices.Concat(g.identityArgs(), signingArgs, []string{"merge", "-s", "ours", "origin/" + activeBranch})
+	_, stderr, err = g.runCmdWithEnv(ctx, gitPath, signingEnv, mergeArgs...)
+	for err != nil && g.isShallow(gitPath) {
+		// The merge base may be beyond the shallow boundary, retry with a deeper history.
+		if deepenErr := g.deepen(ctx, gitPath); deepenErr != nil {
+			return deepenErr
+		}
+		_, stderr, err = g.runCmdWithEnv(ctx, gitPath, signingEnv, mergeArgs...)
+	}
 	if err != nil {
 		logger.Error(err, "Failed to merge branch", "proposedBranch", proposedBranch, "activeBranch", activeBranch, "stderr", stderr)
 		return fmt.Errorf("failed to merge branch %q into %q with 'ours' strategy: %w", activeBranch, proposedBranch, err)
@@ -473,6 +1352,9 @@ func (g *EnvironmentOperations) MergeWithOursStrategy(ctx context.Context, propo
 	_, stderr, err = g.runCmd(ctx, gitPath, "push", "origin", proposedBranch)
 	if err != nil {
 		logger.Error(err, "Failed to push merged branch", "proposedBranch", proposedBranch, "activeBranch", activeBranch, "stderr", stderr)
+		if isSignatureRejected(stderr) {
+			err = &CommitSignatureRejectedError{Branch: proposedBranch, Err: err}
+		}
 		return fmt.Errorf("failed to push merged branch %q: %w", proposedBranch, err)
 	}
 
@@ -480,11 +1362,312 @@ func (g *EnvironmentOperations) MergeWithOursStrategy(ctx context.Context, propo
 	return nil
 }
 
+// CreateBranch creates branch on the remote pointing at the tip of fromBranch. It assumes that origin/<fromBranch> is
+// currently fetched, which should happen via GetBranchShas earlier in the reconcile. The push fails rather than
+// overwriting branch if it was created on the remote in the meantime.
+func (g *EnvironmentOperations) CreateBranch(ctx context.Context, branch, fromBranch string) error {
+	defer g.lockForPush()()
+
+	logger := log.FromContext(ctx)
+	gitPath := gitpaths.Get(g.cloneKey())
+	if gitPath == "" {
+		return fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
+	}
+
+	// An empty expected value for --force-with-lease means the push only succeeds if the remote branch does not exist.
+	start := time.Now()
+	_, stderr, err := g.runCmd(ctx, gitPath, "push", "--force-with-lease=refs/heads/"+branch+":", "origin", "refs/remotes/origin/"+fromBranch+":refs/heads/"+branch)
+	recordGitOperation(g.gitRepo, metrics.GitOperationPush, err, time.Since(start))
+	if err != nil {
+		logger.Error(err, "could not create branch", "branch", branch, "fromBranch", fromBranch, "gitError", stderr)
+		return fmt.Errorf("failed to create branch %q from %q: %w", branch, fromBranch, err)
+	}
+
+	logger.Info("Created branch", "branch", branch, "fromBranch", fromBranch)
+	return nil
+}
+
+// ResetBranch force-updates branch on the remote to sha, which must be in the local clone. The remote branch is read
+// again right before the push, and the push is leased on it, so a branch that no longer points at expectedSha is not
+// overwritten and commits pushed in the meantime are not lost: a *BranchMovedError is returned instead.
+func (g *EnvironmentOperations) ResetBranch(ctx context.Context, branch, sha, expectedSha string) error {
+	defer g.lockForPush()()
+
+	logger := log.FromContext(ctx)
+	gitPath := gitpaths.Get(g.cloneKey())
+	if gitPath == "" {
+		return fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
+	}
+
+	ref := "refs/heads/" + branch
+	remoteShas, err := LsRemoteRefs(ctx, g.gap, g.gitRepo, ref)
+	if err != nil {
+		return fmt.Errorf("failed to read branch %q before resetting it: %w", branch, err)
+	}
+	if remoteShas[ref] != expectedSha {
+		return &BranchMovedError{Branch: branch, ExpectedSha: expectedSha, Sha: remoteShas[ref]}
+	}
+
+	start := time.Now()
+	_, stderr, err := g.runCmd(ctx, gitPath, "push", "--force-with-lease="+ref+":"+remoteShas[ref], "origin", sha+":"+ref)
+	recordGitOperation(g.gitRepo, metrics.GitOperationPush, err, time.Since(start))
+	if err != nil {
+		logger.Error(err, "could not reset branch", "branch", branch, "sha", sha, "expectedSha", expectedSha, "gitError", stderr)
+		return fmt.Errorf("failed to reset branch %q to %q: %w", branch, sha, err)
+	}
+
+	logger.Info("Reset branch", "branch", branch, "sha", sha, "previousSha", expectedSha)
+	return nil
+}
+
+// DeleteBranch deletes branch on the remote. A branch that doesn't exist is already deleted.
+func (g *EnvironmentOperations) DeleteBranch(ctx context.Context, branch string) error {
+	defer g.lockForPush()()
+
+	logger := log.FromContext(ctx)
+	gitPath := gitpaths.Get(g.cloneKey())
+	if gitPath == "" {
+		return fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
+	}
+
+	start := time.Now()
+	_, stderr, err := g.runCmd(ctx, gitPath, "push", "origin", "--delete", "refs/heads/"+branch)
+	recordGitOperation(g.gitRepo, metrics.GitOperationPush, err, time.Since(start))
+	if err != nil {
+		if strings.Contains(stderr, "remote ref does not exist") {
+			logger.V(4).Info("Branch to delete does not exist", "branch", branch)
+			return nil
+		}
+		logger.Error(err, "could not delete branch", "branch", branch, "gitError", stderr)
+		return fmt.Errorf("failed to delete branch %q: %w", branch, err)
+	}
+
+	logger.Info("Deleted branch", "branch", branch)
+	return nil
+}
+
+// IsAncestor reports whether ancestor is an ancestor of (or equal to) descendant. The descendant is fetched from origin
+// if it is not in the local clone, since dry commits usually live on a branch this clone does not track. Fetching a
+// commit brings its whole history, so an ancestor that is still missing afterward cannot be part of that history.
+func (g *EnvironmentOperations) IsAncestor(ctx context.Context, ancestor, descendant string) (bool, error) {
+	defer g.lock()()
+	return g.isAncestor(ctx, ancestor, descendant)
+}
+
+// isAncestor implements IsAncestor, the caller must hold the clone's lock.
+func (g *EnvironmentOperations) isAncestor(ctx context.Context, ancestor, descendant string) (bool, error) {
+	logger := log.FromContext(ctx)
+	gitPath := gitpaths.Get(g.cloneKey())
+	if gitPath == "" {
+		return false, fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
+	}
+
+	if !g.hasCommit(ctx, gitPath, descendant) {
+		start := time.Now()
+		_, stderr, err := g.runCmd(ctx, gitPath, "fetch", "origin", descendant)
+		recordGitOperation(g.gitRepo, metrics.GitOperationFetch, err, time.Since(start))
+		if err != nil {
+			logger.V(4).Info("could not fetch commit", "sha", descendant, "gitError", stderr)
+			return false, fmt.Errorf("failed to fetch commit %q: %w", descendant, err)
+		}
+	}
+
+	if !g.hasCommit(ctx, gitPath, ancestor) {
+		return g.retryIsAncestorDeepened(ctx, gitPath, ancestor, descendant)
+	}
+
+	_, stderr, err := g.runCmd(ctx, gitPath, "merge-base", "--is-ancestor", ancestor, descendant)
+	if err != nil {
+		// merge-base --is-ancestor exits with 1 when the commit is not an ancestor, anything else is a failure.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return g.retryIsAncestorDeepened(ctx, gitPath, ancestor, descendant)
+		}
+		logger.Error(err, "could not run merge-base --is-ancestor", "ancestor", ancestor, "descendant", descendant, "gitError", stderr)
+		return false, fmt.Errorf("failed to check if %q is an ancestor of %q: %w", ancestor, descendant, err)
+	}
+
+	return true, nil
+}
+
+// revertMessagePattern matches the line git revert, and the SCMs' revert buttons, add to the message of a revert commit.
+var revertMessagePattern = regexp.MustCompile(`(?m)^This reverts commit ([a-f0-9]{40}|[a-f0-9]{64})\b`)
+
+// RevertedBy returns the sha of the commit between sha (exclusive) and head (inclusive) that reverts sha, or an empty
+// string if sha isn't reverted in head. Reverts are recognized by the "This reverts commit <sha>" line of their
+// message, and a revert that is itself reverted no longer counts. head must be in the clone, IsAncestor fetches it.
+func (g *EnvironmentOperations) RevertedBy(ctx context.Context, sha, head string) (string, error) {
+	defer g.lock()()
+
+	gitPath := gitpaths.Get(g.cloneKey())
+	if gitPath == "" {
+		return "", fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
+	}
+
+	// Each commit is printed as its sha, a NUL and its message, and the commits are separated by a record separator.
+	stdout, stderr, err := g.runCmd(ctx, gitPath, "log", "--reverse", "--format=%H%x00%B%x1e", sha+".."+head)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "could not list commits", "from", sha, "to", head, "gitError", stderr)
+		return "", fmt.Errorf("failed to list the commits from %q to %q: %w", sha, head, err)
+	}
+
+	revertedBy := ""
+	for record := range strings.SplitSeq(stdout, "\x1e") {
+		commitSha, message, found := strings.Cut(strings.TrimSpace(record), "\x00")
+		if !found {
+			continue
+		}
+		for _, match := range revertMessagePattern.FindAllStringSubmatch(message, -1) {
+			switch match[1] {
+			case sha:
+				revertedBy = commitSha
+			case revertedBy:
+				revertedBy = ""
+			}
+		}
+	}
+	return revertedBy, nil
+}
+
+// CommitWithTrailer fetches branch and returns the sha of the newest commit after since on it whose message has the
+// trailer key with value, or an empty string if there is none. since must be on branch.
+func (g *EnvironmentOperations) CommitWithTrailer(ctx context.Context, since, branch, key, value string) (string, error) {
+	defer g.lock()()
+
+	logger := log.FromContext(ctx)
+	gitPath := gitpaths.Get(g.cloneKey())
+	if gitPath == "" {
+		return "", fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
+	}
+
+	start := time.Now()
+	_, stderr, err := g.runCmd(ctx, gitPath, "fetch", "--force", "origin", "+refs/heads/"+branch+":refs/remotes/origin/"+branch)
+	recordGitOperation(g.gitRepo, metrics.GitOperationFetch, err, time.Since(start))
+	if err != nil {
+		logger.Error(err, "could not fetch branch", "branch", branch, "gitError", stderr)
+		return "", fmt.Errorf("failed to fetch branch %q: %w", branch, err)
+	}
+
+	// Each commit is printed as its sha, a NUL and the values of its key trailers, and the commits are separated by a
+	// record separator.
+	stdout, stderr, err := g.runCmd(ctx, gitPath, "log", "--format=%H%x00%(trailers:key="+key+",valueonly)%x1e", since+"..origin/"+branch)
+	if err != nil {
+		logger.Error(err, "could not list commits", "from", since, "branch", branch, "gitError", stderr)
+		return "", fmt.Errorf("failed to list the commits after %q on branch %q: %w", since, branch, err)
+	}
+	for record := range strings.SplitSeq(stdout, "\x1e") {
+		commitSha, values, found := strings.Cut(strings.TrimSpace(record), "\x00")
+		if !found {
+			continue
+		}
+		for trailerValue := range strings.SplitSeq(values, "\n") {
+			if strings.TrimSpace(trailerValue) == value {
+				return commitSha, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// retryIsAncestorDeepened handles a negative IsAncestor answer. In a shallow clone the ancestor may only be missing
+// because it is beyond the shallow boundary, so the clone is deepened and the check repeated, until the clone has its
+// full history, before trusting the answer.
+func (g *EnvironmentOperations) retryIsAncestorDeepened(ctx context.Context, gitPath, ancestor, descendant string) (bool, error) {
+	if !g.isShallow(gitPath) {
+		return false, nil
+	}
+	if err := g.deepen(ctx, gitPath); err != nil {
+		return false, err
+	}
+	return g.isAncestor(ctx, ancestor, descendant)
+}
+
+// isShallow returns true if the clone was recorded as shallow when it was cloned and has not been unshallowed since.
+func (g *EnvironmentOperations) isShallow(gitPath string) bool {
+	return gitPath != "" && gitpaths.GetDepth(g.storeKey()) > 0
+}
+
+// deepenFactor is how many times deeper than its recorded depth a shallow clone is deepened in each step.
+const deepenFactor = 4
+
+// maxDeepenedDepth is the depth beyond which a shallow clone isn't deepened in steps anymore, but gets its full history.
+const maxDeepenedDepth = 1024
+
+// deepen extends the history of a shallow clone for an operation that failed or gave a wrong answer because it walks
+// history beyond the shallow boundary. Each call deepens the clone to deepenFactor times its recorded depth and records
+// the new depth, so later fetches keep the history. Once that would exceed maxDeepenedDepth, the full history is fetched
+// instead. Callers retry the operation and deepen again while it fails and the clone is still shallow.
+func (g *EnvironmentOperations) deepen(ctx context.Context, gitPath string) error {
+	logger := log.FromContext(ctx)
+	key := g.storeKey()
+
+	depth := gitpaths.GetDepth(key)
+	newDepth := depth * deepenFactor
+	if newDepth > maxDeepenedDepth {
+		return g.unshallow(ctx, gitPath)
+	}
+
+	start := time.Now()
+	_, stderr, err := g.runCmd(ctx, gitPath, "fetch", "--deepen="+strconv.Itoa(newDepth-depth), "origin")
+	recordGitOperation(g.gitRepo, metrics.GitOperationFetch, err, time.Since(start))
+	if err != nil {
+		logger.Error(err, "could not deepen clone", "depth", newDepth, "gitError", stderr)
+		return fmt.Errorf("failed to deepen shallow clone of %q to depth %d: %w", g.gitRepo.Name, newDepth, err)
+	}
+
+	// The clone is not shallow anymore once it's deeper than the repository's history.
+	stdout, _, err := g.runCmd(ctx, gitPath, "rev-parse", "--is-shallow-repository")
+	if err == nil && strings.TrimSpace(stdout) == "false" {
+		newDepth = 0
+	}
+	gitpaths.SetDepth(key, newDepth)
+	logger.V(4).Info("Deepened shallow clone", "repo", g.gitRepo.Name, "depth", newDepth, "duration", time.Since(start))
+	return nil
+}
+
+// unshallow fetches the full history into a shallow clone and records the clone as complete, so later operations that
+// walk history beyond the shallow boundary (merge bases, ancestry checks, history) don't fail or give wrong answers.
+func (g *EnvironmentOperations) unshallow(ctx context.Context, gitPath string) error {
+	logger := log.FromContext(ctx)
+	key := g.storeKey()
+
+	// A clone deeper than the repository's history is not actually shallow, and --unshallow refuses to run on it.
+	stdout, _, err := g.runCmd(ctx, gitPath, "rev-parse", "--is-shallow-repository")
+	if err == nil && strings.TrimSpace(stdout) == "false" {
+		gitpaths.SetDepth(key, 0)
+		return nil
+	}
+
+	start := time.Now()
+	_, stderr, err := g.runCmd(ctx, gitPath, "fetch", "--unshallow", "origin")
+	recordGitOperation(g.gitRepo, metrics.GitOperationFetch, err, time.Since(start))
+	if err != nil {
+		logger.Error(err, "could not unshallow clone", "gitError", stderr)
+		return fmt.Errorf("failed to fetch full history for shallow clone of %q: %w", g.gitRepo.Name, err)
+	}
+
+	gitpaths.SetDepth(key, 0)
+	logger.Info("Fetched full history for shallow clone", "repo", g.gitRepo.Name, "duration", time.Since(start))
+	return nil
+}
+
+// hasCommit returns true if the commit exists in the local clone.
+func (g *EnvironmentOperations) hasCommit(ctx context.Context, gitPath, sha string) bool {
+	_, _, err := g.runCmd(ctx, gitPath, "cat-file", "-e", sha+"^{commit}")
+	return err == nil
+}
+
 // GetRevListFirstParent retrieves the first parent commit SHAs for the given branch using git rev-list.
 func (g *EnvironmentOperations) GetRevListFirstParent(ctx context.Context, branch string, maxCount int) ([]string, error) {
+	defer g.lock()()
+	return g.revListFirstParent(ctx, branch, maxCount)
+}
+
+// revListFirstParent implements GetRevListFirstParent, the caller must hold the clone's lock.
+func (g *EnvironmentOperations) revListFirstParent(ctx context.Context, branch string, maxCount int) ([]string, error) {
 	logger := log.FromContext(ctx)
 
-	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
+	gitPath := gitpaths.Get(g.cloneKey())
 	if gitPath == "" {
 		return nil, fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
 	}
@@ -501,6 +1684,13 @@ func (g *EnvironmentOperations) GetRevListFirstParent(ctx context.Context, branc
 	}
 
 	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) < maxCount && g.isShallow(gitPath) {
+		// The history may have been cut off by the shallow boundary, retry with a deeper history.
+		if err := g.deepen(ctx, gitPath); err != nil {
+			return nil, err
+		}
+		return g.revListFirstParent(ctx, branch, maxCount)
+	}
 	return lines, nil
 }
 
@@ -534,8 +1724,10 @@ func AddTrailerToCommitMessage(ctx context.Context, commitMessage, trailerKey, t
 
 // FetchNotes fetches the git notes from the remote repository.
 func (g *EnvironmentOperations) FetchNotes(ctx context.Context) error {
+	defer g.lock()()
+
 	logger := log.FromContext(ctx)
-	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
+	gitPath := gitpaths.Get(g.cloneKey())
 	if gitPath == "" {
 		return fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
 	}
@@ -550,7 +1742,7 @@ func (g *EnvironmentOperations) FetchNotes(ctx context.Context) error {
 			logger.V(4).Info("Git notes ref does not exist on remote", "ref", HydratorNotesRef)
 			return nil
 		}
-		metrics.RecordGitOperation(g.gitRepo, metrics.GitOperationFetchNotes, metrics.GitOperationResultFailure, time.Since(start))
+		recordGitOperation(g.gitRepo, metrics.GitOperationFetchNotes, err, time.Since(start))
 		logger.Error(err, "Failed to fetch git notes", "stderr", stderr)
 		return fmt.Errorf("failed to fetch git notes: %w", err)
 	}
@@ -563,8 +1755,10 @@ func (g *EnvironmentOperations) FetchNotes(ctx context.Context) error {
 // GetHydratorNote reads the hydrator git note for a given commit SHA.
 // Returns an empty HydratorMetadata if no note exists for the commit.
 func (g *EnvironmentOperations) GetHydratorNote(ctx context.Context, sha string) (HydratorMetadata, error) {
+	defer g.lock()()
+
 	logger := log.FromContext(ctx)
-	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
+	gitPath := gitpaths.Get(g.cloneKey())
 	if gitPath == "" {
 		return HydratorMetadata{}, fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
 	}
@@ -636,9 +1830,11 @@ func ParseTrailersFromMessage(ctx context.Context, commitMessage string) (map[st
 // GetTrailers retrieves the trailers from the last commit in the repository using git interpret-trailers.
 // Returns a map where each key can have multiple values (e.g., multiple "Signed-off-by" trailers).
 func (g *EnvironmentOperations) GetTrailers(ctx context.Context, sha string) (map[string][]string, error) {
+	defer g.lock()()
+
 	logger := log.FromContext(ctx)
 	// run git interpret-trailers to get the trailers from the last commit
-	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
+	gitPath := gitpaths.Get(g.cloneKey())
 	if gitPath == "" {
 		return nil, fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
 	}
@@ -660,8 +1856,10 @@ func (g *EnvironmentOperations) GetTrailers(ctx context.Context, sha string) (ma
 // The format parameter uses git's pretty-format placeholders (e.g., %ae for author email, %ce for committer email).
 // See https://git-scm.com/docs/git-show#_pretty_formats for available format options.
 func (g *EnvironmentOperations) GitShow(ctx context.Context, sha, format string) (string, error) {
+	defer g.lock()()
+
 	logger := log.FromContext(ctx)
-	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
+	gitPath := gitpaths.Get(g.cloneKey())
 	if gitPath == "" {
 		return "", fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
 	}
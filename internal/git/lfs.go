@@ -0,0 +1,72 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sync/atomic"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/utils/gitpaths"
+)
+
+// lfsEnabled is set with SetLFSEnabled.
+var lfsEnabled atomic.Bool
+
+// SetLFSEnabled sets whether new clones are set up for Git LFS. It requires git-lfs to be installed. Downloading LFS
+// objects takes time and disk space, so it is disabled by default and files stored in LFS are checked out as pointer
+// files.
+func SetLFSEnabled(enabled bool) {
+	lfsEnabled.Store(enabled)
+}
+
+// LFSEnabled reports whether new clones are set up for Git LFS.
+func LFSEnabled() bool {
+	return lfsEnabled.Load()
+}
+
+// setupLFS installs the LFS filters and hooks in the clone at path, so that checkouts replace pointer files with the
+// objects they point to and pushes upload any LFS objects the remote is missing. git-lfs asks `git credential fill` for
+// the credentials of the LFS endpoint, which falls back to the same GIT_ASKPASS as every other command, so the endpoint
+// is accessed with the repository's credentials. Many SCMs don't implement the LFS locking API, so the lock
+// verification that would otherwise fail pushes is turned off. The caller must hold the store's lock.
+func (g *EnvironmentOperations) setupLFS(ctx context.Context, path string) error {
+	logger := log.FromContext(ctx)
+
+	stdout, stderr, err := runCmd(ctx, g.gap, path, "lfs", "install", "--local")
+	if err != nil {
+		logger.Error(err, "could not install git lfs", "stdout", stdout, "stderr", stderr)
+		return fmt.Errorf("failed to install git lfs in the clone: %w", err)
+	}
+	stdout, stderr, err = runCmd(ctx, g.gap, path, "config", "lfs.locksverify", "false")
+	if err != nil {
+		logger.Error(err, "could not set git config", "stdout", stdout, "stderr", stderr)
+		return fmt.Errorf("failed to disable git lfs lock verification: %w", err)
+	}
+	return nil
+}
+
+// UsesLFS reports whether a .gitattributes file anywhere in the tree of ref routes files through the LFS filter. It
+// only reads the .gitattributes files, so it is cheap even in a partial clone.
+func (g *EnvironmentOperations) UsesLFS(ctx context.Context, ref string) (bool, error) {
+	defer g.lock()()
+
+	gitPath := gitpaths.Get(g.cloneKey())
+	if gitPath == "" {
+		return false, fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
+	}
+
+	_, stderr, err := g.runCmd(ctx, gitPath, "grep", "--quiet", "--fixed-strings", "-e", "filter=lfs", ref, "--", ":(glob)**/.gitattributes")
+	if err != nil {
+		// git grep exits with 1 when nothing matched.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return false, nil
+		}
+		log.FromContext(ctx).Error(err, "could not look for git lfs attributes", "ref", ref, "gitError", stderr)
+		return false, fmt.Errorf("failed to look for git lfs attributes in %q: %w", ref, err)
+	}
+	return true, nil
+}
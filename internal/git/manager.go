@@ -0,0 +1,55 @@
+package git
+
+import (
+	"sync"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+// GitRepoManager provides the git operations of the environments of GitRepositories. The controllers share the one
+// created in cmd/main.go instead of constructing the operations themselves, so that the operations that push to a
+// repository are serialized across the clones of all of its environments, whichever controller runs them.
+type GitRepoManager interface {
+	// EnvironmentOperations returns the git operations of the environment of gitRepo whose active branch is
+	// activeBranch, see NewEnvironmentOperations.
+	EnvironmentOperations(gitRepo *v1alpha1.GitRepository, gap scms.GitOperationsProvider, activeBranch string, cloneDepth int) *EnvironmentOperations
+}
+
+// repoManager is the GitRepoManager returned by NewGitRepoManager.
+type repoManager struct {
+	// pushLocks maps the store key of a GitRepository to the *sync.Mutex held by the operations that push to it.
+	pushLocks sync.Map
+}
+
+var _ GitRepoManager = &repoManager{}
+
+// defaultRepoManager manages the operations created with NewEnvironmentOperations.
+var defaultRepoManager = &repoManager{}
+
+// NewGitRepoManager returns a GitRepoManager. The operations it returns for the clones of one repository push one at a
+// time, while the operations that only read keep running concurrently in the clones of the other environments.
+func NewGitRepoManager() GitRepoManager {
+	return &repoManager{}
+}
+
+// EnvironmentOperations implements GitRepoManager.
+func (m *repoManager) EnvironmentOperations(gitRepo *v1alpha1.GitRepository, gap scms.GitOperationsProvider, activeBranch string, cloneDepth int) *EnvironmentOperations {
+	return &EnvironmentOperations{
+		gap:          gap,
+		gitRepo:      gitRepo,
+		activeBranch: activeBranch,
+		cloneDepth:   cloneDepth,
+		manager:      m,
+	}
+}
+
+// lockRepository acquires the push lock of the repository with the given store key and returns a function that
+// releases it. It must be acquired before the lock of a clone.
+func (m *repoManager) lockRepository(storeKey string) func() {
+	mu, _ := m.pushLocks.LoadOrStore(storeKey, &sync.Mutex{})
+	//nolint:forcetypeassert // sync.Map stores *sync.Mutex values, type is guaranteed
+	l := mu.(*sync.Mutex)
+	l.Lock()
+	return l.Unlock
+}
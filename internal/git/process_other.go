@@ -0,0 +1,8 @@
+//go:build !unix
+
+package git
+
+import "os/exec"
+
+// setProcessGroup does nothing on platforms without process groups, cancellation only kills the git process itself.
+func setProcessGroup(_ *exec.Cmd) {}
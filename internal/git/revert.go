@@ -0,0 +1,415 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils/gitpaths"
+)
+
+// RevertConflictError indicates that a commit could not be reverted because the revert conflicts with changes made
+// after it.
+type RevertConflictError struct {
+	// Sha is the commit that was reverted.
+	Sha string
+	// Branch is the branch the commit was reverted on.
+	Branch string
+	// Files are the files with conflicts.
+	Files []string
+}
+
+// Error implements the error interface for RevertConflictError.
+func (e *RevertConflictError) Error() string {
+	return fmt.Sprintf("reverting %q on branch %q conflicts in %s", e.Sha, e.Branch, strings.Join(e.Files, ", "))
+}
+
+// CommitNotOnBranchError indicates that a commit to revert is not in the history of the branch it should be reverted on.
+type CommitNotOnBranchError struct {
+	// Sha is the commit that was to be reverted.
+	Sha string
+	// Branch is the branch the commit was to be reverted on.
+	Branch string
+}
+
+// Error implements the error interface for CommitNotOnBranchError.
+func (e *CommitNotOnBranchError) Error() string {
+	return fmt.Sprintf("commit %q is not on branch %q", e.Sha, e.Branch)
+}
+
+// SupersededTargetError indicates that newer commits on a branch left different contents in paths that a commit to
+// revert changes.
+type SupersededTargetError struct {
+	// Sha is the commit that was to be reverted.
+	Sha string
+	// Branch is the branch the commit was to be reverted on.
+	Branch string
+	// Commits is the number of commits on the branch after Sha.
+	Commits int
+	// Paths are the paths changed by Sha whose contents the newer commits changed.
+	Paths []string
+}
+
+// Error implements the error interface for SupersededTargetError.
+func (e *SupersededTargetError) Error() string {
+	return fmt.Sprintf("commit %q is %d commits behind the tip of branch %q and newer commits change the same paths: %s", e.Sha, e.Commits, e.Branch, strings.Join(e.Paths, ", "))
+}
+
+// CheckSuperseded returns a SupersededTargetError if more than maxDistance commits were added to branch after sha and
+// the tip of branch has different contents than sha in any path that sha changes. A merge commit is compared to its
+// first parent. The newer commits are safe to revert past if they changed the paths back, or only changed
+// hydrator.metadata files, which the hydrator rewrites in every commit. It returns nil if sha is not on branch, which
+// Revert reports.
+func (g *EnvironmentOperations) CheckSuperseded(ctx context.Context, sha, branch string, maxDistance int) error {
+	defer g.lock()()
+
+	logger := log.FromContext(ctx)
+	gitPath := gitpaths.Get(g.cloneKey())
+	if gitPath == "" {
+		return fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
+	}
+
+	start := time.Now()
+	_, stderr, err := g.runCmd(ctx, gitPath, "fetch", "--force", "origin", "+refs/heads/"+branch+":refs/remotes/origin/"+branch)
+	recordGitOperation(g.gitRepo, metrics.GitOperationFetch, err, time.Since(start))
+	if err != nil {
+		logger.Error(err, "could not fetch branch", "branch", branch, "gitError", stderr)
+		return fmt.Errorf("failed to fetch branch %q: %w", branch, err)
+	}
+
+	onBranch, err := g.isAncestor(ctx, sha, "origin/"+branch)
+	if err != nil {
+		return err
+	}
+	if !onBranch {
+		return nil
+	}
+
+	stdout, stderr, err := g.runCmd(ctx, gitPath, "rev-list", "--count", sha+"..origin/"+branch)
+	if err != nil {
+		logger.Error(err, "could not count newer commits", "sha", sha, "branch", branch, "gitError", stderr)
+		return fmt.Errorf("failed to count the commits after %q on branch %q: %w", sha, branch, err)
+	}
+	commits, err := strconv.Atoi(strings.TrimSpace(stdout))
+	if err != nil {
+		return fmt.Errorf("unexpected output from rev-list: %q", stdout)
+	}
+	if commits <= maxDistance {
+		return nil
+	}
+
+	stdout, stderr, err = g.runCmd(ctx, gitPath, "diff-tree", "-r", "--root", "--no-commit-id", "--name-only", "-m", "--first-parent", sha,
+		"--", ".", ":(exclude,glob)**/hydrator.metadata")
+	if err != nil {
+		logger.Error(err, "could not get paths changed by commit", "sha", sha, "gitError", stderr)
+		return fmt.Errorf("failed to get the paths changed by commit %q: %w", sha, err)
+	}
+	changed := map[string]bool{}
+	for _, path := range strings.Split(stdout, "\n") {
+		if path != "" {
+			changed[path] = true
+		}
+	}
+
+	// Comparing the trees of sha and the tip ignores the paths the newer commits changed back.
+	stdout, stderr, err = g.runCmd(ctx, gitPath, "diff-tree", "-r", "--name-only", sha, "origin/"+branch)
+	if err != nil {
+		logger.Error(err, "could not get paths changed by newer commits", "sha", sha, "branch", branch, "gitError", stderr)
+		return fmt.Errorf("failed to get the paths changed after %q on branch %q: %w", sha, branch, err)
+	}
+	var overlapping []string
+	for _, path := range strings.Split(stdout, "\n") {
+		if changed[path] {
+			overlapping = append(overlapping, path)
+			delete(changed, path)
+		}
+	}
+	if len(overlapping) == 0 {
+		return nil
+	}
+	slices.Sort(overlapping)
+	return &SupersededTargetError{Sha: sha, Branch: branch, Commits: commits, Paths: overlapping}
+}
+
+// RevertMessageFunc returns the message of the commit that reverts a commit, given the metadata of the reverted commit.
+type RevertMessageFunc func(reverted v1alpha1.CommitShaState) (string, error)
+
+// Revert creates a commit on top of baseBranch that reverts sha and force-pushes it to revertBranch, which is owned by
+// the caller. A merge commit is reverted relative to its first parent, which is the branch it was merged into. It
+// returns the sha of the revert commit, a CommitNotOnBranchError if sha is not on baseBranch, or a RevertConflictError if
+// the revert can't be done without resolving conflicts. The clone is left without a revert in progress either way.
+// If message is set, its result replaces git's default message of the revert commit. The trailers, if any, are added
+// to the message.
+func (g *EnvironmentOperations) Revert(ctx context.Context, sha, baseBranch, revertBranch string, message RevertMessageFunc, trailers map[string]string) (string, error) {
+	defer g.lockForPush()()
+
+	logger := log.FromContext(ctx)
+	gitPath := gitpaths.Get(g.cloneKey())
+	if gitPath == "" {
+		return "", fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
+	}
+
+	start := time.Now()
+	_, stderr, err := g.runCmd(ctx, gitPath, "fetch", "--force", "origin", "+refs/heads/"+baseBranch+":refs/remotes/origin/"+baseBranch)
+	recordGitOperation(g.gitRepo, metrics.GitOperationFetch, err, time.Since(start))
+	if err != nil {
+		logger.Error(err, "could not fetch branch", "branch", baseBranch, "gitError", stderr)
+		return "", fmt.Errorf("failed to fetch branch %q: %w", baseBranch, err)
+	}
+
+	onBranch, err := g.isAncestor(ctx, sha, "origin/"+baseBranch)
+	if err != nil {
+		return "", err
+	}
+	if !onBranch {
+		return "", &CommitNotOnBranchError{Sha: sha, Branch: baseBranch}
+	}
+
+	// rev-list --parents prints the commit followed by its parents.
+	stdout, stderr, err := g.runCmd(ctx, gitPath, "rev-list", "--parents", "-n", "1", sha)
+	if err != nil {
+		logger.Error(err, "could not get parents of commit", "sha", sha, "gitError", stderr)
+		return "", fmt.Errorf("failed to get parents of commit %q: %w", sha, err)
+	}
+	revertArgs := []string{"revert", "--no-edit"}
+	if len(strings.Fields(stdout)) > 2 {
+		revertArgs = append(revertArgs, "-m", "1")
+	}
+	revertArgs = append(revertArgs, sha)
+
+	var revertMessage string
+	if message != nil {
+		// %x00 separates the fields, the body may span several lines.
+		stdout, stderr, err = g.runCmd(ctx, gitPath, "show", "-s", "--format=%an%x00%s%x00%b", sha)
+		if err != nil {
+			logger.Error(err, "could not get commit metadata", "sha", sha, "gitError", stderr)
+			return "", fmt.Errorf("failed to get metadata of commit %q: %w", sha, err)
+		}
+		fields := strings.SplitN(stdout, "\x00", 3)
+		if len(fields) != 3 {
+			return "", fmt.Errorf("unexpected output from git show: %q", stdout)
+		}
+		revertMessage, err = message(v1alpha1.CommitShaState{
+			Sha:     sha,
+			Author:  fields[0],
+			Subject: fields[1],
+			Body:    strings.TrimSpace(fields[2]),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to build the message of the revert commit: %w", err)
+		}
+	}
+
+	// --force discards anything left in the work tree by an earlier failed revert.
+	_, stderr, err = g.runCmd(ctx, gitPath, "checkout", "--force", "--ignore-other-worktrees", "-B", revertBranch, "origin/"+baseBranch)
+	if err != nil {
+		logger.Error(err, "Failed to checkout branch", "branch", revertBranch, "stderr", stderr)
+		return "", fmt.Errorf("failed to checkout branch %q: %w", revertBranch, err)
+	}
+
+	signingArgs, signingEnv, cleanupSigning, err := g.signingConfig(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare commit signing: %w", err)
+	}
+	defer cleanupSigning()
+	_, stderr, err = g.runCmdWithEnv(ctx, gitPath, signingEnv, slices.Concat(g.identityArgs(), signingArgs, revertArgs)...)
+	if err != nil {
+		conflicts, _, diffErr := g.runCmd(ctx, gitPath, "diff", "--name-only", "--diff-filter=U")
+		if _, abortStderr, abortErr := g.runCmd(ctx, gitPath, "revert", "--abort"); abortErr != nil {
+			logger.V(4).Info("could not abort revert", "gitError", abortStderr)
+		}
+		if diffErr == nil && strings.TrimSpace(conflicts) != "" {
+			return "", &RevertConflictError{Sha: sha, Branch: baseBranch, Files: strings.Fields(conflicts)}
+		}
+		logger.Error(err, "could not revert commit", "sha", sha, "branch", baseBranch, "gitError", stderr)
+		return "", fmt.Errorf("failed to revert commit %q on branch %q: %w", sha, baseBranch, err)
+	}
+
+	if revertMessage != "" || len(trailers) > 0 {
+		if revertMessage == "" {
+			revertMessage, stderr, err = g.runCmd(ctx, gitPath, "log", "-1", "--format=%B")
+			if err != nil {
+				logger.Error(err, "could not get revert commit message", "gitError", stderr)
+				return "", fmt.Errorf("failed to get the message of the revert commit: %w", err)
+			}
+		}
+		// interpret-trailers only starts a new paragraph for the trailers after a message that ends with a newline.
+		revertMessage = strings.TrimRight(revertMessage, "\n") + "\n"
+		for _, key := range slices.Sorted(maps.Keys(trailers)) {
+			revertMessage, err = AddTrailerToCommitMessage(ctx, revertMessage, key, trailers[key])
+			if err != nil {
+				return "", fmt.Errorf("failed to add trailer %q to the revert commit: %w", key, err)
+			}
+		}
+		_, stderr, err = g.runCmdWithEnv(ctx, gitPath, signingEnv, slices.Concat(g.identityArgs(), signingArgs, []string{"commit", "--amend", "-m", revertMessage})...)
+		if err != nil {
+			logger.Error(err, "could not amend the message of the revert commit", "gitError", stderr)
+			return "", fmt.Errorf("failed to amend the message of the revert commit: %w", err)
+		}
+	}
+
+	start = time.Now()
+	_, stderr, err = g.runCmd(ctx, gitPath, "push", "--force", "origin", "HEAD:refs/heads/"+revertBranch)
+	recordGitOperation(g.gitRepo, metrics.GitOperationPush, err, time.Since(start))
+	if err != nil {
+		logger.Error(err, "Failed to push revert branch", "branch", revertBranch, "stderr", stderr)
+		if isSignatureRejected(stderr) {
+			err = &CommitSignatureRejectedError{Branch: revertBranch, Err: err}
+		}
+		return "", fmt.Errorf("failed to push revert branch %q: %w", revertBranch, err)
+	}
+
+	stdout, stderr, err = g.runCmd(ctx, gitPath, "rev-parse", "HEAD")
+	if err != nil {
+		logger.Error(err, "could not get revert commit", "gitError", stderr)
+		return "", fmt.Errorf("failed to get the sha of the revert commit: %w", err)
+	}
+	revertSha := strings.TrimSpace(stdout)
+
+	logger.Info("Reverted commit", "sha", sha, "branch", baseBranch, "revertBranch", revertBranch, "revertSha", revertSha)
+	return revertSha, nil
+}
+
+// HydratedStateBefore returns the commit on the first-parent history of branch that the branch ran before drySha was
+// hydrated to it: the newest commit older than the last commit hydrated from drySha that was hydrated from a different
+// dry commit. It returns an empty sha if drySha was never hydrated to branch. Commits without a readable
+// hydrator.metadata file are skipped.
+func (g *EnvironmentOperations) HydratedStateBefore(ctx context.Context, branch, drySha string) (string, error) {
+	defer g.lock()()
+
+	logger := log.FromContext(ctx)
+	gitPath := gitpaths.Get(g.cloneKey())
+	if gitPath == "" {
+		return "", fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
+	}
+
+	start := time.Now()
+	_, stderr, err := g.runCmd(ctx, gitPath, "fetch", "--force", "origin", "+refs/heads/"+branch+":refs/remotes/origin/"+branch)
+	recordGitOperation(g.gitRepo, metrics.GitOperationFetch, err, time.Since(start))
+	if err != nil {
+		logger.Error(err, "could not fetch branch", "branch", branch, "gitError", stderr)
+		return "", fmt.Errorf("failed to fetch branch %q: %w", branch, err)
+	}
+	if g.isShallow(gitPath) {
+		// The state before drySha may be older than the shallow boundary.
+		if err := g.unshallow(ctx, gitPath); err != nil {
+			return "", err
+		}
+	}
+
+	// Only the commits that changed hydrator.metadata can start or end the hydrated state of a dry commit.
+	stdout, stderr, err := g.runCmd(ctx, gitPath, "log", "--first-parent", "--format=%H", "origin/"+branch, "--", "hydrator.metadata")
+	if err != nil {
+		logger.Error(err, "could not get history of branch", "branch", branch, "gitError", stderr)
+		return "", fmt.Errorf("failed to get history of branch %q: %w", branch, err)
+	}
+
+	// oldest is the oldest commit that changed hydrator.metadata since the newest one hydrated from drySha.
+	oldest := ""
+	for _, sha := range strings.Fields(stdout) {
+		contents, _, err := g.runCmd(ctx, gitPath, "show", sha+":hydrator.metadata")
+		if err != nil {
+			if oldest != "" {
+				oldest = sha
+			}
+			continue
+		}
+		metadata, err := parseHydratorMetadata(contents)
+		if err != nil {
+			logger.V(4).Info("skipping commit with invalid hydrator.metadata", "sha", sha, "err", err)
+			if oldest != "" {
+				oldest = sha
+			}
+			continue
+		}
+		if metadata.DrySha == drySha {
+			oldest = sha
+			continue
+		}
+		if oldest != "" {
+			// The branch ran the hydrated state of this commit's dry commit until the parent of oldest, there is no
+			// need to walk further back.
+			stdout, stderr, err = g.runCmd(ctx, gitPath, "rev-parse", oldest+"^")
+			if err != nil {
+				logger.Error(err, "could not get parent of commit", "sha", oldest, "gitError", stderr)
+				return "", fmt.Errorf("failed to get the parent of commit %q: %w", oldest, err)
+			}
+			return strings.TrimSpace(stdout), nil
+		}
+	}
+	if oldest != "" {
+		return "", fmt.Errorf("branch %q has no hydrated state from before dry commit %q", branch, drySha)
+	}
+	return "", nil
+}
+
+// RestoreTree creates a commit on top of branch with the same tree as sha and pushes it to branch. The push fails
+// rather than overwriting commits pushed to branch in the meantime. Nothing is committed if the tip of branch already
+// has the tree of sha. It returns the tip of branch afterward.
+func (g *EnvironmentOperations) RestoreTree(ctx context.Context, branch, sha, message string) (string, error) {
+	defer g.lockForPush()()
+
+	logger := log.FromContext(ctx)
+	gitPath := gitpaths.Get(g.cloneKey())
+	if gitPath == "" {
+		return "", fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
+	}
+
+	start := time.Now()
+	_, stderr, err := g.runCmd(ctx, gitPath, "fetch", "--force", "origin", "+refs/heads/"+branch+":refs/remotes/origin/"+branch)
+	recordGitOperation(g.gitRepo, metrics.GitOperationFetch, err, time.Since(start))
+	if err != nil {
+		logger.Error(err, "could not fetch branch", "branch", branch, "gitError", stderr)
+		return "", fmt.Errorf("failed to fetch branch %q: %w", branch, err)
+	}
+
+	stdout, stderr, err := g.runCmd(ctx, gitPath, "rev-parse", "origin/"+branch, "origin/"+branch+"^{tree}", sha+"^{tree}")
+	if err != nil {
+		logger.Error(err, "could not get trees", "branch", branch, "sha", sha, "gitError", stderr)
+		return "", fmt.Errorf("failed to get the trees of branch %q and commit %q: %w", branch, sha, err)
+	}
+	// rev-parse prints one line per argument.
+	revs := strings.Fields(stdout)
+	if len(revs) != 3 {
+		return "", fmt.Errorf("unexpected output from rev-parse: %q", stdout)
+	}
+	parent, parentTree, tree := revs[0], revs[1], revs[2]
+	if parentTree == tree {
+		logger.V(4).Info("Branch already has the tree of the commit", "branch", branch, "sha", sha)
+		return parent, nil
+	}
+
+	signingArgs, signingEnv, cleanupSigning, err := g.signingConfig(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare commit signing: %w", err)
+	}
+	defer cleanupSigning()
+	stdout, stderr, err = g.runCmdWithEnv(ctx, gitPath, signingEnv, slices.Concat(g.identityArgs(), signingArgs, []string{"commit-tree", tree, "-p", parent, "-m", message})...)
+	if err != nil {
+		logger.Error(err, "could not create commit", "branch", branch, "sha", sha, "gitError", stderr)
+		return "", fmt.Errorf("failed to create a commit with the tree of %q on branch %q: %w", sha, branch, err)
+	}
+	restoredSha := strings.TrimSpace(stdout)
+
+	start = time.Now()
+	_, stderr, err = g.runCmd(ctx, gitPath, "push", "origin", restoredSha+":refs/heads/"+branch)
+	recordGitOperation(g.gitRepo, metrics.GitOperationPush, err, time.Since(start))
+	if err != nil {
+		logger.Error(err, "Failed to push branch", "branch", branch, "stderr", stderr)
+		if isSignatureRejected(stderr) {
+			err = &CommitSignatureRejectedError{Branch: branch, Err: err}
+		}
+		return "", fmt.Errorf("failed to push branch %q: %w", branch, err)
+	}
+
+	logger.Info("Restored tree of commit", "sha", sha, "branch", branch, "restoredSha", restoredSha)
+	return restoredSha, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 
 	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms"
@@ -20,7 +21,8 @@ import (
 )
 
 // CreateGitOperationsProvider creates the appropriate git operations provider based on the SCM provider type.
-// This is a shared utility function used by multiple controllers to avoid code duplication.
+// This is a shared utility function used by multiple controllers to avoid code duplication. If the secret contains an
+// SSH private key, git operations authenticate over SSH instead, see NewSSHGitOperationsProvider.
 //
 // Parameters:
 //   - ctx: Context for logging and cancellation
@@ -36,6 +38,105 @@ func CreateGitOperationsProvider(
 	scmProvider v1alpha1.GenericScmProvider,
 	secret *corev1.Secret,
 	repoObjectKey client.ObjectKey,
+) (scms.GitOperationsProvider, error) {
+	httpsProvider, err := createGitOperationsProvider(ctx, k8sClient, scmProvider, secret, repoObjectKey)
+	if err != nil {
+		return nil, err
+	}
+	provider := NewUrlGitOperationsProvider(httpsProvider)
+	if !HasSSHCredentials(secret) {
+		return provider, nil
+	}
+
+	if scmProvider.GetSpec().AzureDevOps != nil {
+		return nil, errors.New("SSH authentication is not supported for Azure DevOps")
+	}
+	log.FromContext(ctx).V(4).Info("Using SSH for git operations", "scmProvider", scmProvider.GetName())
+	sshProvider, err := NewSSHGitOperationsProvider(provider, secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SSH Auth Provider: %w", err)
+	}
+	return sshProvider, nil
+}
+
+// ValidateCredentials checks that the secret has the credentials the SCM provider needs, without contacting the SCM. A
+// Fake provider needs no credentials. SSH credentials in the secret are checked as well.
+func ValidateCredentials(scmProvider v1alpha1.GenericScmProvider, secret *corev1.Secret) error {
+	spec := scmProvider.GetSpec()
+	if spec.Fake != nil {
+		return nil
+	}
+	if secret == nil {
+		return errors.New("the SCM provider has no secretRef")
+	}
+
+	switch {
+	case spec.GitHub != nil:
+		if err := github.ValidateSecret(scmProvider, secret); err != nil {
+			return fmt.Errorf("failed to validate GitHub credentials: %w", err)
+		}
+	case spec.Forgejo != nil, spec.Gitea != nil:
+		if len(secret.Data["token"]) == 0 && (len(secret.Data["username"]) == 0 || len(secret.Data["password"]) == 0) {
+			return fmt.Errorf("secret %q must set %q or %q and %q", secret.Name, "token", "username", "password")
+		}
+	case spec.GitLab != nil, spec.BitbucketCloud != nil, spec.AzureDevOps != nil:
+		if len(secret.Data["token"]) == 0 {
+			return fmt.Errorf("secret %q is missing required data key %q", secret.Name, "token")
+		}
+	default:
+		return scms.NewUnsupportedScmProviderError(scmProvider)
+	}
+
+	if !HasSSHCredentials(secret) {
+		return nil
+	}
+	if spec.AzureDevOps != nil {
+		return errors.New("SSH authentication is not supported for Azure DevOps")
+	}
+	if _, err := NewSSHGitOperationsProvider(nil, secret); err != nil {
+		return fmt.Errorf("failed to validate SSH credentials: %w", err)
+	}
+	return nil
+}
+
+// UrlGitOperationsProvider wraps a git operations provider to use the GitRepository's spec.url, when it is set, or the
+// clone URL the SCM reported in its status, instead of the URL the wrapped provider builds from the repository's owner
+// and name.
+type UrlGitOperationsProvider struct {
+	scms.GitOperationsProvider
+}
+
+var _ scms.GitOperationsProvider = &UrlGitOperationsProvider{}
+
+// NewUrlGitOperationsProvider creates a new UrlGitOperationsProvider that authenticates with the wrapped provider.
+func NewUrlGitOperationsProvider(provider scms.GitOperationsProvider) *UrlGitOperationsProvider {
+	return &UrlGitOperationsProvider{GitOperationsProvider: provider}
+}
+
+// GetGitHttpsRepoUrl returns the GitRepository's spec.url if it is set, and otherwise the HTTPS clone URL in its status.
+// The wrapped provider's URL is only used until the GitRepository controller has looked the repository up for the
+// current spec.
+func (u *UrlGitOperationsProvider) GetGitHttpsRepoUrl(gitRepo v1alpha1.GitRepository) string {
+	if gitRepo.Spec.Url != "" {
+		return gitRepo.Spec.Url
+	}
+	if httpsURL, _ := scms.ReportedCloneUrls(gitRepo); httpsURL != "" {
+		// The credentials are passed to git separately, like for the URLs the providers build.
+		if parsed, err := url.Parse(httpsURL); err == nil {
+			parsed.User = nil
+			return parsed.String()
+		}
+	}
+	return u.GitOperationsProvider.GetGitHttpsRepoUrl(gitRepo)
+}
+
+// createGitOperationsProvider creates the HTTPS git operations provider for the SCM provider type.
+func createGitOperationsProvider(
+	ctx context.Context,
+	k8sClient client.Client,
+	scmProvider v1alpha1.GenericScmProvider,
+	secret *corev1.Secret,
+	repoObjectKey client.ObjectKey,
 ) (scms.GitOperationsProvider, error) {
 	logger := log.FromContext(ctx)
 
@@ -88,6 +189,6 @@ func CreateGitOperationsProvider(
 		logger.V(4).Info("Creating Azure DevOps git authentication provider")
 		return azuredevops.NewAzdoGitAuthenticationProvider(scmProvider, secret), nil
 	default:
-		return nil, errors.New("no supported git authentication provider found")
+		return nil, scms.NewUnsupportedScmProviderError(scmProvider)
 	}
 }
@@ -0,0 +1,193 @@
+package gitauth
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+const (
+	// SSHPrivateKeySecretKey is the key in the ScmProvider secret that contains the private key used for git operations
+	// over SSH. When it is set, git operations use SSH instead of HTTPS, API calls keep using the provider's usual auth.
+	SSHPrivateKeySecretKey = "sshPrivateKey"
+	// SSHPrivateKeyPassphraseSecretKey is the key in the ScmProvider secret that contains the passphrase of an
+	// encrypted private key.
+	SSHPrivateKeyPassphraseSecretKey = "sshPrivateKeyPassphrase"
+	// SSHKnownHostsSecretKey is the key in the ScmProvider secret that contains the known_hosts entries used to verify
+	// the git server's host key.
+	SSHKnownHostsSecretKey = "sshKnownHosts"
+	// SSHInsecureIgnoreHostKeySecretKey is the key in the ScmProvider secret that disables host key verification when
+	// set to "true". It should only be used for testing.
+	SSHInsecureIgnoreHostKeySecretKey = "insecureIgnoreHostKey"
+)
+
+// SSHGitOperationsProvider wraps a GitOperationsProvider so that git operations authenticate over SSH with a private
+// key from the ScmProvider secret. Repository URLs are derived from the wrapped provider's HTTPS URLs.
+type SSHGitOperationsProvider struct {
+	provider              scms.GitOperationsProvider
+	privateKey            []byte
+	knownHosts            []byte
+	insecureIgnoreHostKey bool
+}
+
+var (
+	_ scms.GitOperationsProvider = &SSHGitOperationsProvider{}
+	_ scms.GitSSHCommandProvider = &SSHGitOperationsProvider{}
+)
+
+// HasSSHCredentials returns true if the secret contains an SSH private key for git operations.
+func HasSSHCredentials(secret *corev1.Secret) bool {
+	return secret != nil && len(secret.Data[SSHPrivateKeySecretKey]) > 0
+}
+
+// NewSSHGitOperationsProvider creates an SSHGitOperationsProvider from the SSH credentials in the secret. An encrypted
+// private key is decrypted here so that ssh never needs to prompt for the passphrase. The secret must either contain
+// known_hosts entries or explicitly opt out of host key verification.
+func NewSSHGitOperationsProvider(provider scms.GitOperationsProvider, secret *corev1.Secret) (*SSHGitOperationsProvider, error) {
+	rawKey := secret.Data[SSHPrivateKeySecretKey]
+	if len(rawKey) == 0 {
+		return nil, fmt.Errorf("secret %q is missing required data key %q", secret.Name, SSHPrivateKeySecretKey)
+	}
+
+	var key any
+	var err error
+	if passphrase := secret.Data[SSHPrivateKeyPassphraseSecretKey]; len(passphrase) > 0 {
+		key, err = ssh.ParseRawPrivateKeyWithPassphrase(rawKey, passphrase)
+	} else {
+		key, err = ssh.ParseRawPrivateKey(rawKey)
+	}
+	if err != nil {
+		var missingPassphraseErr *ssh.PassphraseMissingError
+		if errors.As(err, &missingPassphraseErr) {
+			return nil, fmt.Errorf("private key in secret %q is encrypted but %q is not set", secret.Name, SSHPrivateKeyPassphraseSecretKey)
+		}
+		return nil, fmt.Errorf("failed to parse private key in secret %q: %w", secret.Name, err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private key in secret %q: %w", secret.Name, err)
+	}
+
+	insecureIgnoreHostKey := strings.EqualFold(strings.TrimSpace(string(secret.Data[SSHInsecureIgnoreHostKeySecretKey])), "true")
+	knownHosts := secret.Data[SSHKnownHostsSecretKey]
+	if len(knownHosts) == 0 && !insecureIgnoreHostKey {
+		return nil, fmt.Errorf("secret %q must set %q or %q to use SSH", secret.Name, SSHKnownHostsSecretKey, SSHInsecureIgnoreHostKeySecretKey)
+	}
+
+	return &SSHGitOperationsProvider{
+		provider:              provider,
+		privateKey:            pem.EncodeToMemory(block),
+		knownHosts:            knownHosts,
+		insecureIgnoreHostKey: insecureIgnoreHostKey,
+	}, nil
+}
+
+// GetGitHttpsRepoUrl returns the SSH URL of the repository: the SSH clone URL in the GitRepository's status if there is
+// no spec.url, and otherwise one built from the wrapped provider's HTTPS URL by switching to the ssh scheme with the git
+// user, keeping the host, port and path.
+func (s *SSHGitOperationsProvider) GetGitHttpsRepoUrl(gitRepo v1alpha1.GitRepository) string {
+	if _, sshURL := scms.ReportedCloneUrls(gitRepo); gitRepo.Spec.Url == "" && sshURL != "" {
+		return sshURL
+	}
+	return sshURLFromHTTPS(s.provider.GetGitHttpsRepoUrl(gitRepo))
+}
+
+// CloneUrls returns the HTTPS and SSH URLs to clone the repository with the provider, without any credentials. They are
+// built from the spec, the ones in the status are ignored. The SSH URL is derived from the HTTPS URL as for SSH
+// authentication, so it may not match the SCM's own SSH URL, e.g. if the SCM serves SSH on a different host.
+func CloneUrls(provider scms.GitOperationsProvider, gitRepo v1alpha1.GitRepository) (httpsURL string, sshURL string) {
+	if sshProvider, ok := provider.(*SSHGitOperationsProvider); ok {
+		provider = sshProvider.provider
+	}
+	gitRepo.Status.HttpsCloneUrl, gitRepo.Status.SshCloneUrl = "", ""
+	u, err := url.Parse(provider.GetGitHttpsRepoUrl(gitRepo))
+	if err != nil || u.Host == "" {
+		return "", ""
+	}
+	if u.Scheme == "ssh" {
+		// Only a GitRepository's spec.url can be an SSH URL, there is no HTTPS URL to go with it.
+		return "", u.String()
+	}
+	u.User = nil
+	return u.String(), sshURLFromHTTPS(u.String())
+}
+
+// sshURLFromHTTPS builds the SSH URL of a repository from its HTTPS URL by switching to the ssh scheme with the git
+// user, keeping the host, port and path. SCMs that serve SSH on another port than HTTPS need a GitRepository spec.url
+// with the SSH URL. SSH URLs, which a GitRepository's spec.url can be, are returned as they are.
+func sshURLFromHTTPS(httpsURL string) string {
+	u, err := url.Parse(httpsURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	if u.Scheme == "ssh" {
+		return httpsURL
+	}
+	sshURL := url.URL{
+		Scheme: "ssh",
+		User:   url.User("git"),
+		Host:   u.Host,
+		Path:   u.Path,
+	}
+	return sshURL.String()
+}
+
+// GetToken returns an empty token, SSH authentication does not use one.
+func (s *SSHGitOperationsProvider) GetToken(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+// GetUser returns the git user, SSH authentication uses the user in the repository URL.
+func (s *SSHGitOperationsProvider) GetUser(ctx context.Context) (string, error) {
+	return "git", nil
+}
+
+// GetGitSSHCommand writes the private key and known_hosts to a new private temporary directory and returns the ssh
+// command that uses them. The returned cleanup function removes the directory.
+func (s *SSHGitOperationsProvider) GetGitSSHCommand(ctx context.Context) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "promoter-ssh-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create directory for ssh credentials: %w", err)
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(dir)
+	}
+
+	identityFile := filepath.Join(dir, "identity")
+	// ssh refuses to use a private key that other users can read.
+	if err := os.WriteFile(identityFile, s.privateKey, 0o600); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write ssh identity file: %w", err)
+	}
+
+	args := []string{"ssh", "-i", shellQuote(identityFile), "-o", "IdentitiesOnly=yes", "-o", "BatchMode=yes"}
+	if s.insecureIgnoreHostKey {
+		args = append(args, "-o", "StrictHostKeyChecking=no", "-o", "UserKnownHostsFile=/dev/null")
+	} else {
+		knownHostsFile := filepath.Join(dir, "known_hosts")
+		if err := os.WriteFile(knownHostsFile, s.knownHosts, 0o600); err != nil {
+			cleanup()
+			return "", nil, fmt.Errorf("failed to write ssh known_hosts file: %w", err)
+		}
+		args = append(args, "-o", "StrictHostKeyChecking=yes", "-o", "UserKnownHostsFile="+shellQuote(knownHostsFile))
+	}
+
+	return strings.Join(args, " "), cleanup, nil
+}
+
+// shellQuote quotes s for use in GIT_SSH_COMMAND, which git runs through the shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
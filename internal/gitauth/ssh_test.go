@@ -0,0 +1,148 @@
+package gitauth_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/gitauth"
+)
+
+type httpsProvider struct {
+	url string
+}
+
+func (p httpsProvider) GetGitHttpsRepoUrl(v1alpha1.GitRepository) string { return p.url }
+func (p httpsProvider) GetToken(context.Context) (string, error)         { return "token", nil }
+func (p httpsProvider) GetUser(context.Context) (string, error)          { return "user", nil }
+
+var _ = Describe("SSHGitOperationsProvider", func() {
+	var privateKey ed25519.PrivateKey
+
+	BeforeEach(func() {
+		var err error
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	secretWith := func(data map[string]string) *corev1.Secret {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "scm-secret"}, Data: map[string][]byte{}}
+		for k, v := range data {
+			secret.Data[k] = []byte(v)
+		}
+		return secret
+	}
+
+	marshalKey := func(passphrase string) string {
+		var block *pem.Block
+		var err error
+		if passphrase == "" {
+			block, err = ssh.MarshalPrivateKey(privateKey, "")
+		} else {
+			block, err = ssh.MarshalPrivateKeyWithPassphrase(privateKey, "", []byte(passphrase))
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return string(pem.EncodeToMemory(block))
+	}
+
+	It("should only use SSH when the secret has a private key", func() {
+		Expect(gitauth.HasSSHCredentials(secretWith(map[string]string{"token": "abc"}))).To(BeFalse())
+		Expect(gitauth.HasSSHCredentials(secretWith(map[string]string{gitauth.SSHPrivateKeySecretKey: marshalKey("")}))).To(BeTrue())
+	})
+
+	It("should use the SSH URL the SCM reported, or build one from the provider's HTTPS URL", func() {
+		p, err := gitauth.NewSSHGitOperationsProvider(httpsProvider{url: "https://git.example.com:8443/owner/repo.git"}, secretWith(map[string]string{
+			gitauth.SSHPrivateKeySecretKey:            marshalKey(""),
+			gitauth.SSHInsecureIgnoreHostKeySecretKey: "true",
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p.GetGitHttpsRepoUrl(v1alpha1.GitRepository{})).To(Equal("ssh://git@git.example.com:8443/owner/repo.git"))
+
+		reported := v1alpha1.GitRepository{Status: v1alpha1.GitRepositoryStatus{SshCloneUrl: "git@ssh.example.com:owner/repo.git"}}
+		Expect(p.GetGitHttpsRepoUrl(reported)).To(Equal("git@ssh.example.com:owner/repo.git"))
+
+		token, err := p.GetToken(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(BeEmpty())
+	})
+
+	It("should write the identity and known_hosts with private permissions and remove them on cleanup", func() {
+		p, err := gitauth.NewSSHGitOperationsProvider(httpsProvider{url: "https://git.example.com/owner/repo.git"}, secretWith(map[string]string{
+			gitauth.SSHPrivateKeySecretKey: marshalKey(""),
+			gitauth.SSHKnownHostsSecretKey: "git.example.com ssh-ed25519 AAAA",
+		}))
+		Expect(err).NotTo(HaveOccurred())
+
+		command, cleanup, err := p.GetGitSSHCommand(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(command).To(ContainSubstring("StrictHostKeyChecking=yes"))
+		Expect(command).To(ContainSubstring("IdentitiesOnly=yes"))
+
+		identityFile := strings.Trim(strings.Fields(command)[2], "'")
+		info, err := os.Stat(identityFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+		key, err := os.ReadFile(identityFile)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ssh.ParsePrivateKey(key)
+		Expect(err).NotTo(HaveOccurred())
+
+		knownHosts, err := os.ReadFile(filepath.Join(filepath.Dir(identityFile), "known_hosts"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(knownHosts)).To(Equal("git.example.com ssh-ed25519 AAAA"))
+
+		cleanup()
+		Expect(filepath.Dir(identityFile)).NotTo(BeADirectory())
+	})
+
+	It("should skip host key verification only when explicitly requested", func() {
+		_, err := gitauth.NewSSHGitOperationsProvider(httpsProvider{}, secretWith(map[string]string{
+			gitauth.SSHPrivateKeySecretKey: marshalKey(""),
+		}))
+		Expect(err).To(MatchError(ContainSubstring(gitauth.SSHKnownHostsSecretKey)))
+
+		p, err := gitauth.NewSSHGitOperationsProvider(httpsProvider{}, secretWith(map[string]string{
+			gitauth.SSHPrivateKeySecretKey:            marshalKey(""),
+			gitauth.SSHInsecureIgnoreHostKeySecretKey: "true",
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		command, cleanup, err := p.GetGitSSHCommand(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		defer cleanup()
+		Expect(command).To(ContainSubstring("StrictHostKeyChecking=no"))
+	})
+
+	It("should decrypt a passphrase protected key", func() {
+		_, err := gitauth.NewSSHGitOperationsProvider(httpsProvider{}, secretWith(map[string]string{
+			gitauth.SSHPrivateKeySecretKey:            marshalKey("s3cret"),
+			gitauth.SSHInsecureIgnoreHostKeySecretKey: "true",
+		}))
+		Expect(err).To(MatchError(ContainSubstring(gitauth.SSHPrivateKeyPassphraseSecretKey)))
+
+		p, err := gitauth.NewSSHGitOperationsProvider(httpsProvider{}, secretWith(map[string]string{
+			gitauth.SSHPrivateKeySecretKey:            marshalKey("s3cret"),
+			gitauth.SSHPrivateKeyPassphraseSecretKey:  "s3cret",
+			gitauth.SSHInsecureIgnoreHostKeySecretKey: "true",
+		}))
+		Expect(err).NotTo(HaveOccurred())
+
+		command, cleanup, err := p.GetGitSSHCommand(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		defer cleanup()
+		key, err := os.ReadFile(strings.Trim(strings.Fields(command)[2], "'"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ssh.ParsePrivateKey(key)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
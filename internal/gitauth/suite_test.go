@@ -0,0 +1,17 @@
+package gitauth_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGitauth(t *testing.T) {
+	t.Parallel()
+
+	RegisterFailHandler(Fail)
+
+	c, _ := GinkgoConfiguration()
+	RunSpecs(t, "gitauth Suite", c)
+}
@@ -0,0 +1,118 @@
+// Package health provides the checks of the controller's readiness endpoint.
+package health
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+// DefaultMaxFailingScmProviderFraction is the default fraction of ScmProviders and ClusterScmProviders that may fail
+// before the controller reports that it isn't ready.
+const DefaultMaxFailingScmProviderFraction = 0.5
+
+// GitTokenFailuresFunc returns the number of installations whose last request for a git token failed, and the number
+// of installations git tokens were requested for.
+type GitTokenFailuresFunc func() (failing int, total int)
+
+// SCMConnectivityChecker is a readiness check that fails when the controller can't do any useful work because it can't
+// authenticate to the SCMs: when more than a fraction of the ScmProviders and ClusterScmProviders have a False Ready
+// condition, or when every git token request fails. It reads the Ready conditions the ScmProvider controllers maintain
+// from the cache and never calls an SCM, so it is cheap to probe.
+type SCMConnectivityChecker struct {
+	reader             client.Reader
+	maxFailingFraction float64
+	gitTokenFailures   GitTokenFailuresFunc
+
+	mutex       sync.Mutex
+	lastFailure string
+}
+
+// NewSCMConnectivityChecker returns an SCMConnectivityChecker that lists the providers with reader and fails when more
+// than maxFailingFraction of them are failing. gitTokenFailures may be nil.
+func NewSCMConnectivityChecker(reader client.Reader, maxFailingFraction float64, gitTokenFailures GitTokenFailuresFunc) *SCMConnectivityChecker {
+	return &SCMConnectivityChecker{
+		reader:             reader,
+		maxFailingFraction: maxFailingFraction,
+		gitTokenFailures:   gitTokenFailures,
+	}
+}
+
+// Check is a healthz.Checker. The returned error lists the failing providers. The readiness endpoint withholds
+// it, so it is also logged whenever it changes.
+func (c *SCMConnectivityChecker) Check(req *http.Request) error {
+	err := c.check(req)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	failure := ""
+	if err != nil {
+		failure = err.Error()
+	}
+	if failure != c.lastFailure {
+		logger := log.FromContext(req.Context()).WithName("scm-connectivity")
+		if err != nil {
+			logger.Info("Not ready, the SCMs can't be reached", "reason", failure)
+		} else {
+			logger.Info("Ready, the SCMs can be reached again")
+		}
+		c.lastFailure = failure
+	}
+	return err
+}
+
+// check returns an error describing why the controller can't reach the SCMs, or nil.
+func (c *SCMConnectivityChecker) check(req *http.Request) error {
+	ctx := req.Context()
+
+	var scmProviders promoterv1alpha1.ScmProviderList
+	if err := c.reader.List(ctx, &scmProviders); err != nil {
+		return fmt.Errorf("failed to list ScmProviders: %w", err)
+	}
+	var clusterScmProviders promoterv1alpha1.ClusterScmProviderList
+	if err := c.reader.List(ctx, &clusterScmProviders); err != nil {
+		return fmt.Errorf("failed to list ClusterScmProviders: %w", err)
+	}
+
+	var failing []string
+	for i := range scmProviders.Items {
+		if reason, ok := failingReason(&scmProviders.Items[i]); ok {
+			failing = append(failing, fmt.Sprintf("ScmProvider %s/%s (%s)", scmProviders.Items[i].Namespace, scmProviders.Items[i].Name, reason))
+		}
+	}
+	for i := range clusterScmProviders.Items {
+		if reason, ok := failingReason(&clusterScmProviders.Items[i]); ok {
+			failing = append(failing, fmt.Sprintf("ClusterScmProvider %s (%s)", clusterScmProviders.Items[i].Name, reason))
+		}
+	}
+
+	var errs []error
+	total := len(scmProviders.Items) + len(clusterScmProviders.Items)
+	if total > 0 && float64(len(failing))/float64(total) > c.maxFailingFraction {
+		errs = append(errs, fmt.Errorf("%d of %d SCM providers are not ready: %s", len(failing), total, strings.Join(failing, ", ")))
+	}
+	if c.gitTokenFailures != nil {
+		if failingTokens, totalTokens := c.gitTokenFailures(); totalTokens > 0 && failingTokens == totalTokens {
+			errs = append(errs, fmt.Errorf("the last git token request of all %d GitHub App installations failed", totalTokens))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// failingReason returns the reason of the Ready condition of the provider if it is False.
+func failingReason(provider utils.StatusConditionUpdater) (string, bool) {
+	ready := utils.GetReadyCondition(provider)
+	if ready == nil || ready.Status != metav1.ConditionFalse {
+		return "", false
+	}
+	return ready.Reason, true
+}
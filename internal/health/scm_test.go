@@ -0,0 +1,65 @@
+package health_test
+
+import (
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/health"
+	"github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+var _ = Describe("SCMConnectivityChecker", func() {
+	scmProvider := func(name string, status metav1.ConditionStatus, reason conditions.CommonReason) *promoterv1alpha1.ScmProvider {
+		provider := &promoterv1alpha1.ScmProvider{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		utils.SetReadyCondition(provider, status, reason, "")
+		return provider
+	}
+	check := func(maxFailingFraction float64, gitTokenFailures health.GitTokenFailuresFunc, objs ...client.Object) error {
+		reader := fake.NewClientBuilder().WithScheme(utils.GetScheme()).WithObjects(objs...).Build()
+		checker := health.NewSCMConnectivityChecker(reader, maxFailingFraction, gitTokenFailures)
+		return checker.Check(httptest.NewRequest("GET", "/readyz", nil))
+	}
+
+	It("is ready without any providers", func() {
+		Expect(check(0.5, nil)).To(Succeed())
+	})
+
+	It("is ready while no more than the fraction of providers are failing", func() {
+		Expect(check(0.5, nil,
+			scmProvider("good", metav1.ConditionTrue, conditions.ReconciliationSuccess),
+			scmProvider("bad", metav1.ConditionFalse, conditions.InvalidCredentials),
+		)).To(Succeed())
+	})
+
+	It("isn't ready when more than the fraction of providers are failing, listing them", func() {
+		clusterScmProvider := &promoterv1alpha1.ClusterScmProvider{ObjectMeta: metav1.ObjectMeta{Name: "shared"}}
+		utils.SetReadyCondition(clusterScmProvider, metav1.ConditionFalse, conditions.SecretNotFound, "")
+
+		err := check(0.5, nil,
+			scmProvider("good", metav1.ConditionTrue, conditions.ReconciliationSuccess),
+			scmProvider("bad", metav1.ConditionFalse, conditions.InvalidCredentials),
+			clusterScmProvider,
+		)
+		Expect(err).To(MatchError(ContainSubstring("2 of 3 SCM providers are not ready")))
+		Expect(err).To(MatchError(ContainSubstring("ScmProvider default/bad (InvalidCredentials)")))
+		Expect(err).To(MatchError(ContainSubstring("ClusterScmProvider shared (SecretNotFound)")))
+	})
+
+	It("doesn't count providers that weren't reconciled yet as failing", func() {
+		Expect(check(0.5, nil,
+			&promoterv1alpha1.ScmProvider{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "default"}},
+		)).To(Succeed())
+	})
+
+	It("isn't ready when every git token request fails", func() {
+		Expect(check(1, func() (int, int) { return 1, 2 })).To(Succeed())
+		Expect(check(1, func() (int, int) { return 2, 2 })).To(MatchError(ContainSubstring("all 2 GitHub App installations")))
+	})
+})
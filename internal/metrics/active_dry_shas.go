@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+var (
+	// activeDryShasMutex protects activeDryShas.
+	activeDryShasMutex sync.Mutex
+	// activeDryShas holds the short dry sha each environment of a PromotionStrategy was last set to, by branch, so that
+	// its series can be deleted when another dry sha becomes active or the environment is removed.
+	activeDryShas = map[promotionStrategyKey]map[string]string{}
+)
+
+// SetPromotionActiveDryShas sets the active dry sha gauge of each environment of the PromotionStrategy to the short dry
+// sha in shas, by branch. The series of an environment's previous dry sha, and those of the environments that are no
+// longer in shas, are deleted, so there is at most one series per environment.
+func SetPromotionActiveDryShas(ps *v1alpha1.PromotionStrategy, shas map[string]string) {
+	key := promotionStrategyKey{namespace: ps.Namespace, name: ps.Name}
+
+	activeDryShasMutex.Lock()
+	defer activeDryShasMutex.Unlock()
+
+	previous := activeDryShas[key]
+	for environment, sha := range previous {
+		if shas[environment] != sha {
+			promotionEnvironmentActiveDrySha.DeleteLabelValues(ps.Namespace, ps.Name, environment, sha)
+		}
+	}
+	for environment, sha := range shas {
+		promotionEnvironmentActiveDrySha.WithLabelValues(ps.Namespace, ps.Name, environment, sha).Set(1)
+	}
+
+	if len(shas) == 0 {
+		delete(activeDryShas, key)
+		return
+	}
+	activeDryShas[key] = maps.Clone(shas)
+}
+
+// deleteActiveDryShas removes the active dry sha gauges of a PromotionStrategy.
+func deleteActiveDryShas(namespace, name string) {
+	activeDryShasMutex.Lock()
+	defer activeDryShasMutex.Unlock()
+
+	delete(activeDryShas, promotionStrategyKey{namespace: namespace, name: name})
+	promotionEnvironmentActiveDrySha.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "promotion_strategy": name})
+}
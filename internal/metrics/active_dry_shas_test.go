@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+var _ = Describe("Active dry sha metrics", func() {
+	ps := &v1alpha1.PromotionStrategy{ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "shas"}}
+
+	series := func() int {
+		return testutil.CollectAndCount(promotionEnvironmentActiveDrySha)
+	}
+
+	AfterEach(func() {
+		DeletePromotionStrategyMetrics("team", "shas")
+	})
+
+	It("flips exactly one series when a dry commit is promoted", func() {
+		SetPromotionActiveDryShas(ps, map[string]string{"environment/dev": "aaaaaaa", "environment/prod": "bbbbbbb"})
+		Expect(series()).To(Equal(2))
+
+		SetPromotionActiveDryShas(ps, map[string]string{"environment/dev": "ccccccc", "environment/prod": "bbbbbbb"})
+		Expect(series()).To(Equal(2))
+		Expect(testutil.ToFloat64(promotionEnvironmentActiveDrySha.WithLabelValues("team", "shas", "environment/dev", "ccccccc"))).To(Equal(1.0))
+		// Deleting a series reports whether it existed.
+		Expect(promotionEnvironmentActiveDrySha.DeleteLabelValues("team", "shas", "environment/dev", "aaaaaaa")).To(BeFalse())
+	})
+
+	It("deletes the series of removed environments and deleted PromotionStrategies", func() {
+		SetPromotionActiveDryShas(ps, map[string]string{"environment/dev": "aaaaaaa", "environment/prod": "bbbbbbb"})
+		SetPromotionActiveDryShas(ps, map[string]string{"environment/dev": "aaaaaaa"})
+		Expect(series()).To(Equal(1))
+
+		DeletePromotionStrategyMetrics("team", "shas")
+		Expect(series()).To(BeZero())
+	})
+})
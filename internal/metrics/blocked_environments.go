@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+// promotionStrategyKey identifies a PromotionStrategy.
+type promotionStrategyKey struct {
+	namespace string
+	name      string
+}
+
+// blockedEnvironment is why an environment is blocked and since when.
+type blockedEnvironment struct {
+	reason PromotionBlockedReason
+	since  time.Time
+}
+
+var (
+	// blockedEnvironmentsMutex protects blockedEnvironments.
+	blockedEnvironmentsMutex sync.Mutex
+	// blockedEnvironments holds the environments of each PromotionStrategy that are blocked, by branch. The times the
+	// environments became blocked are only kept in memory, so after a restart they are counted from the first reconcile.
+	blockedEnvironments = map[promotionStrategyKey]map[string]blockedEnvironment{}
+)
+
+// SetPromotionBlockedEnvironments sets the blocked environment gauges of the PromotionStrategy to the environments in
+// blocked, by branch, and removes those of the environments that are no longer blocked. An environment that stays
+// blocked keeps the time it became blocked, even if the reason changes.
+func SetPromotionBlockedEnvironments(ps *v1alpha1.PromotionStrategy, blocked map[string]PromotionBlockedReason, now time.Time) {
+	key := promotionStrategyKey{namespace: ps.Namespace, name: ps.Name}
+
+	blockedEnvironmentsMutex.Lock()
+	defer blockedEnvironmentsMutex.Unlock()
+
+	previous := blockedEnvironments[key]
+	current := make(map[string]blockedEnvironment, len(blocked))
+	for environment, reason := range blocked {
+		state, wasBlocked := previous[environment]
+		if !wasBlocked {
+			state.since = now
+		} else if state.reason != reason {
+			deleteBlockedEnvironmentGauges(key, environment, state.reason)
+		}
+		state.reason = reason
+		current[environment] = state
+
+		promotionEnvironmentBlocked.WithLabelValues(ps.Namespace, ps.Name, environment, string(reason)).Set(1)
+		promotionEnvironmentBlockedDurationSeconds.WithLabelValues(ps.Namespace, ps.Name, environment, string(reason)).
+			Set(now.Sub(state.since).Seconds())
+	}
+	for environment, state := range previous {
+		if _, stillBlocked := current[environment]; !stillBlocked {
+			deleteBlockedEnvironmentGauges(key, environment, state.reason)
+		}
+	}
+
+	if len(current) == 0 {
+		delete(blockedEnvironments, key)
+		return
+	}
+	blockedEnvironments[key] = current
+}
+
+// deleteBlockedEnvironments removes the blocked environment gauges of a PromotionStrategy.
+func deleteBlockedEnvironments(namespace, name string) {
+	blockedEnvironmentsMutex.Lock()
+	defer blockedEnvironmentsMutex.Unlock()
+
+	delete(blockedEnvironments, promotionStrategyKey{namespace: namespace, name: name})
+	labels := prometheus.Labels{"namespace": namespace, "promotion_strategy": name}
+	promotionEnvironmentBlocked.DeletePartialMatch(labels)
+	promotionEnvironmentBlockedDurationSeconds.DeletePartialMatch(labels)
+}
+
+// deleteBlockedEnvironmentGauges removes the blocked environment gauges of an environment for the reason.
+func deleteBlockedEnvironmentGauges(key promotionStrategyKey, environment string, reason PromotionBlockedReason) {
+	promotionEnvironmentBlocked.DeleteLabelValues(key.namespace, key.name, environment, string(reason))
+	promotionEnvironmentBlockedDurationSeconds.DeleteLabelValues(key.namespace, key.name, environment, string(reason))
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+var _ = Describe("Blocked environment metrics", func() {
+	ps := &v1alpha1.PromotionStrategy{ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "app"}}
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	blocked := func(environment string, reason PromotionBlockedReason) float64 {
+		return testutil.ToFloat64(promotionEnvironmentBlocked.WithLabelValues("team", "app", environment, string(reason)))
+	}
+	blockedSeconds := func(environment string, reason PromotionBlockedReason) float64 {
+		return testutil.ToFloat64(promotionEnvironmentBlockedDurationSeconds.WithLabelValues("team", "app", environment, string(reason)))
+	}
+
+	AfterEach(func() {
+		DeletePromotionStrategyMetrics("team", "app")
+	})
+
+	It("keeps the time an environment became blocked while it stays blocked", func() {
+		SetPromotionBlockedEnvironments(ps, map[string]PromotionBlockedReason{"environment/staging": PromotionBlockedPreviousEnvironment}, start)
+		Expect(blocked("environment/staging", PromotionBlockedPreviousEnvironment)).To(Equal(1.0))
+		Expect(blockedSeconds("environment/staging", PromotionBlockedPreviousEnvironment)).To(BeZero())
+
+		SetPromotionBlockedEnvironments(ps, map[string]PromotionBlockedReason{"environment/staging": PromotionBlockedManualMerge}, start.Add(time.Minute))
+		Expect(testutil.CollectAndCount(promotionEnvironmentBlocked)).To(Equal(1))
+		Expect(blocked("environment/staging", PromotionBlockedManualMerge)).To(Equal(1.0))
+		Expect(blockedSeconds("environment/staging", PromotionBlockedManualMerge)).To(Equal(60.0))
+	})
+
+	It("clears the gauges of environments that are unblocked and of deleted PromotionStrategies", func() {
+		SetPromotionBlockedEnvironments(ps, map[string]PromotionBlockedReason{
+			"environment/staging":    PromotionBlockedPreviousEnvironment,
+			"environment/production": PromotionBlockedProposedCommitStatus,
+		}, start)
+		Expect(testutil.CollectAndCount(promotionEnvironmentBlocked)).To(Equal(2))
+
+		SetPromotionBlockedEnvironments(ps, map[string]PromotionBlockedReason{"environment/production": PromotionBlockedProposedCommitStatus}, start.Add(time.Minute))
+		Expect(testutil.CollectAndCount(promotionEnvironmentBlocked)).To(Equal(1))
+		Expect(testutil.CollectAndCount(promotionEnvironmentBlockedDurationSeconds)).To(Equal(1))
+		Expect(blockedSeconds("environment/production", PromotionBlockedProposedCommitStatus)).To(Equal(60.0))
+
+		DeletePromotionStrategyMetrics("team", "app")
+		Expect(testutil.CollectAndCount(promotionEnvironmentBlocked)).To(BeZero())
+		Expect(testutil.CollectAndCount(promotionEnvironmentBlockedDurationSeconds)).To(BeZero())
+
+		// Blocked again after being recreated, the time is counted afresh.
+		SetPromotionBlockedEnvironments(ps, map[string]PromotionBlockedReason{"environment/production": PromotionBlockedProposedCommitStatus}, start.Add(time.Hour))
+		Expect(blockedSeconds("environment/production", PromotionBlockedProposedCommitStatus)).To(BeZero())
+	})
+})
@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("CloudEvents metrics", func() {
+	It("records sent events per type and result", func() {
+		RecordCloudEventSent("io.argoproj.promoter.promotion.merged", true)
+		RecordCloudEventSent("io.argoproj.promoter.promotion.merged", false)
+		RecordCloudEventSent("io.argoproj.promoter.promotion.merged", true)
+
+		Expect(testutil.ToFloat64(cloudEventsSentTotal.WithLabelValues("io.argoproj.promoter.promotion.merged", "success"))).To(Equal(2.0))
+		Expect(testutil.ToFloat64(cloudEventsSentTotal.WithLabelValues("io.argoproj.promoter.promotion.merged", "failure"))).To(Equal(1.0))
+	})
+
+	It("records dropped events per type", func() {
+		RecordCloudEventDropped("io.argoproj.promoter.promotion.blocked")
+		Expect(testutil.ToFloat64(cloudEventsDroppedTotal.WithLabelValues("io.argoproj.promoter.promotion.blocked"))).To(Equal(1.0))
+	})
+})
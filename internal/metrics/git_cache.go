@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/utils/gitpaths"
+)
+
+const gitCacheInterval = time.Minute
+
+// GitCacheRunnable periodically updates git_cached_clones and git_cached_clones_disk_bytes from the clones and stores
+// recorded in gitpaths. Measuring the disk usage walks every clone, so it runs on an interval instead of after each git operation.
+type GitCacheRunnable struct {
+	// tickInterval is the delay between refreshes after the initial run. Zero means gitCacheInterval.
+	tickInterval time.Duration
+}
+
+// NewGitCacheRunnable returns a manager.Runnable that refreshes the cached clone metrics.
+func NewGitCacheRunnable() *GitCacheRunnable {
+	return &GitCacheRunnable{}
+}
+
+// Start implements manager.Runnable.
+func (r *GitCacheRunnable) Start(ctx context.Context) error {
+	refreshGitCacheMetrics(gitpaths.GetValues(), gitpaths.GetStorePaths())
+
+	interval := r.tickInterval
+	if interval <= 0 {
+		interval = gitCacheInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			refreshGitCacheMetrics(gitpaths.GetValues(), gitpaths.GetStorePaths())
+		}
+	}
+}
+
+// refreshGitCacheMetrics counts the clones at clonePaths and measures their disk usage together with that of the stores
+// at storePaths, which hold the objects the clones share.
+func refreshGitCacheMetrics(clonePaths, storePaths []string) {
+	var total int64
+	for _, path := range slices.Concat(clonePaths, storePaths) {
+		total += diskUsage(path)
+	}
+	gitCachedClones.Set(float64(len(clonePaths)))
+	gitCachedClonesDiskBytes.Set(float64(total))
+}
+
+// diskUsage returns the total size of the regular files under path. git commands may add or remove files while the
+// clone is walked, so entries that can't be read are skipped instead of failing the whole measurement.
+func diskUsage(path string) int64 {
+	var total int64
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("refreshGitCacheMetrics", func() {
+	It("reports the number of clones and the size of their files and stores", func() {
+		first := GinkgoT().TempDir()
+		second := GinkgoT().TempDir()
+		store := GinkgoT().TempDir()
+		Expect(os.MkdirAll(filepath.Join(first, ".git", "objects"), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(first, ".git", "objects", "pack"), make([]byte, 100), 0o644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(second, "manifest.yaml"), make([]byte, 23), 0o644)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(store, "objects"), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(store, "objects", "pack"), make([]byte, 1000), 0o644)).To(Succeed())
+
+		refreshGitCacheMetrics([]string{first, second, filepath.Join(second, "deleted")}, []string{store})
+
+		Expect(testutil.ToFloat64(gitCachedClones)).To(Equal(3.0))
+		Expect(testutil.ToFloat64(gitCachedClonesDiskBytes)).To(Equal(1123.0))
+	})
+})
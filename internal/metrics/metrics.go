@@ -48,6 +48,26 @@ func GitOperationResultFromError(err error) GitOperationResult {
 	return GitOperationResultFailure
 }
 
+// GitErrorType classifies why a git operation failed.
+type GitErrorType string
+
+const (
+	// GitErrorTypeAuthentication is used when the git server rejected the credentials.
+	GitErrorTypeAuthentication GitErrorType = "authentication"
+	// GitErrorTypeNotFound is used when the repository or ref does not exist.
+	GitErrorTypeNotFound GitErrorType = "not-found"
+	// GitErrorTypeNetwork is used when the git server could not be reached or the connection was interrupted.
+	GitErrorTypeNetwork GitErrorType = "network"
+	// GitErrorTypeTimeout is used when the operation was canceled or ran past its deadline.
+	GitErrorTypeTimeout GitErrorType = "timeout"
+	// GitErrorTypeRejected is used when the git server rejected a push, for example because it was not a fast-forward.
+	GitErrorTypeRejected GitErrorType = "rejected"
+	// GitErrorTypeDiskFull is used when the volume of the clones ran out of space.
+	GitErrorTypeDiskFull GitErrorType = "disk-full"
+	// GitErrorTypeUnknown is used for any other failure.
+	GitErrorTypeUnknown GitErrorType = "unknown"
+)
+
 // SCMAPI represents the type of API being used in the SCM operations.
 type SCMAPI string
 
@@ -56,6 +76,10 @@ const (
 	SCMAPICommitStatus SCMAPI = "CommitStatus"
 	// SCMAPIPullRequest is used for operations related to pull requests.
 	SCMAPIPullRequest SCMAPI = "PullRequest"
+	// SCMAPIRepository is used for operations related to repositories.
+	SCMAPIRepository SCMAPI = "Repository"
+	// SCMAPIWebhook is used for operations related to repository webhooks.
+	SCMAPIWebhook SCMAPI = "Webhook"
 )
 
 // SCMOperation represents the type of operation being performed on the SCM API.
@@ -74,6 +98,108 @@ const (
 	SCMOperationList SCMOperation = "list"
 	// SCMOperationGet is used when getting a single resource, such as a specific pull request.
 	SCMOperationGet SCMOperation = "get"
+	// SCMOperationDelete is used when deleting resources such as webhooks.
+	SCMOperationDelete SCMOperation = "delete"
+)
+
+// WebhookDeliveryReason represents the reason a delivery to the webhook receiver is accepted or rejected for.
+type WebhookDeliveryReason string
+
+const (
+	// WebhookDeliverySignatureValid is used for deliveries signed with a webhook secret that applies to them.
+	WebhookDeliverySignatureValid WebhookDeliveryReason = "signature_valid"
+	// WebhookDeliverySignatureNotRequired is used for deliveries no webhook secret applies to.
+	WebhookDeliverySignatureNotRequired WebhookDeliveryReason = "signature_not_required"
+	// WebhookDeliveryBodyTooLarge is used for deliveries whose body is larger than the receiver's limit.
+	WebhookDeliveryBodyTooLarge WebhookDeliveryReason = "body_too_large"
+	// WebhookDeliverySignatureMissing is used for deliveries without a signature when a webhook secret applies to them.
+	WebhookDeliverySignatureMissing WebhookDeliveryReason = "signature_missing"
+	// WebhookDeliverySignatureInvalid is used for deliveries not signed with any webhook secret that applies to them.
+	WebhookDeliverySignatureInvalid WebhookDeliveryReason = "signature_invalid"
+	// WebhookDeliveryTokenValid is used for GitLab deliveries whose token, and Azure DevOps deliveries whose basic auth
+	// password, is a webhook secret that applies to them.
+	WebhookDeliveryTokenValid WebhookDeliveryReason = "token_valid"
+	// WebhookDeliveryTokenMissing is used for GitLab deliveries without a token, and Azure DevOps deliveries without
+	// basic auth, when a webhook secret applies to them.
+	WebhookDeliveryTokenMissing WebhookDeliveryReason = "token_missing"
+	// WebhookDeliveryTokenInvalid is used for GitLab deliveries whose token, and Azure DevOps deliveries whose basic
+	// auth password, isn't any webhook secret that applies to them.
+	WebhookDeliveryTokenInvalid WebhookDeliveryReason = "token_invalid"
+	// WebhookDeliverySourceAllowed is used for Bitbucket deliveries from an allowed network that are unsigned or that
+	// no secret applies to.
+	WebhookDeliverySourceAllowed WebhookDeliveryReason = "source_allowed"
+	// WebhookDeliverySourceNotAllowed is used for Bitbucket deliveries from outside the allowed networks, signed or not.
+	WebhookDeliverySourceNotAllowed WebhookDeliveryReason = "source_not_allowed"
+	// WebhookDeliveryBearerTokenValid is used for deliveries whose Authorization header carries the receiver's bearer
+	// token.
+	WebhookDeliveryBearerTokenValid WebhookDeliveryReason = "bearer_token_valid"
+	// WebhookDeliveryBearerTokenMissing is used for deliveries without an Authorization header when the receiver requires
+	// a bearer token from their provider.
+	WebhookDeliveryBearerTokenMissing WebhookDeliveryReason = "bearer_token_missing"
+	// WebhookDeliveryBearerTokenInvalid is used for deliveries whose Authorization header doesn't carry the receiver's
+	// bearer token.
+	WebhookDeliveryBearerTokenInvalid WebhookDeliveryReason = "bearer_token_invalid"
+	// WebhookDeliverySecretUnavailable is used for deliveries whose webhook secret can't be read.
+	WebhookDeliverySecretUnavailable WebhookDeliveryReason = "secret_unavailable"
+	// WebhookDeliveryTooOld is used for deliveries older than the receiver's maximum delivery age.
+	WebhookDeliveryTooOld WebhookDeliveryReason = "too_old"
+	// WebhookDeliveryReplayed is used for deliveries whose delivery ID was already received.
+	WebhookDeliveryReplayed WebhookDeliveryReason = "replayed"
+	// WebhookDeliveryIDMissing is used for signed GitHub deliveries without a delivery ID.
+	WebhookDeliveryIDMissing WebhookDeliveryReason = "delivery_id_missing"
+	// WebhookDeliveryIDInvalid is used for signed GitHub deliveries whose delivery ID isn't a time based UUID.
+	WebhookDeliveryIDInvalid WebhookDeliveryReason = "delivery_id_invalid"
+)
+
+// WebhookEventOutcome represents what the webhook receiver did with a delivery.
+type WebhookEventOutcome string
+
+const (
+	// WebhookEventAccepted is used for deliveries that triggered reconciles.
+	WebhookEventAccepted WebhookEventOutcome = "accepted"
+	// WebhookEventIgnored is used for deliveries that were accepted without triggering any reconcile, e.g. because no
+	// resource tracks the repository, branch or commit they are for.
+	WebhookEventIgnored WebhookEventOutcome = "ignored"
+	// WebhookEventRejected is used for deliveries that were rejected, e.g. because their signature is invalid.
+	WebhookEventRejected WebhookEventOutcome = "rejected"
+	// WebhookEventFailed is used for deliveries the receiver failed to handle.
+	WebhookEventFailed WebhookEventOutcome = "failed"
+)
+
+// PromotionBlockedReason represents why the change proposed for an environment isn't merged.
+type PromotionBlockedReason string
+
+const (
+	// PromotionBlockedPreviousEnvironment is used when the previous environment's active commit statuses don't pass yet.
+	PromotionBlockedPreviousEnvironment PromotionBlockedReason = "previous_environment"
+	// PromotionBlockedProposedCommitStatus is used when a proposed commit status of the environment doesn't pass yet.
+	PromotionBlockedProposedCommitStatus PromotionBlockedReason = "proposed_commit_status"
+	// PromotionBlockedEmergencyRevert is used when auto-merge is held because an emergency revert diverged the
+	// environment's active branch.
+	PromotionBlockedEmergencyRevert PromotionBlockedReason = "emergency_revert"
+	// PromotionBlockedManualMerge is used when the checks pass but auto-merge is disabled, so the pull request waits for
+	// someone to merge it.
+	PromotionBlockedManualMerge PromotionBlockedReason = "manual_merge"
+)
+
+// PreviousEnvironmentCopyResult is what became of the previous environment commit status of an environment, into which
+// the previous environment's active commit statuses are copied, when its PromotionStrategy was reconciled.
+type PreviousEnvironmentCopyResult string
+
+const (
+	// PreviousEnvironmentCopyCreated is used when the commit status was created.
+	PreviousEnvironmentCopyCreated PreviousEnvironmentCopyResult = "created"
+	// PreviousEnvironmentCopyUpdated is used when the commit status was changed.
+	PreviousEnvironmentCopyUpdated PreviousEnvironmentCopyResult = "updated"
+	// PreviousEnvironmentCopyUnchanged is used when the commit status was applied without changing it.
+	PreviousEnvironmentCopyUnchanged PreviousEnvironmentCopyResult = "unchanged"
+	// PreviousEnvironmentCopySkipped is used when the commit status wasn't applied, because the environment proposes no
+	// change or its ChangeTransferPolicy hasn't resolved its branch shas.
+	PreviousEnvironmentCopySkipped PreviousEnvironmentCopyResult = "skipped"
+	// PreviousEnvironmentCopyStale is used when a commit status that wasn't applied still gates the proposed change, but
+	// was copied from an active hydrated sha of the previous environment that is no longer active. It is counted in
+	// addition to PreviousEnvironmentCopySkipped.
+	PreviousEnvironmentCopyStale PreviousEnvironmentCopyResult = "stale"
 )
 
 // RateLimit represents the rate limit information for SCM API calls.
@@ -90,6 +216,9 @@ var (
 	// Labels for git_operations metrics
 	gitOperationLabels = []string{"git_repository", "scm_provider", "scm_provider_kind", "operation", "result"}
 
+	// Labels for git_operation_failures metrics
+	gitOperationFailureLabels = []string{"git_repository", "scm_provider", "scm_provider_kind", "operation", "error_type"}
+
 	// Labels for scm_calls metrics
 	scmCallLabels = []string{"git_repository", "scm_provider", "scm_provider_kind", "api", "operation", "response_code"}
 
@@ -103,7 +232,7 @@ var (
 	gitOperationsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "git_operations_total",
-			Help: "A counter of git clone operations.",
+			Help: "A counter of git operations.",
 		},
 		gitOperationLabels,
 	)
@@ -111,12 +240,34 @@ var (
 	gitOperationsDurationSeconds = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "git_operations_duration_seconds",
-			Help:    "A histogram of the duration of git clone operations.",
+			Help:    "A histogram of the duration of git operations.",
 			Buckets: prometheus.DefBuckets,
 		},
 		gitOperationLabels,
 	)
 
+	gitOperationFailuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "git_operation_failures_total",
+			Help: "A counter of failed git operations, by the classified type of error.",
+		},
+		gitOperationFailureLabels,
+	)
+
+	gitCachedClones = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "git_cached_clones",
+			Help: "Current number of repository clones cached on disk by the controller.",
+		},
+	)
+
+	gitCachedClonesDiskBytes = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "git_cached_clones_disk_bytes",
+			Help: "Total size in bytes of the repository clones cached on disk by the controller.",
+		},
+	)
+
 	scmCallsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "scm_calls_total",
@@ -158,6 +309,115 @@ var (
 		scmCallRateLimitLabels,
 	)
 
+	scmHTTPRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "scm_http_requests_total",
+			Help: "A counter of HTTP requests the SCM clients sent, by provider, host and operation.",
+		},
+		[]string{"provider", "host", "operation", "response_code"},
+	)
+
+	scmHTTPRequestDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "scm_http_request_duration_seconds",
+			Help:    "A histogram of the duration of HTTP requests the SCM clients sent, by provider, host and operation.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"provider", "host", "operation"},
+	)
+
+	scmHTTPRateLimitRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "scm_http_rate_limit_remaining",
+			Help: "The remaining rate limit the SCM last reported in the headers of a response.",
+		},
+		[]string{"provider", "host"},
+	)
+
+	scmHTTPRateLimitResetTimestampSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "scm_http_rate_limit_reset_timestamp_seconds",
+			Help: "The Unix time at which the SCM last reported in the headers of a response that its rate limit resets.",
+		},
+		[]string{"provider", "host"},
+	)
+
+	pullRequestOpenDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "pull_request_open_duration_seconds",
+			Help: "A histogram of how long promotion pull requests stay open until they are merged.",
+			// From seconds, for environments that merge as soon as the pull request opens, to a week.
+			Buckets: []float64{10, 30, 60, 300, 900, 1800, 3600, 3 * 3600, 6 * 3600, 12 * 3600, 24 * 3600, 2 * 24 * 3600, 7 * 24 * 3600},
+		},
+		[]string{"namespace", "promotion_strategy", "environment"},
+	)
+
+	promotionBlockedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "promotion_blocked_total",
+			Help: "A counter of the times the change proposed for an environment became blocked, by reason.",
+		},
+		[]string{"namespace", "promotion_strategy", "environment", "reason"},
+	)
+
+	promotionOpenPullRequests = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "promotion_open_pull_requests",
+			Help: "The number of promotion pull requests open for a PromotionStrategy.",
+		},
+		[]string{"namespace", "promotion_strategy"},
+	)
+
+	promotionEnvironmentBlocked = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "promotion_environment_blocked",
+			Help: "1 for each environment whose proposed change is currently blocked, with the reason it is blocked.",
+		},
+		[]string{"namespace", "promotion_strategy", "environment", "reason"},
+	)
+
+	promotionEnvironmentBlockedDurationSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "promotion_environment_blocked_duration_seconds",
+			Help: "How long the proposed change of an environment has been blocked, as of the last reconcile of its PromotionStrategy.",
+		},
+		[]string{"namespace", "promotion_strategy", "environment", "reason"},
+	)
+
+	// promotionEnvironmentActiveDrySha has one series per environment: the series of the previous dry sha is deleted
+	// when another becomes active, so the sha label only churns as fast as promotions happen.
+	promotionEnvironmentActiveDrySha = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "promotion_environment_active_dry_sha",
+			Help: "1 for the dry commit, by short sha, that is active in each environment of a PromotionStrategy.",
+		},
+		[]string{"namespace", "promotion_strategy", "environment", "dry_sha"},
+	)
+
+	previousEnvironmentCommitStatusCopiesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "previous_environment_commit_status_copies_total",
+			Help: "A counter of the copies of previous environments' active commit statuses into previous environment commit statuses, by result.",
+		},
+		[]string{"namespace", "promotion_strategy", "result"},
+	)
+
+	previousEnvironmentCommitStatusLiveCopies = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "previous_environment_commit_status_live_copies",
+			Help: "The number of previous environment commit statuses that exist for an environment, as of the last reconcile of its PromotionStrategy.",
+		},
+		[]string{"namespace", "promotion_strategy", "environment"},
+	)
+
+	promotionMergesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "promotion_merges_total",
+			Help: "A counter of the promotion pull requests the controller set to merge.",
+		},
+		[]string{"namespace", "promotion_strategy", "environment"},
+	)
+
 	webhookCallsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "webhook_calls_total",
@@ -166,6 +426,14 @@ var (
 		[]string{"ctp_found", "response_code"},
 	)
 
+	webhookDeliveriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhook_deliveries_total",
+			Help: "A counter of deliveries to the webhook receiver by whether they were accepted and why.",
+		},
+		[]string{"result", "reason"},
+	)
+
 	webhookProcessingDurationSeconds = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name: "webhook_processing_duration_seconds",
@@ -174,6 +442,63 @@ var (
 		[]string{"ctp_found", "response_code"},
 	)
 
+	webhookEventsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhook_events_total",
+			Help: "A counter of deliveries to the webhook receiver by SCM provider, event and outcome.",
+		},
+		[]string{"provider", "event", "outcome"},
+	)
+
+	webhookHandlerDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "webhook_handler_duration_seconds",
+			Help:    "A histogram of the time the webhook receiver takes to handle a delivery, by SCM provider and event.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"provider", "event"},
+	)
+
+	webhookHandlerPanicsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhook_handler_panics_total",
+			Help: "A counter of panics recovered while the webhook receiver handled a delivery.",
+		},
+		[]string{"provider"},
+	)
+
+	webhookRoutes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "webhook_routes",
+			Help: "The number of repositories, branches and hydrated commits the webhook receiver can route deliveries to.",
+		},
+		[]string{"index"},
+	)
+
+	cloudEventsSentTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cloudevents_sent_total",
+			Help: "A counter of CloudEvents sent to the sink by event type and whether the sink accepted them.",
+		},
+		[]string{"type", "result"},
+	)
+
+	cloudEventsDroppedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cloudevents_dropped_total",
+			Help: "A counter of CloudEvents dropped without being sent because the emitter's buffer was full.",
+		},
+		[]string{"type"},
+	)
+
+	auditRecordsDroppedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "audit_records_dropped_total",
+			Help: "A counter of audit records dropped without being written to a sink, by sink and reason.",
+		},
+		[]string{"sink", "reason"},
+	)
+
 	webRequestCommitStatusHTTPRequestsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "webrequest_commit_status_http_requests_total",
@@ -218,12 +543,36 @@ func init() {
 	metrics.Registry.MustRegister(
 		gitOperationsTotal,
 		gitOperationsDurationSeconds,
+		gitOperationFailuresTotal,
+		gitCachedClones,
+		gitCachedClonesDiskBytes,
 		scmCallsTotal,
 		scmCallsDurationSeconds,
 		scmCallsRateLimitLimit,
 		scmCallsRateLimitRemaining,
 		scmCallsRateLimitResetRemainingSeconds,
+		scmHTTPRequestsTotal,
+		scmHTTPRequestDurationSeconds,
+		scmHTTPRateLimitRemaining,
+		scmHTTPRateLimitResetTimestampSeconds,
+		pullRequestOpenDurationSeconds,
+		promotionBlockedTotal,
+		promotionOpenPullRequests,
+		promotionEnvironmentBlocked,
+		promotionEnvironmentBlockedDurationSeconds,
+		promotionEnvironmentActiveDrySha,
+		previousEnvironmentCommitStatusCopiesTotal,
+		previousEnvironmentCommitStatusLiveCopies,
+		promotionMergesTotal,
+		webhookDeliveriesTotal,
 		webhookProcessingDurationSeconds,
+		webhookEventsTotal,
+		webhookHandlerDurationSeconds,
+		webhookHandlerPanicsTotal,
+		webhookRoutes,
+		cloudEventsSentTotal,
+		cloudEventsDroppedTotal,
+		auditRecordsDroppedTotal,
 		webRequestCommitStatusHTTPRequestsTotal,
 		webRequestCommitStatusHTTPRequestDurationSeconds,
 		FinalizerDependentCount,
@@ -256,8 +605,19 @@ func RecordGitOperation(gitRepo *v1alpha1.GitRepository, operation GitOperation,
 	gitOperationsDurationSeconds.With(labels).Observe(duration.Seconds())
 }
 
+// RecordGitOperationFailure records a failed git operation with the classified type of its error.
+func RecordGitOperationFailure(gitRepo *v1alpha1.GitRepository, operation GitOperation, errorType GitErrorType) {
+	gitOperationFailuresTotal.With(prometheus.Labels{
+		"git_repository":    gitRepo.Name,
+		"scm_provider":      gitRepo.Spec.ScmProviderRef.Name,
+		"scm_provider_kind": scmProviderKindLabel(gitRepo),
+		"operation":         string(operation),
+		"error_type":        string(errorType),
+	}).Inc()
+}
+
 // RecordSCMCall records both the increment and observation for SCM API calls, and optionally observes rate limit metrics.
-// It emits a structured debug log (verbosity V(1); enable with e.g. --zap-log-level=1) for each call, matching metric labels.
+// It emits a structured debug log (verbosity V(1); enable with e.g. --log-level=1) for each call, matching metric labels.
 func RecordSCMCall(ctx context.Context, gitRepo *v1alpha1.GitRepository, api SCMAPI, operation SCMOperation, responseCode int, duration time.Duration, rateLimit *RateLimit) {
 	kind := scmProviderKindLabel(gitRepo)
 	labels := prometheus.Labels{
@@ -294,6 +654,43 @@ func RecordSCMCall(ctx context.Context, gitRepo *v1alpha1.GitRepository, api SCM
 	}
 }
 
+// RecordPullRequestOpenDuration records how long the pull request that promoted a change to the environment of the
+// PromotionStrategy was open before it was merged.
+func RecordPullRequestOpenDuration(ps *v1alpha1.PromotionStrategy, environment string, duration time.Duration) {
+	pullRequestOpenDurationSeconds.WithLabelValues(ps.Namespace, ps.Name, environment).Observe(duration.Seconds())
+}
+
+// RecordPromotionBlocked records that the change proposed for the environment of the PromotionStrategy became blocked.
+func RecordPromotionBlocked(ps *v1alpha1.PromotionStrategy, environment string, reason PromotionBlockedReason) {
+	promotionBlockedTotal.WithLabelValues(ps.Namespace, ps.Name, environment, string(reason)).Inc()
+}
+
+// SetPromotionOpenPullRequests sets the number of promotion pull requests open for the PromotionStrategy.
+func SetPromotionOpenPullRequests(ps *v1alpha1.PromotionStrategy, count int) {
+	promotionOpenPullRequests.WithLabelValues(ps.Namespace, ps.Name).Set(float64(count))
+}
+
+// DeletePromotionStrategyMetrics removes the gauges of a PromotionStrategy that no longer exists: its open pull
+// requests, its blocked environments, its live previous environment commit statuses and its active dry shas.
+func DeletePromotionStrategyMetrics(namespace, name string) {
+	promotionOpenPullRequests.DeleteLabelValues(namespace, name)
+	deleteBlockedEnvironments(namespace, name)
+	deletePreviousEnvironmentCopies(namespace, name)
+	deleteActiveDryShas(namespace, name)
+}
+
+// RecordPreviousEnvironmentCopy records what became of the previous environment commit status of an environment of the
+// PromotionStrategy.
+func RecordPreviousEnvironmentCopy(ps *v1alpha1.PromotionStrategy, result PreviousEnvironmentCopyResult) {
+	previousEnvironmentCommitStatusCopiesTotal.WithLabelValues(ps.Namespace, ps.Name, string(result)).Inc()
+}
+
+// RecordPromotionMerge records that the controller set the pull request promoting to the environment of the
+// PromotionStrategy to merge.
+func RecordPromotionMerge(namespace, promotionStrategy, environment string) {
+	promotionMergesTotal.WithLabelValues(namespace, promotionStrategy, environment).Inc()
+}
+
 // RecordWebhookCall records the duration of webhook processing.
 func RecordWebhookCall(ctpFound bool, responseCode int, duration time.Duration) {
 	labels := prometheus.Labels{
@@ -304,6 +701,74 @@ func RecordWebhookCall(ctpFound bool, responseCode int, duration time.Duration)
 	webhookProcessingDurationSeconds.With(labels).Observe(duration.Seconds())
 }
 
+// RecordWebhookDelivery records that a delivery to the webhook receiver was accepted or rejected, and why.
+func RecordWebhookDelivery(accepted bool, reason WebhookDeliveryReason) {
+	result := "rejected"
+	if accepted {
+		result = "accepted"
+	}
+	webhookDeliveriesTotal.With(prometheus.Labels{
+		"result": result,
+		"reason": string(reason),
+	}).Inc()
+}
+
+// RecordWebhookEvent records the outcome of a delivery to the webhook receiver and how long handling it took. event
+// must be one of a bounded set of values, since the SCM's event header is set by the sender.
+func RecordWebhookEvent(provider, event string, outcome WebhookEventOutcome, duration time.Duration) {
+	webhookEventsTotal.With(prometheus.Labels{
+		"provider": provider,
+		"event":    event,
+		"outcome":  string(outcome),
+	}).Inc()
+	webhookHandlerDurationSeconds.With(prometheus.Labels{
+		"provider": provider,
+		"event":    event,
+	}).Observe(duration.Seconds())
+}
+
+// RecordWebhookHandlerPanic records that the webhook receiver recovered from a panic while handling a delivery.
+func RecordWebhookHandlerPanic(provider string) {
+	webhookHandlerPanicsTotal.WithLabelValues(provider).Inc()
+}
+
+// SetWebhookRoutes sets the number of repositories, branches and hydrated commits the webhook receiver can route
+// deliveries to.
+func SetWebhookRoutes(repositories, branches, hydratedShas int) {
+	webhookRoutes.WithLabelValues("repositories").Set(float64(repositories))
+	webhookRoutes.WithLabelValues("branches").Set(float64(branches))
+	webhookRoutes.WithLabelValues("hydrated_shas").Set(float64(hydratedShas))
+}
+
+// RecordCloudEventSent records that a CloudEvent of the type was sent to the sink, and whether the sink accepted it.
+func RecordCloudEventSent(eventType string, accepted bool) {
+	result := "failure"
+	if accepted {
+		result = "success"
+	}
+	cloudEventsSentTotal.WithLabelValues(eventType, result).Inc()
+}
+
+// RecordCloudEventDropped records that a CloudEvent of the type was dropped because the emitter's buffer was full.
+func RecordCloudEventDropped(eventType string) {
+	cloudEventsDroppedTotal.WithLabelValues(eventType).Inc()
+}
+
+// AuditRecordDropReason is why an audit record was dropped without being written to a sink.
+type AuditRecordDropReason string
+
+const (
+	// AuditRecordDropBufferFull is used when the sink's buffer was full when the record was recorded.
+	AuditRecordDropBufferFull AuditRecordDropReason = "buffer_full"
+	// AuditRecordDropWriteFailed is used when writing the record failed on every retry.
+	AuditRecordDropWriteFailed AuditRecordDropReason = "write_failed"
+)
+
+// RecordAuditRecordsDropped records that count audit records were dropped without being written to the sink.
+func RecordAuditRecordsDropped(sink string, reason AuditRecordDropReason, count int) {
+	auditRecordsDroppedTotal.WithLabelValues(sink, string(reason)).Add(float64(count))
+}
+
 // RecordWebRequestCommitStatusHTTPRequest records count and duration for a completed outbound HTTP
 // round-trip (Do succeeded, response read). responseCode is the HTTP status from the response;
 // duration is elapsed time from Do through finishing the body read.
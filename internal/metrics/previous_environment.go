@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+var (
+	// previousEnvironmentCopiesMutex protects previousEnvironmentCopyEnvironments.
+	previousEnvironmentCopiesMutex sync.Mutex
+	// previousEnvironmentCopyEnvironments holds the environments of each PromotionStrategy that the live copies gauge
+	// was last set for, so that the series of removed environments can be deleted.
+	previousEnvironmentCopyEnvironments = map[promotionStrategyKey][]string{}
+)
+
+// SetPreviousEnvironmentLiveCopies sets the number of previous environment commit statuses that exist for each
+// environment of the PromotionStrategy, by branch, and removes the gauges of the environments that are no longer in
+// liveCopies.
+func SetPreviousEnvironmentLiveCopies(ps *v1alpha1.PromotionStrategy, liveCopies map[string]int) {
+	key := promotionStrategyKey{namespace: ps.Namespace, name: ps.Name}
+
+	previousEnvironmentCopiesMutex.Lock()
+	defer previousEnvironmentCopiesMutex.Unlock()
+
+	environments := make([]string, 0, len(liveCopies))
+	for environment, count := range liveCopies {
+		environments = append(environments, environment)
+		previousEnvironmentCommitStatusLiveCopies.WithLabelValues(ps.Namespace, ps.Name, environment).Set(float64(count))
+	}
+	for _, environment := range previousEnvironmentCopyEnvironments[key] {
+		if _, ok := liveCopies[environment]; !ok {
+			previousEnvironmentCommitStatusLiveCopies.DeleteLabelValues(ps.Namespace, ps.Name, environment)
+		}
+	}
+
+	if len(environments) == 0 {
+		delete(previousEnvironmentCopyEnvironments, key)
+		return
+	}
+	previousEnvironmentCopyEnvironments[key] = environments
+}
+
+// deletePreviousEnvironmentCopies removes the previous environment commit status metrics of a PromotionStrategy.
+func deletePreviousEnvironmentCopies(namespace, name string) {
+	previousEnvironmentCopiesMutex.Lock()
+	defer previousEnvironmentCopiesMutex.Unlock()
+
+	delete(previousEnvironmentCopyEnvironments, promotionStrategyKey{namespace: namespace, name: name})
+	labels := prometheus.Labels{"namespace": namespace, "promotion_strategy": name}
+	previousEnvironmentCommitStatusCopiesTotal.DeletePartialMatch(labels)
+	previousEnvironmentCommitStatusLiveCopies.DeletePartialMatch(labels)
+}
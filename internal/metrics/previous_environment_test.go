@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+var _ = Describe("Previous environment commit status metrics", func() {
+	ps := &v1alpha1.PromotionStrategy{ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "copies"}}
+
+	liveCopies := func(environment string) float64 {
+		return testutil.ToFloat64(previousEnvironmentCommitStatusLiveCopies.WithLabelValues("team", "copies", environment))
+	}
+
+	AfterEach(func() {
+		DeletePromotionStrategyMetrics("team", "copies")
+	})
+
+	It("clears the gauges of removed environments and the metrics of deleted PromotionStrategies", func() {
+		SetPreviousEnvironmentLiveCopies(ps, map[string]int{"environment/dev": 0, "environment/staging": 1, "environment/production": 1})
+		Expect(testutil.CollectAndCount(previousEnvironmentCommitStatusLiveCopies)).To(Equal(3))
+		Expect(liveCopies("environment/staging")).To(Equal(1.0))
+
+		SetPreviousEnvironmentLiveCopies(ps, map[string]int{"environment/dev": 0, "environment/production": 0})
+		Expect(testutil.CollectAndCount(previousEnvironmentCommitStatusLiveCopies)).To(Equal(2))
+		Expect(liveCopies("environment/production")).To(BeZero())
+
+		RecordPreviousEnvironmentCopy(ps, PreviousEnvironmentCopyCreated)
+		Expect(testutil.ToFloat64(previousEnvironmentCommitStatusCopiesTotal.WithLabelValues("team", "copies", "created"))).To(Equal(1.0))
+
+		DeletePromotionStrategyMetrics("team", "copies")
+		Expect(testutil.CollectAndCount(previousEnvironmentCommitStatusLiveCopies)).To(BeZero())
+		Expect(testutil.CollectAndCount(previousEnvironmentCommitStatusCopiesTotal)).To(BeZero())
+	})
+})
@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
+)
+
+// SCMHTTPOperation is the operation an HTTP request of an SCM client performs, as far as it can be told from the
+// request's method and path.
+type SCMHTTPOperation string
+
+const (
+	// SCMHTTPOperationCreatePR is used for requests that open a pull request.
+	SCMHTTPOperationCreatePR SCMHTTPOperation = "create_pr"
+	// SCMHTTPOperationMergePR is used for requests that merge a pull request.
+	SCMHTTPOperationMergePR SCMHTTPOperation = "merge_pr"
+	// SCMHTTPOperationPostStatus is used for requests that set a commit status.
+	SCMHTTPOperationPostStatus SCMHTTPOperation = "post_status"
+	// SCMHTTPOperationFindOpen is used for requests that list pull requests, which is how open pull requests are found.
+	SCMHTTPOperationFindOpen SCMHTTPOperation = "find_open"
+	// SCMHTTPOperationOther is used for all the other requests.
+	SCMHTTPOperationOther SCMHTTPOperation = "other"
+)
+
+// pullRequestCollections are the last path segments of the pull request endpoints of the SCMs: pulls for GitHub, Gitea
+// and Forgejo, merge_requests for GitLab and pullrequests for Bitbucket Cloud.
+var pullRequestCollections = []string{"pulls", "merge_requests", "pullrequests"}
+
+// scmTransport is an http.RoundTripper that records the requests an SCM client sends and the rate limits the SCM
+// reports in its responses.
+type scmTransport struct {
+	provider string
+	base     http.RoundTripper
+}
+
+// NewSCMTransport returns an http.RoundTripper that sends requests with base, or http.DefaultTransport if base is nil,
+// and records them in the scm_http_* metrics with the provider label and in a span. Unlike RecordSCMCall, it counts
+// every request a client sends, including retries, pagination and token refreshes.
+func NewSCMTransport(provider string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &scmTransport{provider: provider, base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *scmTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	operation := string(ClassifySCMHTTPRequest(req.Method, req.URL.Path))
+
+	ctx, span := tracing.Tracer().Start(req.Context(), "SCM "+req.Method+" "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("scm.provider", t.provider),
+			attribute.String("scm.operation", operation),
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", host),
+			attribute.String("url.path", req.URL.Path),
+		),
+	)
+	req = req.WithContext(ctx)
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	scmHTTPRequestDurationSeconds.WithLabelValues(t.provider, host, operation).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		scmHTTPRequestsTotal.WithLabelValues(t.provider, host, operation, "error").Inc()
+		tracing.EndSpan(span, err)
+		return resp, err //nolint:wrapcheck // the SCM client wraps the errors of its transport
+	}
+	scmHTTPRequestsTotal.WithLabelValues(t.provider, host, operation, strconv.Itoa(resp.StatusCode)).Inc()
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	span.End()
+
+	if remaining, ok := rateLimitHeader(resp.Header, "Remaining"); ok {
+		scmHTTPRateLimitRemaining.WithLabelValues(t.provider, host).Set(remaining)
+	}
+	if reset, ok := rateLimitHeader(resp.Header, "Reset"); ok {
+		scmHTTPRateLimitResetTimestampSeconds.WithLabelValues(t.provider, host).Set(reset)
+	}
+	return resp, nil
+}
+
+// rateLimitHeader returns the number in the X-RateLimit-<name> header, which GitHub sends, or else in the
+// RateLimit-<name> header, which GitLab sends.
+func rateLimitHeader(header http.Header, name string) (float64, bool) {
+	value := header.Get("X-RateLimit-" + name)
+	if value == "" {
+		value = header.Get("RateLimit-" + name)
+	}
+	if value == "" {
+		return 0, false
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return number, true
+}
+
+// ClassifySCMHTTPRequest returns the operation of a request to an SCM API from its method and URL path.
+func ClassifySCMHTTPRequest(method, path string) SCMHTTPOperation {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	n := len(segments)
+	isPullRequests := func(i int) bool {
+		return i >= 0 && slices.Contains(pullRequestCollections, segments[i])
+	}
+
+	switch {
+	case method == http.MethodPost && isPullRequests(n-1):
+		return SCMHTTPOperationCreatePR
+	case method == http.MethodGet && isPullRequests(n-1):
+		return SCMHTTPOperationFindOpen
+	case (method == http.MethodPut || method == http.MethodPost) && segments[n-1] == "merge" && isPullRequests(n-3):
+		return SCMHTTPOperationMergePR
+	case method == http.MethodPost && n >= 2 && segments[n-2] == "statuses":
+		// GitHub, GitLab, Gitea and Forgejo post to statuses/{sha}, Bitbucket Cloud to commit/{sha}/statuses/build.
+		return SCMHTTPOperationPostStatus
+	default:
+		return SCMHTTPOperationOther
+	}
+}
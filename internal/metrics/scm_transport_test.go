@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("SCM transport", func() {
+	It("records requests by provider, host and operation, and the rate limit headers", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("RateLimit-Remaining", "1999")
+			w.Header().Set("RateLimit-Reset", "1700000000")
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusCreated)
+			}
+		}))
+		defer server.Close()
+		serverURL, err := url.Parse(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		host := serverURL.Host
+
+		client := &http.Client{Transport: NewSCMTransport("gitlab", nil)}
+		resp, err := client.Post(server.URL+"/api/v4/projects/group%2Frepo/merge_requests", "application/json", strings.NewReader("{}"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+		resp, err = client.Get(server.URL + "/api/v4/projects/group%2Frepo/merge_requests?state=opened")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+
+		Expect(testutil.ToFloat64(scmHTTPRequestsTotal.WithLabelValues("gitlab", host, "create_pr", "201"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(scmHTTPRequestsTotal.WithLabelValues("gitlab", host, "find_open", "200"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(scmHTTPRateLimitRemaining.WithLabelValues("gitlab", host))).To(Equal(1999.0))
+		Expect(testutil.ToFloat64(scmHTTPRateLimitResetTimestampSeconds.WithLabelValues("gitlab", host))).To(Equal(1700000000.0))
+		Expect(testutil.CollectAndCount(scmHTTPRequestDurationSeconds, "scm_http_request_duration_seconds")).To(BeNumerically(">=", 2))
+	})
+
+	It("records requests that fail without a response", func() {
+		server := httptest.NewServer(http.NotFoundHandler())
+		serverURL, err := url.Parse(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		server.Close()
+
+		client := &http.Client{Transport: NewSCMTransport("github", nil)}
+		_, err = client.Post(server.URL+"/repos/owner/repo/statuses/abc123", "application/json", strings.NewReader("{}"))
+		Expect(err).To(HaveOccurred())
+
+		Expect(testutil.ToFloat64(scmHTTPRequestsTotal.WithLabelValues("github", serverURL.Host, "post_status", "error"))).To(Equal(1.0))
+	})
+
+	DescribeTable("classifies the requests of each SCM",
+		func(method, path string, operation SCMHTTPOperation) {
+			Expect(ClassifySCMHTTPRequest(method, path)).To(Equal(operation))
+		},
+		Entry("GitHub create", http.MethodPost, "/repos/owner/repo/pulls", SCMHTTPOperationCreatePR),
+		Entry("GitHub list", http.MethodGet, "/repos/owner/repo/pulls", SCMHTTPOperationFindOpen),
+		Entry("GitHub merge", http.MethodPut, "/repos/owner/repo/pulls/12/merge", SCMHTTPOperationMergePR),
+		Entry("GitHub status", http.MethodPost, "/repos/owner/repo/statuses/abc123", SCMHTTPOperationPostStatus),
+		Entry("GitHub update", http.MethodPatch, "/repos/owner/repo/pulls/12", SCMHTTPOperationOther),
+		Entry("GitLab merge", http.MethodPut, "/api/v4/projects/group/repo/merge_requests/3/merge", SCMHTTPOperationMergePR),
+		Entry("GitLab status", http.MethodPost, "/api/v4/projects/group/repo/statuses/abc123", SCMHTTPOperationPostStatus),
+		Entry("Gitea merge", http.MethodPost, "/api/v1/repos/owner/repo/pulls/4/merge", SCMHTTPOperationMergePR),
+		Entry("Bitbucket Cloud create", http.MethodPost, "/2.0/repositories/workspace/repo/pullrequests", SCMHTTPOperationCreatePR),
+		Entry("Bitbucket Cloud status", http.MethodPost, "/2.0/repositories/workspace/repo/commit/abc123/statuses/build", SCMHTTPOperationPostStatus),
+		Entry("GitHub token", http.MethodPost, "/app/installations/1/access_tokens", SCMHTTPOperationOther),
+	)
+})
@@ -0,0 +1,167 @@
+package metrics
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	authenticationv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/client-go/rest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+const (
+	// allowedTTL and deniedTTL are how long the decision for a token, path and verb is cached, so that each scrape
+	// doesn't review the token again.
+	allowedTTL = time.Minute
+	deniedTTL  = 10 * time.Second
+)
+
+// FilterProvider is the type of metricsserver.Options.FilterProvider.
+type FilterProvider = func(*rest.Config, *http.Client) (metricsserver.Filter, error)
+
+// AuthorizedFilterProvider returns a metrics FilterProvider that only lets through the requests whose bearer token the
+// API server authenticates with a TokenReview, and whose user is allowed to get the request's path by a
+// SubjectAccessReview, such as with the metrics-reader ClusterRole. The other requests are rejected with 401 or 403.
+// The requests are then handled by the filter of provider, which also sees the rejected requests.
+func AuthorizedFilterProvider(provider FilterProvider) FilterProvider {
+	return func(config *rest.Config, httpClient *http.Client) (metricsserver.Filter, error) {
+		clientset, err := kubernetes.NewForConfigAndClient(config, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create metrics authentication and authorization client: %w", err)
+		}
+		filter, err := provider(config, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		authorizer := newRequestAuthorizer(clientset.AuthenticationV1().TokenReviews(), clientset.AuthorizationV1().SubjectAccessReviews())
+		return func(log logr.Logger, h http.Handler) (http.Handler, error) {
+			return filter(log, authorizer.filter(log, h))
+		}, nil
+	}
+}
+
+// requestAuthorizer authenticates and authorizes the requests to the metrics server with the API server.
+type requestAuthorizer struct {
+	tokenReviews         authenticationv1client.TokenReviewInterface
+	subjectAccessReviews authorizationv1client.SubjectAccessReviewInterface
+	now                  func() time.Time
+	mutex                sync.Mutex
+	decisions            map[decisionKey]decision
+}
+
+// decisionKey identifies a decision by the hash of the request's token, and its path and verb.
+type decisionKey struct {
+	token [sha256.Size]byte
+	path  string
+	verb  string
+}
+
+// decision is the status code a request is rejected with, or http.StatusOK, until expiry.
+type decision struct {
+	status int
+	reason string
+	expiry time.Time
+}
+
+func newRequestAuthorizer(tokenReviews authenticationv1client.TokenReviewInterface, subjectAccessReviews authorizationv1client.SubjectAccessReviewInterface) *requestAuthorizer {
+	return &requestAuthorizer{
+		tokenReviews:         tokenReviews,
+		subjectAccessReviews: subjectAccessReviews,
+		now:                  time.Now,
+		decisions:            map[decisionKey]decision{},
+	}
+}
+
+// filter returns a handler that rejects the requests that aren't authorized, and passes the others to h.
+func (a *requestAuthorizer) filter(log logr.Logger, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d := a.decide(r)
+		if d.status != http.StatusOK {
+			log.V(4).Info("rejected metrics HTTP request", "path", r.URL.Path, "remoteAddr", r.RemoteAddr, "reason", d.reason)
+			http.Error(w, http.StatusText(d.status), d.status)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// decide returns whether r is authorized, from the cache if it was decided recently.
+func (a *requestAuthorizer) decide(r *http.Request) decision {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	token = strings.TrimSpace(token)
+	if !ok || token == "" {
+		return decision{status: http.StatusUnauthorized, reason: "missing bearer token"}
+	}
+
+	key := decisionKey{token: sha256.Sum256([]byte(token)), path: r.URL.Path, verb: strings.ToLower(r.Method)}
+	now := a.now()
+	a.mutex.Lock()
+	d, ok := a.decisions[key]
+	a.mutex.Unlock()
+	if ok && now.Before(d.expiry) {
+		return d
+	}
+
+	d = a.review(r, token, key)
+	if d.status == http.StatusOK {
+		d.expiry = now.Add(allowedTTL)
+	} else {
+		d.expiry = now.Add(deniedTTL)
+	}
+	a.mutex.Lock()
+	for k, cached := range a.decisions {
+		if !now.Before(cached.expiry) {
+			delete(a.decisions, k)
+		}
+	}
+	a.decisions[key] = d
+	a.mutex.Unlock()
+	return d
+}
+
+// review authenticates token with a TokenReview and authorizes its user to request key's path with key's verb with a
+// SubjectAccessReview.
+func (a *requestAuthorizer) review(r *http.Request, token string, key decisionKey) decision {
+	tokenReview, err := a.tokenReviews.Create(r.Context(), &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return decision{status: http.StatusUnauthorized, reason: fmt.Sprintf("failed to review token: %v", err)}
+	}
+	if !tokenReview.Status.Authenticated {
+		return decision{status: http.StatusUnauthorized, reason: "token not authenticated: " + tokenReview.Status.Error}
+	}
+
+	user := tokenReview.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	subjectAccessReview, err := a.subjectAccessReviews.Create(r.Context(), &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:                  user.Username,
+			UID:                   user.UID,
+			Groups:                user.Groups,
+			Extra:                 extra,
+			NonResourceAttributes: &authorizationv1.NonResourceAttributes{Path: key.path, Verb: key.verb},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return decision{status: http.StatusForbidden, reason: fmt.Sprintf("failed to review access of %s: %v", user.Username, err)}
+	}
+	if !subjectAccessReview.Status.Allowed {
+		return decision{status: http.StatusForbidden, reason: fmt.Sprintf("%s is not allowed to %s %s: %s",
+			user.Username, key.verb, key.path, subjectAccessReview.Status.Reason)}
+	}
+	return decision{status: http.StatusOK}
+}
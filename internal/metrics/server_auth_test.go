@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var _ = Describe("requestAuthorizer", func() {
+	var (
+		reviews int
+		now     time.Time
+		handler http.Handler
+	)
+
+	BeforeEach(func() {
+		reviews = 0
+		now = time.Now()
+		clientset := fake.NewClientset()
+		clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			reviews++
+			review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+			switch review.Spec.Token {
+			case "prometheus":
+				review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: "system:serviceaccount:monitoring:prometheus"}}
+			case "other":
+				review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: "system:serviceaccount:default:other"}}
+			}
+			return true, review, nil
+		})
+		clientset.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+			review.Status.Allowed = review.Spec.User == "system:serviceaccount:monitoring:prometheus" &&
+				review.Spec.NonResourceAttributes.Path == "/metrics" && review.Spec.NonResourceAttributes.Verb == "get"
+			return true, review, nil
+		})
+
+		authorizer := newRequestAuthorizer(clientset.AuthenticationV1().TokenReviews(), clientset.AuthorizationV1().SubjectAccessReviews())
+		authorizer.now = func() time.Time { return now }
+		handler = authorizer.filter(logr.Discard(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("metrics"))
+		}))
+	})
+
+	scrape := func(token string) int {
+		request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	It("rejects requests without a bearer token", func() {
+		Expect(scrape("")).To(Equal(http.StatusUnauthorized))
+		Expect(reviews).To(BeZero())
+	})
+
+	It("rejects requests whose token isn't authenticated", func() {
+		Expect(scrape("invalid")).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("rejects requests whose user isn't allowed to get the metrics", func() {
+		Expect(scrape("other")).To(Equal(http.StatusForbidden))
+	})
+
+	It("serves the requests whose user is allowed to get the metrics, reviewing the token once a minute", func() {
+		Expect(scrape("prometheus")).To(Equal(http.StatusOK))
+		Expect(scrape("prometheus")).To(Equal(http.StatusOK))
+		Expect(reviews).To(Equal(1))
+
+		now = now.Add(allowedTTL)
+		Expect(scrape("prometheus")).To(Equal(http.StatusOK))
+		Expect(reviews).To(Equal(2))
+	})
+})
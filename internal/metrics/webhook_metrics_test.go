@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("Webhook receiver metrics", func() {
+	It("records events per provider, event and outcome", func() {
+		RecordWebhookEvent("gitlab", "Push Hook", WebhookEventAccepted, 10*time.Millisecond)
+		RecordWebhookEvent("gitlab", "Push Hook", WebhookEventAccepted, 20*time.Millisecond)
+		RecordWebhookEvent("gitlab", "Push Hook", WebhookEventIgnored, time.Millisecond)
+		RecordWebhookEvent("gitlab", "other", WebhookEventIgnored, time.Millisecond)
+
+		Expect(testutil.ToFloat64(webhookEventsTotal.WithLabelValues("gitlab", "Push Hook", "accepted"))).To(Equal(2.0))
+		Expect(testutil.ToFloat64(webhookEventsTotal.WithLabelValues("gitlab", "Push Hook", "ignored"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(webhookEventsTotal.WithLabelValues("gitlab", "other", "ignored"))).To(Equal(1.0))
+		Expect(testutil.CollectAndCount(webhookHandlerDurationSeconds, "webhook_handler_duration_seconds")).To(BeNumerically(">=", 2))
+	})
+
+	It("records recovered panics per provider", func() {
+		RecordWebhookHandlerPanic("bitbucketCloud")
+		Expect(testutil.ToFloat64(webhookHandlerPanicsTotal.WithLabelValues("bitbucketCloud"))).To(Equal(1.0))
+	})
+
+	It("sets the routes per index", func() {
+		SetWebhookRoutes(3, 12, 7)
+		Expect(testutil.ToFloat64(webhookRoutes.WithLabelValues("repositories"))).To(Equal(3.0))
+		Expect(testutil.ToFloat64(webhookRoutes.WithLabelValues("branches"))).To(Equal(12.0))
+		Expect(testutil.ToFloat64(webhookRoutes.WithLabelValues("hydrated_shas"))).To(Equal(7.0))
+	})
+})
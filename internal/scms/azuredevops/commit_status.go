@@ -50,6 +50,7 @@ func (cs CommitStatus) Set(ctx context.Context, commitStatus *v1alpha1.CommitSta
 	if err != nil {
 		return nil, fmt.Errorf("failed to get GitRepository: %w", err)
 	}
+	project, repository := repositoryArgs(gitRepo)
 
 	scmProvider, _, err := utils.GetScmProviderAndSecretFromRepositoryReference(
 		ctx,
@@ -63,10 +64,7 @@ func (cs CommitStatus) Set(ctx context.Context, commitStatus *v1alpha1.CommitSta
 	}
 
 	// Get Git client from Azure DevOps connection
-	gitClient, err := git.NewClient(ctx, cs.client)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Git client: %w", err)
-	}
+	gitClient := newGitClient(cs.client)
 
 	// Map GitOps Promoter status phase to Azure DevOps status state
 	var state git.GitStatusState
@@ -103,8 +101,8 @@ func (cs CommitStatus) Set(ctx context.Context, commitStatus *v1alpha1.CommitSta
 	// Repository identifier should be the repository name for Azure DevOps
 	createdStatus, err := gitClient.CreateCommitStatus(ctx, git.CreateCommitStatusArgs{
 		CommitId:                &commitStatus.Spec.Sha,
-		RepositoryId:            &gitRepo.Spec.AzureDevOps.Name,
-		Project:                 &gitRepo.Spec.AzureDevOps.Project,
+		RepositoryId:            repository,
+		Project:                 project,
 		GitCommitStatusToCreate: &gitCommitStatus,
 	})
 
@@ -146,6 +144,9 @@ func mapAzureDevOpsStateToPhase(state git.GitStatusState) v1alpha1.CommitStatusP
 }
 
 func createCommitURL(repo *v1alpha1.GitRepository, org string, sha string) string {
+	if webURL := scms.RepositoryWebUrl(*repo); webURL != "" {
+		return fmt.Sprintf("%s/commit/%s", webURL, sha)
+	}
 	return fmt.Sprintf("https://%s/%s/%s/_git/%s/commit/%s",
 		azureDevopsDomain,
 		org,
@@ -0,0 +1,41 @@
+package azuredevops
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+// Credentials implements the scms.CredentialsChecker interface for Azure DevOps.
+type Credentials struct {
+	client core.Client
+}
+
+var _ scms.CredentialsChecker = &Credentials{}
+
+// NewAzureDevopsCredentialsChecker creates a new instance of Credentials for the organization of the SCM provider.
+func NewAzureDevopsCredentialsChecker(ctx context.Context, scmProvider v1alpha1.GenericScmProvider, secret v1.Secret) (*Credentials, error) {
+	connection, _, err := GetClient(ctx, scmProvider, secret, scmProvider.GetSpec().AzureDevOps.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credentials{client: newCoreClient(connection)}, nil
+}
+
+// CheckCredentials lists a project of the organization from the Azure DevOps API.
+func (c *Credentials) CheckCredentials(ctx context.Context) error {
+	if _, err := c.client.GetProjects(ctx, core.GetProjectsArgs{Top: ptr.To(1)}); err != nil {
+		if status := errorStatusCode(err); status == http.StatusUnauthorized || status == http.StatusForbidden {
+			return &scms.CredentialsRejectedError{Message: err.Error()}
+		}
+		return err //nolint:wrapcheck // Error wrapping handled at top level
+	}
+	return nil
+}
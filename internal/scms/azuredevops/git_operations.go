@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -18,6 +22,9 @@ const (
 	azureDevOpsScope = "499b84ac-1321-427f-aa17-267ca6975798/.default"
 )
 
+// httpClient sends the requests to Azure DevOps so that they are instrumented.
+var httpClient = &http.Client{Transport: metrics.NewSCMTransport("azureDevOps", nil)}
+
 // GitAuthenticationProvider provides methods to authenticate with Azure DevOps.
 type GitAuthenticationProvider struct {
 	scmProvider v1alpha1.GenericScmProvider
@@ -106,6 +113,22 @@ func GetClient(ctx context.Context, scmProvider v1alpha1.GenericScmProvider, sec
 	return connection, &authProvider, nil
 }
 
+// newGitClient creates a git client for the organization of the connection. The Azure DevOps clients of the SDK use an
+// uninstrumented http.Client, so the client is created with httpClient instead. The git API is served from the
+// organization URL, so it isn't looked up from the resource areas of the organization.
+func newGitClient(connection *azuredevops.Connection) git.Client {
+	return &git.ClientImpl{
+		Client: *azuredevops.NewClientWithOptions(connection, connection.BaseUrl, azuredevops.WithHTTPClient(httpClient)),
+	}
+}
+
+// newCoreClient creates a core client for the organization of the connection, see newGitClient.
+func newCoreClient(connection *azuredevops.Connection) core.Client {
+	return &core.ClientImpl{
+		Client: *azuredevops.NewClientWithOptions(connection, connection.BaseUrl, azuredevops.WithHTTPClient(httpClient)),
+	}
+}
+
 // createPATConnection creates an Azure DevOps connection using PAT authentication
 func createPATConnection(scmProvider v1alpha1.GenericScmProvider, secret v1.Secret, organizationUrl string) (*azuredevops.Connection, GitAuthenticationProvider, error) {
 	// Use PAT authentication
@@ -29,8 +29,8 @@ type PullRequest struct {
 var _ scms.PullRequestProvider = &PullRequest{}
 
 // NewAzdoPullRequestProvider creates a new instance of PullRequest for Azure DevOps.
-func NewAzdoPullRequestProvider(k8sClient client.Client, secret v1.Secret, scmProvider v1alpha1.GenericScmProvider, org string) (*PullRequest, error) {
-	prClient, _, err := GetClient(context.Background(), scmProvider, secret, org)
+func NewAzdoPullRequestProvider(ctx context.Context, k8sClient client.Client, secret v1.Secret, scmProvider v1alpha1.GenericScmProvider, org string) (*PullRequest, error) {
+	prClient, _, err := GetClient(ctx, scmProvider, secret, org)
 	if err != nil {
 		return nil, err
 	}
@@ -54,12 +54,10 @@ func (pr *PullRequest) Create(ctx context.Context, title, head, base, descriptio
 	if err != nil {
 		return "", fmt.Errorf("failed to get GitRepository: %w", err)
 	}
+	project, repository := repositoryArgs(gitRepo)
 
 	// Get Git client
-	gitClient, err := git.NewClient(ctx, pr.client)
-	if err != nil {
-		return "", fmt.Errorf("failed to create Git client: %w", err)
-	}
+	gitClient := newGitClient(pr.client)
 
 	sourceRef, targetRef, err := getFormattedRefs(head, base)
 	if err != nil {
@@ -77,8 +75,8 @@ func (pr *PullRequest) Create(ctx context.Context, title, head, base, descriptio
 	start := time.Now()
 	createdPR, err := gitClient.CreatePullRequest(ctx, git.CreatePullRequestArgs{
 		GitPullRequestToCreate: &gitPullRequest,
-		RepositoryId:           &gitRepo.Spec.AzureDevOps.Name,
-		Project:                &gitRepo.Spec.AzureDevOps.Project,
+		RepositoryId:           repository,
+		Project:                project,
 	})
 
 	// Record metrics and handle response
@@ -105,6 +103,7 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pu
 	if err != nil {
 		return fmt.Errorf("failed to get GitRepository: %w", err)
 	}
+	project, repository := repositoryArgs(gitRepo)
 
 	prId, err := strconv.Atoi(pullRequest.Status.ID)
 	if err != nil {
@@ -112,10 +111,7 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pu
 	}
 
 	// Get Git client
-	gitClient, err := git.NewClient(ctx, pr.client)
-	if err != nil {
-		return fmt.Errorf("failed to create Git client: %w", err)
-	}
+	gitClient := newGitClient(pr.client)
 
 	// Update Git pull request
 	gitPullRequest := git.GitPullRequest{
@@ -126,9 +122,9 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pu
 	start := time.Now()
 	_, err = gitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
 		GitPullRequestToUpdate: &gitPullRequest,
-		RepositoryId:           &gitRepo.Spec.AzureDevOps.Name,
+		RepositoryId:           repository,
 		PullRequestId:          &prId,
-		Project:                &gitRepo.Spec.AzureDevOps.Project,
+		Project:                project,
 	})
 
 	// Record metrics and handle response
@@ -155,6 +151,7 @@ func (pr *PullRequest) Close(ctx context.Context, pullRequest v1alpha1.PullReque
 	if err != nil {
 		return fmt.Errorf("failed to get GitRepository: %w", err)
 	}
+	project, repository := repositoryArgs(gitRepo)
 
 	prId, err := strconv.Atoi(pullRequest.Status.ID)
 	if err != nil {
@@ -162,10 +159,7 @@ func (pr *PullRequest) Close(ctx context.Context, pullRequest v1alpha1.PullReque
 	}
 
 	// Get Git client
-	gitClient, err := git.NewClient(ctx, pr.client)
-	if err != nil {
-		return fmt.Errorf("failed to create Git client: %w", err)
-	}
+	gitClient := newGitClient(pr.client)
 
 	// Close pull request by setting status to abandoned
 	status := git.PullRequestStatusValues.Abandoned
@@ -176,9 +170,9 @@ func (pr *PullRequest) Close(ctx context.Context, pullRequest v1alpha1.PullReque
 	start := time.Now()
 	_, err = gitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
 		GitPullRequestToUpdate: &gitPullRequest,
-		RepositoryId:           &gitRepo.Spec.AzureDevOps.Name,
+		RepositoryId:           repository,
 		PullRequestId:          &prId,
-		Project:                &gitRepo.Spec.AzureDevOps.Project,
+		Project:                project,
 	})
 
 	// Record metrics and handle response
@@ -205,6 +199,7 @@ func (pr *PullRequest) Merge(ctx context.Context, pullRequest v1alpha1.PullReque
 	if err != nil {
 		return fmt.Errorf("failed to get GitRepository: %w", err)
 	}
+	project, repository := repositoryArgs(gitRepo)
 
 	prId, err := strconv.Atoi(pullRequest.Status.ID)
 	if err != nil {
@@ -212,15 +207,16 @@ func (pr *PullRequest) Merge(ctx context.Context, pullRequest v1alpha1.PullReque
 	}
 
 	// Get Git client
-	gitClient, err := git.NewClient(ctx, pr.client)
-	if err != nil {
-		return fmt.Errorf("failed to create Git client: %w", err)
-	}
+	gitClient := newGitClient(pr.client)
 
 	// Complete the pull request (merge it) using Azure DevOps completion API
 	completionOptions := git.GitPullRequestCompletionOptions{
 		MergeCommitMessage: &pullRequest.Spec.Commit.Message,
 		DeleteSourceBranch: &[]bool{false}[0], // Keep source branch by default
+		MergeStrategy:      &git.GitPullRequestMergeStrategyValues.NoFastForward,
+	}
+	if pullRequest.Spec.MergeStrategy == v1alpha1.PullRequestMergeStrategySquash {
+		completionOptions.MergeStrategy = &git.GitPullRequestMergeStrategyValues.Squash
 	}
 
 	// Set merge status to completed
@@ -234,9 +230,9 @@ func (pr *PullRequest) Merge(ctx context.Context, pullRequest v1alpha1.PullReque
 	start := time.Now()
 	_, err = gitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
 		GitPullRequestToUpdate: &gitPullRequest,
-		RepositoryId:           &gitRepo.Spec.AzureDevOps.Name,
+		RepositoryId:           repository,
 		PullRequestId:          &prId,
-		Project:                &gitRepo.Spec.AzureDevOps.Project,
+		Project:                project,
 	})
 
 	// Record metrics and handle response
@@ -263,12 +259,10 @@ func (pr *PullRequest) FindOpen(ctx context.Context, pullRequest v1alpha1.PullRe
 	if err != nil {
 		return false, "", time.Time{}, fmt.Errorf("failed to get GitRepository: %w", err)
 	}
+	project, repository := repositoryArgs(gitRepo)
 
 	// Get Git client
-	gitClient, err := git.NewClient(ctx, pr.client)
-	if err != nil {
-		return false, "", time.Time{}, fmt.Errorf("failed to create Git client: %w", err)
-	}
+	gitClient := newGitClient(pr.client)
 
 	// Ensure branch names are in correct format for Azure DevOps
 	sourceRef := ensureRefsFormat(pullRequest.Spec.SourceBranch)
@@ -283,8 +277,8 @@ func (pr *PullRequest) FindOpen(ctx context.Context, pullRequest v1alpha1.PullRe
 
 	start := time.Now()
 	pullRequests, err := gitClient.GetPullRequests(ctx, git.GetPullRequestsArgs{
-		RepositoryId:   &gitRepo.Spec.AzureDevOps.Name,
-		Project:        &gitRepo.Spec.AzureDevOps.Project,
+		RepositoryId:   repository,
+		Project:        project,
 		SearchCriteria: &searchCriteria,
 	})
 
@@ -337,6 +331,10 @@ func (pr *PullRequest) generatePullRequestUrl(ctx context.Context, prObj v1alpha
 	if err != nil {
 		return "", fmt.Errorf("failed to get GitRepository: %w", err)
 	}
+	if webURL := scms.RepositoryWebUrl(*gitRepo); webURL != "" {
+		return fmt.Sprintf("%s/pullrequest/%d", webURL, prId), nil
+	}
+
 	scmProvider, _, err := utils.GetScmProviderAndSecretFromRepositoryReference(ctx, pr.k8sClient, "", prObj.Spec.RepositoryReference, &prObj)
 	if err != nil {
 		return "", fmt.Errorf("failed to get SCM provider: %w", err)
@@ -0,0 +1,98 @@
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+// Repository implements the scms.RepositoryProvider interface for Azure DevOps.
+type Repository struct {
+	client *azuredevops.Connection
+}
+
+var _ scms.RepositoryProvider = &Repository{}
+
+// NewAzdoRepositoryProvider creates a new instance of Repository for Azure DevOps.
+func NewAzdoRepositoryProvider(ctx context.Context, scmProvider v1alpha1.GenericScmProvider, secret v1.Secret, org string) (*Repository, error) {
+	client, _, err := GetClient(ctx, scmProvider, secret, org)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{client: client}, nil
+}
+
+// GetRepository gets the repository from the Azure DevOps API by its project and name.
+func (r *Repository) GetRepository(ctx context.Context, gitRepo v1alpha1.GitRepository) (*scms.Repository, error) {
+	gitClient := newGitClient(r.client)
+
+	start := time.Now()
+	repo, err := gitClient.GetRepository(ctx, git.GetRepositoryArgs{
+		RepositoryId: &gitRepo.Spec.AzureDevOps.Name,
+		Project:      &gitRepo.Spec.AzureDevOps.Project,
+	})
+	statusCode := http.StatusOK
+	if err != nil {
+		statusCode = errorStatusCode(err)
+	}
+	metrics.RecordSCMCall(ctx, &gitRepo, metrics.SCMAPIRepository, metrics.SCMOperationGet, statusCode, time.Since(start), nil)
+	if err != nil {
+		switch statusCode {
+		case http.StatusNotFound:
+			return nil, &scms.RepositoryNotFoundError{Message: err.Error()}
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return nil, &scms.RepositoryAccessDeniedError{Message: err.Error()}
+		}
+		return nil, err //nolint:wrapcheck // Error wrapping handled at top level
+	}
+
+	repository := &scms.Repository{
+		// Azure DevOps reports the default branch as a ref, e.g. refs/heads/main.
+		DefaultBranch: strings.TrimPrefix(ptr.Deref(repo.DefaultBranch, ""), "refs/heads/"),
+		HttpsCloneUrl: ptr.Deref(repo.RemoteUrl, ""),
+		SshCloneUrl:   ptr.Deref(repo.SshUrl, ""),
+		Archived:      ptr.Deref(repo.IsDisabled, false),
+	}
+	if repo.Id != nil {
+		repository.ID = repo.Id.String()
+	}
+	if repo.Project != nil && repo.Project.Id != nil {
+		repository.ProjectID = repo.Project.Id.String()
+	}
+	return repository, nil
+}
+
+// repositoryArgs returns the project and repository arguments for the API calls on the GitRepository's repository:
+// the IDs the GitRepository controller looked up for spec.azureDevOps's current project and name, or else the names,
+// which the API accepts too.
+func repositoryArgs(gitRepo *v1alpha1.GitRepository) (project *string, repository *string) {
+	if cached := gitRepo.Status.AzureDevOps; cached != nil && cached.Project == gitRepo.Spec.AzureDevOps.Project && cached.Name == gitRepo.Spec.AzureDevOps.Name {
+		return &cached.ProjectID, &cached.RepositoryID
+	}
+	return &gitRepo.Spec.AzureDevOps.Project, &gitRepo.Spec.AzureDevOps.Name
+}
+
+// errorStatusCode returns the HTTP status code of an error of the Azure DevOps API, or 500 if it has none.
+func errorStatusCode(err error) int {
+	var wrappedErr *azuredevops.WrappedError
+	if errors.As(err, &wrappedErr) && wrappedErr.StatusCode != nil {
+		return *wrappedErr.StatusCode
+	}
+	var wrappedValueErr azuredevops.WrappedError
+	if errors.As(err, &wrappedValueErr) && wrappedValueErr.StatusCode != nil {
+		return *wrappedValueErr.StatusCode
+	}
+	return http.StatusInternalServerError
+}
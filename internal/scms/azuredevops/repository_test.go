@@ -0,0 +1,55 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+func TestRepositoryArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		status             *v1alpha1.AzureDevOpsRepositoryStatus
+		expectedProject    string
+		expectedRepository string
+	}{
+		{
+			name:               "no IDs looked up yet",
+			expectedProject:    "myproject",
+			expectedRepository: "myrepo",
+		},
+		{
+			name: "IDs looked up for the current names",
+			status: &v1alpha1.AzureDevOpsRepositoryStatus{
+				Project: "myproject", Name: "myrepo", ProjectID: "project-guid", RepositoryID: "repo-guid",
+			},
+			expectedProject:    "project-guid",
+			expectedRepository: "repo-guid",
+		},
+		{
+			name: "IDs looked up for a renamed repository",
+			status: &v1alpha1.AzureDevOpsRepositoryStatus{
+				Project: "myproject", Name: "oldrepo", ProjectID: "project-guid", RepositoryID: "repo-guid",
+			},
+			expectedProject:    "myproject",
+			expectedRepository: "myrepo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gitRepo := &v1alpha1.GitRepository{
+				Spec:   v1alpha1.GitRepositorySpec{AzureDevOps: &v1alpha1.AzureDevOpsRepo{Project: "myproject", Name: "myrepo"}},
+				Status: v1alpha1.GitRepositoryStatus{AzureDevOps: tc.status},
+			}
+			project, repository := repositoryArgs(gitRepo)
+			if *project != tc.expectedProject || *repository != tc.expectedRepository {
+				t.Errorf("expected %s/%s, got %s/%s", tc.expectedProject, tc.expectedRepository, *project, *repository)
+			}
+		})
+	}
+}
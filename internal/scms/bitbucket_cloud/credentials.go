@@ -0,0 +1,40 @@
+package bitbucket_cloud
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ktrysmt/go-bitbucket"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+// Credentials implements the scms.CredentialsChecker interface for Bitbucket Cloud.
+type Credentials struct {
+	client *bitbucket.Client
+}
+
+var _ scms.CredentialsChecker = &Credentials{}
+
+// NewBitbucketCloudCredentialsChecker creates a new instance of Credentials for Bitbucket Cloud.
+func NewBitbucketCloudCredentialsChecker(secret v1.Secret) (*Credentials, error) {
+	client, err := GetClient(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credentials{client: client}, nil
+}
+
+// CheckCredentials gets the user the token belongs to from the Bitbucket Cloud API. The Bitbucket client doesn't take
+// a context, so ctx is unused.
+func (c *Credentials) CheckCredentials(_ context.Context) error {
+	if _, err := c.client.User.Profile(); err != nil {
+		if parseErrorStatusCode(err, http.StatusInternalServerError) == http.StatusUnauthorized {
+			return &scms.CredentialsRejectedError{Message: err.Error()}
+		}
+		return err //nolint:wrapcheck // Error wrapping handled at top level
+	}
+	return nil
+}
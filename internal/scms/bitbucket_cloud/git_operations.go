@@ -3,12 +3,14 @@ package bitbucket_cloud
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	"github.com/ktrysmt/go-bitbucket"
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms"
 )
 
@@ -65,6 +67,7 @@ func GetClient(secret v1.Secret) (*bitbucket.Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Bitbucket client: %w", err)
 	}
+	client.HttpClient = &http.Client{Transport: metrics.NewSCMTransport("bitbucketCloud", nil)}
 
 	return client, nil
 }
@@ -190,6 +190,10 @@ func (pr *PullRequest) Merge(ctx context.Context, prObj v1alpha1.PullRequest) er
 		RepoSlug:          repo.Spec.BitbucketCloud.Name,
 		ID:                prObj.Status.ID,
 		CloseSourceBranch: false,
+		MergeStrategy:     bitbucket.MergeCommit,
+	}
+	if prObj.Spec.MergeStrategy == v1alpha1.PullRequestMergeStrategySquash {
+		options.MergeStrategy = bitbucket.Squash
 	}
 
 	start := time.Now()
@@ -311,6 +315,9 @@ func (pr *PullRequest) GetUrl(ctx context.Context, prObj v1alpha1.PullRequest) (
 		return "", fmt.Errorf("failed to get repo: %w", err)
 	}
 
+	if webURL := scms.RepositoryWebUrl(*repo); webURL != "" {
+		return fmt.Sprintf("%s/pull-requests/%s", webURL, prObj.Status.ID), nil
+	}
 	return fmt.Sprintf("https://bitbucket.org/%s/%s/pull-requests/%s",
 		repo.Spec.BitbucketCloud.Owner,
 		repo.Spec.BitbucketCloud.Name,
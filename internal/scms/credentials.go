@@ -0,0 +1,22 @@
+package scms
+
+import "context"
+
+// CredentialsChecker checks the credentials of an ScmProvider or ClusterScmProvider against its SCM, without a
+// repository.
+type CredentialsChecker interface {
+	// CheckCredentials asks the SCM about the account or app the credentials belong to. It returns a
+	// CredentialsRejectedError if the SCM rejected the credentials, and any other error if the request itself failed.
+	CheckCredentials(ctx context.Context) error
+}
+
+// CredentialsRejectedError indicates that the SCM rejected the credentials of the provider.
+type CredentialsRejectedError struct {
+	// Message is the SCM's description of the failure.
+	Message string
+}
+
+// Error implements the error interface for CredentialsRejectedError.
+func (e *CredentialsRejectedError) Error() string {
+	return "credentials rejected: " + e.Message
+}
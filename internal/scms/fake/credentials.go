@@ -0,0 +1,51 @@
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+var (
+	// rejectedCredentials holds the SCM providers whose credentials the fake SCM rejects.
+	rejectedCredentials map[string]bool
+	mutexCredentials    sync.Mutex
+)
+
+// Credentials implements the scms.CredentialsChecker interface for the fake SCM.
+type Credentials struct {
+	key string
+}
+
+var _ scms.CredentialsChecker = &Credentials{}
+
+// NewFakeCredentialsChecker creates a new instance of Credentials for the SCM provider.
+func NewFakeCredentialsChecker(scmProvider v1alpha1.GenericScmProvider) *Credentials {
+	return &Credentials{key: credentialsKey(scmProvider)}
+}
+
+// SetCredentialsRejected makes the fake SCM reject or accept the credentials of the SCM provider.
+func SetCredentialsRejected(scmProvider v1alpha1.GenericScmProvider, rejected bool) {
+	mutexCredentials.Lock()
+	defer mutexCredentials.Unlock()
+	if rejectedCredentials == nil {
+		rejectedCredentials = make(map[string]bool)
+	}
+	rejectedCredentials[credentialsKey(scmProvider)] = rejected
+}
+
+// CheckCredentials returns a scms.CredentialsRejectedError if the credentials were rejected with SetCredentialsRejected.
+func (c *Credentials) CheckCredentials(_ context.Context) error {
+	mutexCredentials.Lock()
+	defer mutexCredentials.Unlock()
+	if rejectedCredentials[c.key] {
+		return &scms.CredentialsRejectedError{Message: "401 Unauthorized"}
+	}
+	return nil
+}
+
+func credentialsKey(scmProvider v1alpha1.GenericScmProvider) string {
+	return scmProvider.GetNamespace() + "/" + scmProvider.GetName()
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 	ginkov2 "github.com/onsi/ginkgo/v2"
 	v1 "k8s.io/api/core/v1"
 )
@@ -47,3 +48,13 @@ func (gh GitAuthenticationProvider) GetToken(ctx context.Context) (string, error
 func (gh GitAuthenticationProvider) GetUser(ctx context.Context) (string, error) {
 	return "git", nil
 }
+
+// repositoryPath returns the owner and name of the repository on the fake git server, taken from the URL path of
+// GitRepositories identified by spec.url.
+func repositoryPath(gitRepo v1alpha1.GitRepository) string {
+	if gitRepo.Spec.Fake != nil {
+		return gitRepo.Spec.Fake.Owner + "/" + gitRepo.Spec.Fake.Name
+	}
+	owner, name := utils.GetRepositoryOwnerAndNameFromUrl(gitRepo.Spec.Url)
+	return owner + "/" + name
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -32,11 +33,17 @@ var (
 
 	// findOpenCallCount is incremented on every FindOpen call (for tests).
 	findOpenCallCount atomic.Uint64
+
+	// mergeHook is called by Merge once the merge is pushed (for tests).
+	mergeHook atomic.Pointer[func(ctx context.Context, pullRequest v1alpha1.PullRequest)]
 )
 
 type pullRequestProviderState struct {
-	id    string
-	state v1alpha1.PullRequestState
+	id        string
+	state     v1alpha1.PullRequestState
+	labels    []string
+	reviewers []string
+	draft     bool
 }
 
 // PullRequest implements the scms.PullRequestProvider interface for testing purposes.
@@ -66,7 +73,7 @@ func (pr *PullRequest) Create(ctx context.Context, title, head, base, descriptio
 	}
 
 	pullRequestCopy := pullRequest.DeepCopy()
-	if p, ok := pullRequests[pr.getMapKey(pullRequest, gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name)]; ok {
+	if p, ok := pullRequests[pr.getMapKey(pullRequest, repositoryPath(*gitRepo))]; ok {
 		logger.Info("Pull request already exists", "id", p.id, "pullRequestSpec", pullRequest.Spec, "pullRequestStatus", pullRequest.Status)
 		if p.state == v1alpha1.PullRequestOpen {
 			return id, errors.New("pull request already exists and is open")
@@ -77,16 +84,46 @@ func (pr *PullRequest) Create(ctx context.Context, title, head, base, descriptio
 	}
 
 	id = strconv.Itoa(len(pullRequests) + 1)
-	pullRequests[pr.getMapKey(*pullRequestCopy, gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name)] = pullRequestProviderState{
-		id:    id,
-		state: v1alpha1.PullRequestOpen,
+	pullRequests[pr.getMapKey(*pullRequestCopy, repositoryPath(*gitRepo))] = pullRequestProviderState{
+		id:        id,
+		state:     v1alpha1.PullRequestOpen,
+		labels:    slices.Clone(pullRequestCopy.Spec.Labels),
+		reviewers: slices.Clone(pullRequestCopy.Spec.Reviewers),
+		draft:     pullRequestCopy.Spec.Draft,
 	}
 
 	return id, nil
 }
 
-// Update updates an existing pull request with the specified title and description.
+// Update updates an existing pull request with the specified title and description, and with the labels, reviewers
+// and draft that changed since it was last opened or updated.
 func (pr *PullRequest) Update(ctx context.Context, title, description string, pullRequest v1alpha1.PullRequest) error {
+	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{Namespace: pullRequest.Namespace, Name: pullRequest.Spec.RepositoryReference.Name})
+	if err != nil {
+		return fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+
+	mutexPR.Lock()
+	defer mutexPR.Unlock()
+	prKey := pr.getMapKey(pullRequest, repositoryPath(*gitRepo))
+	p, ok := pullRequests[prKey]
+	if !ok {
+		// Tests delete pull requests to simulate them being merged or closed on the SCM, which updates don't notice.
+		return nil
+	}
+	changes := scms.GetPullRequestChanges(pullRequest)
+	for _, label := range pullRequest.Spec.Labels {
+		if !slices.Contains(p.labels, label) {
+			p.labels = append(p.labels, label)
+		}
+	}
+	p.labels = slices.DeleteFunc(p.labels, func(label string) bool { return slices.Contains(changes.RemovedLabels, label) })
+	p.reviewers = append(p.reviewers, changes.AddedReviewers...)
+	p.reviewers = slices.DeleteFunc(p.reviewers, func(reviewer string) bool { return slices.Contains(changes.RemovedReviewers, reviewer) })
+	if changes.DraftChanged {
+		p.draft = pullRequest.Spec.Draft
+	}
+	pullRequests[prKey] = p
 	return nil
 }
 
@@ -104,14 +141,13 @@ func (pr *PullRequest) Close(ctx context.Context, pullRequest v1alpha1.PullReque
 
 	mutexPR.Lock()
 	defer mutexPR.Unlock()
-	prKey := pr.getMapKey(pullRequest, gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name)
+	prKey := pr.getMapKey(pullRequest, repositoryPath(*gitRepo))
 	if _, ok := pullRequests[prKey]; !ok {
 		return errors.New("pull request not found")
 	}
-	pullRequests[prKey] = pullRequestProviderState{
-		id:    pullRequests[prKey].id,
-		state: v1alpha1.PullRequestClosed,
-	}
+	p := pullRequests[prKey]
+	p.state = v1alpha1.PullRequestClosed
+	pullRequests[prKey] = p
 	return nil
 }
 
@@ -141,7 +177,7 @@ func (pr *PullRequest) Merge(ctx context.Context, pullRequest v1alpha1.PullReque
 
 	gitServerPort := 5000 + ginkgov2.GinkgoParallelProcess()
 	gitServerPortStr := strconv.Itoa(gitServerPort)
-	_, err = pr.runGitCmd(ctx, gitPath, "clone", "--verbose", "--progress", "--filter=blob:none", "-b", pullRequest.Spec.TargetBranch, fmt.Sprintf("http://localhost:%s/%s/%s", gitServerPortStr, gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name), ".")
+	_, err = pr.runGitCmd(ctx, gitPath, "clone", "--verbose", "--progress", "--filter=blob:none", "-b", pullRequest.Spec.TargetBranch, fmt.Sprintf("http://localhost:%s/%s", gitServerPortStr, repositoryPath(*gitRepo)), ".")
 	if err != nil {
 		return err
 	}
@@ -185,7 +221,15 @@ func (pr *PullRequest) Merge(ctx context.Context, pullRequest v1alpha1.PullReque
 	}
 	beforeSha = strings.TrimSpace(beforeSha)
 
-	_, err = pr.runGitCmd(ctx, gitPath, "merge", "--no-ff", "origin/"+pullRequest.Spec.SourceBranch, "-m", pullRequest.Spec.Commit.Message)
+	if pullRequest.Spec.MergeStrategy == v1alpha1.PullRequestMergeStrategySquash {
+		_, err = pr.runGitCmd(ctx, gitPath, "merge", "--squash", "origin/"+pullRequest.Spec.SourceBranch)
+		if err != nil {
+			return err
+		}
+		_, err = pr.runGitCmd(ctx, gitPath, "commit", "-m", pullRequest.Spec.Commit.Message)
+	} else {
+		_, err = pr.runGitCmd(ctx, gitPath, "merge", "--no-ff", "origin/"+pullRequest.Spec.SourceBranch, "-m", pullRequest.Spec.Commit.Message)
+	}
 	if err != nil {
 		return err
 	}
@@ -200,20 +244,33 @@ func (pr *PullRequest) Merge(ctx context.Context, pullRequest v1alpha1.PullReque
 	// The new findChangeTransferPolicy code will search by active.hydrated.sha as fallback
 	pr.sendWebhook(ctx, pullRequest, beforeSha)
 
+	if hook := mergeHook.Load(); hook != nil {
+		(*hook)(ctx, pullRequest)
+	}
+
 	mutexPR.Lock()
 	defer mutexPR.Unlock()
-	prKey := pr.getMapKey(pullRequest, gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name)
+	prKey := pr.getMapKey(pullRequest, repositoryPath(*gitRepo))
 
-	if _, ok := pullRequests[prKey]; !ok {
+	p, ok := pullRequests[prKey]
+	if !ok {
 		return errors.New("pull request not found")
 	}
-	pullRequests[prKey] = pullRequestProviderState{
-		id:    pullRequests[prKey].id,
-		state: v1alpha1.PullRequestMerged,
-	}
+	p.state = v1alpha1.PullRequestMerged
+	pullRequests[prKey] = p
 	return nil
 }
 
+// SetMergeHook sets a test-only function that Merge calls with its context and the pull request once the merge is
+// pushed, before Merge returns. A nil hook removes it.
+func SetMergeHook(hook func(ctx context.Context, pullRequest v1alpha1.PullRequest)) {
+	if hook == nil {
+		mergeHook.Store(nil)
+		return
+	}
+	mergeHook.Store(&hook)
+}
+
 // ResetFindOpenCallCount resets the test-only counter of FindOpen invocations.
 func ResetFindOpenCallCount() {
 	findOpenCallCount.Store(0)
@@ -236,13 +293,30 @@ func (pr *PullRequest) GetRecordedState(ctx context.Context, pullRequest v1alpha
 	if pullRequests == nil {
 		return false, "", "", nil
 	}
-	st, ok := pullRequests[pr.getMapKey(pullRequest, gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name)]
+	st, ok := pullRequests[pr.getMapKey(pullRequest, repositoryPath(*gitRepo))]
 	if !ok {
 		return false, "", "", nil
 	}
 	return true, st.state, st.id, nil
 }
 
+// GetRecordedDetails returns the labels, reviewers and draft of the PR entry stored in the fake provider for the given
+// resource.
+func (pr *PullRequest) GetRecordedDetails(ctx context.Context, pullRequest v1alpha1.PullRequest) (labels, reviewers []string, draft bool, err error) {
+	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{Namespace: pullRequest.Namespace, Name: pullRequest.Spec.RepositoryReference.Name})
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+
+	mutexPR.RLock()
+	defer mutexPR.RUnlock()
+	st, ok := pullRequests[pr.getMapKey(pullRequest, repositoryPath(*gitRepo))]
+	if !ok {
+		return nil, nil, false, errors.New("pull request not found")
+	}
+	return slices.Clone(st.labels), slices.Clone(st.reviewers), st.draft, nil
+}
+
 // FindOpen checks if a pull request is open and returns its status.
 func (pr *PullRequest) FindOpen(ctx context.Context, pullRequest v1alpha1.PullRequest) (bool, string, time.Time, error) {
 	findOpenCallCount.Add(1)
@@ -266,12 +340,12 @@ func (pr *PullRequest) findOpen(ctx context.Context, pullRequest v1alpha1.PullRe
 		return false, ""
 	}
 
-	pullRequestState, ok := pullRequests[pr.getMapKey(pullRequest, gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name)]
+	pullRequestState, ok := pullRequests[pr.getMapKey(pullRequest, repositoryPath(*gitRepo))]
 	return ok && pullRequestState.state == v1alpha1.PullRequestOpen, pullRequestState.id
 }
 
-func (pr *PullRequest) getMapKey(pullRequest v1alpha1.PullRequest, owner, name string) string {
-	return fmt.Sprintf("%s/%s/%s/%s", owner, name, pullRequest.Spec.SourceBranch, pullRequest.Spec.TargetBranch)
+func (pr *PullRequest) getMapKey(pullRequest v1alpha1.PullRequest, repoPath string) string {
+	return fmt.Sprintf("%s/%s/%s", repoPath, pullRequest.Spec.SourceBranch, pullRequest.Spec.TargetBranch)
 }
 
 // DeletePullRequest deletes a pull request from the fake provider's internal map.
@@ -287,7 +361,7 @@ func (pr *PullRequest) DeletePullRequest(ctx context.Context, pullRequest v1alph
 	if pullRequests == nil {
 		return nil
 	}
-	prKey := pr.getMapKey(pullRequest, gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name)
+	prKey := pr.getMapKey(pullRequest, repositoryPath(*gitRepo))
 	delete(pullRequests, prKey)
 	return nil
 }
@@ -313,7 +387,7 @@ func (pr *PullRequest) sendWebhook(ctx context.Context, pullRequest v1alpha1.Pul
 	// Set GitHub webhook headers
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-Github-Event", "push")
-	req.Header.Set("X-Github-Delivery", fmt.Sprintf("pr-merge-delivery-%d", time.Now().Unix()))
+	req.Header.Set("X-Github-Delivery", fmt.Sprintf("pr-merge-delivery-%d", time.Now().UnixNano()))
 
 	// Send the request
 	httpClient := &http.Client{Timeout: 5 * time.Second}
@@ -390,9 +464,9 @@ func (pr *PullRequest) GetUrl(ctx context.Context, pullRequest v1alpha1.PullRequ
 		return "", fmt.Errorf("failed to get GitRepository: %w", err)
 	}
 
-	prKey := pr.getMapKey(pullRequest, gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name)
+	prKey := pr.getMapKey(pullRequest, repositoryPath(*gitRepo))
 	if prState, ok := pullRequests[prKey]; ok {
-		return fmt.Sprintf("http://localhost:5000/%s/%s/pull/%s", gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name, prState.id), nil
+		return fmt.Sprintf("http://localhost:5000/%s/pull/%s", repositoryPath(*gitRepo), prState.id), nil
 	}
 
 	logger.Info("Pull request not found", "pullRequest", pullRequest)
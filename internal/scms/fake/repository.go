@@ -0,0 +1,30 @@
+package fake
+
+import (
+	"sync"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+var (
+	// archivedRepositories holds the repositories archived in the fake SCM.
+	archivedRepositories map[string]bool
+	mutexRepository      sync.Mutex
+)
+
+// SetRepositoryArchived archives or unarchives the repository in the fake SCM.
+func SetRepositoryArchived(gitRepo v1alpha1.GitRepository, archived bool) {
+	mutexRepository.Lock()
+	defer mutexRepository.Unlock()
+	if archivedRepositories == nil {
+		archivedRepositories = make(map[string]bool)
+	}
+	archivedRepositories[repositoryPath(gitRepo)] = archived
+}
+
+// RepositoryArchived returns whether the repository is archived in the fake SCM.
+func RepositoryArchived(gitRepo v1alpha1.GitRepository) bool {
+	mutexRepository.Lock()
+	defer mutexRepository.Unlock()
+	return archivedRepositories[repositoryPath(gitRepo)]
+}
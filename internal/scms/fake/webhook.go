@@ -0,0 +1,95 @@
+package fake
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+var (
+	// webhooks holds the registered webhooks by repository and webhook ID.
+	webhooks      map[string]map[string]scms.Webhook
+	lastWebhookID int
+	mutexWebhook  sync.Mutex
+	// webhookDeleteErrors holds the errors the deletes of a repository's webhooks fail with, see FailWebhookDeletes.
+	webhookDeleteErrors map[string]error
+)
+
+// Webhook implements the scms.WebhookProvider interface for testing purposes.
+type Webhook struct{}
+
+var _ scms.WebhookProvider = &Webhook{}
+
+// NewFakeWebhookProvider creates a new instance of Webhook for testing purposes.
+func NewFakeWebhookProvider() *Webhook {
+	return &Webhook{}
+}
+
+// Ensure stores the webhook, creating it again if the webhook with the given ID was deleted.
+func (w *Webhook) Ensure(_ context.Context, gitRepo v1alpha1.GitRepository, id string, webhook scms.Webhook) (string, error) {
+	mutexWebhook.Lock()
+	defer mutexWebhook.Unlock()
+	if webhooks == nil {
+		webhooks = make(map[string]map[string]scms.Webhook)
+	}
+	key := webhookMapKey(gitRepo)
+	if webhooks[key] == nil {
+		webhooks[key] = make(map[string]scms.Webhook)
+	}
+
+	if _, ok := webhooks[key][id]; !ok {
+		lastWebhookID++
+		id = strconv.Itoa(lastWebhookID)
+	}
+	webhooks[key][id] = webhook
+	return id, nil
+}
+
+// Delete deletes the webhook, unless the deletes of the repository's webhooks were made to fail.
+func (w *Webhook) Delete(_ context.Context, gitRepo v1alpha1.GitRepository, id string) error {
+	mutexWebhook.Lock()
+	err := webhookDeleteErrors[webhookMapKey(gitRepo)]
+	mutexWebhook.Unlock()
+	if err != nil {
+		return err
+	}
+	DeleteWebhook(gitRepo, id)
+	return nil
+}
+
+// FailWebhookDeletes makes the deletes of the repository's webhooks fail with err, as if the SCM rejected them, or
+// succeed again if err is nil.
+func FailWebhookDeletes(gitRepo v1alpha1.GitRepository, err error) {
+	mutexWebhook.Lock()
+	defer mutexWebhook.Unlock()
+	if webhookDeleteErrors == nil {
+		webhookDeleteErrors = make(map[string]error)
+	}
+	if err == nil {
+		delete(webhookDeleteErrors, webhookMapKey(gitRepo))
+		return
+	}
+	webhookDeleteErrors[webhookMapKey(gitRepo)] = err
+}
+
+// GetWebhook returns the webhook stored in the fake provider for the repository, if any.
+func GetWebhook(gitRepo v1alpha1.GitRepository, id string) (scms.Webhook, bool) {
+	mutexWebhook.Lock()
+	defer mutexWebhook.Unlock()
+	webhook, ok := webhooks[webhookMapKey(gitRepo)][id]
+	return webhook, ok
+}
+
+// DeleteWebhook deletes the webhook from the fake provider, as if it was deleted on the SCM.
+func DeleteWebhook(gitRepo v1alpha1.GitRepository, id string) {
+	mutexWebhook.Lock()
+	defer mutexWebhook.Unlock()
+	delete(webhooks[webhookMapKey(gitRepo)], id)
+}
+
+func webhookMapKey(gitRepo v1alpha1.GitRepository) string {
+	return repositoryPath(gitRepo)
+}
@@ -0,0 +1,41 @@
+package forgejo
+
+import (
+	"context"
+	"net/http"
+
+	k8sV1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+// Credentials implements the scms.CredentialsChecker interface for Forgejo.
+type Credentials struct {
+	domain string
+	secret k8sV1.Secret
+}
+
+var _ scms.CredentialsChecker = &Credentials{}
+
+// NewForgejoCredentialsChecker creates a new instance of Credentials for Forgejo.
+func NewForgejoCredentialsChecker(domain string, secret k8sV1.Secret) *Credentials {
+	return &Credentials{domain: domain, secret: secret}
+}
+
+// CheckCredentials gets the user the token or user name and password belong to from the Forgejo API.
+func (c *Credentials) CheckCredentials(ctx context.Context) error {
+	client, err := GetClient(c.domain, c.secret)
+	if err != nil {
+		return err
+	}
+	client.SetContext(ctx)
+
+	_, resp, err := client.GetMyUserInfo()
+	if err != nil {
+		if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+			return &scms.CredentialsRejectedError{Message: err.Error()}
+		}
+		return err //nolint:wrapcheck // Error wrapping handled at top level
+	}
+	return nil
+}
@@ -3,10 +3,12 @@ package forgejo
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	forgejo "codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms"
 	k8sV1 "k8s.io/api/core/v1"
 )
@@ -82,7 +84,7 @@ func GetClient(domain string, secret k8sV1.Secret) (*forgejo.Client, error) {
 
 	client, err := forgejo.NewClient(
 		"https://"+domain,
-		options...,
+		append(options, forgejo.SetHTTPClient(&http.Client{Transport: metrics.NewSCMTransport("forgejo", nil)}))...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Forgejo client: %w", err)
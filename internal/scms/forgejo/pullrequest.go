@@ -169,8 +169,12 @@ func (pr *PullRequest) Merge(ctx context.Context, prObj promoterv1alpha1.PullReq
 		return err
 	}
 
+	style := forgejo.MergeStyleMerge
+	if prObj.Spec.MergeStrategy == promoterv1alpha1.PullRequestMergeStrategySquash {
+		style = forgejo.MergeStyleSquash
+	}
 	options := forgejo.MergePullRequestOption{
-		Style:        forgejo.MergeStyleMerge, // TODO: make the merge style configurable
+		Style:        style,
 		Message:      prObj.Spec.Commit.Message,
 		HeadCommitId: prObj.Spec.MergeSha,
 	}
@@ -247,5 +251,8 @@ func (pr *PullRequest) GetUrl(ctx context.Context, pullRequest promoterv1alpha1.
 		return "", fmt.Errorf("failed to get GitRepository: %w", err)
 	}
 
+	if webURL := scms.RepositoryWebUrl(*gitRepo); webURL != "" {
+		return fmt.Sprintf("%s/pulls/%s", webURL, pullRequest.Status.ID), nil
+	}
 	return fmt.Sprintf("https://%s/%s/%s/pulls/%s", pr.domain, gitRepo.Spec.Forgejo.Owner, gitRepo.Spec.Forgejo.Name, pullRequest.Status.ID), nil
 }
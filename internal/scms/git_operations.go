@@ -15,3 +15,11 @@ type GitOperationsProvider interface {
 	// GetUser returns the user name for authentication.
 	GetUser(ctx context.Context) (string, error)
 }
+
+// GitSSHCommandProvider is implemented by GitOperationsProviders that authenticate git operations over SSH instead of
+// HTTPS with a token.
+type GitSSHCommandProvider interface {
+	// GetGitSSHCommand returns the GIT_SSH_COMMAND for a single git command and a function that removes any credentials
+	// written for it. The cleanup function must be called once the git command has exited.
+	GetGitSSHCommand(ctx context.Context) (string, func(), error)
+}
@@ -0,0 +1,52 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bradleyfalzon/ghinstallation/v2"
+	"github.com/google/go-github/v71/github"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+// Credentials implements the scms.CredentialsChecker interface for GitHub.
+type Credentials struct {
+	client *github.Client
+}
+
+var _ scms.CredentialsChecker = &Credentials{}
+
+// NewGithubCredentialsChecker creates a new instance of Credentials for the GitHub App of the SCM provider.
+func NewGithubCredentialsChecker(scmProvider v1alpha1.GenericScmProvider, secret v1.Secret) (*Credentials, error) {
+	itr, err := ghinstallation.NewAppsTransport(scmTransport, scmProvider.GetSpec().GitHub.AppID, secret.Data[githubAppPrivateKeySecretKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub App transport: %w", err)
+	}
+
+	client := github.NewClient(&http.Client{Transport: itr})
+	if enterprise, baseUrl, uploadUrl := getUrls(scmProvider.GetSpec().GitHub.Domain); enterprise {
+		itr.BaseURL = baseUrl
+		client, err = client.WithEnterpriseURLs(baseUrl, uploadUrl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitHub enterprise client: %w", err)
+		}
+	}
+
+	return &Credentials{client: client}, nil
+}
+
+// CheckCredentials gets the GitHub App from the GitHub API, authenticated as the app itself, which checks its ID and
+// private key without needing an installation.
+func (c *Credentials) CheckCredentials(ctx context.Context) error {
+	if _, _, err := c.client.Apps.Get(ctx, ""); err != nil {
+		if isAccessDenied(err) {
+			return &scms.CredentialsRejectedError{Message: err.Error()}
+		}
+		return err //nolint:wrapcheck // Error wrapping handled at top level
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 	"github.com/bradleyfalzon/ghinstallation/v2"
 	"github.com/google/go-github/v71/github"
@@ -20,12 +21,22 @@ import (
 const (
 	// githubAppPrivateKeySecretKey is the key in the secret that contains the private key for the GitHub App.
 	githubAppPrivateKeySecretKey = "githubAppPrivateKey"
+
+	// minGitTokenLifetime is how long a token handed to git must remain valid. Git reads the credential once when a
+	// command starts, so a token that expires during a long clone or push fails the command halfway through.
+	// Installation tokens last an hour, so this still lets a cached token be reused for most of its lifetime.
+	minGitTokenLifetime = 15 * time.Minute
 )
 
+// scmTransport sends the requests to GitHub, including the ones for installation tokens, so that they are all
+// instrumented.
+var scmTransport = metrics.NewSCMTransport("github", nil)
+
 // GitAuthenticationProvider provides methods to authenticate with GitHub using a GitHub App.
 type GitAuthenticationProvider struct {
 	scmProvider v1alpha1.GenericScmProvider
 	transport   *ghinstallation.Transport
+	privateKey  []byte
 }
 
 // NewGithubGitAuthenticationProvider creates a new instance of GitAuthenticationProvider for GitHub using the provided SCM provider and secret.
@@ -47,9 +58,18 @@ func NewGithubGitAuthenticationProvider(ctx context.Context, k8sClient client.Cl
 	return GitAuthenticationProvider{
 		scmProvider: scmProvider,
 		transport:   itr,
+		privateKey:  secret.Data[githubAppPrivateKeySecretKey],
 	}, nil
 }
 
+// ValidateSecret checks that the secret contains a GitHub App private key that can be parsed.
+func ValidateSecret(scmProvider v1alpha1.GenericScmProvider, secret *v1.Secret) error {
+	if _, err := ghinstallation.NewAppsTransport(scmTransport, scmProvider.GetSpec().GitHub.AppID, secret.Data[githubAppPrivateKeySecretKey]); err != nil {
+		return fmt.Errorf("invalid GitHub App private key in secret %q: %w", secret.Name, err)
+	}
+	return nil
+}
+
 // GetGitHttpsRepoUrl constructs the HTTPS URL for a GitHub repository based on the provided GitRepository object.
 func (gh GitAuthenticationProvider) GetGitHttpsRepoUrl(gitRepository v1alpha1.GitRepository) string {
 	if gh.scmProvider.GetSpec().GitHub != nil && gh.scmProvider.GetSpec().GitHub.Domain != "" {
@@ -58,15 +78,128 @@ func (gh GitAuthenticationProvider) GetGitHttpsRepoUrl(gitRepository v1alpha1.Gi
 	return fmt.Sprintf("https://github.com/%s/%s.git", gitRepository.Spec.GitHub.Owner, gitRepository.Spec.GitHub.Name)
 }
 
-// GetToken retrieves the authentication token for GitHub.
+// GetToken retrieves the authentication token for GitHub. It is called right before each git command, and returns a
+// cached installation token only while it has at least minGitTokenLifetime left, otherwise a new token is requested.
 func (gh GitAuthenticationProvider) GetToken(ctx context.Context) (string, error) {
-	token, err := gh.transport.Token(ctx)
+	key := gitTokenKey{
+		baseURL:        gh.transport.BaseURL,
+		appID:          gh.transport.AppID(),
+		installationID: gh.transport.InstallationID(),
+	}
+	token, err := gitTokens.get(ctx, key, func(ctx context.Context) (string, time.Time, error) {
+		return installationToken(ctx, gh.transport, gh.privateKey)
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to get GitHub token for provider %q: %w", gh.scmProvider.GetName(), err)
 	}
 	return token, nil
 }
 
+// installationToken returns a token from the transport along with its expiry. The transport reuses its token until
+// shortly before it expires, which is too late for git, so a fresh transport for the same installation is used when
+// the token is about to expire.
+func installationToken(ctx context.Context, itr *ghinstallation.Transport, privateKey []byte) (string, time.Time, error) {
+	token, err := itr.Token(ctx)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to get installation token: %w", err)
+	}
+	expiresAt, _, err := itr.Expiry()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to get installation token expiry: %w", err)
+	}
+	if time.Until(expiresAt) >= minGitTokenLifetime {
+		return token, expiresAt, nil
+	}
+
+	fresh, err := ghinstallation.New(scmTransport, itr.AppID(), itr.InstallationID(), privateKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to create GitHub installation transport: %w", err)
+	}
+	fresh.BaseURL = itr.BaseURL
+	token, err = fresh.Token(ctx)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to refresh installation token: %w", err)
+	}
+	expiresAt, _, err = fresh.Expiry()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to get installation token expiry: %w", err)
+	}
+	return token, expiresAt, nil
+}
+
+// gitTokenKey identifies the installation a cached git token belongs to.
+type gitTokenKey struct {
+	baseURL        string
+	appID          int64
+	installationID int64
+}
+
+// cachedGitToken is an installation token and the time it expires.
+type cachedGitToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// gitTokenCache caches installation tokens used for git operations. A new transport is created on every reconcile, so
+// without the cache every reconcile would request a new token.
+type gitTokenCache struct {
+	mutex  sync.Mutex
+	tokens map[gitTokenKey]cachedGitToken
+	// failing holds the installations whose last token request failed.
+	failing map[gitTokenKey]bool
+	now     func() time.Time
+}
+
+// gitTokens is the process wide cache of installation tokens used for git operations.
+var gitTokens = &gitTokenCache{
+	tokens: make(map[gitTokenKey]cachedGitToken),
+	now:    time.Now,
+}
+
+// get returns the cached token for the key if it has at least minGitTokenLifetime left, otherwise it calls fetch and
+// caches the result. The lock is held while fetching so that concurrent reconciles don't all request a new token.
+func (c *gitTokenCache) get(ctx context.Context, key gitTokenKey, fetch func(ctx context.Context) (string, time.Time, error)) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if cached, ok := c.tokens[key]; ok && cached.expiresAt.Sub(c.now()) >= minGitTokenLifetime {
+		return cached.token, nil
+	}
+
+	token, expiresAt, err := fetch(ctx)
+	if err != nil {
+		if c.failing == nil {
+			c.failing = make(map[gitTokenKey]bool)
+		}
+		c.failing[key] = true
+		return "", err
+	}
+	delete(c.failing, key)
+	c.tokens[key] = cachedGitToken{token: token, expiresAt: expiresAt}
+	return token, nil
+}
+
+// failures returns the number of installations whose last token request failed, and the number of installations
+// tokens were requested for.
+func (c *gitTokenCache) failures() (failing int, total int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	total = len(c.tokens)
+	for key := range c.failing {
+		if _, ok := c.tokens[key]; !ok {
+			total++
+		}
+	}
+	return len(c.failing), total
+}
+
+// GitTokenFailures returns the number of GitHub App installations whose last request for a git token failed, and the
+// number of installations git tokens were requested for since the controller started. It doesn't request any token.
+func GitTokenFailures() (failing int, total int) {
+	return gitTokens.failures()
+}
+
 // GetUser returns a static user identifier for GitHub authentication.
 func (gh GitAuthenticationProvider) GetUser(ctx context.Context) (string, error) {
 	return "git", nil
@@ -79,7 +212,7 @@ func getInstallationClient(scmProvider v1alpha1.GenericScmProvider, secret v1.Se
 		return nil, nil, fmt.Errorf("installation ID is required for scmProvider %q", scmProvider.GetName())
 	}
 
-	itr, err := ghinstallation.New(http.DefaultTransport, scmProvider.GetSpec().GitHub.AppID, id, secret.Data[githubAppPrivateKeySecretKey])
+	itr, err := ghinstallation.New(scmTransport, scmProvider.GetSpec().GitHub.AppID, id, secret.Data[githubAppPrivateKeySecretKey])
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create GitHub installation transport: %w", err)
 	}
@@ -126,7 +259,7 @@ var appInstallationIdCacheMutex sync.RWMutex
 func GetClient(ctx context.Context, scmProvider v1alpha1.GenericScmProvider, secret v1.Secret, org string) (*github.Client, *ghinstallation.Transport, error) {
 	logger := log.FromContext(ctx)
 
-	itr, err := ghinstallation.NewAppsTransport(http.DefaultTransport, scmProvider.GetSpec().GitHub.AppID, secret.Data[githubAppPrivateKeySecretKey])
+	itr, err := ghinstallation.NewAppsTransport(scmTransport, scmProvider.GetSpec().GitHub.AppID, secret.Data[githubAppPrivateKeySecretKey])
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create GitHub installation transport: %w", err)
 	}
@@ -197,5 +330,16 @@ func GetClient(ctx context.Context, scmProvider v1alpha1.GenericScmProvider, sec
 		return getInstallationClient(scmProvider, secret, id)
 	}
 	appInstallationIdCacheMutex.Unlock()
-	return nil, nil, fmt.Errorf("installation of app %d not found for org: %s", scmProvider.GetSpec().GitHub.AppID, org)
+	return nil, nil, &installationNotFoundError{appID: scmProvider.GetSpec().GitHub.AppID, org: org}
+}
+
+// installationNotFoundError indicates that the GitHub App is not installed in the organization.
+type installationNotFoundError struct {
+	appID int64
+	org   string
+}
+
+// Error implements the error interface for installationNotFoundError.
+func (e *installationNotFoundError) Error() string {
+	return fmt.Sprintf("installation of app %d not found for org: %s", e.appID, e.org)
 }
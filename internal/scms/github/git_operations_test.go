@@ -0,0 +1,157 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/bradleyfalzon/ghinstallation/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("gitTokenCache", func() {
+	var cache *gitTokenCache
+	var now time.Time
+	var fetches int
+	var key gitTokenKey
+
+	fetchExpiringIn := func(lifetime time.Duration) func(ctx context.Context) (string, time.Time, error) {
+		return func(ctx context.Context) (string, time.Time, error) {
+			fetches++
+			return fmt.Sprintf("token-%d", fetches), now.Add(lifetime), nil
+		}
+	}
+
+	BeforeEach(func() {
+		now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		cache = &gitTokenCache{
+			tokens: make(map[gitTokenKey]cachedGitToken),
+			now:    func() time.Time { return now },
+		}
+		fetches = 0
+		key = gitTokenKey{baseURL: "https://api.github.com", appID: 1, installationID: 2}
+	})
+
+	It("should reuse a token that has enough lifetime left", func() {
+		token, err := cache.get(GinkgoT().Context(), key, fetchExpiringIn(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("token-1"))
+
+		now = now.Add(30 * time.Minute)
+		token, err = cache.get(GinkgoT().Context(), key, fetchExpiringIn(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("token-1"))
+		Expect(fetches).To(Equal(1))
+	})
+
+	It("should fetch a new token before the cached one could expire during a git command", func() {
+		_, err := cache.get(GinkgoT().Context(), key, fetchExpiringIn(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+
+		// The cached token is still valid, but for less than minGitTokenLifetime.
+		now = now.Add(time.Hour - minGitTokenLifetime + time.Second)
+		token, err := cache.get(GinkgoT().Context(), key, fetchExpiringIn(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("token-2"))
+		Expect(fetches).To(Equal(2))
+	})
+
+	It("should cache tokens separately for each installation", func() {
+		_, err := cache.get(GinkgoT().Context(), key, fetchExpiringIn(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+
+		otherKey := key
+		otherKey.installationID = 3
+		token, err := cache.get(GinkgoT().Context(), otherKey, fetchExpiringIn(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("token-2"))
+	})
+
+	It("should not cache a failed fetch", func() {
+		_, err := cache.get(GinkgoT().Context(), key, func(ctx context.Context) (string, time.Time, error) {
+			return "", time.Time{}, errors.New("boom")
+		})
+		Expect(err).To(MatchError("boom"))
+		Expect(cache.tokens).To(BeEmpty())
+	})
+
+	It("should count the installations whose last token request failed", func() {
+		failingFetch := func(ctx context.Context) (string, time.Time, error) {
+			return "", time.Time{}, errors.New("bad credentials")
+		}
+		otherKey := key
+		otherKey.installationID = 3
+
+		_, err := cache.get(GinkgoT().Context(), key, fetchExpiringIn(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cache.get(GinkgoT().Context(), otherKey, failingFetch)
+		Expect(err).To(HaveOccurred())
+		failing, total := cache.failures()
+		Expect(failing).To(Equal(1))
+		Expect(total).To(Equal(2))
+
+		// The cached token of the first installation is about to expire and can't be refreshed.
+		now = now.Add(time.Hour)
+		_, err = cache.get(GinkgoT().Context(), key, failingFetch)
+		Expect(err).To(HaveOccurred())
+		failing, total = cache.failures()
+		Expect(failing).To(Equal(2))
+		Expect(total).To(Equal(2))
+
+		_, err = cache.get(GinkgoT().Context(), otherKey, fetchExpiringIn(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		failing, total = cache.failures()
+		Expect(failing).To(Equal(1))
+		Expect(total).To(Equal(2))
+	})
+})
+
+// roundTripperFunc is an http.RoundTripper that calls itself.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+var _ = Describe("installationToken", func() {
+	It("should refresh a token that is about to expire with the instrumented transport", func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+		// The first token expires before a git command could finish with it, the second one doesn't.
+		lifetimes := []time.Duration{5 * time.Minute, time.Hour}
+		var issued int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			expiresAt := time.Now().Add(lifetimes[issued]).UTC().Format(time.RFC3339)
+			issued++
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"token": "token-%d", "expires_at": %q}`, issued, expiresAt)
+		}))
+		DeferCleanup(server.Close)
+
+		var requests int
+		DeferCleanup(func(original http.RoundTripper) { scmTransport = original }, scmTransport)
+		scmTransport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			requests++
+			return http.DefaultTransport.RoundTrip(req)
+		})
+		itr, err := ghinstallation.New(scmTransport, 1, 2, privateKey)
+		Expect(err).NotTo(HaveOccurred())
+		itr.BaseURL = server.URL
+
+		token, expiresAt, err := installationToken(GinkgoT().Context(), itr, privateKey)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("token-2"))
+		Expect(time.Until(expiresAt)).To(BeNumerically(">", minGitTokenLifetime))
+		Expect(requests).To(Equal(2), "both tokens are requested with the instrumented transport")
+	})
+})
@@ -3,7 +3,9 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v71/github"
@@ -47,6 +49,7 @@ func (pr *PullRequest) Create(ctx context.Context, title, head, base, descriptio
 		Head:  github.Ptr(head),
 		Base:  github.Ptr(base),
 		Body:  github.Ptr(description),
+		Draft: github.Ptr(pullRequest.Spec.Draft),
 	}
 
 	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{Namespace: pullRequest.Namespace, Name: pullRequest.Spec.RepositoryReference.Name})
@@ -69,10 +72,20 @@ func (pr *PullRequest) Create(ctx context.Context, title, head, base, descriptio
 		"url", response.Request.URL)
 	logger.Info("github response status", "status", response.Status)
 
+	// The pull request exists at this point, so failing to label it or request reviews doesn't fail the create. The
+	// labels are added again on the next update.
+	if err := pr.addLabels(ctx, gitRepo, *githubPullRequest.Number, pullRequest.Spec.Labels); err != nil {
+		logger.Error(err, "failed to add labels to pull request", "number", *githubPullRequest.Number)
+	}
+	if err := pr.requestReviewers(ctx, gitRepo, *githubPullRequest.Number, pullRequest.Spec.Reviewers); err != nil {
+		logger.Error(err, "failed to request reviewers for pull request", "number", *githubPullRequest.Number)
+	}
+
 	return strconv.Itoa(*githubPullRequest.Number), nil
 }
 
-// Update updates an existing pull request with the specified title and description.
+// Update updates an existing pull request with the specified title and description, and with the labels, reviewers
+// and draft that changed since it was last opened or updated.
 func (pr *PullRequest) Update(ctx context.Context, title, description string, pullRequest v1alpha1.PullRequest) error {
 	logger := log.FromContext(ctx)
 
@@ -92,7 +105,7 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pu
 	}
 
 	start := time.Now()
-	_, response, err := pr.client.PullRequests.Edit(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, prNumber, newPR)
+	githubPullRequest, response, err := pr.client.PullRequests.Edit(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, prNumber, newPR)
 	if response != nil {
 		metrics.RecordSCMCall(ctx, gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
 	}
@@ -107,6 +120,139 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pu
 	logger.V(4).Info("github response status",
 		"status", response.Status)
 
+	changes := scms.GetPullRequestChanges(pullRequest)
+	if err := pr.addLabels(ctx, gitRepo, prNumber, pullRequest.Spec.Labels); err != nil {
+		return err
+	}
+	if err := pr.removeLabels(ctx, gitRepo, prNumber, changes.RemovedLabels); err != nil {
+		return err
+	}
+	if err := pr.requestReviewers(ctx, gitRepo, prNumber, changes.AddedReviewers); err != nil {
+		return err
+	}
+	if err := pr.removeReviewers(ctx, gitRepo, prNumber, changes.RemovedReviewers); err != nil {
+		return err
+	}
+	// The pull request may have been converted on GitHub already, in which case there is nothing left to do.
+	if changes.DraftChanged && githubPullRequest.GetDraft() != pullRequest.Spec.Draft {
+		return pr.setDraft(ctx, gitRepo, githubPullRequest.GetNodeID(), pullRequest.Spec.Draft)
+	}
+	return nil
+}
+
+// addLabels adds the labels to the pull request. Labels that were added to the pull request on GitHub are kept.
+func (pr *PullRequest) addLabels(ctx context.Context, gitRepo *v1alpha1.GitRepository, prNumber int, labels []string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	_, response, err := pr.client.Issues.AddLabelsToIssue(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, prNumber, labels)
+	if response != nil {
+		metrics.RecordSCMCall(ctx, gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add labels to pull request: %w", err)
+	}
+	return nil
+}
+
+// removeLabels removes the labels from the pull request. Labels that were already removed on GitHub are skipped.
+func (pr *PullRequest) removeLabels(ctx context.Context, gitRepo *v1alpha1.GitRepository, prNumber int, labels []string) error {
+	for _, label := range labels {
+		start := time.Now()
+		response, err := pr.client.Issues.RemoveLabelForIssue(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, prNumber, label)
+		if response != nil {
+			metrics.RecordSCMCall(ctx, gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+		}
+		if err != nil && (response == nil || response.StatusCode != http.StatusNotFound) {
+			return fmt.Errorf("failed to remove label %q from pull request: %w", label, err)
+		}
+	}
+	return nil
+}
+
+// requestReviewers requests reviews of the pull request from the reviewers.
+func (pr *PullRequest) requestReviewers(ctx context.Context, gitRepo *v1alpha1.GitRepository, prNumber int, reviewers []string) error {
+	if len(reviewers) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	_, response, err := pr.client.PullRequests.RequestReviewers(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, prNumber, reviewersRequest(reviewers))
+	if response != nil {
+		metrics.RecordSCMCall(ctx, gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to request reviewers: %w", err)
+	}
+	return nil
+}
+
+// removeReviewers removes the review requests of the reviewers from the pull request.
+func (pr *PullRequest) removeReviewers(ctx context.Context, gitRepo *v1alpha1.GitRepository, prNumber int, reviewers []string) error {
+	if len(reviewers) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	response, err := pr.client.PullRequests.RemoveReviewers(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, prNumber, reviewersRequest(reviewers))
+	if response != nil {
+		metrics.RecordSCMCall(ctx, gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove reviewers: %w", err)
+	}
+	return nil
+}
+
+// reviewersRequest splits the reviewers into users and teams. Reviewers given as organization/team are teams.
+func reviewersRequest(reviewers []string) github.ReviewersRequest {
+	request := github.ReviewersRequest{}
+	for _, reviewer := range reviewers {
+		if _, team, isTeam := strings.Cut(reviewer, "/"); isTeam {
+			request.TeamReviewers = append(request.TeamReviewers, team)
+		} else {
+			request.Reviewers = append(request.Reviewers, reviewer)
+		}
+	}
+	return request
+}
+
+// setDraft converts the pull request to a draft, or marks it as ready for review. The REST API can only open pull
+// requests as drafts, so this uses the GraphQL API, which is served next to the REST API: at /graphql on github.com and
+// at /api/graphql on GitHub Enterprise Server.
+func (pr *PullRequest) setDraft(ctx context.Context, gitRepo *v1alpha1.GitRepository, nodeID string, draft bool) error {
+	mutation := "markPullRequestReadyForReview"
+	if draft {
+		mutation = "convertPullRequestToDraft"
+	}
+	body := map[string]any{
+		"query":     fmt.Sprintf("mutation($id: ID!) { %s(input: {pullRequestId: $id}) { clientMutationId } }", mutation),
+		"variables": map[string]any{"id": nodeID},
+	}
+	req, err := pr.client.NewRequest(http.MethodPost, "../graphql", body)
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", mutation, err)
+	}
+
+	var result struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	start := time.Now()
+	response, err := pr.client.Do(ctx, req, &result)
+	if response != nil {
+		metrics.RecordSCMCall(ctx, gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to %s: %w", mutation, err)
+	}
+	// GraphQL reports the errors of a mutation in the body of a successful response.
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("failed to %s: %s", mutation, result.Errors[0].Message)
+	}
 	return nil
 }
 
@@ -160,6 +306,11 @@ func (pr *PullRequest) Merge(ctx context.Context, pullRequest v1alpha1.PullReque
 		return fmt.Errorf("failed to get GitRepository: %w", err)
 	}
 
+	mergeMethod := string(v1alpha1.PullRequestMergeStrategyMerge)
+	if pullRequest.Spec.MergeStrategy != "" {
+		mergeMethod = string(pullRequest.Spec.MergeStrategy)
+	}
+
 	start := time.Now()
 	_, response, err := pr.client.PullRequests.Merge(
 		ctx,
@@ -168,7 +319,7 @@ func (pr *PullRequest) Merge(ctx context.Context, pullRequest v1alpha1.PullReque
 		prNumber,
 		pullRequest.Spec.Commit.Message,
 		&github.PullRequestOptions{
-			MergeMethod:        "merge",
+			MergeMethod:        mergeMethod,
 			DontDefaultIfBlank: false,
 			SHA:                pullRequest.Spec.MergeSha,
 		})
@@ -236,6 +387,9 @@ func (pr *PullRequest) GetUrl(ctx context.Context, pullRequest v1alpha1.PullRequ
 		return "", fmt.Errorf("failed to convert PR number to int when generating pull request url: %w", err)
 	}
 
+	if webURL := scms.RepositoryWebUrl(*gitRepo); webURL != "" {
+		return fmt.Sprintf("%s/pull/%d", webURL, prNumber), nil
+	}
 	if pr.client.BaseURL.Host == "api.github.com" {
 		return fmt.Sprintf("%s/%s/%s/pull/%d", "https://github.com", gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, prNumber), nil
 	}
@@ -0,0 +1,142 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+
+	"github.com/google/go-github/v71/github"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+var _ = Describe("PullRequest", func() {
+	var (
+		mu       sync.Mutex
+		requests map[string]any
+		existing string
+		graphql  string
+		pr       *PullRequest
+	)
+
+	BeforeEach(func() {
+		requests = map[string]any{}
+		existing = `{"number": 1, "node_id": "PR_1"}`
+		graphql = `{"data": {}}`
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			var body any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			requests[r.Method+" "+r.URL.Path] = body
+			switch r.Method + " " + r.URL.Path {
+			case "PATCH /api/v3/repos/owner/repo/pulls/1":
+				_, _ = w.Write([]byte(existing))
+			case "POST /api/v3/repos/owner/repo/issues/1/labels", "DELETE /api/v3/repos/owner/repo/issues/1/labels/env":
+				_, _ = w.Write([]byte(`[]`))
+			case "POST /api/v3/repos/owner/repo/pulls/1/requested_reviewers", "DELETE /api/v3/repos/owner/repo/pulls/1/requested_reviewers":
+				_, _ = w.Write([]byte(`{"number": 1}`))
+			case "POST /api/graphql":
+				_, _ = w.Write([]byte(graphql))
+			case "PUT /api/v3/repos/owner/repo/pulls/1/merge":
+				_, _ = w.Write([]byte(`{"merged": true}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			}
+		}))
+		DeferCleanup(server.Close)
+
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		client := github.NewClient(nil)
+		baseURL, err := url.Parse(server.URL + "/api/v3/")
+		Expect(err).NotTo(HaveOccurred())
+		client.BaseURL = baseURL
+		pr = &PullRequest{
+			client: client,
+			k8sClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&v1alpha1.GitRepository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "default"},
+				Spec:       v1alpha1.GitRepositorySpec{GitHub: &v1alpha1.GitHubRepo{Owner: "owner", Name: "repo"}},
+			}).Build(),
+		}
+	})
+
+	pullRequest := func(spec v1alpha1.PullRequestSpec, status v1alpha1.PullRequestStatus) v1alpha1.PullRequest {
+		spec.RepositoryReference = v1alpha1.ObjectReference{Name: "repo"}
+		status.ID = "1"
+		return v1alpha1.PullRequest{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}, Spec: spec, Status: status}
+	}
+
+	It("should only change the labels, reviewers and draft that changed in the spec", func() {
+		Expect(pr.Update(context.Background(), "Promote", "description", pullRequest(v1alpha1.PullRequestSpec{
+			Labels:    []string{"promotion"},
+			Reviewers: []string{"bob", "org/team"},
+			Draft:     true,
+		}, v1alpha1.PullRequestStatus{
+			// The gone label was already removed from the pull request on GitHub.
+			Labels:    []string{"promotion", "env", "gone"},
+			Reviewers: []string{"alice", "bob"},
+		}))).To(Succeed())
+
+		Expect(requests).To(HaveKeyWithValue("POST /api/v3/repos/owner/repo/issues/1/labels", ConsistOf("promotion")))
+		Expect(requests).To(HaveKey("DELETE /api/v3/repos/owner/repo/issues/1/labels/env"))
+		Expect(requests).To(HaveKeyWithValue("POST /api/v3/repos/owner/repo/pulls/1/requested_reviewers", And(
+			HaveKeyWithValue("team_reviewers", ConsistOf("team")),
+			Not(HaveKey("reviewers")),
+		)))
+		Expect(requests).To(HaveKeyWithValue("DELETE /api/v3/repos/owner/repo/pulls/1/requested_reviewers",
+			HaveKeyWithValue("reviewers", ConsistOf("alice"))))
+		Expect(requests).To(HaveKeyWithValue("POST /api/graphql", And(
+			HaveKeyWithValue("query", ContainSubstring("convertPullRequestToDraft")),
+			HaveKeyWithValue("variables", HaveKeyWithValue("id", "PR_1")),
+		)))
+	})
+
+	It("should leave the pull request alone when the spec didn't change", func() {
+		// The pull request was marked as ready on GitHub.
+		Expect(pr.Update(context.Background(), "Promote", "description", pullRequest(v1alpha1.PullRequestSpec{
+			Reviewers: []string{"alice"},
+			Draft:     true,
+		}, v1alpha1.PullRequestStatus{
+			Reviewers: []string{"alice"},
+			Draft:     true,
+		}))).To(Succeed())
+
+		Expect(requests).To(ConsistOf(HaveKey("title")), "only the title and description are updated")
+	})
+
+	It("should not convert a pull request that was already converted on GitHub", func() {
+		existing = `{"number": 1, "node_id": "PR_1", "draft": false}`
+		Expect(pr.Update(context.Background(), "Promote", "description", pullRequest(v1alpha1.PullRequestSpec{},
+			v1alpha1.PullRequestStatus{Draft: true}))).To(Succeed())
+
+		Expect(requests).NotTo(HaveKey("POST /api/graphql"))
+	})
+
+	It("should report the GraphQL errors of the draft mutation", func() {
+		existing = `{"number": 1, "node_id": "PR_1", "draft": true}`
+		graphql = `{"errors": [{"message": "Resource not accessible by integration"}]}`
+		err := pr.Update(context.Background(), "Promote", "description", pullRequest(v1alpha1.PullRequestSpec{},
+			v1alpha1.PullRequestStatus{Draft: true}))
+		Expect(err).To(MatchError(ContainSubstring("failed to markPullRequestReadyForReview: Resource not accessible by integration")))
+	})
+
+	It("should squash the commits with the squash merge strategy", func() {
+		Expect(pr.Merge(context.Background(), pullRequest(v1alpha1.PullRequestSpec{
+			MergeStrategy: v1alpha1.PullRequestMergeStrategySquash,
+			MergeSha:      "abc",
+		}, v1alpha1.PullRequestStatus{}))).To(Succeed())
+
+		Expect(requests).To(HaveKeyWithValue("PUT /api/v3/repos/owner/repo/pulls/1/merge", HaveKeyWithValue("merge_method", "squash")))
+	})
+})
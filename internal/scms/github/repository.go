@@ -0,0 +1,73 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v71/github"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+// Repository implements the scms.RepositoryProvider interface for GitHub.
+type Repository struct {
+	client *github.Client
+}
+
+var _ scms.RepositoryProvider = &Repository{}
+
+// NewGithubRepositoryProvider creates a new instance of Repository for GitHub. If the GitHub App is not installed in
+// the organization or its credentials are rejected, a scms.RepositoryAccessDeniedError is returned.
+func NewGithubRepositoryProvider(ctx context.Context, scmProvider v1alpha1.GenericScmProvider, secret v1.Secret, org string) (*Repository, error) {
+	client, _, err := GetClient(ctx, scmProvider, secret, org)
+	if err != nil {
+		var installationErr *installationNotFoundError
+		if errors.As(err, &installationErr) || isAccessDenied(err) {
+			return nil, &scms.RepositoryAccessDeniedError{Message: err.Error()}
+		}
+		return nil, err
+	}
+
+	return &Repository{client: client}, nil
+}
+
+// GetRepository gets the repository from the GitHub API.
+func (r *Repository) GetRepository(ctx context.Context, gitRepo v1alpha1.GitRepository) (*scms.Repository, error) {
+	start := time.Now()
+	repository, response, err := r.client.Repositories.Get(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name)
+	if response != nil {
+		metrics.RecordSCMCall(ctx, &gitRepo, metrics.SCMAPIRepository, metrics.SCMOperationGet, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+	}
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return nil, &scms.RepositoryNotFoundError{Message: err.Error()}
+		}
+		if isAccessDenied(err) {
+			return nil, &scms.RepositoryAccessDeniedError{Message: err.Error()}
+		}
+		return nil, err //nolint:wrapcheck // Error wrapping handled at top level
+	}
+
+	return &scms.Repository{
+		ID:            strconv.FormatInt(repository.GetID(), 10),
+		DefaultBranch: repository.GetDefaultBranch(),
+		HttpsCloneUrl: repository.GetCloneURL(),
+		SshCloneUrl:   repository.GetSSHURL(),
+		Archived:      repository.GetArchived() || repository.GetDisabled(),
+	}, nil
+}
+
+// isAccessDenied reports whether the GitHub API rejected a request's credentials or permissions.
+func isAccessDenied(err error) bool {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil {
+		return false
+	}
+	return ghErr.Response.StatusCode == http.StatusUnauthorized || ghErr.Response.StatusCode == http.StatusForbidden
+}
@@ -0,0 +1,112 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v71/github"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+// Webhook implements the scms.WebhookProvider interface for GitHub.
+type Webhook struct {
+	client *github.Client
+}
+
+var _ scms.WebhookProvider = &Webhook{}
+
+// NewGithubWebhookProvider creates a new instance of Webhook for GitHub.
+func NewGithubWebhookProvider(ctx context.Context, scmProvider v1alpha1.GenericScmProvider, secret v1.Secret, org string) (*Webhook, error) {
+	client, _, err := GetClient(ctx, scmProvider, secret, org)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Webhook{client: client}, nil
+}
+
+// Ensure creates the repository webhook, or updates the existing one. GitHub never returns the webhook's secret, so the
+// existing webhook is always updated to keep the secret in sync.
+func (w *Webhook) Ensure(ctx context.Context, gitRepo v1alpha1.GitRepository, id string, webhook scms.Webhook) (string, error) {
+	events := make([]string, 0, len(webhook.Events))
+	for _, event := range webhook.Events {
+		events = append(events, string(event))
+	}
+	hook := &github.Hook{
+		Config: &github.HookConfig{
+			URL:         github.Ptr(webhook.Url),
+			ContentType: github.Ptr("json"),
+			Secret:      github.Ptr(webhook.Secret),
+		},
+		Events: events,
+		Active: github.Ptr(true),
+	}
+
+	if id != "" {
+		hookID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse webhook ID %q: %w", id, err)
+		}
+		start := time.Now()
+		_, response, err := w.client.Repositories.EditHook(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, hookID, hook)
+		if response != nil {
+			metrics.RecordSCMCall(ctx, &gitRepo, metrics.SCMAPIWebhook, metrics.SCMOperationUpdate, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+		}
+		if err == nil {
+			return id, nil
+		}
+		if response == nil || response.StatusCode != http.StatusNotFound {
+			return "", webhookError(err)
+		}
+		// The webhook was deleted on GitHub, create it again.
+	}
+
+	start := time.Now()
+	created, response, err := w.client.Repositories.CreateHook(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, hook)
+	if response != nil {
+		metrics.RecordSCMCall(ctx, &gitRepo, metrics.SCMAPIWebhook, metrics.SCMOperationCreate, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+	}
+	if err != nil {
+		return "", webhookError(err)
+	}
+	return strconv.FormatInt(created.GetID(), 10), nil
+}
+
+// Delete deletes the repository webhook.
+func (w *Webhook) Delete(ctx context.Context, gitRepo v1alpha1.GitRepository, id string) error {
+	hookID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse webhook ID %q: %w", id, err)
+	}
+	start := time.Now()
+	response, err := w.client.Repositories.DeleteHook(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, hookID)
+	if response != nil {
+		metrics.RecordSCMCall(ctx, &gitRepo, metrics.SCMAPIWebhook, metrics.SCMOperationDelete, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+	}
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return webhookError(err)
+	}
+	return nil
+}
+
+// webhookError returns a scms.WebhookAccessDeniedError for errors of requests the GitHub API rejected because of the
+// credentials or permissions, e.g. a GitHub App without the repository webhooks permission. GitHub answers 404 to
+// tokens that can read the repository but not manage its webhooks, so that is treated as denied too when creating.
+func webhookError(err error) error {
+	var ghErr *github.ErrorResponse
+	if isAccessDenied(err) || (errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound) {
+		return &scms.WebhookAccessDeniedError{Message: err.Error()}
+	}
+	return err //nolint:wrapcheck // Error wrapping handled at top level
+}
@@ -91,9 +91,14 @@ func (cs *CommitStatus) Set(ctx context.Context, commitStatus *v1alpha1.CommitSt
 		Description: gitlab.Ptr(commitStatus.Spec.Description),
 	}
 
+	projectID, err := getProjectID(ctx, cs.client, repo)
+	if err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 	glStatus, resp, err := cs.client.Commits.SetCommitStatus(
-		repo.Spec.GitLab.ProjectID,
+		projectID,
 		commitStatus.Spec.Sha,
 		commitStatusOptions,
 		gitlab.WithContext(ctx),
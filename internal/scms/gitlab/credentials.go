@@ -0,0 +1,40 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+// Credentials implements the scms.CredentialsChecker interface for GitLab.
+type Credentials struct {
+	client *gitlab.Client
+}
+
+var _ scms.CredentialsChecker = &Credentials{}
+
+// NewGitlabCredentialsChecker creates a new instance of Credentials for GitLab.
+func NewGitlabCredentialsChecker(secret v1.Secret, domain string) (*Credentials, error) {
+	client, err := GetClient(secret, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credentials{client: client}, nil
+}
+
+// CheckCredentials gets the user the token belongs to from the GitLab API.
+func (c *Credentials) CheckCredentials(ctx context.Context) error {
+	_, resp, err := c.client.Users.CurrentUser(gitlab.WithContext(ctx))
+	if err != nil {
+		if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+			return &scms.CredentialsRejectedError{Message: err.Error()}
+		}
+		return err //nolint:wrapcheck // Error wrapping handled at top level
+	}
+	return nil
+}
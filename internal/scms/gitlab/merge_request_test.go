@@ -0,0 +1,129 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+var _ = Describe("merge requests", func() {
+	var (
+		mu       sync.Mutex
+		requests map[string]map[string]any
+		existing string
+		pr       *PullRequest
+	)
+
+	BeforeEach(func() {
+		requests = map[string]map[string]any{}
+		existing = `{"iid": 1}`
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			requests[r.Method+" "+r.URL.Path] = body
+			switch r.Method + " " + r.URL.Path {
+			case "GET /api/v4/users":
+				switch r.URL.Query().Get("username") {
+				case "alice":
+					_, _ = w.Write([]byte(`[{"id": 11, "username": "alice"}]`))
+				case "bob":
+					_, _ = w.Write([]byte(`[{"id": 12, "username": "bob"}]`))
+				default:
+					_, _ = w.Write([]byte(`[]`))
+				}
+			case "GET /api/v4/projects/42/merge_requests/1":
+				_, _ = w.Write([]byte(existing))
+			case "POST /api/v4/projects/42/merge_requests", "PUT /api/v4/projects/42/merge_requests/1",
+				"PUT /api/v4/projects/42/merge_requests/1/merge":
+				_, _ = w.Write([]byte(`{"iid": 1}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		DeferCleanup(server.Close)
+
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL+"/api/v4"))
+		Expect(err).NotTo(HaveOccurred())
+		pr = &PullRequest{
+			client: client,
+			k8sClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&v1alpha1.GitRepository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "default"},
+				Spec:       v1alpha1.GitRepositorySpec{GitLab: &v1alpha1.GitLabRepo{Namespace: "group", Name: "project", ProjectID: 42}},
+			}).Build(),
+		}
+	})
+
+	pullRequest := func(spec v1alpha1.PullRequestSpec, status v1alpha1.PullRequestStatus) v1alpha1.PullRequest {
+		spec.RepositoryReference = v1alpha1.ObjectReference{Name: "repo"}
+		return v1alpha1.PullRequest{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}, Spec: spec, Status: status}
+	}
+
+	It("should open a merge request with the labels, reviewers and draft", func() {
+		_, err := pr.Create(context.Background(), "Promote", "env-next", "env", "description", pullRequest(v1alpha1.PullRequestSpec{
+			Labels:    []string{"promotion", "env"},
+			Reviewers: []string{"alice", "bob"},
+			Draft:     true,
+		}, v1alpha1.PullRequestStatus{}))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(requests["POST /api/v4/projects/42/merge_requests"]).To(And(
+			HaveKeyWithValue("title", "Draft: Promote"),
+			HaveKeyWithValue("labels", "promotion,env"),
+			HaveKeyWithValue("reviewer_ids", ConsistOf(BeNumerically("==", 11), BeNumerically("==", 12))),
+		))
+	})
+
+	It("should only change the labels, reviewers and draft that changed in the spec", func() {
+		// carol was added as a reviewer on GitLab, and the merge request was converted to a draft there.
+		existing = `{"iid": 1, "draft": true, "reviewers": [{"id": 11, "username": "alice"}, {"id": 13, "username": "carol"}]}`
+		Expect(pr.Update(context.Background(), "Promote", "description", pullRequest(v1alpha1.PullRequestSpec{
+			Labels:    []string{"promotion"},
+			Reviewers: []string{"bob"},
+		}, v1alpha1.PullRequestStatus{
+			ID:        "1",
+			Labels:    []string{"promotion", "env"},
+			Reviewers: []string{"alice"},
+		}))).To(Succeed())
+
+		Expect(requests["PUT /api/v4/projects/42/merge_requests/1"]).To(And(
+			HaveKeyWithValue("title", "Draft: Promote"),
+			HaveKeyWithValue("add_labels", "promotion"),
+			HaveKeyWithValue("remove_labels", "env"),
+			HaveKeyWithValue("reviewer_ids", ConsistOf(BeNumerically("==", 12), BeNumerically("==", 13))),
+		))
+	})
+
+	It("should mark the merge request as ready when the spec stops being a draft", func() {
+		existing = `{"iid": 1, "draft": true}`
+		Expect(pr.Update(context.Background(), "Promote", "description", pullRequest(v1alpha1.PullRequestSpec{},
+			v1alpha1.PullRequestStatus{ID: "1", Draft: true}))).To(Succeed())
+
+		update := requests["PUT /api/v4/projects/42/merge_requests/1"]
+		Expect(update).To(HaveKeyWithValue("title", "Promote"))
+		Expect(update).NotTo(HaveKey("reviewer_ids"))
+	})
+
+	It("should squash the commits with the squash merge strategy", func() {
+		Expect(pr.Merge(context.Background(), pullRequest(v1alpha1.PullRequestSpec{
+			MergeStrategy: v1alpha1.PullRequestMergeStrategySquash,
+		}, v1alpha1.PullRequestStatus{ID: "1"}))).To(Succeed())
+
+		Expect(requests["PUT /api/v4/projects/42/merge_requests/1/merge"]).To(HaveKeyWithValue("squash", true))
+	})
+})
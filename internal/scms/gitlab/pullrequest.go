@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	gitlab "gitlab.com/gitlab-org/api/client-go"
@@ -18,6 +20,9 @@ import (
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 )
 
+// draftPrefix is the prefix of the titles of draft merge requests.
+const draftPrefix = "Draft: "
+
 // PullRequest implements the scms.PullRequestProvider interface for GitLab.
 type PullRequest struct {
 	client    *gitlab.Client
@@ -52,15 +57,33 @@ func (pr *PullRequest) Create(ctx context.Context, title, head, base, desc strin
 	}
 
 	options := &gitlab.CreateMergeRequestOptions{
-		Title:        gitlab.Ptr(title),
+		Title:        gitlab.Ptr(draftTitle(title, prObj.Spec.Draft)),
 		SourceBranch: gitlab.Ptr(head),
 		TargetBranch: gitlab.Ptr(base),
 		Description:  gitlab.Ptr(desc),
 	}
+	if len(prObj.Spec.Labels) > 0 {
+		options.Labels = gitlab.Ptr(gitlab.LabelOptions(prObj.Spec.Labels))
+	}
+
+	projectID, err := getProjectID(ctx, pr.client, repo)
+	if err != nil {
+		return "", err
+	}
+
+	// Failing to look up a reviewer doesn't fail the create, the merge request is opened without the reviewers.
+	if len(prObj.Spec.Reviewers) > 0 {
+		reviewerIDs, err := pr.getUserIDs(ctx, repo, prObj.Spec.Reviewers)
+		if err != nil {
+			logger.Error(err, "failed to look up the reviewers of the merge request")
+		} else {
+			options.ReviewerIDs = &reviewerIDs
+		}
+	}
 
 	start := time.Now()
 	mr, resp, err := pr.client.MergeRequests.CreateMergeRequest(
-		repo.Spec.GitLab.ProjectID,
+		projectID,
 		options,
 	)
 	if resp != nil {
@@ -81,7 +104,8 @@ func (pr *PullRequest) Create(ctx context.Context, title, head, base, desc strin
 	return strconv.FormatInt(mr.IID, 10), nil
 }
 
-// Update updates an existing pull request with the specified title and description.
+// Update updates an existing pull request with the specified title and description, and with the labels, reviewers
+// and draft that changed since it was last opened or updated.
 func (pr *PullRequest) Update(ctx context.Context, title, description string, prObj v1alpha1.PullRequest) error {
 	logger := log.FromContext(ctx)
 
@@ -98,14 +122,57 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pr
 		return fmt.Errorf("failed to get repo: %w", err)
 	}
 
+	projectID, err := getProjectID(ctx, pr.client, repo)
+	if err != nil {
+		return err
+	}
+
+	// The merge request is a draft when its title starts with "Draft:", so its draft and reviewers on GitLab are
+	// looked up to keep the ones that were changed there.
+	start := time.Now()
+	mr, resp, err := pr.client.MergeRequests.GetMergeRequest(projectID, mrIID, nil, gitlab.WithContext(ctx))
+	if resp != nil {
+		metrics.RecordSCMCall(ctx, repo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, resp.StatusCode, time.Since(start), nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get merge request: %w", err)
+	}
+
+	changes := scms.GetPullRequestChanges(prObj)
+	draft := mr.Draft
+	if changes.DraftChanged {
+		draft = prObj.Spec.Draft
+	}
 	options := &gitlab.UpdateMergeRequestOptions{
-		Title:       gitlab.Ptr(title),
+		Title:       gitlab.Ptr(draftTitle(title, draft)),
 		Description: gitlab.Ptr(description),
 	}
+	if len(prObj.Spec.Labels) > 0 {
+		options.AddLabels = gitlab.Ptr(gitlab.LabelOptions(prObj.Spec.Labels))
+	}
+	if len(changes.RemovedLabels) > 0 {
+		options.RemoveLabels = gitlab.Ptr(gitlab.LabelOptions(changes.RemovedLabels))
+	}
+	if len(changes.AddedReviewers) > 0 || len(changes.RemovedReviewers) > 0 {
+		reviewerIDs, err := pr.getUserIDs(ctx, repo, changes.AddedReviewers)
+		if err != nil {
+			return err
+		}
+		for _, reviewer := range mr.Reviewers {
+			if !slices.Contains(changes.RemovedReviewers, reviewer.Username) && !slices.Contains(reviewerIDs, reviewer.ID) {
+				reviewerIDs = append(reviewerIDs, reviewer.ID)
+			}
+		}
+		// An empty list removes all the reviewers, a nil one would leave them.
+		if reviewerIDs == nil {
+			reviewerIDs = []int64{}
+		}
+		options.ReviewerIDs = &reviewerIDs
+	}
 
-	start := time.Now()
-	_, resp, err := pr.client.MergeRequests.UpdateMergeRequest(
-		repo.Spec.GitLab.ProjectID,
+	start = time.Now()
+	_, resp, err = pr.client.MergeRequests.UpdateMergeRequest(
+		projectID,
 		mrIID,
 		options,
 		gitlab.WithContext(ctx),
@@ -128,6 +195,36 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pr
 	return nil
 }
 
+// draftTitle returns the title of a merge request that is a draft or not. GitLab derives the draft of a merge request
+// from the "Draft:" prefix of its title.
+func draftTitle(title string, draft bool) string {
+	if draft && !strings.HasPrefix(title, draftPrefix) {
+		return draftPrefix + title
+	}
+	return title
+}
+
+// getUserIDs looks up the IDs of the users with the usernames, which the merge request API takes instead of the
+// usernames.
+func (pr *PullRequest) getUserIDs(ctx context.Context, repo *v1alpha1.GitRepository, usernames []string) ([]int64, error) {
+	var ids []int64
+	for _, username := range usernames {
+		start := time.Now()
+		users, resp, err := pr.client.Users.ListUsers(&gitlab.ListUsersOptions{Username: gitlab.Ptr(username)}, gitlab.WithContext(ctx))
+		if resp != nil {
+			metrics.RecordSCMCall(ctx, repo, metrics.SCMAPIPullRequest, metrics.SCMOperationList, resp.StatusCode, time.Since(start), nil)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up user %q: %w", username, err)
+		}
+		if len(users) == 0 {
+			return nil, fmt.Errorf("user %q not found", username)
+		}
+		ids = append(ids, users[0].ID)
+	}
+	return ids, nil
+}
+
 // Close closes an existing pull request.
 func (pr *PullRequest) Close(ctx context.Context, prObj v1alpha1.PullRequest) error {
 	logger := log.FromContext(ctx)
@@ -149,9 +246,14 @@ func (pr *PullRequest) Close(ctx context.Context, prObj v1alpha1.PullRequest) er
 		StateEvent: gitlab.Ptr("close"),
 	}
 
+	projectID, err := getProjectID(ctx, pr.client, repo)
+	if err != nil {
+		return err
+	}
+
 	start := time.Now()
 	_, resp, err := pr.client.MergeRequests.UpdateMergeRequest(
-		repo.Spec.GitLab.ProjectID,
+		projectID,
 		mrIID,
 		options,
 		gitlab.WithContext(ctx),
@@ -194,7 +296,7 @@ func (pr *PullRequest) Merge(ctx context.Context, prObj v1alpha1.PullRequest) er
 	options := &gitlab.AcceptMergeRequestOptions{
 		AutoMerge:                gitlab.Ptr(false),
 		ShouldRemoveSourceBranch: gitlab.Ptr(false),
-		Squash:                   gitlab.Ptr(false),
+		Squash:                   gitlab.Ptr(prObj.Spec.MergeStrategy == v1alpha1.PullRequestMergeStrategySquash),
 		SHA:                      gitlab.Ptr(prObj.Spec.MergeSha),
 	}
 	// Gitlab throws a 422 if you send it an empty commit message. So leave it as nil unless we have a message.
@@ -202,9 +304,14 @@ func (pr *PullRequest) Merge(ctx context.Context, prObj v1alpha1.PullRequest) er
 		options.MergeCommitMessage = gitlab.Ptr(prObj.Spec.Commit.Message)
 	}
 
+	projectID, err := getProjectID(ctx, pr.client, repo)
+	if err != nil {
+		return err
+	}
+
 	start := time.Now()
 	_, resp, err := pr.client.MergeRequests.AcceptMergeRequest(
-		repo.Spec.GitLab.ProjectID,
+		projectID,
 		mrIID,
 		options,
 		gitlab.WithContext(ctx),
@@ -285,6 +392,9 @@ func (pr *PullRequest) GetUrl(ctx context.Context, prObj v1alpha1.PullRequest) (
 		return "", fmt.Errorf("failed to get repo: %w", err)
 	}
 
+	if webURL := scms.RepositoryWebUrl(*repo); webURL != "" {
+		return fmt.Sprintf("%s/-/merge_requests/%s", webURL, prObj.Status.ID), nil
+	}
 	return FormatMergeRequestUrl(pr.client, repo.Spec.GitLab, prObj.Status.ID), nil
 }
 
@@ -0,0 +1,64 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+// Repository implements the scms.RepositoryProvider interface for GitLab.
+type Repository struct {
+	client *gitlab.Client
+}
+
+var _ scms.RepositoryProvider = &Repository{}
+
+// NewGitlabRepositoryProvider creates a new instance of Repository for GitLab.
+func NewGitlabRepositoryProvider(secret v1.Secret, domain string) (*Repository, error) {
+	client, err := GetClient(secret, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{client: client}, nil
+}
+
+// GetRepository gets the project from the GitLab API by its path.
+func (r *Repository) GetRepository(ctx context.Context, gitRepo v1alpha1.GitRepository) (*scms.Repository, error) {
+	start := time.Now()
+	project, resp, err := r.client.Projects.GetProject(
+		gitRepo.Spec.GitLab.Namespace+"/"+gitRepo.Spec.GitLab.Name,
+		nil,
+		gitlab.WithContext(ctx),
+	)
+	if resp != nil {
+		metrics.RecordSCMCall(ctx, &gitRepo, metrics.SCMAPIRepository, metrics.SCMOperationGet, resp.StatusCode, time.Since(start), nil)
+	}
+	if err != nil {
+		if resp != nil {
+			switch resp.StatusCode {
+			case http.StatusNotFound:
+				return nil, &scms.RepositoryNotFoundError{Message: err.Error()}
+			case http.StatusUnauthorized, http.StatusForbidden:
+				return nil, &scms.RepositoryAccessDeniedError{Message: err.Error()}
+			}
+		}
+		return nil, err //nolint:wrapcheck // Error wrapping handled at top level
+	}
+
+	return &scms.Repository{
+		ID:            strconv.FormatInt(project.ID, 10),
+		DefaultBranch: project.DefaultBranch,
+		HttpsCloneUrl: project.HTTPURLToRepo,
+		SshCloneUrl:   project.SSHURLToRepo,
+		Archived:      project.Archived,
+	}, nil
+}
@@ -1,10 +1,14 @@
 package gitlab
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
 	"github.com/go-logr/logr"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 	corev1 "k8s.io/api/core/v1"
@@ -61,3 +65,26 @@ func ApplyHTTPAuth(secret corev1.Secret, req *http.Request) error {
 	req.Header.Set("Private-Token", token)
 	return nil
 }
+
+// getProjectID returns the ID of the GitRepository's project for the API calls: the one set in spec.gitlab, or else
+// the one the GitRepository controller looked up for spec.gitlab's current namespace and name. Only if neither is
+// known yet, e.g. before the GitRepository was reconciled, it is looked up here.
+func getProjectID(ctx context.Context, client *gitlab.Client, repo *v1alpha1.GitRepository) (int, error) {
+	if repo.Spec.GitLab.ProjectID != 0 {
+		return repo.Spec.GitLab.ProjectID, nil
+	}
+	if cached := repo.Status.GitLab; cached != nil && cached.Namespace == repo.Spec.GitLab.Namespace && cached.Name == repo.Spec.GitLab.Name {
+		return cached.ProjectID, nil
+	}
+
+	path := repo.Spec.GitLab.Namespace + "/" + repo.Spec.GitLab.Name
+	start := time.Now()
+	project, resp, err := client.Projects.GetProject(path, nil, gitlab.WithContext(ctx))
+	if resp != nil {
+		metrics.RecordSCMCall(ctx, repo, metrics.SCMAPIRepository, metrics.SCMOperationGet, resp.StatusCode, time.Since(start), nil)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up ID of project %q: %w", path, err)
+	}
+	return int(project.ID), nil
+}
@@ -0,0 +1,98 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+var _ = Describe("getProjectID", func() {
+	var (
+		mu             sync.Mutex
+		lookups        int
+		mergeRequestIn []string
+		server         *httptest.Server
+	)
+
+	BeforeEach(func() {
+		lookups = 0
+		mergeRequestIn = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			path := r.URL.EscapedPath()
+			if project, ok := strings.CutSuffix(strings.TrimPrefix(path, "/api/v4/projects/"), "/merge_requests/1"); ok {
+				mergeRequestIn = append(mergeRequestIn, project)
+				_, _ = w.Write([]byte(`{"iid": 1}`))
+				return
+			}
+			if r.Method == http.MethodGet && path == "/api/v4/projects/group%2Fproject" {
+				lookups++
+				_, _ = w.Write([]byte(`{"id": 7}`))
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		DeferCleanup(server.Close)
+	})
+
+	closeMergeRequest := func(gitRepo *v1alpha1.GitRepository) {
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		gitRepo.ObjectMeta = metav1.ObjectMeta{Name: "repo", Namespace: "default"}
+		client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL+"/api/v4"))
+		Expect(err).NotTo(HaveOccurred())
+		pr := &PullRequest{
+			client:    client,
+			k8sClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(gitRepo).Build(),
+		}
+
+		Expect(pr.Close(context.Background(), v1alpha1.PullRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+			Spec:       v1alpha1.PullRequestSpec{RepositoryReference: v1alpha1.ObjectReference{Name: "repo"}},
+			Status:     v1alpha1.PullRequestStatus{ID: "1"},
+		})).To(Succeed())
+	}
+
+	It("should not look up the project ID the GitRepository controller resolved", func() {
+		closeMergeRequest(&v1alpha1.GitRepository{
+			Spec: v1alpha1.GitRepositorySpec{GitLab: &v1alpha1.GitLabRepo{Namespace: "group", Name: "project"}},
+			Status: v1alpha1.GitRepositoryStatus{
+				GitLab: &v1alpha1.GitLabRepositoryStatus{Namespace: "group", Name: "project", ProjectID: 42},
+			},
+		})
+		Expect(lookups).To(BeZero())
+		Expect(mergeRequestIn).To(Equal([]string{"42"}))
+	})
+
+	It("should not look up the project ID set in the spec", func() {
+		closeMergeRequest(&v1alpha1.GitRepository{
+			Spec: v1alpha1.GitRepositorySpec{GitLab: &v1alpha1.GitLabRepo{Namespace: "group", Name: "project", ProjectID: 13}},
+		})
+		Expect(lookups).To(BeZero())
+		Expect(mergeRequestIn).To(Equal([]string{"13"}))
+	})
+
+	It("should look up the project ID when none was resolved for the current namespace and name", func() {
+		closeMergeRequest(&v1alpha1.GitRepository{
+			Spec: v1alpha1.GitRepositorySpec{GitLab: &v1alpha1.GitLabRepo{Namespace: "group", Name: "project"}},
+			Status: v1alpha1.GitRepositoryStatus{
+				GitLab: &v1alpha1.GitLabRepositoryStatus{Namespace: "group", Name: "renamed", ProjectID: 42},
+			},
+		})
+		Expect(lookups).To(Equal(1))
+		Expect(mergeRequestIn).To(Equal([]string{"7"}))
+	})
+})
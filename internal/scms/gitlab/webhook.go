@@ -0,0 +1,113 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+// Webhook implements the scms.WebhookProvider interface for GitLab.
+type Webhook struct {
+	client *gitlab.Client
+}
+
+var _ scms.WebhookProvider = &Webhook{}
+
+// NewGitlabWebhookProvider creates a new instance of Webhook for GitLab.
+func NewGitlabWebhookProvider(secret v1.Secret, domain string) (*Webhook, error) {
+	client, err := GetClient(secret, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Webhook{client: client}, nil
+}
+
+// Ensure creates the project hook, or updates the existing one. The promoter's events map to GitLab's push, merge
+// request and pipeline events, and the secret is sent as the hook's token.
+func (w *Webhook) Ensure(ctx context.Context, gitRepo v1alpha1.GitRepository, id string, webhook scms.Webhook) (string, error) {
+	project := gitRepo.Spec.GitLab.Namespace + "/" + gitRepo.Spec.GitLab.Name
+	pushEvents := gitlab.Ptr(slices.Contains(webhook.Events, v1alpha1.WebhookEventPush))
+	mergeRequestsEvents := gitlab.Ptr(slices.Contains(webhook.Events, v1alpha1.WebhookEventPullRequest))
+	pipelineEvents := gitlab.Ptr(slices.Contains(webhook.Events, v1alpha1.WebhookEventStatus))
+
+	if id != "" {
+		hookID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse webhook ID %q: %w", id, err)
+		}
+		start := time.Now()
+		_, resp, err := w.client.Projects.EditProjectHook(project, hookID, &gitlab.EditProjectHookOptions{
+			URL:                 gitlab.Ptr(webhook.Url),
+			Token:               gitlab.Ptr(webhook.Secret),
+			PushEvents:          pushEvents,
+			MergeRequestsEvents: mergeRequestsEvents,
+			PipelineEvents:      pipelineEvents,
+		}, gitlab.WithContext(ctx))
+		if resp != nil {
+			metrics.RecordSCMCall(ctx, &gitRepo, metrics.SCMAPIWebhook, metrics.SCMOperationUpdate, resp.StatusCode, time.Since(start), nil)
+		}
+		if err == nil {
+			return id, nil
+		}
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return "", webhookError(resp, err)
+		}
+		// The hook was deleted on GitLab, create it again.
+	}
+
+	start := time.Now()
+	hook, resp, err := w.client.Projects.AddProjectHook(project, &gitlab.AddProjectHookOptions{
+		URL:                 gitlab.Ptr(webhook.Url),
+		Token:               gitlab.Ptr(webhook.Secret),
+		PushEvents:          pushEvents,
+		MergeRequestsEvents: mergeRequestsEvents,
+		PipelineEvents:      pipelineEvents,
+	}, gitlab.WithContext(ctx))
+	if resp != nil {
+		metrics.RecordSCMCall(ctx, &gitRepo, metrics.SCMAPIWebhook, metrics.SCMOperationCreate, resp.StatusCode, time.Since(start), nil)
+	}
+	if err != nil {
+		return "", webhookError(resp, err)
+	}
+	return strconv.FormatInt(hook.ID, 10), nil
+}
+
+// Delete deletes the project hook.
+func (w *Webhook) Delete(ctx context.Context, gitRepo v1alpha1.GitRepository, id string) error {
+	hookID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse webhook ID %q: %w", id, err)
+	}
+	start := time.Now()
+	resp, err := w.client.Projects.DeleteProjectHook(gitRepo.Spec.GitLab.Namespace+"/"+gitRepo.Spec.GitLab.Name, hookID, gitlab.WithContext(ctx))
+	if resp != nil {
+		metrics.RecordSCMCall(ctx, &gitRepo, metrics.SCMAPIWebhook, metrics.SCMOperationDelete, resp.StatusCode, time.Since(start), nil)
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return webhookError(resp, err)
+	}
+	return nil
+}
+
+// webhookError returns a scms.WebhookAccessDeniedError for requests GitLab rejected because the token is not allowed
+// to manage the project's hooks, which needs the Maintainer role.
+func webhookError(resp *gitlab.Response, err error) error {
+	if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+		return &scms.WebhookAccessDeniedError{Message: err.Error()}
+	}
+	return err //nolint:wrapcheck // Error wrapping handled at top level
+}
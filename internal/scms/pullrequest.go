@@ -2,6 +2,7 @@ package scms
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
@@ -9,12 +10,15 @@ import (
 
 // PullRequestProvider defines the interface for managing pull requests in a source control management system.
 type PullRequestProvider interface {
-	// Create creates a new pull request with the specified title, head, base, and description.
+	// Create creates a new pull request with the specified title, head, base, and description. The pull request is
+	// opened with pullRequest.Spec's labels, reviewers and draft, for the providers that support them.
 	Create(ctx context.Context, title, head, base, description string, pullRequest v1alpha1.PullRequest) (string, error)
 	// Close closes an existing pull request.
 	// pullRequest.Status.ID is guaranteed to be set when this is called.
 	Close(ctx context.Context, pullRequest v1alpha1.PullRequest) error
-	// Update updates an existing pull request with the specified title, description, and pull request details.
+	// Update updates an existing pull request with the specified title, description, and pull request details. The
+	// labels, reviewers and draft that changed from the ones in pullRequest.Status, which the pull request was last
+	// opened or updated with, are changed on the pull request as well, see PullRequestChanges.
 	// pullRequest.Status.ID is guaranteed to be set when this is called.
 	Update(ctx context.Context, title, description string, pullRequest v1alpha1.PullRequest) error
 	// Merge merges an existing pull request with the specified commit message.
@@ -26,3 +30,58 @@ type PullRequestProvider interface {
 	// GetUrl retrieves the URL of the pull request.
 	GetUrl(ctx context.Context, pullRequest v1alpha1.PullRequest) (string, error)
 }
+
+// UnsupportedPullRequestFields returns the names of the fields of a PullRequest spec that are set but that the pull
+// requests of the SCM don't support. Labels, reviewers and drafts are supported by GitHub, GitLab and Fake providers,
+// the merge strategy by all of them.
+func UnsupportedPullRequestFields(scmProvider *v1alpha1.ScmProviderSpec, labels, reviewers []string, draft bool) []string {
+	if scmProvider.GitHub != nil || scmProvider.GitLab != nil || scmProvider.Fake != nil {
+		return nil
+	}
+	var unsupported []string
+	if len(labels) > 0 {
+		unsupported = append(unsupported, "labels")
+	}
+	if len(reviewers) > 0 {
+		unsupported = append(unsupported, "reviewers")
+	}
+	if draft {
+		unsupported = append(unsupported, "draft")
+	}
+	return unsupported
+}
+
+// PullRequestChanges are the changes of the labels, reviewers and draft of a pull request's spec since it was last
+// opened or updated on the SCM.
+type PullRequestChanges struct {
+	// RemovedLabels are the labels that were removed from the spec. The spec's labels are added on every update, so
+	// that the ones that failed to be added when the pull request was opened are added later.
+	RemovedLabels []string
+	// AddedReviewers and RemovedReviewers are the reviewers that were added to and removed from the spec.
+	AddedReviewers, RemovedReviewers []string
+	// DraftChanged is whether the spec's draft changed. The pull request is then converted to a draft if spec.draft
+	// is true, and marked as ready otherwise.
+	DraftChanged bool
+}
+
+// GetPullRequestChanges returns the changes of the PullRequest's spec since its status was set, when the pull request
+// was last opened or updated on the SCM.
+func GetPullRequestChanges(pullRequest v1alpha1.PullRequest) PullRequestChanges {
+	return PullRequestChanges{
+		RemovedLabels:    missing(pullRequest.Status.Labels, pullRequest.Spec.Labels),
+		AddedReviewers:   missing(pullRequest.Spec.Reviewers, pullRequest.Status.Reviewers),
+		RemovedReviewers: missing(pullRequest.Status.Reviewers, pullRequest.Spec.Reviewers),
+		DraftChanged:     pullRequest.Spec.Draft != pullRequest.Status.Draft,
+	}
+}
+
+// missing returns the values that aren't in list.
+func missing(values, list []string) []string {
+	var result []string
+	for _, value := range values {
+		if !slices.Contains(list, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+package scms
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+// RepositoryProvider defines the interface for looking up a repository in a source control management system.
+type RepositoryProvider interface {
+	// GetRepository gets the repository, which also checks that it exists and can be read with the provider's
+	// credentials. It returns a RepositoryNotFoundError or a RepositoryAccessDeniedError if the SCM answered that it
+	// can't, and any other error if the lookup itself failed.
+	GetRepository(ctx context.Context, gitRepo v1alpha1.GitRepository) (*Repository, error)
+}
+
+// ReportedCloneUrls returns the clone URLs the SCM reported in the status of the GitRepository, which the other
+// controllers use instead of building them from the spec. They are empty until the GitRepository controller has looked
+// the repository up, and while the status was reconciled from an earlier spec, which may name another repository.
+func ReportedCloneUrls(gitRepo v1alpha1.GitRepository) (httpsURL string, sshURL string) {
+	if gitRepo.Status.ObservedGeneration != gitRepo.Generation {
+		return "", ""
+	}
+	return gitRepo.Status.HttpsCloneUrl, gitRepo.Status.SshCloneUrl
+}
+
+// RepositoryWebUrl returns the URL of the repository's web page, the HTTPS clone URL the SCM reported without
+// credentials and .git suffix, or "" if there is none, see ReportedCloneUrls. The providers build the URLs of pull
+// requests and commits on it, and only fall back to building them from the repository's owner and name.
+func RepositoryWebUrl(gitRepo v1alpha1.GitRepository) string {
+	httpsURL, _ := ReportedCloneUrls(gitRepo)
+	u, err := url.Parse(httpsURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	u.User = nil
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), ".git")
+	u.RawPath = ""
+	return u.String()
+}
+
+// Repository describes a repository as the SCM reports it.
+type Repository struct {
+	// ID is the SCM's internal ID of the repository, empty if the SCM doesn't have one.
+	ID string
+	// ProjectID is the SCM's internal ID of the project the repository belongs to, for SCMs whose API identifies
+	// repositories within a project, such as Azure DevOps. It is empty for the others.
+	ProjectID string
+	// DefaultBranch is the repository's default branch, empty if the repository has no commits yet.
+	DefaultBranch string
+	// HttpsCloneUrl is the URL to clone the repository over HTTPS.
+	HttpsCloneUrl string
+	// SshCloneUrl is the URL to clone the repository over SSH.
+	SshCloneUrl string
+	// Archived is whether the repository is archived, disabled or otherwise read-only on the SCM, so that nothing can
+	// be pushed to it and no pull requests or commit statuses can be created in it.
+	Archived bool
+}
+
+// RepositoryNotFoundError indicates that the SCM reported the repository doesn't exist. SCMs that hide private
+// repositories from unauthorized users report missing permissions this way too.
+type RepositoryNotFoundError struct {
+	// Message is the SCM's description of the failure.
+	Message string
+}
+
+// Error implements the error interface for RepositoryNotFoundError.
+func (e *RepositoryNotFoundError) Error() string {
+	return "repository not found: " + e.Message
+}
+
+// RepositoryAccessDeniedError indicates that the SCM rejected the provider's credentials for the repository.
+type RepositoryAccessDeniedError struct {
+	// Message is the SCM's description of the failure.
+	Message string
+}
+
+// Error implements the error interface for RepositoryAccessDeniedError.
+func (e *RepositoryAccessDeniedError) Error() string {
+	return "repository access denied: " + e.Message
+}
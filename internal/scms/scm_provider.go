@@ -0,0 +1,30 @@
+package scms
+
+import (
+	"fmt"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+// UnsupportedScmProviderError indicates that an ScmProvider or ClusterScmProvider doesn't configure any SCM the
+// controller supports, so no provider can be created for it.
+type UnsupportedScmProviderError struct {
+	// Kind is ScmProvider or ClusterScmProvider.
+	Kind string
+	// Name is the name of the ScmProvider or ClusterScmProvider.
+	Name string
+}
+
+// NewUnsupportedScmProviderError returns an UnsupportedScmProviderError for the ScmProvider or ClusterScmProvider.
+func NewUnsupportedScmProviderError(scmProvider v1alpha1.GenericScmProvider) *UnsupportedScmProviderError {
+	kind := v1alpha1.ScmProviderKind
+	if _, ok := scmProvider.(*v1alpha1.ClusterScmProvider); ok {
+		kind = v1alpha1.ClusterScmProviderKind
+	}
+	return &UnsupportedScmProviderError{Kind: kind, Name: scmProvider.GetName()}
+}
+
+// Error implements the error interface for UnsupportedScmProviderError.
+func (e *UnsupportedScmProviderError) Error() string {
+	return fmt.Sprintf("%s %q doesn't configure a supported SCM, set exactly one of its provider fields", e.Kind, e.Name)
+}
@@ -0,0 +1,39 @@
+package scms
+
+import (
+	"context"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+// WebhookProvider defines the interface for managing a repository's webhooks in a source control management system.
+type WebhookProvider interface {
+	// Ensure creates the webhook, or updates the webhook with the given ID to match it. If the webhook with the given ID
+	// doesn't exist anymore, it is created again. It returns the ID of the webhook, and a WebhookAccessDeniedError if
+	// the provider's credentials are not allowed to manage the repository's webhooks.
+	Ensure(ctx context.Context, gitRepo v1alpha1.GitRepository, id string, webhook Webhook) (string, error)
+	// Delete deletes the webhook with the given ID. A webhook that doesn't exist anymore is not an error.
+	Delete(ctx context.Context, gitRepo v1alpha1.GitRepository, id string) error
+}
+
+// Webhook describes a webhook to register on a repository.
+type Webhook struct {
+	// Url is the URL the SCM sends the events to.
+	Url string
+	// Secret is the secret shared with the receiver, empty for none.
+	Secret string
+	// Events are the events the webhook is sent for.
+	Events []v1alpha1.WebhookEvent
+}
+
+// WebhookAccessDeniedError indicates that the SCM rejected the provider's credentials for managing the repository's
+// webhooks.
+type WebhookAccessDeniedError struct {
+	// Message is the SCM's description of the failure.
+	Message string
+}
+
+// Error implements the error interface for WebhookAccessDeniedError.
+func (e *WebhookAccessDeniedError) Error() string {
+	return "webhook access denied: " + e.Message
+}
@@ -4,17 +4,52 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"golang.org/x/time/rate"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
 	// ControllerConfigurationName is the name of the global controller configuration resource.
 	ControllerConfigurationName = "promoter-controller-configuration"
+
+	// DefaultCloneIdleTimeout is how long a cached clone may go unused before it is removed, when the
+	// ControllerConfiguration doesn't set one.
+	DefaultCloneIdleTimeout = 24 * time.Hour
+
+	// DefaultMinReconcileInterval is the shortest reconcileInterval a ChangeTransferPolicy may set, when the
+	// ControllerConfiguration doesn't set one.
+	DefaultMinReconcileInterval = 10 * time.Second
+
+	// DefaultAccessCheckInterval is how often the GitRepository controller checks that a repository can be accessed,
+	// when the ControllerConfiguration doesn't set it.
+	DefaultAccessCheckInterval = 5 * time.Minute
+
+	// DefaultScmProviderRequeueDuration is how often the ScmProvider and ClusterScmProvider controllers check the
+	// credentials of a provider, when the controller isn't started with another duration.
+	DefaultScmProviderRequeueDuration = 5 * time.Minute
+
+	// DefaultWebhookFreshness is how long a verified webhook delivery is considered fresh for adaptive polling, when
+	// the ControllerConfiguration doesn't set it.
+	DefaultWebhookFreshness = 30 * time.Minute
+
+	// DefaultRateLimiterBaseDelay is how long a request is requeued after its first failure, when the controller isn't
+	// started with another delay. It is controller-runtime's default.
+	DefaultRateLimiterBaseDelay = 5 * time.Millisecond
+	// DefaultRateLimiterMaxDelay is the longest a request is requeued after failing, when the controller isn't started
+	// with another delay. It is controller-runtime's default.
+	DefaultRateLimiterMaxDelay = 1000 * time.Second
+	// DefaultRateLimiterQPS is how many requests each controller requeues per second overall, when the controller isn't
+	// started with another rate. It is controller-runtime's default.
+	DefaultRateLimiterQPS = 10
+	// DefaultRateLimiterBurst is how many requests each controller may requeue at once beyond DefaultRateLimiterQPS,
+	// when the controller isn't started with another burst. It is controller-runtime's default.
+	DefaultRateLimiterBurst = 100
 )
 
 // ControllerConfigurationTypes is a constraint that defines the set of controller configuration types
@@ -57,11 +92,67 @@ type ControllerResultTypes interface {
 // This configuration is provided at Manager creation time and contains runtime parameters
 // that don't change during the Manager's lifetime. It's used in conjunction with the
 // dynamically-fetched ControllerConfiguration resource to provide complete configuration
-// information for the controller.
+// information for the controller. Most of its fields come from the controller's flags, and the ControllerConfiguration
+// takes precedence over them: a setting of the ControllerConfiguration is used if it is set, then the ManagerConfig's,
+// then the default.
 type ManagerConfig struct {
 	// ControllerNamespace is the namespace where the promoter controller is running.
 	// This namespace is used when fetching the ControllerConfiguration resource from the cluster.
 	ControllerNamespace string
+	// GitRepositoryRequeueDuration is how often the GitRepository controller checks that a repository can be accessed.
+	// When set, it takes precedence over the ControllerConfiguration's spec.gitRepository.accessCheckInterval.
+	GitRepositoryRequeueDuration time.Duration
+	// ScmProviderRequeueDuration is how often the ScmProvider and ClusterScmProvider controllers check the credentials
+	// of a provider. When set, it takes precedence over the ControllerConfiguration's
+	// spec.scmProvider.credentialsCheckInterval.
+	ScmProviderRequeueDuration time.Duration
+	// RateLimiter configures the rate limiter of the controllers whose ControllerConfiguration doesn't set one.
+	RateLimiter RateLimiterConfig
+	// CloneDepth is the number of commits fetched when the ChangeTransferPolicy controller clones a repository, when
+	// the ControllerConfiguration doesn't set it. Zero clones the full history.
+	CloneDepth int32
+}
+
+// RateLimiterConfig configures a rate limiter that requeues a failing request with an exponential backoff, and limits
+// how many requests the controller requeues overall with a token bucket. Each field defaults to the matching
+// DefaultRateLimiter constant when it is zero.
+type RateLimiterConfig struct {
+	// BaseDelay is how long a request is requeued after its first failure. The delay doubles with each failure.
+	BaseDelay time.Duration
+	// MaxDelay is the longest a request is requeued after failing.
+	MaxDelay time.Duration
+	// QPS is how many requests are requeued per second overall.
+	QPS float64
+	// Burst is how many requests may be requeued at once beyond QPS.
+	Burst int
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c RateLimiterConfig) Validate() error {
+	if c.BaseDelay < 0 || c.MaxDelay < 0 || c.QPS < 0 || c.Burst < 0 {
+		return errors.New("rate limiter delays, qps and burst must not be negative")
+	}
+	if c.BaseDelay > 0 && c.MaxDelay > 0 && c.BaseDelay > c.MaxDelay {
+		return fmt.Errorf("rate limiter base delay %s must not be longer than its max delay %s", c.BaseDelay, c.MaxDelay)
+	}
+	return nil
+}
+
+// withDefaults returns the configuration with its zero fields set to their defaults.
+func (c RateLimiterConfig) withDefaults() RateLimiterConfig {
+	if c.BaseDelay == 0 {
+		c.BaseDelay = DefaultRateLimiterBaseDelay
+	}
+	if c.MaxDelay == 0 {
+		c.MaxDelay = DefaultRateLimiterMaxDelay
+	}
+	if c.QPS == 0 {
+		c.QPS = DefaultRateLimiterQPS
+	}
+	if c.Burst == 0 {
+		c.Burst = DefaultRateLimiterBurst
+	}
+	return c
 }
 
 // Manager is responsible for managing the global controller configuration for the promoter controller.
@@ -76,6 +167,16 @@ type Manager struct {
 	apiReader client.Reader
 	// config holds the static configuration for the Manager, including the controller's namespace.
 	config ManagerConfig
+
+	// mutex guards valid, invalidResourceVersion and invalidErr.
+	mutex sync.Mutex
+	// valid is the last ControllerConfiguration that was valid. It is used instead of the current one while the current
+	// one is invalid, so that an invalid update doesn't stop the controllers.
+	valid *promoterv1alpha1.ControllerConfiguration
+	// invalidResourceVersion is the resource version of the last ControllerConfiguration that was invalid, so that it
+	// isn't validated again, and invalidErr is why it is invalid.
+	invalidResourceVersion string
+	invalidErr             error
 }
 
 // getControllerConfiguration retrieves the global controller configuration for the promoter controller.
@@ -98,7 +199,7 @@ func (m *Manager) getControllerConfiguration(ctx context.Context) (*promoterv1al
 		return nil, fmt.Errorf("failed to get global promotion configuration: %w", err)
 	}
 
-	return controllerConfiguration, nil
+	return m.lastValid(ctx, controllerConfiguration)
 }
 
 // getControllerConfigurationDirect retrieves the global controller configuration directly from the API server.
@@ -119,7 +220,37 @@ func (m *Manager) getControllerConfigurationDirect(ctx context.Context) (*promot
 		return nil, fmt.Errorf("failed to get global promotion configuration: %w", err)
 	}
 
-	return controllerConfiguration, nil
+	return m.lastValid(ctx, controllerConfiguration)
+}
+
+// lastValid returns controllerConfiguration if it is valid, or else the last ControllerConfiguration that was valid.
+// It returns an error if no ControllerConfiguration was valid yet. The ControllerConfiguration controller records an
+// event on an invalid ControllerConfiguration. The returned ControllerConfiguration is shared and must not be modified.
+func (m *Manager) lastValid(ctx context.Context, controllerConfiguration *promoterv1alpha1.ControllerConfiguration) (*promoterv1alpha1.ControllerConfiguration, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	resourceVersion := controllerConfiguration.ResourceVersion
+	if m.valid != nil && m.valid.ResourceVersion == resourceVersion {
+		return m.valid, nil
+	}
+	if m.invalidResourceVersion != resourceVersion {
+		if err := ValidateControllerConfiguration(&controllerConfiguration.Spec); err != nil {
+			m.invalidResourceVersion = resourceVersion
+			m.invalidErr = err
+			if m.valid != nil {
+				log.FromContext(ctx).Info("Ignoring the invalid ControllerConfiguration, using the last valid one",
+					"resourceVersion", resourceVersion, "lastValidResourceVersion", m.valid.ResourceVersion, "error", err.Error())
+			}
+		} else {
+			m.valid = controllerConfiguration
+			return controllerConfiguration, nil
+		}
+	}
+	if m.valid == nil {
+		return nil, fmt.Errorf("invalid controller configuration: %w", m.invalidErr)
+	}
+	return m.valid, nil
 }
 
 // GetControllerNamespace returns the namespace where the controller is running.
@@ -165,6 +296,183 @@ func (m *Manager) GetPullRequestControllersTemplate(ctx context.Context) (promot
 	return config.Spec.PullRequest.Template, nil
 }
 
+// GetChangeTransferPolicyCloneDepth retrieves the number of commits fetched when the ChangeTransferPolicy controller
+// clones a repository. Zero means the full history is cloned.
+//
+// This function fetches the ControllerConfiguration resource from the cluster. It requires the manager's cache to be
+// started, so do not call this method during SetupWithManager. Instead, call it from within your Reconcile method.
+//
+// Parameters:
+//   - ctx: Context for the request, used for cancellation and deadlines
+//
+// Returns the configured clone depth, or the CloneDepth of the ManagerConfig if it is not set, or an error if the
+// configuration cannot be retrieved.
+func (m *Manager) GetChangeTransferPolicyCloneDepth(ctx context.Context) (int32, error) {
+	config, err := m.getControllerConfiguration(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get controller configuration: %w", err)
+	}
+	if config.Spec.ChangeTransferPolicy.CloneDepth != nil {
+		return *config.Spec.ChangeTransferPolicy.CloneDepth, nil
+	}
+	return m.config.CloneDepth, nil
+}
+
+// GetChangeTransferPolicyCloneIdleTimeout retrieves how long a cached clone may go unused before it is removed. Zero
+// means clones are only removed when their ChangeTransferPolicy or GitRepository is deleted.
+//
+// This function fetches the ControllerConfiguration resource from the cluster. It requires the manager's cache to be
+// started, so do not call this method during SetupWithManager.
+//
+// Parameters:
+//   - ctx: Context for the request, used for cancellation and deadlines
+//
+// Returns the configured idle timeout, DefaultCloneIdleTimeout if it is not set, or an error if the configuration
+// cannot be retrieved.
+func (m *Manager) GetChangeTransferPolicyCloneIdleTimeout(ctx context.Context) (time.Duration, error) {
+	config, err := m.getControllerConfiguration(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get controller configuration: %w", err)
+	}
+	if config.Spec.ChangeTransferPolicy.CloneIdleTimeout == nil {
+		return DefaultCloneIdleTimeout, nil
+	}
+	return config.Spec.ChangeTransferPolicy.CloneIdleTimeout.Duration, nil
+}
+
+// GetChangeTransferPolicyMinReconcileInterval retrieves the shortest reconcileInterval a ChangeTransferPolicy may set.
+//
+// This function fetches the ControllerConfiguration resource from the cluster. It requires the manager's cache to be
+// started, so do not call this method during SetupWithManager.
+//
+// Parameters:
+//   - ctx: Context for the request, used for cancellation and deadlines
+//
+// Returns the configured minimum, DefaultMinReconcileInterval if it is not set, or an error if the configuration cannot
+// be retrieved.
+func (m *Manager) GetChangeTransferPolicyMinReconcileInterval(ctx context.Context) (time.Duration, error) {
+	config, err := m.getControllerConfiguration(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get controller configuration: %w", err)
+	}
+	if config.Spec.ChangeTransferPolicy.MinReconcileInterval == nil {
+		return DefaultMinReconcileInterval, nil
+	}
+	return config.Spec.ChangeTransferPolicy.MinReconcileInterval.Duration, nil
+}
+
+// GetGitRepositoryAccessCheckInterval retrieves how often the GitRepository controller checks that a repository exists
+// and can be accessed.
+//
+// This function fetches the ControllerConfiguration resource from the cluster. It requires the manager's cache to be
+// started, so do not call this method during SetupWithManager.
+//
+// Parameters:
+//   - ctx: Context for the request, used for cancellation and deadlines
+//
+// Returns the GitRepositoryRequeueDuration of the ManagerConfig if it is set, the configured interval, or
+// DefaultAccessCheckInterval if neither is set, or an error if the configuration cannot be retrieved.
+func (m *Manager) GetGitRepositoryAccessCheckInterval(ctx context.Context) (time.Duration, error) {
+	if m.config.GitRepositoryRequeueDuration > 0 {
+		return m.config.GitRepositoryRequeueDuration, nil
+	}
+	config, err := m.getControllerConfiguration(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get controller configuration: %w", err)
+	}
+	if config.Spec.GitRepository.AccessCheckInterval != nil {
+		return config.Spec.GitRepository.AccessCheckInterval.Duration, nil
+	}
+	return DefaultAccessCheckInterval, nil
+}
+
+// GetAdaptivePollingMaxRequeueDuration retrieves the longest ChangeTransferPolicies and PromotionStrategies wait
+// between reconciles while webhook deliveries for their GitRepository are fresh. Zero means adaptive polling is
+// disabled.
+//
+// This function fetches the ControllerConfiguration resource from the cluster. It requires the manager's cache to be
+// started, so do not call this method during SetupWithManager.
+//
+// Parameters:
+//   - ctx: Context for the request, used for cancellation and deadlines
+//
+// Returns the configured maximum, zero if it is not set, or an error if the configuration cannot be retrieved.
+func (m *Manager) GetAdaptivePollingMaxRequeueDuration(ctx context.Context) (time.Duration, error) {
+	config, err := m.getControllerConfiguration(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get controller configuration: %w", err)
+	}
+	if config.Spec.AdaptivePolling.MaxRequeueDuration == nil {
+		return 0, nil
+	}
+	return config.Spec.AdaptivePolling.MaxRequeueDuration.Duration, nil
+}
+
+// GetAdaptivePollingWebhookFreshness retrieves how long a verified webhook delivery for a GitRepository is considered
+// fresh.
+//
+// This function fetches the ControllerConfiguration resource from the cluster. It requires the manager's cache to be
+// started, so do not call this method during SetupWithManager.
+//
+// Parameters:
+//   - ctx: Context for the request, used for cancellation and deadlines
+//
+// Returns the configured freshness, DefaultWebhookFreshness if it is not set, or an error if the configuration cannot
+// be retrieved.
+func (m *Manager) GetAdaptivePollingWebhookFreshness(ctx context.Context) (time.Duration, error) {
+	config, err := m.getControllerConfiguration(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get controller configuration: %w", err)
+	}
+	if config.Spec.AdaptivePolling.WebhookFreshness == nil {
+		return DefaultWebhookFreshness, nil
+	}
+	return config.Spec.AdaptivePolling.WebhookFreshness.Duration, nil
+}
+
+// GetScmProviderRequeueDuration retrieves how often the ScmProvider and ClusterScmProvider controllers check the
+// credentials of a provider.
+//
+// This function fetches the ControllerConfiguration resource from the cluster. It requires the manager's cache to be
+// started, so do not call this method during SetupWithManager.
+//
+// Parameters:
+//   - ctx: Context for the request, used for cancellation and deadlines
+//
+// Returns the ScmProviderRequeueDuration of the ManagerConfig if it is set, the configured interval, or
+// DefaultScmProviderRequeueDuration if neither is set, or an error if the configuration cannot be retrieved.
+func (m *Manager) GetScmProviderRequeueDuration(ctx context.Context) (time.Duration, error) {
+	if m.config.ScmProviderRequeueDuration > 0 {
+		return m.config.ScmProviderRequeueDuration, nil
+	}
+	config, err := m.getControllerConfiguration(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get controller configuration: %w", err)
+	}
+	if config.Spec.ScmProvider.CredentialsCheckInterval != nil {
+		return config.Spec.ScmProvider.CredentialsCheckInterval.Duration, nil
+	}
+	return DefaultScmProviderRequeueDuration, nil
+}
+
+// GetChangeTransferPolicyAlwaysOpenPullRequests retrieves whether the ChangeTransferPolicy controller opens pull
+// requests for proposed dry commits that don't change the hydrated manifests.
+//
+// This function fetches the ControllerConfiguration resource from the cluster. It requires the manager's cache to be
+// started, so do not call this method during SetupWithManager. Instead, call it from within your Reconcile method.
+//
+// Parameters:
+//   - ctx: Context for the request, used for cancellation and deadlines
+//
+// Returns true if a pull request should always be opened, or an error if the configuration cannot be retrieved.
+func (m *Manager) GetChangeTransferPolicyAlwaysOpenPullRequests(ctx context.Context) (bool, error) {
+	config, err := m.getControllerConfiguration(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get controller configuration: %w", err)
+	}
+	return config.Spec.ChangeTransferPolicy.AlwaysOpenPullRequests, nil
+}
+
 // GetRequeueDuration retrieves the requeue duration for a specific controller type.
 // The type parameter T must satisfy the ControllerConfigurationTypes constraint.
 //
@@ -217,7 +525,9 @@ func GetMaxConcurrentReconcilesDirect[T ControllerConfigurationTypes](ctx contex
 // at build time based on the ControllerConfiguration resource.
 //
 // The returned rate limiter can be one of several types (FastSlow, ExponentialFailure, Bucket, or MaxOf)
-// depending on the configuration. See buildRateLimiter for details on supported limiter types.
+// depending on the configuration. See buildRateLimiter for details on supported limiter types. If the
+// ControllerConfiguration doesn't configure a rate limiter for the controller, the one of GetDefaultRateLimiter is
+// returned.
 //
 // Parameters:
 //   - ctx: Context for the request, used for cancellation and deadlines
@@ -231,6 +541,11 @@ func GetRateLimiterDirect[T ControllerConfigurationTypes, R ControllerResultType
 		return nil, fmt.Errorf("failed to get work queue for controller: %w", err)
 	}
 
+	if workQueue.RateLimiter.FastSlow == nil && workQueue.RateLimiter.ExponentialFailure == nil &&
+		workQueue.RateLimiter.Bucket == nil && len(workQueue.RateLimiter.MaxOf) == 0 {
+		return GetDefaultRateLimiter[R](m), nil
+	}
+
 	limiter, err := buildRateLimiter[R](workQueue.RateLimiter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build rate limiter: %w", err)
@@ -239,6 +554,22 @@ func GetRateLimiterDirect[T ControllerConfigurationTypes, R ControllerResultType
 	return limiter, nil
 }
 
+// GetDefaultRateLimiter returns a new rate limiter configured with the RateLimiter of the ManagerConfig, for the
+// controllers that have no rate limiter in the ControllerConfiguration. Each controller needs its own rate limiter,
+// since it tracks the failures of the controller's requests.
+//
+// The type parameter R is the request type for the rate limiter (e.g., ctrl.Request or mcreconcile.Request).
+//
+// A failing request is requeued after BaseDelay, doubling with each failure up to MaxDelay, and no more than QPS
+// requests are requeued per second overall, with bursts of Burst.
+func GetDefaultRateLimiter[R ControllerResultTypes](m *Manager) workqueue.TypedRateLimiter[R] {
+	config := m.config.RateLimiter.withDefaults()
+	return workqueue.NewTypedMaxOfRateLimiter[R](
+		workqueue.NewTypedItemExponentialFailureRateLimiter[R](config.BaseDelay, config.MaxDelay),
+		&workqueue.TypedBucketRateLimiter[R]{Limiter: rate.NewLimiter(rate.Limit(config.QPS), config.Burst)},
+	)
+}
+
 // getWorkQueueForController retrieves the WorkQueue configuration for a specific controller type.
 // The type parameter T must satisfy the ControllerConfigurationTypes constraint.
 //
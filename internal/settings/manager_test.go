@@ -0,0 +1,315 @@
+package settings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+// newTestManager returns a Manager reading a ControllerConfiguration whose PullRequest work queue has an exponential
+// failure rate limiter and whose PromotionStrategy work queue has none.
+func newTestManager(t *testing.T, rateLimiter RateLimiterConfig) *Manager {
+	t.Helper()
+
+	config := &promoterv1alpha1.ControllerConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: ControllerConfigurationName, Namespace: "promoter-system"},
+		Spec: promoterv1alpha1.ControllerConfigurationSpec{
+			PullRequest: promoterv1alpha1.PullRequestConfiguration{
+				WorkQueue: promoterv1alpha1.WorkQueue{
+					MaxConcurrentReconciles: 1,
+					RateLimiter: promoterv1alpha1.RateLimiter{
+						RateLimiterTypes: promoterv1alpha1.RateLimiterTypes{
+							ExponentialFailure: &promoterv1alpha1.ExponentialFailure{
+								BaseDelay: metav1.Duration{Duration: time.Second},
+								MaxDelay:  metav1.Duration{Duration: 4 * time.Second},
+							},
+						},
+					},
+				},
+			},
+			PromotionStrategy: promoterv1alpha1.PromotionStrategyConfiguration{
+				WorkQueue: promoterv1alpha1.WorkQueue{MaxConcurrentReconciles: 1},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(utils.GetScheme()).WithObjects(config).Build()
+	return NewManager(c, c, ManagerConfig{ControllerNamespace: "promoter-system", RateLimiter: rateLimiter})
+}
+
+// delays returns the delays the rate limiter requeues a request with after each of failures failures.
+func delays(limiter workqueue.TypedRateLimiter[ctrl.Request], failures int) []time.Duration {
+	var delays []time.Duration
+	for range failures {
+		delays = append(delays, limiter.When(ctrl.Request{}))
+	}
+	return delays
+}
+
+func TestGetRateLimiterDirect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		rateLimiter    RateLimiterConfig
+		configured     bool
+		expectedDelays []time.Duration
+	}{
+		{
+			name:           "rate limiter of the ControllerConfiguration",
+			rateLimiter:    RateLimiterConfig{BaseDelay: 10 * time.Millisecond, MaxDelay: 40 * time.Millisecond},
+			configured:     true,
+			expectedDelays: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second},
+		},
+		{
+			name:        "configured default rate limiter",
+			rateLimiter: RateLimiterConfig{BaseDelay: 10 * time.Millisecond, MaxDelay: 40 * time.Millisecond},
+			expectedDelays: []time.Duration{
+				10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 40 * time.Millisecond,
+			},
+		},
+		{
+			name:           "controller-runtime's default rate limiter",
+			expectedDelays: []time.Duration{5 * time.Millisecond, 10 * time.Millisecond, 20 * time.Millisecond},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := newTestManager(t, test.rateLimiter)
+			var limiter workqueue.TypedRateLimiter[ctrl.Request]
+			var err error
+			if test.configured {
+				limiter, err = GetRateLimiterDirect[promoterv1alpha1.PullRequestConfiguration, ctrl.Request](context.Background(), m)
+			} else {
+				limiter, err = GetRateLimiterDirect[promoterv1alpha1.PromotionStrategyConfiguration, ctrl.Request](context.Background(), m)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			actual := delays(limiter, len(test.expectedDelays))
+			for i := range actual {
+				if actual[i] != test.expectedDelays[i] {
+					t.Fatalf("expected delays %v, got %v", test.expectedDelays, actual)
+				}
+			}
+			if limiter.NumRequeues(ctrl.Request{}) != len(test.expectedDelays) {
+				t.Errorf("expected %d requeues, got %d", len(test.expectedDelays), limiter.NumRequeues(ctrl.Request{}))
+			}
+			limiter.Forget(ctrl.Request{})
+			if delay := limiter.When(ctrl.Request{}); delay != test.expectedDelays[0] {
+				t.Errorf("expected the delay to start over at %s once the request is forgotten, got %s", test.expectedDelays[0], delay)
+			}
+		})
+	}
+}
+
+func TestGetDefaultRateLimiterLimitsRequeuesOverall(t *testing.T) {
+	t.Parallel()
+
+	limiter := GetDefaultRateLimiter[ctrl.Request](newTestManager(t, RateLimiterConfig{
+		BaseDelay: time.Millisecond,
+		MaxDelay:  time.Millisecond,
+		QPS:       1,
+		Burst:     1,
+	}))
+
+	// The first request takes the only token, so the next one waits about a second for the bucket to refill even
+	// though it never failed before.
+	if delay := limiter.When(ctrl.Request{NamespacedName: types.NamespacedName{Name: "first"}}); delay != time.Millisecond {
+		t.Errorf("expected the first request to be requeued after the base delay, got %s", delay)
+	}
+	if delay := limiter.When(ctrl.Request{NamespacedName: types.NamespacedName{Name: "second"}}); delay < 900*time.Millisecond {
+		t.Errorf("expected the second request to wait for the bucket to refill, got %s", delay)
+	}
+}
+
+func TestRateLimiterConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config RateLimiterConfig
+		valid  bool
+	}{
+		{name: "defaults", valid: true},
+		{name: "configured", config: RateLimiterConfig{BaseDelay: time.Second, MaxDelay: time.Minute, QPS: 5, Burst: 10}, valid: true},
+		{name: "negative delay", config: RateLimiterConfig{BaseDelay: -time.Second}},
+		{name: "negative qps", config: RateLimiterConfig{QPS: -1}},
+		{name: "base delay longer than max delay", config: RateLimiterConfig{BaseDelay: time.Minute, MaxDelay: time.Second}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := test.config.Validate()
+			if test.valid && err != nil {
+				t.Errorf("expected the configuration to be valid, got %v", err)
+			}
+			if !test.valid && err == nil {
+				t.Error("expected the configuration to be invalid")
+			}
+		})
+	}
+}
+
+func TestManagerKeepsLastValidControllerConfiguration(t *testing.T) {
+	t.Parallel()
+
+	config := &promoterv1alpha1.ControllerConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: ControllerConfigurationName, Namespace: "promoter-system"},
+		Spec: promoterv1alpha1.ControllerConfigurationSpec{
+			PullRequest: promoterv1alpha1.PullRequestConfiguration{
+				Template: promoterv1alpha1.PullRequestTemplate{Title: "Promote {{ .ChangeTransferPolicy.Name }}"},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(utils.GetScheme()).WithObjects(config).Build()
+	m := NewManager(c, c, ManagerConfig{ControllerNamespace: "promoter-system"})
+	ctx := context.Background()
+
+	title := func() string {
+		t.Helper()
+		template, err := m.GetPullRequestControllersTemplate(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return template.Title
+	}
+	update := func(title string) {
+		t.Helper()
+		if err := c.Get(ctx, types.NamespacedName{Name: config.Name, Namespace: config.Namespace}, config); err != nil {
+			t.Fatalf("failed to get the configuration: %v", err)
+		}
+		config.Spec.PullRequest.Template.Title = title
+		if err := c.Update(ctx, config); err != nil {
+			t.Fatalf("failed to update the configuration: %v", err)
+		}
+	}
+
+	if actual := title(); actual != "Promote {{ .ChangeTransferPolicy.Name }}" {
+		t.Fatalf("expected the configured title, got %q", actual)
+	}
+
+	update("Promote {{ .ChangeTransferPolicy.Name")
+	if actual := title(); actual != "Promote {{ .ChangeTransferPolicy.Name }}" {
+		t.Errorf("expected the invalid update to be ignored, got %q", actual)
+	}
+
+	update("Promote {{ .PromotionStrategy.Name }}")
+	if actual := title(); actual != "Promote {{ .PromotionStrategy.Name }}" {
+		t.Errorf("expected the valid update to be used, got %q", actual)
+	}
+}
+
+func TestManagerFailsWithoutValidControllerConfiguration(t *testing.T) {
+	t.Parallel()
+
+	config := &promoterv1alpha1.ControllerConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: ControllerConfigurationName, Namespace: "promoter-system"},
+		Spec: promoterv1alpha1.ControllerConfigurationSpec{
+			PullRequest: promoterv1alpha1.PullRequestConfiguration{
+				Template: promoterv1alpha1.PullRequestTemplate{Title: "{{ end }}"},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(utils.GetScheme()).WithObjects(config).Build()
+	m := NewManager(c, c, ManagerConfig{ControllerNamespace: "promoter-system"})
+
+	for range 2 {
+		if _, err := m.GetPullRequestControllersTemplate(context.Background()); err == nil {
+			t.Fatal("expected an error while no configuration was valid")
+		}
+	}
+}
+
+func TestGetScmProviderRequeueDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		configured *metav1.Duration
+		flag       time.Duration
+		expected   time.Duration
+	}{
+		{name: "default", expected: DefaultScmProviderRequeueDuration},
+		{name: "flag", flag: time.Minute, expected: time.Minute},
+		{name: "configuration", configured: &metav1.Duration{Duration: time.Hour}, expected: time.Hour},
+		{name: "flag over configuration", configured: &metav1.Duration{Duration: time.Hour}, flag: time.Minute, expected: time.Minute},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := &promoterv1alpha1.ControllerConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: ControllerConfigurationName, Namespace: "promoter-system"},
+				Spec: promoterv1alpha1.ControllerConfigurationSpec{
+					ScmProvider: promoterv1alpha1.ScmProviderConfiguration{CredentialsCheckInterval: test.configured},
+				},
+			}
+			c := fake.NewClientBuilder().WithScheme(utils.GetScheme()).WithObjects(config).Build()
+			m := NewManager(c, c, ManagerConfig{ControllerNamespace: "promoter-system", ScmProviderRequeueDuration: test.flag})
+
+			actual, err := m.GetScmProviderRequeueDuration(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetChangeTransferPolicyCloneDepth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		configured *int32
+		flag       int32
+		expected   int32
+	}{
+		{name: "default", expected: 0},
+		{name: "flag", flag: 50, expected: 50},
+		{name: "configuration over flag", configured: ptr.To[int32](10), flag: 50, expected: 10},
+		{name: "full history configured over flag", configured: ptr.To[int32](0), flag: 50, expected: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := &promoterv1alpha1.ControllerConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: ControllerConfigurationName, Namespace: "promoter-system"},
+				Spec: promoterv1alpha1.ControllerConfigurationSpec{
+					ChangeTransferPolicy: promoterv1alpha1.ChangeTransferPolicyConfiguration{CloneDepth: test.configured},
+				},
+			}
+			c := fake.NewClientBuilder().WithScheme(utils.GetScheme()).WithObjects(config).Build()
+			m := NewManager(c, c, ManagerConfig{ControllerNamespace: "promoter-system", CloneDepth: test.flag})
+
+			actual, err := m.GetChangeTransferPolicyCloneDepth(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != test.expected {
+				t.Errorf("expected %d, got %d", test.expected, actual)
+			}
+		})
+	}
+}
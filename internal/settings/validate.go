@@ -0,0 +1,100 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+// ValidateControllerConfiguration returns an error listing what is invalid in spec beyond what the CRD's schema checks,
+// such as pull request templates that don't parse or negative durations. The Manager ignores an invalid
+// ControllerConfiguration and keeps using the last valid one.
+func ValidateControllerConfiguration(spec *promoterv1alpha1.ControllerConfigurationSpec) error {
+	var errs []error
+
+	if err := utils.ValidateStringTemplate(spec.PullRequest.Template.Title); err != nil {
+		errs = append(errs, fmt.Errorf("pullRequest.template.title: %w", err))
+	}
+	if err := utils.ValidateStringTemplate(spec.PullRequest.Template.Description); err != nil {
+		errs = append(errs, fmt.Errorf("pullRequest.template.description: %w", err))
+	}
+
+	for _, workQueue := range []struct {
+		field     string
+		workQueue promoterv1alpha1.WorkQueue
+	}{
+		{"promotionStrategy", spec.PromotionStrategy.WorkQueue},
+		{"changeTransferPolicy", spec.ChangeTransferPolicy.WorkQueue},
+		{"pullRequest", spec.PullRequest.WorkQueue},
+		{"commitStatus", spec.CommitStatus.WorkQueue},
+		{"argocdCommitStatus", spec.ArgoCDCommitStatus.WorkQueue},
+		{"timedCommitStatus", spec.TimedCommitStatus.WorkQueue},
+		{"gitCommitStatus", spec.GitCommitStatus.WorkQueue},
+		{"webRequestCommitStatus", spec.WebRequestCommitStatus.WorkQueue},
+	} {
+		if err := validateWorkQueue(workQueue.workQueue); err != nil {
+			errs = append(errs, fmt.Errorf("%s.workQueue: %w", workQueue.field, err))
+		}
+	}
+
+	if depth := spec.ChangeTransferPolicy.CloneDepth; depth != nil && *depth < 0 {
+		errs = append(errs, fmt.Errorf("changeTransferPolicy.cloneDepth %d must not be negative", *depth))
+	}
+	for _, duration := range []struct {
+		field    string
+		duration *metav1.Duration
+		// positive is whether the duration must be positive rather than only not negative.
+		positive bool
+	}{
+		{"changeTransferPolicy.cloneIdleTimeout", spec.ChangeTransferPolicy.CloneIdleTimeout, false},
+		{"changeTransferPolicy.minReconcileInterval", spec.ChangeTransferPolicy.MinReconcileInterval, false},
+		{"gitRepository.accessCheckInterval", spec.GitRepository.AccessCheckInterval, true},
+		{"scmProvider.credentialsCheckInterval", spec.ScmProvider.CredentialsCheckInterval, true},
+		{"adaptivePolling.maxRequeueDuration", spec.AdaptivePolling.MaxRequeueDuration, false},
+		{"adaptivePolling.webhookFreshness", spec.AdaptivePolling.WebhookFreshness, false},
+	} {
+		switch {
+		case duration.duration == nil:
+		case duration.positive && duration.duration.Duration <= 0:
+			errs = append(errs, fmt.Errorf("%s %s must be positive", duration.field, duration.duration.Duration))
+		case duration.duration.Duration < 0:
+			errs = append(errs, fmt.Errorf("%s %s must not be negative", duration.field, duration.duration.Duration))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateWorkQueue returns an error if workQueue is invalid.
+func validateWorkQueue(workQueue promoterv1alpha1.WorkQueue) error {
+	if workQueue.RequeueDuration.Duration < 0 {
+		return fmt.Errorf("requeueDuration %s must not be negative", workQueue.RequeueDuration.Duration)
+	}
+
+	rateLimiter := workQueue.RateLimiter
+	if rateLimiter.FastSlow == nil && rateLimiter.ExponentialFailure == nil && rateLimiter.Bucket == nil && len(rateLimiter.MaxOf) == 0 {
+		return nil
+	}
+	if _, err := buildRateLimiter[ctrl.Request](rateLimiter); err != nil {
+		return fmt.Errorf("rateLimiter: %w", err)
+	}
+	for _, types := range append([]promoterv1alpha1.RateLimiterTypes{rateLimiter.RateLimiterTypes}, rateLimiter.MaxOf...) {
+		if types.FastSlow != nil && (types.FastSlow.FastDelay.Duration < 0 || types.FastSlow.SlowDelay.Duration < 0) {
+			return errors.New("rateLimiter.fastSlow: the delays must not be negative")
+		}
+		if types.ExponentialFailure != nil {
+			if types.ExponentialFailure.BaseDelay.Duration < 0 {
+				return errors.New("rateLimiter.exponentialFailure.baseDelay must not be negative")
+			}
+			if types.ExponentialFailure.MaxDelay.Duration < types.ExponentialFailure.BaseDelay.Duration {
+				return errors.New("rateLimiter.exponentialFailure.maxDelay must not be shorter than baseDelay")
+			}
+		}
+	}
+	return nil
+}
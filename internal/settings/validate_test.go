@@ -0,0 +1,103 @@
+package settings
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+func TestValidateControllerConfiguration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		modify func(spec *promoterv1alpha1.ControllerConfigurationSpec)
+		err    string
+	}{
+		{
+			name:   "valid",
+			modify: func(*promoterv1alpha1.ControllerConfigurationSpec) {},
+		},
+		{
+			name: "template that doesn't parse",
+			modify: func(spec *promoterv1alpha1.ControllerConfigurationSpec) {
+				spec.PullRequest.Template.Description = "{{ .ChangeTransferPolicy.Name"
+			},
+			err: "pullRequest.template.description",
+		},
+		{
+			name: "template calling a removed function",
+			modify: func(spec *promoterv1alpha1.ControllerConfigurationSpec) {
+				spec.PullRequest.Template.Title = `{{ env "HOME" }}`
+			},
+			err: "pullRequest.template.title",
+		},
+		{
+			name: "negative requeue duration",
+			modify: func(spec *promoterv1alpha1.ControllerConfigurationSpec) {
+				spec.CommitStatus.WorkQueue.RequeueDuration = metav1.Duration{Duration: -time.Minute}
+			},
+			err: "commitStatus.workQueue: requeueDuration -1m0s must not be negative",
+		},
+		{
+			name: "exponential failure with a max delay shorter than its base delay",
+			modify: func(spec *promoterv1alpha1.ControllerConfigurationSpec) {
+				spec.PromotionStrategy.WorkQueue.RateLimiter.MaxOf = []promoterv1alpha1.RateLimiterTypes{{
+					ExponentialFailure: &promoterv1alpha1.ExponentialFailure{
+						BaseDelay: metav1.Duration{Duration: time.Minute},
+						MaxDelay:  metav1.Duration{Duration: time.Second},
+					},
+				}}
+			},
+			err: "promotionStrategy.workQueue: rateLimiter.exponentialFailure.maxDelay",
+		},
+		{
+			name: "zero credentials check interval",
+			modify: func(spec *promoterv1alpha1.ControllerConfigurationSpec) {
+				spec.ScmProvider.CredentialsCheckInterval = &metav1.Duration{}
+			},
+			err: "scmProvider.credentialsCheckInterval 0s must be positive",
+		},
+		{
+			name: "negative clone idle timeout",
+			modify: func(spec *promoterv1alpha1.ControllerConfigurationSpec) {
+				spec.ChangeTransferPolicy.CloneIdleTimeout = &metav1.Duration{Duration: -time.Hour}
+			},
+			err: "changeTransferPolicy.cloneIdleTimeout -1h0m0s must not be negative",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := promoterv1alpha1.ControllerConfigurationSpec{
+				PullRequest: promoterv1alpha1.PullRequestConfiguration{
+					Template: promoterv1alpha1.PullRequestTemplate{
+						Title:       "Promote {{ trunc 7 .ChangeTransferPolicy.Status.Proposed.Dry.Sha }}",
+						Description: "{{ .ChangeTransferPolicy.Spec.ActiveBranch }}",
+					},
+				},
+				GitRepository: promoterv1alpha1.GitRepositoryConfiguration{
+					AccessCheckInterval: &metav1.Duration{Duration: time.Minute},
+				},
+			}
+			test.modify(&spec)
+
+			err := ValidateControllerConfiguration(&spec)
+			if test.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+// Request is a request the controllers reconcile: a reconcile.Request, or a mcreconcile.Request for the controllers
+// that watch other clusters.
+type Request interface {
+	reconcile.Request | mcreconcile.Request
+}
+
+// reconciler is a reconcile.TypedReconciler that runs each reconcile of another in a span.
+type reconciler[request Request] struct {
+	kind       string
+	reconciler reconcile.TypedReconciler[request]
+}
+
+// NewReconciler returns a reconciler that runs each reconcile of r in a span named after the kind of resource r
+// reconciles, with the namespace and name of the resource. The logger of the reconcile logs the span's trace ID.
+func NewReconciler[request Request](kind string, r reconcile.TypedReconciler[request]) reconcile.TypedReconciler[request] {
+	return &reconciler[request]{kind: kind, reconciler: r}
+}
+
+// Reconcile implements reconcile.TypedReconciler.
+func (r *reconciler[request]) Reconcile(ctx context.Context, req request) (reconcile.Result, error) {
+	ctx, span := Tracer().Start(ctx, "Reconcile "+r.kind, trace.WithAttributes(r.attributes(req)...))
+	ctx = withTraceLogger(ctx, span)
+
+	result, err := r.reconciler.Reconcile(ctx, req)
+	EndSpan(span, err)
+	return result, err //nolint:wrapcheck // the controller logs the reconciler's error as is
+}
+
+// attributes returns the attributes of the span of a reconcile of req.
+func (r *reconciler[request]) attributes(req request) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{attribute.String("kind", r.kind)}
+	switch req := any(req).(type) {
+	case reconcile.Request:
+		attributes = append(attributes, attribute.String("namespace", req.Namespace), attribute.String("name", req.Name))
+	case mcreconcile.Request:
+		attributes = append(attributes, attribute.String("namespace", req.Namespace), attribute.String("name", req.Name))
+		if req.ClusterName != "" {
+			attributes = append(attributes, attribute.String("cluster", req.ClusterName.String()))
+		}
+	}
+	return attributes
+}
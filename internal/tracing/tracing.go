@@ -0,0 +1,148 @@
+// Package tracing exports OpenTelemetry traces of reconciles, of the git commands they run and of the requests they
+// send to SCMs to an OTLP endpoint.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// instrumentationName is the name of the tracer the promoter creates its spans with.
+	instrumentationName = "github.com/argoproj-labs/gitops-promoter"
+	// serviceName is the service.name of the traces, unless OTEL_SERVICE_NAME sets another.
+	serviceName = "gitops-promoter"
+	// shutdownTimeout is how long exporting the remaining spans may take when the promoter stops.
+	shutdownTimeout = 10 * time.Second
+)
+
+// Config configures where traces are exported to.
+type Config struct {
+	// Endpoint is the URL of the OTLP/HTTP endpoint traces are exported to, such as
+	// http://otel-collector:4318/v1/traces. If empty, the standard OTEL_EXPORTER_OTLP_ENDPOINT and
+	// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment variables are used.
+	Endpoint string
+}
+
+// Enabled returns whether an endpoint is configured, by Endpoint or the environment, and the OpenTelemetry SDK isn't
+// disabled by OTEL_SDK_DISABLED.
+func (c Config) Enabled() bool {
+	if disabled, _ := strconv.ParseBool(os.Getenv("OTEL_SDK_DISABLED")); disabled {
+		return false
+	}
+	return c.Endpoint != "" || os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" ||
+		os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c Config) Validate() error {
+	if c.Endpoint == "" {
+		return nil
+	}
+	endpoint, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid OTLP endpoint: %w", err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" || endpoint.Host == "" {
+		return fmt.Errorf("OTLP endpoint %q must be an absolute http or https URL", c.Endpoint)
+	}
+	return nil
+}
+
+// Provider exports the spans of the promoter. It is the global OpenTelemetry TracerProvider once created.
+type Provider struct {
+	tracerProvider *sdktrace.TracerProvider
+}
+
+// NewProvider creates a Provider for the configuration and makes it the global TracerProvider, or returns nil if
+// tracing isn't enabled. Without a Provider, spans are not recorded. It must be added to the manager, which exports the
+// remaining spans when it stops.
+func NewProvider(ctx context.Context, config Config) (*Provider, error) {
+	if !config.Enabled() {
+		return nil, nil
+	}
+
+	var options []otlptracehttp.Option
+	if config.Endpoint != "" {
+		options = append(options, otlptracehttp.WithEndpointURL(config.Endpoint))
+	}
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the defaults, since the later options take precedence.
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(serviceName)),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil && !errors.Is(err, resource.ErrPartialResource) {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+
+	// The sampler is configured by the OTEL_TRACES_SAMPLER environment variables and samples every trace by default.
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	return &Provider{tracerProvider: tracerProvider}, nil
+}
+
+// Start implements manager.Runnable. It exports the remaining spans and stops the Provider when ctx is done.
+func (p *Provider) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+	if err := p.tracerProvider.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down tracer provider: %w", err)
+	}
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Replicas that aren't the leader reconcile too, so their
+// spans are exported as well.
+func (p *Provider) NeedLeaderElection() bool {
+	return false
+}
+
+// Tracer returns the tracer the promoter creates its spans with.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// EndSpan ends the span, marking it as failed with err if err isn't nil.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// withTraceLogger returns a copy of ctx whose logger logs the trace and span IDs of span, so that the logs of a
+// reconcile can be found from its trace. ctx is returned unchanged if the span isn't recorded.
+func withTraceLogger(ctx context.Context, span trace.Span) context.Context {
+	spanContext := span.SpanContext()
+	if !spanContext.IsValid() {
+		return ctx
+	}
+	logger := log.FromContext(ctx).WithValues("trace_id", spanContext.TraceID().String(), "span_id", spanContext.SpanID().String())
+	return log.IntoContext(ctx, logger)
+}
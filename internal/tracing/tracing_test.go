@@ -0,0 +1,90 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
+)
+
+var _ = Describe("Config", func() {
+	It("is disabled without an endpoint", func() {
+		GinkgoT().Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+		GinkgoT().Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+		Expect(tracing.Config{}.Enabled()).To(BeFalse())
+		Expect(tracing.Config{Endpoint: "http://collector:4318/v1/traces"}.Enabled()).To(BeTrue())
+	})
+
+	It("is enabled by the OTLP environment variables unless the SDK is disabled", func() {
+		GinkgoT().Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318")
+		Expect(tracing.Config{}.Enabled()).To(BeTrue())
+
+		GinkgoT().Setenv("OTEL_SDK_DISABLED", "true")
+		Expect(tracing.Config{}.Enabled()).To(BeFalse())
+	})
+
+	It("rejects endpoints that aren't absolute http or https URLs", func() {
+		Expect(tracing.Config{}.Validate()).To(Succeed())
+		Expect(tracing.Config{Endpoint: "https://collector:4318/v1/traces"}.Validate()).To(Succeed())
+		Expect(tracing.Config{Endpoint: "collector:4318"}.Validate()).NotTo(Succeed())
+		Expect(tracing.Config{Endpoint: "grpc://collector:4317"}.Validate()).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("NewReconciler", func() {
+	var recorder *tracetest.SpanRecorder
+
+	BeforeEach(func() {
+		previous := otel.GetTracerProvider()
+		recorder = tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		DeferCleanup(otel.SetTracerProvider, previous)
+	})
+
+	It("reconciles in a span with the namespace and name of the resource", func() {
+		var reconcileSpan trace.SpanContext
+		r := tracing.NewReconciler("PromotionStrategy", reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+			reconcileSpan = trace.SpanContextFromContext(ctx)
+			return reconcile.Result{}, nil
+		}))
+
+		_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "team", Name: "app"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Name()).To(Equal("Reconcile PromotionStrategy"))
+		Expect(spans[0].SpanContext()).To(Equal(reconcileSpan))
+		Expect(spans[0].Attributes()).To(ContainElements(
+			attribute.String("kind", "PromotionStrategy"),
+			attribute.String("namespace", "team"),
+			attribute.String("name", "app"),
+		))
+		Expect(spans[0].Status().Code).To(Equal(codes.Unset))
+	})
+
+	It("marks the span of a failed reconcile as failed", func() {
+		r := tracing.NewReconciler("PullRequest", reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+			return reconcile.Result{}, errors.New("failed to merge")
+		}))
+
+		_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "team", Name: "app"}})
+		Expect(err).To(MatchError("failed to merge"))
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Status().Code).To(Equal(codes.Error))
+		Expect(spans[0].Status().Description).To(Equal("failed to merge"))
+	})
+})
@@ -13,6 +13,24 @@ type (
 const (
 	// Ready is the condition type for a resource that is ready.
 	Ready CommonType = "Ready"
+	// Superseded is the condition type for a resource whose pending promotion was reverted upstream.
+	Superseded CommonType = "Superseded"
+	// ActiveBranchRewritten is the condition type for an active branch whose history was rewritten upstream.
+	ActiveBranchRewritten CommonType = "ActiveBranchRewritten"
+	// ProposedBranchDiverged is the condition type for a proposed branch that diverged from the commits previously seen on it.
+	ProposedBranchDiverged CommonType = "ProposedBranchDiverged"
+	// BranchMissing is the condition type for a ChangeTransferPolicy whose active branch does not exist on the remote.
+	BranchMissing CommonType = "BranchMissing"
+	// PullRequestCreated is the condition type for whether a pull request is open for a ChangeTransferPolicy.
+	PullRequestCreated CommonType = "PullRequestCreated"
+	// Conflicted is the condition type for a RevertCommit whose commit can't be reverted without resolving conflicts.
+	Conflicted CommonType = "Conflicted"
+	// Completed is the condition type for a RevertCommit whose revert landed.
+	Completed CommonType = "Completed"
+	// WebhookRegistered is the condition type for whether the webhook a GitRepository manages is registered on the SCM.
+	WebhookRegistered CommonType = "WebhookRegistered"
+	// DeletionBlocked indicates that the deletion of the resource waits for the resources that depend on it.
+	DeletionBlocked CommonType = "DeletionBlocked"
 )
 
 // Reasons that apply to all CRDs.
@@ -33,6 +51,31 @@ const (
 const (
 	// PullRequestNotReady is the condition type for a pull request not being ready.
 	PullRequestNotReady CommonReason = "PullRequestNotReady"
+	// MetadataInvalid is the condition type for a hydrated branch whose hydrator.metadata is missing or malformed.
+	MetadataInvalid CommonReason = "MetadataInvalid"
+	// ActiveBranchMissing is the condition type for an active branch that does not exist on the remote.
+	ActiveBranchMissing CommonReason = "ActiveBranchMissing"
+	// HistoryRewritten is the condition reason for an active branch that no longer contains the commit it previously pointed at.
+	HistoryRewritten CommonReason = "HistoryRewritten"
+	// HistoryDiverged is the condition reason for a proposed branch that contains neither the previously seen proposed
+	// commit nor the active branch.
+	HistoryDiverged CommonReason = "HistoryDiverged"
+	// CloneFailed is the condition reason for a repository that could not be cloned.
+	CloneFailed CommonReason = "CloneFailed"
+	// CommitSignatureRejected is the condition reason for a push that was rejected because of the signature of a commit
+	// created by the promoter.
+	CommitSignatureRejected CommonReason = "CommitSignatureRejected"
+	// GitOperationFailed is the condition reason for a git operation that failed for a reason that retrying won't fix,
+	// such as rejected credentials.
+	GitOperationFailed CommonReason = "GitOperationFailed"
+	// RefNotFound is the condition reason for a branch ref that does not exist on the remote.
+	RefNotFound CommonReason = "RefNotFound"
+	// BranchShasUnresolved is the condition reason for a ChangeTransferPolicy whose active or proposed shas could not be resolved.
+	BranchShasUnresolved CommonReason = "BranchShasUnresolved"
+	// PullRequestOpen is the condition reason for a ChangeTransferPolicy with an open pull request.
+	PullRequestOpen CommonReason = "PullRequestOpen"
+	// NothingToPromote is the condition reason for a ChangeTransferPolicy whose active branch already has the proposed dry sha.
+	NothingToPromote CommonReason = "NothingToPromote"
 )
 
 // Reasons that apply to PromotionStrategy.
@@ -41,4 +84,76 @@ const (
 	ChangeTransferPolicyNotReady CommonReason = "ChangeTransferPolicyNotReady"
 	// PreviousEnvironmentCommitStatusNotReady is the condition type for a previous environment commit status not being ready.
 	PreviousEnvironmentCommitStatusNotReady CommonReason = "PreviousEnvironmentCommitStatusNotReady"
+	// SupersededByRevert is the condition reason for a proposed dry commit that was reverted upstream.
+	SupersededByRevert CommonReason = "SupersededByRevert"
+	// ProposedBranchInvalid is the condition reason for proposed branch names that are invalid or changed after creation.
+	ProposedBranchInvalid CommonReason = "ProposedBranchInvalid"
+)
+
+// Reasons that apply to PullRequest.
+const (
+	// PullRequestFieldsNotSupported is the condition reason for a pull request that sets labels, reviewers or draft,
+	// which the pull requests of its SCM provider don't support.
+	PullRequestFieldsNotSupported CommonReason = "PullRequestFieldsNotSupported"
+)
+
+// Reasons that apply to GitRepository.
+const (
+	// NotFound is the condition reason for a repository that the SCM reports doesn't exist.
+	NotFound CommonReason = "NotFound"
+	// AccessDenied is the condition reason for a repository that the SCM provider's credentials can't access.
+	AccessDenied CommonReason = "AccessDenied"
+	// UrlNotSupported is the condition reason for a repository identified by its URL whose SCM provider needs its owner
+	// and name.
+	UrlNotSupported CommonReason = "UrlNotSupported"
+	// RepositoryArchived is the condition reason for a repository that is archived or read-only on the SCM.
+	RepositoryArchived CommonReason = "RepositoryArchived"
+	// WebhookUpToDate is the condition reason for a managed webhook that is registered with the requested configuration.
+	WebhookUpToDate CommonReason = "WebhookUpToDate"
+	// WebhookAccessDenied is the condition reason for a managed webhook that the SCM provider's credentials can't manage.
+	WebhookAccessDenied CommonReason = "WebhookAccessDenied"
+	// WebhookNotSupported is the condition reason for a managed webhook on an SCM the controller can't register webhooks on.
+	WebhookNotSupported CommonReason = "WebhookNotSupported"
+	// DependentResourcesExist indicates that PullRequests or ChangeTransferPolicies still use the GitRepository.
+	DependentResourcesExist CommonReason = "DependentResourcesExist"
+)
+
+// Reasons that apply to ScmProvider and ClusterScmProvider.
+const (
+	// SecretNotFound is the condition reason for an SCM provider whose secret doesn't exist.
+	SecretNotFound CommonReason = "SecretNotFound"
+	// InvalidCredentials is the condition reason for an SCM provider whose secret lacks the credentials it needs, or
+	// has credentials that can't be parsed.
+	InvalidCredentials CommonReason = "InvalidCredentials"
+)
+
+// Reasons that apply to resources that reference a GitRepository.
+const (
+	// GitRepositoryNotReady is the condition reason for a resource whose GitRepository doesn't exist or can't be accessed.
+	GitRepositoryNotReady CommonReason = "GitRepositoryNotReady"
+)
+
+// Reasons that apply to RevertCommit.
+const (
+	// RevertConflict is the condition reason for a commit that can't be reverted without resolving conflicts by hand.
+	RevertConflict CommonReason = "RevertConflict"
+	// EnvironmentNotFound is the condition reason for an environment that is not part of the referenced PromotionStrategy.
+	EnvironmentNotFound CommonReason = "EnvironmentNotFound"
+	// CommitSuperseded is the condition reason for a commit to revert that is no longer on the dry branch.
+	CommitSuperseded CommonReason = "CommitSuperseded"
+	// SupersededTargetRequiresForce is the condition reason for a commit to revert that newer commits change the same
+	// paths as, which is only reverted when forced.
+	SupersededTargetRequiresForce CommonReason = "SupersededTargetRequiresForce"
+	// CommitMessageTemplateInvalid is the condition reason for a revert commit message template that fails to render.
+	CommitMessageTemplateInvalid CommonReason = "CommitMessageTemplateInvalid"
+	// NoConflict is the condition reason for a revert that doesn't conflict, or wasn't attempted yet.
+	NoConflict CommonReason = "NoConflict"
+	// RevertInProgress is the condition reason for a revert that hasn't landed yet.
+	RevertInProgress CommonReason = "RevertInProgress"
+	// PullRequestMerged is the condition reason for a revert whose pull request was merged.
+	PullRequestMerged CommonReason = "PullRequestMerged"
+	// PullRequestClosed is the condition reason for a revert whose pull request was closed without being merged.
+	PullRequestClosed CommonReason = "PullRequestClosed"
+	// EnvironmentsReverted is the condition reason for a revert that was promoted in every environment.
+	EnvironmentsReverted CommonReason = "EnvironmentsReverted"
 )
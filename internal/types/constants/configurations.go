@@ -57,4 +57,11 @@ const (
 	// CommitStatusControllerFieldOwner is the field owner for Server-Side Apply operations
 	// performed by the CommitStatus controller.
 	CommitStatusControllerFieldOwner = "promoter.argoproj.io/commitstatus-controller"
+
+	// RevertCommitControllerFieldOwner is the field owner for Server-Side Apply operations
+	// performed by the RevertCommit controller.
+	RevertCommitControllerFieldOwner = "promoter.argoproj.io/revertcommit-controller"
+
+	// WebhookReceiverFieldOwner is the field owner for the GitRepository status the webhook receiver patches.
+	WebhookReceiverFieldOwner = "promoter.argoproj.io/webhook-receiver"
 )
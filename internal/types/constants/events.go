@@ -27,17 +27,145 @@ const (
 
 	// PullRequestUpdatedReason indicates that a pull request has been updated.
 	PullRequestUpdatedReason = "PullRequestUpdated"
+	// PullRequestUpdatedMessage is the message for an updated pull request.
+	PullRequestUpdatedMessage = "Pull Request %s updated"
+
+	// PullRequestOpenedReason indicates that a pull request has been opened on the SCM.
+	PullRequestOpenedReason = "PullRequestOpened"
+	// PullRequestOpenedMessage is the message for a pull request opened on the SCM.
+	PullRequestOpenedMessage = "Pull Request %s opened at %s"
+
+	// PullRequestClosedReason indicates that a pull request has been closed without merging it.
+	PullRequestClosedReason = "PullRequestClosed"
+	// PullRequestClosedMessage is the message for a pull request closed without merging it.
+	PullRequestClosedMessage = "Pull Request %s closed"
 
 	// CommitStatusSetReason indicates that a commit status has been set.
 	CommitStatusSetReason = "CommitStatusSet"
+	// CommitStatusSetMessage is the message for a commit status set on the SCM.
+	CommitStatusSetMessage = "Commit status %s set to %s for hash %s"
+
+	// PromotionBlockedReason indicates that a commit status of the change proposed for an environment fails, so that the
+	// change isn't promoted.
+	PromotionBlockedReason = "PromotionBlocked"
+	// PromotionBlockedMessage is the message for a change whose promotion is blocked by failing commit statuses.
+	PromotionBlockedMessage = "Promotion of dry sha %s to environment %s is blocked by failing commit statuses %s"
+
+	// PreviousEnvironmentCommitStatusStaleReason indicates that a previous environment commit status gating a proposed
+	// change was copied from an active hydrated sha of the previous environment that is no longer active, and couldn't
+	// be updated.
+	PreviousEnvironmentCommitStatusStaleReason = "PreviousEnvironmentCommitStatusStale"
+	// PreviousEnvironmentCommitStatusStaleMessage is the message for a stale previous environment commit status.
+	PreviousEnvironmentCommitStatusStaleMessage = "Commit status %s gating environment %s was copied from hydrated sha %s of environment %s, whose active hydrated sha is now %s"
 
 	// OrphanedChangeTransferPolicyDeletedReason indicates that an orphaned ChangeTransferPolicy has been deleted.
 	OrphanedChangeTransferPolicyDeletedReason = "OrphanedChangeTransferPolicyDeleted"
 	// OrphanedChangeTransferPolicyDeletedMessage is the message for a deleted orphaned ChangeTransferPolicy.
 	OrphanedChangeTransferPolicyDeletedMessage = "Deleted orphaned ChangeTransferPolicy %s"
 
+	// SupersededByRevertReason indicates that a pull request was closed because its proposed dry commit was reverted upstream.
+	SupersededByRevertReason = "SupersededByRevert"
+	// SupersededByRevertMessage is the message for a pull request closed because its proposed dry commit was reverted upstream.
+	SupersededByRevertMessage = "Closed Pull Request %s for %s, proposed dry sha %s was reverted upstream"
+
+	// ActiveCommitStatusFailingReason indicates that an active commit status started failing shortly after a promotion
+	// to an environment with autoRevert enabled.
+	ActiveCommitStatusFailingReason = "ActiveCommitStatusFailing"
+	// ActiveCommitStatusFailingMessage is the message for an active commit status that started failing shortly after a promotion.
+	ActiveCommitStatusFailingMessage = "Active commit statuses %s failed in environment %s after dry sha %s was promoted, reverting it if they are still failing after %s"
+	// AutoRevertCreatedReason indicates that a RevertCommit was created to revert a promotion automatically.
+	AutoRevertCreatedReason = "AutoRevertCreated"
+	// AutoRevertCreatedMessage is the message for a RevertCommit created to revert a promotion automatically.
+	AutoRevertCreatedMessage = "Created RevertCommit %s to revert dry sha %s in environment %s, active commit statuses %s kept failing"
+	// AutoRevertSkippedReason indicates that a promotion whose active commit statuses are failing won't be reverted automatically.
+	AutoRevertSkippedReason = "AutoRevertSkipped"
+	// AutoRevertSkippedMessage is the message for a promotion that won't be reverted automatically.
+	AutoRevertSkippedMessage = "Not reverting dry sha %s in environment %s automatically: %s"
+	// EmergencyRevertDivergedReason indicates that an environment's active branch was reverted directly, so auto-merge is
+	// held for the environment.
+	EmergencyRevertDivergedReason = "EmergencyRevertDiverged"
+	// EmergencyRevertDivergedMessage is the message for an environment whose active branch was reverted directly.
+	EmergencyRevertDivergedMessage = "RevertCommit %s reverted hydrated sha %s directly on %s, holding auto-merge until dry sha %s is superseded"
+
+	// ReconcileIntervalRaisedReason indicates that the reconcileInterval of a ChangeTransferPolicy is below the configured minimum, which is used instead.
+	ReconcileIntervalRaisedReason = "ReconcileIntervalRaised"
+	// ReconcileIntervalRaisedMessage is the message for a reconcileInterval below the configured minimum.
+	ReconcileIntervalRaisedMessage = "reconcileInterval %s is below the ControllerConfiguration's minReconcileInterval %s, reconciling every %s instead"
+
+	// ProposedBranchCreatedReason indicates that a missing proposed branch was created from the active branch.
+	ProposedBranchCreatedReason = "ProposedBranchCreated"
+	// ProposedBranchCreatedMessage is the message for a proposed branch created from the active branch.
+	ProposedBranchCreatedMessage = "Created proposed branch %s from active branch %s at %s"
+
+	// BranchMissingReason indicates that the active branch of a ChangeTransferPolicy does not exist on the remote.
+	BranchMissingReason = "BranchMissing"
+	// BranchMissingMessage is the message for an active branch that does not exist on the remote.
+	BranchMissingMessage = "Ref %s does not exist in repository %s, checking again with ls-remote until it is created"
+
+	// ProposedBranchDivergedReason indicates that the proposed branch diverged from the commits previously seen on it.
+	ProposedBranchDivergedReason = "ProposedBranchDiverged"
+	// ProposedBranchDivergedMessage is the message for a proposed branch that diverged from the commits previously seen on it.
+	ProposedBranchDivergedMessage = "Proposed branch %s diverged, it is at %s which has neither %s nor active branch %s in its history"
+
+	// ProposedBranchResetReason indicates that a diverged proposed branch was reset to the last proposed commit.
+	ProposedBranchResetReason = "ProposedBranchReset"
+	// ProposedBranchResetMessage is the message for a diverged proposed branch that was reset to the last proposed commit.
+	ProposedBranchResetMessage = "Reset diverged proposed branch %s from %s back to %s"
+
+	// GitLFSDisabledReason indicates that a repository uses Git LFS but the controller's clones are not set up for it.
+	GitLFSDisabledReason = "GitLFSDisabled"
+	// GitLFSDisabledMessage is the message for a repository that uses Git LFS while Git LFS is disabled.
+	GitLFSDisabledMessage = "Branch %s stores files in Git LFS, but the controller runs without --enable-git-lfs, so its clones only contain the LFS pointer files"
+
+	// NoChangesToPromoteReason indicates that no pull request was opened because the proposed dry commit doesn't change the hydrated manifests.
+	NoChangesToPromoteReason = "NoChangesToPromote"
+	// NoChangesToPromoteMessage is the message for a proposed dry commit that doesn't change the hydrated manifests.
+	NoChangesToPromoteMessage = "Proposed dry sha %s doesn't change the hydrated manifests of %s, treating it as promoted without a pull request"
+	// NoChangesToPromotePullRequestClosedMessage is the message for an open pull request closed because the proposed dry commit doesn't change the hydrated manifests.
+	NoChangesToPromotePullRequestClosedMessage = "Closed Pull Request %s, proposed dry sha %s doesn't change the hydrated manifests of %s"
+
 	// OrphanedCommitStatusDeletedReason indicates that an orphaned CommitStatus has been deleted.
 	OrphanedCommitStatusDeletedReason = "OrphanedCommitStatusDeleted"
 	// OrphanedCommitStatusDeletedMessage is the message for a deleted orphaned CommitStatus.
 	OrphanedCommitStatusDeletedMessage = "Deleted orphaned CommitStatus %s"
+
+	// RevertProposedReason indicates that the manifests an environment ran before a reverted commit were proposed for it.
+	RevertProposedReason = "RevertProposed"
+	// RevertProposedMessage is the message for manifests proposed to revert a commit in an environment.
+	RevertProposedMessage = "Proposed the manifests of %s on %s to revert %s in environment %s"
+
+	// RevertCreatedReason indicates that a commit was reverted on a revert branch.
+	RevertCreatedReason = "RevertCreated"
+	// RevertCreatedMessage is the message for a commit that was reverted on a revert branch.
+	RevertCreatedMessage = "Reverted %s on %s with commit %s on branch %s"
+
+	// RevertMergedReason indicates that the pull request of a revert was merged.
+	RevertMergedReason = "RevertMerged"
+	// RevertMergedMessage is the message for a revert whose pull request was merged.
+	RevertMergedMessage = "Revert pull request %s was merged into %s"
+
+	// RevertCommitPhaseChangedReason indicates that a RevertCommit moved to another phase.
+	RevertCommitPhaseChangedReason = "PhaseChanged"
+	// RevertCommitPhaseChangedMessage is the message for a RevertCommit that moved to another phase.
+	RevertCommitPhaseChangedMessage = "RevertCommit is now in phase %s"
+
+	// WebhookRegisteredReason indicates that the webhook of a GitRepository was created on the SCM.
+	WebhookRegisteredReason = "WebhookRegistered"
+	// WebhookRegisteredMessage is the message for a webhook that was created on the SCM.
+	WebhookRegisteredMessage = "Registered webhook %s sending %v events to %s"
+
+	// WebhookAccessDeniedReason indicates that the SCM rejected the credentials for managing a repository's webhooks.
+	WebhookAccessDeniedReason = "WebhookAccessDenied"
+	// WebhookAccessDeniedMessage is the message for credentials that are not allowed to manage a repository's webhooks.
+	WebhookAccessDeniedMessage = "The ScmProvider's credentials are not allowed to manage the repository's webhooks: %s"
+
+	// DeletionForcedReason indicates that a resource was deleted although other resources still depend on it.
+	DeletionForcedReason = "DeletionForced"
+	// DeletionForcedMessage is the message for a deletion forced by the force-delete-after annotation.
+	DeletionForcedMessage = "Forced the deletion after the force-delete-after annotation's time, leaving dependent resources %s"
+
+	// InvalidConfigurationReason indicates that a ControllerConfiguration is invalid and is ignored.
+	InvalidConfigurationReason = "InvalidConfiguration"
+	// InvalidConfigurationMessage is the message for an invalid ControllerConfiguration.
+	InvalidConfigurationMessage = "Ignoring the invalid configuration, the controllers keep using the last valid one: %s"
 )
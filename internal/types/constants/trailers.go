@@ -6,6 +6,9 @@ const (
 	TrailerCommitStatusActivePrefix = "Commit-status-active-"
 	// TrailerCommitStatusProposedPrefix is the prefix for trailers indicating proposed commit statuses.
 	TrailerCommitStatusProposedPrefix = "Commit-status-proposed-"
+	// TrailerEmergencyRevert is the trailer key used to mark a commit that reverts a hydrated commit directly on an
+	// environment branch. Its value is the reverted hydrated commit, so that the hydrator knows to reconcile the branch.
+	TrailerEmergencyRevert = "Emergency-revert"
 	// TrailerPullRequestCreationTime is the trailer key used to store the creation time of the pull request.
 	TrailerPullRequestCreationTime = "Pull-request-creation-time"
 	// TrailerPullRequestMergeTime is the trailer key used to store the merge time of the pull request.
@@ -18,6 +21,9 @@ const (
 	TrailerPullRequestTargetBranch = "Pull-request-target-branch"
 	// TrailerPullRequestUrl is the trailer key used to store the URL of the pull request.
 	TrailerPullRequestUrl = "Pull-request-url"
+	// TrailerRevertCommit is the trailer key used to store the name of the RevertCommit that a commit reverts a commit
+	// for, so that the PromotionStrategy can recognize the revert when it is promoted.
+	TrailerRevertCommit = "Revert-commit"
 	// TrailerShaDryActive is the trailer key used to store the SHA of the active dry commit.
 	TrailerShaDryActive = "Sha-dry-active"
 	// TrailerShaDryProposed is the trailer key used to store the SHA of the proposed dry commit.
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
+)
+
+// SetReadyCondition sets the Ready condition of obj, observed at its current generation. Reconcilers set it to explain
+// why obj isn't Ready; HandleReconciliationResult sets it to True when a reconcile succeeds without setting it.
+func SetReadyCondition(obj StatusConditionUpdater, status metav1.ConditionStatus, reason promoterConditions.CommonReason, message string) {
+	meta.SetStatusCondition(obj.GetConditions(), metav1.Condition{
+		Type:               string(promoterConditions.Ready),
+		Status:             status,
+		Reason:             string(reason),
+		Message:            message,
+		ObservedGeneration: obj.GetGeneration(),
+	})
+}
+
+// GetReadyCondition returns the Ready condition of obj, or nil if it has none.
+func GetReadyCondition(obj StatusConditionUpdater) *metav1.Condition {
+	return meta.FindStatusCondition(*obj.GetConditions(), string(promoterConditions.Ready))
+}
+
+// IsReady returns whether the Ready condition of obj is True and was observed at its current generation. A Ready
+// condition of an earlier generation doesn't tell whether the current spec works yet.
+func IsReady(obj StatusConditionUpdater) bool {
+	ready := GetReadyCondition(obj)
+	return ready != nil && ready.Status == metav1.ConditionTrue && ready.ObservedGeneration == obj.GetGeneration()
+}
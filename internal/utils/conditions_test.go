@@ -0,0 +1,41 @@
+package utils_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+var _ = Describe("Ready condition helpers", func() {
+	It("sets the Ready condition at the current generation", func() {
+		scmProvider := &promoterv1alpha1.ScmProvider{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+		Expect(utils.GetReadyCondition(scmProvider)).To(BeNil())
+		Expect(utils.IsReady(scmProvider)).To(BeFalse())
+
+		utils.SetReadyCondition(scmProvider, metav1.ConditionFalse, conditions.SecretNotFound, "Secret \"creds\" not found")
+		ready := utils.GetReadyCondition(scmProvider)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal(string(conditions.SecretNotFound)))
+		Expect(ready.Message).To(Equal("Secret \"creds\" not found"))
+		Expect(ready.ObservedGeneration).To(Equal(int64(3)))
+		Expect(utils.IsReady(scmProvider)).To(BeFalse())
+
+		utils.SetReadyCondition(scmProvider, metav1.ConditionTrue, conditions.ReconciliationSuccess, "Reconciliation successful")
+		Expect(scmProvider.Status.Conditions).To(HaveLen(1))
+		Expect(utils.IsReady(scmProvider)).To(BeTrue())
+	})
+
+	It("doesn't consider a Ready condition of an earlier generation", func() {
+		gitRepo := &promoterv1alpha1.GitRepository{ObjectMeta: metav1.ObjectMeta{Generation: 1}}
+		utils.SetReadyCondition(gitRepo, metav1.ConditionTrue, conditions.ReconciliationSuccess, "Reconciliation successful")
+		Expect(utils.IsReady(gitRepo)).To(BeTrue())
+
+		gitRepo.Generation = 2
+		Expect(utils.IsReady(gitRepo)).To(BeFalse())
+	})
+})
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DefaultShutdownDrainPeriod is how long the reconciles that are running when the controller stops are given to
+// finish by default.
+const DefaultShutdownDrainPeriod = 20 * time.Second
+
+// shutdownDrainPeriod is set with SetShutdownDrainPeriod. It is stored as nanoseconds so that it can be read by
+// concurrent reconciles without a lock.
+var shutdownDrainPeriod atomic.Int64
+
+func init() {
+	shutdownDrainPeriod.Store(int64(DefaultShutdownDrainPeriod))
+}
+
+// SetShutdownDrainPeriod sets how long the reconciles that are running when the controller stops are given to finish
+// before their context is cancelled. Zero cancels them right away.
+func SetShutdownDrainPeriod(period time.Duration) {
+	shutdownDrainPeriod.Store(int64(period))
+}
+
+// drainingReconciler is a reconcile.TypedReconciler that lets the reconciles of another finish when the controller
+// stops.
+type drainingReconciler[request comparable] struct {
+	reconciler reconcile.TypedReconciler[request]
+}
+
+// NewDrainingReconciler returns a reconciler that runs each reconcile of r with a context that is only cancelled once
+// the shutdown drain period passed after the controller stopped. The controller doesn't start new reconciles once it
+// stops, but waits for the running ones, so a reconcile that already merged a pull request on the SCM still gets to
+// update the status of its resources, rather than leaving them half-done because every later call fails with the
+// cancelled context.
+func NewDrainingReconciler[request comparable](r reconcile.TypedReconciler[request]) reconcile.TypedReconciler[request] {
+	return &drainingReconciler[request]{reconciler: r}
+}
+
+// Reconcile implements reconcile.TypedReconciler.
+func (r *drainingReconciler[request]) Reconcile(ctx context.Context, req request) (reconcile.Result, error) {
+	ctx, cancel := DrainContext(ctx, time.Duration(shutdownDrainPeriod.Load()))
+	defer cancel()
+	return r.reconciler.Reconcile(ctx, req) //nolint:wrapcheck // the controller logs the reconciler's error as is
+}
+
+// DrainContext returns a context with the values and deadline of ctx that is cancelled drain after ctx is done, with
+// the same cause, or when the returned function is called.
+func DrainContext(ctx context.Context, drain time.Duration) (context.Context, context.CancelFunc) {
+	if drain <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	drained, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancelDeadline context.CancelFunc
+		drained, cancelDeadline = context.WithDeadline(drained, deadline)
+		cancelCause := cancel
+		cancel = func(cause error) {
+			cancelCause(cause)
+			cancelDeadline()
+		}
+	}
+	stop := context.AfterFunc(ctx, func() {
+		timer := time.NewTimer(drain)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			cancel(context.Cause(ctx))
+		case <-drained.Done():
+		}
+	})
+	return drained, func() {
+		stop()
+		cancel(context.Canceled)
+	}
+}
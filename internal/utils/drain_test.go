@@ -0,0 +1,144 @@
+package utils_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+// mergingReconciler merges the pull request on a fake SCM, which takes mergeDuration, and then records the merge in the
+// PullRequest's status, like the PullRequest controller does.
+type mergingReconciler struct {
+	client        client.Client
+	mergeDuration time.Duration
+	// merging is closed once the merge started.
+	merging chan struct{}
+	merged  bool
+}
+
+func (r *mergingReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	var pr promoterv1alpha1.PullRequest
+	if err := r.client.Get(ctx, req.NamespacedName, &pr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	close(r.merging)
+	select {
+	case <-time.After(r.mergeDuration):
+		r.merged = true
+	case <-ctx.Done():
+		return reconcile.Result{}, context.Cause(ctx)
+	}
+
+	// Like the API clients, the status update fails once the context is cancelled.
+	if err := ctx.Err(); err != nil {
+		return reconcile.Result{}, err
+	}
+	pr.Status.State = promoterv1alpha1.PullRequestMerged
+	return reconcile.Result{}, r.client.Status().Update(ctx, &pr)
+}
+
+var _ = Describe("DrainingReconciler", func() {
+	var (
+		c          client.Client
+		reconciler *mergingReconciler
+		req        reconcile.Request
+	)
+
+	BeforeEach(func() {
+		pr := &promoterv1alpha1.PullRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "default"},
+			Status:     promoterv1alpha1.PullRequestStatus{State: promoterv1alpha1.PullRequestOpen},
+		}
+		c = fake.NewClientBuilder().WithScheme(utils.GetScheme()).WithObjects(pr).WithStatusSubresource(pr).Build()
+		reconciler = &mergingReconciler{client: c, mergeDuration: 100 * time.Millisecond, merging: make(chan struct{})}
+		req = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(pr)}
+		DeferCleanup(utils.SetShutdownDrainPeriod, utils.DefaultShutdownDrainPeriod)
+	})
+
+	// reconcileUntilShutdown runs a reconcile and cancels its context, like the manager does on SIGTERM, once the merge
+	// started.
+	reconcileUntilShutdown := func() error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			<-reconciler.merging
+			cancel()
+		}()
+		_, err := utils.NewDrainingReconciler(reconciler).Reconcile(ctx, req)
+		return err
+	}
+	state := func() promoterv1alpha1.PullRequestState {
+		var pr promoterv1alpha1.PullRequest
+		Expect(c.Get(context.Background(), req.NamespacedName, &pr)).To(Succeed())
+		return pr.Status.State
+	}
+
+	It("should let a merge that is in flight on shutdown finish and record it", func() {
+		utils.SetShutdownDrainPeriod(time.Second)
+
+		Expect(reconcileUntilShutdown()).To(Succeed())
+		Expect(reconciler.merged).To(BeTrue())
+		Expect(state()).To(Equal(promoterv1alpha1.PullRequestMerged))
+	})
+
+	It("should cancel a reconcile that doesn't finish within the drain period", func() {
+		utils.SetShutdownDrainPeriod(10 * time.Millisecond)
+
+		Expect(reconcileUntilShutdown()).To(MatchError(context.Canceled))
+		Expect(reconciler.merged).To(BeFalse())
+		Expect(state()).To(Equal(promoterv1alpha1.PullRequestOpen))
+	})
+
+	It("should cancel the reconcile right away without a drain period", func() {
+		utils.SetShutdownDrainPeriod(0)
+
+		start := time.Now()
+		Expect(reconcileUntilShutdown()).To(MatchError(context.Canceled))
+		Expect(time.Since(start)).To(BeNumerically("<", reconciler.mergeDuration))
+		Expect(state()).To(Equal(promoterv1alpha1.PullRequestOpen))
+	})
+})
+
+var _ = Describe("DrainContext", func() {
+	It("should keep the values and deadline of the context", func() {
+		type key struct{}
+		deadline := time.Now().Add(time.Hour)
+		parent, cancel := context.WithDeadline(context.WithValue(context.Background(), key{}, "value"), deadline)
+		defer cancel()
+
+		ctx, cancelDrain := utils.DrainContext(parent, time.Minute)
+		defer cancelDrain()
+		Expect(ctx.Value(key{})).To(Equal("value"))
+		drainDeadline, ok := ctx.Deadline()
+		Expect(ok).To(BeTrue())
+		Expect(drainDeadline).To(BeTemporally("==", deadline))
+	})
+
+	It("should be cancelled with the cause of the context once the drain period passed", func() {
+		parent, cancel := context.WithCancelCause(context.Background())
+		ctx, cancelDrain := utils.DrainContext(parent, 50*time.Millisecond)
+		defer cancelDrain()
+
+		cause := context.Canceled
+		cancel(cause)
+		Consistently(ctx.Done(), 20*time.Millisecond).ShouldNot(BeClosed())
+		Eventually(ctx.Done()).Should(BeClosed())
+		Expect(context.Cause(ctx)).To(MatchError(cause))
+	})
+
+	It("should be cancelled when its cancel function is called", func() {
+		ctx, cancelDrain := utils.DrainContext(context.Background(), time.Minute)
+		cancelDrain()
+		Expect(ctx.Done()).To(BeClosed())
+	})
+})
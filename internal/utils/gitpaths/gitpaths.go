@@ -2,6 +2,7 @@ package gitpaths
 
 import (
 	"sync"
+	"time"
 )
 
 var storage sync.Map
@@ -31,3 +32,272 @@ func GetValues() []string {
 func Set(key string, path string) {
 	storage.Store(key, path)
 }
+
+// Keys returns the keys of all stored paths.
+func Keys() []string {
+	var keys []string
+	storage.Range(func(key, _ any) bool {
+		//nolint:forcetypeassert // sync.Map stores string keys, type is guaranteed
+		keys = append(keys, key.(string))
+		return true
+	})
+	return keys
+}
+
+// Delete removes the path, depth, sparse checkout paths, owner, store and last use stored for the given key.
+func Delete(key string) {
+	storage.Delete(key)
+	depths.Delete(key)
+	sparsePaths.Delete(key)
+	owners.Delete(key)
+	cloneStores.Delete(key)
+	lastUsed.Delete(key)
+}
+
+var locks sync.Map
+
+// Lock acquires the lock for the given key and returns a function that releases it. Git commands that run in the same
+// clone must hold its lock, concurrent commands in one work tree can corrupt its index and refs.
+func Lock(key string) func() {
+	mu, _ := locks.LoadOrStore(key, &sync.Mutex{})
+	//nolint:forcetypeassert // sync.Map stores *sync.Mutex values, type is guaranteed
+	m := mu.(*sync.Mutex)
+	m.Lock()
+	return m.Unlock
+}
+
+var depths sync.Map
+
+// GetDepth retrieves the clone depth recorded for the given key. Zero means the clone has the full history.
+func GetDepth(key string) int {
+	depth, ok := depths.Load(key)
+	if !ok {
+		return 0
+	}
+	//nolint:forcetypeassert // sync.Map stores int values, type is guaranteed
+	return depth.(int)
+}
+
+// SetDepth records the clone depth for the given key.
+func SetDepth(key string, depth int) {
+	depths.Store(key, depth)
+}
+
+var sparsePaths sync.Map
+
+// GetSparsePaths retrieves the sparse checkout paths recorded for the given key. Nil means every file is checked out.
+func GetSparsePaths(key string) []string {
+	paths, ok := sparsePaths.Load(key)
+	if !ok {
+		return nil
+	}
+	//nolint:forcetypeassert // sync.Map stores []string values, type is guaranteed
+	return paths.([]string)
+}
+
+// SetSparsePaths records the sparse checkout paths for the given key. Nil records that every file is checked out.
+func SetSparsePaths(key string, paths []string) {
+	if paths == nil {
+		sparsePaths.Delete(key)
+		return
+	}
+	sparsePaths.Store(key, paths)
+}
+
+// Owner identifies the GitRepository and environment a clone was made for.
+type Owner struct {
+	// Namespace is the namespace of the GitRepository.
+	Namespace string
+	// Name is the name of the GitRepository.
+	Name string
+	// ActiveBranch is the active branch of the environment that uses the clone.
+	ActiveBranch string
+}
+
+var owners sync.Map
+
+// GetOwner retrieves the owner recorded for the given key.
+func GetOwner(key string) (Owner, bool) {
+	owner, ok := owners.Load(key)
+	if !ok {
+		return Owner{}, false
+	}
+	//nolint:forcetypeassert // sync.Map stores Owner values, type is guaranteed
+	return owner.(Owner), true
+}
+
+// SetOwner records the owner of the clone for the given key.
+func SetOwner(key string, owner Owner) {
+	owners.Store(key, owner)
+}
+
+var lastUsed sync.Map
+
+// GetLastUsed retrieves the time the clone for the given key was last used. It is the zero time if the clone was never
+// used.
+func GetLastUsed(key string) time.Time {
+	t, ok := lastUsed.Load(key)
+	if !ok {
+		return time.Time{}
+	}
+	//nolint:forcetypeassert // sync.Map stores time.Time values, type is guaranteed
+	return t.(time.Time)
+}
+
+// Touch records that the clone for the given key was used now.
+func Touch(key string) {
+	lastUsed.Store(key, time.Now())
+}
+
+// store is a bare repository holding the objects and remote-tracking branches fetched from the remote of one
+// GitRepository. The clones of all of its environments are work trees of it, so each object is only fetched and stored
+// once.
+type store struct {
+	// path is the directory of the bare repository.
+	path string
+	// users is the number of clones that are work trees of the store.
+	users int
+	// released is when the number of users last dropped to zero.
+	released time.Time
+}
+
+var (
+	stores      = map[string]*store{}
+	storesMutex sync.Mutex
+	cloneStores sync.Map
+)
+
+// AddStore registers the store at path for the given store key with one user.
+func AddStore(storeKey string, path string) {
+	storesMutex.Lock()
+	defer storesMutex.Unlock()
+	stores[storeKey] = &store{path: path, users: 1}
+}
+
+// AcquireStore adds a user to the store for the given store key and returns its path, or an empty string if there is
+// no store.
+func AcquireStore(storeKey string) string {
+	storesMutex.Lock()
+	defer storesMutex.Unlock()
+	s, ok := stores[storeKey]
+	if !ok {
+		return ""
+	}
+	s.users++
+	return s.path
+}
+
+// ReleaseStore removes a user from the store for the given store key.
+func ReleaseStore(storeKey string) {
+	storesMutex.Lock()
+	defer storesMutex.Unlock()
+	s, ok := stores[storeKey]
+	if !ok || s.users == 0 {
+		return
+	}
+	s.users--
+	if s.users == 0 {
+		s.released = time.Now()
+	}
+}
+
+// GetStore retrieves the path of the store for the given store key.
+func GetStore(storeKey string) string {
+	storesMutex.Lock()
+	defer storesMutex.Unlock()
+	s, ok := stores[storeKey]
+	if !ok {
+		return ""
+	}
+	return s.path
+}
+
+// GetStoreUsers retrieves the number of clones that use the store for the given store key.
+func GetStoreUsers(storeKey string) int {
+	storesMutex.Lock()
+	defer storesMutex.Unlock()
+	s, ok := stores[storeKey]
+	if !ok {
+		return 0
+	}
+	return s.users
+}
+
+// StoreKeys returns the keys of all stores.
+func StoreKeys() []string {
+	storesMutex.Lock()
+	defer storesMutex.Unlock()
+	keys := make([]string, 0, len(stores))
+	for key := range stores {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// GetStorePaths returns the paths of all stores.
+func GetStorePaths() []string {
+	storesMutex.Lock()
+	defer storesMutex.Unlock()
+	paths := make([]string, 0, len(stores))
+	for _, s := range stores {
+		paths = append(paths, s.path)
+	}
+	return paths
+}
+
+// DeleteUnusedStore deregisters the store for the given store key if no clone used it for longer than idleTimeout, and
+// returns its path. It returns an empty string if the store is still used. The caller must hold the store's lock, so
+// that the store is not removed while a clone is being added to it.
+func DeleteUnusedStore(storeKey string, idleTimeout time.Duration) string {
+	storesMutex.Lock()
+	defer storesMutex.Unlock()
+	s, ok := stores[storeKey]
+	if !ok || s.users > 0 || time.Since(s.released) <= idleTimeout {
+		return ""
+	}
+	delete(stores, storeKey)
+	depths.Delete(storeKey)
+	return s.path
+}
+
+// GetCloneStore retrieves the key of the store the clone for the given key is a work tree of.
+func GetCloneStore(key string) string {
+	storeKey, ok := cloneStores.Load(key)
+	if !ok {
+		return ""
+	}
+	//nolint:forcetypeassert // sync.Map stores string values, type is guaranteed
+	return storeKey.(string)
+}
+
+// SetCloneStore records the key of the store the clone for the given key is a work tree of.
+func SetCloneStore(key string, storeKey string) {
+	cloneStores.Store(key, storeKey)
+}
+
+var storeLocks sync.Map
+
+// LockStore acquires the lock of the store with the given store key for writing and returns a function that releases
+// it. Git commands that change the refs, config or shallow boundary shared by the work trees of a store must hold it,
+// since git fails instead of waiting when another command has locked the file it needs. It must be acquired after the
+// lock of a clone and held only for the duration of a command.
+func LockStore(storeKey string) func() {
+	m := storeLock(storeKey)
+	m.Lock()
+	return m.Unlock
+}
+
+// RLockStore acquires the lock of the store with the given store key for reading and returns a function that releases
+// it. Git commands that only read the store hold it, so they run concurrently with each other but not with commands
+// that change the store.
+func RLockStore(storeKey string) func() {
+	m := storeLock(storeKey)
+	m.RLock()
+	return m.RUnlock
+}
+
+func storeLock(storeKey string) *sync.RWMutex {
+	mu, _ := storeLocks.LoadOrStore(storeKey, &sync.RWMutex{})
+	//nolint:forcetypeassert // sync.Map stores *sync.RWMutex values, type is guaranteed
+	return mu.(*sync.RWMutex)
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// promoterKeyDomain is the domain of the label and annotation keys the promoter uses itself.
+const promoterKeyDomain = "promoter.argoproj.io"
+
+var (
+	// propagatedKeys holds the keys set with SetPropagatedKeys.
+	propagatedKeys      []string
+	propagatedKeysMutex sync.RWMutex
+)
+
+// SetPropagatedKeys sets the keys of the labels and annotations that are propagated from every parent resource to the
+// resources created for it, in addition to the keys listed in the parent's propagateLabels field.
+func SetPropagatedKeys(keys []string) {
+	propagatedKeysMutex.Lock()
+	defer propagatedKeysMutex.Unlock()
+	propagatedKeys = slices.Clone(keys)
+}
+
+// PropagatedMetadata returns the labels and annotations of the parent whose keys are in keys or were set with
+// SetPropagatedKeys. Keys in the promoter's own domain are never propagated, so they can't collide with the labels and
+// annotations the promoter sets on the resources it creates.
+func PropagatedMetadata(parent metav1.Object, keys []string) (labels, annotations map[string]string) {
+	propagatedKeysMutex.RLock()
+	allKeys := slices.Concat(propagatedKeys, keys)
+	propagatedKeysMutex.RUnlock()
+
+	labels = map[string]string{}
+	annotations = map[string]string{}
+	for _, key := range allKeys {
+		if IsPromoterKey(key) {
+			continue
+		}
+		if value, ok := parent.GetLabels()[key]; ok {
+			labels[key] = value
+		}
+		if value, ok := parent.GetAnnotations()[key]; ok {
+			annotations[key] = value
+		}
+	}
+	return labels, annotations
+}
+
+// IsPromoterKey returns whether the label or annotation key is in the promoter's domain, e.g.
+// promoter.argoproj.io/environment or changetransferpolicy.promoter.argoproj.io/finalizer.
+func IsPromoterKey(key string) bool {
+	prefix, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+	return prefix == promoterKeyDomain || strings.HasSuffix(prefix, "."+promoterKeyDomain)
+}
@@ -0,0 +1,62 @@
+package utils_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+var _ = Describe("PropagatedMetadata", func() {
+	parent := &promoterv1alpha1.GitRepository{ObjectMeta: metav1.ObjectMeta{
+		Labels: map[string]string{
+			"team":                            "payments",
+			"cost-center":                     "1234",
+			"unlisted":                        "value",
+			promoterv1alpha1.EnvironmentLabel: "environment-production",
+		},
+		Annotations: map[string]string{
+			"team":              "payments@example.com",
+			"example.com/owner": "someone",
+			"unlisted":          "value",
+			"gitrepository.promoter.argoproj.io/finalizer": "value",
+		},
+	}}
+
+	AfterEach(func() {
+		utils.SetPropagatedKeys(nil)
+	})
+
+	It("should copy the labels and annotations with the listed keys", func() {
+		labels, annotations := utils.PropagatedMetadata(parent, []string{"team", "example.com/owner", "missing"})
+		Expect(labels).To(Equal(map[string]string{"team": "payments"}))
+		Expect(annotations).To(Equal(map[string]string{"team": "payments@example.com", "example.com/owner": "someone"}))
+	})
+
+	It("should copy the keys set for the controller too", func() {
+		utils.SetPropagatedKeys([]string{"cost-center"})
+		labels, annotations := utils.PropagatedMetadata(parent, []string{"team"})
+		Expect(labels).To(Equal(map[string]string{"team": "payments", "cost-center": "1234"}))
+		Expect(annotations).To(Equal(map[string]string{"team": "payments@example.com"}))
+	})
+
+	It("should never copy the promoter's own keys", func() {
+		utils.SetPropagatedKeys([]string{"gitrepository.promoter.argoproj.io/finalizer"})
+		labels, annotations := utils.PropagatedMetadata(parent, []string{promoterv1alpha1.EnvironmentLabel})
+		Expect(labels).To(BeEmpty())
+		Expect(annotations).To(BeEmpty())
+	})
+})
+
+var _ = DescribeTable("IsPromoterKey",
+	func(key string, expected bool) {
+		Expect(utils.IsPromoterKey(key)).To(Equal(expected))
+	},
+	Entry("promoter label", promoterv1alpha1.PromotionStrategyLabel, true),
+	Entry("promoter subdomain", promoterv1alpha1.ChangeTransferPolicyPullRequestFinalizer, true),
+	Entry("other domain", "example.com/promoter.argoproj.io", false),
+	Entry("lookalike domain", "notpromoter.argoproj.io/team", false),
+	Entry("no prefix", "team", false),
+)
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/tools/events"
+)
+
+// DefaultEventRateLimitInterval is how long a RateLimitedRecorder drops repeats of an event recorded for a resource.
+const DefaultEventRateLimitInterval = 5 * time.Minute
+
+// RateLimitedRecorder is an event recorder that drops an event when the same event, with the same type, reason and
+// note, was already recorded for the same resource within the interval. Controllers requeue their resources often and
+// record the same event on each reconcile, such as ReconciliationSuccess or a Warning while they wait for something;
+// this keeps those from being sent to the API server every time.
+//
+// An event that keeps being recorded for longer than the interval is an ongoing condition, such as a promotion that is
+// still blocked. Instead of the same event, it is recorded once per interval with a note that says how long it has
+// been going on. An event that differs from the ones recorded within the interval, such as the next state of the
+// resource, is always recorded right away.
+type RateLimitedRecorder struct {
+	events.EventRecorder
+	interval time.Duration
+	now      func() time.Time
+
+	mu         sync.Mutex
+	recorded   map[rateLimitKey]rateLimitEntry
+	lastPruned time.Time
+}
+
+var _ events.EventRecorder = &RateLimitedRecorder{}
+
+// rateLimitKey identifies an event of a resource.
+type rateLimitKey struct {
+	kind      string
+	namespace string
+	name      string
+	eventtype string
+	reason    string
+	note      string
+}
+
+// rateLimitEntry is when an event of a resource was first recorded, last recorded and last dropped.
+type rateLimitEntry struct {
+	// since is when the event was first recorded, before it kept being repeated.
+	since time.Time
+	// recorded is when the event, or a summary of it, was last recorded.
+	recorded time.Time
+	// seen is when the event was last recorded or dropped.
+	seen time.Time
+}
+
+// NewRateLimitedRecorder returns a RateLimitedRecorder that records the events with recorder, at most once per
+// interval for the same event of the same resource.
+func NewRateLimitedRecorder(recorder events.EventRecorder, interval time.Duration) *RateLimitedRecorder {
+	return &RateLimitedRecorder{
+		EventRecorder: recorder,
+		interval:      interval,
+		now:           time.Now,
+		recorded:      map[rateLimitKey]rateLimitEntry{},
+	}
+}
+
+// Eventf records the event unless it was recorded for the same resource within the interval. An event that has been
+// repeated for longer than the interval is recorded with a note saying for how long.
+func (r *RateLimitedRecorder) Eventf(regarding runtime.Object, related runtime.Object, eventtype, reason, action, note string, args ...any) {
+	if r.interval <= 0 {
+		r.EventRecorder.Eventf(regarding, related, eventtype, reason, action, note, args...)
+		return
+	}
+
+	formatted := fmt.Sprintf(note, args...)
+	record, ongoing := r.allow(regarding, eventtype, reason, formatted)
+	switch {
+	case !record:
+		return
+	case ongoing > 0:
+		r.EventRecorder.Eventf(regarding, related, eventtype, reason, action, "%s (ongoing for %s)", formatted, duration.HumanDuration(ongoing))
+	default:
+		r.EventRecorder.Eventf(regarding, related, eventtype, reason, action, note, args...)
+	}
+}
+
+// allow returns whether the event may be recorded, and if so, remembers when it was. If the event has been repeated
+// since before the interval, it also returns for how long, so that it is recorded as a summary.
+func (r *RateLimitedRecorder) allow(regarding runtime.Object, eventtype, reason, note string) (bool, time.Duration) {
+	key := rateLimitKey{kind: fmt.Sprintf("%T", regarding), eventtype: eventtype, reason: reason, note: note}
+	if obj, err := meta.Accessor(regarding); err == nil {
+		key.namespace = obj.GetNamespace()
+		key.name = obj.GetName()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	if now.Sub(r.lastPruned) >= r.interval {
+		for k, entry := range r.recorded {
+			if now.Sub(entry.seen) >= r.interval {
+				delete(r.recorded, k)
+			}
+		}
+		r.lastPruned = now
+	}
+
+	entry, ok := r.recorded[key]
+	if !ok || now.Sub(entry.seen) >= r.interval {
+		// The event is new, or it stopped being repeated for an interval, so it's recorded as is.
+		r.recorded[key] = rateLimitEntry{since: now, recorded: now, seen: now}
+		return true, 0
+	}
+	entry.seen = now
+	if now.Sub(entry.recorded) < r.interval {
+		r.recorded[key] = entry
+		return false, 0
+	}
+	entry.recorded = now
+	r.recorded[key] = entry
+	return true, now.Sub(entry.since)
+}
@@ -0,0 +1,80 @@
+package utils_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
+	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+var _ = Describe("RateLimitedRecorder", func() {
+	const interval = 100 * time.Millisecond
+
+	var (
+		fakeRecorder *events.FakeRecorder
+		recorder     *utils.RateLimitedRecorder
+		obj          *promoterv1alpha1.PromotionStrategy
+	)
+
+	BeforeEach(func() {
+		fakeRecorder = events.NewFakeRecorder(10)
+		recorder = utils.NewRateLimitedRecorder(fakeRecorder, interval)
+		obj = &promoterv1alpha1.PromotionStrategy{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+	})
+
+	It("should drop repeats of an event within the interval", func() {
+		recorder.Eventf(obj, nil, "Normal", string(conditions.ReconciliationSuccess), "Reconciling", "Reconciliation successful")
+		recorder.Eventf(obj, nil, "Normal", string(conditions.ReconciliationSuccess), "Reconciling", "Reconciliation successful")
+		Expect(fakeRecorder.Events).To(HaveLen(1))
+
+		time.Sleep(interval)
+		recorder.Eventf(obj, nil, "Normal", string(conditions.ReconciliationSuccess), "Reconciling", "Reconciliation successful")
+		Expect(fakeRecorder.Events).To(HaveLen(2))
+		Expect(<-fakeRecorder.Events).To(Equal("Normal ReconciliationSuccess Reconciliation successful"))
+		Expect(<-fakeRecorder.Events).To(Equal("Normal ReconciliationSuccess Reconciliation successful"))
+	})
+
+	It("should summarize an event that keeps being repeated for longer than the interval", func() {
+		blocked := func() {
+			recorder.Eventf(obj, nil, "Warning", constants.PromotionBlockedReason, "EvaluatingPromotion", constants.PromotionBlockedMessage, "abc1234", "environment/production", "e2e-tests")
+		}
+		blocked()
+		time.Sleep(interval / 2)
+		blocked()
+		Expect(fakeRecorder.Events).To(HaveLen(1))
+
+		time.Sleep(interval / 2)
+		blocked()
+		Expect(fakeRecorder.Events).To(HaveLen(2))
+		Expect(<-fakeRecorder.Events).To(Equal("Warning PromotionBlocked Promotion of dry sha abc1234 to environment environment/production is blocked by failing commit statuses e2e-tests"))
+		Expect(<-fakeRecorder.Events).To(MatchRegexp(`^Warning PromotionBlocked Promotion of dry sha abc1234 to environment environment/production is blocked by failing commit statuses e2e-tests \(ongoing for \d+s\)$`))
+
+		// A different event, such as the next state of the resource, is recorded right away.
+		recorder.Eventf(obj, nil, "Warning", constants.PromotionBlockedReason, "EvaluatingPromotion", constants.PromotionBlockedMessage, "def5678", "environment/production", "e2e-tests")
+		Expect(fakeRecorder.Events).To(HaveLen(1))
+	})
+
+	It("should record events that differ in their resource, reason or note", func() {
+		other := &promoterv1alpha1.PromotionStrategy{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"}}
+
+		recorder.Eventf(obj, nil, "Warning", string(conditions.ReconciliationError), "Reconciling", "Reconciliation failed: %v", "timeout")
+		recorder.Eventf(obj, nil, "Warning", string(conditions.ReconciliationError), "Reconciling", "Reconciliation failed: %v", "conflict")
+		recorder.Eventf(other, nil, "Warning", string(conditions.ReconciliationError), "Reconciling", "Reconciliation failed: %v", "timeout")
+		recorder.Eventf(obj, nil, "Normal", string(conditions.ReconciliationSuccess), "Reconciling", "Reconciliation successful")
+		Expect(fakeRecorder.Events).To(HaveLen(4))
+	})
+
+	It("should record every event without an interval", func() {
+		recorder = utils.NewRateLimitedRecorder(fakeRecorder, 0)
+		recorder.Eventf(obj, nil, "Normal", string(conditions.ReconciliationSuccess), "Reconciling", "Reconciliation successful")
+		recorder.Eventf(obj, nil, "Normal", string(conditions.ReconciliationSuccess), "Reconciling", "Reconciliation successful")
+		Expect(fakeRecorder.Events).To(HaveLen(2))
+	})
+})
@@ -6,6 +6,7 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	promoterv1alpha2 "github.com/argoproj-labs/gitops-promoter/api/v1alpha2"
 	argocd "github.com/argoproj-labs/gitops-promoter/internal/types/argocd"
 )
 
@@ -16,6 +17,7 @@ func GetScheme() *runtime.Scheme {
 
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(promoterv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(promoterv1alpha2.AddToScheme(scheme))
 	utilruntime.Must(argocd.AddToScheme(scheme))
 
 	return scheme
@@ -0,0 +1,249 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// secretSyncTimeout is how long a read waits for the informer of a Secret that wasn't read before to sync.
+const secretSyncTimeout = 30 * time.Second
+
+// secretIdleTimeout is how long the informer of a Secret keeps running after the Secret was last read. The reconcilers
+// read the Secrets their resources reference on every reconcile, so only the informers of Secrets that nothing
+// references anymore are stopped.
+const secretIdleTimeout = time.Hour
+
+// secretSweepInterval is the delay between the checks for informers of Secrets that weren't read for
+// secretIdleTimeout.
+const secretSweepInterval = 10 * time.Minute
+
+// SecretCacheOptions returns the options of the manager's cache for Secrets. The manager only caches the Secrets of the
+// namespace with the label, which the kubeconfig provider watches to connect to the other clusters. The Secrets the
+// promoter's resources reference are read through a ReferencedSecrets instead.
+func SecretCacheOptions(namespace, kubeconfigSecretLabel string) cache.ByObject {
+	return cache.ByObject{
+		Namespaces: map[string]cache.Config{namespace: {}},
+		Label:      labels.SelectorFromSet(labels.Set{kubeconfigSecretLabel: "true"}),
+	}
+}
+
+// ReferencedSecrets reads the Secrets the promoter's resources reference, like the Secrets of the ScmProviders, from
+// informers that each list and watch a single Secret by its name. The informer of a Secret is started the first time
+// it's read, so that the controller neither caches every Secret it can read nor has to change the Secrets it reads. It
+// is stopped again when the Secret doesn't exist, or when the Secret wasn't read for secretIdleTimeout, so that names
+// that were read once don't keep a watch open. Add it to the manager, which stops the informers when it stops.
+type ReferencedSecrets struct {
+	clientset kubernetes.Interface
+	// ctx is the parent of the contexts of the informers, it's canceled when the manager stops.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu        sync.Mutex
+	informers map[client.ObjectKey]*secretInformer
+	// events are the channels of the sources returned by Source, which get the changes to the Secrets.
+	events []chan event.GenericEvent
+}
+
+// secretInformer is the informer of a single Secret.
+type secretInformer struct {
+	toolscache.SharedIndexInformer
+	// ctx is canceled to stop the informer.
+	ctx    context.Context
+	cancel context.CancelFunc
+	// lastRead is when the Secret was last read. It's guarded by the mutex of the ReferencedSecrets.
+	lastRead time.Time
+}
+
+// NewReferencedSecrets returns a ReferencedSecrets that lists and watches the Secrets with the clientset.
+func NewReferencedSecrets(clientset kubernetes.Interface) *ReferencedSecrets {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ReferencedSecrets{
+		clientset: clientset,
+		ctx:       ctx,
+		cancel:    cancel,
+		informers: map[client.ObjectKey]*secretInformer{},
+	}
+}
+
+// Start implements manager.Runnable. It stops the informers of the Secrets that weren't read for secretIdleTimeout, and
+// stops all informers when ctx is done.
+func (s *ReferencedSecrets) Start(ctx context.Context) error {
+	defer s.cancel()
+
+	ticker := time.NewTicker(secretSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.stopIdle(ctx, secretIdleTimeout)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The webhook receiver of the replicas that aren't the
+// leader reads Secrets too.
+func (s *ReferencedSecrets) NeedLeaderElection() bool {
+	return false
+}
+
+// Lister returns the lister of the Secret with the key. The informer of the Secret is started, and waited for to sync,
+// the first time.
+func (s *ReferencedSecrets) Lister(ctx context.Context, key client.ObjectKey) (corev1listers.SecretNamespaceLister, error) {
+	informer, err := s.informer(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return corev1listers.NewSecretLister(informer.GetIndexer()).Secrets(key.Namespace), nil
+}
+
+// informer returns the synced informer of the Secret with the key, and starts it if it isn't running.
+func (s *ReferencedSecrets) informer(ctx context.Context, key client.ObjectKey) (*secretInformer, error) {
+	s.mu.Lock()
+	informer, ok := s.informers[key]
+	if !ok {
+		informer = &secretInformer{
+			SharedIndexInformer: corev1informers.NewFilteredSecretInformer(s.clientset, key.Namespace, 0, toolscache.Indexers{}, func(opts *metav1.ListOptions) {
+				opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", key.Name).String()
+			}),
+		}
+		if _, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj any) { s.notify(obj) },
+			UpdateFunc: func(_, obj any) { s.notify(obj) },
+			DeleteFunc: func(obj any) { s.notify(obj) },
+		}); err != nil {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("failed to add event handler to the informer of Secret %q: %w", key, err)
+		}
+		informer.ctx, informer.cancel = context.WithCancel(s.ctx)
+		go informer.RunWithContext(informer.ctx)
+		s.informers[key] = informer
+		log.FromContext(ctx).V(4).Info("Started informer of referenced Secret", "secret", key)
+	}
+	informer.lastRead = time.Now()
+	s.mu.Unlock()
+
+	syncCtx, cancel := context.WithTimeout(ctx, secretSyncTimeout)
+	defer cancel()
+	// An informer that is stopped before it synced never syncs, don't wait for it.
+	defer context.AfterFunc(informer.ctx, cancel)()
+	if !toolscache.WaitForCacheSync(syncCtx.Done(), informer.HasSynced) {
+		return nil, fmt.Errorf("timed out waiting for the informer of Secret %q to sync", key)
+	}
+	return informer, nil
+}
+
+// stopInformer stops the informer of the Secret with the key, unless it was replaced by a new one in the meantime.
+func (s *ReferencedSecrets) stopInformer(ctx context.Context, key client.ObjectKey, informer *secretInformer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.informers[key] != informer {
+		return
+	}
+	informer.cancel()
+	delete(s.informers, key)
+	log.FromContext(ctx).V(4).Info("Stopped informer of referenced Secret", "secret", key)
+}
+
+// stopIdle stops the informers of the Secrets that weren't read for longer than idleTimeout.
+func (s *ReferencedSecrets) stopIdle(ctx context.Context, idleTimeout time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, informer := range s.informers {
+		if time.Since(informer.lastRead) <= idleTimeout {
+			continue
+		}
+		informer.cancel()
+		delete(s.informers, key)
+		log.FromContext(ctx).V(4).Info("Stopped informer of idle referenced Secret", "secret", key)
+	}
+}
+
+// Source returns a source of the changes to the Secrets read through s, for controllers to watch them with the handler.
+func (s *ReferencedSecrets) Source(h handler.EventHandler) source.Source {
+	// The buffer matches the default internal buffer size of source.Channel.
+	events := make(chan event.GenericEvent, 1024)
+	s.mu.Lock()
+	s.events = append(s.events, events)
+	s.mu.Unlock()
+	return source.Channel(events, h)
+}
+
+// notify sends a Secret an informer added, updated or deleted to the sources, unless their channel is full.
+func (s *ReferencedSecrets) notify(obj any) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	secret, ok := obj.(*v1.Secret)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	events := s.events
+	s.mu.Unlock()
+	for _, ch := range events {
+		select {
+		case ch <- event.GenericEvent{Object: secret}:
+		default:
+			// The controllers of a replica that isn't the leader don't run, and so don't drain their channels. The
+			// controllers check the credentials again periodically anyway.
+			log.Log.V(4).Info("Dropped change to referenced Secret, the channel is full", "secret", client.ObjectKeyFromObject(secret))
+		}
+	}
+}
+
+// secretClient is a client.Client that reads Secrets from a ReferencedSecrets.
+type secretClient struct {
+	client.Client
+	secrets *ReferencedSecrets
+}
+
+// NewSecretClient returns a client that reads from c, but reads Secrets from secrets. The reconcilers and the webhook
+// receiver read the Secrets the promoter's resources reference through it, and build the SCM providers with them, while
+// the manager's cache only holds the kubeconfig Secrets.
+func NewSecretClient(c client.Client, secrets *ReferencedSecrets) client.Client {
+	return &secretClient{Client: c, secrets: secrets}
+}
+
+// Get implements client.Reader.
+func (c *secretClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	secret, ok := obj.(*v1.Secret)
+	if !ok {
+		return c.Client.Get(ctx, key, obj, opts...) //nolint:wrapcheck // the wrapped client's error is returned as is
+	}
+
+	informer, err := c.secrets.informer(ctx, key)
+	if err != nil {
+		return err
+	}
+	found, err := corev1listers.NewSecretLister(informer.GetIndexer()).Secrets(key.Namespace).Get(key.Name)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Don't keep watching a name that doesn't exist, the next read lists it again.
+			c.secrets.stopInformer(ctx, key, informer)
+		}
+		return err //nolint:wrapcheck // the lister's error is returned as is, NotFound included
+	}
+	found.DeepCopyInto(secret)
+	return nil
+}
@@ -0,0 +1,134 @@
+package utils_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+var _ = Describe("SecretCacheOptions", func() {
+	It("should only select the kubeconfig Secrets of the namespace", func() {
+		opts := utils.SecretCacheOptions("promoter-system", constants.KubeconfigSecretLabel)
+		Expect(opts.Namespaces).To(HaveKey("promoter-system"))
+		Expect(opts.Namespaces).To(HaveLen(1))
+		Expect(opts.Label.Matches(labels.Set{constants.KubeconfigSecretLabel: "true"})).To(BeTrue())
+		Expect(opts.Label.Matches(labels.Set{})).To(BeFalse())
+	})
+})
+
+var _ = Describe("SecretClient", func() {
+	var (
+		ctx       context.Context
+		cancel    context.CancelFunc
+		clientset *kubefake.Clientset
+		c         client.Client
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(func() { cancel() })
+
+		clientset = kubefake.NewClientset(
+			&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "scm-credentials", Namespace: "default"}, Data: map[string][]byte{"token": []byte("a")}},
+		)
+		secrets := utils.NewReferencedSecrets(clientset)
+		go func() {
+			defer GinkgoRecover()
+			Expect(secrets.Start(ctx)).To(Succeed())
+		}()
+
+		cached := fake.NewClientBuilder().WithScheme(utils.GetScheme()).WithObjects(
+			&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "default"}},
+		).Build()
+		c = utils.NewSecretClient(cached, secrets)
+	})
+
+	It("should read a Secret from the informer of its name without changing it", func() {
+		var secret v1.Secret
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "scm-credentials"}, &secret)).To(Succeed())
+		Expect(secret.Data).To(HaveKeyWithValue("token", []byte("a")))
+
+		stored, err := clientset.CoreV1().Secrets("default").Get(ctx, "scm-credentials", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stored.Labels).To(BeEmpty())
+	})
+
+	It("should see the changes to a Secret once it has been read", func() {
+		var secret v1.Secret
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "scm-credentials"}, &secret)).To(Succeed())
+
+		secret.Data["token"] = []byte("b")
+		_, err := clientset.CoreV1().Secrets("default").Update(ctx, &secret, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func(g Gomega) {
+			var updated v1.Secret
+			g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "scm-credentials"}, &updated)).To(Succeed())
+			g.Expect(updated.Data).To(HaveKeyWithValue("token", []byte("b")))
+		}).Should(Succeed())
+	})
+
+	It("should return NotFound for a Secret that doesn't exist, until it's created", func() {
+		var secret v1.Secret
+		err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "missing"}, &secret)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		_, err = clientset.CoreV1().Secrets("default").Create(ctx, &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "default"}}, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() error {
+			return c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "missing"}, &secret)
+		}).Should(Succeed())
+	})
+
+	It("should not keep watching a Secret that doesn't exist", func() {
+		var running atomic.Int32
+		clientset.PrependWatchReactor("secrets", func(action k8stesting.Action) (bool, watch.Interface, error) {
+			w, err := clientset.Tracker().Watch(action.GetResource(), action.GetNamespace())
+			if err != nil {
+				return true, nil, err
+			}
+			running.Add(1)
+			return true, &countedWatch{Interface: w, running: &running}, nil
+		})
+
+		for range 2 {
+			var secret v1.Secret
+			err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "missing"}, &secret)
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		}
+		Eventually(running.Load).Should(BeZero())
+	})
+
+	It("should read other objects from the wrapped client", func() {
+		var cm v1.ConfigMap
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "config"}, &cm)).To(Succeed())
+		err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "missing"}, &cm)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+})
+
+// countedWatch is a watch that decrements running when it's stopped.
+type countedWatch struct {
+	watch.Interface
+	running *atomic.Int32
+	once    sync.Once
+}
+
+func (w *countedWatch) Stop() {
+	w.once.Do(func() { w.running.Add(-1) })
+	w.Interface.Stop()
+}
@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/tracing"
+)
+
+// shard and totalShards are set with SetShard. They are only read when the controllers are set up.
+var (
+	shard       = 0
+	totalShards = 1
+)
+
+// SetShard sets the shard of the resources this controller reconciles, out of totalShards. It must be called before
+// the controllers are set up. With a single shard, the default, every resource is reconciled.
+func SetShard(s, total int) {
+	shard = s
+	totalShards = total
+}
+
+// ShardOf returns the shard, out of totalShards, of the resources whose shard key is key. Keys are spread evenly
+// between the shards, and when the number of shards changes from n to m, only the keys that have to move do: going
+// from n to n+1 shards moves about 1/(n+1) of the keys, all of them to the new shard.
+func ShardOf(key string, totalShards int) int {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return jumpHash(h.Sum64(), totalShards)
+}
+
+// jumpHash is the jump consistent hash of Lamping and Veach, https://arxiv.org/abs/1406.2294.
+func jumpHash(key uint64, buckets int) int {
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
+
+// ShardKey returns the key whose shard reconciles obj: the namespace and name of the GitRepository of obj. Every
+// resource of a PromotionStrategy, and the CommitStatuses it reads, refer to the same GitRepository, so they are all
+// reconciled by the same shard, which reads them from its cache as they are rather than while another shard is
+// updating them. The resources that only refer to a PromotionStrategy are keyed by its GitRepository, which is read
+// from reader, or by the PromotionStrategy itself if it doesn't exist. The resources that don't belong to a
+// repository, such as the ScmProviders, are keyed by their own namespace and name.
+func ShardKey(ctx context.Context, reader client.Reader, obj client.Object) (string, error) {
+	var promotionStrategyName string
+	switch obj := obj.(type) {
+	case *promoterv1alpha1.GitRepository:
+		return obj.Namespace + "/" + obj.Name, nil
+	case *promoterv1alpha1.PromotionStrategy:
+		return obj.Namespace + "/" + obj.Spec.RepositoryReference.Name, nil
+	case *promoterv1alpha1.ChangeTransferPolicy:
+		return obj.Namespace + "/" + obj.Spec.RepositoryReference.Name, nil
+	case *promoterv1alpha1.PullRequest:
+		return obj.Namespace + "/" + obj.Spec.RepositoryReference.Name, nil
+	case *promoterv1alpha1.CommitStatus:
+		return obj.Namespace + "/" + obj.Spec.RepositoryReference.Name, nil
+	case *promoterv1alpha1.ArgoCDCommitStatus:
+		promotionStrategyName = obj.Spec.PromotionStrategyRef.Name
+	case *promoterv1alpha1.GitCommitStatus:
+		promotionStrategyName = obj.Spec.PromotionStrategyRef.Name
+	case *promoterv1alpha1.TimedCommitStatus:
+		promotionStrategyName = obj.Spec.PromotionStrategyRef.Name
+	case *promoterv1alpha1.WebRequestCommitStatus:
+		promotionStrategyName = obj.Spec.PromotionStrategyRef.Name
+	case *promoterv1alpha1.RevertCommit:
+		promotionStrategyName = obj.Spec.PromotionStrategyRef.Name
+	}
+	if promotionStrategyName == "" {
+		return obj.GetNamespace() + "/" + obj.GetName(), nil
+	}
+
+	var ps promoterv1alpha1.PromotionStrategy
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: promotionStrategyName}, &ps); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return obj.GetNamespace() + "/" + promotionStrategyName, nil
+		}
+		return "", fmt.Errorf("failed to get PromotionStrategy %q: %w", promotionStrategyName, err)
+	}
+	return ShardKey(ctx, reader, &ps)
+}
+
+// shardedReconciler is a reconcile.TypedReconciler that only passes on the reconciles of the resources of this shard.
+type shardedReconciler[request tracing.Request] struct {
+	reader      client.Reader
+	object      client.Object
+	reconciler  reconcile.TypedReconciler[request]
+	shard       int
+	totalShards int
+}
+
+// NewShardedReconciler returns a reconciler that only runs the reconciles of r for the resources of the kind of object
+// that belong to the shard set with SetShard, read from reader. The other shards reconcile the others. It filters the
+// reconciles rather than the events, so that the resources enqueued by the other controllers and the webhook receiver
+// are filtered as well. Resources that don't exist are passed on, so that r handles them as usual. With a single
+// shard, it returns r.
+func NewShardedReconciler[request tracing.Request](
+	reader client.Reader,
+	object client.Object,
+	r reconcile.TypedReconciler[request],
+) reconcile.TypedReconciler[request] {
+	if totalShards <= 1 {
+		return r
+	}
+	return &shardedReconciler[request]{reader: reader, object: object, reconciler: r, shard: shard, totalShards: totalShards}
+}
+
+// Reconcile implements reconcile.TypedReconciler.
+func (r *shardedReconciler[request]) Reconcile(ctx context.Context, req request) (reconcile.Result, error) {
+	var key types.NamespacedName
+	switch req := any(req).(type) {
+	case reconcile.Request:
+		key = req.NamespacedName
+	case mcreconcile.Request:
+		// The resources of the multicluster controllers are in the local cluster, the cluster of the request is the
+		// one of the resource that enqueued it.
+		key = req.NamespacedName
+	}
+
+	obj, ok := r.object.DeepCopyObject().(client.Object)
+	if !ok {
+		return reconcile.Result{}, fmt.Errorf("%T is not a client.Object", r.object)
+	}
+	if err := r.reader.Get(ctx, key, obj); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return r.reconciler.Reconcile(ctx, req) //nolint:wrapcheck // the controller logs the reconciler's error as is
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get %s to find its shard: %w", key, err)
+	}
+	shardKey, err := ShardKey(ctx, r.reader, obj)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get the shard key of %s: %w", key, err)
+	}
+	if s := ShardOf(shardKey, r.totalShards); s != r.shard {
+		log.FromContext(ctx).V(4).Info("skipping resource of another shard", "shardKey", shardKey, "shard", s)
+		return reconcile.Result{}, nil
+	}
+	return r.reconciler.Reconcile(ctx, req) //nolint:wrapcheck // the controller logs the reconciler's error as is
+}
@@ -0,0 +1,160 @@
+package utils_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+// countingReconciler counts the reconciles it runs.
+type countingReconciler struct {
+	reconciles int
+}
+
+func (r *countingReconciler) Reconcile(context.Context, reconcile.Request) (reconcile.Result, error) {
+	r.reconciles++
+	return reconcile.Result{}, nil
+}
+
+var _ = Describe("ShardOf", func() {
+	keys := make([]string, 10000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("namespace-%d/repository-%d", i%50, i)
+	}
+
+	It("should spread the keys evenly between the shards", func() {
+		counts := make([]int, 4)
+		for _, key := range keys {
+			s := utils.ShardOf(key, len(counts))
+			Expect(s).To(BeNumerically(">=", 0))
+			Expect(s).To(BeNumerically("<", len(counts)))
+			counts[s]++
+		}
+		for _, count := range counts {
+			Expect(count).To(BeNumerically("~", len(keys)/len(counts), len(keys)/20))
+		}
+	})
+
+	It("should only move keys to the new shard when a shard is added", func() {
+		moved := 0
+		for _, key := range keys {
+			before, after := utils.ShardOf(key, 4), utils.ShardOf(key, 5)
+			if before != after {
+				Expect(after).To(Equal(4))
+				moved++
+			}
+		}
+		Expect(moved).To(BeNumerically("~", len(keys)/5, len(keys)/20))
+	})
+
+	It("should put every key in the only shard", func() {
+		for _, key := range keys[:100] {
+			Expect(utils.ShardOf(key, 1)).To(Equal(0))
+		}
+	})
+})
+
+var _ = Describe("ShardKey", func() {
+	var c client.Client
+
+	BeforeEach(func() {
+		ps := &promoterv1alpha1.PromotionStrategy{
+			ObjectMeta: metav1.ObjectMeta{Name: "ps", Namespace: "default"},
+			Spec: promoterv1alpha1.PromotionStrategySpec{
+				RepositoryReference: promoterv1alpha1.ObjectReference{Name: "repo"},
+			},
+		}
+		c = fake.NewClientBuilder().WithScheme(utils.GetScheme()).WithObjects(ps).Build()
+	})
+
+	It("should key every resource of a PromotionStrategy by its GitRepository", func() {
+		meta := metav1.ObjectMeta{Name: "resource", Namespace: "default"}
+		repositoryRef := promoterv1alpha1.ObjectReference{Name: "repo"}
+		psRef := promoterv1alpha1.ObjectReference{Name: "ps"}
+		for _, obj := range []client.Object{
+			&promoterv1alpha1.GitRepository{ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "default"}},
+			&promoterv1alpha1.PromotionStrategy{ObjectMeta: meta, Spec: promoterv1alpha1.PromotionStrategySpec{RepositoryReference: repositoryRef}},
+			&promoterv1alpha1.ChangeTransferPolicy{ObjectMeta: meta, Spec: promoterv1alpha1.ChangeTransferPolicySpec{RepositoryReference: repositoryRef}},
+			&promoterv1alpha1.PullRequest{ObjectMeta: meta, Spec: promoterv1alpha1.PullRequestSpec{RepositoryReference: repositoryRef}},
+			&promoterv1alpha1.CommitStatus{ObjectMeta: meta, Spec: promoterv1alpha1.CommitStatusSpec{RepositoryReference: repositoryRef}},
+			&promoterv1alpha1.ArgoCDCommitStatus{ObjectMeta: meta, Spec: promoterv1alpha1.ArgoCDCommitStatusSpec{PromotionStrategyRef: psRef}},
+			&promoterv1alpha1.GitCommitStatus{ObjectMeta: meta, Spec: promoterv1alpha1.GitCommitStatusSpec{PromotionStrategyRef: psRef}},
+			&promoterv1alpha1.TimedCommitStatus{ObjectMeta: meta, Spec: promoterv1alpha1.TimedCommitStatusSpec{PromotionStrategyRef: psRef}},
+			&promoterv1alpha1.WebRequestCommitStatus{ObjectMeta: meta, Spec: promoterv1alpha1.WebRequestCommitStatusSpec{PromotionStrategyRef: psRef}},
+			&promoterv1alpha1.RevertCommit{ObjectMeta: meta, Spec: promoterv1alpha1.RevertCommitSpec{PromotionStrategyRef: psRef}},
+		} {
+			Expect(utils.ShardKey(context.Background(), c, obj)).To(Equal("default/repo"), "%T", obj)
+		}
+	})
+
+	It("should key a resource of a PromotionStrategy that doesn't exist by the PromotionStrategy", func() {
+		tcs := &promoterv1alpha1.TimedCommitStatus{
+			ObjectMeta: metav1.ObjectMeta{Name: "timed", Namespace: "default"},
+			Spec:       promoterv1alpha1.TimedCommitStatusSpec{PromotionStrategyRef: promoterv1alpha1.ObjectReference{Name: "deleted"}},
+		}
+		Expect(utils.ShardKey(context.Background(), c, tcs)).To(Equal("default/deleted"))
+	})
+
+	It("should key the resources that don't belong to a repository by themselves", func() {
+		provider := &promoterv1alpha1.ClusterScmProvider{ObjectMeta: metav1.ObjectMeta{Name: "github"}}
+		Expect(utils.ShardKey(context.Background(), c, provider)).To(Equal("/github"))
+	})
+})
+
+var _ = Describe("ShardedReconciler", func() {
+	// repositoryOfShard returns the name of a GitRepository of the shard s out of totalShards.
+	repositoryOfShard := func(s, totalShards int) string {
+		for i := 0; ; i++ {
+			name := fmt.Sprintf("repo-%d", i)
+			if utils.ShardOf("default/"+name, totalShards) == s {
+				return name
+			}
+		}
+	}
+
+	var c client.Client
+
+	BeforeEach(func() {
+		DeferCleanup(utils.SetShard, 0, 1)
+		var objects []client.Object
+		for s := range 3 {
+			objects = append(objects, &promoterv1alpha1.PullRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pr-%d", s), Namespace: "default"},
+				Spec: promoterv1alpha1.PullRequestSpec{
+					RepositoryReference: promoterv1alpha1.ObjectReference{Name: repositoryOfShard(s, 3)},
+				},
+			})
+		}
+		c = fake.NewClientBuilder().WithScheme(utils.GetScheme()).WithObjects(objects...).Build()
+	})
+
+	reconcileAll := func(r reconcile.Reconciler) {
+		for _, name := range []string{"pr-0", "pr-1", "pr-2", "deleted"} {
+			_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "default", Name: name}})
+			Expect(err).NotTo(HaveOccurred())
+		}
+	}
+
+	It("should only reconcile the resources of its shard, and the ones that don't exist", func() {
+		utils.SetShard(1, 3)
+		reconciler := &countingReconciler{}
+		reconcileAll(utils.NewShardedReconciler(c, &promoterv1alpha1.PullRequest{}, reconciler))
+		Expect(reconciler.reconciles).To(Equal(2))
+	})
+
+	It("should reconcile every resource with a single shard", func() {
+		reconciler := &countingReconciler{}
+		Expect(utils.NewShardedReconciler(c, &promoterv1alpha1.PullRequest{}, reconciler)).To(BeIdenticalTo(reconciler))
+		reconcileAll(reconciler)
+		Expect(reconciler.reconciles).To(Equal(4))
+	})
+})
@@ -77,6 +77,8 @@ func statusApplyConfig(obj client.Object, conditionsOnly bool) (any, error) {
 		return scmProviderStatusApply(o, conditionsOnly)
 	case *promoterv1alpha1.ClusterScmProvider:
 		return clusterScmProviderStatusApply(o, conditionsOnly)
+	case *promoterv1alpha1.RevertCommit:
+		return revertCommitStatusApply(o, conditionsOnly)
 	default:
 		return nil, fmt.Errorf("unsupported object type for status SSA: %T", obj)
 	}
@@ -164,9 +166,12 @@ func pullRequestStatusApply(o *promoterv1alpha1.PullRequest, conditionsOnly bool
 
 func gitRepositoryStatusApply(o *promoterv1alpha1.GitRepository, conditionsOnly bool) (any, error) {
 	statusAC := acv1alpha1.GitRepositoryStatus()
+	// The webhook receiver owns lastWebhookDelivery, don't apply a cached value of it over a newer one.
+	status := o.Status
+	status.LastWebhookDelivery = nil
 	if conditionsOnly {
 		statusAC = statusAC.WithConditions(ConditionsToApply(o.Status.Conditions)...)
-	} else if err := jsonRoundTrip(&o.Status, statusAC); err != nil {
+	} else if err := jsonRoundTrip(&status, statusAC); err != nil {
 		return nil, err
 	}
 	return acv1alpha1.GitRepository(o.Name, o.Namespace).WithStatus(statusAC), nil
@@ -192,6 +197,16 @@ func clusterScmProviderStatusApply(o *promoterv1alpha1.ClusterScmProvider, condi
 	return acv1alpha1.ClusterScmProvider(o.Name, "").WithStatus(statusAC), nil
 }
 
+func revertCommitStatusApply(o *promoterv1alpha1.RevertCommit, conditionsOnly bool) (any, error) {
+	statusAC := acv1alpha1.RevertCommitStatus()
+	if conditionsOnly {
+		statusAC = statusAC.WithConditions(ConditionsToApply(o.Status.Conditions)...)
+	} else if err := jsonRoundTrip(&o.Status, statusAC); err != nil {
+		return nil, err
+	}
+	return acv1alpha1.RevertCommit(o.Name, o.Namespace).WithStatus(statusAC), nil
+}
+
 // jsonRoundTrip copies all JSON-tagged fields from src into dst by marshaling src and
 // unmarshaling into dst. This works for status types whose apply configuration mirrors
 // the original type's JSON shape (which is true for all generated apply configs).
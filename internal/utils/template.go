@@ -2,11 +2,15 @@ package utils
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 	"text/template"
 
 	sprig "github.com/go-task/slim-sprig/v3"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 )
 
 var sanitizedSprigFuncMap = sprig.GenericFuncMap()
@@ -18,6 +22,14 @@ func init() {
 	sanitizedSprigFuncMap["urlQueryEscape"] = url.QueryEscape
 }
 
+// ValidateStringTemplate returns an error if templateStr doesn't parse as a template RenderStringTemplate can render.
+func ValidateStringTemplate(templateStr string) error {
+	if _, err := template.New("").Funcs(sanitizedSprigFuncMap).Parse(templateStr); err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+	return nil
+}
+
 // RenderStringTemplate renders a string template with the provided data.
 func RenderStringTemplate(templateStr string, data any, options ...string) (string, error) {
 	tmpl, err := template.New("").Funcs(sanitizedSprigFuncMap).Parse(templateStr)
@@ -37,3 +49,39 @@ func RenderStringTemplate(templateStr string, data any, options ...string) (stri
 
 	return buf.String(), nil
 }
+
+// revertCommitMessageData is the data spec.commitMessageTemplate of a RevertCommit is rendered with.
+type revertCommitMessageData struct {
+	Sha            string
+	ShortSha       string
+	Subject        string
+	Author         string
+	Name           string
+	Namespace      string
+	TemplateFields map[string]string
+}
+
+// RenderRevertCommitMessage renders the commit message template of rc for the reverted commit. A field of
+// spec.templateFields that the template uses but that isn't set is an error, and so is an empty message.
+func RenderRevertCommitMessage(rc *promoterv1alpha1.RevertCommit, reverted promoterv1alpha1.CommitShaState) (string, error) {
+	shortSha := reverted.Sha
+	if len(shortSha) > 7 {
+		shortSha = shortSha[:7]
+	}
+	message, err := RenderStringTemplate(rc.Spec.CommitMessageTemplate, revertCommitMessageData{
+		Sha:            reverted.Sha,
+		ShortSha:       shortSha,
+		Subject:        reverted.Subject,
+		Author:         reverted.Author,
+		Name:           rc.Name,
+		Namespace:      rc.Namespace,
+		TemplateFields: rc.Spec.TemplateFields,
+	}, "missingkey=error")
+	if err != nil {
+		return "", fmt.Errorf("failed to render commit message template: %w", err)
+	}
+	if strings.TrimSpace(message) == "" {
+		return "", errors.New("commit message template renders an empty message")
+	}
+	return message, nil
+}
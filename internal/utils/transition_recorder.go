@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+
+	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
+)
+
+// ReadyTransitionRecorder is an event recorder for controllers that reconcile their resources periodically. It only
+// records an event when its reason differs from the reason of the Ready condition the resource had before the
+// reconcile, so a resource that stays in the same state doesn't get the same event on every check.
+type ReadyTransitionRecorder struct {
+	events.EventRecorder
+	previousReason string
+}
+
+var _ events.EventRecorder = &ReadyTransitionRecorder{}
+
+// NewReadyTransitionRecorder returns a ReadyTransitionRecorder that records the events with recorder.
+func NewReadyTransitionRecorder(recorder events.EventRecorder) *ReadyTransitionRecorder {
+	return &ReadyTransitionRecorder{EventRecorder: recorder}
+}
+
+// SetPrevious remembers the reason of the Ready condition in conditions. Call it right after getting the resource,
+// before the Ready condition is removed to start the reconcile fresh.
+func (r *ReadyTransitionRecorder) SetPrevious(conditions []metav1.Condition) {
+	r.previousReason = ""
+	if ready := meta.FindStatusCondition(conditions, string(promoterConditions.Ready)); ready != nil {
+		r.previousReason = ready.Reason
+	}
+}
+
+// Eventf records the event unless its reason is the reason of the previous Ready condition.
+func (r *ReadyTransitionRecorder) Eventf(regarding runtime.Object, related runtime.Object, eventtype, reason, action, note string, args ...any) {
+	if reason == r.previousReason {
+		return
+	}
+	r.EventRecorder.Eventf(regarding, related, eventtype, reason, action, note, args...)
+}
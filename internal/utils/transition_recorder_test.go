@@ -0,0 +1,46 @@
+package utils_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+var _ = Describe("ReadyTransitionRecorder", func() {
+	var (
+		fakeRecorder *events.FakeRecorder
+		recorder     *utils.ReadyTransitionRecorder
+		obj          *promoterv1alpha1.GitRepository
+	)
+
+	BeforeEach(func() {
+		fakeRecorder = events.NewFakeRecorder(10)
+		recorder = utils.NewReadyTransitionRecorder(fakeRecorder)
+		obj = &promoterv1alpha1.GitRepository{ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "default"}}
+	})
+
+	It("should record every event without a previous Ready condition", func() {
+		recorder.SetPrevious(nil)
+		recorder.Eventf(obj, nil, "Normal", string(conditions.ReconciliationSuccess), "Reconciling", "Reconciliation successful")
+		Expect(fakeRecorder.Events).To(HaveLen(1))
+	})
+
+	It("should only record events that change the Ready reason", func() {
+		recorder.SetPrevious([]metav1.Condition{{
+			Type:   string(conditions.Ready),
+			Status: metav1.ConditionFalse,
+			Reason: string(conditions.AccessDenied),
+		}})
+
+		recorder.Eventf(obj, nil, "Warning", string(conditions.AccessDenied), "Reconciling", "Access denied")
+		Expect(fakeRecorder.Events).To(BeEmpty())
+
+		recorder.Eventf(obj, nil, "Normal", string(conditions.ReconciliationSuccess), "Reconciling", "Reconciliation successful")
+		Expect(fakeRecorder.Events).To(HaveLen(1))
+	})
+})
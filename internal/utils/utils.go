@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"hash/fnv"
+	"net/url"
+	"path"
 	"regexp"
 	"runtime/debug"
 	"slices"
@@ -186,11 +188,39 @@ func GetPullRequestName(repoOwner, repoName, pcProposedBranch, pcActiveBranch st
 	return fmt.Sprintf("%s-%s-%s-%s", repoOwner, repoName, pcProposedBranch, pcActiveBranch)
 }
 
+// GetRepositoryOwnerAndNameFromUrl returns the owner and name of the repository at a clone URL, taken from the last two
+// segments of its path. The owner keeps any further segments, such as GitLab subgroups, and is empty if the path has
+// only one segment.
+func GetRepositoryOwnerAndNameFromUrl(repoURL string) (owner, name string) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", ""
+	}
+	repoPath := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	owner, name = path.Split(repoPath)
+	return strings.TrimSuffix(owner, "/"), name
+}
+
 // GetChangeTransferPolicyName returns a name for the ChangeTransferPolicy based on the promotion strategy name and environment branch.
 func GetChangeTransferPolicyName(promotionStrategyName, environmentBranch string) string {
 	return fmt.Sprintf("%s-%s", promotionStrategyName, environmentBranch)
 }
 
+// GetRepositoryBranchIndexKey returns the key of a branch of a GitRepository in the .spec.gitRepositoryRef.branch index
+// of ChangeTransferPolicies, which the webhook receiver uses to find the ChangeTransferPolicies tracking a pushed branch.
+// GitRepository names can't contain a slash, so the key is unambiguous.
+func GetRepositoryBranchIndexKey(gitRepositoryName, branch string) string {
+	return gitRepositoryName + "/" + branch
+}
+
+// GetRepositoryFullNameIndexKey returns the key of a repository in the .spec.fullName index of GitRepositories, which
+// the webhook receiver uses to find the GitRepositories a delivery is for. The key is scoped to the webhook provider
+// the repository's deliveries come from, so that repositories with the same full name on different SCMs don't match
+// each other's deliveries. Full names are case-insensitive on the SCMs, so they are lowercased.
+func GetRepositoryFullNameIndexKey(provider, fullName string) string {
+	return provider + ":" + strings.ToLower(fullName)
+}
+
 // KubeSafeUniqueName returns a DNS-1123 subdomain-safe unique name: lowercase, non-alphanumerics become '-',
 // then a rune budget is reserved for "-"+FNV hash (hash of the full sanitized string) under DNS1123 max length.
 //
@@ -292,6 +322,20 @@ type StatusConditionUpdater interface {
 	SetObservedGeneration(generation int64)
 }
 
+// readyConditionChanged returns true unless the Ready condition of obj, as last applied and read from c, has the
+// status, reason and message of ready.
+func readyConditionChanged(ctx context.Context, c client.Client, obj StatusConditionUpdater, ready *metav1.Condition) bool {
+	applied, ok := obj.DeepCopyObject().(StatusConditionUpdater)
+	if !ok {
+		return true
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), applied); err != nil {
+		return true
+	}
+	previous := meta.FindStatusCondition(*applied.GetConditions(), string(promoterConditions.Ready))
+	return previous == nil || previous.Status != ready.Status || previous.Reason != ready.Reason || previous.Message != ready.Message
+}
+
 // HandleReconciliationResult handles reconciliation results for any object with status conditions.
 // It applies the object's status subresource via Server-Side Apply under the provided
 // fieldOwner with ForceOwnership. If the full-status apply is rejected (e.g. by an
@@ -367,11 +411,14 @@ func HandleReconciliationResult(
 	if *err == nil {
 		// Success case: set Ready condition if not already set
 		meta.SetStatusCondition(conditions, *readyCondition)
-		eventType := "Normal"
-		if readyCondition.Status == metav1.ConditionFalse {
-			eventType = "Warning"
+		switch {
+		case readyCondition.Status != metav1.ConditionFalse:
+			recorder.Eventf(obj, nil, "Normal", readyCondition.Reason, "Reconciling", readyCondition.Message)
+		case readyConditionChanged(ctx, c, obj, readyCondition):
+			// A reconciler that reports a problem on the Ready condition, such as a missing branch, requeues until it
+			// is fixed. Warn when the problem appears, not on every requeue.
+			recorder.Eventf(obj, nil, "Warning", readyCondition.Reason, "Reconciling", readyCondition.Message)
 		}
-		recorder.Eventf(obj, nil, eventType, readyCondition.Reason, "Reconciling", readyCondition.Message)
 	} else {
 		// Error case: set Ready condition to False. Conflict errors from Update calls
 		// elsewhere in the reconcile flow are expected and transient, so don't spam events
@@ -358,6 +358,75 @@ var _ = Describe("HandleReconciliationResult panic recovery", func() {
 	})
 })
 
+var _ = Describe("HandleReconciliationResult events", func() {
+	It("should only warn about a not ready condition when it changes", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(promoterv1alpha1.AddToScheme(scheme)).To(Succeed())
+		obj := &promoterv1alpha1.PromotionStrategy{
+			TypeMeta:   metav1.TypeMeta{Kind: "PromotionStrategy", APIVersion: "promoter.argoproj.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "test-strategy", Namespace: "default", Generation: 1},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(obj).Build()
+		Expect(fakeClient.Create(ctx, obj)).To(Succeed())
+		recorder := events.NewFakeRecorder(10)
+
+		reconcileNotReady := func(reason conditions.CommonReason) {
+			var err error
+			func() {
+				defer utils.HandleReconciliationResult(ctx, metav1.Now().Time, obj, fakeClient, recorder, testFieldOwner, nil, &err)
+				meta.RemoveStatusCondition(obj.GetConditions(), string(conditions.Ready))
+				utils.SetReadyCondition(obj, metav1.ConditionFalse, reason, "waiting for "+string(reason))
+			}()
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		reconcileNotReady(conditions.ActiveBranchMissing)
+		Expect(recorder.Events).To(Receive(ContainSubstring("ActiveBranchMissing")))
+		reconcileNotReady(conditions.ActiveBranchMissing)
+		Expect(recorder.Events).NotTo(Receive(), "the same condition is not warned about again on a requeue")
+		reconcileNotReady(conditions.GitRepositoryNotReady)
+		Expect(recorder.Events).To(Receive(ContainSubstring("GitRepositoryNotReady")))
+	})
+
+	It("should warn once about an archived repository until it is unarchived", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(promoterv1alpha1.AddToScheme(scheme)).To(Succeed())
+		obj := &promoterv1alpha1.PullRequest{
+			TypeMeta:   metav1.TypeMeta{Kind: "PullRequest", APIVersion: "promoter.argoproj.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pr", Namespace: "default", Generation: 1},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(obj).Build()
+		Expect(fakeClient.Create(ctx, obj)).To(Succeed())
+		recorder := events.NewFakeRecorder(10)
+
+		// The message the PullRequest, CommitStatus and RevertCommit controllers set while their GitRepository is archived.
+		archivedMessage := `GitRepository "repo" is not ready (RepositoryArchived): The repository is archived or read-only on the SCM, unarchive it to resume promotions.`
+		reconcile := func(status metav1.ConditionStatus, reason conditions.CommonReason, message string) {
+			var err error
+			func() {
+				defer utils.HandleReconciliationResult(ctx, metav1.Now().Time, obj, fakeClient, recorder, testFieldOwner, nil, &err)
+				meta.RemoveStatusCondition(obj.GetConditions(), string(conditions.Ready))
+				utils.SetReadyCondition(obj, status, reason, message)
+			}()
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		reconcile(metav1.ConditionFalse, conditions.GitRepositoryNotReady, archivedMessage)
+		Expect(recorder.Events).To(Receive(And(HavePrefix("Warning"), ContainSubstring("RepositoryArchived"))))
+		for range 3 {
+			reconcile(metav1.ConditionFalse, conditions.GitRepositoryNotReady, archivedMessage)
+		}
+		Expect(recorder.Events).NotTo(Receive(), "an archived repository is not warned about again on each requeue")
+
+		reconcile(metav1.ConditionTrue, conditions.ReconciliationSuccess, "Reconciliation successful")
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal")))
+		reconcile(metav1.ConditionFalse, conditions.GitRepositoryNotReady, archivedMessage)
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning")), "archiving the repository again is warned about")
+	})
+})
+
 var _ = Describe("HandleReconciliationResult fallback status apply", func() {
 	var (
 		ctx      context.Context
@@ -594,3 +663,17 @@ var _ = Describe("HandleReconciliationResult fallback status apply", func() {
 		Expect(readyCondition.Message).To(ContainSubstring("reconciliation failed for test"))
 	})
 })
+
+var _ = Describe("GetRepositoryOwnerAndNameFromUrl", func() {
+	DescribeTable("should take the owner and name from the last segments of the URL path",
+		func(repoURL, owner, name string) {
+			gotOwner, gotName := utils.GetRepositoryOwnerAndNameFromUrl(repoURL)
+			Expect(gotOwner).To(Equal(owner))
+			Expect(gotName).To(Equal(name))
+		},
+		Entry("HTTPS URL", "https://git.example.com/owner/repo.git", "owner", "repo"),
+		Entry("subgroups", "https://gitlab.example.com/group/subgroup/repo", "group/subgroup", "repo"),
+		Entry("SSH URL with port", "ssh://git@git.example.com:2222/owner/repo.git", "owner", "repo"),
+		Entry("single segment", "https://git.example.com/repo.git/", "", "repo"),
+	)
+})
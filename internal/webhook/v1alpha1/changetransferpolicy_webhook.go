@@ -0,0 +1,122 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+// SetupChangeTransferPolicyWebhookWithManager registers the validating webhook for ChangeTransferPolicies with the
+// manager. v1alpha1 is the conversion hub of ChangeTransferPolicies, so they are also converted to and from v1alpha2 on
+// the /convert endpoint.
+func SetupChangeTransferPolicyWebhookWithManager(mgr ctrl.Manager) error {
+	//nolint:wrapcheck // the builder's errors name the webhook
+	return ctrl.NewWebhookManagedBy(mgr, &promoterv1alpha1.ChangeTransferPolicy{}).
+		WithValidator(&ChangeTransferPolicyCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-promoter-argoproj-io-v1alpha1-changetransferpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=promoter.argoproj.io,resources=changetransferpolicies,verbs=create;update,versions=v1alpha1,name=vchangetransferpolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ChangeTransferPolicyCustomValidator validates ChangeTransferPolicies when they are created or updated. The
+// repository and branches of a ChangeTransferPolicy are immutable: its pull requests, clone and commit statuses refer
+// to them, and the controller can't migrate those to other branches.
+type ChangeTransferPolicyCustomValidator struct{}
+
+var _ admission.Validator[*promoterv1alpha1.ChangeTransferPolicy] = &ChangeTransferPolicyCustomValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *ChangeTransferPolicyCustomValidator) ValidateCreate(_ context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy) (admission.Warnings, error) {
+	specPath := field.NewPath("spec")
+	errs := validateBranchName(ctp.Spec.ActiveBranch, specPath.Child("activeBranch"))
+	errs = append(errs, validateBranchName(ctp.Spec.ProposedBranch, specPath.Child("proposedBranch"))...)
+	if ctp.Spec.ProposedBranch != "" && ctp.Spec.ProposedBranch == ctp.Spec.ActiveBranch {
+		errs = append(errs, field.Invalid(specPath.Child("proposedBranch"), ctp.Spec.ProposedBranch, "must differ from spec.activeBranch"))
+	}
+	return nil, changeTransferPolicyInvalid(ctp, errs)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *ChangeTransferPolicyCustomValidator) ValidateUpdate(_ context.Context, oldCTP, ctp *promoterv1alpha1.ChangeTransferPolicy) (admission.Warnings, error) {
+	specPath := field.NewPath("spec")
+	var errs field.ErrorList
+	for _, f := range []struct {
+		path     *field.Path
+		old, new string
+	}{
+		{specPath.Child("gitRepositoryRef", "name"), oldCTP.Spec.RepositoryReference.Name, ctp.Spec.RepositoryReference.Name},
+		{specPath.Child("activeBranch"), oldCTP.Spec.ActiveBranch, ctp.Spec.ActiveBranch},
+		{specPath.Child("proposedBranch"), oldCTP.Spec.ProposedBranch, ctp.Spec.ProposedBranch},
+	} {
+		if f.old != f.new {
+			errs = append(errs, field.Forbidden(f.path, fmt.Sprintf(
+				"field is immutable, cannot change from %q to %q: delete the ChangeTransferPolicy and its PromotionStrategy recreates it", f.old, f.new)))
+		}
+	}
+	return nil, changeTransferPolicyInvalid(ctp, errs)
+}
+
+// ValidateDelete implements admission.Validator. Deletes are always allowed.
+func (v *ChangeTransferPolicyCustomValidator) ValidateDelete(_ context.Context, _ *promoterv1alpha1.ChangeTransferPolicy) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// changeTransferPolicyInvalid returns an invalid error for the ChangeTransferPolicy with the errors, or nil if there
+// are none.
+func changeTransferPolicyInvalid(ctp *promoterv1alpha1.ChangeTransferPolicy, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return k8serrors.NewInvalid(promoterv1alpha1.GroupVersion.WithKind("ChangeTransferPolicy").GroupKind(), ctp.Name, errs)
+}
+
+// validateBranchName returns an error if the branch isn't a valid short branch name, following the rules of
+// git check-ref-format. Full ref names, such as refs/heads/main, are rejected, since the controller adds the prefix.
+func validateBranchName(branch string, fldPath *field.Path) field.ErrorList {
+	invalid := func(detail string) field.ErrorList {
+		return field.ErrorList{field.Invalid(fldPath, branch, detail)}
+	}
+	switch {
+	case branch == "":
+		return field.ErrorList{field.Required(fldPath, "")}
+	case strings.HasPrefix(branch, "refs/"):
+		return invalid("must be a branch name, not a full ref name")
+	case branch == "@":
+		return invalid("must not be @")
+	case strings.ContainsAny(branch, " ~^:?*[\\\x7f") || strings.IndexFunc(branch, func(r rune) bool { return r < 0x20 }) >= 0:
+		return invalid("must not contain whitespace, control characters or any of ~^:?*[\\")
+	case strings.Contains(branch, "..") || strings.Contains(branch, "@{") || strings.Contains(branch, "//"):
+		return invalid(`must not contain "..", "@{" or "//"`)
+	case strings.HasPrefix(branch, "/") || strings.HasSuffix(branch, "/") || strings.HasSuffix(branch, "."):
+		return invalid(`must not start or end with "/" or end with "."`)
+	}
+	for component := range strings.SplitSeq(branch, "/") {
+		if strings.HasPrefix(component, ".") || strings.HasSuffix(component, ".lock") {
+			return invalid(`path components must not start with "." or end with ".lock"`)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package v1alpha1_test
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	webhookv1alpha1 "github.com/argoproj-labs/gitops-promoter/internal/webhook/v1alpha1"
+)
+
+var _ = Describe("ChangeTransferPolicy webhook", func() {
+	validator := &webhookv1alpha1.ChangeTransferPolicyCustomValidator{}
+
+	changeTransferPolicy := func(repo, active, proposed string) *promoterv1alpha1.ChangeTransferPolicy {
+		return &promoterv1alpha1.ChangeTransferPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "ctp", Namespace: "default"},
+			Spec: promoterv1alpha1.ChangeTransferPolicySpec{
+				RepositoryReference: promoterv1alpha1.ObjectReference{Name: repo},
+				ActiveBranch:        active,
+				ProposedBranch:      proposed,
+			},
+		}
+	}
+
+	DescribeTable("validates the branches on create",
+		func(active, proposed, message string) {
+			_, err := validator.ValidateCreate(context.Background(), changeTransferPolicy("repo", active, proposed))
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(k8serrors.IsInvalid(err)).To(BeTrue(), "expected an invalid error, got %v", err)
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("with distinct branches", "environment/production", "environment/production-next", ""),
+		Entry("with the same branches", "environment/production", "environment/production", "spec.proposedBranch: Invalid value: \"environment/production\": must differ from spec.activeBranch"),
+		Entry("without an active branch", "", "environment/production-next", "spec.activeBranch: Required value"),
+		Entry("with a full ref name", "refs/heads/environment/production", "environment/production-next", "not a full ref name"),
+		Entry("with whitespace", "environment/production", "environment/production next", "must not contain whitespace"),
+		Entry("with two dots", "environment/..production", "environment/production-next", `must not contain ".."`),
+		Entry("with a trailing slash", "environment/production/", "environment/production-next", `must not start or end with "/"`),
+		Entry("with a .lock component", "environment/production", "environment/production.lock", `end with ".lock"`),
+	)
+
+	DescribeTable("makes the repository and branches immutable",
+		func(repo, active, proposed, message string) {
+			_, err := validator.ValidateUpdate(context.Background(),
+				changeTransferPolicy("repo", "environment/production", "environment/production-next"),
+				changeTransferPolicy(repo, active, proposed))
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(k8serrors.IsInvalid(err)).To(BeTrue(), "expected an invalid error, got %v", err)
+			Expect(err.Error()).To(ContainSubstring(message))
+			Expect(err.Error()).To(ContainSubstring("delete the ChangeTransferPolicy and its PromotionStrategy recreates it"))
+		},
+		Entry("unchanged", "repo", "environment/production", "environment/production-next", ""),
+		Entry("with another repository", "other-repo", "environment/production", "environment/production-next",
+			"spec.gitRepositoryRef.name: Forbidden: field is immutable"),
+		Entry("with another active branch", "repo", "environment/prod", "environment/production-next",
+			"spec.activeBranch: Forbidden: field is immutable"),
+		Entry("with another proposed branch", "repo", "environment/production", "environment/production-proposed",
+			"spec.proposedBranch: Forbidden: field is immutable"),
+	)
+
+	It("allows deletes", func() {
+		_, err := validator.ValidateDelete(context.Background(), changeTransferPolicy("repo", "main", "main-next"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,74 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+// SetupClusterScmProviderWebhookWithManager registers the validating webhook for ClusterScmProviders with the manager.
+// Their Secrets are looked up in controllerNamespace.
+func SetupClusterScmProviderWebhookWithManager(mgr ctrl.Manager, controllerNamespace string) error {
+	//nolint:wrapcheck // the builder's errors name the webhook
+	return ctrl.NewWebhookManagedBy(mgr, &promoterv1alpha1.ClusterScmProvider{}).
+		WithValidator(&ClusterScmProviderCustomValidator{Reader: mgr.GetAPIReader(), ControllerNamespace: controllerNamespace}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-promoter-argoproj-io-v1alpha1-clusterscmprovider,mutating=false,failurePolicy=fail,sideEffects=None,groups=promoter.argoproj.io,resources=clusterscmproviders,verbs=create;update,versions=v1alpha1,name=vclusterscmprovider-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ClusterScmProviderCustomValidator validates ClusterScmProviders when they are created or updated, like
+// ScmProviderCustomValidator validates ScmProviders.
+type ClusterScmProviderCustomValidator struct {
+	// Reader reads the Secrets ClusterScmProviders refer to. A missing Secret is a warning, since it may be created
+	// later.
+	Reader client.Reader
+	// ControllerNamespace is the namespace the Secrets of ClusterScmProviders are in.
+	ControllerNamespace string
+}
+
+var _ admission.Validator[*promoterv1alpha1.ClusterScmProvider] = &ClusterScmProviderCustomValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *ClusterScmProviderCustomValidator) ValidateCreate(ctx context.Context, scmProvider *promoterv1alpha1.ClusterScmProvider) (admission.Warnings, error) {
+	return v.validate(ctx, scmProvider)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *ClusterScmProviderCustomValidator) ValidateUpdate(ctx context.Context, _, scmProvider *promoterv1alpha1.ClusterScmProvider) (admission.Warnings, error) {
+	return v.validate(ctx, scmProvider)
+}
+
+// ValidateDelete implements admission.Validator. Deletes are always allowed.
+func (v *ClusterScmProviderCustomValidator) ValidateDelete(_ context.Context, _ *promoterv1alpha1.ClusterScmProvider) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ClusterScmProviderCustomValidator) validate(ctx context.Context, scmProvider *promoterv1alpha1.ClusterScmProvider) (admission.Warnings, error) {
+	if errs := validateScmProviderSpec(&scmProvider.Spec, field.NewPath("spec")); len(errs) > 0 {
+		return nil, k8serrors.NewInvalid(promoterv1alpha1.GroupVersion.WithKind(promoterv1alpha1.ClusterScmProviderKind).GroupKind(), scmProvider.Name, errs)
+	}
+	return secretWarnings(ctx, v.Reader, &scmProvider.Spec, v.ControllerNamespace)
+}
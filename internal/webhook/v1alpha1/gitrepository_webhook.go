@@ -0,0 +1,97 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+// SetupGitRepositoryWebhookWithManager registers the validating webhook for GitRepositories with the manager.
+func SetupGitRepositoryWebhookWithManager(mgr ctrl.Manager) error {
+	//nolint:wrapcheck // the builder's errors name the webhook
+	return ctrl.NewWebhookManagedBy(mgr, &promoterv1alpha1.GitRepository{}).
+		WithValidator(&GitRepositoryCustomValidator{Reader: mgr.GetAPIReader()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-promoter-argoproj-io-v1alpha1-gitrepository,mutating=false,failurePolicy=fail,sideEffects=None,groups=promoter.argoproj.io,resources=gitrepositories,verbs=create;update,versions=v1alpha1,name=vgitrepository-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// GitRepositoryCustomValidator validates GitRepositories when they are created or updated. A spec.url is only
+// supported by Fake ScmProviders: the others need the repository's owner and name for their API, e.g. to open pull
+// requests. The controller still reports the UrlNotSupported condition, since the webhook is optional and the
+// ScmProvider may only be created or changed later.
+type GitRepositoryCustomValidator struct {
+	// Reader reads the ScmProviders and ClusterScmProviders GitRepositories refer to. A missing provider is a warning,
+	// since it may be created later.
+	Reader client.Reader
+}
+
+var _ admission.Validator[*promoterv1alpha1.GitRepository] = &GitRepositoryCustomValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *GitRepositoryCustomValidator) ValidateCreate(ctx context.Context, gitRepo *promoterv1alpha1.GitRepository) (admission.Warnings, error) {
+	return v.validateUrl(ctx, gitRepo)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *GitRepositoryCustomValidator) ValidateUpdate(ctx context.Context, _, gitRepo *promoterv1alpha1.GitRepository) (admission.Warnings, error) {
+	return v.validateUrl(ctx, gitRepo)
+}
+
+// ValidateDelete implements admission.Validator. Deletes are always allowed.
+func (v *GitRepositoryCustomValidator) ValidateDelete(_ context.Context, _ *promoterv1alpha1.GitRepository) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateUrl rejects a spec.url unless the GitRepository's ScmProvider is a Fake one.
+func (v *GitRepositoryCustomValidator) validateUrl(ctx context.Context, gitRepo *promoterv1alpha1.GitRepository) (admission.Warnings, error) {
+	if gitRepo.Spec.Url == "" || v.Reader == nil {
+		return nil, nil
+	}
+
+	ref := gitRepo.Spec.ScmProviderRef
+	var scmProvider promoterv1alpha1.GenericScmProvider
+	var key client.ObjectKey
+	if ref.Kind == promoterv1alpha1.ClusterScmProviderKind {
+		scmProvider, key = &promoterv1alpha1.ClusterScmProvider{}, client.ObjectKey{Name: ref.Name}
+	} else {
+		scmProvider, key = &promoterv1alpha1.ScmProvider{}, client.ObjectKey{Namespace: gitRepo.Namespace, Name: ref.Name}
+	}
+	err := v.Reader.Get(ctx, key, scmProvider)
+	switch {
+	case k8serrors.IsNotFound(err):
+		return admission.Warnings{fmt.Sprintf("%s %q referenced by spec.scmProviderRef does not exist yet, spec.url is only supported by Fake providers", ref.Kind, ref.Name)}, nil
+	case err != nil:
+		// Not being able to check the provider doesn't make the spec invalid.
+		return admission.Warnings{fmt.Sprintf("unable to check that %s %q referenced by spec.scmProviderRef supports spec.url: %v", ref.Kind, ref.Name, err)}, nil
+	case scmProvider.GetSpec().Fake != nil:
+		return nil, nil
+	}
+
+	errs := field.ErrorList{field.Forbidden(field.NewPath("spec", "url"), fmt.Sprintf(
+		"%s %q needs the repository's owner and name, set them in the SCM specific field instead", ref.Kind, ref.Name))}
+	return nil, k8serrors.NewInvalid(promoterv1alpha1.GroupVersion.WithKind("GitRepository").GroupKind(), gitRepo.Name, errs)
+}
@@ -0,0 +1,78 @@
+package v1alpha1_test
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+	webhookv1alpha1 "github.com/argoproj-labs/gitops-promoter/internal/webhook/v1alpha1"
+)
+
+var _ = Describe("GitRepository webhook", func() {
+	var validator *webhookv1alpha1.GitRepositoryCustomValidator
+
+	BeforeEach(func() {
+		validator = &webhookv1alpha1.GitRepositoryCustomValidator{
+			Reader: fake.NewClientBuilder().WithScheme(utils.GetScheme()).WithObjects(
+				&promoterv1alpha1.ScmProvider{
+					ObjectMeta: metav1.ObjectMeta{Name: "fake", Namespace: "default"},
+					Spec:       promoterv1alpha1.ScmProviderSpec{Fake: &promoterv1alpha1.Fake{}},
+				},
+				&promoterv1alpha1.ScmProvider{
+					ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "default"},
+					Spec:       promoterv1alpha1.ScmProviderSpec{GitHub: &promoterv1alpha1.GitHub{AppID: 1}},
+				},
+				&promoterv1alpha1.ClusterScmProvider{
+					ObjectMeta: metav1.ObjectMeta{Name: "gitlab"},
+					Spec:       promoterv1alpha1.ScmProviderSpec{GitLab: &promoterv1alpha1.GitLab{}},
+				},
+			).Build(),
+		}
+	})
+
+	gitRepository := func(kind, provider, url string) *promoterv1alpha1.GitRepository {
+		return &promoterv1alpha1.GitRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "default"},
+			Spec: promoterv1alpha1.GitRepositorySpec{
+				Url:            url,
+				ScmProviderRef: promoterv1alpha1.ScmProviderObjectReference{Kind: kind, Name: provider},
+			},
+		}
+	}
+
+	It("allows a url with a Fake ScmProvider", func() {
+		warnings, err := validator.ValidateCreate(context.Background(), gitRepository(promoterv1alpha1.ScmProviderKind, "fake", "https://git.example.com/repo.git"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("rejects a url with the providers that need the owner and name", func() {
+		_, err := validator.ValidateCreate(context.Background(), gitRepository(promoterv1alpha1.ScmProviderKind, "github", "https://git.example.com/repo.git"))
+		Expect(k8serrors.IsInvalid(err)).To(BeTrue(), "expected an invalid error, got %v", err)
+		Expect(err.Error()).To(ContainSubstring(`spec.url: Forbidden: ScmProvider "github" needs the repository's owner and name`))
+
+		old := gitRepository(promoterv1alpha1.ClusterScmProviderKind, "gitlab", "")
+		_, err = validator.ValidateUpdate(context.Background(), old, gitRepository(promoterv1alpha1.ClusterScmProviderKind, "gitlab", "https://git.example.com/repo.git"))
+		Expect(k8serrors.IsInvalid(err)).To(BeTrue(), "expected an invalid error, got %v", err)
+		Expect(err.Error()).To(ContainSubstring(`ClusterScmProvider "gitlab"`))
+	})
+
+	It("only warns about a url with a provider that doesn't exist yet", func() {
+		warnings, err := validator.ValidateCreate(context.Background(), gitRepository(promoterv1alpha1.ScmProviderKind, "missing", "https://git.example.com/repo.git"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(ContainSubstring(`ScmProvider "missing" referenced by spec.scmProviderRef does not exist yet`)))
+	})
+
+	It("allows any provider without a url", func() {
+		warnings, err := validator.ValidateCreate(context.Background(), gitRepository(promoterv1alpha1.ScmProviderKind, "github", ""))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+})
@@ -0,0 +1,218 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+// SetupPromotionStrategyWebhookWithManager registers the defaulting and validating webhooks for PromotionStrategies
+// with the manager. v1alpha1 is the conversion hub of PromotionStrategies, so they are also converted to and from
+// v1alpha2 on the /convert endpoint.
+func SetupPromotionStrategyWebhookWithManager(mgr ctrl.Manager) error {
+	//nolint:wrapcheck // the builder's errors name the webhook
+	return ctrl.NewWebhookManagedBy(mgr, &promoterv1alpha1.PromotionStrategy{}).
+		WithDefaulter(&PromotionStrategyCustomDefaulter{}).
+		WithValidator(&PromotionStrategyCustomValidator{Reader: mgr.GetAPIReader()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-promoter-argoproj-io-v1alpha1-promotionstrategy,mutating=true,failurePolicy=fail,sideEffects=None,groups=promoter.argoproj.io,resources=promotionstrategies,verbs=create;update,versions=v1alpha1,name=mpromotionstrategy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// PromotionStrategyCustomDefaulter sets the defaults of PromotionStrategies when they are created or updated, so that
+// the stored spec shows the values the controller uses instead of leaving them implicit. The controller still applies
+// the same defaults itself, since the webhook is optional.
+type PromotionStrategyCustomDefaulter struct{}
+
+var _ admission.Defaulter[*promoterv1alpha1.PromotionStrategy] = &PromotionStrategyCustomDefaulter{}
+
+// Default implements admission.Defaulter. It:
+//   - defaults the proposed branch template to promoterv1alpha1.DefaultProposedBranchTemplate,
+//   - defaults the autoMerge of the environments to true,
+//   - sorts the commit status lists by key and drops the later entries of repeated keys,
+//   - trims the whitespace around the auto revert dry branches and strips their refs/heads/ prefix,
+//   - and does the same to the branches of new environments. The branch identifies an environment and its
+//     ChangeTransferPolicy, so the environments that already exist keep theirs.
+func (d *PromotionStrategyCustomDefaulter) Default(ctx context.Context, ps *promoterv1alpha1.PromotionStrategy) error {
+	existingBranches, err := existingEnvironmentBranches(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ps.Spec.ProposedBranchTemplate == "" {
+		ps.Spec.ProposedBranchTemplate = promoterv1alpha1.DefaultProposedBranchTemplate
+	}
+	ps.Spec.ActiveCommitStatuses = sortCommitStatusSelectors(ps.Spec.ActiveCommitStatuses)
+	ps.Spec.ProposedCommitStatuses = sortCommitStatusSelectors(ps.Spec.ProposedCommitStatuses)
+
+	for i := range ps.Spec.Environments {
+		environment := &ps.Spec.Environments[i]
+		if !slices.Contains(existingBranches, environment.Branch) {
+			environment.Branch = normalizeBranch(environment.Branch)
+		}
+		if environment.AutoMerge == nil {
+			environment.AutoMerge = ptr.To(true)
+		}
+		environment.ActiveCommitStatuses = sortCommitStatusSelectors(environment.ActiveCommitStatuses)
+		environment.ProposedCommitStatuses = sortCommitStatusSelectors(environment.ProposedCommitStatuses)
+		if environment.AutoRevert != nil {
+			environment.AutoRevert.DryBranch = normalizeBranch(environment.AutoRevert.DryBranch)
+		}
+	}
+	return nil
+}
+
+// existingEnvironmentBranches returns the branches of the environments of the PromotionStrategy before the update in
+// the admission request, or none if it is a create.
+func existingEnvironmentBranches(ctx context.Context) ([]string, error) {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || len(req.OldObject.Raw) == 0 {
+		return nil, nil
+	}
+	var oldPS promoterv1alpha1.PromotionStrategy
+	if err := json.Unmarshal(req.OldObject.Raw, &oldPS); err != nil {
+		return nil, fmt.Errorf("failed to decode the existing PromotionStrategy: %w", err)
+	}
+	branches := make([]string, 0, len(oldPS.Spec.Environments))
+	for _, environment := range oldPS.Spec.Environments {
+		branches = append(branches, environment.Branch)
+	}
+	return branches, nil
+}
+
+// sortCommitStatusSelectors returns the selectors sorted by key, keeping only the first selector of each key.
+func sortCommitStatusSelectors(selectors []promoterv1alpha1.CommitStatusSelector) []promoterv1alpha1.CommitStatusSelector {
+	if len(selectors) == 0 {
+		return selectors
+	}
+	slices.SortStableFunc(selectors, func(a, b promoterv1alpha1.CommitStatusSelector) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return slices.CompactFunc(selectors, func(a, b promoterv1alpha1.CommitStatusSelector) bool {
+		return a.Key == b.Key
+	})
+}
+
+// +kubebuilder:webhook:path=/validate-promoter-argoproj-io-v1alpha1-promotionstrategy,mutating=false,failurePolicy=fail,sideEffects=None,groups=promoter.argoproj.io,resources=promotionstrategies,verbs=create;update,versions=v1alpha1,name=vpromotionstrategy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// PromotionStrategyCustomValidator validates PromotionStrategies when they are created or updated. The repository of a
+// PromotionStrategy is immutable: its ChangeTransferPolicies, pull requests, clones and commit statuses refer to it, and
+// moving them to another repository would leave them split between the two. The labels, reviewers and draft of the
+// pull request templates are rejected for the SCM providers whose pull requests don't support them. The controller
+// still reports the PullRequestFieldsNotSupported condition on the PullRequests, since the webhook is optional and
+// the provider may only be created or changed later.
+type PromotionStrategyCustomValidator struct {
+	// Reader reads the GitRepositories PromotionStrategies refer to and their ScmProviders and ClusterScmProviders.
+	// The pull request templates aren't checked when they don't exist.
+	Reader client.Reader
+}
+
+var _ admission.Validator[*promoterv1alpha1.PromotionStrategy] = &PromotionStrategyCustomValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *PromotionStrategyCustomValidator) ValidateCreate(ctx context.Context, ps *promoterv1alpha1.PromotionStrategy) (admission.Warnings, error) {
+	return nil, promotionStrategyInvalid(ps, v.validatePullRequestTemplates(ctx, ps))
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *PromotionStrategyCustomValidator) ValidateUpdate(ctx context.Context, oldPS, ps *promoterv1alpha1.PromotionStrategy) (admission.Warnings, error) {
+	var errs field.ErrorList
+	if oldName, name := oldPS.Spec.RepositoryReference.Name, ps.Spec.RepositoryReference.Name; oldName != name {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "gitRepositoryRef", "name"), fmt.Sprintf(
+			"field is immutable, cannot change from %q to %q: create a new PromotionStrategy for the repository instead", oldName, name)))
+	}
+	errs = append(errs, v.validatePullRequestTemplates(ctx, ps)...)
+	return nil, promotionStrategyInvalid(ps, errs)
+}
+
+// ValidateDelete implements admission.Validator. Deletes are always allowed.
+func (v *PromotionStrategyCustomValidator) ValidateDelete(_ context.Context, _ *promoterv1alpha1.PromotionStrategy) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validatePullRequestTemplates rejects the labels, reviewers and draft of the pull request templates if the pull
+// requests of the repository's SCM provider don't support them.
+func (v *PromotionStrategyCustomValidator) validatePullRequestTemplates(ctx context.Context, ps *promoterv1alpha1.PromotionStrategy) field.ErrorList {
+	if v.Reader == nil || (ps.Spec.PullRequest == nil && !slices.ContainsFunc(ps.Spec.Environments, func(environment promoterv1alpha1.Environment) bool {
+		return environment.PullRequest != nil
+	})) {
+		return nil
+	}
+
+	var gitRepo promoterv1alpha1.GitRepository
+	if err := v.Reader.Get(ctx, client.ObjectKey{Namespace: ps.Namespace, Name: ps.Spec.RepositoryReference.Name}, &gitRepo); err != nil {
+		return nil
+	}
+	scmProvider, err := getScmProvider(ctx, v.Reader, &gitRepo)
+	if err != nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	validate := func(template *promoterv1alpha1.PullRequestTemplateSpec, fldPath *field.Path) {
+		if template == nil {
+			return
+		}
+		for _, name := range scms.UnsupportedPullRequestFields(scmProvider.GetSpec(), template.Labels, template.Reviewers, ptr.Deref(template.Draft, false)) {
+			errs = append(errs, field.Forbidden(fldPath.Child(name), fmt.Sprintf(
+				"the pull requests of %s %q don't support %s", gitRepo.Spec.ScmProviderRef.Kind, gitRepo.Spec.ScmProviderRef.Name, name)))
+		}
+	}
+	validate(ps.Spec.PullRequest, field.NewPath("spec", "pullRequest"))
+	for i := range ps.Spec.Environments {
+		validate(ps.Spec.Environments[i].PullRequest, field.NewPath("spec", "environments").Index(i).Child("pullRequest"))
+	}
+	return errs
+}
+
+// promotionStrategyInvalid returns an invalid error for the PromotionStrategy with the errors, or nil if there are none.
+func promotionStrategyInvalid(ps *promoterv1alpha1.PromotionStrategy, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return k8serrors.NewInvalid(promoterv1alpha1.GroupVersion.WithKind("PromotionStrategy").GroupKind(), ps.Name, errs)
+}
+
+// getScmProvider gets the ScmProvider or ClusterScmProvider the GitRepository refers to.
+func getScmProvider(ctx context.Context, reader client.Reader, gitRepo *promoterv1alpha1.GitRepository) (promoterv1alpha1.GenericScmProvider, error) {
+	ref := gitRepo.Spec.ScmProviderRef
+	var scmProvider promoterv1alpha1.GenericScmProvider
+	var key client.ObjectKey
+	if ref.Kind == promoterv1alpha1.ClusterScmProviderKind {
+		scmProvider, key = &promoterv1alpha1.ClusterScmProvider{}, client.ObjectKey{Name: ref.Name}
+	} else {
+		scmProvider, key = &promoterv1alpha1.ScmProvider{}, client.ObjectKey{Namespace: gitRepo.Namespace, Name: ref.Name}
+	}
+	if err := reader.Get(ctx, key, scmProvider); err != nil {
+		return nil, err //nolint:wrapcheck // the callers name the provider
+	}
+	return scmProvider, nil
+}
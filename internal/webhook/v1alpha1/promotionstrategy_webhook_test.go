@@ -0,0 +1,208 @@
+package v1alpha1_test
+
+import (
+	"context"
+	"encoding/json"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+	webhookv1alpha1 "github.com/argoproj-labs/gitops-promoter/internal/webhook/v1alpha1"
+)
+
+var _ = Describe("PromotionStrategy webhook", func() {
+	defaulter := &webhookv1alpha1.PromotionStrategyCustomDefaulter{}
+
+	promotionStrategy := func(environments ...promoterv1alpha1.Environment) *promoterv1alpha1.PromotionStrategy {
+		return &promoterv1alpha1.PromotionStrategy{
+			ObjectMeta: metav1.ObjectMeta{Name: "ps", Namespace: "default"},
+			Spec: promoterv1alpha1.PromotionStrategySpec{
+				RepositoryReference: promoterv1alpha1.ObjectReference{Name: "repo"},
+				Environments:        environments,
+			},
+		}
+	}
+	selectors := func(keys ...string) []promoterv1alpha1.CommitStatusSelector {
+		result := make([]promoterv1alpha1.CommitStatusSelector, 0, len(keys))
+		for _, key := range keys {
+			result = append(result, promoterv1alpha1.CommitStatusSelector{Key: key})
+		}
+		return result
+	}
+	create := func() context.Context {
+		return admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create},
+		})
+	}
+	update := func(oldPS *promoterv1alpha1.PromotionStrategy) context.Context {
+		raw, err := json.Marshal(oldPS)
+		Expect(err).NotTo(HaveOccurred())
+		return admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Update, OldObject: runtime.RawExtension{Raw: raw}},
+		})
+	}
+
+	It("materializes the implicit defaults on create", func() {
+		ps := promotionStrategy(
+			promoterv1alpha1.Environment{
+				Branch:               " refs/heads/environment/development ",
+				ActiveCommitStatuses: selectors("test", "argocd-health", "test"),
+				AutoRevert:           &promoterv1alpha1.AutoRevert{Enabled: true, DryBranch: "refs/heads/main"},
+			},
+			promoterv1alpha1.Environment{Branch: "environment/production", AutoMerge: ptr.To(false)},
+		)
+		ps.Spec.ProposedCommitStatuses = selectors("security-scan", "lint")
+		Expect(defaulter.Default(create(), ps)).To(Succeed())
+
+		Expect(ps.Spec.ProposedBranchTemplate).To(Equal(promoterv1alpha1.DefaultProposedBranchTemplate))
+		Expect(ps.Spec.ProposedCommitStatuses).To(Equal(selectors("lint", "security-scan")))
+		Expect(ps.Spec.Environments[0].Branch).To(Equal("environment/development"))
+		Expect(ps.Spec.Environments[0].AutoMerge).To(Equal(ptr.To(true)))
+		Expect(ps.Spec.Environments[0].ActiveCommitStatuses).To(Equal(selectors("argocd-health", "test")))
+		Expect(ps.Spec.Environments[0].AutoRevert.DryBranch).To(Equal("main"))
+		Expect(ps.Spec.Environments[1].AutoMerge).To(Equal(ptr.To(false)))
+	})
+
+	It("keeps the proposed branch template that is set", func() {
+		ps := promotionStrategy(promoterv1alpha1.Environment{Branch: "environment/development"})
+		ps.Spec.ProposedBranchTemplate = "{{ .Branch }}-proposed"
+		Expect(defaulter.Default(create(), ps)).To(Succeed())
+
+		Expect(ps.Spec.ProposedBranchTemplate).To(Equal("{{ .Branch }}-proposed"))
+	})
+
+	It("leaves a defaulted PromotionStrategy unchanged", func() {
+		ps := promotionStrategy(
+			promoterv1alpha1.Environment{Branch: " refs/heads/environment/development", ProposedCommitStatuses: selectors("b", "a", "b")},
+			promoterv1alpha1.Environment{Branch: "environment/production"},
+		)
+		ps.Spec.ActiveCommitStatuses = selectors("z", "y")
+		Expect(defaulter.Default(create(), ps)).To(Succeed())
+
+		defaulted := ps.DeepCopy()
+		Expect(defaulter.Default(update(defaulted), ps)).To(Succeed())
+		Expect(ps).To(Equal(defaulted))
+	})
+
+	It("normalizes only the branches of new environments on update", func() {
+		oldPS := promotionStrategy(promoterv1alpha1.Environment{Branch: "refs/heads/environment/development", AutoMerge: ptr.To(true)})
+		ps := oldPS.DeepCopy()
+		ps.Spec.Environments = append(ps.Spec.Environments, promoterv1alpha1.Environment{Branch: "refs/heads/environment/production"})
+		Expect(defaulter.Default(update(oldPS), ps)).To(Succeed())
+
+		Expect(ps.Spec.Environments[0].Branch).To(Equal("refs/heads/environment/development"))
+		Expect(ps.Spec.Environments[1].Branch).To(Equal("environment/production"))
+	})
+})
+
+var _ = Describe("PromotionStrategy validation", func() {
+	validator := &webhookv1alpha1.PromotionStrategyCustomValidator{}
+
+	promotionStrategy := func(repo string) *promoterv1alpha1.PromotionStrategy {
+		return &promoterv1alpha1.PromotionStrategy{
+			ObjectMeta: metav1.ObjectMeta{Name: "ps", Namespace: "default"},
+			Spec: promoterv1alpha1.PromotionStrategySpec{
+				RepositoryReference: promoterv1alpha1.ObjectReference{Name: repo},
+				Environments:        []promoterv1alpha1.Environment{{Branch: "environment/production"}},
+			},
+		}
+	}
+
+	It("allows updates that keep the repository", func() {
+		ps := promotionStrategy("repo")
+		ps.Spec.Environments = append(ps.Spec.Environments, promoterv1alpha1.Environment{Branch: "environment/staging"})
+		_, err := validator.ValidateUpdate(context.Background(), promotionStrategy("repo"), ps)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("makes the repository immutable", func() {
+		_, err := validator.ValidateUpdate(context.Background(), promotionStrategy("repo"), promotionStrategy("other-repo"))
+		Expect(k8serrors.IsInvalid(err)).To(BeTrue(), "expected an invalid error, got %v", err)
+		Expect(err.Error()).To(ContainSubstring(`spec.gitRepositoryRef.name: Forbidden: field is immutable, cannot change from "repo" to "other-repo"`))
+		Expect(err.Error()).To(ContainSubstring("create a new PromotionStrategy"))
+	})
+})
+
+var _ = Describe("PromotionStrategy pull request validation", func() {
+	var validator *webhookv1alpha1.PromotionStrategyCustomValidator
+
+	BeforeEach(func() {
+		gitRepository := func(name, provider string) *promoterv1alpha1.GitRepository {
+			return &promoterv1alpha1.GitRepository{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: promoterv1alpha1.GitRepositorySpec{
+					ScmProviderRef: promoterv1alpha1.ScmProviderObjectReference{Kind: promoterv1alpha1.ScmProviderKind, Name: provider},
+				},
+			}
+		}
+		validator = &webhookv1alpha1.PromotionStrategyCustomValidator{
+			Reader: fake.NewClientBuilder().WithScheme(utils.GetScheme()).WithObjects(
+				&promoterv1alpha1.ScmProvider{
+					ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "default"},
+					Spec:       promoterv1alpha1.ScmProviderSpec{GitHub: &promoterv1alpha1.GitHub{AppID: 1}},
+				},
+				&promoterv1alpha1.ScmProvider{
+					ObjectMeta: metav1.ObjectMeta{Name: "gitea", Namespace: "default"},
+					Spec:       promoterv1alpha1.ScmProviderSpec{Gitea: &promoterv1alpha1.Gitea{}},
+				},
+				gitRepository("github-repo", "github"),
+				gitRepository("gitea-repo", "gitea"),
+			).Build(),
+		}
+	})
+
+	promotionStrategy := func(repo string, template *promoterv1alpha1.PullRequestTemplateSpec) *promoterv1alpha1.PromotionStrategy {
+		return &promoterv1alpha1.PromotionStrategy{
+			ObjectMeta: metav1.ObjectMeta{Name: "ps", Namespace: "default"},
+			Spec: promoterv1alpha1.PromotionStrategySpec{
+				RepositoryReference: promoterv1alpha1.ObjectReference{Name: repo},
+				Environments: []promoterv1alpha1.Environment{
+					{Branch: "environment/development"},
+					{Branch: "environment/production", PullRequest: template},
+				},
+			},
+		}
+	}
+	template := &promoterv1alpha1.PullRequestTemplateSpec{
+		Labels:        []string{"promotion"},
+		Reviewers:     []string{"alice"},
+		Draft:         ptr.To(true),
+		MergeStrategy: promoterv1alpha1.PullRequestMergeStrategySquash,
+	}
+
+	It("allows labels, reviewers and drafts with the providers that support them", func() {
+		_, err := validator.ValidateCreate(context.Background(), promotionStrategy("github-repo", template))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects labels, reviewers and drafts with the providers that don't support them", func() {
+		_, err := validator.ValidateUpdate(context.Background(), promotionStrategy("gitea-repo", nil), promotionStrategy("gitea-repo", template))
+		Expect(k8serrors.IsInvalid(err)).To(BeTrue(), "expected an invalid error, got %v", err)
+		Expect(err.Error()).To(ContainSubstring(`spec.environments[1].pullRequest.labels: Forbidden: the pull requests of ScmProvider "gitea" don't support labels`))
+		Expect(err.Error()).To(ContainSubstring("spec.environments[1].pullRequest.reviewers"))
+		Expect(err.Error()).To(ContainSubstring("spec.environments[1].pullRequest.draft"))
+		Expect(err.Error()).NotTo(ContainSubstring("mergeStrategy"))
+	})
+
+	It("allows the merge strategy with any provider", func() {
+		_, err := validator.ValidateCreate(context.Background(), promotionStrategy("gitea-repo", &promoterv1alpha1.PullRequestTemplateSpec{
+			MergeStrategy: promoterv1alpha1.PullRequestMergeStrategySquash,
+		}))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("doesn't check the templates of a repository that doesn't exist yet", func() {
+		_, err := validator.ValidateCreate(context.Background(), promotionStrategy("missing", template))
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,139 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+// branchRefPrefix is the prefix of the full ref name of a branch, which PullRequest branches are stored without.
+const branchRefPrefix = "refs/heads/"
+
+// pullRequestStates are the states of the spec of a PullRequest.
+var pullRequestStates = []string{
+	string(promoterv1alpha1.PullRequestOpen),
+	string(promoterv1alpha1.PullRequestClosed),
+	string(promoterv1alpha1.PullRequestMerged),
+}
+
+// SetupPullRequestWebhookWithManager registers the defaulting and validating webhooks for PullRequests with the
+// manager. v1alpha1 is the conversion hub of PullRequests, so this also registers the /convert endpoint that converts
+// them to and from v1alpha2.
+func SetupPullRequestWebhookWithManager(mgr ctrl.Manager) error {
+	//nolint:wrapcheck // the builder's errors name the webhook
+	return ctrl.NewWebhookManagedBy(mgr, &promoterv1alpha1.PullRequest{}).
+		WithDefaulter(&PullRequestCustomDefaulter{}).
+		WithValidator(&PullRequestCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-promoter-argoproj-io-v1alpha1-pullrequest,mutating=true,failurePolicy=fail,sideEffects=None,groups=promoter.argoproj.io,resources=pullrequests,verbs=create;update,versions=v1alpha1,name=mpullrequest-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// PullRequestCustomDefaulter sets the defaults of PullRequests when they are created or updated, so that the stored
+// spec is what the controller acts on.
+type PullRequestCustomDefaulter struct{}
+
+var _ admission.Defaulter[*promoterv1alpha1.PullRequest] = &PullRequestCustomDefaulter{}
+
+// Default implements admission.Defaulter. It:
+//   - defaults the state to open,
+//   - trims the whitespace around the title and description,
+//   - generates the title from the branches if it is empty,
+//   - and on create, trims the whitespace around the branches and strips their refs/heads/ prefix. The branches are
+//     immutable, so they are left as they are on update.
+func (d *PullRequestCustomDefaulter) Default(ctx context.Context, pr *promoterv1alpha1.PullRequest) error {
+	if req, err := admission.RequestFromContext(ctx); err != nil || req.Operation == admissionv1.Create {
+		pr.Spec.SourceBranch = normalizeBranch(pr.Spec.SourceBranch)
+		pr.Spec.TargetBranch = normalizeBranch(pr.Spec.TargetBranch)
+	}
+
+	if pr.Spec.State == "" {
+		pr.Spec.State = promoterv1alpha1.PullRequestOpen
+	}
+	pr.Spec.Description = strings.TrimSpace(pr.Spec.Description)
+	pr.Spec.Title = strings.TrimSpace(pr.Spec.Title)
+	if pr.Spec.Title == "" {
+		pr.Spec.Title = fmt.Sprintf("Promote %s to %s", pr.Spec.SourceBranch, pr.Spec.TargetBranch)
+	}
+	return nil
+}
+
+// normalizeBranch returns the branch name without surrounding whitespace or a refs/heads/ prefix.
+func normalizeBranch(branch string) string {
+	return strings.TrimPrefix(strings.TrimSpace(branch), branchRefPrefix)
+}
+
+// +kubebuilder:webhook:path=/validate-promoter-argoproj-io-v1alpha1-pullrequest,mutating=false,failurePolicy=fail,sideEffects=None,groups=promoter.argoproj.io,resources=pullrequests,verbs=create;update,versions=v1alpha1,name=vpullrequest-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// PullRequestCustomValidator validates the state of PullRequests when they are created or updated. Merged and closed
+// are terminal states: the controller can't reopen a pull request or unmerge it, so a PullRequest can't leave them.
+type PullRequestCustomValidator struct{}
+
+var _ admission.Validator[*promoterv1alpha1.PullRequest] = &PullRequestCustomValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *PullRequestCustomValidator) ValidateCreate(_ context.Context, pr *promoterv1alpha1.PullRequest) (admission.Warnings, error) {
+	return nil, pullRequestInvalid(pr, validatePullRequestState(pr.Spec.State))
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *PullRequestCustomValidator) ValidateUpdate(_ context.Context, oldPR, pr *promoterv1alpha1.PullRequest) (admission.Warnings, error) {
+	errs := validatePullRequestState(pr.Spec.State)
+	if len(errs) == 0 && isTerminalPullRequestState(oldPR.Spec.State) && pr.Spec.State != oldPR.Spec.State {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "state"), fmt.Sprintf(
+			"cannot change from %q to %q: the pull request is %s, create a new PullRequest instead", oldPR.Spec.State, pr.Spec.State, oldPR.Spec.State)))
+	}
+	return nil, pullRequestInvalid(pr, errs)
+}
+
+// ValidateDelete implements admission.Validator. Deletes are always allowed.
+func (v *PullRequestCustomValidator) ValidateDelete(_ context.Context, _ *promoterv1alpha1.PullRequest) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validatePullRequestState returns an error if the state isn't a state of a PullRequest.
+func validatePullRequestState(state promoterv1alpha1.PullRequestState) field.ErrorList {
+	for _, s := range pullRequestStates {
+		if string(state) == s {
+			return nil
+		}
+	}
+	return field.ErrorList{field.NotSupported(field.NewPath("spec", "state"), state, pullRequestStates)}
+}
+
+// isTerminalPullRequestState returns whether a PullRequest can't leave the state.
+func isTerminalPullRequestState(state promoterv1alpha1.PullRequestState) bool {
+	return state == promoterv1alpha1.PullRequestMerged || state == promoterv1alpha1.PullRequestClosed
+}
+
+// pullRequestInvalid returns an invalid error for the PullRequest with the errors, or nil if there are none.
+func pullRequestInvalid(pr *promoterv1alpha1.PullRequest, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return k8serrors.NewInvalid(promoterv1alpha1.GroupVersion.WithKind("PullRequest").GroupKind(), pr.Name, errs)
+}
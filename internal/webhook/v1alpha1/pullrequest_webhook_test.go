@@ -0,0 +1,125 @@
+package v1alpha1_test
+
+import (
+	"context"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	webhookv1alpha1 "github.com/argoproj-labs/gitops-promoter/internal/webhook/v1alpha1"
+)
+
+var _ = Describe("PullRequest webhook", func() {
+	defaulter := &webhookv1alpha1.PullRequestCustomDefaulter{}
+
+	pullRequest := func(spec promoterv1alpha1.PullRequestSpec) *promoterv1alpha1.PullRequest {
+		return &promoterv1alpha1.PullRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "default"},
+			Spec:       spec,
+		}
+	}
+	withOperation := func(operation admissionv1.Operation) context.Context {
+		return admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{Operation: operation},
+		})
+	}
+
+	It("defaults the state and generates the title on create", func() {
+		pr := pullRequest(promoterv1alpha1.PullRequestSpec{
+			SourceBranch: " refs/heads/environment/production-next ",
+			TargetBranch: "refs/heads/environment/production",
+			Description:  "\n  Promoting abc123.  \n",
+		})
+		Expect(defaulter.Default(withOperation(admissionv1.Create), pr)).To(Succeed())
+
+		Expect(pr.Spec.State).To(Equal(promoterv1alpha1.PullRequestOpen))
+		Expect(pr.Spec.SourceBranch).To(Equal("environment/production-next"))
+		Expect(pr.Spec.TargetBranch).To(Equal("environment/production"))
+		Expect(pr.Spec.Title).To(Equal("Promote environment/production-next to environment/production"))
+		Expect(pr.Spec.Description).To(Equal("Promoting abc123."))
+	})
+
+	It("keeps the title and state that are set", func() {
+		pr := pullRequest(promoterv1alpha1.PullRequestSpec{
+			Title:        "  Promote to production\t",
+			SourceBranch: "environment/production-next",
+			TargetBranch: "environment/production",
+			State:        promoterv1alpha1.PullRequestMerged,
+		})
+		Expect(defaulter.Default(withOperation(admissionv1.Update), pr)).To(Succeed())
+
+		Expect(pr.Spec.Title).To(Equal("Promote to production"))
+		Expect(pr.Spec.State).To(Equal(promoterv1alpha1.PullRequestMerged))
+	})
+
+	It("leaves the immutable branches alone on update", func() {
+		pr := pullRequest(promoterv1alpha1.PullRequestSpec{
+			Title:        "Promote",
+			SourceBranch: "refs/heads/environment/production-next",
+			TargetBranch: "refs/heads/environment/production",
+		})
+		Expect(defaulter.Default(withOperation(admissionv1.Update), pr)).To(Succeed())
+
+		Expect(pr.Spec.SourceBranch).To(Equal("refs/heads/environment/production-next"))
+		Expect(pr.Spec.TargetBranch).To(Equal("refs/heads/environment/production"))
+	})
+})
+
+var _ = Describe("PullRequest state validation", func() {
+	validator := &webhookv1alpha1.PullRequestCustomValidator{}
+
+	pullRequest := func(state promoterv1alpha1.PullRequestState) *promoterv1alpha1.PullRequest {
+		return &promoterv1alpha1.PullRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "default"},
+			Spec:       promoterv1alpha1.PullRequestSpec{State: state},
+		}
+	}
+
+	DescribeTable("validates the state on create",
+		func(state promoterv1alpha1.PullRequestState, allowed bool) {
+			_, err := validator.ValidateCreate(context.Background(), pullRequest(state))
+			if allowed {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(k8serrors.IsInvalid(err)).To(BeTrue(), "expected an invalid error, got %v", err)
+			Expect(err.Error()).To(ContainSubstring(`spec.state: Unsupported value: "reopened"`))
+		},
+		Entry("open", promoterv1alpha1.PullRequestOpen, true),
+		Entry("closed", promoterv1alpha1.PullRequestClosed, true),
+		Entry("merged", promoterv1alpha1.PullRequestMerged, true),
+		Entry("an unknown state", promoterv1alpha1.PullRequestState("reopened"), false),
+	)
+
+	DescribeTable("validates state transitions on update",
+		func(from, to promoterv1alpha1.PullRequestState, allowed bool) {
+			_, err := validator.ValidateUpdate(context.Background(), pullRequest(from), pullRequest(to))
+			if allowed {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(k8serrors.IsInvalid(err)).To(BeTrue(), "expected an invalid error, got %v", err)
+			Expect(err.Error()).To(ContainSubstring("spec.state: Forbidden: cannot change from %q to %q", from, to))
+		},
+		Entry("open to open", promoterv1alpha1.PullRequestOpen, promoterv1alpha1.PullRequestOpen, true),
+		Entry("open to merged", promoterv1alpha1.PullRequestOpen, promoterv1alpha1.PullRequestMerged, true),
+		Entry("open to closed", promoterv1alpha1.PullRequestOpen, promoterv1alpha1.PullRequestClosed, true),
+		Entry("merged to merged", promoterv1alpha1.PullRequestMerged, promoterv1alpha1.PullRequestMerged, true),
+		Entry("closed to closed", promoterv1alpha1.PullRequestClosed, promoterv1alpha1.PullRequestClosed, true),
+		Entry("merged to open", promoterv1alpha1.PullRequestMerged, promoterv1alpha1.PullRequestOpen, false),
+		Entry("merged to closed", promoterv1alpha1.PullRequestMerged, promoterv1alpha1.PullRequestClosed, false),
+		Entry("closed to open", promoterv1alpha1.PullRequestClosed, promoterv1alpha1.PullRequestOpen, false),
+		Entry("closed to merged", promoterv1alpha1.PullRequestClosed, promoterv1alpha1.PullRequestMerged, false),
+	)
+
+	It("allows deletes", func() {
+		_, err := validator.ValidateDelete(context.Background(), pullRequest(promoterv1alpha1.PullRequestMerged))
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
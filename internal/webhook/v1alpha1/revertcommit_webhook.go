@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+// SetupRevertCommitWebhookWithManager registers the validating webhook for RevertCommits with the manager.
+func SetupRevertCommitWebhookWithManager(mgr ctrl.Manager) error {
+	//nolint:wrapcheck // the builder's errors name the webhook
+	return ctrl.NewWebhookManagedBy(mgr, &promoterv1alpha1.RevertCommit{}).
+		WithValidator(&RevertCommitCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-promoter-argoproj-io-v1alpha1-revertcommit,mutating=false,failurePolicy=fail,sideEffects=None,groups=promoter.argoproj.io,resources=revertcommits,verbs=create;update,versions=v1alpha1,name=vrevertcommit-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// RevertCommitCustomValidator validates RevertCommits when they are created or updated. It renders the commit message
+// template against a sample reverted commit, so that a template that doesn't parse, uses a template field that isn't
+// set or renders an empty message is rejected up front instead of failing the revert. The controller still reports
+// the CommitMessageTemplateInvalid condition, since the webhook is optional.
+type RevertCommitCustomValidator struct{}
+
+var _ admission.Validator[*promoterv1alpha1.RevertCommit] = &RevertCommitCustomValidator{}
+
+// sampleRevertedCommit is the reverted commit the commit message templates are rendered with at admission.
+var sampleRevertedCommit = promoterv1alpha1.CommitShaState{
+	Sha:     strings.Repeat("0", 40),
+	Subject: "subject",
+	Author:  "author",
+}
+
+// ValidateCreate implements admission.Validator.
+func (v *RevertCommitCustomValidator) ValidateCreate(_ context.Context, rc *promoterv1alpha1.RevertCommit) (admission.Warnings, error) {
+	return nil, revertCommitInvalid(rc, validateRevertCommitMessageTemplate(rc))
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *RevertCommitCustomValidator) ValidateUpdate(_ context.Context, _, rc *promoterv1alpha1.RevertCommit) (admission.Warnings, error) {
+	return nil, revertCommitInvalid(rc, validateRevertCommitMessageTemplate(rc))
+}
+
+// ValidateDelete implements admission.Validator. Deletes are always allowed.
+func (v *RevertCommitCustomValidator) ValidateDelete(_ context.Context, _ *promoterv1alpha1.RevertCommit) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateRevertCommitMessageTemplate returns the errors of rendering the commit message template of rc. An empty
+// template isn't rendered: the revert commit keeps git's default message.
+func validateRevertCommitMessageTemplate(rc *promoterv1alpha1.RevertCommit) field.ErrorList {
+	if rc.Spec.CommitMessageTemplate == "" {
+		return nil
+	}
+	if _, err := utils.RenderRevertCommitMessage(rc, sampleRevertedCommit); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "commitMessageTemplate"), rc.Spec.CommitMessageTemplate, err.Error())}
+	}
+	return nil
+}
+
+// revertCommitInvalid returns an invalid error for the RevertCommit with the errors, or nil if there are none.
+func revertCommitInvalid(rc *promoterv1alpha1.RevertCommit, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return k8serrors.NewInvalid(promoterv1alpha1.GroupVersion.WithKind("RevertCommit").GroupKind(), rc.Name, errs)
+}
@@ -0,0 +1,58 @@
+package v1alpha1_test
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	webhookv1alpha1 "github.com/argoproj-labs/gitops-promoter/internal/webhook/v1alpha1"
+)
+
+var _ = Describe("RevertCommit commit message template validation", func() {
+	validator := &webhookv1alpha1.RevertCommitCustomValidator{}
+
+	revertCommit := func(template string, fields map[string]string) *promoterv1alpha1.RevertCommit {
+		return &promoterv1alpha1.RevertCommit{
+			ObjectMeta: metav1.ObjectMeta{Name: "revert", Namespace: "default"},
+			Spec: promoterv1alpha1.RevertCommitSpec{
+				CommitMessageTemplate: template,
+				TemplateFields:        fields,
+			},
+		}
+	}
+
+	DescribeTable("validates the template on create and update",
+		func(template string, fields map[string]string, invalid string) {
+			rc := revertCommit(template, fields)
+			_, createErr := validator.ValidateCreate(context.Background(), rc)
+			_, updateErr := validator.ValidateUpdate(context.Background(), revertCommit("", nil), rc)
+			for _, err := range []error{createErr, updateErr} {
+				if invalid == "" {
+					Expect(err).NotTo(HaveOccurred())
+					continue
+				}
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue(), "expected an invalid error, got %v", err)
+				Expect(err.Error()).To(ContainSubstring("spec.commitMessageTemplate: Invalid value"))
+				Expect(err.Error()).To(ContainSubstring(invalid))
+			}
+		},
+		Entry("no template", "", nil, ""),
+		Entry("a template of the reverted commit and template fields",
+			`Revert {{ .ShortSha }} "{{ .Subject }}" by {{ .Author }} ({{ .TemplateFields.ticket }})`,
+			map[string]string{"ticket": "INC-1"}, ""),
+		Entry("a template that doesn't parse", "Revert {{ .Sha", nil, "failed to parse template"),
+		Entry("a template field that isn't set", "Revert {{ .TemplateFields.ticket }}", nil, `map has no entry for key "ticket"`),
+		Entry("an unknown field", "Revert {{ .Commit }}", nil, "can't evaluate field Commit"),
+		Entry("a template that renders an empty message", "{{ if false }}Revert{{ end }}  ", nil, "renders an empty message"),
+	)
+
+	It("allows deletes", func() {
+		_, err := validator.ValidateDelete(context.Background(), revertCommit("{{", nil))
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,189 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 contains the admission webhooks of the promoter.argoproj.io/v1alpha1 API.
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+// scmProviderFields are the fields of an ScmProviderSpec that configure the SCM, exactly one of which must be set.
+var scmProviderFields = []string{"github", "gitlab", "forgejo", "gitea", "bitbucketCloud", "azureDevOps", "fake"}
+
+// SetupScmProviderWebhookWithManager registers the validating webhook for ScmProviders with the manager.
+func SetupScmProviderWebhookWithManager(mgr ctrl.Manager) error {
+	//nolint:wrapcheck // the builder's errors name the webhook
+	return ctrl.NewWebhookManagedBy(mgr, &promoterv1alpha1.ScmProvider{}).
+		WithValidator(&ScmProviderCustomValidator{Reader: mgr.GetAPIReader()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-promoter-argoproj-io-v1alpha1-scmprovider,mutating=false,failurePolicy=fail,sideEffects=None,groups=promoter.argoproj.io,resources=scmproviders,verbs=create;update,versions=v1alpha1,name=vscmprovider-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ScmProviderCustomValidator validates ScmProviders when they are created or updated.
+type ScmProviderCustomValidator struct {
+	// Reader reads the Secrets ScmProviders refer to. A missing Secret is a warning, since it may be created later.
+	Reader client.Reader
+}
+
+var _ admission.Validator[*promoterv1alpha1.ScmProvider] = &ScmProviderCustomValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *ScmProviderCustomValidator) ValidateCreate(ctx context.Context, scmProvider *promoterv1alpha1.ScmProvider) (admission.Warnings, error) {
+	return v.validate(ctx, scmProvider)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *ScmProviderCustomValidator) ValidateUpdate(ctx context.Context, _, scmProvider *promoterv1alpha1.ScmProvider) (admission.Warnings, error) {
+	return v.validate(ctx, scmProvider)
+}
+
+// ValidateDelete implements admission.Validator. Deletes are always allowed.
+func (v *ScmProviderCustomValidator) ValidateDelete(_ context.Context, _ *promoterv1alpha1.ScmProvider) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ScmProviderCustomValidator) validate(ctx context.Context, scmProvider *promoterv1alpha1.ScmProvider) (admission.Warnings, error) {
+	if errs := validateScmProviderSpec(&scmProvider.Spec, field.NewPath("spec")); len(errs) > 0 {
+		return nil, k8serrors.NewInvalid(promoterv1alpha1.GroupVersion.WithKind(promoterv1alpha1.ScmProviderKind).GroupKind(), scmProvider.Name, errs)
+	}
+	return secretWarnings(ctx, v.Reader, &scmProvider.Spec, scmProvider.Namespace)
+}
+
+// validateScmProviderSpec returns the errors of an ScmProviderSpec: exactly one SCM must be configured, with the
+// fields it needs, and every SCM but the fake one needs a Secret with its credentials.
+func validateScmProviderSpec(spec *promoterv1alpha1.ScmProviderSpec, specPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	configured := configuredScmProviderFields(spec)
+	switch len(configured) {
+	case 0:
+		errs = append(errs, field.Required(specPath, "exactly one of "+strings.Join(scmProviderFields, ", ")+" must be set"))
+	case 1:
+	default:
+		errs = append(errs, field.Forbidden(specPath, fmt.Sprintf("exactly one of %s must be set, but %s are set",
+			strings.Join(scmProviderFields, ", "), strings.Join(configured, " and "))))
+	}
+
+	if spec.Fake == nil && (spec.SecretRef == nil || spec.SecretRef.Name == "") {
+		errs = append(errs, field.Required(specPath.Child("secretRef", "name"), "the Secret with the credentials of the SCM is required"))
+	}
+
+	switch {
+	case spec.GitHub != nil:
+		if spec.GitHub.AppID <= 0 {
+			errs = append(errs, field.Invalid(specPath.Child("github", "appID"), spec.GitHub.AppID, "must be the ID of a GitHub App"))
+		}
+		errs = append(errs, validateDomain(spec.GitHub.Domain, false, specPath.Child("github", "domain"))...)
+	case spec.GitLab != nil:
+		errs = append(errs, validateDomain(spec.GitLab.Domain, false, specPath.Child("gitlab", "domain"))...)
+	case spec.Forgejo != nil:
+		errs = append(errs, validateDomain(spec.Forgejo.Domain, true, specPath.Child("forgejo", "domain"))...)
+	case spec.Gitea != nil:
+		errs = append(errs, validateDomain(spec.Gitea.Domain, true, specPath.Child("gitea", "domain"))...)
+	case spec.AzureDevOps != nil:
+		if spec.AzureDevOps.Organization == "" {
+			errs = append(errs, field.Required(specPath.Child("azureDevOps", "organization"), ""))
+		}
+		errs = append(errs, validateDomain(spec.AzureDevOps.Domain, false, specPath.Child("azureDevOps", "domain"))...)
+	case spec.Fake != nil:
+		errs = append(errs, validateDomain(spec.Fake.Domain, false, specPath.Child("fake", "domain"))...)
+	}
+	return errs
+}
+
+// configuredScmProviderFields returns the fields of the SCMs the spec configures.
+func configuredScmProviderFields(spec *promoterv1alpha1.ScmProviderSpec) []string {
+	set := []bool{
+		spec.GitHub != nil,
+		spec.GitLab != nil,
+		spec.Forgejo != nil,
+		spec.Gitea != nil,
+		spec.BitbucketCloud != nil,
+		spec.AzureDevOps != nil,
+		spec.Fake != nil,
+	}
+	var configured []string
+	for i, isSet := range set {
+		if isSet {
+			configured = append(configured, scmProviderFields[i])
+		}
+	}
+	return configured
+}
+
+// validateDomain returns the errors of the domain of an SCM, a host name or IP address with an optional port, such as
+// "gitea.example.com" or "gitea.example.com:3000". It must not be a URL.
+func validateDomain(domain string, required bool, fldPath *field.Path) field.ErrorList {
+	if domain == "" {
+		if required {
+			return field.ErrorList{field.Required(fldPath, "")}
+		}
+		return nil
+	}
+	if strings.Contains(domain, "/") {
+		return field.ErrorList{field.Invalid(fldPath, domain, "must be a host name with an optional port, not a URL")}
+	}
+
+	host := domain
+	if h, port, err := net.SplitHostPort(domain); err == nil {
+		host = h
+		if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+			return field.ErrorList{field.Invalid(fldPath, domain, "must have a port between 1 and 65535")}
+		}
+	}
+	if net.ParseIP(host) != nil {
+		return nil
+	}
+	if msgs := validation.IsDNS1123Subdomain(strings.ToLower(host)); len(msgs) > 0 {
+		return field.ErrorList{field.Invalid(fldPath, domain, strings.Join(msgs, "; "))}
+	}
+	return nil
+}
+
+// secretWarnings returns a warning if the Secret the spec refers to doesn't exist in the namespace. It isn't an error,
+// since the Secret may be created after the ScmProvider.
+func secretWarnings(ctx context.Context, reader client.Reader, spec *promoterv1alpha1.ScmProviderSpec, namespace string) (admission.Warnings, error) {
+	if reader == nil || spec.SecretRef == nil || spec.SecretRef.Name == "" {
+		return nil, nil
+	}
+	var secret corev1.Secret
+	err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: spec.SecretRef.Name}, &secret)
+	switch {
+	case k8serrors.IsNotFound(err):
+		return admission.Warnings{fmt.Sprintf("Secret %q referenced by spec.secretRef does not exist in namespace %q yet", spec.SecretRef.Name, namespace)}, nil
+	case err != nil:
+		// Not being able to check the Secret doesn't make the spec invalid.
+		return admission.Warnings{fmt.Sprintf("unable to check that Secret %q referenced by spec.secretRef exists: %v", spec.SecretRef.Name, err)}, nil
+	default:
+		return nil, nil
+	}
+}
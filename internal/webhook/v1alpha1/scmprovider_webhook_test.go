@@ -0,0 +1,120 @@
+package v1alpha1_test
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+	webhookv1alpha1 "github.com/argoproj-labs/gitops-promoter/internal/webhook/v1alpha1"
+)
+
+var _ = Describe("ScmProvider webhook", func() {
+	var validator *webhookv1alpha1.ScmProviderCustomValidator
+
+	BeforeEach(func() {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "scm-credentials", Namespace: "default"}}
+		validator = &webhookv1alpha1.ScmProviderCustomValidator{
+			Reader: fake.NewClientBuilder().WithScheme(utils.GetScheme()).WithObjects(secret).Build(),
+		}
+	})
+
+	scmProvider := func(spec promoterv1alpha1.ScmProviderSpec) *promoterv1alpha1.ScmProvider {
+		return &promoterv1alpha1.ScmProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "scm", Namespace: "default"},
+			Spec:       spec,
+		}
+	}
+	secretRef := &corev1.LocalObjectReference{Name: "scm-credentials"}
+
+	DescribeTable("rejects invalid specs",
+		func(spec promoterv1alpha1.ScmProviderSpec, message string) {
+			_, err := validator.ValidateCreate(context.Background(), scmProvider(spec))
+			Expect(k8serrors.IsInvalid(err)).To(BeTrue(), "expected an invalid error, got %v", err)
+			Expect(err.Error()).To(ContainSubstring(message))
+
+			_, err = validator.ValidateUpdate(context.Background(), scmProvider(spec), scmProvider(spec))
+			Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+		},
+		Entry("without a provider", promoterv1alpha1.ScmProviderSpec{SecretRef: secretRef},
+			"exactly one of github, gitlab, forgejo, gitea, bitbucketCloud, azureDevOps, fake must be set"),
+		Entry("with two providers", promoterv1alpha1.ScmProviderSpec{
+			SecretRef: secretRef,
+			GitHub:    &promoterv1alpha1.GitHub{AppID: 1},
+			Fake:      &promoterv1alpha1.Fake{},
+		}, "but github and fake are set"),
+		Entry("without a secret", promoterv1alpha1.ScmProviderSpec{GitLab: &promoterv1alpha1.GitLab{}},
+			"spec.secretRef.name: Required value"),
+		Entry("without a GitHub App ID", promoterv1alpha1.ScmProviderSpec{SecretRef: secretRef, GitHub: &promoterv1alpha1.GitHub{}},
+			"spec.github.appID: Invalid value"),
+		Entry("without a Gitea domain", promoterv1alpha1.ScmProviderSpec{SecretRef: secretRef, Gitea: &promoterv1alpha1.Gitea{}},
+			"spec.gitea.domain: Required value"),
+		Entry("with a URL as the domain", promoterv1alpha1.ScmProviderSpec{SecretRef: secretRef, Forgejo: &promoterv1alpha1.Forgejo{Domain: "https://codeberg.org"}},
+			"not a URL"),
+		Entry("with an invalid domain", promoterv1alpha1.ScmProviderSpec{SecretRef: secretRef, GitLab: &promoterv1alpha1.GitLab{Domain: "gitlab_example.com"}},
+			"spec.gitlab.domain: Invalid value"),
+		Entry("with an invalid port", promoterv1alpha1.ScmProviderSpec{SecretRef: secretRef, Gitea: &promoterv1alpha1.Gitea{Domain: "gitea.example.com:99999"}},
+			"port between 1 and 65535"),
+		Entry("without an Azure DevOps organization", promoterv1alpha1.ScmProviderSpec{SecretRef: secretRef, AzureDevOps: &promoterv1alpha1.AzureDevOps{}},
+			"spec.azureDevOps.organization: Required value"),
+	)
+
+	DescribeTable("accepts valid specs",
+		func(spec promoterv1alpha1.ScmProviderSpec) {
+			warnings, err := validator.ValidateCreate(context.Background(), scmProvider(spec))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		},
+		Entry("GitHub", promoterv1alpha1.ScmProviderSpec{SecretRef: secretRef, GitHub: &promoterv1alpha1.GitHub{AppID: 1, Domain: "github.example.com"}}),
+		Entry("Gitea with a port", promoterv1alpha1.ScmProviderSpec{SecretRef: secretRef, Gitea: &promoterv1alpha1.Gitea{Domain: "gitea.example.com:3000"}}),
+		Entry("Bitbucket Cloud", promoterv1alpha1.ScmProviderSpec{SecretRef: secretRef, BitbucketCloud: &promoterv1alpha1.BitbucketCloud{}}),
+		Entry("fake without a secret", promoterv1alpha1.ScmProviderSpec{Fake: &promoterv1alpha1.Fake{Domain: "127.0.0.1:8080"}}),
+	)
+
+	It("warns about a missing secret", func() {
+		warnings, err := validator.ValidateCreate(context.Background(), scmProvider(promoterv1alpha1.ScmProviderSpec{
+			SecretRef: &corev1.LocalObjectReference{Name: "not-created-yet"},
+			GitLab:    &promoterv1alpha1.GitLab{},
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(ContainSubstring(`Secret "not-created-yet" referenced by spec.secretRef does not exist in namespace "default"`)))
+	})
+
+	It("allows deletes", func() {
+		warnings, err := validator.ValidateDelete(context.Background(), scmProvider(promoterv1alpha1.ScmProviderSpec{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+})
+
+var _ = Describe("ClusterScmProvider webhook", func() {
+	It("looks up the secret in the controller's namespace", func() {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "scm-credentials", Namespace: "promoter-system"}}
+		validator := &webhookv1alpha1.ClusterScmProviderCustomValidator{
+			Reader:              fake.NewClientBuilder().WithScheme(utils.GetScheme()).WithObjects(secret).Build(),
+			ControllerNamespace: "promoter-system",
+		}
+		scmProvider := &promoterv1alpha1.ClusterScmProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "scm"},
+			Spec: promoterv1alpha1.ScmProviderSpec{
+				SecretRef: &corev1.LocalObjectReference{Name: "scm-credentials"},
+				GitLab:    &promoterv1alpha1.GitLab{},
+			},
+		}
+		warnings, err := validator.ValidateCreate(context.Background(), scmProvider)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		scmProvider.Spec.Fake = &promoterv1alpha1.Fake{}
+		_, err = validator.ValidateUpdate(context.Background(), scmProvider, scmProvider)
+		Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("ClusterScmProvider.promoter.argoproj.io \"scm\" is invalid"))
+	})
+})
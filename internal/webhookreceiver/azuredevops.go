@@ -0,0 +1,53 @@
+package webhookreceiver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+)
+
+// errBasicAuthMissing and errBasicAuthInvalid are returned by verifyAzureDevOpsDelivery for deliveries that don't
+// carry any secret that applies to them.
+var (
+	errBasicAuthMissing = errors.New("missing basic auth")
+	errBasicAuthInvalid = errors.New("invalid basic auth password")
+)
+
+// azureDevOpsFullName returns the full name of the repository of an Azure DevOps delivery, i.e. project/name.
+func azureDevOpsFullName(jsonBytes []byte) string {
+	project := gjson.GetBytes(jsonBytes, "resource.repository.project.name").String()
+	name := gjson.GetBytes(jsonBytes, "resource.repository.name").String()
+	if project == "" || name == "" {
+		return ""
+	}
+	return project + "/" + name
+}
+
+// verifyAzureDevOpsDelivery checks that the password of an Azure DevOps delivery's basic auth is a webhook secret that
+// applies to it. Azure DevOps service hooks can't sign their deliveries, the secret is set as the password of the
+// service hook's basic authentication instead, the user name is ignored. It returns the reason the delivery is accepted
+// or rejected for and the scope of the GitRepositories it may trigger reconciles for, and for rejected deliveries the
+// response code and an error that is safe to send back.
+func (wr *WebhookReceiver) verifyAzureDevOpsDelivery(ctx context.Context, r *http.Request, jsonBytes []byte) (metrics.WebhookDeliveryReason, deliveryScope, int, error) {
+	secrets, err := wr.webhookSecrets(ctx, ProviderAzureDevops, azureDevOpsFullName(jsonBytes))
+	if err != nil {
+		return metrics.WebhookDeliverySecretUnavailable, deliveryScope{}, http.StatusInternalServerError, err
+	}
+	if len(secrets) == 0 {
+		return metrics.WebhookDeliverySignatureNotRequired, deliveryScope{}, 0, nil
+	}
+
+	_, password, ok := r.BasicAuth()
+	if !ok || password == "" {
+		return metrics.WebhookDeliveryTokenMissing, deliveryScope{}, http.StatusUnauthorized, errBasicAuthMissing
+	}
+	scope, ok := verifiedScope(secrets, func(secret []byte) bool { return ValidGitLabToken(password, secret) })
+	if !ok {
+		return metrics.WebhookDeliveryTokenInvalid, deliveryScope{}, http.StatusUnauthorized, errBasicAuthInvalid
+	}
+	return metrics.WebhookDeliveryTokenValid, scope, 0, nil
+}
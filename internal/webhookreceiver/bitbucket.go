@@ -0,0 +1,144 @@
+package webhookreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+)
+
+// errBitbucketSignatureMissing, errBitbucketSignatureInvalid and errSourceNotAllowed are returned by
+// verifyBitbucketDelivery for deliveries that aren't signed with any secret that applies to them, or that are from
+// outside the allowed networks.
+var (
+	errBitbucketSignatureMissing = errors.New("missing X-Hub-Signature header")
+	errBitbucketSignatureInvalid = errors.New("invalid X-Hub-Signature header")
+	errSourceNotAllowed          = errors.New("delivery from a network that isn't allowed")
+)
+
+// bitbucketDataCenterPayload returns whether a Bitbucket delivery's payload is one of Bitbucket Data Center. Data
+// Center's payloads carry their event key, Bitbucket Cloud's don't.
+func bitbucketDataCenterPayload(jsonBytes []byte) bool {
+	return gjson.GetBytes(jsonBytes, "eventKey").String() != ""
+}
+
+// bitbucketFullName returns the full name of the repository of a Bitbucket delivery, i.e. workspace/repo_slug for
+// Bitbucket Cloud and PROJECT/repo_slug for Bitbucket Data Center.
+func bitbucketFullName(jsonBytes []byte) string {
+	if fullName := gjson.GetBytes(jsonBytes, "repository.full_name").String(); fullName != "" {
+		return fullName
+	}
+	repository := gjson.GetBytes(jsonBytes, "repository")
+	if !repository.Exists() {
+		// Data Center's pull request events only have the repository in the refs of the pull request.
+		repository = gjson.GetBytes(jsonBytes, "pullRequest.toRef.repository")
+	}
+	projectKey := repository.Get("project.key").String()
+	slug := repository.Get("slug").String()
+	if projectKey == "" || slug == "" {
+		return ""
+	}
+	return projectKey + "/" + slug
+}
+
+// bitbucketPushRef returns the ref of the first change of a Bitbucket Cloud repo:push or a Bitbucket Data Center
+// repo:refs_changed event, e.g. refs/heads/main. It's empty for changes that aren't to a branch.
+func bitbucketPushRef(jsonBytes []byte) string {
+	if change := gjson.GetBytes(jsonBytes, "push.changes.0"); change.Exists() {
+		branch := change.Get("new")
+		if !branch.Exists() || branch.Type == gjson.Null {
+			// The branch was deleted.
+			branch = change.Get("old")
+		}
+		if branch.Get("type").String() != "branch" || branch.Get("name").String() == "" {
+			return ""
+		}
+		return "refs/heads/" + branch.Get("name").String()
+	}
+	return gjson.GetBytes(jsonBytes, "changes.0.refId").String()
+}
+
+// parseBitbucketPullRequestEvent returns the pullRequestEvent of a Bitbucket Cloud pullrequest:fulfilled or
+// pullrequest:rejected event or a Bitbucket Data Center pr:merged or pr:declined event.
+func parseBitbucketPullRequestEvent(eventKey string, jsonBytes []byte) pullRequestEvent {
+	// Only the events of merged and declined pull requests are routed here, the only changes of a pull request that
+	// change what a PullRequest's status reflects.
+	event := pullRequestEvent{
+		fullName: bitbucketFullName(jsonBytes),
+		action:   eventKey,
+		relevant: true,
+	}
+	if pr := gjson.GetBytes(jsonBytes, "pullrequest"); pr.Exists() {
+		event.number = pr.Get("id").String()
+		event.sourceBranch = pr.Get("source.branch.name").String()
+		event.targetBranch = pr.Get("destination.branch.name").String()
+		return event
+	}
+	pr := gjson.GetBytes(jsonBytes, "pullRequest")
+	event.number = pr.Get("id").String()
+	event.sourceBranch = pr.Get("fromRef.displayId").String()
+	event.targetBranch = pr.Get("toRef.displayId").String()
+	return event
+}
+
+// bitbucketCommitCheckSha returns the commit of a Bitbucket Cloud repo:commit_status_updated event.
+func bitbucketCommitCheckSha(jsonBytes []byte) string {
+	return gjson.GetBytes(jsonBytes, "commit_status.commit.hash").String()
+}
+
+// verifyBitbucketDelivery checks a Bitbucket delivery's source address and X-Hub-Signature, which Bitbucket Cloud and
+// Data Center compute like GitHub's X-Hub-Signature-256. When allowed networks are configured, deliveries from other
+// addresses are rejected whether they are signed or not, and unsigned deliveries from them are accepted. It returns the
+// reason the delivery is accepted or rejected for and the scope of the GitRepositories it may trigger reconciles for,
+// and for rejected deliveries the response code and an error that is safe to send back.
+func (wr *WebhookReceiver) verifyBitbucketDelivery(ctx context.Context, provider string, r *http.Request, jsonBytes []byte) (metrics.WebhookDeliveryReason, deliveryScope, int, error) {
+	allowlist := len(wr.config.BitbucketAllowedNetworks) > 0
+	if allowlist && !wr.bitbucketSourceAllowed(r) {
+		return metrics.WebhookDeliverySourceNotAllowed, deliveryScope{}, http.StatusForbidden, errSourceNotAllowed
+	}
+
+	signature := r.Header.Get("X-Hub-Signature")
+	if signature == "" && allowlist {
+		return metrics.WebhookDeliverySourceAllowed, deliveryScope{}, 0, nil
+	}
+
+	secrets, err := wr.webhookSecrets(ctx, provider, bitbucketFullName(jsonBytes))
+	if err != nil {
+		return metrics.WebhookDeliverySecretUnavailable, deliveryScope{}, http.StatusInternalServerError, err
+	}
+	if len(secrets) == 0 {
+		if allowlist {
+			return metrics.WebhookDeliverySourceAllowed, deliveryScope{}, 0, nil
+		}
+		return metrics.WebhookDeliverySignatureNotRequired, deliveryScope{}, 0, nil
+	}
+	if signature == "" {
+		return metrics.WebhookDeliverySignatureMissing, deliveryScope{}, http.StatusUnauthorized, errBitbucketSignatureMissing
+	}
+	scope, ok := verifiedScope(secrets, func(secret []byte) bool { return ValidGitHubSignature(jsonBytes, signature, secret) })
+	if !ok {
+		return metrics.WebhookDeliverySignatureInvalid, deliveryScope{}, http.StatusUnauthorized, errBitbucketSignatureInvalid
+	}
+	return metrics.WebhookDeliverySignatureValid, scope, 0, nil
+}
+
+// bitbucketSourceAllowed returns whether the request's remote address is in one of the allowed networks.
+func (wr *WebhookReceiver) bitbucketSourceAllowed(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	if ip == nil {
+		return false
+	}
+	return slices.ContainsFunc(wr.config.BitbucketAllowedNetworks, func(network net.IPNet) bool {
+		return network.Contains(ip)
+	})
+}
@@ -0,0 +1,50 @@
+package webhookreceiver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+)
+
+// errGiteaSignatureMissing and errGiteaSignatureInvalid are returned by verifyGiteaDelivery for deliveries that aren't
+// signed with any secret that applies to them.
+var (
+	errGiteaSignatureMissing = errors.New("missing X-Gitea-Signature or X-Forgejo-Signature header")
+	errGiteaSignatureInvalid = errors.New("invalid X-Gitea-Signature or X-Forgejo-Signature header")
+)
+
+// ValidGiteaSignature returns whether signature, the value of a Gitea or Forgejo delivery's X-Gitea-Signature or
+// X-Forgejo-Signature header, is the hex encoded HMAC-SHA256 signature of payload with one of secrets. The signatures
+// are compared in constant time.
+func ValidGiteaSignature(payload []byte, signature string, secrets ...[]byte) bool {
+	return ValidGitHubSignature(payload, "sha256="+signature, secrets...)
+}
+
+// verifyGiteaDelivery checks a Gitea or Forgejo delivery's signature. Forgejo sends its signature in both
+// X-Forgejo-Signature and X-Gitea-Signature. It returns the reason the delivery is accepted or rejected for and the
+// scope of the GitRepositories it may trigger reconciles for, and for rejected deliveries the response code and an
+// error that is safe to send back.
+func (wr *WebhookReceiver) verifyGiteaDelivery(ctx context.Context, provider string, r *http.Request, jsonBytes []byte) (metrics.WebhookDeliveryReason, deliveryScope, int, error) {
+	secrets, err := wr.webhookSecrets(ctx, provider, deliveryFullName(provider, jsonBytes))
+	if err != nil {
+		return metrics.WebhookDeliverySecretUnavailable, deliveryScope{}, http.StatusInternalServerError, err
+	}
+	if len(secrets) == 0 {
+		return metrics.WebhookDeliverySignatureNotRequired, deliveryScope{}, 0, nil
+	}
+
+	signature := r.Header.Get("X-Forgejo-Signature")
+	if signature == "" {
+		signature = r.Header.Get("X-Gitea-Signature")
+	}
+	if signature == "" {
+		return metrics.WebhookDeliverySignatureMissing, deliveryScope{}, http.StatusUnauthorized, errGiteaSignatureMissing
+	}
+	scope, ok := verifiedScope(secrets, func(secret []byte) bool { return ValidGiteaSignature(jsonBytes, signature, secret) })
+	if !ok {
+		return metrics.WebhookDeliverySignatureInvalid, deliveryScope{}, http.StatusUnauthorized, errGiteaSignatureInvalid
+	}
+	return metrics.WebhookDeliverySignatureValid, scope, 0, nil
+}
@@ -0,0 +1,80 @@
+package webhookreceiver
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+)
+
+// gitLabMergeRequestActions are the actions of GitLab merge request events that change what a PullRequest's status
+// reflects. "update" is also sent for changes of the title or description, reconciling the PullRequest for them is
+// harmless.
+var gitLabMergeRequestActions = []string{"close", "merge", "reopen", "update"}
+
+// errTokenMissing and errTokenInvalid are returned by verifyGitLabDelivery for deliveries that don't carry any secret
+// that applies to them.
+var (
+	errTokenMissing = errors.New("missing X-Gitlab-Token header")
+	errTokenInvalid = errors.New("invalid X-Gitlab-Token header")
+)
+
+// parseGitLabMergeRequestEvent returns the pullRequestEvent of a GitLab Merge Request Hook.
+func parseGitLabMergeRequestEvent(jsonBytes []byte) pullRequestEvent {
+	action := gjson.GetBytes(jsonBytes, "object_attributes.action").String()
+	return pullRequestEvent{
+		fullName:     gjson.GetBytes(jsonBytes, "project.path_with_namespace").String(),
+		number:       gjson.GetBytes(jsonBytes, "object_attributes.iid").String(),
+		sourceBranch: gjson.GetBytes(jsonBytes, "object_attributes.source_branch").String(),
+		targetBranch: gjson.GetBytes(jsonBytes, "object_attributes.target_branch").String(),
+		action:       action,
+		relevant:     slices.Contains(gitLabMergeRequestActions, action),
+	}
+}
+
+// gitLabCommitCheckSha returns the commit of a GitLab Pipeline Hook or Job Hook.
+func gitLabCommitCheckSha(eventName string, jsonBytes []byte) string {
+	if eventName == "Job Hook" {
+		return gjson.GetBytes(jsonBytes, "sha").String()
+	}
+	return gjson.GetBytes(jsonBytes, "object_attributes.sha").String()
+}
+
+// ValidGitLabToken returns whether token, the value of a delivery's X-Gitlab-Token header, is one of secrets. The
+// token is compared in constant time.
+func ValidGitLabToken(token string, secrets ...[]byte) bool {
+	for _, secret := range secrets {
+		if subtle.ConstantTimeCompare([]byte(token), secret) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyGitLabDelivery checks that a GitLab delivery's X-Gitlab-Token is a webhook secret that applies to it. It
+// returns the reason the delivery is accepted or rejected for and the scope of the GitRepositories it may trigger
+// reconciles for, and for rejected deliveries the response code and an error that is safe to send back.
+func (wr *WebhookReceiver) verifyGitLabDelivery(ctx context.Context, r *http.Request, jsonBytes []byte) (metrics.WebhookDeliveryReason, deliveryScope, int, error) {
+	secrets, err := wr.webhookSecrets(ctx, ProviderGitLab, deliveryFullName(ProviderGitLab, jsonBytes))
+	if err != nil {
+		return metrics.WebhookDeliverySecretUnavailable, deliveryScope{}, http.StatusInternalServerError, err
+	}
+	if len(secrets) == 0 {
+		return metrics.WebhookDeliverySignatureNotRequired, deliveryScope{}, 0, nil
+	}
+
+	token := r.Header.Get("X-Gitlab-Token")
+	if token == "" {
+		return metrics.WebhookDeliveryTokenMissing, deliveryScope{}, http.StatusUnauthorized, errTokenMissing
+	}
+	scope, ok := verifiedScope(secrets, func(secret []byte) bool { return ValidGitLabToken(token, secret) })
+	if !ok {
+		return metrics.WebhookDeliveryTokenInvalid, deliveryScope{}, http.StatusUnauthorized, errTokenInvalid
+	}
+	return metrics.WebhookDeliveryTokenValid, scope, 0, nil
+}
@@ -0,0 +1,181 @@
+package webhookreceiver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+// commitCheck is what the receiver uses of an event about a commit status or check run of a commit.
+type commitCheck struct {
+	// provider is the provider the event was delivered by.
+	provider string
+	// sha is the commit the status or check run is for.
+	sha string
+	// name is the name of the status or check run, i.e. its context for GitHub statuses. It's empty for the events the
+	// receiver doesn't ingest, which only reconcile the PromotionStrategies of the commit.
+	name string
+	// phase, url and description are the state, link and summary of the status or check run.
+	phase       promoterv1alpha1.CommitStatusPhase
+	url         string
+	description string
+}
+
+// parseCommitCheck returns the commitCheck of a delivery with routeCommitCheck. Only GitHub statuses and check runs
+// are ingested, the events of the other providers only have their sha set.
+func parseCommitCheck(provider, event string, jsonBytes []byte) commitCheck {
+	check := commitCheck{provider: provider, sha: commitCheckSha(provider, event, jsonBytes)}
+	if provider != ProviderGitHub {
+		return check
+	}
+
+	switch event {
+	case "status":
+		check.name = gjson.GetBytes(jsonBytes, "context").String()
+		check.url = gjson.GetBytes(jsonBytes, "target_url").String()
+		check.description = gjson.GetBytes(jsonBytes, "description").String()
+		switch gjson.GetBytes(jsonBytes, "state").String() {
+		case "success":
+			check.phase = promoterv1alpha1.CommitPhaseSuccess
+		case "failure", "error":
+			check.phase = promoterv1alpha1.CommitPhaseFailure
+		default:
+			check.phase = promoterv1alpha1.CommitPhasePending
+		}
+	case "check_run":
+		check.name = gjson.GetBytes(jsonBytes, "check_run.name").String()
+		check.url = gjson.GetBytes(jsonBytes, "check_run.details_url").String()
+		check.description = gjson.GetBytes(jsonBytes, "check_run.output.title").String()
+		check.phase = promoterv1alpha1.CommitPhasePending
+		if gjson.GetBytes(jsonBytes, "check_run.status").String() == "completed" {
+			switch gjson.GetBytes(jsonBytes, "check_run.conclusion").String() {
+			case "success", "neutral", "skipped":
+				check.phase = promoterv1alpha1.CommitPhaseSuccess
+			default:
+				check.phase = promoterv1alpha1.CommitPhaseFailure
+			}
+		}
+	}
+	// The CommitStatus only takes links, drop anything else an SCM may send.
+	if !strings.HasPrefix(check.url, "https://") && !strings.HasPrefix(check.url, "http://") {
+		check.url = ""
+	}
+	return check
+}
+
+// ingestCommitCheck creates or updates the CommitStatus of a commit check for each ChangeTransferPolicy that gates its
+// proposed or active hydrated commit on the key the check's name maps to, so that the ChangeTransferPolicy sees the
+// SCM's state of the check. Keys only hold the characters of a label, the check's name matches a key when it is the
+// same once the others are replaced by '-', e.g. "ci/tests" matches "ci-tests". Checks whose key already has a
+// CommitStatus from the promoter for the commit, such as the check runs the promoter sets itself, aren't ingested.
+// Returns the ChangeTransferPolicies a CommitStatus was ingested for.
+func (wr *WebhookReceiver) ingestCommitCheck(ctx context.Context, check commitCheck, ctps []promoterv1alpha1.ChangeTransferPolicy) ([]promoterv1alpha1.ChangeTransferPolicy, error) {
+	if check.name == "" {
+		return nil, nil
+	}
+	key := utils.KubeSafeLabel(check.name)
+
+	var ingested []promoterv1alpha1.ChangeTransferPolicy
+	// A commit has a single CommitStatus per key, whichever ChangeTransferPolicies gate on it.
+	commitStatuses := map[string]*promoterv1alpha1.CommitStatus{}
+	for _, ctp := range ctps {
+		var selectors []promoterv1alpha1.CommitStatusSelector
+		if ctp.Status.Proposed.Hydrated.Sha == check.sha {
+			selectors = append(selectors, ctp.Spec.ProposedCommitStatuses...)
+		}
+		if ctp.Status.Active.Hydrated.Sha == check.sha {
+			selectors = append(selectors, ctp.Spec.ActiveCommitStatuses...)
+		}
+		if !containsKey(selectors, key) {
+			continue
+		}
+
+		name := utils.KubeSafeUniqueName(ctx, ctp.Spec.RepositoryReference.Name+"-"+key+"-"+check.sha)
+		cs, ok := commitStatuses[ctp.Namespace+"/"+name]
+		if !ok {
+			managed, err := wr.hasPromoterCommitStatus(ctx, ctp.Namespace, key, check.sha)
+			if err != nil {
+				return nil, err
+			}
+			if managed {
+				log.FromContext(ctx).V(4).Info("not ingesting commit check with a CommitStatus of the promoter", "key", key, "sha", check.sha)
+				continue
+			}
+			cs = &promoterv1alpha1.CommitStatus{}
+			cs.Name = name
+			cs.Namespace = ctp.Namespace
+			commitStatuses[ctp.Namespace+"/"+name] = cs
+		}
+		if err := wr.applyIngestedCommitStatus(ctx, cs, check, key, ctp); err != nil {
+			return nil, err
+		}
+		ingested = append(ingested, ctp)
+	}
+	return ingested, nil
+}
+
+// applyIngestedCommitStatus creates or updates the ingested CommitStatus of a commit check, owned by the
+// ChangeTransferPolicies gating on it so that it is deleted with them.
+func (wr *WebhookReceiver) applyIngestedCommitStatus(ctx context.Context, cs *promoterv1alpha1.CommitStatus, check commitCheck, key string, ctp promoterv1alpha1.ChangeTransferPolicy) error {
+	_, err := controllerutil.CreateOrUpdate(ctx, wr.k8sClient, cs, func() error {
+		if cs.Labels == nil {
+			cs.Labels = map[string]string{}
+		}
+		cs.Labels[promoterv1alpha1.CommitStatusLabel] = key
+		cs.Labels[promoterv1alpha1.IngestedCommitStatusLabel] = check.provider
+		cs.Spec = promoterv1alpha1.CommitStatusSpec{
+			RepositoryReference: ctp.Spec.RepositoryReference,
+			Sha:                 check.sha,
+			Name:                check.name,
+			Description:         check.description,
+			Phase:               check.phase,
+			Url:                 check.url,
+		}
+		//nolint:wrapcheck // the caller wraps the error of CreateOrUpdate
+		return controllerutil.SetOwnerReference(&ctp, cs, wr.k8sClient.Scheme())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to ingest commit check %q of sha %q into CommitStatus %q: %w", check.name, check.sha, cs.Name, err)
+	}
+	return nil
+}
+
+// hasPromoterCommitStatus returns whether a CommitStatus that wasn't ingested has the key for the sha.
+func (wr *WebhookReceiver) hasPromoterCommitStatus(ctx context.Context, namespace, key, sha string) (bool, error) {
+	var csList promoterv1alpha1.CommitStatusList
+	err := wr.k8sClient.List(ctx, &csList, &client.ListOptions{
+		Namespace:     namespace,
+		LabelSelector: labels.SelectorFromSet(map[string]string{promoterv1alpha1.CommitStatusLabel: key}),
+		FieldSelector: fields.SelectorFromSet(map[string]string{".spec.sha": sha}),
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to list CommitStatuses for key %q and sha %q: %w", key, sha, err)
+	}
+	for _, cs := range csList.Items {
+		if _, ingested := cs.Labels[promoterv1alpha1.IngestedCommitStatusLabel]; !ingested {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// containsKey returns whether one of the selectors has the key once it is label safe.
+func containsKey(selectors []promoterv1alpha1.CommitStatusSelector, key string) bool {
+	for _, selector := range selectors {
+		if utils.KubeSafeLabel(selector.Key) == key {
+			return true
+		}
+	}
+	return false
+}
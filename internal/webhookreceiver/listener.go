@@ -0,0 +1,149 @@
+package webhookreceiver
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+)
+
+// bearerTokenProviders are the providers whose webhooks can send custom headers, and so must carry the bearer token
+// when one is configured. GitHub and Bitbucket deliveries are verified with their signatures instead.
+var bearerTokenProviders = []string{ProviderGitLab, ProviderGitea, ProviderForgejo, ProviderAzureDevops}
+
+// errBearerTokenMissing and errBearerTokenInvalid are returned by verifyBearerToken for deliveries that don't carry the
+// receiver's bearer token.
+var (
+	errBearerTokenMissing = errors.New("missing bearer token in Authorization header")
+	errBearerTokenInvalid = errors.New("invalid bearer token in Authorization header")
+)
+
+// Validate checks that the TLS and bearer token options are consistent and that their files can be read, so that a
+// misconfigured receiver fails at startup instead of when it starts listening.
+func (c Config) Validate() error {
+	if _, err := c.loadBearerToken(); err != nil {
+		return err
+	}
+	if _, err := c.newTLSConfig(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// loadBearerToken returns the token in BearerTokenFile, or nil if it's unset.
+func (c Config) loadBearerToken() ([]byte, error) {
+	if c.BearerTokenFile == "" {
+		return nil, nil
+	}
+	token, err := os.ReadFile(c.BearerTokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook receiver bearer token file: %w", err)
+	}
+	token = bytes.TrimSpace(token)
+	if len(token) == 0 {
+		return nil, fmt.Errorf("webhook receiver bearer token file %q is empty", c.BearerTokenFile)
+	}
+	return token, nil
+}
+
+// newTLSConfig returns the TLS configuration of the receiver's listener without its certificate, or nil if the receiver
+// serves plain HTTP. It checks that the certificate, key and client CA files can be loaded.
+func (c Config) newTLSConfig() (*tls.Config, error) {
+	if c.TLSCertFile == "" && c.TLSKeyFile == "" {
+		if c.ClientCAFile != "" {
+			return nil, errors.New("webhook receiver client CA file requires a TLS certificate and key file")
+		}
+		return nil, nil
+	}
+	if c.TLSCertFile == "" || c.TLSKeyFile == "" {
+		return nil, errors.New("webhook receiver TLS requires both a certificate and a key file")
+	}
+	if _, err := tls.LoadX509KeyPair(c.TLSCertFile, c.TLSKeyFile); err != nil {
+		return nil, fmt.Errorf("failed to load webhook receiver TLS certificate and key: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+	if c.ClientCAFile != "" {
+		caBundle, err := os.ReadFile(c.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read webhook receiver client CA file: %w", err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("webhook receiver client CA file %q has no PEM encoded certificates", c.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	for _, opt := range c.TLSOpts {
+		opt(tlsConfig)
+	}
+	return tlsConfig, nil
+}
+
+// listen returns the receiver's listener on addr. With TLS, the certificate and key are reloaded when their files
+// change until ctx is done.
+func (wr *WebhookReceiver) listen(ctx context.Context, addr string) (net.Listener, error) {
+	tlsConfig, err := wr.config.newTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var watcher *certwatcher.CertWatcher
+	if tlsConfig != nil {
+		watcher, err = certwatcher.New(wr.config.TLSCertFile, wr.config.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load webhook receiver TLS certificate and key: %w", err)
+		}
+		tlsConfig.GetCertificate = watcher.GetCertificate
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	if tlsConfig == nil {
+		return listener, nil
+	}
+
+	go func() {
+		if err := watcher.Start(ctx); err != nil {
+			logger.Error(err, "webhook receiver TLS certificate watcher failed")
+		}
+	}()
+	return tls.NewListener(listener, tlsConfig), nil
+}
+
+// verifyBearerToken checks that a delivery's Authorization header carries the receiver's bearer token. It returns the
+// reason the delivery is accepted or rejected for, and for rejected deliveries the response code and an error that is
+// safe to send back.
+func (wr *WebhookReceiver) verifyBearerToken(r *http.Request) (metrics.WebhookDeliveryReason, int, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return metrics.WebhookDeliveryBearerTokenMissing, http.StatusUnauthorized, errBearerTokenMissing
+	}
+	if subtle.ConstantTimeCompare([]byte(token), wr.bearerToken) != 1 {
+		return metrics.WebhookDeliveryBearerTokenInvalid, http.StatusUnauthorized, errBearerTokenInvalid
+	}
+	return metrics.WebhookDeliveryBearerTokenValid, 0, nil
+}
+
+// requiresBearerToken returns whether deliveries from the provider must carry the receiver's bearer token.
+func (wr *WebhookReceiver) requiresBearerToken(provider string) bool {
+	return len(wr.bearerToken) > 0 && slices.Contains(bearerTokenProviders, provider)
+}
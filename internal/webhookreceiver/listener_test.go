@@ -0,0 +1,82 @@
+package webhookreceiver_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/webhookreceiver"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config.Validate", func() {
+	var certFile, keyFile, caFile, tokenFile string
+
+	BeforeEach(func() {
+		dir := GinkgoT().TempDir()
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "webhook-receiver"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).NotTo(HaveOccurred())
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		Expect(err).NotTo(HaveOccurred())
+
+		certFile = filepath.Join(dir, "tls.crt")
+		keyFile = filepath.Join(dir, "tls.key")
+		caFile = filepath.Join(dir, "ca.crt")
+		tokenFile = filepath.Join(dir, "token")
+		certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+		Expect(os.WriteFile(certFile, certPEM, 0o600)).To(Succeed())
+		Expect(os.WriteFile(caFile, certPEM, 0o600)).To(Succeed())
+		Expect(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)).To(Succeed())
+		Expect(os.WriteFile(tokenFile, []byte("token\n"), 0o600)).To(Succeed())
+	})
+
+	It("accepts a receiver without TLS or bearer token", func() {
+		Expect(webhookreceiver.Config{}.Validate()).To(Succeed())
+	})
+
+	It("accepts TLS with a client CA and a bearer token", func() {
+		config := webhookreceiver.Config{TLSCertFile: certFile, TLSKeyFile: keyFile, ClientCAFile: caFile, BearerTokenFile: tokenFile}
+		Expect(config.Validate()).To(Succeed())
+	})
+
+	It("rejects a certificate without a key", func() {
+		Expect(webhookreceiver.Config{TLSCertFile: certFile}.Validate()).To(MatchError(ContainSubstring("both a certificate and a key file")))
+	})
+
+	It("rejects a client CA without TLS", func() {
+		Expect(webhookreceiver.Config{ClientCAFile: caFile}.Validate()).To(MatchError(ContainSubstring("requires a TLS certificate")))
+	})
+
+	It("rejects unreadable certificate files", func() {
+		config := webhookreceiver.Config{TLSCertFile: certFile + ".missing", TLSKeyFile: keyFile}
+		Expect(config.Validate()).To(MatchError(ContainSubstring("failed to load webhook receiver TLS certificate and key")))
+	})
+
+	It("rejects a client CA file without certificates", func() {
+		config := webhookreceiver.Config{TLSCertFile: certFile, TLSKeyFile: keyFile, ClientCAFile: tokenFile}
+		Expect(config.Validate()).To(MatchError(ContainSubstring("has no PEM encoded certificates")))
+	})
+
+	It("rejects an empty bearer token file", func() {
+		Expect(os.WriteFile(tokenFile, []byte("\n"), 0o600)).To(Succeed())
+		Expect(webhookreceiver.Config{BearerTokenFile: tokenFile}.Validate()).To(MatchError(ContainSubstring("is empty")))
+	})
+})
@@ -0,0 +1,120 @@
+package webhookreceiver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
+)
+
+// deliveryPersistInterval is how often the last verified delivery for a GitRepository is persisted on its status at
+// most, so that the replicas and shards that didn't receive it poll less too, without a status write per delivery.
+const deliveryPersistInterval = time.Minute
+
+// DeliveryTracker remembers when the last verified delivery for each GitRepository was received, so that the
+// ChangeTransferPolicy and PromotionStrategy controllers can poll less often while webhooks are delivered. It is safe
+// for concurrent use. A nil DeliveryTracker tracks nothing.
+type DeliveryTracker struct {
+	mu         sync.RWMutex
+	deliveries map[client.ObjectKey]time.Time
+}
+
+// NewDeliveryTracker creates a new DeliveryTracker.
+func NewDeliveryTracker() *DeliveryTracker {
+	return &DeliveryTracker{deliveries: map[client.ObjectKey]time.Time{}}
+}
+
+// Record records that a verified delivery for the GitRepository was received at the given time.
+func (t *DeliveryTracker) Record(gitRepo client.ObjectKey, received time.Time) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if received.After(t.deliveries[gitRepo]) {
+		t.deliveries[gitRepo] = received
+	}
+}
+
+// LastDelivery returns when the last verified delivery for the GitRepository was received. It is the zero time if
+// none was received since the controller started.
+func (t *DeliveryTracker) LastDelivery(gitRepo client.ObjectKey) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.deliveries[gitRepo]
+}
+
+// AdaptiveRequeueInterval returns how long to wait before reconciling a resource again, given its usual interval and
+// when the last verified delivery for its GitRepository was received. While the delivery is younger than freshness,
+// the interval is lengthened up to maxInterval, but not past the time the delivery becomes stale, so that the usual
+// interval is used again as soon as webhooks stop arriving. A maxInterval that isn't longer than interval disables the
+// lengthening.
+func AdaptiveRequeueInterval(interval, maxInterval, freshness time.Duration, lastDelivery, now time.Time) time.Duration {
+	if maxInterval <= interval || lastDelivery.IsZero() {
+		return interval
+	}
+	remaining := freshness - now.Sub(lastDelivery)
+	if remaining <= interval {
+		return interval
+	}
+	return min(remaining, maxInterval)
+}
+
+// verifiedDelivery returns whether a delivery accepted for the reason was verified, i.e. was signed or carried a
+// token with a webhook secret, carried the receiver's bearer token, or came from an allowed network. Only verified
+// deliveries count for adaptive polling, so that unauthenticated requests can't make the controllers poll less.
+func verifiedDelivery(reason metrics.WebhookDeliveryReason) bool {
+	switch reason {
+	case metrics.WebhookDeliverySignatureValid, metrics.WebhookDeliveryTokenValid, metrics.WebhookDeliverySourceAllowed,
+		metrics.WebhookDeliveryBearerTokenValid:
+		return true
+	default:
+		return false
+	}
+}
+
+// recordDelivery records a verified delivery for the GitRepositories of the repository it's for that the scope allows,
+// and persists it on their status.
+func (wr *WebhookReceiver) recordDelivery(ctx context.Context, scope deliveryScope, provider string, jsonBytes []byte) {
+	if wr.deliveries == nil {
+		return
+	}
+	fullName := deliveryFullName(provider, jsonBytes)
+	if fullName == "" {
+		return
+	}
+	gitRepos, err := wr.findGitRepositories(ctx, provider, fullName)
+	if err != nil {
+		log.FromContext(ctx).V(4).Info("unable to find GitRepositories to record the delivery for", "error", err)
+		return
+	}
+	received := time.Now()
+	for _, gitRepo := range filterGitRepositories(scope, gitRepos) {
+		wr.deliveries.Record(client.ObjectKeyFromObject(&gitRepo), received)
+		wr.persistDelivery(ctx, &gitRepo, received)
+	}
+}
+
+// persistDelivery sets the GitRepository's status.lastWebhookDelivery to a verified delivery, unless the one there is
+// less than deliveryPersistInterval older. A failure is only logged, the delivery is still recorded by this replica.
+func (wr *WebhookReceiver) persistDelivery(ctx context.Context, gitRepo *promoterv1alpha1.GitRepository, received time.Time) {
+	if last := gitRepo.Status.LastWebhookDelivery; last != nil && received.Sub(last.Time) < deliveryPersistInterval {
+		return
+	}
+	patch := client.MergeFrom(gitRepo.DeepCopy())
+	lastWebhookDelivery := metav1.NewTime(received)
+	gitRepo.Status.LastWebhookDelivery = &lastWebhookDelivery
+	if err := wr.k8sClient.Status().Patch(ctx, gitRepo, patch, client.FieldOwner(constants.WebhookReceiverFieldOwner)); err != nil {
+		log.FromContext(ctx).V(4).Info("unable to persist the delivery on the GitRepository", "gitRepository", gitRepo.Name, "error", err)
+	}
+}
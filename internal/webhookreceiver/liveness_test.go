@@ -0,0 +1,51 @@
+package webhookreceiver_test
+
+import (
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/webhookreceiver"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeliveryTracker", func() {
+	gitRepo := client.ObjectKey{Namespace: "default", Name: "repo"}
+
+	It("returns the zero time for GitRepositories without deliveries", func() {
+		Expect(webhookreceiver.NewDeliveryTracker().LastDelivery(gitRepo)).To(BeZero())
+	})
+
+	It("returns the latest delivery", func() {
+		tracker := webhookreceiver.NewDeliveryTracker()
+		received := time.Now()
+		tracker.Record(gitRepo, received)
+		tracker.Record(gitRepo, received.Add(-time.Minute))
+		Expect(tracker.LastDelivery(gitRepo)).To(Equal(received))
+		Expect(tracker.LastDelivery(client.ObjectKey{Namespace: "other", Name: "repo"})).To(BeZero())
+	})
+
+	It("tracks nothing when nil", func() {
+		var tracker *webhookreceiver.DeliveryTracker
+		tracker.Record(gitRepo, time.Now())
+		Expect(tracker.LastDelivery(gitRepo)).To(BeZero())
+	})
+})
+
+var _ = Describe("AdaptiveRequeueInterval", func() {
+	now := time.Now()
+
+	DescribeTable("lengthens the interval while deliveries are fresh",
+		func(maxInterval time.Duration, lastDelivery time.Time, expected time.Duration) {
+			Expect(webhookreceiver.AdaptiveRequeueInterval(5*time.Minute, maxInterval, 30*time.Minute, lastDelivery, now)).To(Equal(expected))
+		},
+		Entry("no delivery", 10*time.Minute, time.Time{}, 5*time.Minute),
+		Entry("fresh delivery", 10*time.Minute, now.Add(-time.Minute), 10*time.Minute),
+		Entry("delivery about to become stale", 10*time.Minute, now.Add(-22*time.Minute), 8*time.Minute),
+		Entry("delivery becoming stale within the interval", 10*time.Minute, now.Add(-27*time.Minute), 5*time.Minute),
+		Entry("stale delivery", 10*time.Minute, now.Add(-time.Hour), 5*time.Minute),
+		Entry("adaptive polling disabled", 0*time.Second, now.Add(-time.Minute), 5*time.Minute),
+		Entry("maximum shorter than the interval", 2*time.Minute, now.Add(-time.Minute), 5*time.Minute),
+	)
+})
@@ -0,0 +1,197 @@
+package webhookreceiver
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/tidwall/gjson"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+// routesRefreshInterval is how often the webhook_routes metric is refreshed.
+const routesRefreshInterval = 30 * time.Second
+
+// otherEvent is the event label of deliveries of events the receiver has no route for.
+const otherEvent = "other"
+
+// eventRoute is how the receiver handles the deliveries of an event.
+type eventRoute int
+
+const (
+	// routePush reconciles the ChangeTransferPolicies of the pushed branch.
+	routePush eventRoute = iota
+	// routePullRequest reconciles the PullRequests of the pull request.
+	routePullRequest
+	// routeCommitCheck reconciles the PromotionStrategies owning the ChangeTransferPolicies of the commit.
+	routeCommitCheck
+)
+
+// eventRoutes are the routes of the events of each provider. Deliveries of other events are accepted and ignored.
+var eventRoutes = map[string]map[string]eventRoute{
+	ProviderGitHub: {
+		"push":         routePush,
+		"pull_request": routePullRequest,
+		"status":       routeCommitCheck,
+		"check_run":    routeCommitCheck,
+	},
+	ProviderGitLab: {
+		"Push Hook":          routePush,
+		"Merge Request Hook": routePullRequest,
+		"Pipeline Hook":      routeCommitCheck,
+		"Job Hook":           routeCommitCheck,
+	},
+	ProviderForgejo: {
+		"push": routePush,
+	},
+	ProviderGitea: {
+		"push": routePush,
+	},
+	ProviderBitbucketCloud: {
+		"repo:push":                  routePush,
+		"pullrequest:fulfilled":      routePullRequest,
+		"pullrequest:rejected":       routePullRequest,
+		"repo:commit_status_updated": routeCommitCheck,
+	},
+	// Bitbucket Data Center has no webhook event for build statuses.
+	ProviderBitbucketDataCenter: {
+		"repo:refs_changed": routePush,
+		"pr:merged":         routePullRequest,
+		"pr:declined":       routePullRequest,
+	},
+	ProviderAzureDevops: {
+		"git.push": routePush,
+	},
+}
+
+// deliveryEvent returns the event of a delivery, as the provider names it.
+func deliveryEvent(provider string, header http.Header, jsonBytes []byte) string {
+	switch provider {
+	case ProviderGitHub:
+		return header.Get("X-Github-Event")
+	case ProviderGitLab:
+		return header.Get("X-Gitlab-Event")
+	case ProviderForgejo:
+		return header.Get("X-Forgejo-Event")
+	case ProviderGitea:
+		return header.Get("X-Gitea-Event")
+	case ProviderBitbucketCloud, ProviderBitbucketDataCenter:
+		return header.Get("X-Event-Key")
+	case ProviderAzureDevops:
+		return gjson.GetBytes(jsonBytes, "eventType").String()
+	default:
+		return ""
+	}
+}
+
+// eventLabel returns the event label of the metrics of a delivery: the event if the receiver has a route for it, so
+// that senders can't create arbitrary label values.
+func eventLabel(provider, event string) string {
+	if _, ok := eventRoutes[provider][event]; ok {
+		return event
+	}
+	return otherEvent
+}
+
+// providerLabel returns the provider label of the metrics of a delivery.
+func providerLabel(provider string) string {
+	if provider == ProviderUnknown {
+		return "unknown"
+	}
+	return provider
+}
+
+// eventOutcome returns the outcome of a delivery from its response code.
+func eventOutcome(responseCode int) metrics.WebhookEventOutcome {
+	switch {
+	case responseCode == http.StatusNoContent:
+		return metrics.WebhookEventAccepted
+	case responseCode >= 200 && responseCode < 300:
+		return metrics.WebhookEventIgnored
+	case responseCode >= 500:
+		return metrics.WebhookEventFailed
+	default:
+		return metrics.WebhookEventRejected
+	}
+}
+
+// refreshRoutes periodically sets the webhook_routes metric from the indexes deliveries are routed with, until ctx is
+// done.
+func (wr *WebhookReceiver) refreshRoutes(ctx context.Context) {
+	if wr.mgr == nil || !wr.mgr.GetCache().WaitForCacheSync(ctx) {
+		return
+	}
+
+	ticker := time.NewTicker(routesRefreshInterval)
+	defer ticker.Stop()
+	for {
+		wr.countRoutes(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// countRoutes sets the webhook_routes metric to the number of GitRepositories, and of branches and hydrated commits
+// of their ChangeTransferPolicies.
+func (wr *WebhookReceiver) countRoutes(ctx context.Context) {
+	// The lists are only read, don't deep copy them out of the cache on every refresh.
+	var gitRepos promoterv1alpha1.GitRepositoryList
+	if err := wr.k8sClient.List(ctx, &gitRepos, client.UnsafeDisableDeepCopy); err != nil {
+		logger.Error(err, "failed to list GitRepositories for webhook_routes metric")
+		return
+	}
+	var ctps promoterv1alpha1.ChangeTransferPolicyList
+	if err := wr.k8sClient.List(ctx, &ctps, client.UnsafeDisableDeepCopy); err != nil {
+		logger.Error(err, "failed to list ChangeTransferPolicies for webhook_routes metric")
+		return
+	}
+
+	branches := map[string]struct{}{}
+	shas := map[string]struct{}{}
+	for _, ctp := range ctps.Items {
+		for _, branch := range []string{ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch} {
+			branches[ctp.Namespace+"/"+utils.GetRepositoryBranchIndexKey(ctp.Spec.RepositoryReference.Name, branch)] = struct{}{}
+		}
+		for _, sha := range []string{ctp.Status.Proposed.Hydrated.Sha, ctp.Status.Active.Hydrated.Sha} {
+			if sha != "" {
+				shas[sha] = struct{}{}
+			}
+		}
+	}
+	metrics.SetWebhookRoutes(len(gitRepos.Items), len(branches), len(shas))
+	logger.V(1).Info("refreshed webhook routes", "repositories", len(gitRepos.Items), "branches", len(branches), "hydratedShas", len(shas))
+}
+
+// parsePullRequestEvent returns the pullRequestEvent of a delivery with routePullRequest.
+func parsePullRequestEvent(provider, event string, jsonBytes []byte) pullRequestEvent {
+	var parsed pullRequestEvent
+	switch provider {
+	case ProviderGitHub:
+		parsed = parseGitHubPullRequestEvent(jsonBytes)
+	case ProviderGitLab:
+		parsed = parseGitLabMergeRequestEvent(jsonBytes)
+	default:
+		parsed = parseBitbucketPullRequestEvent(event, jsonBytes)
+	}
+	parsed.provider = provider
+	return parsed
+}
+
+// commitCheckSha returns the commit of a delivery with routeCommitCheck.
+func commitCheckSha(provider, event string, jsonBytes []byte) string {
+	switch provider {
+	case ProviderGitHub:
+		return gitHubCommitCheckSha(event, jsonBytes)
+	case ProviderGitLab:
+		return gitLabCommitCheckSha(event, jsonBytes)
+	default:
+		return bitbucketCommitCheckSha(jsonBytes)
+	}
+}
@@ -7,16 +7,23 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"runtime/debug"
+	"slices"
+	"strings"
 	"time"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 
 	"github.com/tidwall/gjson"
+	"k8s.io/utils/lru"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	controllerruntime "sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
@@ -24,37 +31,71 @@ var logger = ctrl.Log.WithName("webhookReceiver")
 
 // Provider type constants
 const (
-	ProviderGitHub         = "github"
-	ProviderGitLab         = "gitlab"
-	ProviderForgejo        = "forgejo"
-	ProviderGitea          = "gitea"
-	ProviderBitbucketCloud = "bitbucketCloud"
-	ProviderAzureDevops    = "azureDevOps"
-	ProviderUnknown        = ""
+	ProviderGitHub              = "github"
+	ProviderGitLab              = "gitlab"
+	ProviderForgejo             = "forgejo"
+	ProviderGitea               = "gitea"
+	ProviderBitbucketCloud      = "bitbucketCloud"
+	ProviderBitbucketDataCenter = "bitbucketDataCenter"
+	ProviderAzureDevops         = "azureDevOps"
+	ProviderUnknown             = ""
 )
 
-// EnqueueFunc is a function type that can be used to enqueue CTP reconcile requests
-// without modifying the CTP object. This matches controller.CTPEnqueueFunc.
+// EnqueueFunc is a function type that can be used to enqueue CTP, PullRequest or PromotionStrategy reconcile requests
+// without modifying the object. This matches controller.CTPEnqueueFunc, controller.PullRequestEnqueueFunc and
+// controller.PromotionStrategyEnqueueFunc.
 type EnqueueFunc func(namespace, name string)
 
-// WebhookReceiver is a server that listens for webhooks and triggers reconciles of ChangeTransferPolicies.
+// pullRequestActions are the actions of GitHub pull_request events that change what a PullRequest's status reflects.
+var pullRequestActions = []string{"closed", "reopened", "synchronize"}
+
+// WebhookReceiver is a server that listens for webhooks and triggers reconciles of ChangeTransferPolicies,
+// PullRequests and PromotionStrategies.
 type WebhookReceiver struct {
 	mgr        controllerruntime.Manager
 	k8sClient  client.Client
+	config     Config
 	enqueueCTP EnqueueFunc
+	enqueuePR  EnqueueFunc
+	enqueuePS  EnqueueFunc
+	// seenDeliveries holds the delivery IDs of the recent GitHub deliveries, to reject replayed ones.
+	seenDeliveries *lru.Cache
+	// deliveries records the verified deliveries for each GitRepository, for adaptive polling.
+	deliveries *DeliveryTracker
+	// bearerToken is the token deliveries from the providers that can send custom headers must carry, if set. It's
+	// loaded from the BearerTokenFile of the config when the receiver starts.
+	bearerToken []byte
 }
 
-// NewWebhookReceiver creates a new instance of WebhookReceiver.
-func NewWebhookReceiver(mgr controllerruntime.Manager, enqueueCTP EnqueueFunc) WebhookReceiver {
+// NewWebhookReceiver creates a new instance of WebhookReceiver, which reads the resources, webhook Secrets included,
+// with k8sClient. Verified deliveries are recorded in deliveries, which may be nil.
+func NewWebhookReceiver(mgr controllerruntime.Manager, k8sClient client.Client, config Config, enqueueCTP EnqueueFunc, enqueuePR EnqueueFunc, enqueuePS EnqueueFunc, deliveries *DeliveryTracker) WebhookReceiver {
 	return WebhookReceiver{
-		mgr:        mgr,
-		k8sClient:  mgr.GetClient(),
-		enqueueCTP: enqueueCTP,
+		mgr:            mgr,
+		k8sClient:      k8sClient,
+		config:         config,
+		enqueueCTP:     enqueueCTP,
+		enqueuePR:      enqueuePR,
+		enqueuePS:      enqueuePS,
+		seenDeliveries: lru.New(seenDeliveriesSize),
+		deliveries:     deliveries,
 	}
 }
 
-// Start starts the webhook receiver server on the given address.
+// Start starts the webhook receiver server on the given address. It serves TLS if the config has a certificate and
+// key, and returns an error right away if they, the client CA or the bearer token can't be loaded.
 func (wr *WebhookReceiver) Start(ctx context.Context, addr string) error {
+	bearerToken, err := wr.config.loadBearerToken()
+	if err != nil {
+		return err
+	}
+	wr.bearerToken = bearerToken
+
+	listener, err := wr.listen(ctx, addr)
+	if err != nil {
+		return err
+	}
+
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", wr.postRoot)
 
@@ -64,7 +105,7 @@ func (wr *WebhookReceiver) Start(ctx context.Context, addr string) error {
 	}
 
 	go func() {
-		err := server.ListenAndServe()
+		err := server.Serve(listener)
 		if errors.Is(err, http.ErrServerClosed) {
 			logger.Info("webhook receiver server closed")
 		} else if err != nil {
@@ -73,6 +114,8 @@ func (wr *WebhookReceiver) Start(ctx context.Context, addr string) error {
 	}()
 	logger.Info("webhook receiver server started")
 
+	go wr.refreshRoutes(ctx)
+
 	<-ctx.Done()
 	logger.Info("webhook receiver server stopped")
 
@@ -85,7 +128,8 @@ func (wr *WebhookReceiver) Start(ctx context.Context, addr string) error {
 }
 
 // DetectProvider determines the SCM provider based on webhook headers.
-// Returns ProviderGitHub, ProviderGitLab, ProviderForgejo, ProviderGitea, ProviderBitbucketCloud, ProviderAzureDevops or ProviderUnknown.
+// Returns ProviderGitHub, ProviderGitLab, ProviderForgejo, ProviderGitea, ProviderBitbucketCloud,
+// ProviderBitbucketDataCenter, ProviderAzureDevops or ProviderUnknown.
 func (wr *WebhookReceiver) DetectProvider(r *http.Request) string {
 	// Check for GitHub webhook headers
 	if r.Header.Get("X-Github-Event") != "" || r.Header.Get("X-Github-Delivery") != "" {
@@ -107,24 +151,30 @@ func (wr *WebhookReceiver) DetectProvider(r *http.Request) string {
 		return ProviderGitea
 	}
 
-	// Check for Bitbucket Cloud webhook headers
-	if r.Header.Get("X-Hook-Uuid") != "" {
-		return ProviderBitbucketCloud
-	}
-
+	var bodyBytes []byte
 	if r.ContentLength > 0 {
-		bodyBytes, err := io.ReadAll(r.Body)
+		var err error
+		bodyBytes, err = io.ReadAll(r.Body)
 		if err != nil {
 			logger.Error(err, "error reading request body for provider detection")
 			return ProviderUnknown
 		}
 		// Restore the body for downstream handlers
 		r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+	}
 
-		// Azure DevOps: check for both EventType and PublisherId
-		if gjson.GetBytes(bodyBytes, "eventType").Exists() && gjson.GetBytes(bodyBytes, "publisherId").Exists() {
-			return ProviderAzureDevops
+	// Check for Bitbucket webhook headers. Both Bitbucket Cloud and Data Center send X-Event-Key, Data Center's payloads
+	// are told apart by their event key, and otherwise by the X-Hook-UUID only Cloud sends.
+	if r.Header.Get("X-Hook-Uuid") != "" || r.Header.Get("X-Event-Key") != "" {
+		if bitbucketDataCenterPayload(bodyBytes) || r.Header.Get("X-Hook-Uuid") == "" {
+			return ProviderBitbucketDataCenter
 		}
+		return ProviderBitbucketCloud
+	}
+
+	// Azure DevOps: check for both EventType and PublisherId
+	if gjson.GetBytes(bodyBytes, "eventType").Exists() && gjson.GetBytes(bodyBytes, "publisherId").Exists() {
+		return ProviderAzureDevops
 	}
 
 	return ProviderUnknown
@@ -133,14 +183,29 @@ func (wr *WebhookReceiver) DetectProvider(r *http.Request) string {
 func (wr *WebhookReceiver) postRoot(w http.ResponseWriter, r *http.Request) {
 	var responseCode int
 	var ctpFound bool
+	provider := ProviderUnknown
+	var event string
 	startTime := time.Now()
 	var updateDuration time.Duration
+	logger := logger
 
 	// Record the webhook call metrics. // We use a deferred function to ensure that the metrics are recorded even if an error occurs.
 	// We also subtract the update duration from the total time to get a more accurate measurement of how long actual
 	// processing took.
 	defer func() {
 		metrics.RecordWebhookCall(ctpFound, responseCode, time.Since(startTime)-updateDuration)
+		metrics.RecordWebhookEvent(providerLabel(provider), eventLabel(provider, event), eventOutcome(responseCode), time.Since(startTime))
+		logger.V(1).Info("handled webhook delivery", "responseCode", responseCode, "outcome", eventOutcome(responseCode), "duration", time.Since(startTime))
+	}()
+	// A panic while handling a delivery fails only that delivery, it's answered with an error so that the SCM reports
+	// the delivery as failed.
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			metrics.RecordWebhookHandlerPanic(providerLabel(provider))
+			logger.Error(fmt.Errorf("panic: %v", recovered), "recovered from panic while handling webhook delivery", "stack", string(debug.Stack()))
+			responseCode = http.StatusInternalServerError
+			http.Error(w, "error handling delivery", responseCode)
+		}
 	}()
 
 	if r.Method != http.MethodPost {
@@ -149,12 +214,35 @@ func (wr *WebhookReceiver) postRoot(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if wr.config.MaxBodySize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, wr.config.MaxBodySize)
+	}
+	jsonBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			metrics.RecordWebhookDelivery(false, metrics.WebhookDeliveryBodyTooLarge)
+			responseCode = http.StatusRequestEntityTooLarge
+			http.Error(w, "body too large", responseCode)
+			return
+		}
+		responseCode = http.StatusInternalServerError
+		http.Error(w, "error reading body", responseCode)
+		return
+	}
+	// Restore the body for the provider detection
+	r.Body = io.NopCloser(bytes.NewReader(jsonBytes))
+
 	// Determine provider from headers
-	provider := wr.DetectProvider(r)
+	provider = wr.DetectProvider(r)
+	event = deliveryEvent(provider, r.Header, jsonBytes)
 
 	// Extract and log a single delivery ID from common webhook headers (GitHub, GitLab, Forgejo/Gitea).
 	deliveryID := wr.extractDeliveryID(r)
-	logger := logger.WithValues("provider", provider, "deliveryID", deliveryID)
+	logger = logger.WithValues("provider", provider, "event", event, "deliveryID", deliveryID)
+	// The handlers log with the delivery's logger, so that the resources a delivery triggered reconciles of can be
+	// traced back to it.
+	ctx := log.IntoContext(r.Context(), logger)
 
 	if provider == ProviderUnknown {
 		logger.V(4).Info("unable to detect provider from headers")
@@ -163,43 +251,181 @@ func (wr *WebhookReceiver) postRoot(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// TODO: add a configurable payload max size for DoS protection.
-	jsonBytes, err := io.ReadAll(r.Body)
-	if err != nil {
-		responseCode = http.StatusInternalServerError
-		http.Error(w, "error reading body", responseCode)
+	deliveryReason := metrics.WebhookDeliverySignatureNotRequired
+	if wr.requiresBearerToken(provider) {
+		var bearerErr error
+		deliveryReason, responseCode, bearerErr = wr.verifyBearerToken(r)
+		if bearerErr != nil {
+			metrics.RecordWebhookDelivery(false, deliveryReason)
+			logger.Info("rejected delivery", "reason", deliveryReason, "error", bearerErr.Error())
+			http.Error(w, bearerErr.Error(), responseCode)
+			return
+		}
+	}
+
+	reason, scope, code, verifyErr := wr.verifyDelivery(ctx, provider, r, jsonBytes)
+	// A delivery that carried the bearer token stays verified when no webhook secret applies to it.
+	if reason != metrics.WebhookDeliverySignatureNotRequired {
+		deliveryReason = reason
+	}
+	responseCode = code
+	if verifyErr != nil {
+		// The body isn't logged, it can't be trusted.
+		metrics.RecordWebhookDelivery(false, deliveryReason)
+		if responseCode == http.StatusInternalServerError {
+			logger.Error(verifyErr, "unable to verify delivery")
+			http.Error(w, "unable to verify delivery", responseCode)
+			return
+		}
+		logger.Info("rejected delivery", "reason", deliveryReason, "error", verifyErr.Error())
+		http.Error(w, verifyErr.Error(), responseCode)
 		return
 	}
+	metrics.RecordWebhookDelivery(true, deliveryReason)
+	if verifiedDelivery(deliveryReason) {
+		wr.recordDelivery(ctx, scope, provider, jsonBytes)
+	}
 
-	ctp, err := wr.findChangeTransferPolicy(r.Context(), provider, jsonBytes)
-	if err != nil {
-		logger.V(4).Info("could not find any matching ChangeTransferPolicies", "error", err)
-		responseCode = http.StatusNoContent
+	if code, handled := wr.handleEvent(ctx, scope, provider, event, jsonBytes); handled {
+		responseCode = code
 		w.WriteHeader(responseCode)
 		return
 	}
-	if ctp == nil {
+
+	ctps, err := wr.findChangeTransferPolicies(ctx, scope, provider, jsonBytes)
+	if err != nil {
+		logger.V(4).Info("could not find any matching ChangeTransferPolicies", "error", err)
+	}
+	if len(ctps) == 0 {
+		// Deliveries for repositories and branches no ChangeTransferPolicy tracks are accepted and ignored, the SCM
+		// would report them as failing otherwise.
 		logger.Info("no ChangeTransferPolicy found for webhook delivery")
-		responseCode = http.StatusNoContent
+		responseCode = http.StatusAccepted
 		w.WriteHeader(responseCode)
 		return
 	}
 
 	ctpFound = true
 
-	// Use the enqueue function to trigger reconciliation.
+	// Use the enqueue function to trigger reconciliation. It only queues the ChangeTransferPolicies, the delivery is
+	// answered without waiting for them to be reconciled.
 	startUpdate := time.Now()
-	if wr.enqueueCTP != nil {
-		wr.enqueueCTP(ctp.Namespace, ctp.Name)
+	for _, ctp := range ctps {
+		if wr.enqueueCTP != nil {
+			wr.enqueueCTP(ctp.Namespace, ctp.Name)
+		}
+		logger.Info("Triggered reconcile of ChangeTransferPolicy via webhook", "namespace", ctp.Namespace, "name", ctp.Name)
 	}
 	updateDuration = time.Since(startUpdate)
-	logger.Info("Triggered reconcile of ChangeTransferPolicy via webhook", "namespace", ctp.Namespace, "name", ctp.Name)
 
 	responseCode = http.StatusNoContent
 	w.WriteHeader(responseCode)
 }
 
-func (wr *WebhookReceiver) findChangeTransferPolicy(ctx context.Context, provider string, jsonBytes []byte) (*promoterv1alpha1.ChangeTransferPolicy, error) {
+// verifyDelivery checks a delivery with the webhook secrets that apply to it, the way its provider sends them. It
+// returns the reason the delivery is accepted or rejected for and the scope of the GitRepositories it may trigger
+// reconciles for, and for rejected deliveries the response code and an error that is safe to send back.
+func (wr *WebhookReceiver) verifyDelivery(ctx context.Context, provider string, r *http.Request, jsonBytes []byte) (metrics.WebhookDeliveryReason, deliveryScope, int, error) {
+	switch provider {
+	case ProviderGitHub:
+		return wr.verifyGitHubDelivery(ctx, r, jsonBytes)
+	case ProviderGitLab:
+		return wr.verifyGitLabDelivery(ctx, r, jsonBytes)
+	case ProviderForgejo, ProviderGitea:
+		return wr.verifyGiteaDelivery(ctx, provider, r, jsonBytes)
+	case ProviderBitbucketCloud, ProviderBitbucketDataCenter:
+		return wr.verifyBitbucketDelivery(ctx, provider, r, jsonBytes)
+	case ProviderAzureDevops:
+		return wr.verifyAzureDevOpsDelivery(ctx, r, jsonBytes)
+	default:
+		return metrics.WebhookDeliverySignatureNotRequired, deliveryScope{}, 0, nil
+	}
+}
+
+// handleEvent handles the deliveries of pull request and commit check events, and ignores the deliveries of events
+// without a route. handled is false for pushes.
+func (wr *WebhookReceiver) handleEvent(ctx context.Context, scope deliveryScope, provider string, event string, jsonBytes []byte) (responseCode int, handled bool) {
+	route, ok := eventRoutes[provider][event]
+	if !ok {
+		log.FromContext(ctx).V(4).Info("ignoring event without a route")
+		return http.StatusAccepted, true
+	}
+	switch route {
+	case routePullRequest:
+		return wr.handlePullRequestEvent(ctx, scope, parsePullRequestEvent(provider, event, jsonBytes)), true
+	case routeCommitCheck:
+		return wr.handleCommitCheckEvent(ctx, scope, parseCommitCheck(provider, event, jsonBytes)), true
+	default:
+		return 0, false
+	}
+}
+
+// findChangeTransferPolicies returns the ChangeTransferPolicies to reconcile for a push. The ChangeTransferPolicy whose
+// proposed or active hydrated sha was pushed over is preferred. For GitHub, GitLab and Bitbucket, the
+// ChangeTransferPolicies tracking the pushed branch of the repository are used otherwise, which also finds them before
+// their status has a sha. Only the ChangeTransferPolicies of the GitRepositories the delivery's scope allows are
+// returned.
+func (wr *WebhookReceiver) findChangeTransferPolicies(ctx context.Context, scope deliveryScope, provider string, jsonBytes []byte) ([]promoterv1alpha1.ChangeTransferPolicy, error) {
+	ctp, err := wr.findChangeTransferPolicy(ctx, scope, provider, jsonBytes)
+	if ctp != nil {
+		return []promoterv1alpha1.ChangeTransferPolicy{*ctp}, nil
+	}
+
+	var ref string
+	switch provider {
+	case ProviderGitHub, ProviderGitLab:
+		ref = gjson.GetBytes(jsonBytes, "ref").String()
+	case ProviderBitbucketCloud, ProviderBitbucketDataCenter:
+		ref = bitbucketPushRef(jsonBytes)
+	default:
+		return nil, err
+	}
+
+	ctps, repoErr := wr.findChangeTransferPoliciesForBranch(ctx, scope, provider, deliveryFullName(provider, jsonBytes), ref)
+	if repoErr != nil {
+		return nil, errors.Join(err, repoErr)
+	}
+	return ctps, nil
+}
+
+// findChangeTransferPoliciesForBranch returns the ChangeTransferPolicies whose proposed or active branch is the pushed
+// ref, in the GitRepositories of the provider's repository with the full name that the scope allows.
+func (wr *WebhookReceiver) findChangeTransferPoliciesForBranch(ctx context.Context, scope deliveryScope, provider, fullName string, ref string) ([]promoterv1alpha1.ChangeTransferPolicy, error) {
+	logger := log.FromContext(ctx)
+
+	branch, isBranch := strings.CutPrefix(ref, "refs/heads/")
+	if fullName == "" || !isBranch || branch == "" {
+		logger.V(4).Info("unable to extract repository and branch from payload")
+		return nil, nil
+	}
+
+	gitRepos, err := wr.findGitRepositories(ctx, provider, fullName)
+	if err != nil {
+		return nil, err
+	}
+
+	var ctps []promoterv1alpha1.ChangeTransferPolicy
+	for _, gitRepo := range filterGitRepositories(scope, gitRepos) {
+		var ctpList promoterv1alpha1.ChangeTransferPolicyList
+		err := wr.k8sClient.List(ctx, &ctpList, &client.ListOptions{
+			Namespace: gitRepo.Namespace,
+			FieldSelector: fields.SelectorFromSet(map[string]string{
+				".spec.gitRepositoryRef.branch": utils.GetRepositoryBranchIndexKey(gitRepo.Name, branch),
+			}),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list changetransferpolicies for webhook receiver: %w", err)
+		}
+		ctps = append(ctps, ctpList.Items...)
+	}
+	return ctps, nil
+}
+
+// findChangeTransferPolicy returns the ChangeTransferPolicy whose proposed, or else active, hydrated sha a push was
+// pushed over, among the ChangeTransferPolicies of the GitRepositories the scope allows.
+func (wr *WebhookReceiver) findChangeTransferPolicy(ctx context.Context, scope deliveryScope, provider string, jsonBytes []byte) (*promoterv1alpha1.ChangeTransferPolicy, error) {
+	logger := log.FromContext(ctx)
+
 	var beforeSha string
 	var ref string
 	ctpLists := promoterv1alpha1.ChangeTransferPolicyList{}
@@ -232,6 +458,12 @@ func (wr *WebhookReceiver) findChangeTransferPolicy(ctx context.Context, provide
 				}
 			}
 		}
+	case ProviderBitbucketDataCenter:
+		// Bitbucket Data Center webhook format
+		if gjson.GetBytes(jsonBytes, "changes").Exists() && gjson.GetBytes(jsonBytes, "actor").Exists() {
+			beforeSha = gjson.GetBytes(jsonBytes, "changes.0.fromHash").String()
+			ref = gjson.GetBytes(jsonBytes, "changes.0.refId").String()
+		}
 	case ProviderAzureDevops:
 		// Azure DevOps webhook format
 		if gjson.GetBytes(jsonBytes, "resource.refUpdates").Exists() {
@@ -260,8 +492,12 @@ func (wr *WebhookReceiver) findChangeTransferPolicy(ctx context.Context, provide
 	if err != nil {
 		return nil, fmt.Errorf("failed to list changetransferpolicies for webhook receiver: %w", err)
 	}
+	ctps, err := wr.filterChangeTransferPolicies(ctx, scope, ctpLists.Items)
+	if err != nil {
+		return nil, err
+	}
 
-	if len(ctpLists.Items) == 0 {
+	if len(ctps) == 0 {
 		// List again, this time checking the active sha. This lets us catch cases where someone manually merged a PR in the SCM.
 		err = wr.k8sClient.List(ctx, &ctpLists, &client.ListOptions{
 			FieldSelector: fields.SelectorFromSet(map[string]string{
@@ -271,16 +507,237 @@ func (wr *WebhookReceiver) findChangeTransferPolicy(ctx context.Context, provide
 		if err != nil {
 			return nil, fmt.Errorf("failed to list changetransferpolicies for webhook receiver: %w", err)
 		}
+		ctps, err = wr.filterChangeTransferPolicies(ctx, scope, ctpLists.Items)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if len(ctpLists.Items) == 0 {
+	if len(ctps) == 0 {
 		return nil, fmt.Errorf("no changetransferpolicies found from webhook receiver sha: %s, ref: %s", beforeSha, ref)
 	}
-	if len(ctpLists.Items) > 1 {
+	if len(ctps) > 1 {
 		return nil, fmt.Errorf("too many changetransferpolicies found for sha: %s, ref: %s", beforeSha, ref)
 	}
 
-	return &ctpLists.Items[0], nil
+	return &ctps[0], nil
+}
+
+// pullRequestEvent is what the receiver uses of a GitHub pull_request event, a GitLab Merge Request Hook or a Bitbucket
+// pull request event.
+type pullRequestEvent struct {
+	// provider is the provider the event was delivered by.
+	provider string
+	// fullName is the full name of the repository, i.e. owner/name for GitHub, namespace/name for GitLab and
+	// workspace/repo_slug for Bitbucket Cloud.
+	fullName string
+	// number is the number of the pull request, or the IID of the merge request.
+	number string
+	// sourceBranch and targetBranch are the branches of the pull request.
+	sourceBranch string
+	targetBranch string
+	// action is the SCM's action of the event.
+	action string
+	// relevant is whether the action changes what a PullRequest's status reflects.
+	relevant bool
+}
+
+// parseGitHubPullRequestEvent returns the pullRequestEvent of a GitHub pull_request event.
+func parseGitHubPullRequestEvent(jsonBytes []byte) pullRequestEvent {
+	action := gjson.GetBytes(jsonBytes, "action").String()
+	return pullRequestEvent{
+		fullName:     gjson.GetBytes(jsonBytes, "repository.full_name").String(),
+		number:       gjson.GetBytes(jsonBytes, "number").String(),
+		sourceBranch: gjson.GetBytes(jsonBytes, "pull_request.head.ref").String(),
+		targetBranch: gjson.GetBytes(jsonBytes, "pull_request.base.ref").String(),
+		action:       action,
+		relevant:     slices.Contains(pullRequestActions, action),
+	}
+}
+
+// handlePullRequestEvent enqueues the PullRequests a pull request event is for, so that their status reflects a merge,
+// close, reopen or push right away. Events with other actions and for pull requests the promoter doesn't manage are
+// accepted and ignored. Returns the response code for the delivery.
+func (wr *WebhookReceiver) handlePullRequestEvent(ctx context.Context, scope deliveryScope, event pullRequestEvent) int {
+	logger := log.FromContext(ctx)
+
+	if !event.relevant {
+		logger.V(4).Info("ignoring pull request event", "action", event.action)
+		return http.StatusAccepted
+	}
+
+	prs, err := wr.findPullRequests(ctx, scope, event)
+	if err != nil {
+		logger.Error(err, "failed to find PullRequests for pull request event")
+		return http.StatusInternalServerError
+	}
+	if len(prs) == 0 {
+		logger.V(4).Info("no PullRequest found for pull request event", "action", event.action)
+		return http.StatusAccepted
+	}
+
+	for _, pr := range prs {
+		if wr.enqueuePR != nil {
+			wr.enqueuePR(pr.Namespace, pr.Name)
+		}
+		logger.Info("Triggered reconcile of PullRequest via webhook", "namespace", pr.Namespace, "name", pr.Name, "action", event.action)
+	}
+	return http.StatusNoContent
+}
+
+// findPullRequests returns the PullRequests of a pull request event: those in the GitRepositories of the event's
+// repository that the scope allows and that have the pull request's number as their ID, or its source and target
+// branches.
+func (wr *WebhookReceiver) findPullRequests(ctx context.Context, scope deliveryScope, event pullRequestEvent) ([]promoterv1alpha1.PullRequest, error) {
+	if event.fullName == "" {
+		return nil, nil
+	}
+
+	gitRepos, err := wr.findGitRepositories(ctx, event.provider, event.fullName)
+	if err != nil {
+		return nil, err
+	}
+
+	var prs []promoterv1alpha1.PullRequest
+	for _, gitRepo := range filterGitRepositories(scope, gitRepos) {
+		var prList promoterv1alpha1.PullRequestList
+		err := wr.k8sClient.List(ctx, &prList, &client.ListOptions{
+			Namespace: gitRepo.Namespace,
+			FieldSelector: fields.SelectorFromSet(map[string]string{
+				".spec.gitRepositoryRef.name": gitRepo.Name,
+			}),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pullrequests for webhook receiver: %w", err)
+		}
+		for _, pr := range prList.Items {
+			if (event.number != "" && pr.Status.ID == event.number) ||
+				(pr.Spec.SourceBranch == event.sourceBranch && pr.Spec.TargetBranch == event.targetBranch) {
+				prs = append(prs, pr)
+			}
+		}
+	}
+	return prs, nil
+}
+
+// gitHubCommitCheckSha returns the commit of a GitHub status or check_run event.
+func gitHubCommitCheckSha(eventName string, jsonBytes []byte) string {
+	if eventName == "check_run" {
+		return gjson.GetBytes(jsonBytes, "check_run.head_sha").String()
+	}
+	return gjson.GetBytes(jsonBytes, "sha").String()
+}
+
+// handleCommitCheckEvent ingests a commit status or check run the SCM reports into CommitStatuses for the
+// ChangeTransferPolicies whose proposed or active hydrated sha is its commit, and enqueues those ChangeTransferPolicies
+// and the PromotionStrategies owning them, so that a gate that turned green is noticed right away. Events for commits
+// the promoter doesn't track, or whose GitRepositories the scope doesn't allow, are accepted and ignored. Returns the
+// response code for the delivery.
+func (wr *WebhookReceiver) handleCommitCheckEvent(ctx context.Context, scope deliveryScope, check commitCheck) int {
+	logger := log.FromContext(ctx)
+
+	if check.sha == "" {
+		logger.V(4).Info("unable to extract commit SHA from payload")
+		return http.StatusAccepted
+	}
+
+	ctps, err := wr.findChangeTransferPoliciesForSha(ctx, check.sha)
+	if err == nil {
+		ctps, err = wr.filterChangeTransferPolicies(ctx, scope, ctps)
+	}
+	if err != nil {
+		logger.Error(err, "failed to find ChangeTransferPolicies for commit check event", "sha", check.sha)
+		return http.StatusInternalServerError
+	}
+
+	ingested, err := wr.ingestCommitCheck(ctx, check, ctps)
+	if err != nil {
+		logger.Error(err, "failed to ingest commit check", "sha", check.sha, "check", check.name)
+		return http.StatusInternalServerError
+	}
+	for _, ctp := range ingested {
+		if wr.enqueueCTP != nil {
+			wr.enqueueCTP(ctp.Namespace, ctp.Name)
+		}
+		logger.Info("Triggered reconcile of ChangeTransferPolicy via webhook", "namespace", ctp.Namespace, "name", ctp.Name, "check", check.name)
+	}
+
+	var promotionStrategies []client.ObjectKey
+	for _, ctp := range ctps {
+		owner := metav1.GetControllerOf(&ctp)
+		if owner == nil || owner.Kind != promoterv1alpha1.PromotionStrategyKind {
+			continue
+		}
+		key := client.ObjectKey{Namespace: ctp.Namespace, Name: owner.Name}
+		if !slices.Contains(promotionStrategies, key) {
+			promotionStrategies = append(promotionStrategies, key)
+		}
+	}
+	if len(promotionStrategies) == 0 {
+		logger.V(4).Info("no PromotionStrategy found for commit check event", "sha", check.sha)
+		return http.StatusAccepted
+	}
+
+	for _, key := range promotionStrategies {
+		if wr.enqueuePS != nil {
+			wr.enqueuePS(key.Namespace, key.Name)
+		}
+		logger.Info("Triggered reconcile of PromotionStrategy via webhook", "namespace", key.Namespace, "name", key.Name)
+	}
+	return http.StatusNoContent
+}
+
+// findChangeTransferPoliciesForSha returns the ChangeTransferPolicies whose proposed or active hydrated sha is sha,
+// once each.
+func (wr *WebhookReceiver) findChangeTransferPoliciesForSha(ctx context.Context, sha string) ([]promoterv1alpha1.ChangeTransferPolicy, error) {
+	var ctps []promoterv1alpha1.ChangeTransferPolicy
+	for _, field := range []string{".status.proposed.hydrated.sha", ".status.active.hydrated.sha"} {
+		var ctpList promoterv1alpha1.ChangeTransferPolicyList
+		err := wr.k8sClient.List(ctx, &ctpList, &client.ListOptions{
+			FieldSelector: fields.SelectorFromSet(map[string]string{field: sha}),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list changetransferpolicies for webhook receiver: %w", err)
+		}
+		for _, ctp := range ctpList.Items {
+			if !slices.ContainsFunc(ctps, func(found promoterv1alpha1.ChangeTransferPolicy) bool { return found.UID == ctp.UID }) {
+				ctps = append(ctps, ctp)
+			}
+		}
+	}
+	return ctps, nil
+}
+
+// deliveryFullName returns the full name of the repository a delivery is for. It's empty for other providers.
+func deliveryFullName(provider string, jsonBytes []byte) string {
+	switch provider {
+	case ProviderGitHub, ProviderForgejo, ProviderGitea:
+		return gjson.GetBytes(jsonBytes, "repository.full_name").String()
+	case ProviderGitLab:
+		return gjson.GetBytes(jsonBytes, "project.path_with_namespace").String()
+	case ProviderBitbucketCloud, ProviderBitbucketDataCenter:
+		return bitbucketFullName(jsonBytes)
+	case ProviderAzureDevops:
+		return azureDevOpsFullName(jsonBytes)
+	default:
+		return ""
+	}
+}
+
+// findGitRepositories returns the GitRepositories of the provider's repository with the full name, i.e. owner/name
+// for GitHub, Gitea and Forgejo, namespace/name for GitLab, workspace/repo_slug for Bitbucket Cloud and project/name
+// for Azure DevOps.
+func (wr *WebhookReceiver) findGitRepositories(ctx context.Context, provider, fullName string) ([]promoterv1alpha1.GitRepository, error) {
+	var gitRepos promoterv1alpha1.GitRepositoryList
+	err := wr.k8sClient.List(ctx, &gitRepos, &client.ListOptions{
+		FieldSelector: fields.SelectorFromSet(map[string]string{
+			".spec.fullName": utils.GetRepositoryFullNameIndexKey(provider, fullName),
+		}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gitrepositories for webhook receiver: %w", err)
+	}
+	return gitRepos.Items, nil
 }
 
 // extractDeliveryID inspects common webhook headers and returns the first non-empty delivery ID string found (provider-agnostic).
@@ -318,5 +775,9 @@ func (wr *WebhookReceiver) extractDeliveryID(r *http.Request) string {
 	if id := r.Header.Get("X-Hook-Uuid"); id != "" {
 		return id
 	}
+	// Bitbucket Data Center
+	if id := r.Header.Get("X-Request-Id"); id != "" {
+		return id
+	}
 	return ""
 }
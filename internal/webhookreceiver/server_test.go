@@ -2,6 +2,7 @@ package webhookreceiver_test
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/argoproj-labs/gitops-promoter/internal/webhookreceiver"
 	. "github.com/onsi/ginkgo/v2"
@@ -17,6 +18,7 @@ var _ = Describe("DetectProvider", func() {
 
 	tests := map[string]struct {
 		headers        map[string]string
+		body           string
 		expectedResult string
 	}{
 		"GitHub webhook with X-GitHub-Event": {
@@ -61,6 +63,28 @@ var _ = Describe("DetectProvider", func() {
 			},
 			expectedResult: webhookreceiver.ProviderBitbucketCloud,
 		},
+		"Bitbucket Cloud webhook with X-Event-Key and X-Hook-UUID": {
+			headers: map[string]string{
+				"X-Event-Key": "repo:push",
+				"X-Hook-UUID": "12345-abcde",
+			},
+			expectedResult: webhookreceiver.ProviderBitbucketCloud,
+		},
+		"Bitbucket Data Center webhook with X-Event-Key": {
+			headers: map[string]string{
+				"X-Event-Key":  "repo:refs_changed",
+				"X-Request-Id": "12345-abcde",
+			},
+			expectedResult: webhookreceiver.ProviderBitbucketDataCenter,
+		},
+		"Bitbucket Data Center webhook with X-Hook-UUID and a Data Center payload": {
+			headers: map[string]string{
+				"X-Event-Key": "pr:merged",
+				"X-Hook-UUID": "12345-abcde",
+			},
+			body:           `{"eventKey":"pr:merged","date":"2026-01-01T00:00:00+0000"}`,
+			expectedResult: webhookreceiver.ProviderBitbucketDataCenter,
+		},
 		"Unknown provider - no headers": {
 			headers:        map[string]string{},
 			expectedResult: webhookreceiver.ProviderUnknown,
@@ -75,7 +99,7 @@ var _ = Describe("DetectProvider", func() {
 
 	for name, test := range tests {
 		It(name, func() {
-			req, err := http.NewRequest(http.MethodPost, "/", nil)
+			req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
 			Expect(err).NotTo(HaveOccurred())
 
 			for key, value := range test.headers {
@@ -97,3 +121,54 @@ var _ = Describe("DetectProvider", func() {
 		Expect(result).To(Equal(webhookreceiver.ProviderGitHub))
 	})
 })
+
+var _ = Describe("ValidGitHubSignature", func() {
+	payload := []byte(`{"ref":"refs/heads/environment/development"}`)
+	// Computed with: printf '%s' "$payload" | openssl dgst -sha256 -hmac secret
+	const signature = "sha256=156edc0ee9c4445ffe6f2d17f168c120e1f2b8a0ad819873c9cdd5dbe08b92c5"
+
+	It("should accept the signature of one of the secrets", func() {
+		Expect(webhookreceiver.ValidGitHubSignature(payload, signature, []byte("other"), []byte("secret"))).To(BeTrue())
+	})
+
+	It("should reject the signature of another secret", func() {
+		Expect(webhookreceiver.ValidGitHubSignature(payload, signature, []byte("other"))).To(BeFalse())
+	})
+
+	It("should reject the signature of another payload", func() {
+		Expect(webhookreceiver.ValidGitHubSignature([]byte(`{}`), signature, []byte("secret"))).To(BeFalse())
+	})
+
+	It("should reject malformed signatures", func() {
+		Expect(webhookreceiver.ValidGitHubSignature(payload, "", []byte("secret"))).To(BeFalse())
+		Expect(webhookreceiver.ValidGitHubSignature(payload, "sha1=abc", []byte("secret"))).To(BeFalse())
+		Expect(webhookreceiver.ValidGitHubSignature(payload, "sha256=not-hex", []byte("secret"))).To(BeFalse())
+	})
+})
+
+var _ = Describe("ValidGiteaSignature", func() {
+	payload := []byte(`{"ref":"refs/heads/environment/development"}`)
+	// The signature of ValidGitHubSignature's test, Gitea and Forgejo send it without the "sha256=" prefix.
+	const signature = "156edc0ee9c4445ffe6f2d17f168c120e1f2b8a0ad819873c9cdd5dbe08b92c5"
+
+	It("should accept the signature of one of the secrets", func() {
+		Expect(webhookreceiver.ValidGiteaSignature(payload, signature, []byte("other"), []byte("secret"))).To(BeTrue())
+	})
+
+	It("should reject the signature of another secret or with a prefix", func() {
+		Expect(webhookreceiver.ValidGiteaSignature(payload, signature, []byte("other"))).To(BeFalse())
+		Expect(webhookreceiver.ValidGiteaSignature(payload, "sha256="+signature, []byte("secret"))).To(BeFalse())
+		Expect(webhookreceiver.ValidGiteaSignature(payload, "", []byte("secret"))).To(BeFalse())
+	})
+})
+
+var _ = Describe("ValidGitLabToken", func() {
+	It("should accept one of the secrets", func() {
+		Expect(webhookreceiver.ValidGitLabToken("secret", []byte("other"), []byte("secret"))).To(BeTrue())
+	})
+
+	It("should reject other tokens", func() {
+		Expect(webhookreceiver.ValidGitLabToken("secret", []byte("other"))).To(BeFalse())
+		Expect(webhookreceiver.ValidGitLabToken("", []byte("secret"))).To(BeFalse())
+	})
+})
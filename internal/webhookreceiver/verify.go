@@ -0,0 +1,283 @@
+package webhookreceiver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+)
+
+const (
+	// DefaultMaxBodySize is the default limit of the size of a delivery's body. GitHub caps payloads at 25 MB.
+	DefaultMaxBodySize = 25 << 20
+	// DefaultMaxDeliveryAge is the default age after which a delivery is rejected as too old.
+	DefaultMaxDeliveryAge = 5 * time.Minute
+
+	// seenDeliveriesSize is the number of delivery IDs remembered to detect replayed deliveries.
+	seenDeliveriesSize = 4096
+)
+
+// Config configures how the WebhookReceiver serves and verifies the deliveries it gets.
+type Config struct {
+	// SecretName is the name of a Secret in SecretNamespace whose "webhookSecret" key is the secret GitHub, Gitea,
+	// Forgejo and Bitbucket sign the deliveries with, GitLab sends in the X-Gitlab-Token header and Azure DevOps sends as
+	// the password of basic auth. Deliveries for repositories whose GitRepository references its own webhook secret in
+	// spec.manageWebhooks.secretRef may use either, those verified with the GitRepository's secret only trigger
+	// reconciles for it. Deliveries are accepted unverified when no secret applies to them, and then only trigger
+	// reconciles for the GitRepositories without a webhook secret. Azure DevOps deliveries can't carry both basic auth
+	// and the bearer token of BearerTokenFile.
+	SecretName string
+	// SecretNamespace is the namespace of the Secret named SecretName.
+	SecretNamespace string
+	// MaxBodySize is the maximum size of a delivery's body in bytes. Zero means no limit.
+	MaxBodySize int64
+	// MaxDeliveryAge is the maximum age of a signed GitHub delivery, taken from the time in its delivery ID. Zero means
+	// no limit.
+	MaxDeliveryAge time.Duration
+	// BitbucketAllowedNetworks are the networks unsigned Bitbucket deliveries are accepted from, for Bitbucket Cloud
+	// plans that can't sign deliveries. If set, every Bitbucket delivery from other addresses is rejected, signed or
+	// not, and even when no secret applies to it.
+	BitbucketAllowedNetworks []net.IPNet
+	// TLSCertFile and TLSKeyFile are the paths of the certificate and key the receiver serves TLS with. The receiver
+	// serves plain HTTP if they are unset. The files are reloaded when they change.
+	TLSCertFile string
+	TLSKeyFile  string
+	// ClientCAFile is the path of the CA bundle client certificates are verified with. If set, the receiver requires
+	// clients to present a certificate signed by one of its CAs. Requires TLSCertFile and TLSKeyFile.
+	ClientCAFile string
+	// TLSOpts are applied to the receiver's TLS configuration, e.g. to disable HTTP/2.
+	TLSOpts []func(*tls.Config)
+	// BearerTokenFile is the path of a file holding a token that deliveries from the providers that can send custom
+	// headers (GitLab, Gitea, Forgejo and Azure DevOps) must carry in an "Authorization: Bearer" header.
+	BearerTokenFile string
+}
+
+// errSignatureMissing and errSignatureInvalid are returned by verifyGitHubDelivery for deliveries that don't carry the
+// signature of any secret that applies to them.
+var (
+	errSignatureMissing = errors.New("missing X-Hub-Signature-256 header")
+	errSignatureInvalid = errors.New("invalid X-Hub-Signature-256 header")
+)
+
+// errDeliveryIDMissing and errDeliveryIDInvalid are returned by verifyGitHubDelivery for signed deliveries without a
+// time based delivery ID.
+var (
+	errDeliveryIDMissing = errors.New("missing X-Github-Delivery header")
+	errDeliveryIDInvalid = errors.New("invalid X-Github-Delivery header, it must be a time based UUID")
+)
+
+// ValidGitHubSignature returns whether signatureHeader, the value of a delivery's X-Hub-Signature-256 header, is the
+// HMAC-SHA256 signature of payload with one of secrets. The signatures are compared in constant time.
+func ValidGitHubSignature(payload []byte, signatureHeader string, secrets ...[]byte) bool {
+	hexSignature, ok := strings.CutPrefix(signatureHeader, "sha256=")
+	if !ok {
+		return false
+	}
+	signature, err := hex.DecodeString(hexSignature)
+	if err != nil {
+		return false
+	}
+	for _, secret := range secrets {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(payload)
+		if hmac.Equal(mac.Sum(nil), signature) {
+			return true
+		}
+	}
+	return false
+}
+
+// deliveryTime returns the time a GitHub delivery was created, taken from its delivery ID. GitHub's delivery IDs are
+// time based (version 1) UUIDs, ok is false for IDs that aren't.
+func deliveryTime(deliveryID string) (t time.Time, ok bool) {
+	id, err := uuid.Parse(deliveryID)
+	if err != nil || id.Version() != 1 {
+		return time.Time{}, false
+	}
+	sec, nsec := id.Time().UnixTime()
+	return time.Unix(sec, nsec), true
+}
+
+// verifyGitHubDelivery checks a GitHub delivery's signature, age and delivery ID. Signed deliveries must carry a time
+// based delivery ID, like the ones GitHub sends, so that replayed and old deliveries are rejected. It returns the reason
+// the delivery is accepted or rejected for and the scope of the GitRepositories it may trigger reconciles for, and for
+// rejected deliveries the response code and an error that is safe to send back.
+func (wr *WebhookReceiver) verifyGitHubDelivery(ctx context.Context, r *http.Request, jsonBytes []byte) (metrics.WebhookDeliveryReason, deliveryScope, int, error) {
+	secrets, err := wr.webhookSecrets(ctx, ProviderGitHub, deliveryFullName(ProviderGitHub, jsonBytes))
+	if err != nil {
+		return metrics.WebhookDeliverySecretUnavailable, deliveryScope{}, http.StatusInternalServerError, err
+	}
+	if len(secrets) == 0 {
+		return metrics.WebhookDeliverySignatureNotRequired, deliveryScope{}, 0, nil
+	}
+
+	signature := r.Header.Get("X-Hub-Signature-256")
+	if signature == "" {
+		return metrics.WebhookDeliverySignatureMissing, deliveryScope{}, http.StatusUnauthorized, errSignatureMissing
+	}
+	scope, ok := verifiedScope(secrets, func(secret []byte) bool { return ValidGitHubSignature(jsonBytes, signature, secret) })
+	if !ok {
+		return metrics.WebhookDeliverySignatureInvalid, deliveryScope{}, http.StatusUnauthorized, errSignatureInvalid
+	}
+
+	deliveryID := r.Header.Get("X-Github-Delivery")
+	if deliveryID == "" {
+		return metrics.WebhookDeliveryIDMissing, deliveryScope{}, http.StatusBadRequest, errDeliveryIDMissing
+	}
+	created, ok := deliveryTime(deliveryID)
+	if !ok {
+		return metrics.WebhookDeliveryIDInvalid, deliveryScope{}, http.StatusBadRequest, errDeliveryIDInvalid
+	}
+	if wr.config.MaxDeliveryAge > 0 && time.Since(created) > wr.config.MaxDeliveryAge {
+		return metrics.WebhookDeliveryTooOld, deliveryScope{}, http.StatusBadRequest, fmt.Errorf("delivery is older than %s", wr.config.MaxDeliveryAge)
+	}
+	if wr.seenDeliveries != nil {
+		if _, seen := wr.seenDeliveries.Get(deliveryID); seen {
+			return metrics.WebhookDeliveryReplayed, deliveryScope{}, http.StatusConflict, errors.New("delivery was already received")
+		}
+		wr.seenDeliveries.Add(deliveryID, struct{}{})
+	}
+	return metrics.WebhookDeliverySignatureValid, scope, 0, nil
+}
+
+// webhookSecret is a webhook secret that applies to a delivery. gitRepo is the GitRepository that references it, and
+// is nil for the secret of the controller, which applies to the deliveries for any repository.
+type webhookSecret struct {
+	value   []byte
+	gitRepo *client.ObjectKey
+}
+
+// deliveryScope holds the GitRepositories a delivery may trigger reconciles for. The payload of a delivery only names
+// the repository it's for, a delivery verified with the secret of one GitRepository mustn't trigger reconciles for the
+// ChangeTransferPolicies of others, e.g. through the shas it carries. The zero value is the scope of the deliveries no
+// secret verified, which may only trigger reconciles for the GitRepositories without a webhook secret.
+type deliveryScope struct {
+	// all is set for deliveries verified with the controller's secret, which may trigger reconciles for any
+	// GitRepository.
+	all bool
+	// gitRepos are the GitRepositories whose webhook secret verified the delivery.
+	gitRepos []client.ObjectKey
+}
+
+// verifiedScope returns the scope of a delivery that valid accepts for some of the secrets, and false if valid accepts
+// it for none of them.
+func verifiedScope(secrets []webhookSecret, valid func(secret []byte) bool) (deliveryScope, bool) {
+	var scope deliveryScope
+	verified := false
+	for _, secret := range secrets {
+		if !valid(secret.value) {
+			continue
+		}
+		verified = true
+		if secret.gitRepo == nil {
+			scope.all = true
+		} else {
+			scope.gitRepos = append(scope.gitRepos, *secret.gitRepo)
+		}
+	}
+	return scope, verified
+}
+
+// allows returns whether a delivery with the scope may trigger reconciles for the resources of the GitRepository.
+func (s deliveryScope) allows(gitRepo *promoterv1alpha1.GitRepository) bool {
+	if s.all {
+		return true
+	}
+	if len(s.gitRepos) > 0 {
+		return slices.Contains(s.gitRepos, client.ObjectKeyFromObject(gitRepo))
+	}
+	return gitRepo.Spec.ManageWebhooks == nil || gitRepo.Spec.ManageWebhooks.SecretRef == nil
+}
+
+// filterGitRepositories returns the GitRepositories the scope allows.
+func filterGitRepositories(scope deliveryScope, gitRepos []promoterv1alpha1.GitRepository) []promoterv1alpha1.GitRepository {
+	return slices.DeleteFunc(gitRepos, func(gitRepo promoterv1alpha1.GitRepository) bool {
+		return !scope.allows(&gitRepo)
+	})
+}
+
+// filterChangeTransferPolicies returns the ChangeTransferPolicies whose GitRepository the scope allows. The
+// ChangeTransferPolicies of a GitRepository that doesn't exist are dropped, unless the scope allows all of them.
+func (wr *WebhookReceiver) filterChangeTransferPolicies(ctx context.Context, scope deliveryScope, ctps []promoterv1alpha1.ChangeTransferPolicy) ([]promoterv1alpha1.ChangeTransferPolicy, error) {
+	if scope.all {
+		return ctps, nil
+	}
+	var allowed []promoterv1alpha1.ChangeTransferPolicy
+	for _, ctp := range ctps {
+		var gitRepo promoterv1alpha1.GitRepository
+		err := wr.k8sClient.Get(ctx, client.ObjectKey{Namespace: ctp.Namespace, Name: ctp.Spec.RepositoryReference.Name}, &gitRepo)
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitRepository %q of ChangeTransferPolicy %q: %w", ctp.Spec.RepositoryReference.Name, ctp.Name, err)
+		}
+		if scope.allows(&gitRepo) {
+			allowed = append(allowed, ctp)
+		}
+	}
+	return allowed, nil
+}
+
+// webhookSecrets returns the webhook secrets that apply to a delivery for the provider's repository with the full name:
+// the one of the controller and the ones of the repository's GitRepositories. A referenced Secret that doesn't exist or lacks
+// the secret is an error, so that a broken configuration doesn't let unverified deliveries in.
+func (wr *WebhookReceiver) webhookSecrets(ctx context.Context, provider, fullName string) ([]webhookSecret, error) {
+	var secrets []webhookSecret
+	if wr.config.SecretName != "" {
+		secret, err := wr.getWebhookSecret(ctx, client.ObjectKey{Namespace: wr.config.SecretNamespace, Name: wr.config.SecretName})
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, webhookSecret{value: secret})
+	}
+
+	if fullName == "" {
+		return secrets, nil
+	}
+	gitRepos, err := wr.findGitRepositories(ctx, provider, fullName)
+	if err != nil {
+		return nil, err
+	}
+	for _, gitRepo := range gitRepos {
+		if gitRepo.Spec.ManageWebhooks == nil || gitRepo.Spec.ManageWebhooks.SecretRef == nil {
+			continue
+		}
+		secret, err := wr.getWebhookSecret(ctx, client.ObjectKey{Namespace: gitRepo.Namespace, Name: gitRepo.Spec.ManageWebhooks.SecretRef.Name})
+		if err != nil {
+			return nil, err
+		}
+		key := client.ObjectKeyFromObject(&gitRepo)
+		secrets = append(secrets, webhookSecret{value: secret, gitRepo: &key})
+	}
+	return secrets, nil
+}
+
+// getWebhookSecret returns the webhook secret in the Secret with the key.
+func (wr *WebhookReceiver) getWebhookSecret(ctx context.Context, key client.ObjectKey) ([]byte, error) {
+	var secret v1.Secret
+	if err := wr.k8sClient.Get(ctx, key, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get webhook Secret %q: %w", key, err)
+	}
+	value := secret.Data[promoterv1alpha1.WebhookSecretKey]
+	if len(value) == 0 {
+		return nil, fmt.Errorf("webhook Secret %q has no %q key", key, promoterv1alpha1.WebhookSecretKey)
+	}
+	return value, nil
+}
@@ -19,6 +19,7 @@ package e2e
 import (
 	"fmt"
 	"os/exec"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -121,4 +122,234 @@ var _ = Describe("controller", Ordered, func() {
 			EventuallyWithOffset(1, verifyControllerUp, time.Minute, time.Second).Should(Succeed())
 		})
 	})
+
+	Context("Proposed branches", func() {
+		const (
+			testNamespace = "promoter-e2e-proposed-branches"
+			repoPath      = "e2e/proposed-branches.git"
+		)
+
+		BeforeAll(func() {
+			By("creating the test namespace")
+			cmd := exec.Command("kubectl", "create", "ns", testNamespace)
+			_, err := utils.Run(cmd)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("starting a git server with only the environment branches")
+			Expect(utils.InstallGitServer(testNamespace)).To(Succeed())
+			Expect(utils.CreateGitRepository(testNamespace, repoPath, "environment/dev", "environment/staging")).To(Succeed())
+		})
+
+		AfterAll(func() {
+			By("removing the test namespace")
+			cmd := exec.Command("kubectl", "delete", "ns", testNamespace)
+			_, _ = utils.Run(cmd)
+		})
+
+		It("should create the missing proposed branches from the active branches", func() {
+			By("creating a PromotionStrategy whose proposed branches don't exist")
+			Expect(utils.Apply(testNamespace, fmt.Sprintf(`apiVersion: v1
+kind: Secret
+metadata:
+  name: fake-scm-provider
+---
+apiVersion: promoter.argoproj.io/v1alpha1
+kind: ScmProvider
+metadata:
+  name: fake-scm-provider
+spec:
+  secretRef:
+    name: fake-scm-provider
+  fake: {}
+---
+apiVersion: promoter.argoproj.io/v1alpha1
+kind: GitRepository
+metadata:
+  name: proposed-branches
+spec:
+  url: %s
+  scmProviderRef:
+    kind: ScmProvider
+    name: fake-scm-provider
+---
+apiVersion: promoter.argoproj.io/v1alpha1
+kind: PromotionStrategy
+metadata:
+  name: proposed-branches
+spec:
+  gitRepositoryRef:
+    name: proposed-branches
+  environments:
+  - branch: environment/dev
+  - branch: environment/staging
+  - branch: environment/prod
+`, utils.GitServerURL(testNamespace, repoPath)))).To(Succeed())
+
+			By("validating that the proposed branches are created at the tip of the active branches")
+			for _, branch := range []string{"environment/dev", "environment/staging"} {
+				activeSha, err := utils.GetGitServerBranchSha(testNamespace, repoPath, branch)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(func(g Gomega) {
+					proposedSha, err := utils.GetGitServerBranchSha(testNamespace, repoPath, branch+"-next")
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(proposedSha).To(Equal(activeSha))
+				}, 2*time.Minute, time.Second).Should(Succeed())
+			}
+
+			By("validating that the environment without an active branch reports it")
+			Eventually(func(g Gomega) {
+				cmd := exec.Command("kubectl", "get", "changetransferpolicies", "-n", testNamespace, "-o",
+					`jsonpath={.items[?(@.spec.activeBranch=="environment/prod")].status.conditions[?(@.type=="Ready")].reason}`)
+				reason, err := utils.Run(cmd)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(string(reason)).To(Equal("ActiveBranchMissing"))
+			}, 2*time.Minute, time.Second).Should(Succeed())
+			_, err := utils.GetGitServerBranchSha(testNamespace, repoPath, "environment/prod-next")
+			Expect(err).To(HaveOccurred(), "the proposed branch of an environment without an active branch is not created")
+		})
+	})
+
+	Context("Revert commits", func() {
+		const (
+			testNamespace = "promoter-e2e-revert-commits"
+			repoPath      = "e2e/revert-commits.git"
+		)
+
+		BeforeAll(func() {
+			By("creating the test namespace")
+			cmd := exec.Command("kubectl", "create", "ns", testNamespace)
+			_, err := utils.Run(cmd)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("starting a git server with a bad change on the dry branch")
+			Expect(utils.InstallGitServer(testNamespace)).To(Succeed())
+			Expect(utils.CreateGitRepository(testNamespace, repoPath, "main", "environment/dev", "environment/staging")).To(Succeed())
+			Expect(utils.GitServerExec(testNamespace, strings.Join([]string{
+				"set -e",
+				"rm -rf /tmp/change && git clone -q -b main /srv/git/" + repoPath + " /tmp/change && cd /tmp/change",
+				"git config user.name e2e && git config user.email e2e@example.com",
+				"echo bad > change.txt && git add change.txt && git commit -q -m 'bad change' && git push -q origin main",
+			}, "\n"))).To(Succeed())
+		})
+
+		AfterAll(func() {
+			By("removing the test namespace")
+			cmd := exec.Command("kubectl", "delete", "ns", testNamespace)
+			_, _ = utils.Run(cmd)
+		})
+
+		// getRevertCommitStatus returns the field of the status of the RevertCommit at jsonpath.
+		getRevertCommitStatus := func(g Gomega, field string) string {
+			cmd := exec.Command("kubectl", "get", "revertcommits", "revert-bad-change", "-n", testNamespace, "-o",
+				"jsonpath={.status."+field+"}")
+			output, err := utils.Run(cmd)
+			g.Expect(err).NotTo(HaveOccurred())
+			return string(output)
+		}
+
+		It("should revert a change and promote the revert to the first environment", func() {
+			badSha, err := utils.GetGitServerBranchSha(testNamespace, repoPath, "main")
+			Expect(err).NotTo(HaveOccurred())
+
+			By("creating a PromotionStrategy that waits for the pull requests to be merged by hand")
+			Expect(utils.Apply(testNamespace, fmt.Sprintf(`apiVersion: v1
+kind: Secret
+metadata:
+  name: fake-scm-provider
+---
+apiVersion: promoter.argoproj.io/v1alpha1
+kind: ScmProvider
+metadata:
+  name: fake-scm-provider
+spec:
+  secretRef:
+    name: fake-scm-provider
+  fake: {}
+---
+apiVersion: promoter.argoproj.io/v1alpha1
+kind: GitRepository
+metadata:
+  name: revert-commits
+spec:
+  url: %s
+  scmProviderRef:
+    kind: ScmProvider
+    name: fake-scm-provider
+---
+apiVersion: promoter.argoproj.io/v1alpha1
+kind: PromotionStrategy
+metadata:
+  name: revert-commits
+spec:
+  gitRepositoryRef:
+    name: revert-commits
+  environments:
+  - branch: environment/dev
+    autoMerge: false
+  - branch: environment/staging
+    autoMerge: false
+`, utils.GitServerURL(testNamespace, repoPath)))).To(Succeed())
+
+			By("reverting the bad change")
+			Expect(utils.Apply(testNamespace, fmt.Sprintf(`apiVersion: promoter.argoproj.io/v1alpha1
+kind: RevertCommit
+metadata:
+  name: revert-bad-change
+spec:
+  promotionStrategyRef:
+    name: revert-commits
+  dryBranch: main
+  sha: %s
+`, badSha))).To(Succeed())
+
+			By("validating that the revert is pushed to its branch and proposed in a pull request")
+			var revertSha, revertBranch string
+			Eventually(func(g Gomega) {
+				g.Expect(getRevertCommitStatus(g, "phase")).To(Equal("PullRequestOpen"))
+				g.Expect(getRevertCommitStatus(g, "pullRequestName")).NotTo(BeEmpty())
+				revertSha = getRevertCommitStatus(g, "revertSha")
+				revertBranch = getRevertCommitStatus(g, "revertBranch")
+			}, 2*time.Minute, time.Second).Should(Succeed())
+			pushedSha, err := utils.GetGitServerBranchSha(testNamespace, repoPath, revertBranch)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pushedSha).To(Equal(revertSha))
+
+			By("merging the revert pull request and hydrating the revert to the first environment")
+			Expect(utils.GitServerExec(testNamespace, fmt.Sprintf("git -C /srv/git/%s update-ref refs/heads/main %s %s",
+				repoPath, revertSha, badSha))).To(Succeed())
+			Eventually(func(g Gomega) {
+				_, err := utils.GetGitServerBranchSha(testNamespace, repoPath, "environment/dev-next")
+				g.Expect(err).NotTo(HaveOccurred())
+			}, 2*time.Minute, time.Second).Should(Succeed())
+			Expect(utils.HydrateGitServerBranch(testNamespace, repoPath, "environment/dev-next", revertSha)).To(Succeed())
+
+			By("validating that the first environment proposes the revert")
+			Eventually(func(g Gomega) {
+				cmd := exec.Command("kubectl", "get", "changetransferpolicies", "-n", testNamespace, "-o",
+					`jsonpath={.items[?(@.spec.activeBranch=="environment/dev")].status.proposed.dry.sha}`)
+				proposedDrySha, err := utils.Run(cmd)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(string(proposedDrySha)).To(Equal(revertSha))
+			}, 2*time.Minute, time.Second).Should(Succeed())
+			cmd := exec.Command("kubectl", "get", "changetransferpolicies", "-n", testNamespace, "-o",
+				`jsonpath={.items[?(@.spec.activeBranch=="environment/staging")].status.proposed.dry.sha}`)
+			proposedDrySha, err := utils.Run(cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(proposedDrySha)).NotTo(Equal(revertSha), "the revert is only hydrated to the first environment")
+
+			By("merging the revert into the first environment")
+			Expect(utils.GitServerExec(testNamespace, fmt.Sprintf("git -C /srv/git/%s update-ref refs/heads/environment/dev refs/heads/environment/dev-next",
+				repoPath))).To(Succeed())
+
+			By("validating that the history of the first environment records the revert")
+			Eventually(func(g Gomega) {
+				cmd := exec.Command("kubectl", "get", "promotionstrategies", "revert-commits", "-n", testNamespace, "-o",
+					`jsonpath={.status.environments[0].history[0].active.dry.sha} {.status.environments[0].history[0].kind} `+
+						`{.status.environments[0].history[0].revertCommitRef.name} {.status.environments[0].history[0].revertedSha}`)
+				history, err := utils.Run(cmd)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(strings.Fields(string(history))).To(Equal([]string{revertSha, "revert", "revert-bad-change", badSha}))
+			}, 2*time.Minute, time.Second).Should(Succeed())
+		})
+	})
 })
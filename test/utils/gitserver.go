@@ -0,0 +1,141 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// gitServerManifest is a Pod and Service serving the bare repositories under /srv/git over smart HTTP with
+// git-http-backend, pushes included. The repositories are at http://git-server.<namespace>:8080/cgi-bin/git/<path>.
+const gitServerManifest = `apiVersion: v1
+kind: Pod
+metadata:
+  name: git-server
+  labels:
+    app: git-server
+spec:
+  containers:
+  - name: git-server
+    image: alpine:3.20
+    command:
+    - sh
+    - -c
+    - |
+      set -e
+      apk add --no-cache git git-daemon busybox-extras
+      mkdir -p /srv/git /www/cgi-bin
+      printf '#!/bin/sh\nexport GIT_PROJECT_ROOT=/srv/git GIT_HTTP_EXPORT_ALL=1\nexec git http-backend\n' > /www/cgi-bin/git
+      chmod +x /www/cgi-bin/git
+      touch /tmp/ready
+      exec httpd -f -p 8080 -h /www
+    ports:
+    - containerPort: 8080
+    readinessProbe:
+      exec:
+        command: ["test", "-f", "/tmp/ready"]
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: git-server
+spec:
+  selector:
+    app: git-server
+  ports:
+  - port: 8080
+`
+
+// InstallGitServer runs a git server in namespace and waits for it to be ready. The GitRepositories of the e2e tests
+// point their spec.url at it with GitServerURL.
+func InstallGitServer(namespace string) error {
+	if err := Apply(namespace, gitServerManifest); err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(context.Background(), "kubectl", "wait", "pod/git-server",
+		"--for", "condition=Ready",
+		"--namespace", namespace,
+		"--timeout", "5m",
+	)
+	_, err := Run(cmd)
+	return err
+}
+
+// GitServerURL returns the URL the controller clones the repository at path of the git server in namespace with.
+func GitServerURL(namespace, path string) string {
+	return fmt.Sprintf("http://git-server.%s.svc.cluster.local:8080/cgi-bin/git/%s", namespace, path)
+}
+
+// CreateGitRepository creates a bare repository at path on the git server in namespace, with one commit on each of
+// branches. The branches don't share history, like environment branches written by a hydrator.
+func CreateGitRepository(namespace, path string, branches ...string) error {
+	script := []string{
+		"set -e",
+		fmt.Sprintf("git init --bare -b main /srv/git/%s", path),
+		fmt.Sprintf("git -C /srv/git/%s config http.receivepack true", path),
+		"rm -rf /tmp/seed && mkdir /tmp/seed && cd /tmp/seed && git init -q -b main",
+		"git config user.name e2e && git config user.email e2e@example.com",
+	}
+	for _, branch := range branches {
+		script = append(script,
+			fmt.Sprintf("git checkout -q --orphan %q && echo %q > README.md && git add README.md", branch, branch),
+			fmt.Sprintf("git commit -q -m %q && git push -q /srv/git/%s %q", "initial commit of "+branch, path, branch),
+		)
+	}
+	return GitServerExec(namespace, strings.Join(script, "\n"))
+}
+
+// HydrateGitServerBranch commits a hydrator.metadata file for drySha on branch of the repository at path on the git
+// server in namespace, like a hydrator hydrating drySha to branch.
+func HydrateGitServerBranch(namespace, path, branch, drySha string) error {
+	return GitServerExec(namespace, strings.Join([]string{
+		"set -e",
+		fmt.Sprintf("rm -rf /tmp/hydrate && git clone -q -b %q /srv/git/%s /tmp/hydrate && cd /tmp/hydrate", branch, path),
+		"git config user.name e2e && git config user.email e2e@example.com",
+		fmt.Sprintf(`printf '{"drySha": "%s"}' > hydrator.metadata && git add hydrator.metadata`, drySha),
+		fmt.Sprintf("git commit -q -m %q && git push -q origin %q", "hydrate "+drySha, branch),
+	}, "\n"))
+}
+
+// GitServerExec runs script with sh on the git server in namespace.
+func GitServerExec(namespace, script string) error {
+	_, err := gitServerOutput(namespace, script)
+	return err
+}
+
+// GetGitServerBranchSha returns the sha of branch of the repository at path on the git server in namespace.
+func GetGitServerBranchSha(namespace, path, branch string) (string, error) {
+	output, err := gitServerOutput(namespace, fmt.Sprintf("git -C /srv/git/%s rev-parse --verify -q %q", path, "refs/heads/"+branch))
+	return strings.TrimSpace(string(output)), err
+}
+
+func gitServerOutput(namespace, script string) ([]byte, error) {
+	cmd := exec.CommandContext(context.Background(), "kubectl", "exec", "git-server", "--namespace", namespace, "--",
+		"sh", "-c", script)
+	return Run(cmd)
+}
+
+// Apply applies manifest in namespace.
+func Apply(namespace, manifest string) error {
+	cmd := exec.CommandContext(context.Background(), "kubectl", "apply", "--namespace", namespace, "-f", "-")
+	cmd.Stdin = strings.NewReader(manifest)
+	_, err := Run(cmd)
+	return err
+}